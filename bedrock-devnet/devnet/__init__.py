@@ -288,7 +288,8 @@ def wait_for_rpc_server(url):
 def devnet_test(paths):
     # Check the L2 config
     run_command(
-        ['go', 'run', 'cmd/check-l2/main.go', '--l2-rpc-url', 'http://localhost:9545', '--l1-rpc-url', 'http://localhost:8545'],
+        ['go', 'run', 'cmd/check-l2/main.go', '--l2-rpc-url', 'http://localhost:9545', '--l1-rpc-url', 'http://localhost:8545',
+         '--deploy-config', paths.devnet_config_path, '--l1-deployments', paths.addresses_json_path],
         cwd=paths.ops_chain_ops,
     )
 
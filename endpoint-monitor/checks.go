@@ -0,0 +1,258 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/sources"
+)
+
+// Check is a synthetic check that runs periodically against a provider.
+type Check interface {
+	// Name is the check type, as configured per provider, and as used in metric labels.
+	Name() string
+	Interval() time.Duration
+	// Run runs the check once, and returns any errors encountered for reporting.
+	// Errors are wrapped with a short message that is used as the error label of the check status metric.
+	Run(ctx context.Context, p ProviderConfig) error
+}
+
+// staticMethods are JSON-RPC methods of which the result does not change with the chain head,
+// and can thus be compared against the result of the reference endpoint.
+var staticMethods = map[string]bool{
+	"eth_chainId": true,
+	"net_version": true,
+}
+
+// rpcCheck calls a list of parameterless JSON-RPC methods, and checks that they return a result.
+// The results of static methods are compared against the reference endpoint, if any.
+type rpcCheck struct {
+	interval     time.Duration
+	timeout      time.Duration
+	methods      []string
+	referenceUrl string
+}
+
+func (c *rpcCheck) Name() string {
+	return CheckRPC
+}
+
+func (c *rpcCheck) Interval() time.Duration {
+	return c.interval
+}
+
+func (c *rpcCheck) Run(ctx context.Context, p ProviderConfig) error {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	cl, err := rpc.DialContext(ctx, p.HttpUrl)
+	if err != nil {
+		return errors.Wrap(err, "dial")
+	}
+	defer cl.Close()
+	var ref *rpc.Client
+	if c.referenceUrl != "" {
+		ref, err = rpc.DialContext(ctx, c.referenceUrl)
+		if err != nil {
+			return errors.Wrap(err, "reference_dial")
+		}
+		defer ref.Close()
+	}
+
+	var result error
+	for _, method := range c.methods {
+		err := c.call(ctx, cl, ref, p, method)
+		if err != nil {
+			errType := getWrappingErrorMsg(err)
+			MetricRPCStatus.With(prometheus.Labels{"provider": p.Name, "method": method, "status": "error", "error": errType}).Inc()
+			if result == nil {
+				result = errors.Wrap(err, method)
+			}
+		} else {
+			MetricRPCStatus.With(prometheus.Labels{"provider": p.Name, "method": method, "status": "success", "error": ""}).Inc()
+		}
+	}
+	return result
+}
+
+func (c *rpcCheck) call(ctx context.Context, cl *rpc.Client, ref *rpc.Client, p ProviderConfig, method string) error {
+	var res json.RawMessage
+	start := time.Now()
+	err := cl.CallContext(ctx, &res, method)
+	MetricRPCLatency.With(prometheus.Labels{"provider": p.Name, "method": method}).Observe(time.Since(start).Seconds())
+	if err != nil {
+		return errors.Wrap(err, "call")
+	}
+	if len(res) == 0 || bytes.Equal(res, []byte("null")) {
+		return errors.Wrap(errors.New("result is null"), "empty_result")
+	}
+	if ref == nil || !staticMethods[method] {
+		return nil
+	}
+	var refRes json.RawMessage
+	if err := ref.CallContext(ctx, &refRes, method); err != nil {
+		return errors.Wrap(err, "reference_call")
+	}
+	if !bytes.Equal(res, refRes) {
+		return errors.Wrap(fmt.Errorf("got %s, reference returned %s", res, refRes), "mismatch")
+	}
+	return nil
+}
+
+// headLagCheck compares the head block number of the provider against the reference endpoint.
+type headLagCheck struct {
+	interval     time.Duration
+	timeout      time.Duration
+	referenceUrl string
+}
+
+func (c *headLagCheck) Name() string {
+	return CheckHeadLag
+}
+
+func (c *headLagCheck) Interval() time.Duration {
+	return c.interval
+}
+
+func (c *headLagCheck) Run(ctx context.Context, p ProviderConfig) error {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	cl, err := ethclient.DialContext(ctx, p.HttpUrl)
+	if err != nil {
+		return errors.Wrap(err, "dial")
+	}
+	defer cl.Close()
+	ref, err := ethclient.DialContext(ctx, c.referenceUrl)
+	if err != nil {
+		return errors.Wrap(err, "reference_dial")
+	}
+	defer ref.Close()
+
+	head, err := cl.BlockNumber(ctx)
+	if err != nil {
+		return errors.Wrap(err, "block_number")
+	}
+	refHead, err := ref.BlockNumber(ctx)
+	if err != nil {
+		return errors.Wrap(err, "reference_block_number")
+	}
+	MetricHeadLag.With(prometheus.Labels{"provider": p.Name}).Set(float64(refHead) - float64(head))
+	return nil
+}
+
+// logsCheck queries the logs of a range of blocks up to the head of the provider.
+// The number of logs is compared against the reference endpoint, if any.
+type logsCheck struct {
+	interval     time.Duration
+	timeout      time.Duration
+	blockRange   uint64
+	referenceUrl string
+}
+
+func (c *logsCheck) Name() string {
+	return CheckLogs
+}
+
+func (c *logsCheck) Interval() time.Duration {
+	return c.interval
+}
+
+func (c *logsCheck) Run(ctx context.Context, p ProviderConfig) error {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	cl, err := ethclient.DialContext(ctx, p.HttpUrl)
+	if err != nil {
+		return errors.Wrap(err, "dial")
+	}
+	defer cl.Close()
+
+	head, err := cl.BlockNumber(ctx)
+	if err != nil {
+		return errors.Wrap(err, "block_number")
+	}
+	from := uint64(0)
+	if head >= c.blockRange {
+		from = head - c.blockRange + 1
+	}
+	query := ethereum.FilterQuery{FromBlock: new(big.Int).SetUint64(from), ToBlock: new(big.Int).SetUint64(head)}
+	logs, err := cl.FilterLogs(ctx, query)
+	if err != nil {
+		return errors.Wrap(err, "get_logs")
+	}
+	MetricLogsCount.With(prometheus.Labels{"provider": p.Name}).Set(float64(len(logs)))
+	if c.referenceUrl == "" {
+		return nil
+	}
+
+	ref, err := ethclient.DialContext(ctx, c.referenceUrl)
+	if err != nil {
+		return errors.Wrap(err, "reference_dial")
+	}
+	defer ref.Close()
+	refHead, err := ref.BlockNumber(ctx)
+	if err != nil {
+		return errors.Wrap(err, "reference_block_number")
+	}
+	if refHead < head {
+		// the reference cannot serve the full range yet, and the range may still be reorged
+		return nil
+	}
+	refLogs, err := ref.FilterLogs(ctx, query)
+	if err != nil {
+		return errors.Wrap(err, "reference_get_logs")
+	}
+	if len(refLogs) != len(logs) {
+		return errors.Wrap(fmt.Errorf("got %d logs in blocks %d-%d, reference returned %d", len(logs), from, head, len(refLogs)), "logs_mismatch")
+	}
+	return nil
+}
+
+// syncStatusCheck reports how far the heads of a rollup node are behind, as reported by optimism_syncStatus.
+type syncStatusCheck struct {
+	interval time.Duration
+	timeout  time.Duration
+}
+
+func (c *syncStatusCheck) Name() string {
+	return CheckSyncStatus
+}
+
+func (c *syncStatusCheck) Interval() time.Duration {
+	return c.interval
+}
+
+func (c *syncStatusCheck) Run(ctx context.Context, p ProviderConfig) error {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	rpcCl, err := rpc.DialContext(ctx, p.HttpUrl)
+	if err != nil {
+		return errors.Wrap(err, "dial")
+	}
+	defer rpcCl.Close()
+	status, err := sources.NewRollupClient(client.NewBaseRPCClient(rpcCl)).SyncStatus(ctx)
+	if err != nil {
+		return errors.Wrap(err, "sync_status")
+	}
+	now := time.Now()
+	for head, ref := range map[string]uint64{
+		"unsafe":    status.UnsafeL2.Time,
+		"safe":      status.SafeL2.Time,
+		"finalized": status.FinalizedL2.Time,
+	} {
+		lag := now.Sub(time.Unix(int64(ref), 0))
+		MetricSyncStatusLag.With(prometheus.Labels{"provider": p.Name, "head": head}).Set(lag.Seconds())
+	}
+	MetricSyncStatusL1Lag.With(prometheus.Labels{"provider": p.Name}).Set(float64(status.HeadL1.Number) - float64(status.CurrentL1.Number))
+	return nil
+}
@@ -0,0 +1,214 @@
+package app
+
+import (
+	"context"
+	"math/big"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+type testEthAPI struct {
+	chainID uint64
+	head    uint64
+	logs    int
+}
+
+func (api *testEthAPI) ChainId() hexutil.Uint64 {
+	return hexutil.Uint64(api.chainID)
+}
+
+func (api *testEthAPI) BlockNumber() hexutil.Uint64 {
+	return hexutil.Uint64(api.head)
+}
+
+func (api *testEthAPI) GetLogs(args map[string]interface{}) ([]types.Log, error) {
+	logs := make([]types.Log, api.logs)
+	for i := range logs {
+		logs[i].Topics = []common.Hash{}
+	}
+	return logs, nil
+}
+
+func (api *testEthAPI) NewHeads(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, _ := rpc.NotifierFromContext(ctx)
+	sub := notifier.CreateSubscription()
+	go func() {
+		header := &types.Header{Number: new(big.Int).SetUint64(api.head), Difficulty: new(big.Int)}
+		_ = notifier.Notify(sub.ID, header)
+	}()
+	return sub, nil
+}
+
+type testNetAPI struct{}
+
+func (api *testNetAPI) Version() string {
+	return "10"
+}
+
+type testRollupAPI struct {
+	status *eth.SyncStatus
+}
+
+func (api *testRollupAPI) SyncStatus() (*eth.SyncStatus, error) {
+	return api.status, nil
+}
+
+// newTestServer serves the test APIs over http and websocket.
+func newTestServer(t *testing.T, api *testEthAPI, rollup *testRollupAPI) (httpUrl string, wsUrl string) {
+	srv := rpc.NewServer()
+	require.NoError(t, srv.RegisterName("eth", api))
+	require.NoError(t, srv.RegisterName("net", &testNetAPI{}))
+	if rollup != nil {
+		require.NoError(t, srv.RegisterName("optimism", rollup))
+	}
+	httpSrv := httptest.NewServer(srv)
+	wsSrv := httptest.NewServer(srv.WebsocketHandler([]string{"*"}))
+	t.Cleanup(func() {
+		wsSrv.Close()
+		httpSrv.Close()
+		srv.Stop()
+	})
+	return httpSrv.URL, "ws" + strings.TrimPrefix(wsSrv.URL, "http")
+}
+
+func TestGetWrappingErrorMsg(t *testing.T) {
+	err := errors.Wrap(errors.Wrap(errors.New("connection refused"), "dial"), "eth_chainId")
+	require.Equal(t, "eth_chainId: dial", getWrappingErrorMsg(err))
+	require.Equal(t, "nodata", getWrappingErrorMsg(errors.New("nodata")))
+}
+
+func TestWebsocketCheck(t *testing.T) {
+	e := NewEndpointMonitor(Config{}, testlog.Logger(t, log.LvlInfo))
+	_, wsUrl := newTestServer(t, &testEthAPI{head: 100}, nil)
+	require.NoError(t, e.runWebsocketCheck(ProviderConfig{Name: "ws-ok", Url: wsUrl}, 100*time.Millisecond))
+
+	err := e.runWebsocketCheck(ProviderConfig{Name: "ws-down", Url: "ws://127.0.0.1:1"}, 100*time.Millisecond)
+	require.Error(t, err)
+	require.Equal(t, "dial", getWrappingErrorMsg(err))
+}
+
+func TestRPCCheck(t *testing.T) {
+	ctx := context.Background()
+	url, _ := newTestServer(t, &testEthAPI{chainID: 10, head: 100}, nil)
+	refUrl, _ := newTestServer(t, &testEthAPI{chainID: 10, head: 120}, nil)
+	otherUrl, _ := newTestServer(t, &testEthAPI{chainID: 420, head: 100}, nil)
+	methods := []string{"eth_chainId", "eth_blockNumber", "net_version"}
+	MetricRPCStatus.Reset()
+
+	t.Run("Success", func(t *testing.T) {
+		check := &rpcCheck{timeout: time.Second, methods: methods, referenceUrl: refUrl}
+		p := ProviderConfig{Name: "rpc-ok", HttpUrl: url}
+		require.NoError(t, check.Run(ctx, p))
+		// eth_blockNumber is not static, and is not compared against the reference
+		for _, method := range methods {
+			require.Equal(t, 1.0, testutil.ToFloat64(MetricRPCStatus.WithLabelValues("success", p.Name, method, "")))
+		}
+	})
+
+	t.Run("NoReference", func(t *testing.T) {
+		check := &rpcCheck{timeout: time.Second, methods: methods}
+		require.NoError(t, check.Run(ctx, ProviderConfig{Name: "rpc-noref", HttpUrl: otherUrl}))
+	})
+
+	t.Run("Mismatch", func(t *testing.T) {
+		check := &rpcCheck{timeout: time.Second, methods: methods, referenceUrl: refUrl}
+		p := ProviderConfig{Name: "rpc-mismatch", HttpUrl: otherUrl}
+		err := check.Run(ctx, p)
+		require.ErrorContains(t, err, "eth_chainId: mismatch")
+		require.Equal(t, 1.0, testutil.ToFloat64(MetricRPCStatus.WithLabelValues("error", p.Name, "eth_chainId", "mismatch")))
+		require.Equal(t, 1.0, testutil.ToFloat64(MetricRPCStatus.WithLabelValues("success", p.Name, "net_version", "")))
+	})
+
+	t.Run("UnknownMethod", func(t *testing.T) {
+		check := &rpcCheck{timeout: time.Second, methods: []string{"eth_syncing"}}
+		p := ProviderConfig{Name: "rpc-unknown", HttpUrl: url}
+		require.ErrorContains(t, check.Run(ctx, p), "eth_syncing: call")
+		require.Equal(t, 1.0, testutil.ToFloat64(MetricRPCStatus.WithLabelValues("error", p.Name, "eth_syncing", "call")))
+	})
+}
+
+func TestHeadLagCheck(t *testing.T) {
+	ctx := context.Background()
+	url, _ := newTestServer(t, &testEthAPI{head: 100}, nil)
+	refUrl, _ := newTestServer(t, &testEthAPI{head: 105}, nil)
+
+	check := &headLagCheck{timeout: time.Second, referenceUrl: refUrl}
+	require.NoError(t, check.Run(ctx, ProviderConfig{Name: "lag", HttpUrl: url}))
+	require.Equal(t, 5.0, testutil.ToFloat64(MetricHeadLag.WithLabelValues("lag")))
+
+	// a provider ahead of the reference has a negative lag
+	check = &headLagCheck{timeout: time.Second, referenceUrl: url}
+	require.NoError(t, check.Run(ctx, ProviderConfig{Name: "ahead", HttpUrl: refUrl}))
+	require.Equal(t, -5.0, testutil.ToFloat64(MetricHeadLag.WithLabelValues("ahead")))
+}
+
+func TestLogsCheck(t *testing.T) {
+	ctx := context.Background()
+	url, _ := newTestServer(t, &testEthAPI{head: 100, logs: 3}, nil)
+
+	t.Run("NoReference", func(t *testing.T) {
+		check := &logsCheck{timeout: time.Second, blockRange: 10}
+		require.NoError(t, check.Run(ctx, ProviderConfig{Name: "logs-noref", HttpUrl: url}))
+		require.Equal(t, 3.0, testutil.ToFloat64(MetricLogsCount.WithLabelValues("logs-noref")))
+	})
+
+	t.Run("Match", func(t *testing.T) {
+		refUrl, _ := newTestServer(t, &testEthAPI{head: 101, logs: 3}, nil)
+		check := &logsCheck{timeout: time.Second, blockRange: 10, referenceUrl: refUrl}
+		require.NoError(t, check.Run(ctx, ProviderConfig{Name: "logs-match", HttpUrl: url}))
+	})
+
+	t.Run("Mismatch", func(t *testing.T) {
+		refUrl, _ := newTestServer(t, &testEthAPI{head: 100, logs: 4}, nil)
+		check := &logsCheck{timeout: time.Second, blockRange: 10, referenceUrl: refUrl}
+		err := check.Run(ctx, ProviderConfig{Name: "logs-mismatch", HttpUrl: url})
+		require.ErrorContains(t, err, "got 3 logs in blocks 91-100, reference returned 4")
+		require.Equal(t, "logs_mismatch", getWrappingErrorMsg(err))
+	})
+
+	t.Run("ReferenceBehind", func(t *testing.T) {
+		refUrl, _ := newTestServer(t, &testEthAPI{head: 99, logs: 4}, nil)
+		check := &logsCheck{timeout: time.Second, blockRange: 10, referenceUrl: refUrl}
+		require.NoError(t, check.Run(ctx, ProviderConfig{Name: "logs-behind", HttpUrl: url}))
+	})
+}
+
+func TestSyncStatusCheck(t *testing.T) {
+	now := uint64(time.Now().Unix())
+	status := &eth.SyncStatus{
+		CurrentL1:   eth.L1BlockRef{Number: 90},
+		HeadL1:      eth.L1BlockRef{Number: 100},
+		UnsafeL2:    eth.L2BlockRef{Time: now - 10},
+		SafeL2:      eth.L2BlockRef{Time: now - 100},
+		FinalizedL2: eth.L2BlockRef{Time: now - 1000},
+	}
+	url, _ := newTestServer(t, &testEthAPI{}, &testRollupAPI{status: status})
+
+	check := &syncStatusCheck{timeout: time.Second}
+	require.NoError(t, check.Run(context.Background(), ProviderConfig{Name: "node", HttpUrl: url}))
+	require.InDelta(t, 10, testutil.ToFloat64(MetricSyncStatusLag.WithLabelValues("node", "unsafe")), 2)
+	require.InDelta(t, 100, testutil.ToFloat64(MetricSyncStatusLag.WithLabelValues("node", "safe")), 2)
+	require.InDelta(t, 1000, testutil.ToFloat64(MetricSyncStatusLag.WithLabelValues("node", "finalized")), 2)
+	require.Equal(t, 10.0, testutil.ToFloat64(MetricSyncStatusL1Lag.WithLabelValues("node")))
+
+	// a plain execution engine does not serve the sync status
+	ethUrl, _ := newTestServer(t, &testEthAPI{}, nil)
+	err := check.Run(context.Background(), ProviderConfig{Name: "engine", HttpUrl: ethUrl})
+	require.Error(t, err)
+	require.Equal(t, "sync_status", getWrappingErrorMsg(err))
+}
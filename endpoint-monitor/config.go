@@ -12,15 +12,38 @@ import (
 	"github.com/urfave/cli/v2"
 )
 
+// Check types that can be enabled per provider.
+const (
+	CheckWebsocket  = "ws"
+	CheckRPC        = "rpc"
+	CheckHeadLag    = "head_lag"
+	CheckLogs       = "logs"
+	CheckSyncStatus = "sync_status"
+)
+
+var allChecks = []string{CheckWebsocket, CheckRPC, CheckHeadLag, CheckLogs, CheckSyncStatus}
+
 type ProviderConfig struct {
 	Name string
 	Url  string
+	// HttpUrl is the JSON-RPC endpoint used by the request-response checks. Defaults to Url.
+	HttpUrl string
+	// Checks are the check types to run against the provider.
+	Checks []string
 }
 
 const (
-	ProvidersFlagName     = "providers"
-	CheckIntervalFlagName = "check-interval"
-	CheckDurationFlagName = "check-duration"
+	ProvidersFlagName               = "providers"
+	CheckIntervalFlagName           = "check-interval"
+	CheckDurationFlagName           = "check-duration"
+	CheckTimeoutFlagName            = "check-timeout"
+	ReferenceUrlFlagName            = "reference-url"
+	RPCCheckIntervalFlagName        = "rpc-check-interval"
+	RPCMethodsFlagName              = "rpc-methods"
+	HeadLagCheckIntervalFlagName    = "head-lag-check-interval"
+	LogsCheckIntervalFlagName       = "logs-check-interval"
+	LogsCheckRangeFlagName          = "logs-check-range"
+	SyncStatusCheckIntervalFlagName = "sync-status-check-interval"
 )
 
 func CLIFlags(envPrefix string) []cli.Flag {
@@ -36,16 +59,63 @@ func CLIFlags(envPrefix string) []cli.Flag {
 		},
 		&cli.DurationFlag{
 			Name:    CheckIntervalFlagName,
-			Usage:   "Check interval duration",
+			Usage:   "Check interval duration of the websocket check",
 			Value:   5 * time.Minute,
 			EnvVars: prefixEnvVars("CHECK_INTERVAL"),
 		},
 		&cli.DurationFlag{
 			Name:    CheckDurationFlagName,
-			Usage:   "Check duration",
+			Usage:   "Check duration of the websocket check",
 			Value:   4 * time.Minute,
 			EnvVars: prefixEnvVars("CHECK_DURATION"),
 		},
+		&cli.DurationFlag{
+			Name:    CheckTimeoutFlagName,
+			Usage:   "Timeout of a single run of the rpc, head_lag, logs and sync_status checks",
+			Value:   30 * time.Second,
+			EnvVars: prefixEnvVars("CHECK_TIMEOUT"),
+		},
+		&cli.StringFlag{
+			Name:    ReferenceUrlFlagName,
+			Usage:   "JSON-RPC endpoint to compare providers against. Required by the head_lag check, optional for the rpc and logs checks",
+			EnvVars: prefixEnvVars("REFERENCE_URL"),
+		},
+		&cli.DurationFlag{
+			Name:    RPCCheckIntervalFlagName,
+			Usage:   "Check interval duration of the rpc check",
+			Value:   1 * time.Minute,
+			EnvVars: prefixEnvVars("RPC_CHECK_INTERVAL"),
+		},
+		&cli.StringSliceFlag{
+			Name:    RPCMethodsFlagName,
+			Usage:   "List of parameterless JSON-RPC methods to call in the rpc check",
+			Value:   cli.NewStringSlice("eth_chainId", "eth_blockNumber", "eth_gasPrice"),
+			EnvVars: prefixEnvVars("RPC_METHODS"),
+		},
+		&cli.DurationFlag{
+			Name:    HeadLagCheckIntervalFlagName,
+			Usage:   "Check interval duration of the head_lag check",
+			Value:   1 * time.Minute,
+			EnvVars: prefixEnvVars("HEAD_LAG_CHECK_INTERVAL"),
+		},
+		&cli.DurationFlag{
+			Name:    LogsCheckIntervalFlagName,
+			Usage:   "Check interval duration of the logs check",
+			Value:   5 * time.Minute,
+			EnvVars: prefixEnvVars("LOGS_CHECK_INTERVAL"),
+		},
+		&cli.Uint64Flag{
+			Name:    LogsCheckRangeFlagName,
+			Usage:   "Number of blocks, up to the head of the provider, to query with eth_getLogs in the logs check",
+			Value:   100,
+			EnvVars: prefixEnvVars("LOGS_CHECK_RANGE"),
+		},
+		&cli.DurationFlag{
+			Name:    SyncStatusCheckIntervalFlagName,
+			Usage:   "Check interval duration of the sync_status check",
+			Value:   1 * time.Minute,
+			EnvVars: prefixEnvVars("SYNC_STATUS_CHECK_INTERVAL"),
+		},
 	}
 	flags = append(flags, opmetrics.CLIFlags(envPrefix)...)
 	flags = append(flags, oplog.CLIFlags(envPrefix)...)
@@ -56,6 +126,15 @@ type Config struct {
 	Providers     []string
 	CheckInterval time.Duration
 	CheckDuration time.Duration
+	CheckTimeout  time.Duration
+	ReferenceUrl  string
+
+	RPCCheckInterval        time.Duration
+	RPCMethods              []string
+	HeadLagCheckInterval    time.Duration
+	LogsCheckInterval       time.Duration
+	LogsCheckRange          uint64
+	SyncStatusCheckInterval time.Duration
 
 	LogConfig     oplog.CLIConfig
 	MetricsConfig opmetrics.CLIConfig
@@ -65,6 +144,22 @@ func (c Config) Check() error {
 	if c.CheckDuration >= c.CheckInterval {
 		return fmt.Errorf("%s must be less than %s", CheckDurationFlagName, CheckIntervalFlagName)
 	}
+	for name, interval := range map[string]time.Duration{
+		RPCCheckIntervalFlagName:        c.RPCCheckInterval,
+		HeadLagCheckIntervalFlagName:    c.HeadLagCheckInterval,
+		LogsCheckIntervalFlagName:       c.LogsCheckInterval,
+		SyncStatusCheckIntervalFlagName: c.SyncStatusCheckInterval,
+	} {
+		if c.CheckTimeout >= interval {
+			return fmt.Errorf("%s must be less than %s", CheckTimeoutFlagName, name)
+		}
+	}
+	if c.LogsCheckRange == 0 {
+		return fmt.Errorf("%s must be positive", LogsCheckRangeFlagName)
+	}
+	if len(c.RPCMethods) == 0 {
+		return fmt.Errorf("%s must not be empty", RPCMethodsFlagName)
+	}
 	if err := c.MetricsConfig.Check(); err != nil {
 		return err
 	}
@@ -73,16 +168,26 @@ func (c Config) Check() error {
 
 func NewConfig(ctx *cli.Context) Config {
 	return Config{
-		Providers:     ctx.StringSlice(ProvidersFlagName),
-		CheckInterval: ctx.Duration(CheckIntervalFlagName),
-		CheckDuration: ctx.Duration(CheckDurationFlagName),
-		LogConfig:     oplog.ReadCLIConfig(ctx),
-		MetricsConfig: opmetrics.ReadCLIConfig(ctx),
+		Providers:               ctx.StringSlice(ProvidersFlagName),
+		CheckInterval:           ctx.Duration(CheckIntervalFlagName),
+		CheckDuration:           ctx.Duration(CheckDurationFlagName),
+		CheckTimeout:            ctx.Duration(CheckTimeoutFlagName),
+		ReferenceUrl:            ctx.String(ReferenceUrlFlagName),
+		RPCCheckInterval:        ctx.Duration(RPCCheckIntervalFlagName),
+		RPCMethods:              ctx.StringSlice(RPCMethodsFlagName),
+		HeadLagCheckInterval:    ctx.Duration(HeadLagCheckIntervalFlagName),
+		LogsCheckInterval:       ctx.Duration(LogsCheckIntervalFlagName),
+		LogsCheckRange:          ctx.Uint64(LogsCheckRangeFlagName),
+		SyncStatusCheckInterval: ctx.Duration(SyncStatusCheckIntervalFlagName),
+		LogConfig:               oplog.ReadCLIConfig(ctx),
+		MetricsConfig:           opmetrics.ReadCLIConfig(ctx),
 	}
 }
 
 // GetProviderConfigs fetches endpoint provider configurations from the environment
 // Each provider should have a corresponding env var with the url, ex: PROVIDER1_URL=<provider-url>
+// Optionally, a provider can have a JSON-RPC url for the request-response checks, ex: PROVIDER1_HTTP_URL=<provider-http-url>,
+// and a comma-separated list of checks to run, ex: PROVIDER1_CHECKS=ws,rpc,head_lag. Only the ws check runs by default.
 func (c Config) GetProviderConfigs() []ProviderConfig {
 	result := make([]ProviderConfig, 0)
 	for _, provider := range c.Providers {
@@ -91,7 +196,38 @@ func (c Config) GetProviderConfigs() []ProviderConfig {
 		if url == "" {
 			panic(fmt.Sprintf("%s is not set", envKey))
 		}
-		result = append(result, ProviderConfig{Name: provider, Url: url})
+		httpUrl := os.Getenv(fmt.Sprintf("ENDPOINT_MONITOR_%s_HTTP_URL", strings.ToUpper(provider)))
+		if httpUrl == "" {
+			httpUrl = url
+		}
+		checks := []string{CheckWebsocket}
+		if v := os.Getenv(fmt.Sprintf("ENDPOINT_MONITOR_%s_CHECKS", strings.ToUpper(provider))); v != "" {
+			checks = strings.Split(v, ",")
+		}
+		for i, check := range checks {
+			checks[i] = strings.TrimSpace(check)
+		}
+		result = append(result, ProviderConfig{Name: provider, Url: url, HttpUrl: httpUrl, Checks: checks})
 	}
 	return result
 }
+
+// CheckProviderConfigs checks that the providers only enable known checks,
+// and that the checks that compare against a reference endpoint have one.
+func (c Config) CheckProviderConfigs(providers []ProviderConfig) error {
+	for _, p := range providers {
+		for _, check := range p.Checks {
+			known := false
+			for _, name := range allChecks {
+				known = known || check == name
+			}
+			if !known {
+				return fmt.Errorf("provider %s enables unknown check %q, expected one of %v", p.Name, check, allChecks)
+			}
+			if check == CheckHeadLag && c.ReferenceUrl == "" {
+				return fmt.Errorf("provider %s enables the %s check, which requires a reference url", p.Name, CheckHeadLag)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetProviderConfigs(t *testing.T) {
+	t.Setenv("ENDPOINT_MONITOR_ALICE_URL", "ws://alice")
+	t.Setenv("ENDPOINT_MONITOR_BOB_URL", "ws://bob")
+	t.Setenv("ENDPOINT_MONITOR_BOB_HTTP_URL", "http://bob")
+	t.Setenv("ENDPOINT_MONITOR_BOB_CHECKS", "ws, rpc,head_lag")
+	cfg := Config{Providers: []string{"alice", "bob"}}
+
+	providers := cfg.GetProviderConfigs()
+	require.Equal(t, []ProviderConfig{
+		{Name: "alice", Url: "ws://alice", HttpUrl: "ws://alice", Checks: []string{CheckWebsocket}},
+		{Name: "bob", Url: "ws://bob", HttpUrl: "http://bob", Checks: []string{CheckWebsocket, CheckRPC, CheckHeadLag}},
+	}, providers)
+}
+
+func TestCheckProviderConfigs(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		cfg := Config{ReferenceUrl: "http://reference"}
+		require.NoError(t, cfg.CheckProviderConfigs([]ProviderConfig{
+			{Name: "alice", Checks: allChecks},
+			{Name: "bob", Checks: []string{CheckWebsocket}},
+		}))
+	})
+
+	t.Run("NoChecks", func(t *testing.T) {
+		require.NoError(t, Config{}.CheckProviderConfigs([]ProviderConfig{{Name: "alice"}}))
+	})
+
+	t.Run("UnknownCheck", func(t *testing.T) {
+		err := Config{}.CheckProviderConfigs([]ProviderConfig{
+			{Name: "alice", Checks: []string{CheckWebsocket}},
+			{Name: "bob", Checks: []string{CheckRPC, "ping"}},
+		})
+		require.ErrorContains(t, err, "provider bob enables unknown check \"ping\"")
+	})
+
+	t.Run("HeadLagWithoutReference", func(t *testing.T) {
+		err := Config{}.CheckProviderConfigs([]ProviderConfig{
+			{Name: "alice", Checks: []string{CheckRPC, CheckLogs}},
+			{Name: "bob", Checks: []string{CheckHeadLag}},
+		})
+		require.ErrorContains(t, err, "provider bob enables the head_lag check, which requires a reference url")
+	})
+
+	t.Run("OptionalReference", func(t *testing.T) {
+		// the rpc and logs checks only compare against the reference endpoint if there is one
+		require.NoError(t, Config{}.CheckProviderConfigs([]ProviderConfig{
+			{Name: "alice", Checks: []string{CheckRPC, CheckLogs, CheckSyncStatus}},
+		}))
+	})
+}
@@ -19,15 +19,6 @@ import (
 	"github.com/ethereum-optimism/optimism/op-service/opio"
 )
 
-var (
-	MetricWsSubscribeStatus = prometheus.NewCounterVec(
-		prometheus.CounterOpts{
-			Name: "ws_subscribe_status",
-			Help: "eth_subscribe over websocket check status"},
-		[]string{"status", "provider", "error"},
-	)
-)
-
 func Main(version string) func(cliCtx *cli.Context) error {
 	return func(cliCtx *cli.Context) error {
 		cfg := NewConfig(cliCtx)
@@ -38,12 +29,16 @@ func Main(version string) func(cliCtx *cli.Context) error {
 		l := oplog.NewLogger(oplog.AppOut(cliCtx), cfg.LogConfig)
 		oplog.SetGlobalLogHandler(l.GetHandler())
 
+		if err := cfg.CheckProviderConfigs(cfg.GetProviderConfigs()); err != nil {
+			return fmt.Errorf("invalid provider config: %w", err)
+		}
+
 		endpointMonitor := NewEndpointMonitor(cfg, l)
 		l.Info(fmt.Sprintf("starting endpoint monitor with checkInterval=%s checkDuration=%s", cfg.CheckInterval, cfg.CheckDuration))
 		endpointMonitor.Start()
 
 		registry := opmetrics.NewRegistry()
-		registry.MustRegister(MetricWsSubscribeStatus)
+		registerMetrics(registry)
 		metricsCfg := cfg.MetricsConfig
 
 		l.Info("starting metrics server", "addr", metricsCfg.ListenAddr, "port", metricsCfg.ListenPort)
@@ -66,15 +61,28 @@ func Main(version string) func(cliCtx *cli.Context) error {
 type EndpointMonitor struct {
 	cfg    Config
 	logger log.Logger
+	checks map[string]Check
 }
 
 func NewEndpointMonitor(cfg Config, l log.Logger) EndpointMonitor {
-	return EndpointMonitor{cfg: cfg, logger: l}
+	checks := map[string]Check{
+		CheckRPC:        &rpcCheck{interval: cfg.RPCCheckInterval, timeout: cfg.CheckTimeout, methods: cfg.RPCMethods, referenceUrl: cfg.ReferenceUrl},
+		CheckHeadLag:    &headLagCheck{interval: cfg.HeadLagCheckInterval, timeout: cfg.CheckTimeout, referenceUrl: cfg.ReferenceUrl},
+		CheckLogs:       &logsCheck{interval: cfg.LogsCheckInterval, timeout: cfg.CheckTimeout, blockRange: cfg.LogsCheckRange, referenceUrl: cfg.ReferenceUrl},
+		CheckSyncStatus: &syncStatusCheck{interval: cfg.SyncStatusCheckInterval, timeout: cfg.CheckTimeout},
+	}
+	return EndpointMonitor{cfg: cfg, logger: l, checks: checks}
 }
 
 func (e EndpointMonitor) Start() {
 	for _, providerConfig := range e.cfg.GetProviderConfigs() {
-		go e.runWebsocketCheckLoop(providerConfig, e.cfg.CheckInterval, e.cfg.CheckDuration)
+		for _, name := range providerConfig.Checks {
+			if name == CheckWebsocket {
+				go e.runWebsocketCheckLoop(providerConfig, e.cfg.CheckInterval, e.cfg.CheckDuration)
+			} else if check, ok := e.checks[name]; ok {
+				go e.runCheckLoop(check, providerConfig)
+			}
+		}
 	}
 }
 
@@ -105,6 +113,28 @@ func (e EndpointMonitor) runWebsocketCheckLoop(p ProviderConfig, checkInterval,
 	}
 }
 
+// runCheckLoop runs the check every check interval and reports status metrics to prometheus
+func (e EndpointMonitor) runCheckLoop(check Check, p ProviderConfig) {
+	ticker := time.NewTicker(check.Interval())
+	defer ticker.Stop()
+
+	for {
+		e.logger.Debug("running check", "check", check.Name(), "provider", p.Name)
+		start := time.Now()
+		err := check.Run(context.Background(), p)
+		MetricCheckDuration.With(prometheus.Labels{"check": check.Name(), "provider": p.Name}).Observe(time.Since(start).Seconds())
+		if err != nil {
+			errType := getWrappingErrorMsg(err)
+			MetricCheckStatus.With(prometheus.Labels{"check": check.Name(), "provider": p.Name, "status": "error", "error": errType}).Inc()
+			e.logger.Error("finished check", "check", check.Name(), "provider", p.Name, "error", errType, "err", err)
+		} else {
+			MetricCheckStatus.With(prometheus.Labels{"check": check.Name(), "provider": p.Name, "status": "success", "error": ""}).Inc()
+			e.logger.Debug("finished check", "check", check.Name(), "provider", p.Name)
+		}
+		<-ticker.C
+	}
+}
+
 // runWebsocketCheck creates a client and subscribes to blockchain head notifications and returns any errors encountered for reporting
 func (e EndpointMonitor) runWebsocketCheck(p ProviderConfig, duration time.Duration) error {
 	client, err := ethclient.Dial(p.Url)
@@ -0,0 +1,80 @@
+package app
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	MetricWsSubscribeStatus = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "ws_subscribe_status",
+			Help: "eth_subscribe over websocket check status"},
+		[]string{"status", "provider", "error"},
+	)
+	MetricCheckStatus = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "check_status",
+			Help: "Synthetic check status, by check type"},
+		[]string{"check", "status", "provider", "error"},
+	)
+	MetricCheckDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "check_duration_seconds",
+			Help:    "Synthetic check duration, by check type",
+			Buckets: []float64{.05, .1, .25, .5, 1, 2.5, 5, 10, 30},
+		},
+		[]string{"check", "provider"},
+	)
+	MetricRPCStatus = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "rpc_status",
+			Help: "JSON-RPC call status of the rpc check, by method"},
+		[]string{"status", "provider", "method", "error"},
+	)
+	MetricRPCLatency = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "rpc_latency_seconds",
+			Help:    "JSON-RPC call latency of the rpc check, by method",
+			Buckets: []float64{.01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
+		},
+		[]string{"provider", "method"},
+	)
+	MetricHeadLag = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "head_lag_blocks",
+			Help: "Number of blocks the head of the provider is behind the head of the reference endpoint"},
+		[]string{"provider"},
+	)
+	MetricLogsCount = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "logs_count",
+			Help: "Number of logs returned by eth_getLogs over the checked block range"},
+		[]string{"provider"},
+	)
+	MetricSyncStatusLag = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "sync_status_lag_seconds",
+			Help: "Seconds between now and the timestamp of the L2 heads of a rollup node, by head type"},
+		[]string{"provider", "head"},
+	)
+	MetricSyncStatusL1Lag = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "sync_status_l1_lag_blocks",
+			Help: "Number of L1 blocks the derivation of a rollup node is behind its L1 head"},
+		[]string{"provider"},
+	)
+)
+
+func registerMetrics(registry *prometheus.Registry) {
+	registry.MustRegister(
+		MetricWsSubscribeStatus,
+		MetricCheckStatus,
+		MetricCheckDuration,
+		MetricRPCStatus,
+		MetricRPCLatency,
+		MetricHeadLag,
+		MetricLogsCount,
+		MetricSyncStatusLag,
+		MetricSyncStatusL1Lag,
+	)
+}
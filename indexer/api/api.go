@@ -21,7 +21,10 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 )
 
-const ethereumAddressRegex = `^0x[a-fA-F0-9]{40}$`
+const (
+	ethereumAddressRegex = `^0x[a-fA-F0-9]{40}$`
+	ethereumHashRegex    = `^0x[a-fA-F0-9]{64}$`
+)
 
 // Api ... Indexer API struct
 // TODO : Structured error responses
@@ -31,11 +34,22 @@ type API struct {
 	serverConfig    config.ServerConfig
 	metricsConfig   config.ServerConfig
 	metricsRegistry *prometheus.Registry
+	eventsHubs      []*routes.EventsHub
+}
+
+// L2ChainViews ... Database views of an additional L2 chain served by the API
+type L2ChainViews struct {
+	ChainConfig           config.ChainConfig
+	BridgeTransfers       database.BridgeTransfersView
+	ERC721BridgeTransfers database.ERC721BridgeTransfersView
+	BridgeEvents          database.BridgeEventsView
+	Blocks                database.BlocksView
 }
 
 const (
 	MetricsNamespace = "op_indexer_api"
 	addressParam     = "{address:%s}"
+	hashParam        = "{hash:%s}"
 
 	// Endpoint paths
 	// NOTE - This can be further broken out over time as new version iterations
@@ -43,6 +57,19 @@ const (
 	HealthPath      = "/healthz"
 	DepositsPath    = "/api/v0/deposits/"
 	WithdrawalsPath = "/api/v0/withdrawals/"
+
+	ERC721DepositsPath    = "/api/v0/erc721/deposits/"
+	ERC721WithdrawalsPath = "/api/v0/erc721/withdrawals/"
+
+	DepositsByTxHashPath        = "/api/v0/deposits/tx/"
+	DepositByMessageHashPath    = "/api/v0/deposits/message/"
+	WithdrawalsByTxHashPath     = "/api/v0/withdrawals/tx/"
+	WithdrawalByMessageHashPath = "/api/v0/withdrawals/message/"
+	VolumeStatsPath             = "/api/v0/stats/volume"
+	ValueLockedStatsPath        = "/api/v0/stats/tvl"
+	PendingWithdrawalsStatsPath = "/api/v0/stats/pending-withdrawals"
+
+	BridgeEventsPath = "/api/v0/events"
 )
 
 // chiMetricsMiddleware ... Injects a metrics recorder into request processing middleware
@@ -52,11 +79,22 @@ func chiMetricsMiddleware(rec metrics.HTTPRecorder) func(http.Handler) http.Hand
 	}
 }
 
-// NewApi ... Construct a new api instance
-func NewApi(logger log.Logger, bv database.BridgeTransfersView, serverConfig config.ServerConfig, metricsConfig config.ServerConfig) *API {
+// NewApi ... Construct a new api instance. Requests are served by the primary chain, or any of the
+// additional L2 chains selected with the `chainId` query param
+func NewApi(logger log.Logger, bv database.BridgeTransfersView, erc721 database.ERC721BridgeTransfersView, events database.BridgeEventsView, blocks database.BlocksView, chainConfig config.ChainConfig, serverConfig config.ServerConfig, metricsConfig config.ServerConfig, l2Chains []L2ChainViews) *API {
 	// (1) Initialize dependencies
 	apiRouter := chi.NewRouter()
-	h := routes.NewRoutes(logger, bv, apiRouter)
+	eventsHub := routes.NewEventsHub(logger, events)
+	h := routes.NewRoutes(logger, bv, erc721, eventsHub, blocks, chainConfig, apiRouter)
+
+	eventsHubs := []*routes.EventsHub{eventsHub}
+	chains := routes.NewChainRoutes(h, chainConfig.L2ChainID)
+	for _, l2Chain := range l2Chains {
+		l2ChainLog := logger.New("l2_chain_id", l2Chain.ChainConfig.L2ChainID)
+		l2ChainEventsHub := routes.NewEventsHub(l2ChainLog, l2Chain.BridgeEvents)
+		eventsHubs = append(eventsHubs, l2ChainEventsHub)
+		chains.AddChain(l2Chain.ChainConfig.L2ChainID, routes.NewRoutes(l2ChainLog, l2Chain.BridgeTransfers, l2Chain.ERC721BridgeTransfers, l2ChainEventsHub, l2Chain.Blocks, l2Chain.ChainConfig, apiRouter))
+	}
 
 	mr := metrics.NewRegistry()
 	promRecorder := metrics.NewPromHTTPRecorder(mr, MetricsNamespace)
@@ -67,16 +105,29 @@ func NewApi(logger log.Logger, bv database.BridgeTransfersView, serverConfig con
 	apiRouter.Use(middleware.Heartbeat(HealthPath))
 
 	// (3) Set GET routes
-	apiRouter.Get(fmt.Sprintf(DepositsPath+addressParam, ethereumAddressRegex), h.L1DepositsHandler)
-	apiRouter.Get(fmt.Sprintf(WithdrawalsPath+addressParam, ethereumAddressRegex), h.L2WithdrawalsHandler)
+	apiRouter.Get(fmt.Sprintf(DepositsPath+addressParam, ethereumAddressRegex), chains.Handler(routes.Routes.L1DepositsHandler))
+	apiRouter.Get(fmt.Sprintf(WithdrawalsPath+addressParam, ethereumAddressRegex), chains.Handler(routes.Routes.L2WithdrawalsHandler))
+	apiRouter.Get(fmt.Sprintf(ERC721DepositsPath+addressParam, ethereumAddressRegex), chains.Handler(routes.Routes.L1ERC721DepositsHandler))
+	apiRouter.Get(fmt.Sprintf(ERC721WithdrawalsPath+addressParam, ethereumAddressRegex), chains.Handler(routes.Routes.L2ERC721WithdrawalsHandler))
+
+	apiRouter.Get(fmt.Sprintf(DepositsByTxHashPath+hashParam, ethereumHashRegex), chains.Handler(routes.Routes.L1DepositsByTransactionHashHandler))
+	apiRouter.Get(fmt.Sprintf(DepositByMessageHashPath+hashParam, ethereumHashRegex), chains.Handler(routes.Routes.L1DepositByMessageHashHandler))
+	apiRouter.Get(fmt.Sprintf(WithdrawalsByTxHashPath+hashParam, ethereumHashRegex), chains.Handler(routes.Routes.L2WithdrawalsByTransactionHashHandler))
+	apiRouter.Get(fmt.Sprintf(WithdrawalByMessageHashPath+hashParam, ethereumHashRegex), chains.Handler(routes.Routes.L2WithdrawalByMessageHashHandler))
 
-	return &API{log: logger, router: apiRouter, metricsRegistry: mr, serverConfig: serverConfig, metricsConfig: metricsConfig}
+	apiRouter.Get(VolumeStatsPath, chains.Handler(routes.Routes.VolumeHandler))
+	apiRouter.Get(ValueLockedStatsPath, chains.Handler(routes.Routes.ValueLockedHandler))
+	apiRouter.Get(PendingWithdrawalsStatsPath, chains.Handler(routes.Routes.PendingWithdrawalsHandler))
+
+	apiRouter.Get(BridgeEventsPath, chains.Handler(routes.Routes.BridgeEventsHandler))
+
+	return &API{log: logger, router: apiRouter, metricsRegistry: mr, serverConfig: serverConfig, metricsConfig: metricsConfig, eventsHubs: eventsHubs}
 }
 
 // Run ... Runs the API server routines
 func (a *API) Run(ctx context.Context) error {
 	var wg sync.WaitGroup
-	errCh := make(chan error, 2)
+	errCh := make(chan error, 2+len(a.eventsHubs))
 
 	// (1) Construct an inner function that will start a goroutine
 	//    and handle any panics that occur on a shared error channel
@@ -99,9 +150,12 @@ func (a *API) Run(ctx context.Context) error {
 		}()
 	}
 
-	// (2) Start the API and metrics servers
+	// (2) Start the API and metrics servers, and the feeds of streamed bridge events of each chain
 	runProcess(a.startServer)
 	runProcess(a.startMetricsServer)
+	for _, eventsHub := range a.eventsHubs {
+		runProcess(eventsHub.Start)
+	}
 
 	// (3) Wait for all processes to complete
 	wg.Wait()
@@ -1,10 +1,13 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"math/big"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/ethereum-optimism/optimism/indexer/api/models"
@@ -20,6 +23,47 @@ import (
 // MockBridgeTransfersView mocks the BridgeTransfersView interface
 type MockBridgeTransfersView struct{}
 
+// MockERC721BridgeTransfersView mocks the ERC721BridgeTransfersView interface
+type MockERC721BridgeTransfersView struct{}
+
+// MockBridgeEventsView mocks the BridgeEventsView interface
+type MockBridgeEventsView struct{}
+
+// MockL2ChainBridgeTransfersView mocks the BridgeTransfersView interface of an additional L2 chain without deposits
+type MockL2ChainBridgeTransfersView struct {
+	MockBridgeTransfersView
+}
+
+func (mbv *MockL2ChainBridgeTransfersView) L1BridgeDeposits(filter database.BridgeTransfersFilter, cursor string, limit int) (*database.L1BridgeDepositsResponse, error) {
+	return &database.L1BridgeDepositsResponse{}, nil
+}
+
+// MockBlocksView mocks the BlocksView interface with the latest indexed L1 & L2 headers
+type MockBlocksView struct {
+	database.MockBlocksView
+
+	l1Latest *database.L1BlockHeader
+	l2Latest *database.L2BlockHeader
+}
+
+func (mbv *MockBlocksView) L1LatestBlockHeader() (*database.L1BlockHeader, error) {
+	return mbv.l1Latest, nil
+}
+
+func (mbv *MockBlocksView) L2LatestBlockHeader() (*database.L2BlockHeader, error) {
+	return mbv.l2Latest, nil
+}
+
+var mockBlocks = &MockBlocksView{
+	l1Latest: &database.L1BlockHeader{BlockHeader: database.BlockHeader{Number: big.NewInt(100)}},
+	l2Latest: &database.L2BlockHeader{BlockHeader: database.BlockHeader{Number: big.NewInt(100)}},
+}
+
+var chainConfig = config.ChainConfig{
+	L1ConfirmationDepth: 10,
+	L2ConfirmationDepth: 10,
+}
+
 var mockAddress = "0x4204204204204204204204204204204204204204"
 
 var apiConfig = config.ServerConfig{
@@ -49,6 +93,24 @@ var (
 			TokenPair:              database.TokenPair{},
 		},
 	}
+
+	erc721Deposit = database.L1ERC721BridgeDeposit{
+		TransactionSourceHash: common.HexToHash("def"),
+		ERC721BridgeTransfer: database.ERC721BridgeTransfer{
+			CrossDomainMessageHash: &common.Hash{},
+			TokenPair:              database.TokenPair{LocalTokenAddress: common.HexToAddress("0x721"), RemoteTokenAddress: common.HexToAddress("0x1721")},
+			TokenID:                big.NewInt(42),
+		},
+	}
+
+	erc721Withdrawal = database.L2ERC721BridgeWithdrawal{
+		TransactionWithdrawalHash: common.HexToHash("0x721"),
+		ERC721BridgeTransfer: database.ERC721BridgeTransfer{
+			CrossDomainMessageHash: &common.Hash{},
+			TokenPair:              database.TokenPair{LocalTokenAddress: common.HexToAddress("0x1721"), RemoteTokenAddress: common.HexToAddress("0x721")},
+			TokenID:                big.NewInt(42),
+		},
+	}
 )
 
 func (mbv *MockBridgeTransfersView) L1BridgeDeposit(hash common.Hash) (*database.L1BridgeDeposit, error) {
@@ -75,6 +137,7 @@ func (mbv *MockBridgeTransfersView) L1BridgeDepositsByAddress(address common.Add
 				L1TransactionHash: common.HexToHash("0x123"),
 				L2TransactionHash: common.HexToHash("0x555"),
 				L1BlockHash:       common.HexToHash("0x456"),
+				L1BlockNumber:     big.NewInt(90),
 			},
 		},
 	}, nil
@@ -87,15 +150,136 @@ func (mbv *MockBridgeTransfersView) L2BridgeWithdrawalsByAddress(address common.
 				L2BridgeWithdrawal:         withdrawal,
 				L2TransactionHash:          common.HexToHash("0x789"),
 				L2BlockHash:                common.HexToHash("0x456"),
+				L2BlockNumber:              big.NewInt(91),
 				ProvenL1TransactionHash:    common.HexToHash("0x123"),
 				FinalizedL1TransactionHash: common.HexToHash("0x123"),
 			},
 		},
 	}, nil
 }
+
+func (mbv *MockBridgeTransfersView) L1BridgeDeposits(filter database.BridgeTransfersFilter, cursor string, limit int) (*database.L1BridgeDepositsResponse, error) {
+	return mbv.L1BridgeDepositsByAddress(*filter.FromAddress, cursor, limit)
+}
+
+func (mbv *MockBridgeTransfersView) L1BridgeDepositsByTransactionHash(hash common.Hash) ([]database.L1BridgeDepositWithTransactionHashes, error) {
+	deposits, err := mbv.L1BridgeDepositsByAddress(common.Address{}, "", 1)
+	return deposits.Deposits, err
+}
+
+func (mbv *MockBridgeTransfersView) L1BridgeDepositByMessageHash(hash common.Hash) (*database.L1BridgeDepositWithTransactionHashes, error) {
+	if hash != *deposit.CrossDomainMessageHash {
+		return nil, nil
+	}
+	deposits, err := mbv.L1BridgeDepositsByAddress(common.Address{}, "", 1)
+	return &deposits.Deposits[0], err
+}
+
+func (mbv *MockBridgeTransfersView) L2BridgeWithdrawals(filter database.BridgeTransfersFilter, cursor string, limit int) (*database.L2BridgeWithdrawalsResponse, error) {
+	return mbv.L2BridgeWithdrawalsByAddress(*filter.FromAddress, cursor, limit)
+}
+
+func (mbv *MockBridgeTransfersView) L2BridgeWithdrawalsByTransactionHash(hash common.Hash) ([]database.L2BridgeWithdrawalWithTransactionHashes, error) {
+	withdrawals, err := mbv.L2BridgeWithdrawalsByAddress(common.Address{}, "", 1)
+	return withdrawals.Withdrawals, err
+}
+
+func (mbv *MockBridgeTransfersView) L2BridgeWithdrawalByMessageHash(hash common.Hash) (*database.L2BridgeWithdrawalWithTransactionHashes, error) {
+	if hash != *withdrawal.CrossDomainMessageHash {
+		return nil, nil
+	}
+	withdrawals, err := mbv.L2BridgeWithdrawalsByAddress(common.Address{}, "", 1)
+	return &withdrawals.Withdrawals[0], err
+}
+
+func (mbv *MockBridgeTransfersView) L1BridgeDepositsDailyVolume(from, to uint64) ([]database.TokenVolume, error) {
+	return []database.TokenVolume{{Day: 1697760000, TokenAddress: common.HexToAddress("0x20"), Count: 2, Amount: big.NewInt(300)}}, nil
+}
+
+func (mbv *MockBridgeTransfersView) L2BridgeWithdrawalsDailyVolume(from, to uint64) ([]database.TokenVolume, error) {
+	return []database.TokenVolume{}, nil
+}
+
+func (mbv *MockBridgeTransfersView) BridgeValueLocked() ([]database.TokenValueLocked, error) {
+	return []database.TokenValueLocked{{L1TokenAddress: common.HexToAddress("0x20"), Deposited: big.NewInt(300), Withdrawn: big.NewInt(100)}}, nil
+}
+
+func (mbv *MockBridgeTransfersView) L2PendingWithdrawals() (*database.PendingWithdrawals, error) {
+	return &database.PendingWithdrawals{Initiated: 1, ReadyToProve: 2, Proven: 3}, nil
+}
+
+func (mev *MockERC721BridgeTransfersView) L1ERC721BridgeDeposit(hash common.Hash) (*database.L1ERC721BridgeDeposit, error) {
+	return &erc721Deposit, nil
+}
+
+func (mev *MockERC721BridgeTransfersView) L1ERC721BridgeDepositWithFilter(filter database.ERC721BridgeTransfer) (*database.L1ERC721BridgeDeposit, error) {
+	return &erc721Deposit, nil
+}
+
+func (mev *MockERC721BridgeTransfersView) L2ERC721BridgeWithdrawal(hash common.Hash) (*database.L2ERC721BridgeWithdrawal, error) {
+	return &erc721Withdrawal, nil
+}
+
+func (mev *MockERC721BridgeTransfersView) L2ERC721BridgeWithdrawalWithFilter(filter database.ERC721BridgeTransfer) (*database.L2ERC721BridgeWithdrawal, error) {
+	return &erc721Withdrawal, nil
+}
+
+func (mev *MockERC721BridgeTransfersView) L1ERC721BridgeDepositsByAddress(address common.Address, cursor string, limit int) (*database.L1ERC721BridgeDepositsResponse, error) {
+	return &database.L1ERC721BridgeDepositsResponse{
+		Deposits: []database.L1ERC721BridgeDepositWithTransactionHashes{
+			{
+				L1ERC721BridgeDeposit: erc721Deposit,
+				L1TransactionHash:     common.HexToHash("0x123"),
+				L2TransactionHash:     common.HexToHash("0x555"),
+				L1BlockHash:           common.HexToHash("0x456"),
+				L1BlockNumber:         big.NewInt(95),
+			},
+		},
+	}, nil
+}
+
+func (mev *MockERC721BridgeTransfersView) L2ERC721BridgeWithdrawalsByAddress(address common.Address, cursor string, limit int) (*database.L2ERC721BridgeWithdrawalsResponse, error) {
+	return &database.L2ERC721BridgeWithdrawalsResponse{
+		Withdrawals: []database.L2ERC721BridgeWithdrawalWithTransactionHashes{
+			{
+				L2ERC721BridgeWithdrawal: erc721Withdrawal,
+				L2TransactionHash:        common.HexToHash("0x789"),
+				L2BlockHash:              common.HexToHash("0x456"),
+				L2BlockNumber:            big.NewInt(80),
+				L2OutputIndex:            big.NewInt(3),
+			},
+		},
+	}, nil
+}
+
+// mockBridgeEvents ... The latest event is stored after a subscription is made
+var mockBridgeEvents = []database.BridgeEvent{
+	{ID: 1, EventType: database.BridgeEventDepositInitiated, FromAddress: common.HexToAddress(mockAddress), TransferHash: common.HexToHash("0x1"), Timestamp: 1},
+	{ID: 2, EventType: database.BridgeEventDepositFinalized, FromAddress: common.HexToAddress(mockAddress), TransferHash: common.HexToHash("0x1"), Timestamp: 2},
+	{ID: 3, EventType: database.BridgeEventWithdrawalInitiated, ToAddress: common.HexToAddress(mockAddress), TransferHash: common.HexToHash("0x2"), Timestamp: 3},
+}
+
+func (mev *MockBridgeEventsView) BridgeEvents(filter database.BridgeEventsFilter, afterID uint64, limit int) ([]database.BridgeEvent, error) {
+	events := []database.BridgeEvent{}
+	for _, event := range mockBridgeEvents {
+		if event.ID > afterID && len(events) < limit {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+func (mev *MockBridgeEventsView) BridgeEventWithFilter(filter database.BridgeEvent) (*database.BridgeEvent, error) {
+	return nil, nil
+}
+
+func (mev *MockBridgeEventsView) LatestBridgeEventID() (uint64, error) {
+	return 2, nil
+}
+
 func TestHealthz(t *testing.T) {
 	logger := testlog.Logger(t, log.LvlInfo)
-	api := NewApi(logger, &MockBridgeTransfersView{}, apiConfig, metricsConfig)
+	api := NewApi(logger, &MockBridgeTransfersView{}, &MockERC721BridgeTransfersView{}, &MockBridgeEventsView{}, mockBlocks, chainConfig, apiConfig, metricsConfig, nil)
 	request, err := http.NewRequest("GET", "/healthz", nil)
 	assert.Nil(t, err)
 
@@ -107,7 +291,7 @@ func TestHealthz(t *testing.T) {
 
 func TestL1BridgeDepositsHandler(t *testing.T) {
 	logger := testlog.Logger(t, log.LvlInfo)
-	api := NewApi(logger, &MockBridgeTransfersView{}, apiConfig, metricsConfig)
+	api := NewApi(logger, &MockBridgeTransfersView{}, &MockERC721BridgeTransfersView{}, &MockBridgeEventsView{}, mockBlocks, chainConfig, apiConfig, metricsConfig, nil)
 	request, err := http.NewRequest("GET", fmt.Sprintf("/api/v0/deposits/%s", mockAddress), nil)
 	assert.Nil(t, err)
 
@@ -126,11 +310,12 @@ func TestL1BridgeDepositsHandler(t *testing.T) {
 	assert.Equal(t, resp.Items[0].L1TxHash, common.HexToHash("0x123").String())
 	assert.Equal(t, resp.Items[0].Timestamp, deposit.Tx.Timestamp)
 	assert.Equal(t, resp.Items[0].L2TxHash, common.HexToHash("555").String())
+	assert.True(t, resp.Items[0].Confirmed)
 }
 
 func TestL2BridgeWithdrawalsByAddressHandler(t *testing.T) {
 	logger := testlog.Logger(t, log.LvlInfo)
-	api := NewApi(logger, &MockBridgeTransfersView{}, apiConfig, metricsConfig)
+	api := NewApi(logger, &MockBridgeTransfersView{}, &MockERC721BridgeTransfersView{}, &MockBridgeEventsView{}, mockBlocks, chainConfig, apiConfig, metricsConfig, nil)
 	request, err := http.NewRequest("GET", fmt.Sprintf("/api/v0/withdrawals/%s", mockAddress), nil)
 	assert.Nil(t, err)
 
@@ -154,5 +339,255 @@ func TestL2BridgeWithdrawalsByAddressHandler(t *testing.T) {
 	assert.Equal(t, resp.Items[0].L1TokenAddress, withdrawal.TokenPair.RemoteTokenAddress.String())
 	assert.Equal(t, resp.Items[0].L2TokenAddress, withdrawal.TokenPair.LocalTokenAddress.String())
 	assert.Equal(t, resp.Items[0].Timestamp, withdrawal.Tx.Timestamp)
+	assert.False(t, resp.Items[0].Confirmed)
+	assert.Equal(t, resp.Items[0].Status, models.WithdrawalStatusFinalized)
+
+}
+
+func TestL1ERC721BridgeDepositsHandler(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	api := NewApi(logger, &MockBridgeTransfersView{}, &MockERC721BridgeTransfersView{}, &MockBridgeEventsView{}, mockBlocks, chainConfig, apiConfig, metricsConfig, nil)
+	request, err := http.NewRequest("GET", fmt.Sprintf("/api/v0/erc721/deposits/%s", mockAddress), nil)
+	assert.Nil(t, err)
+
+	responseRecorder := httptest.NewRecorder()
+	api.router.ServeHTTP(responseRecorder, request)
+
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+	var resp models.ERC721DepositResponse
+	err = json.Unmarshal(responseRecorder.Body.Bytes(), &resp)
+	assert.Nil(t, err)
+
+	require.Len(t, resp.Items, 1)
+
+	assert.Equal(t, resp.Items[0].Guid, erc721Deposit.TransactionSourceHash.String())
+	assert.Equal(t, resp.Items[0].L1TxHash, common.HexToHash("0x123").String())
+	assert.Equal(t, resp.Items[0].L2TxHash, common.HexToHash("0x555").String())
+	assert.Equal(t, resp.Items[0].TokenId, "42")
+	assert.Equal(t, resp.Items[0].L1TokenAddress, erc721Deposit.TokenPair.LocalTokenAddress.String())
+	assert.Equal(t, resp.Items[0].L2TokenAddress, erc721Deposit.TokenPair.RemoteTokenAddress.String())
+	assert.False(t, resp.Items[0].Confirmed)
+}
+
+func TestL2ERC721BridgeWithdrawalsHandler(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	api := NewApi(logger, &MockBridgeTransfersView{}, &MockERC721BridgeTransfersView{}, &MockBridgeEventsView{}, mockBlocks, chainConfig, apiConfig, metricsConfig, nil)
+	request, err := http.NewRequest("GET", fmt.Sprintf("/api/v0/erc721/withdrawals/%s", mockAddress), nil)
+	assert.Nil(t, err)
 
+	responseRecorder := httptest.NewRecorder()
+	api.router.ServeHTTP(responseRecorder, request)
+
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+	var resp models.ERC721WithdrawalResponse
+	err = json.Unmarshal(responseRecorder.Body.Bytes(), &resp)
+	assert.Nil(t, err)
+
+	require.Len(t, resp.Items, 1)
+
+	assert.Equal(t, resp.Items[0].Guid, erc721Withdrawal.TransactionWithdrawalHash.String())
+	assert.Equal(t, resp.Items[0].TransactionHash, common.HexToHash("0x789").String())
+	assert.Equal(t, resp.Items[0].TokenId, "42")
+	assert.Equal(t, resp.Items[0].L1TokenAddress, erc721Withdrawal.TokenPair.RemoteTokenAddress.String())
+	assert.Equal(t, resp.Items[0].L2TokenAddress, erc721Withdrawal.TokenPair.LocalTokenAddress.String())
+	assert.True(t, resp.Items[0].Confirmed)
+	assert.Equal(t, resp.Items[0].Status, models.WithdrawalStatusReadyToProve)
+	assert.Equal(t, resp.Items[0].L2OutputIndex, "3")
+}
+
+func TestL1BridgeDepositsByTransactionHashHandler(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	api := NewApi(logger, &MockBridgeTransfersView{}, &MockERC721BridgeTransfersView{}, &MockBridgeEventsView{}, mockBlocks, chainConfig, apiConfig, metricsConfig, nil)
+	request, err := http.NewRequest("GET", fmt.Sprintf("/api/v0/deposits/tx/%s", common.HexToHash("0x123")), nil)
+	assert.Nil(t, err)
+
+	responseRecorder := httptest.NewRecorder()
+	api.router.ServeHTTP(responseRecorder, request)
+
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+	var resp models.DepositResponse
+	err = json.Unmarshal(responseRecorder.Body.Bytes(), &resp)
+	assert.Nil(t, err)
+
+	require.Len(t, resp.Items, 1)
+	assert.Equal(t, resp.Items[0].Guid, deposit.TransactionSourceHash.String())
+
+	// Malformed hashes are not routed
+	request, err = http.NewRequest("GET", "/api/v0/deposits/tx/0x123", nil)
+	assert.Nil(t, err)
+
+	responseRecorder = httptest.NewRecorder()
+	api.router.ServeHTTP(responseRecorder, request)
+	assert.Equal(t, http.StatusNotFound, responseRecorder.Code)
+}
+
+func TestL2BridgeWithdrawalByMessageHashHandler(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	api := NewApi(logger, &MockBridgeTransfersView{}, &MockERC721BridgeTransfersView{}, &MockBridgeEventsView{}, mockBlocks, chainConfig, apiConfig, metricsConfig, nil)
+	request, err := http.NewRequest("GET", fmt.Sprintf("/api/v0/withdrawals/message/%s", common.Hash{}), nil)
+	assert.Nil(t, err)
+
+	responseRecorder := httptest.NewRecorder()
+	api.router.ServeHTTP(responseRecorder, request)
+
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+	var resp models.WithdrawalItem
+	err = json.Unmarshal(responseRecorder.Body.Bytes(), &resp)
+	assert.Nil(t, err)
+	assert.Equal(t, resp.Guid, withdrawal.TransactionWithdrawalHash.String())
+
+	// Unknown message
+	request, err = http.NewRequest("GET", fmt.Sprintf("/api/v0/withdrawals/message/%s", common.HexToHash("0x1")), nil)
+	assert.Nil(t, err)
+
+	responseRecorder = httptest.NewRecorder()
+	api.router.ServeHTTP(responseRecorder, request)
+	assert.Equal(t, http.StatusNotFound, responseRecorder.Code)
+}
+
+func TestStatsHandlers(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	api := NewApi(logger, &MockBridgeTransfersView{}, &MockERC721BridgeTransfersView{}, &MockBridgeEventsView{}, mockBlocks, chainConfig, apiConfig, metricsConfig, nil)
+
+	// (1) Volume
+	request, err := http.NewRequest("GET", "/api/v0/stats/volume?from=1697760000&to=1697846400", nil)
+	assert.Nil(t, err)
+
+	responseRecorder := httptest.NewRecorder()
+	api.router.ServeHTTP(responseRecorder, request)
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+	var volume models.VolumeResponse
+	err = json.Unmarshal(responseRecorder.Body.Bytes(), &volume)
+	assert.Nil(t, err)
+	require.Len(t, volume.Deposits, 1)
+	assert.Equal(t, volume.Deposits[0].Amount, "300")
+	assert.Equal(t, volume.Deposits[0].Count, uint64(2))
+	assert.Empty(t, volume.Withdrawals)
+
+	// inverted range
+	request, err = http.NewRequest("GET", "/api/v0/stats/volume?from=1697846400&to=1697760000", nil)
+	assert.Nil(t, err)
+
+	responseRecorder = httptest.NewRecorder()
+	api.router.ServeHTTP(responseRecorder, request)
+	assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+	// (2) Value Locked
+	request, err = http.NewRequest("GET", "/api/v0/stats/tvl", nil)
+	assert.Nil(t, err)
+
+	responseRecorder = httptest.NewRecorder()
+	api.router.ServeHTTP(responseRecorder, request)
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+	var locked models.ValueLockedResponse
+	err = json.Unmarshal(responseRecorder.Body.Bytes(), &locked)
+	assert.Nil(t, err)
+	require.Len(t, locked.Items, 1)
+	assert.Equal(t, locked.Items[0].Locked, "200")
+
+	// (3) Pending Withdrawals
+	request, err = http.NewRequest("GET", "/api/v0/stats/pending-withdrawals", nil)
+	assert.Nil(t, err)
+
+	responseRecorder = httptest.NewRecorder()
+	api.router.ServeHTTP(responseRecorder, request)
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+	var pending models.PendingWithdrawalsResponse
+	err = json.Unmarshal(responseRecorder.Body.Bytes(), &pending)
+	assert.Nil(t, err)
+	assert.Equal(t, models.PendingWithdrawalsResponse{Initiated: 1, ReadyToProve: 2, Proven: 3}, pending)
+}
+
+func TestBridgeEventsHandler(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	api := NewApi(logger, &MockBridgeTransfersView{}, &MockERC721BridgeTransfersView{}, &MockBridgeEventsView{}, mockBlocks, chainConfig, apiConfig, metricsConfig, nil)
+
+	// A closed connection stops the stream once the missed events are written
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// (1) Resume after the first event, up to the start of the subscription
+	request, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("/api/v0/events?address=%s", mockAddress), nil)
+	assert.Nil(t, err)
+	request.Header.Set("Last-Event-ID", "1")
+
+	responseRecorder := httptest.NewRecorder()
+	api.router.ServeHTTP(responseRecorder, request)
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+	assert.Equal(t, "text/event-stream", responseRecorder.Header().Get("Content-Type"))
+
+	body := responseRecorder.Body.String()
+	require.Equal(t, 1, strings.Count(body, "id: "))
+	assert.Contains(t, body, "id: 2\nevent: deposit-finalized\ndata: ")
+
+	// (2) Without a cursor, only newly indexed events are streamed
+	request, err = http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("/api/v0/events?address=%s", mockAddress), nil)
+	assert.Nil(t, err)
+
+	responseRecorder = httptest.NewRecorder()
+	api.router.ServeHTTP(responseRecorder, request)
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+	assert.Empty(t, responseRecorder.Body.String())
+
+	// (3) A subscription must be scoped to an address or message hash
+	request, err = http.NewRequest("GET", "/api/v0/events", nil)
+	assert.Nil(t, err)
+
+	responseRecorder = httptest.NewRecorder()
+	api.router.ServeHTTP(responseRecorder, request)
+	assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+}
+
+func TestL2ChainRoutes(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	primaryConfig := chainConfig
+	primaryConfig.L2ChainID = 10
+	l2Chains := []L2ChainViews{{
+		ChainConfig:           config.ChainConfig{L2ChainID: 8453},
+		BridgeTransfers:       &MockL2ChainBridgeTransfersView{},
+		ERC721BridgeTransfers: &MockERC721BridgeTransfersView{},
+		BridgeEvents:          &MockBridgeEventsView{},
+		Blocks:                mockBlocks,
+	}}
+	api := NewApi(logger, &MockBridgeTransfersView{}, &MockERC721BridgeTransfersView{}, &MockBridgeEventsView{}, mockBlocks, primaryConfig, apiConfig, metricsConfig, l2Chains)
+
+	depositsCount := func(query string) int {
+		request, err := http.NewRequest("GET", fmt.Sprintf("/api/v0/deposits/%s%s", mockAddress, query), nil)
+		require.NoError(t, err)
+
+		responseRecorder := httptest.NewRecorder()
+		api.router.ServeHTTP(responseRecorder, request)
+		require.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var resp models.DepositResponse
+		require.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &resp))
+		return len(resp.Items)
+	}
+
+	// (1) Served by the primary chain by default, or when selected by its id
+	require.Equal(t, 1, depositsCount(""))
+	require.Equal(t, 1, depositsCount("?chainId=10"))
+
+	// (2) Served by the selected L2 chain
+	require.Equal(t, 0, depositsCount("?chainId=8453"))
+
+	// (3) Unknown & invalid chains
+	request, err := http.NewRequest("GET", fmt.Sprintf("/api/v0/deposits/%s?chainId=1", mockAddress), nil)
+	require.NoError(t, err)
+	responseRecorder := httptest.NewRecorder()
+	api.router.ServeHTTP(responseRecorder, request)
+	require.Equal(t, http.StatusNotFound, responseRecorder.Code)
+
+	request, err = http.NewRequest("GET", fmt.Sprintf("/api/v0/deposits/%s?chainId=base", mockAddress), nil)
+	require.NoError(t, err)
+	responseRecorder = httptest.NewRecorder()
+	api.router.ServeHTTP(responseRecorder, request)
+	require.Equal(t, http.StatusBadRequest, responseRecorder.Code)
 }
@@ -12,6 +12,7 @@ type DepositItem struct {
 	Amount         string `json:"amount"`
 	L1TokenAddress string `json:"l1TokenAddress"`
 	L2TokenAddress string `json:"l2TokenAddress"`
+	Confirmed      bool   `json:"confirmed"`
 }
 
 // DepositResponse ... Data model for API JSON response
@@ -21,6 +22,23 @@ type DepositResponse struct {
 	Items       []DepositItem `json:"items"`
 }
 
+// WithdrawalStatus ... Lifecycle state of a withdrawal, derived from the indexed L1 & L2 state
+type WithdrawalStatus string
+
+const (
+	// WithdrawalStatusInitiated ... No output proposal covering the withdrawal yet
+	WithdrawalStatusInitiated WithdrawalStatus = "initiated"
+	// WithdrawalStatusReadyToProve ... An output proposal covering the withdrawal is available. Also the
+	// case for proven withdrawals whose output proposal has since been deleted, requiring a new proof
+	WithdrawalStatusReadyToProve WithdrawalStatus = "ready-to-prove"
+	// WithdrawalStatusProven ... Proven, but still within the challenge window
+	WithdrawalStatusProven WithdrawalStatus = "proven"
+	// WithdrawalStatusReadyToFinalize ... The challenge window has passed since the withdrawal was proven
+	WithdrawalStatusReadyToFinalize WithdrawalStatus = "ready-to-finalize"
+	// WithdrawalStatusFinalized ... Finalized on L1
+	WithdrawalStatusFinalized WithdrawalStatus = "finalized"
+)
+
 // WithdrawalItem ... Data model for API JSON response
 type WithdrawalItem struct {
 	Guid                 string `json:"guid"`
@@ -35,6 +53,15 @@ type WithdrawalItem struct {
 	ClaimTransactionHash string `json:"claimTransactionHash"`
 	L1TokenAddress       string `json:"l1TokenAddress"`
 	L2TokenAddress       string `json:"l2TokenAddress"`
+	Confirmed            bool   `json:"confirmed"`
+
+	Status WithdrawalStatus `json:"status"`
+	// L2OutputIndex ... Index of the output proposal the withdrawal is proven against, or else the first
+	// one it can be proven against, empty if none
+	L2OutputIndex string `json:"l2OutputIndex"`
+	// FinalizeTimestamp ... Estimated time at which the withdrawal can be finalized, 0 if unknown.
+	// Before the withdrawal is proven, this assumes it is proven as soon as possible
+	FinalizeTimestamp uint64 `json:"finalizeTimestamp"`
 }
 
 // WithdrawalResponse ... Data model for API JSON response
@@ -43,3 +70,104 @@ type WithdrawalResponse struct {
 	HasNextPage bool             `json:"hasNextPage"`
 	Items       []WithdrawalItem `json:"items"`
 }
+
+// ERC721DepositItem ... NFT deposit item model for API responses
+type ERC721DepositItem struct {
+	Guid           string `json:"guid"`
+	From           string `json:"from"`
+	To             string `json:"to"`
+	Timestamp      uint64 `json:"timestamp"`
+	L1BlockHash    string `json:"l1BlockHash"`
+	L1TxHash       string `json:"l1TxHash"`
+	L2TxHash       string `json:"l2TxHash"`
+	MessageHash    string `json:"messageHash"`
+	TokenId        string `json:"tokenId"`
+	L1TokenAddress string `json:"l1TokenAddress"`
+	L2TokenAddress string `json:"l2TokenAddress"`
+	Confirmed      bool   `json:"confirmed"`
+}
+
+// ERC721DepositResponse ... Data model for API JSON response
+type ERC721DepositResponse struct {
+	Cursor      string              `json:"cursor"`
+	HasNextPage bool                `json:"hasNextPage"`
+	Items       []ERC721DepositItem `json:"items"`
+}
+
+// ERC721WithdrawalItem ... NFT withdrawal item model for API responses
+type ERC721WithdrawalItem struct {
+	Guid                 string `json:"guid"`
+	From                 string `json:"from"`
+	To                   string `json:"to"`
+	TransactionHash      string `json:"transactionHash"`
+	MessageHash          string `json:"messageHash"`
+	Timestamp            uint64 `json:"timestamp"`
+	L2BlockHash          string `json:"l2BlockHash"`
+	TokenId              string `json:"tokenId"`
+	ProofTransactionHash string `json:"proofTransactionHash"`
+	ClaimTransactionHash string `json:"claimTransactionHash"`
+	L1TokenAddress       string `json:"l1TokenAddress"`
+	L2TokenAddress       string `json:"l2TokenAddress"`
+	Confirmed            bool   `json:"confirmed"`
+
+	Status            WithdrawalStatus `json:"status"`
+	L2OutputIndex     string           `json:"l2OutputIndex"`
+	FinalizeTimestamp uint64           `json:"finalizeTimestamp"`
+}
+
+// ERC721WithdrawalResponse ... Data model for API JSON response
+type ERC721WithdrawalResponse struct {
+	Cursor      string                 `json:"cursor"`
+	HasNextPage bool                   `json:"hasNextPage"`
+	Items       []ERC721WithdrawalItem `json:"items"`
+}
+
+// TokenVolumeItem ... Number & sum of transfers initiated with a token in a UTC day
+type TokenVolumeItem struct {
+	Day          uint64 `json:"day"`
+	TokenAddress string `json:"tokenAddress"`
+	Count        uint64 `json:"count"`
+	Amount       string `json:"amount"`
+}
+
+// VolumeResponse ... Data model for API JSON response
+type VolumeResponse struct {
+	From        uint64            `json:"from"`
+	To          uint64            `json:"to"`
+	Deposits    []TokenVolumeItem `json:"deposits"`
+	Withdrawals []TokenVolumeItem `json:"withdrawals"`
+}
+
+// ValueLockedItem ... Value locked in the bridge for an L1 token
+type ValueLockedItem struct {
+	L1TokenAddress string `json:"l1TokenAddress"`
+	Deposited      string `json:"deposited"`
+	Withdrawn      string `json:"withdrawn"`
+	Locked         string `json:"locked"`
+}
+
+// ValueLockedResponse ... Data model for API JSON response
+type ValueLockedResponse struct {
+	Items []ValueLockedItem `json:"items"`
+}
+
+// PendingWithdrawalsResponse ... Data model for API JSON response
+type PendingWithdrawalsResponse struct {
+	Initiated    uint64 `json:"initiated"`
+	ReadyToProve uint64 `json:"readyToProve"`
+	// Proven ... Includes withdrawals that are ready to be finalized
+	Proven uint64 `json:"proven"`
+}
+
+// BridgeEventItem ... A step in the lifecycle of a deposit or withdrawal, streamed to subscribers
+type BridgeEventItem struct {
+	// ID ... Resume cursor, supplied as the `Last-Event-ID` header when reconnecting
+	ID              uint64 `json:"id"`
+	Type            string `json:"type"`
+	TransferHash    string `json:"transferHash"`
+	MessageHash     string `json:"messageHash"`
+	From            string `json:"from"`
+	To              string `json:"to"`
+	TransactionHash string `json:"transactionHash"`
+	Timestamp       uint64 `json:"timestamp"`
+}
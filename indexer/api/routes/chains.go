@@ -0,0 +1,54 @@
+package routes
+
+import (
+	"net/http"
+)
+
+// ChainRoutes ... Route handlers of each indexed L2 chain. Requests select the chain with the
+// `chainId` query param, and are served by the primary chain when not specified
+type ChainRoutes struct {
+	primary Routes
+	chains  map[uint]Routes
+	v       *Validator
+}
+
+// NewChainRoutes ... Construct a new chain route handler instance, served by the primary chain by
+// default. The primary chain is also selectable by its id when known
+func NewChainRoutes(primary Routes, primaryChainID uint) *ChainRoutes {
+	c := &ChainRoutes{primary: primary, chains: make(map[uint]Routes)}
+	if primaryChainID != 0 {
+		c.chains[primaryChainID] = primary
+	}
+	return c
+}
+
+// AddChain ... Registers the route handlers of an additional L2 chain
+func (c *ChainRoutes) AddChain(chainID uint, routes Routes) {
+	c.chains[chainID] = routes
+}
+
+// Handler ... Wraps the route handler, invoking it with the routes of the requested chain
+func (c *ChainRoutes) Handler(handler func(Routes, http.ResponseWriter, *http.Request)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		chainIDValue := r.URL.Query().Get("chainId")
+		if chainIDValue == "" {
+			handler(c.primary, w, r)
+			return
+		}
+
+		chainID, err := c.v.ParseValidateChainID(chainIDValue)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			c.primary.logger.Error("Invalid chainId param", "param", chainIDValue, "err", err)
+			return
+		}
+
+		routes, ok := c.chains[chainID]
+		if !ok {
+			http.Error(w, "chain not indexed", http.StatusNotFound)
+			return
+		}
+
+		handler(routes, w, r)
+	}
+}
@@ -1,6 +1,7 @@
 package routes
 
 import (
+	"math/big"
 	"net/http"
 
 	"github.com/ethereum-optimism/optimism/indexer/api/models"
@@ -9,23 +10,28 @@ import (
 	"github.com/go-chi/chi/v5"
 )
 
+// newDepositItem ... Converts a database.L1BridgeDepositWithTransactionHashes to an api.DepositItem
+func newDepositItem(deposit *database.L1BridgeDepositWithTransactionHashes, confirmedHeight *big.Int) models.DepositItem {
+	return models.DepositItem{
+		Guid:           deposit.L1BridgeDeposit.TransactionSourceHash.String(),
+		L1BlockHash:    deposit.L1BlockHash.String(),
+		Timestamp:      deposit.L1BridgeDeposit.Tx.Timestamp,
+		L1TxHash:       deposit.L1TransactionHash.String(),
+		L2TxHash:       deposit.L2TransactionHash.String(),
+		From:           deposit.L1BridgeDeposit.Tx.FromAddress.String(),
+		To:             deposit.L1BridgeDeposit.Tx.ToAddress.String(),
+		Amount:         deposit.L1BridgeDeposit.Tx.Amount.String(),
+		L1TokenAddress: deposit.L1BridgeDeposit.TokenPair.LocalTokenAddress.String(),
+		L2TokenAddress: deposit.L1BridgeDeposit.TokenPair.RemoteTokenAddress.String(),
+		Confirmed:      confirmed(deposit.L1BlockNumber, confirmedHeight),
+	}
+}
+
 // newDepositResponse ... Converts a database.L1BridgeDepositsResponse to an api.DepositResponse
-func newDepositResponse(deposits *database.L1BridgeDepositsResponse) models.DepositResponse {
+func newDepositResponse(deposits *database.L1BridgeDepositsResponse, confirmedHeight *big.Int) models.DepositResponse {
 	items := make([]models.DepositItem, len(deposits.Deposits))
-	for i, deposit := range deposits.Deposits {
-		item := models.DepositItem{
-			Guid:           deposit.L1BridgeDeposit.TransactionSourceHash.String(),
-			L1BlockHash:    deposit.L1BlockHash.String(),
-			Timestamp:      deposit.L1BridgeDeposit.Tx.Timestamp,
-			L1TxHash:       deposit.L1TransactionHash.String(),
-			L2TxHash:       deposit.L2TransactionHash.String(),
-			From:           deposit.L1BridgeDeposit.Tx.FromAddress.String(),
-			To:             deposit.L1BridgeDeposit.Tx.ToAddress.String(),
-			Amount:         deposit.L1BridgeDeposit.Tx.Amount.String(),
-			L1TokenAddress: deposit.L1BridgeDeposit.TokenPair.LocalTokenAddress.String(),
-			L2TokenAddress: deposit.L1BridgeDeposit.TokenPair.RemoteTokenAddress.String(),
-		}
-		items[i] = item
+	for i := range deposits.Deposits {
+		items[i] = newDepositItem(&deposits.Deposits[i], confirmedHeight)
 	}
 
 	return models.DepositResponse{
@@ -35,7 +41,8 @@ func newDepositResponse(deposits *database.L1BridgeDepositsResponse) models.Depo
 	}
 }
 
-// L1DepositsHandler ... Handles /api/v0/deposits/{address} GET requests
+// L1DepositsHandler ... Handles /api/v0/deposits/{address} GET requests. Deposits can be
+// further filtered by L1 token and time range via the `token`, `from` & `to` query params
 func (h Routes) L1DepositsHandler(w http.ResponseWriter, r *http.Request) {
 	addressValue := chi.URLParam(r, "address")
 	cursor := r.URL.Query().Get("cursor")
@@ -62,17 +69,98 @@ func (h Routes) L1DepositsHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	deposits, err := h.view.L1BridgeDepositsByAddress(address, cursor, limit)
+	filter, err := h.parseTransfersFilter(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		h.logger.Error("Invalid filter params", "err", err.Error())
+		return
+	}
+	filter.FromAddress = &address
+
+	deposits, err := h.view.L1BridgeDeposits(filter, cursor, limit)
 	if err != nil {
 		http.Error(w, "Internal server error reading deposits", http.StatusInternalServerError)
 		h.logger.Error("Unable to read deposits from DB", "err", err.Error())
 		return
 	}
 
-	response := newDepositResponse(deposits)
+	confirmedHeight, err := h.l1ConfirmedHeight()
+	if err != nil {
+		http.Error(w, "Internal server error reading deposits", http.StatusInternalServerError)
+		h.logger.Error("Unable to read latest L1 block header from DB", "err", err.Error())
+		return
+	}
+
+	response := newDepositResponse(deposits, confirmedHeight)
 
 	err = jsonResponse(w, response, http.StatusOK)
 	if err != nil {
 		h.logger.Error("Error writing response", "err", err)
 	}
 }
+
+// L1DepositsByTransactionHashHandler ... Handles /api/v0/deposits/tx/{hash} GET requests. The hash
+// can either be the L1 transaction initiating the deposits or the L2 transaction executing it
+func (h Routes) L1DepositsByTransactionHashHandler(w http.ResponseWriter, r *http.Request) {
+	hashValue := chi.URLParam(r, "hash")
+	txHash, err := h.v.ParseValidateHash(hashValue)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		h.logger.Error("Invalid hash param", "param", hashValue, "err", err.Error())
+		return
+	}
+
+	deposits, err := h.view.L1BridgeDepositsByTransactionHash(txHash)
+	if err != nil {
+		http.Error(w, "Internal server error reading deposits", http.StatusInternalServerError)
+		h.logger.Error("Unable to read deposits from DB", "err", err.Error())
+		return
+	}
+
+	confirmedHeight, err := h.l1ConfirmedHeight()
+	if err != nil {
+		http.Error(w, "Internal server error reading deposits", http.StatusInternalServerError)
+		h.logger.Error("Unable to read latest L1 block header from DB", "err", err.Error())
+		return
+	}
+
+	response := newDepositResponse(&database.L1BridgeDepositsResponse{Deposits: deposits}, confirmedHeight)
+
+	err = jsonResponse(w, response, http.StatusOK)
+	if err != nil {
+		h.logger.Error("Error writing response", "err", err)
+	}
+}
+
+// L1DepositByMessageHashHandler ... Handles /api/v0/deposits/message/{hash} GET requests
+func (h Routes) L1DepositByMessageHashHandler(w http.ResponseWriter, r *http.Request) {
+	hashValue := chi.URLParam(r, "hash")
+	msgHash, err := h.v.ParseValidateHash(hashValue)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		h.logger.Error("Invalid hash param", "param", hashValue, "err", err.Error())
+		return
+	}
+
+	deposit, err := h.view.L1BridgeDepositByMessageHash(msgHash)
+	if err != nil {
+		http.Error(w, "Internal server error reading deposit", http.StatusInternalServerError)
+		h.logger.Error("Unable to read deposit from DB", "err", err.Error())
+		return
+	} else if deposit == nil {
+		http.Error(w, "deposit not found", http.StatusNotFound)
+		return
+	}
+
+	confirmedHeight, err := h.l1ConfirmedHeight()
+	if err != nil {
+		http.Error(w, "Internal server error reading deposit", http.StatusInternalServerError)
+		h.logger.Error("Unable to read latest L1 block header from DB", "err", err.Error())
+		return
+	}
+
+	err = jsonResponse(w, newDepositItem(deposit, confirmedHeight), http.StatusOK)
+	if err != nil {
+		h.logger.Error("Error writing response", "err", err)
+	}
+}
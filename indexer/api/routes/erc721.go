@@ -0,0 +1,187 @@
+package routes
+
+import (
+	"math/big"
+	"net/http"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/indexer/api/models"
+	"github.com/ethereum-optimism/optimism/indexer/database"
+	"github.com/go-chi/chi/v5"
+)
+
+// newERC721DepositResponse ... Converts a database.L1ERC721BridgeDepositsResponse to an api.ERC721DepositResponse
+func newERC721DepositResponse(deposits *database.L1ERC721BridgeDepositsResponse, confirmedHeight *big.Int) models.ERC721DepositResponse {
+	items := make([]models.ERC721DepositItem, len(deposits.Deposits))
+	for i, deposit := range deposits.Deposits {
+		item := models.ERC721DepositItem{
+			Guid:           deposit.L1ERC721BridgeDeposit.TransactionSourceHash.String(),
+			L1BlockHash:    deposit.L1BlockHash.String(),
+			Timestamp:      deposit.L1ERC721BridgeDeposit.Timestamp,
+			L1TxHash:       deposit.L1TransactionHash.String(),
+			L2TxHash:       deposit.L2TransactionHash.String(),
+			From:           deposit.L1ERC721BridgeDeposit.FromAddress.String(),
+			To:             deposit.L1ERC721BridgeDeposit.ToAddress.String(),
+			TokenId:        deposit.L1ERC721BridgeDeposit.TokenID.String(),
+			L1TokenAddress: deposit.L1ERC721BridgeDeposit.TokenPair.LocalTokenAddress.String(),
+			L2TokenAddress: deposit.L1ERC721BridgeDeposit.TokenPair.RemoteTokenAddress.String(),
+			Confirmed:      confirmed(deposit.L1BlockNumber, confirmedHeight),
+		}
+		if deposit.L1ERC721BridgeDeposit.CrossDomainMessageHash != nil {
+			item.MessageHash = deposit.L1ERC721BridgeDeposit.CrossDomainMessageHash.String()
+		}
+		items[i] = item
+	}
+
+	return models.ERC721DepositResponse{
+		Cursor:      deposits.Cursor,
+		HasNextPage: deposits.HasNextPage,
+		Items:       items,
+	}
+}
+
+// newERC721WithdrawalResponse ... Converts a database.L2ERC721BridgeWithdrawalsResponse to an api.ERC721WithdrawalResponse
+func newERC721WithdrawalResponse(withdrawals *database.L2ERC721BridgeWithdrawalsResponse, confirmedHeight *big.Int, finalizationPeriod uint64, now uint64) models.ERC721WithdrawalResponse {
+	items := make([]models.ERC721WithdrawalItem, len(withdrawals.Withdrawals))
+	for i, withdrawal := range withdrawals.Withdrawals {
+		// NFT withdrawals follow the same multi-step withdrawal process as any other withdrawal
+		status, outputIndex, finalizeTimestamp := withdrawalStatus(&database.L2BridgeWithdrawalWithTransactionHashes{
+			ProvenL1Timestamp:          withdrawal.ProvenL1Timestamp,
+			FinalizedL1TransactionHash: withdrawal.FinalizedL1TransactionHash,
+			L2OutputIndex:              withdrawal.L2OutputIndex,
+			L2OutputTimestamp:          withdrawal.L2OutputTimestamp,
+			ProvenL2OutputIndex:        withdrawal.ProvenL2OutputIndex,
+			ProvenL2OutputTimestamp:    withdrawal.ProvenL2OutputTimestamp,
+		}, finalizationPeriod, now)
+		l2OutputIndex := ""
+		if outputIndex != nil {
+			l2OutputIndex = outputIndex.String()
+		}
+
+		item := models.ERC721WithdrawalItem{
+			Guid:                 withdrawal.L2ERC721BridgeWithdrawal.TransactionWithdrawalHash.String(),
+			L2BlockHash:          withdrawal.L2BlockHash.String(),
+			Timestamp:            withdrawal.L2ERC721BridgeWithdrawal.Timestamp,
+			From:                 withdrawal.L2ERC721BridgeWithdrawal.FromAddress.String(),
+			To:                   withdrawal.L2ERC721BridgeWithdrawal.ToAddress.String(),
+			TransactionHash:      withdrawal.L2TransactionHash.String(),
+			TokenId:              withdrawal.L2ERC721BridgeWithdrawal.TokenID.String(),
+			ProofTransactionHash: withdrawal.ProvenL1TransactionHash.String(),
+			ClaimTransactionHash: withdrawal.FinalizedL1TransactionHash.String(),
+			L1TokenAddress:       withdrawal.L2ERC721BridgeWithdrawal.TokenPair.RemoteTokenAddress.String(),
+			L2TokenAddress:       withdrawal.L2ERC721BridgeWithdrawal.TokenPair.LocalTokenAddress.String(),
+			Confirmed:            confirmed(withdrawal.L2BlockNumber, confirmedHeight),
+			Status:               status,
+			L2OutputIndex:        l2OutputIndex,
+			FinalizeTimestamp:    finalizeTimestamp,
+		}
+		if withdrawal.L2ERC721BridgeWithdrawal.CrossDomainMessageHash != nil {
+			item.MessageHash = withdrawal.L2ERC721BridgeWithdrawal.CrossDomainMessageHash.String()
+		}
+		items[i] = item
+	}
+
+	return models.ERC721WithdrawalResponse{
+		Cursor:      withdrawals.Cursor,
+		HasNextPage: withdrawals.HasNextPage,
+		Items:       items,
+	}
+}
+
+// L1ERC721DepositsHandler ... Handles /api/v0/erc721/deposits/{address} GET requests
+func (h Routes) L1ERC721DepositsHandler(w http.ResponseWriter, r *http.Request) {
+	addressValue := chi.URLParam(r, "address")
+	cursor := r.URL.Query().Get("cursor")
+	limitQuery := r.URL.Query().Get("limit")
+
+	address, err := h.v.ParseValidateAddress(addressValue)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		h.logger.Error("Invalid address param", "param", addressValue, "err", err)
+		return
+	}
+
+	err = h.v.ValidateCursor(cursor)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		h.logger.Error("Invalid cursor param", "param", cursor, "err", err)
+		return
+	}
+
+	limit, err := h.v.ParseValidateLimit(limitQuery)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		h.logger.Error("Invalid limit param", "param", limitQuery, "err", err)
+		return
+	}
+
+	deposits, err := h.erc721View.L1ERC721BridgeDepositsByAddress(address, cursor, limit)
+	if err != nil {
+		http.Error(w, "Internal server error reading nft deposits", http.StatusInternalServerError)
+		h.logger.Error("Unable to read nft deposits from DB", "err", err.Error())
+		return
+	}
+
+	confirmedHeight, err := h.l1ConfirmedHeight()
+	if err != nil {
+		http.Error(w, "Internal server error reading nft deposits", http.StatusInternalServerError)
+		h.logger.Error("Unable to read latest L1 block header from DB", "err", err.Error())
+		return
+	}
+
+	response := newERC721DepositResponse(deposits, confirmedHeight)
+
+	err = jsonResponse(w, response, http.StatusOK)
+	if err != nil {
+		h.logger.Error("Error writing response", "err", err.Error())
+	}
+}
+
+// L2ERC721WithdrawalsHandler ... Handles /api/v0/erc721/withdrawals/{address} GET requests
+func (h Routes) L2ERC721WithdrawalsHandler(w http.ResponseWriter, r *http.Request) {
+	addressValue := chi.URLParam(r, "address")
+	cursor := r.URL.Query().Get("cursor")
+	limitQuery := r.URL.Query().Get("limit")
+
+	address, err := h.v.ParseValidateAddress(addressValue)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		h.logger.Error("Invalid address param", "param", addressValue, "err", err)
+		return
+	}
+
+	err = h.v.ValidateCursor(cursor)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		h.logger.Error("Invalid cursor param", "param", cursor, "err", err)
+		return
+	}
+
+	limit, err := h.v.ParseValidateLimit(limitQuery)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		h.logger.Error("Invalid limit param", "param", limitQuery, "err", err)
+		return
+	}
+
+	withdrawals, err := h.erc721View.L2ERC721BridgeWithdrawalsByAddress(address, cursor, limit)
+	if err != nil {
+		http.Error(w, "Internal server error reading nft withdrawals", http.StatusInternalServerError)
+		h.logger.Error("Unable to read nft withdrawals from DB", "err", err.Error())
+		return
+	}
+
+	confirmedHeight, err := h.l2ConfirmedHeight()
+	if err != nil {
+		http.Error(w, "Internal server error reading nft withdrawals", http.StatusInternalServerError)
+		h.logger.Error("Unable to read latest L2 block header from DB", "err", err.Error())
+		return
+	}
+
+	response := newERC721WithdrawalResponse(withdrawals, confirmedHeight, h.finalizationPeriod, uint64(time.Now().Unix()))
+
+	err = jsonResponse(w, response, http.StatusOK)
+	if err != nil {
+		h.logger.Error("Error writing response", "err", err.Error())
+	}
+}
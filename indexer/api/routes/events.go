@@ -0,0 +1,168 @@
+package routes
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/indexer/api/models"
+	"github.com/ethereum-optimism/optimism/indexer/database"
+)
+
+const (
+	// eventsKeepAliveInterval ... Interval at which idle event streams are kept alive with a comment
+	eventsKeepAliveInterval = 15 * time.Second
+
+	// eventsBackfillLimit ... Page size of the events read from the DB when resuming a stream
+	eventsBackfillLimit = 100
+)
+
+// newBridgeEventItem ... Converts a database.BridgeEvent to an api.BridgeEventItem
+func newBridgeEventItem(event *database.BridgeEvent) models.BridgeEventItem {
+	item := models.BridgeEventItem{
+		ID:              event.ID,
+		Type:            string(event.EventType),
+		TransferHash:    event.TransferHash.String(),
+		From:            event.FromAddress.String(),
+		To:              event.ToAddress.String(),
+		TransactionHash: event.TransactionHash.String(),
+		Timestamp:       event.Timestamp,
+	}
+	if event.MessageHash != nil {
+		item.MessageHash = event.MessageHash.String()
+	}
+	return item
+}
+
+// writeBridgeEvent ... Writes the event as a server-sent event, flushing it to the subscriber
+func writeBridgeEvent(w http.ResponseWriter, rc *http.ResponseController, event *database.BridgeEvent) error {
+	data, err := json.Marshal(newBridgeEventItem(event))
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.EventType, data); err != nil {
+		return err
+	}
+	return rc.Flush()
+}
+
+// BridgeEventsHandler ... Handles /api/v0/events GET requests, streaming the bridge events of an `address`
+// or `messageHash` as server-sent events. Reconnecting subscribers resume after the event id supplied by
+// the `Last-Event-ID` header or `cursor` query param, otherwise only newly indexed events are streamed.
+func (h Routes) BridgeEventsHandler(w http.ResponseWriter, r *http.Request) {
+	var filter database.BridgeEventsFilter
+	if addressValue := r.URL.Query().Get("address"); addressValue != "" {
+		address, err := h.v.ParseValidateAddress(addressValue)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			h.logger.Error("Invalid address param", "param", addressValue, "err", err)
+			return
+		}
+		filter.Address = &address
+	}
+	if hashValue := r.URL.Query().Get("messageHash"); hashValue != "" {
+		msgHash, err := h.v.ParseValidateHash(hashValue)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			h.logger.Error("Invalid messageHash param", "param", hashValue, "err", err)
+			return
+		}
+		filter.MessageHash = &msgHash
+	}
+	if filter.Address == nil && filter.MessageHash == nil {
+		http.Error(w, "address or messageHash must be specified", http.StatusBadRequest)
+		return
+	}
+
+	cursorValue := r.Header.Get("Last-Event-ID")
+	if cursorValue == "" {
+		cursorValue = r.URL.Query().Get("cursor")
+	}
+	var cursor uint64
+	if cursorValue != "" {
+		var err error
+		cursor, err = h.v.ParseValidateEventCursor(cursorValue)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			h.logger.Error("Invalid cursor param", "param", cursorValue, "err", err)
+			return
+		}
+	}
+
+	// Subscribe prior to reading missed events such that none are stored in between
+	sub, latestID, err := h.events.Subscribe(filter)
+	if err != nil {
+		http.Error(w, "Internal server error subscribing to bridge events", http.StatusInternalServerError)
+		h.logger.Error("Unable to subscribe to bridge events", "err", err.Error())
+		return
+	}
+	defer h.events.Unsubscribe(sub)
+
+	// The stream outlives the server write timeout
+	rc := http.NewResponseController(w)
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		h.logger.Error("Unable to clear write deadline", "err", err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	if err := rc.Flush(); err != nil {
+		h.logger.Error("Unable to flush event stream", "err", err.Error())
+		return
+	}
+
+	// (1) Catch up on the events missed since the cursor, up to where the subscription starts
+	for cursorValue != "" && cursor < latestID {
+		events, err := h.events.view.BridgeEvents(filter, cursor, eventsBackfillLimit)
+		if err != nil {
+			// The subscriber reconnects & resumes from the last event written
+			h.logger.Error("Unable to read bridge events from DB", "err", err.Error())
+			return
+		}
+
+		for i := range events {
+			if events[i].ID > latestID {
+				break
+			}
+			if err := writeBridgeEvent(w, rc, &events[i]); err != nil {
+				h.logger.Debug("Unable to write bridge event", "err", err)
+				return
+			}
+		}
+		if len(events) < eventsBackfillLimit {
+			break
+		}
+		cursor = events[len(events)-1].ID
+	}
+
+	// (2) Stream events as they are indexed
+	keepAlive := time.NewTicker(eventsKeepAliveInterval)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case event, ok := <-sub.Events():
+			if !ok {
+				// Either shutting down or lagging behind. The subscriber reconnects & resumes from the last event written
+				return
+			}
+			if err := writeBridgeEvent(w, rc, &event); err != nil {
+				h.logger.Debug("Unable to write bridge event", "err", err)
+				return
+			}
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+			if err := rc.Flush(); err != nil {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package routes
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/indexer/database"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	// eventsPollInterval ... Interval at which newly stored bridge events are read from the DB
+	eventsPollInterval = time.Second
+
+	// eventsPollLimit ... Max number of bridge events read from the DB at once
+	eventsPollLimit = 1_000
+
+	// subscriptionBufferSize ... Number of events buffered for a subscriber before it is considered lagging
+	subscriptionBufferSize = 256
+)
+
+// EventsSubscription ... Live feed of the bridge events matching a filter
+type EventsSubscription struct {
+	filter database.BridgeEventsFilter
+	events chan database.BridgeEvent
+}
+
+// Events ... Channel of matching events, closed when the hub stops or the subscriber lags behind
+func (s *EventsSubscription) Events() <-chan database.BridgeEvent {
+	return s.events
+}
+
+func (s *EventsSubscription) matches(event *database.BridgeEvent) bool {
+	if s.filter.Address != nil && *s.filter.Address != event.FromAddress && *s.filter.Address != event.ToAddress {
+		return false
+	}
+	if s.filter.MessageHash != nil && (event.MessageHash == nil || *s.filter.MessageHash != *event.MessageHash) {
+		return false
+	}
+	return true
+}
+
+// EventsHub ... Tails the bridge events stored by the indexer, fanning them out to all subscribers.
+// A single poller is shared by all subscribers such that the DB load is independent of their count.
+//
+// NOTE: Event ids become visible in order, including those stored by the concurrent workers of a backfill, which
+// are serialized at commit time. The hub can therefore safely advance past the latest id it has read.
+type EventsHub struct {
+	log  log.Logger
+	view database.BridgeEventsView
+
+	mu            sync.Mutex
+	initialized   bool
+	stopped       bool
+	latestID      uint64
+	subscriptions map[*EventsSubscription]struct{}
+}
+
+// NewEventsHub ... Construct a new events hub instance
+func NewEventsHub(logger log.Logger, view database.BridgeEventsView) *EventsHub {
+	return &EventsHub{
+		log:           logger.New("module", "events_hub"),
+		view:          view,
+		subscriptions: make(map[*EventsSubscription]struct{}),
+	}
+}
+
+// Start ... Polls for new bridge events until the context is cancelled, closing all subscriptions on exit
+func (hub *EventsHub) Start(ctx context.Context) error {
+	defer hub.closeSubscriptions()
+
+	ticker := time.NewTicker(eventsPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			// The DB may be briefly unavailable. Subscribers are kept open & caught up on the next tick
+			if err := hub.poll(); err != nil {
+				hub.log.Error("unable to read bridge events", "err", err)
+			}
+		}
+	}
+}
+
+// Subscribe ... Registers a subscription to the events matching the filter. Events with an id greater than the
+// returned id are delivered through the subscription, prior events must be read from the DB by the subscriber.
+func (hub *EventsHub) Subscribe(filter database.BridgeEventsFilter) (*EventsSubscription, uint64, error) {
+	hub.mu.Lock()
+	defer hub.mu.Unlock()
+	if hub.stopped {
+		return nil, 0, errors.New("events hub stopped")
+	}
+	if err := hub.init(); err != nil {
+		return nil, 0, err
+	}
+
+	sub := &EventsSubscription{filter: filter, events: make(chan database.BridgeEvent, subscriptionBufferSize)}
+	hub.subscriptions[sub] = struct{}{}
+	return sub, hub.latestID, nil
+}
+
+// Unsubscribe ... Removes the subscription from the hub. Safe to call more than once
+func (hub *EventsHub) Unsubscribe(sub *EventsSubscription) {
+	hub.mu.Lock()
+	defer hub.mu.Unlock()
+	if _, ok := hub.subscriptions[sub]; ok {
+		delete(hub.subscriptions, sub)
+		close(sub.events)
+	}
+}
+
+// init ... Starts the feed at the latest stored event. Must be called with the lock held
+func (hub *EventsHub) init() error {
+	if hub.initialized {
+		return nil
+	}
+
+	latestID, err := hub.view.LatestBridgeEventID()
+	if err != nil {
+		return err
+	}
+
+	hub.latestID = latestID
+	hub.initialized = true
+	return nil
+}
+
+// poll ... Reads all events stored since the last poll and delivers them to the matching subscribers
+func (hub *EventsHub) poll() error {
+	hub.mu.Lock()
+	err := hub.init()
+	latestID := hub.latestID
+	hub.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	for {
+		events, err := hub.view.BridgeEvents(database.BridgeEventsFilter{}, latestID, eventsPollLimit)
+		if err != nil {
+			return err
+		}
+		if len(events) == 0 {
+			return nil
+		}
+
+		hub.mu.Lock()
+		for i := range events {
+			hub.broadcast(&events[i])
+		}
+		latestID = events[len(events)-1].ID
+		hub.latestID = latestID
+		hub.mu.Unlock()
+
+		if len(events) < eventsPollLimit {
+			return nil
+		}
+	}
+}
+
+// broadcast ... Delivers the event to the matching subscribers. Lagging subscribers are dropped rather than
+// blocking the feed, and are expected to resume from their last received event. Must be called with the lock held
+func (hub *EventsHub) broadcast(event *database.BridgeEvent) {
+	for sub := range hub.subscriptions {
+		if !sub.matches(event) {
+			continue
+		}
+
+		select {
+		case sub.events <- *event:
+		default:
+			hub.log.Warn("dropping lagging subscriber", "event_id", event.ID)
+			delete(hub.subscriptions, sub)
+			close(sub.events)
+		}
+	}
+}
+
+func (hub *EventsHub) closeSubscriptions() {
+	hub.mu.Lock()
+	defer hub.mu.Unlock()
+	hub.stopped = true
+	for sub := range hub.subscriptions {
+		delete(hub.subscriptions, sub)
+		close(sub.events)
+	}
+}
@@ -0,0 +1,82 @@
+package routes
+
+import (
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/indexer/database"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+type mockBridgeEventsView struct {
+	events []database.BridgeEvent
+}
+
+func (m *mockBridgeEventsView) BridgeEvents(filter database.BridgeEventsFilter, afterID uint64, limit int) ([]database.BridgeEvent, error) {
+	events := []database.BridgeEvent{}
+	for _, event := range m.events {
+		if event.ID > afterID && len(events) < limit {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+func (m *mockBridgeEventsView) BridgeEventWithFilter(filter database.BridgeEvent) (*database.BridgeEvent, error) {
+	return nil, nil
+}
+
+func (m *mockBridgeEventsView) LatestBridgeEventID() (uint64, error) {
+	if len(m.events) == 0 {
+		return 0, nil
+	}
+	return m.events[len(m.events)-1].ID, nil
+}
+
+func TestEventsHubBroadcast(t *testing.T) {
+	alice, bob := common.HexToAddress("0xa"), common.HexToAddress("0xb")
+	msgHash := common.HexToHash("0x1")
+
+	view := &mockBridgeEventsView{events: []database.BridgeEvent{{ID: 1, FromAddress: alice}}}
+	hub := NewEventsHub(testlog.Logger(t, log.LvlInfo), view)
+
+	aliceSub, latestID, err := hub.Subscribe(database.BridgeEventsFilter{Address: &alice})
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), latestID)
+	msgSub, _, err := hub.Subscribe(database.BridgeEventsFilter{MessageHash: &msgHash})
+	require.NoError(t, err)
+
+	// (1) Only the events stored after subscribing are delivered, to the matching subscribers
+	view.events = append(view.events,
+		database.BridgeEvent{ID: 2, FromAddress: bob, ToAddress: alice},
+		database.BridgeEvent{ID: 3, FromAddress: bob, MessageHash: &msgHash},
+	)
+	require.NoError(t, hub.poll())
+
+	require.Len(t, aliceSub.Events(), 1)
+	require.Equal(t, uint64(2), (<-aliceSub.Events()).ID)
+	require.Len(t, msgSub.Events(), 1)
+	require.Equal(t, uint64(3), (<-msgSub.Events()).ID)
+
+	// (2) Lagging subscribers are dropped rather than blocking the feed
+	for i := 0; i <= subscriptionBufferSize; i++ {
+		view.events = append(view.events, database.BridgeEvent{ID: uint64(4 + i), FromAddress: alice})
+	}
+	require.NoError(t, hub.poll())
+
+	received := 0
+	for range aliceSub.Events() {
+		received++
+	}
+	require.Equal(t, subscriptionBufferSize, received)
+	hub.Unsubscribe(aliceSub)
+
+	// (3) Stopping the hub closes the remaining subscriptions
+	hub.closeSubscriptions()
+	_, ok := <-msgSub.Events()
+	require.False(t, ok)
+	_, _, err = hub.Subscribe(database.BridgeEventsFilter{Address: &bob})
+	require.Error(t, err)
+}
@@ -1,6 +1,11 @@
 package routes
 
 import (
+	"errors"
+	"math/big"
+	"net/http"
+
+	"github.com/ethereum-optimism/optimism/indexer/config"
 	"github.com/ethereum-optimism/optimism/indexer/database"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/go-chi/chi/v5"
@@ -10,15 +15,84 @@ import (
 type Routes struct {
 	logger log.Logger
 	view   database.BridgeTransfersView
+	blocks database.BlocksView
 	router *chi.Mux
 	v      *Validator
+
+	erc721View database.ERC721BridgeTransfersView
+	events     *EventsHub
+
+	l1ConfirmationDepth *big.Int
+	l2ConfirmationDepth *big.Int
+	finalizationPeriod  uint64
 }
 
 // NewRoutes ... Construct a new route handler instance
-func NewRoutes(logger log.Logger, bv database.BridgeTransfersView, r *chi.Mux) Routes {
+func NewRoutes(logger log.Logger, bv database.BridgeTransfersView, erc721 database.ERC721BridgeTransfersView, events *EventsHub, blocks database.BlocksView, chainConfig config.ChainConfig, r *chi.Mux) Routes {
 	return Routes{
 		logger: logger,
 		view:   bv,
+		blocks: blocks,
 		router: r,
+
+		erc721View: erc721,
+		events:     events,
+
+		l1ConfirmationDepth: new(big.Int).SetUint64(uint64(chainConfig.L1ConfirmationDepth)),
+		l2ConfirmationDepth: new(big.Int).SetUint64(uint64(chainConfig.L2ConfirmationDepth)),
+		finalizationPeriod:  uint64(chainConfig.FinalizationPeriodSeconds),
+	}
+}
+
+// l1ConfirmedHeight ... Returns the highest L1 block height considered confirmed, or nil if
+// nothing has been indexed yet. Besides the L1 blocks with bridge activity, the L1 ETL indexes
+// the last block of every batch, so the latest L1 header is the head of the ETL traversal.
+func (h Routes) l1ConfirmedHeight() (*big.Int, error) {
+	latest, err := h.blocks.L1LatestBlockHeader()
+	if err != nil || latest == nil {
+		return nil, err
+	}
+	return new(big.Int).Sub(latest.Number, h.l1ConfirmationDepth), nil
+}
+
+// l2ConfirmedHeight ... Returns the highest L2 block height considered confirmed, or nil if
+// nothing has been indexed yet
+func (h Routes) l2ConfirmedHeight() (*big.Int, error) {
+	latest, err := h.blocks.L2LatestBlockHeader()
+	if err != nil || latest == nil {
+		return nil, err
 	}
+	return new(big.Int).Sub(latest.Number, h.l2ConfirmationDepth), nil
+}
+
+// parseTransfersFilter ... Parses the optional `token`, `from` & `to` query params narrowing down listed transfers
+func (h Routes) parseTransfersFilter(r *http.Request) (database.BridgeTransfersFilter, error) {
+	var filter database.BridgeTransfersFilter
+	if token := r.URL.Query().Get("token"); token != "" {
+		tokenAddress, err := h.v.ParseValidateAddress(token)
+		if err != nil {
+			return filter, err
+		}
+		filter.TokenAddress = &tokenAddress
+	}
+
+	var err error
+	filter.FromTimestamp, err = h.v.ParseValidateTimestamp(r.URL.Query().Get("from"))
+	if err != nil {
+		return filter, err
+	}
+	filter.ToTimestamp, err = h.v.ParseValidateTimestamp(r.URL.Query().Get("to"))
+	if err != nil {
+		return filter, err
+	}
+	if filter.ToTimestamp > 0 && filter.FromTimestamp > filter.ToTimestamp {
+		return filter, errors.New("from timestamp must not be after the to timestamp")
+	}
+
+	return filter, nil
+}
+
+// confirmed ... Reports whether a block is at or below the confirmed height
+func confirmed(number *big.Int, confirmedHeight *big.Int) bool {
+	return number != nil && confirmedHeight != nil && number.Cmp(confirmedHeight) <= 0
 }
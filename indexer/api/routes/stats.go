@@ -0,0 +1,145 @@
+package routes
+
+import (
+	"errors"
+	"math/big"
+	"net/http"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/indexer/api/models"
+	"github.com/ethereum-optimism/optimism/indexer/database"
+)
+
+const (
+	// defaultVolumeRange ... Default time range of the volume stats, in seconds
+	defaultVolumeRange = 30 * 24 * 60 * 60
+
+	// maxVolumeRange ... Max time range of the volume stats, in seconds
+	maxVolumeRange = 366 * 24 * 60 * 60
+)
+
+// newTokenVolumeItems ... Converts a list of database.TokenVolume to api.TokenVolumeItem
+func newTokenVolumeItems(volumes []database.TokenVolume) []models.TokenVolumeItem {
+	items := make([]models.TokenVolumeItem, len(volumes))
+	for i, volume := range volumes {
+		items[i] = models.TokenVolumeItem{
+			Day:          volume.Day,
+			TokenAddress: volume.TokenAddress.String(),
+			Count:        volume.Count,
+			Amount:       volume.Amount.String(),
+		}
+	}
+	return items
+}
+
+// newValueLockedResponse ... Converts a list of database.TokenValueLocked to an api.ValueLockedResponse
+func newValueLockedResponse(locked []database.TokenValueLocked) models.ValueLockedResponse {
+	items := make([]models.ValueLockedItem, len(locked))
+	for i, token := range locked {
+		items[i] = models.ValueLockedItem{
+			L1TokenAddress: token.L1TokenAddress.String(),
+			Deposited:      token.Deposited.String(),
+			Withdrawn:      token.Withdrawn.String(),
+			Locked:         new(big.Int).Sub(token.Deposited, token.Withdrawn).String(),
+		}
+	}
+	return models.ValueLockedResponse{Items: items}
+}
+
+// volumeRange ... Parses the `from` & `to` query params, defaulting to the most recent 30 days
+func (h Routes) volumeRange(r *http.Request, now uint64) (uint64, uint64, error) {
+	from, err := h.v.ParseValidateTimestamp(r.URL.Query().Get("from"))
+	if err != nil {
+		return 0, 0, err
+	}
+	to, err := h.v.ParseValidateTimestamp(r.URL.Query().Get("to"))
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if to == 0 {
+		to = now
+	}
+	if from == 0 && to > defaultVolumeRange {
+		from = to - defaultVolumeRange
+	}
+
+	if from > to {
+		return 0, 0, errors.New("from timestamp must not be after the to timestamp")
+	} else if to-from > maxVolumeRange {
+		return 0, 0, errors.New("time range cannot exceed 366 days")
+	}
+
+	return from, to, nil
+}
+
+// VolumeHandler ... Handles /api/v0/stats/volume GET requests
+func (h Routes) VolumeHandler(w http.ResponseWriter, r *http.Request) {
+	from, to, err := h.volumeRange(r, uint64(time.Now().Unix()))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		h.logger.Error("Invalid time range params", "err", err)
+		return
+	}
+
+	deposits, err := h.view.L1BridgeDepositsDailyVolume(from, to)
+	if err != nil {
+		http.Error(w, "Internal server error reading volume", http.StatusInternalServerError)
+		h.logger.Error("Unable to read deposit volume from DB", "err", err.Error())
+		return
+	}
+	withdrawals, err := h.view.L2BridgeWithdrawalsDailyVolume(from, to)
+	if err != nil {
+		http.Error(w, "Internal server error reading volume", http.StatusInternalServerError)
+		h.logger.Error("Unable to read withdrawal volume from DB", "err", err.Error())
+		return
+	}
+
+	response := models.VolumeResponse{
+		From:        from,
+		To:          to,
+		Deposits:    newTokenVolumeItems(deposits),
+		Withdrawals: newTokenVolumeItems(withdrawals),
+	}
+
+	err = jsonResponse(w, response, http.StatusOK)
+	if err != nil {
+		h.logger.Error("Error writing response", "err", err.Error())
+	}
+}
+
+// ValueLockedHandler ... Handles /api/v0/stats/tvl GET requests
+func (h Routes) ValueLockedHandler(w http.ResponseWriter, r *http.Request) {
+	locked, err := h.view.BridgeValueLocked()
+	if err != nil {
+		http.Error(w, "Internal server error reading value locked", http.StatusInternalServerError)
+		h.logger.Error("Unable to read value locked from DB", "err", err.Error())
+		return
+	}
+
+	err = jsonResponse(w, newValueLockedResponse(locked), http.StatusOK)
+	if err != nil {
+		h.logger.Error("Error writing response", "err", err.Error())
+	}
+}
+
+// PendingWithdrawalsHandler ... Handles /api/v0/stats/pending-withdrawals GET requests
+func (h Routes) PendingWithdrawalsHandler(w http.ResponseWriter, r *http.Request) {
+	pending, err := h.view.L2PendingWithdrawals()
+	if err != nil {
+		http.Error(w, "Internal server error reading pending withdrawals", http.StatusInternalServerError)
+		h.logger.Error("Unable to read pending withdrawals from DB", "err", err.Error())
+		return
+	}
+
+	response := models.PendingWithdrawalsResponse{
+		Initiated:    pending.Initiated,
+		ReadyToProve: pending.ReadyToProve,
+		Proven:       pending.Proven,
+	}
+
+	err = jsonResponse(w, response, http.StatusOK)
+	if err != nil {
+		h.logger.Error("Error writing response", "err", err.Error())
+	}
+}
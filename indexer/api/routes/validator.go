@@ -6,6 +6,7 @@ import (
 	"errors"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 )
 
 // Validator ... Validates API user request parameters
@@ -60,3 +61,47 @@ func (v *Validator) ValidateCursor(cursor string) error {
 
 	return nil
 }
+
+// ParseValidateHash ... Validates and parses a 32 byte hash parameter
+func (v *Validator) ParseValidateHash(hash string) (common.Hash, error) {
+	b, err := hexutil.Decode(hash)
+	if err != nil || len(b) != common.HashLength {
+		return common.Hash{}, errors.New("hash must be a 32 byte hex string")
+	}
+
+	return common.BytesToHash(b), nil
+}
+
+// ParseValidateTimestamp ... Validates and parses an optional unix timestamp query parameter
+func (v *Validator) ParseValidateTimestamp(timestamp string) (uint64, error) {
+	if timestamp == "" {
+		return 0, nil
+	}
+
+	val, err := strconv.ParseUint(timestamp, 10, 64)
+	if err != nil {
+		return 0, errors.New("timestamp must be a unix timestamp in seconds")
+	}
+
+	return val, nil
+}
+
+// ParseValidateEventCursor ... Validates and parses the id of the last event received by a subscriber
+func (v *Validator) ParseValidateEventCursor(cursor string) (uint64, error) {
+	val, err := strconv.ParseUint(cursor, 10, 64)
+	if err != nil {
+		return 0, errors.New("cursor must be the id of a previously received event")
+	}
+
+	return val, nil
+}
+
+// ParseValidateChainID ... Validates and parses the chainId query parameter
+func (v *Validator) ParseValidateChainID(chainID string) (uint, error) {
+	val, err := strconv.ParseUint(chainID, 10, 64)
+	if err != nil || val == 0 {
+		return 0, errors.New("chainId must be a positive integer")
+	}
+
+	return uint(val), nil
+}
@@ -62,3 +62,66 @@ func Test_ParseValidateCursor(t *testing.T) {
 	err = v.ValidateCursor(cursor)
 	require.Error(t, err, "cursor must start with 0x")
 }
+
+func TestParseValidateHash(t *testing.T) {
+	v := Validator{}
+
+	// (1) Happy case
+	hash := "0x95222290dd7278aa3ddd389cc1e1d165cc4bafe595222290dd7278aa3ddd389c"
+	_, err := v.ParseValidateHash(hash)
+	require.NoError(t, err, "hash should be valid")
+
+	// (2) Missing prefix
+	_, err = v.ParseValidateHash(hash[2:])
+	require.Error(t, err, "hash must begin with 0x")
+
+	// (3) Length validation
+	_, err = v.ParseValidateHash(hash[:64])
+	require.Error(t, err, "hash must be 32 bytes")
+}
+
+func TestParseValidateTimestamp(t *testing.T) {
+	v := Validator{}
+
+	// (1) Happy case
+	ts, err := v.ParseValidateTimestamp("1697760000")
+	require.NoError(t, err, "timestamp should be valid")
+	require.Equal(t, uint64(1697760000), ts)
+
+	// (2) Unset
+	ts, err = v.ParseValidateTimestamp("")
+	require.NoError(t, err, "timestamp is optional")
+	require.Zero(t, ts)
+
+	// (3) Type validation
+	_, err = v.ParseValidateTimestamp("-1")
+	require.Error(t, err, "timestamp must be a positive integer")
+}
+
+func TestParseValidateEventCursor(t *testing.T) {
+	v := Validator{}
+
+	// (1) Happy case
+	cursor, err := v.ParseValidateEventCursor("42")
+	require.NoError(t, err, "cursor should be valid")
+	require.Equal(t, uint64(42), cursor)
+
+	// (2) Type validation
+	_, err = v.ParseValidateEventCursor("0x2a")
+	require.Error(t, err, "cursor must be a decimal event id")
+}
+
+func TestParseValidateChainID(t *testing.T) {
+	v := Validator{}
+
+	// (1) Happy case
+	chainID, err := v.ParseValidateChainID("8453")
+	require.NoError(t, err, "chain id should be valid")
+	require.Equal(t, uint(8453), chainID)
+
+	// (2) Type validation
+	_, err = v.ParseValidateChainID("0x2105")
+	require.Error(t, err, "chain id must be a decimal integer")
+	_, err = v.ParseValidateChainID("0")
+	require.Error(t, err, "chain id must be positive")
+}
@@ -1,31 +1,80 @@
 package routes
 
 import (
+	"math/big"
 	"net/http"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/indexer/api/models"
 	"github.com/ethereum-optimism/optimism/indexer/database"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/go-chi/chi/v5"
 )
 
-// FIXME make a pure function that returns a struct instead of newWithdrawalResponse
+// withdrawalStatus ... Derives the lifecycle state of a withdrawal at the supplied time, along with the
+// index of the output proposal it is proven against (or else can be proven against) and the estimated
+// time it can be finalized. Finalization requires the challenge window to have passed for both the proof
+// and the proven output proposal. A proof no longer counts once its output proposal has been deleted.
+func withdrawalStatus(withdrawal *database.L2BridgeWithdrawalWithTransactionHashes, finalizationPeriod uint64, now uint64) (models.WithdrawalStatus, *big.Int, uint64) {
+	proven := withdrawal.ProvenL1Timestamp != nil && (withdrawal.ProvenL2OutputIndex == nil || withdrawal.ProvenL2OutputTimestamp != nil)
+	l2OutputIndex, l2OutputTimestamp := withdrawal.L2OutputIndex, withdrawal.L2OutputTimestamp
+	if proven && withdrawal.ProvenL2OutputIndex != nil {
+		l2OutputIndex, l2OutputTimestamp = withdrawal.ProvenL2OutputIndex, withdrawal.ProvenL2OutputTimestamp
+	}
+
+	var finalizeTimestamp uint64
+	if l2OutputTimestamp != nil {
+		finalizeTimestamp = *l2OutputTimestamp + finalizationPeriod
+	}
+	if proven && *withdrawal.ProvenL1Timestamp+finalizationPeriod > finalizeTimestamp {
+		finalizeTimestamp = *withdrawal.ProvenL1Timestamp + finalizationPeriod
+	}
+
+	switch {
+	case withdrawal.FinalizedL1TransactionHash != (common.Hash{}):
+		return models.WithdrawalStatusFinalized, l2OutputIndex, finalizeTimestamp
+	case proven && now >= finalizeTimestamp:
+		return models.WithdrawalStatusReadyToFinalize, l2OutputIndex, finalizeTimestamp
+	case proven:
+		return models.WithdrawalStatusProven, l2OutputIndex, finalizeTimestamp
+	case l2OutputIndex != nil:
+		return models.WithdrawalStatusReadyToProve, l2OutputIndex, finalizeTimestamp
+	default:
+		return models.WithdrawalStatusInitiated, l2OutputIndex, finalizeTimestamp
+	}
+}
+
+// newWithdrawalItem ... Converts a database.L2BridgeWithdrawalWithTransactionHashes to an api.WithdrawalItem
+func newWithdrawalItem(withdrawal *database.L2BridgeWithdrawalWithTransactionHashes, confirmedHeight *big.Int, finalizationPeriod uint64, now uint64) models.WithdrawalItem {
+	status, outputIndex, finalizeTimestamp := withdrawalStatus(withdrawal, finalizationPeriod, now)
+	l2OutputIndex := ""
+	if outputIndex != nil {
+		l2OutputIndex = outputIndex.String()
+	}
+
+	return models.WithdrawalItem{
+		Guid:                 withdrawal.L2BridgeWithdrawal.TransactionWithdrawalHash.String(),
+		L2BlockHash:          withdrawal.L2BlockHash.String(),
+		From:                 withdrawal.L2BridgeWithdrawal.Tx.FromAddress.String(),
+		To:                   withdrawal.L2BridgeWithdrawal.Tx.ToAddress.String(),
+		TransactionHash:      withdrawal.L2TransactionHash.String(),
+		Amount:               withdrawal.L2BridgeWithdrawal.Tx.Amount.String(),
+		ProofTransactionHash: withdrawal.ProvenL1TransactionHash.String(),
+		ClaimTransactionHash: withdrawal.FinalizedL1TransactionHash.String(),
+		L1TokenAddress:       withdrawal.L2BridgeWithdrawal.TokenPair.RemoteTokenAddress.String(),
+		L2TokenAddress:       withdrawal.L2BridgeWithdrawal.TokenPair.LocalTokenAddress.String(),
+		Confirmed:            confirmed(withdrawal.L2BlockNumber, confirmedHeight),
+		Status:               status,
+		L2OutputIndex:        l2OutputIndex,
+		FinalizeTimestamp:    finalizeTimestamp,
+	}
+}
+
 // newWithdrawalResponse ... Converts a database.L2BridgeWithdrawalsResponse to an api.WithdrawalResponse
-func newWithdrawalResponse(withdrawals *database.L2BridgeWithdrawalsResponse) models.WithdrawalResponse {
+func newWithdrawalResponse(withdrawals *database.L2BridgeWithdrawalsResponse, confirmedHeight *big.Int, finalizationPeriod uint64, now uint64) models.WithdrawalResponse {
 	items := make([]models.WithdrawalItem, len(withdrawals.Withdrawals))
-	for i, withdrawal := range withdrawals.Withdrawals {
-		item := models.WithdrawalItem{
-			Guid:                 withdrawal.L2BridgeWithdrawal.TransactionWithdrawalHash.String(),
-			L2BlockHash:          withdrawal.L2BlockHash.String(),
-			From:                 withdrawal.L2BridgeWithdrawal.Tx.FromAddress.String(),
-			To:                   withdrawal.L2BridgeWithdrawal.Tx.ToAddress.String(),
-			TransactionHash:      withdrawal.L2TransactionHash.String(),
-			Amount:               withdrawal.L2BridgeWithdrawal.Tx.Amount.String(),
-			ProofTransactionHash: withdrawal.ProvenL1TransactionHash.String(),
-			ClaimTransactionHash: withdrawal.FinalizedL1TransactionHash.String(),
-			L1TokenAddress:       withdrawal.L2BridgeWithdrawal.TokenPair.RemoteTokenAddress.String(),
-			L2TokenAddress:       withdrawal.L2BridgeWithdrawal.TokenPair.LocalTokenAddress.String(),
-		}
-		items[i] = item
+	for i := range withdrawals.Withdrawals {
+		items[i] = newWithdrawalItem(&withdrawals.Withdrawals[i], confirmedHeight, finalizationPeriod, now)
 	}
 
 	return models.WithdrawalResponse{
@@ -35,7 +84,8 @@ func newWithdrawalResponse(withdrawals *database.L2BridgeWithdrawalsResponse) mo
 	}
 }
 
-// L2WithdrawalsHandler ... Handles /api/v0/withdrawals/{address} GET requests
+// L2WithdrawalsHandler ... Handles /api/v0/withdrawals/{address} GET requests. Withdrawals can be
+// further filtered by L2 token and time range via the `token`, `from` & `to` query params
 func (h Routes) L2WithdrawalsHandler(w http.ResponseWriter, r *http.Request) {
 	addressValue := chi.URLParam(r, "address")
 	cursor := r.URL.Query().Get("cursor")
@@ -62,16 +112,100 @@ func (h Routes) L2WithdrawalsHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	withdrawals, err := h.view.L2BridgeWithdrawalsByAddress(address, cursor, limit)
+	filter, err := h.parseTransfersFilter(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		h.logger.Error("Invalid filter params", "err", err)
+		return
+	}
+	filter.FromAddress = &address
+
+	withdrawals, err := h.view.L2BridgeWithdrawals(filter, cursor, limit)
 	if err != nil {
 		http.Error(w, "Internal server error reading withdrawals", http.StatusInternalServerError)
 		h.logger.Error("Unable to read withdrawals from DB", "err", err.Error())
 		return
 	}
-	response := newWithdrawalResponse(withdrawals)
+
+	confirmedHeight, err := h.l2ConfirmedHeight()
+	if err != nil {
+		http.Error(w, "Internal server error reading withdrawals", http.StatusInternalServerError)
+		h.logger.Error("Unable to read latest L2 block header from DB", "err", err.Error())
+		return
+	}
+
+	response := newWithdrawalResponse(withdrawals, confirmedHeight, h.finalizationPeriod, uint64(time.Now().Unix()))
 
 	err = jsonResponse(w, response, http.StatusOK)
 	if err != nil {
 		h.logger.Error("Error writing response", "err", err.Error())
 	}
 }
+
+// L2WithdrawalsByTransactionHashHandler ... Handles /api/v0/withdrawals/tx/{hash} GET requests. The hash can
+// either be the L2 transaction initiating the withdrawals or the L1 transaction proving or finalizing it
+func (h Routes) L2WithdrawalsByTransactionHashHandler(w http.ResponseWriter, r *http.Request) {
+	hashValue := chi.URLParam(r, "hash")
+	txHash, err := h.v.ParseValidateHash(hashValue)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		h.logger.Error("Invalid hash param", "param", hashValue, "err", err)
+		return
+	}
+
+	withdrawals, err := h.view.L2BridgeWithdrawalsByTransactionHash(txHash)
+	if err != nil {
+		http.Error(w, "Internal server error reading withdrawals", http.StatusInternalServerError)
+		h.logger.Error("Unable to read withdrawals from DB", "err", err.Error())
+		return
+	}
+
+	confirmedHeight, err := h.l2ConfirmedHeight()
+	if err != nil {
+		http.Error(w, "Internal server error reading withdrawals", http.StatusInternalServerError)
+		h.logger.Error("Unable to read latest L2 block header from DB", "err", err.Error())
+		return
+	}
+
+	response := newWithdrawalResponse(&database.L2BridgeWithdrawalsResponse{Withdrawals: withdrawals}, confirmedHeight, h.finalizationPeriod, uint64(time.Now().Unix()))
+
+	err = jsonResponse(w, response, http.StatusOK)
+	if err != nil {
+		h.logger.Error("Error writing response", "err", err.Error())
+	}
+}
+
+// L2WithdrawalByMessageHashHandler ... Handles /api/v0/withdrawals/message/{hash} GET requests
+func (h Routes) L2WithdrawalByMessageHashHandler(w http.ResponseWriter, r *http.Request) {
+	hashValue := chi.URLParam(r, "hash")
+	msgHash, err := h.v.ParseValidateHash(hashValue)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		h.logger.Error("Invalid hash param", "param", hashValue, "err", err)
+		return
+	}
+
+	withdrawal, err := h.view.L2BridgeWithdrawalByMessageHash(msgHash)
+	if err != nil {
+		http.Error(w, "Internal server error reading withdrawal", http.StatusInternalServerError)
+		h.logger.Error("Unable to read withdrawal from DB", "err", err.Error())
+		return
+	} else if withdrawal == nil {
+		http.Error(w, "withdrawal not found", http.StatusNotFound)
+		return
+	}
+
+	confirmedHeight, err := h.l2ConfirmedHeight()
+	if err != nil {
+		http.Error(w, "Internal server error reading withdrawal", http.StatusInternalServerError)
+		h.logger.Error("Unable to read latest L2 block header from DB", "err", err.Error())
+		return
+	}
+
+	item := newWithdrawalItem(withdrawal, confirmedHeight, h.finalizationPeriod, uint64(time.Now().Unix()))
+
+	err = jsonResponse(w, item, http.StatusOK)
+	if err != nil {
+		h.logger.Error("Error writing response", "err", err.Error())
+	}
+}
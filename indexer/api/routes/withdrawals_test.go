@@ -0,0 +1,79 @@
+package routes
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/indexer/api/models"
+	"github.com/ethereum-optimism/optimism/indexer/database"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithdrawalStatus(t *testing.T) {
+	u64 := func(v uint64) *uint64 { return &v }
+	period := uint64(100)
+
+	// no output proposal covering the withdrawal
+	withdrawal := &database.L2BridgeWithdrawalWithTransactionHashes{}
+	status, l2OutputIndex, finalizeTimestamp := withdrawalStatus(withdrawal, period, 1000)
+	require.Equal(t, models.WithdrawalStatusInitiated, status)
+	require.Nil(t, l2OutputIndex)
+	require.Zero(t, finalizeTimestamp)
+
+	// finalizable a challenge period after the output proposal at the earliest
+	withdrawal.L2OutputIndex = big.NewInt(1)
+	withdrawal.L2OutputTimestamp = u64(900)
+	status, l2OutputIndex, finalizeTimestamp = withdrawalStatus(withdrawal, period, 1000)
+	require.Equal(t, models.WithdrawalStatusReadyToProve, status)
+	require.Equal(t, big.NewInt(1), l2OutputIndex)
+	require.Equal(t, uint64(1000), finalizeTimestamp)
+
+	// or a challenge period after the proof
+	withdrawal.ProvenL1Timestamp = u64(950)
+	status, _, finalizeTimestamp = withdrawalStatus(withdrawal, period, 1000)
+	require.Equal(t, models.WithdrawalStatusProven, status)
+	require.Equal(t, uint64(1050), finalizeTimestamp)
+
+	status, _, _ = withdrawalStatus(withdrawal, period, 1050)
+	require.Equal(t, models.WithdrawalStatusReadyToFinalize, status)
+
+	withdrawal.FinalizedL1TransactionHash = common.HexToHash("0x123")
+	status, _, _ = withdrawalStatus(withdrawal, period, 1050)
+	require.Equal(t, models.WithdrawalStatusFinalized, status)
+}
+
+func TestWithdrawalStatusProvenOutput(t *testing.T) {
+	u64 := func(v uint64) *uint64 { return &v }
+	period := uint64(100)
+
+	// proven against a later output than the first one covering the withdrawal
+	withdrawal := &database.L2BridgeWithdrawalWithTransactionHashes{
+		L2OutputIndex:           big.NewInt(1),
+		L2OutputTimestamp:       u64(900),
+		ProvenL1Timestamp:       u64(950),
+		ProvenL2OutputIndex:     big.NewInt(2),
+		ProvenL2OutputTimestamp: u64(1000),
+	}
+	status, l2OutputIndex, finalizeTimestamp := withdrawalStatus(withdrawal, period, 1050)
+	require.Equal(t, models.WithdrawalStatusProven, status)
+	require.Equal(t, big.NewInt(2), l2OutputIndex)
+	require.Equal(t, uint64(1100), finalizeTimestamp)
+
+	status, _, _ = withdrawalStatus(withdrawal, period, 1100)
+	require.Equal(t, models.WithdrawalStatusReadyToFinalize, status)
+
+	// the proof no longer counts once the proven output is deleted
+	withdrawal.ProvenL2OutputTimestamp = nil
+	status, l2OutputIndex, finalizeTimestamp = withdrawalStatus(withdrawal, period, 1100)
+	require.Equal(t, models.WithdrawalStatusReadyToProve, status)
+	require.Equal(t, big.NewInt(1), l2OutputIndex)
+	require.Equal(t, uint64(1000), finalizeTimestamp)
+
+	// nor is there an output to prove against again until a replacement is proposed
+	withdrawal.L2OutputIndex, withdrawal.L2OutputTimestamp = nil, nil
+	status, l2OutputIndex, finalizeTimestamp = withdrawalStatus(withdrawal, period, 1100)
+	require.Equal(t, models.WithdrawalStatusInitiated, status)
+	require.Nil(t, l2OutputIndex)
+	require.Zero(t, finalizeTimestamp)
+}
@@ -48,6 +48,9 @@ func WithTimeout(t time.Duration) Option {
 type Config struct {
 	PaginationLimit int
 	BaseURL         string
+
+	// L2ChainID ... Selects the chain served by an indexer of multiple L2 chains. Defaults to its primary chain
+	L2ChainID uint
 }
 
 // Client ... Indexer client struct
@@ -126,7 +129,7 @@ func (c *Client) HealthCheck() error {
 func (c *Client) GetDepositsByAddress(l1Address common.Address, cursor string) (*models.DepositResponse, error) {
 	var dResponse *models.DepositResponse
 	url := c.cfg.BaseURL + api.DepositsPath + l1Address.String() + urlParams
-	endpoint := fmt.Sprintf(url, cursor, c.cfg.PaginationLimit)
+	endpoint := fmt.Sprintf(url, cursor, c.cfg.PaginationLimit) + c.chainParam()
 
 	resp, err := c.doRecordRequest(deposits, endpoint)
 	if err != nil {
@@ -192,7 +195,7 @@ func (c *Client) GetWithdrawalsByAddress(l2Address common.Address, cursor string
 	var wResponse *models.WithdrawalResponse
 	url := c.cfg.BaseURL + api.WithdrawalsPath + l2Address.String() + urlParams
 
-	endpoint := fmt.Sprintf(url, cursor, c.cfg.PaginationLimit)
+	endpoint := fmt.Sprintf(url, cursor, c.cfg.PaginationLimit) + c.chainParam()
 	resp, err := c.doRecordRequest(withdrawals, endpoint)
 	if err != nil {
 		return nil, err
@@ -204,3 +207,11 @@ func (c *Client) GetWithdrawalsByAddress(l2Address common.Address, cursor string
 
 	return wResponse, nil
 }
+
+// chainParam ... Returns the query param selecting the configured L2 chain, if any
+func (c *Client) chainParam() string {
+	if c.cfg.L2ChainID == 0 {
+		return ""
+	}
+	return fmt.Sprintf("&chainId=%d", c.cfg.L2ChainID)
+}
@@ -43,7 +43,18 @@ func runIndexer(ctx *cli.Context) error {
 	}
 	defer db.Close()
 
-	indexer, err := indexer.NewIndexer(log, db, cfg.Chain, cfg.RPCs, cfg.HTTPServer, cfg.MetricsServer)
+	l2ChainDBs := make([]indexer.L2ChainDB, len(cfg.L2Chains))
+	for i := range cfg.L2Chains {
+		l2ChainDB, err := database.NewL2ChainDB(log, cfg.DB, cfg.L2Chains[i].L2ChainID)
+		if err != nil {
+			log.Error("failed to connect to l2 chain database", "l2_chain_id", cfg.L2Chains[i].L2ChainID, "err", err)
+			return err
+		}
+		defer l2ChainDB.Close()
+		l2ChainDBs[i] = indexer.L2ChainDB{Config: cfg.L2Chains[i], DB: l2ChainDB}
+	}
+
+	indexer, err := indexer.NewIndexer(log, db, cfg.Chain, cfg.RPCs, cfg.HTTPServer, cfg.MetricsServer, l2ChainDBs)
 	if err != nil {
 		log.Error("failed to create indexer", "err", err)
 		return err
@@ -68,7 +79,24 @@ func runApi(ctx *cli.Context) error {
 	}
 	defer db.Close()
 
-	api := api.NewApi(log, db.BridgeTransfers, cfg.HTTPServer, cfg.MetricsServer)
+	l2Chains := make([]api.L2ChainViews, len(cfg.L2Chains))
+	for i := range cfg.L2Chains {
+		l2ChainDB, err := database.NewL2ChainDB(log, cfg.DB, cfg.L2Chains[i].L2ChainID)
+		if err != nil {
+			log.Error("failed to connect to l2 chain database", "l2_chain_id", cfg.L2Chains[i].L2ChainID, "err", err)
+			return err
+		}
+		defer l2ChainDB.Close()
+		l2Chains[i] = api.L2ChainViews{
+			ChainConfig:           cfg.L2Chains[i].ChainConfig,
+			BridgeTransfers:       l2ChainDB.BridgeTransfers,
+			ERC721BridgeTransfers: l2ChainDB.ERC721BridgeTransfers,
+			BridgeEvents:          l2ChainDB.BridgeEvents,
+			Blocks:                l2ChainDB.Blocks,
+		}
+	}
+
+	api := api.NewApi(log, db.BridgeTransfers, db.ERC721BridgeTransfers, db.BridgeEvents, db.Blocks, cfg.Chain, cfg.HTTPServer, cfg.MetricsServer, l2Chains)
 	return api.Run(ctx.Context)
 }
 
@@ -89,7 +117,25 @@ func runMigrations(ctx *cli.Context) error {
 	}
 	defer db.Close()
 
-	return db.ExecuteSQLMigration(migrationsDir)
+	if err := db.ExecuteSQLMigration(migrationsDir); err != nil {
+		return err
+	}
+
+	// The schema of each additional L2 chain references the shared L1 state migrated above
+	for i := range cfg.L2Chains {
+		l2ChainDB, err := database.NewL2ChainDB(log, cfg.DB, cfg.L2Chains[i].L2ChainID)
+		if err != nil {
+			log.Error("failed to connect to l2 chain database", "l2_chain_id", cfg.L2Chains[i].L2ChainID, "err", err)
+			return err
+		}
+		defer l2ChainDB.Close()
+
+		if err := l2ChainDB.ExecuteSQLMigration(migrationsDir); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 func newCli(GitCommit string, GitDate string) *cli.App {
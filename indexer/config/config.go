@@ -1,6 +1,7 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"reflect"
@@ -15,6 +16,12 @@ const (
 	// default to 5 seconds
 	defaultLoopInterval     = 5000
 	defaultHeaderBufferSize = 500
+
+	// default to the 7 day challenge period of production chains
+	defaultFinalizationPeriodSeconds = 604800
+
+	// default to the max number of L1 blocks indexed at once by the bridge processor
+	defaultBackfillRangeSize = 10_000
 )
 
 // In the future, presets can just be onchain config and fetched on initialization
@@ -26,6 +33,9 @@ type Config struct {
 	DB            DBConfig     `toml:"db"`
 	HTTPServer    ServerConfig `toml:"http"`
 	MetricsServer ServerConfig `toml:"metrics"`
+
+	// Additional L2 chains settling on the same L1, indexed alongside `Chain`
+	L2Chains []L2ChainConfig `toml:"l2-chains"`
 }
 
 // L1Contracts configures deployed contracts
@@ -100,6 +110,9 @@ type ChainConfig struct {
 	Preset           int
 	L1StartingHeight uint `toml:"l1-starting-height"`
 
+	// Identifies the chain when multiple L2 chains are indexed. Defaults to the preset
+	L2ChainID uint `toml:"l2-chain-id"`
+
 	L1Contracts L1Contracts `toml:"l1-contracts"`
 	L2Contracts L2Contracts `toml:"-"`
 
@@ -107,8 +120,8 @@ type ChainConfig struct {
 	L1BedrockStartingHeight uint `toml:"-"`
 	L2BedrockStartingHeight uint `toml:"-"`
 
-	// These configuration options will be removed once
-	// native reorg handling is implemented
+	// The number of blocks behind the indexed head after which data is
+	// reported as confirmed. Reorgs of unconfirmed blocks are rolled back
 	L1ConfirmationDepth uint `toml:"l1-confirmation-depth"`
 	L2ConfirmationDepth uint `toml:"l2-confirmation-depth"`
 
@@ -117,6 +130,24 @@ type ChainConfig struct {
 
 	L1HeaderBufferSize uint `toml:"l1-header-buffer-size"`
 	L2HeaderBufferSize uint `toml:"l2-header-buffer-size"`
+
+	// The challenge period of output proposals, after which proven withdrawals can be finalized
+	FinalizationPeriodSeconds uint `toml:"finalization-period-seconds"`
+
+	// Concurrently backfill unindexed history with the number of workers, splitting it into
+	// ranges of L1 blocks. When unset, history is indexed sequentially
+	BackfillWorkers   uint `toml:"backfill-workers"`
+	BackfillRangeSize uint `toml:"backfill-range-size"`
+}
+
+// L2ChainConfig configures an additional L2 chain. The L1 options of the ChainConfig
+// are shared by all chains and only taken from the primary `Chain`.
+//
+// NOTE: L1 history is only extracted for the contracts configured at the time. Adding a
+// chain to an indexer with indexed state requires re-indexing from the L1 starting height
+type L2ChainConfig struct {
+	ChainConfig
+	L2RPC string `toml:"l2-rpc"`
 }
 
 // RPCsConfig configures the RPC urls
@@ -189,27 +220,107 @@ func LoadConfig(log log.Logger, path string) (Config, error) {
 	}
 
 	// Defaults for any unset options
+	setChainConfigDefaults(log, &cfg.Chain)
+
+	// Additional L2 chains, each with their own preset
+	l2Chains, err := loadL2ChainConfigs(log, string(data))
+	if err != nil {
+		return cfg, err
+	}
+	cfg.L2Chains = l2Chains
+
+	chainIDs := make(map[uint]struct{})
+	if cfg.Chain.L2ChainID != 0 {
+		chainIDs[cfg.Chain.L2ChainID] = struct{}{}
+	} else if len(cfg.L2Chains) > 0 {
+		return cfg, errors.New("l2-chain-id must be configured for the chain when indexing multiple l2 chains")
+	}
+	for i := range cfg.L2Chains {
+		if cfg.L2Chains[i].L2ChainID == 0 {
+			return cfg, fmt.Errorf("l2-chain-id must be configured for l2 chain %d", i)
+		} else if _, ok := chainIDs[cfg.L2Chains[i].L2ChainID]; ok {
+			return cfg, fmt.Errorf("duplicate l2 chain: %d", cfg.L2Chains[i].L2ChainID)
+		}
+		chainIDs[cfg.L2Chains[i].L2ChainID] = struct{}{}
+	}
+
+	log.Info("loaded config")
+	return cfg, nil
+}
 
-	if cfg.Chain.L1PollingInterval == 0 {
+// loadL2ChainConfigs loads the additional L2 chains. Like the primary chain, precedence is
+// given to the config file vs the preset of each chain
+func loadL2ChainConfigs(log log.Logger, data string) ([]L2ChainConfig, error) {
+	var primitives struct {
+		L2Chains []toml.Primitive `toml:"l2-chains"`
+	}
+	md, err := toml.Decode(data, &primitives)
+	if err != nil {
+		log.Error("failed to decode config file", "err", err)
+		return nil, err
+	}
+
+	l2Chains := make([]L2ChainConfig, len(primitives.L2Chains))
+	for i := range primitives.L2Chains {
+		var l2Chain L2ChainConfig
+		if err := md.PrimitiveDecode(primitives.L2Chains[i], &l2Chain); err != nil {
+			return nil, err
+		}
+
+		if l2Chain.Preset != 0 {
+			preset, ok := Presets[l2Chain.Preset]
+			if !ok {
+				return nil, fmt.Errorf("unknown preset: %d", l2Chain.Preset)
+			}
+
+			log.Info("detected l2 chain preset", "preset", l2Chain.Preset, "name", preset.Name)
+			l2Chain.ChainConfig = preset.ChainConfig
+			if err := md.PrimitiveDecode(primitives.L2Chains[i], &l2Chain); err != nil {
+				return nil, err
+			}
+		}
+
+		l2Chain.L2Contracts = L2ContractsFromPredeploys()
+		setChainConfigDefaults(log.New("l2_chain", i), &l2Chain.ChainConfig)
+		l2Chains[i] = l2Chain
+	}
+
+	return l2Chains, nil
+}
+
+// setChainConfigDefaults sets the defaults for any unset options
+func setChainConfigDefaults(log log.Logger, chain *ChainConfig) {
+	if chain.L2ChainID == 0 && chain.Preset > 0 {
+		chain.L2ChainID = uint(chain.Preset)
+	}
+
+	if chain.L1PollingInterval == 0 {
 		log.Info("setting default L1 polling interval", "interval", defaultLoopInterval)
-		cfg.Chain.L1PollingInterval = defaultLoopInterval
+		chain.L1PollingInterval = defaultLoopInterval
 	}
 
-	if cfg.Chain.L2PollingInterval == 0 {
+	if chain.L2PollingInterval == 0 {
 		log.Info("setting default L2 polling interval", "interval", defaultLoopInterval)
-		cfg.Chain.L2PollingInterval = defaultLoopInterval
+		chain.L2PollingInterval = defaultLoopInterval
 	}
 
-	if cfg.Chain.L1HeaderBufferSize == 0 {
+	if chain.L1HeaderBufferSize == 0 {
 		log.Info("setting default L1 header buffer", "size", defaultHeaderBufferSize)
-		cfg.Chain.L1HeaderBufferSize = defaultHeaderBufferSize
+		chain.L1HeaderBufferSize = defaultHeaderBufferSize
 	}
 
-	if cfg.Chain.L2HeaderBufferSize == 0 {
+	if chain.L2HeaderBufferSize == 0 {
 		log.Info("setting default L2 header buffer", "size", defaultHeaderBufferSize)
-		cfg.Chain.L2HeaderBufferSize = defaultHeaderBufferSize
+		chain.L2HeaderBufferSize = defaultHeaderBufferSize
 	}
 
-	log.Info("loaded config")
-	return cfg, nil
+	if chain.FinalizationPeriodSeconds == 0 {
+		log.Info("setting default finalization period", "seconds", defaultFinalizationPeriodSeconds)
+		chain.FinalizationPeriodSeconds = defaultFinalizationPeriodSeconds
+	}
+
+	if chain.BackfillRangeSize == 0 {
+		log.Info("setting default backfill range size", "size", defaultBackfillRangeSize)
+		chain.BackfillRangeSize = defaultBackfillRangeSize
+	}
 }
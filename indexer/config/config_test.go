@@ -180,6 +180,47 @@ func TestLoadConfigPollingValues(t *testing.T) {
 	require.Equal(t, conf.Chain.L2HeaderBufferSize, uint(105))
 }
 
+func TestLoadConfigBackfillValues(t *testing.T) {
+	tmpfile, err := os.CreateTemp("", "test_backfill_values.toml")
+	require.NoError(t, err)
+	defer os.Remove(tmpfile.Name())
+	defer tmpfile.Close()
+
+	testData := `
+	[chain]
+	backfill-workers = 4`
+
+	data := []byte(testData)
+	err = os.WriteFile(tmpfile.Name(), data, 0644)
+	require.NoError(t, err)
+	defer os.Remove(tmpfile.Name())
+
+	err = tmpfile.Close()
+	require.NoError(t, err)
+
+	logger := testlog.Logger(t, log.LvlInfo)
+	conf, err := LoadConfig(logger, tmpfile.Name())
+	require.NoError(t, err)
+
+	require.Equal(t, conf.Chain.BackfillWorkers, uint(4))
+	require.Equal(t, conf.Chain.BackfillRangeSize, uint(defaultBackfillRangeSize))
+
+	// an explicit range size is kept
+	testData = `
+	[chain]
+	backfill-workers = 4
+	backfill-range-size = 500`
+
+	err = os.WriteFile(tmpfile.Name(), []byte(testData), 0644)
+	require.NoError(t, err)
+
+	conf, err = LoadConfig(logger, tmpfile.Name())
+	require.NoError(t, err)
+
+	require.Equal(t, conf.Chain.BackfillWorkers, uint(4))
+	require.Equal(t, conf.Chain.BackfillRangeSize, uint(500))
+}
+
 func TestLoadedConfigPresetPrecendence(t *testing.T) {
 	tmpfile, err := os.CreateTemp("", "test_bad_preset.toml")
 	require.NoError(t, err)
@@ -225,6 +266,113 @@ func TestLoadedConfigPresetPrecendence(t *testing.T) {
 	require.Equal(t, Presets[10].ChainConfig.L1Contracts.AddressManager, conf.Chain.L1Contracts.AddressManager)
 }
 
+func TestLoadConfigL2Chains(t *testing.T) {
+	tmpfile, err := os.CreateTemp("", "test_l2_chains.toml")
+	require.NoError(t, err)
+	defer os.Remove(tmpfile.Name())
+	defer tmpfile.Close()
+
+	testData := `
+		[chain]
+		preset = 10
+
+		[rpcs]
+		l1-rpc = "https://l1.example.com"
+		l2-rpc = "https://l2.example.com"
+
+		[[l2-chains]]
+		preset = 8453
+		l2-rpc = "https://base.example.com"
+		l2-polling-interval = 2000
+
+		[[l2-chains]]
+		l2-chain-id = 1234
+		l2-rpc = "https://custom.example.com"
+		l1-starting-height = 100
+
+		[l2-chains.l1-contracts]
+		optimism-portal = "0x0000000000000000000000000000000000000001"
+	`
+
+	data := []byte(testData)
+	err = os.WriteFile(tmpfile.Name(), data, 0644)
+	require.NoError(t, err)
+	defer os.Remove(tmpfile.Name())
+
+	err = tmpfile.Close()
+	require.NoError(t, err)
+
+	logger := testlog.Logger(t, log.LvlInfo)
+	conf, err := LoadConfig(logger, tmpfile.Name())
+	require.NoError(t, err)
+
+	require.Equal(t, uint(10), conf.Chain.L2ChainID)
+	require.Len(t, conf.L2Chains, 2)
+
+	// preset is used but does not overwrite config
+	base := conf.L2Chains[0]
+	require.Equal(t, uint(8453), base.L2ChainID)
+	require.Equal(t, "https://base.example.com", base.L2RPC)
+	require.Equal(t, uint(2000), base.L2PollingInterval)
+	require.Equal(t, Presets[8453].ChainConfig.L1Contracts, base.L1Contracts)
+	require.Equal(t, L2ContractsFromPredeploys(), base.L2Contracts)
+
+	custom := conf.L2Chains[1]
+	require.Equal(t, uint(1234), custom.L2ChainID)
+	require.Equal(t, uint(100), custom.L1StartingHeight)
+	require.Equal(t, common.HexToAddress("0x0000000000000000000000000000000000000001"), custom.L1Contracts.OptimismPortalProxy)
+	require.Equal(t, uint(defaultLoopInterval), custom.L2PollingInterval)
+	require.Equal(t, uint(defaultFinalizationPeriodSeconds), custom.FinalizationPeriodSeconds)
+}
+
+func TestLoadConfigL2ChainsWithoutChainID(t *testing.T) {
+	testCases := []struct {
+		name string
+		data string
+	}{
+		{
+			name: "primary chain",
+			data: `
+			[[l2-chains]]
+			preset = 8453`,
+		},
+		{
+			name: "l2 chain",
+			data: `
+			[chain]
+			preset = 10
+
+			[[l2-chains]]
+			l2-rpc = "https://custom.example.com"`,
+		},
+		{
+			name: "duplicate chain",
+			data: `
+			[chain]
+			preset = 10
+
+			[[l2-chains]]
+			l2-chain-id = 10`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tmpfile, err := os.CreateTemp("", "test_l2_chains.toml")
+			require.NoError(t, err)
+			defer os.Remove(tmpfile.Name())
+			defer tmpfile.Close()
+
+			err = os.WriteFile(tmpfile.Name(), []byte(tc.data), 0644)
+			require.NoError(t, err)
+
+			logger := testlog.Logger(t, log.LvlInfo)
+			_, err = LoadConfig(logger, tmpfile.Name())
+			require.Error(t, err)
+		})
+	}
+}
+
 func TestLocalDevnet(t *testing.T) {
 	tmpfile, err := os.CreateTemp("", "test_user_values.toml")
 	require.NoError(t, err)
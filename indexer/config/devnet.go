@@ -39,7 +39,7 @@ func DevnetPreset() (*Preset, error) {
 
 	return &Preset{
 		Name:        "Local Devnet",
-		ChainConfig: ChainConfig{Preset: DevnetPresetId, L1Contracts: l1Contracts},
+		ChainConfig: ChainConfig{Preset: DevnetPresetId, L1Contracts: l1Contracts, FinalizationPeriodSeconds: 2},
 	}, nil
 }
 
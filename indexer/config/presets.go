@@ -30,9 +30,10 @@ var Presets = map[int]Preset{
 				LegacyCanonicalTransactionChain: common.HexToAddress("0x5e4e65926ba27467555eb562121fac00d24e9dd2"),
 				LegacyStateCommitmentChain:      common.HexToAddress("0xBe5dAb4A2e9cd0F27300dB4aB94BeE3A233AEB19"),
 			},
-			L1StartingHeight:        13596466,
-			L1BedrockStartingHeight: 17422590,
-			L2BedrockStartingHeight: 105235063,
+			L1StartingHeight:          13596466,
+			L1BedrockStartingHeight:   17422590,
+			L2BedrockStartingHeight:   105235063,
+			FinalizationPeriodSeconds: 604800,
 		},
 	},
 	420: {
@@ -52,9 +53,10 @@ var Presets = map[int]Preset{
 				LegacyCanonicalTransactionChain: common.HexToAddress("0x607F755149cFEB3a14E1Dc3A4E2450Cde7dfb04D"),
 				LegacyStateCommitmentChain:      common.HexToAddress("0x9c945aC97Baf48cB784AbBB61399beB71aF7A378"),
 			},
-			L1StartingHeight:        7017096,
-			L1BedrockStartingHeight: 8300214,
-			L2BedrockStartingHeight: 4061224,
+			L1StartingHeight:          7017096,
+			L1BedrockStartingHeight:   8300214,
+			L2BedrockStartingHeight:   4061224,
+			FinalizationPeriodSeconds: 12,
 		},
 	},
 	11155420: {
@@ -70,7 +72,8 @@ var Presets = map[int]Preset{
 				L1StandardBridgeProxy:       common.HexToAddress("0xFBb0621E0B23b5478B630BD55a5f21f67730B0F1"),
 				L1ERC721BridgeProxy:         common.HexToAddress("0xd83e03D576d23C9AEab8cC44Fa98d058D2176D1f"),
 			},
-			L1StartingHeight: 4071408,
+			L1StartingHeight:          4071408,
+			FinalizationPeriodSeconds: 12,
 		},
 	},
 	8453: {
@@ -86,7 +89,8 @@ var Presets = map[int]Preset{
 				L1StandardBridgeProxy:       common.HexToAddress("0x3154Cf16ccdb4C6d922629664174b904d80F2C35"),
 				L1ERC721BridgeProxy:         common.HexToAddress("0x608d94945A64503E642E6370Ec598e519a2C1E53"),
 			},
-			L1StartingHeight: 17481768,
+			L1StartingHeight:          17481768,
+			FinalizationPeriodSeconds: 604800,
 		},
 	},
 	84531: {
@@ -102,7 +106,8 @@ var Presets = map[int]Preset{
 				L1StandardBridgeProxy:       common.HexToAddress("0xfA6D8Ee5BE770F84FC001D098C4bD604Fe01284a"),
 				L1ERC721BridgeProxy:         common.HexToAddress("0x5E0c967457347D5175bF82E8CCCC6480FCD7e568"),
 			},
-			L1StartingHeight: 8410981,
+			L1StartingHeight:          8410981,
+			FinalizationPeriodSeconds: 12,
 		},
 	},
 	84532: {
@@ -118,7 +123,8 @@ var Presets = map[int]Preset{
 				L1StandardBridgeProxy:       common.HexToAddress("0xfd0Bf71F60660E2f608ed56e1659C450eB113120"),
 				L1ERC721BridgeProxy:         common.HexToAddress("0x21eFD066e581FA55Ef105170Cc04d74386a09190"),
 			},
-			L1StartingHeight: 4370868,
+			L1StartingHeight:          4370868,
+			FinalizationPeriodSeconds: 12,
 		},
 	},
 	7777777: {
@@ -134,7 +140,8 @@ var Presets = map[int]Preset{
 				L1StandardBridgeProxy:       common.HexToAddress("0x3e2Ea9B92B7E48A52296fD261dc26fd995284631"),
 				L1ERC721BridgeProxy:         common.HexToAddress("0x83A4521A3573Ca87f3a971B169C5A0E1d34481c3"),
 			},
-			L1StartingHeight: 17473923,
+			L1StartingHeight:          17473923,
+			FinalizationPeriodSeconds: 604800,
 		},
 	},
 	999: {
@@ -150,7 +157,8 @@ var Presets = map[int]Preset{
 				L1StandardBridgeProxy:       common.HexToAddress("0x7CC09AC2452D6555d5e0C213Ab9E2d44eFbFc956"),
 				L1ERC721BridgeProxy:         common.HexToAddress("0x57C1C6b596ce90C0e010c358DD4Aa052404bB70F"),
 			},
-			L1StartingHeight: 8942381,
+			L1StartingHeight:          8942381,
+			FinalizationPeriodSeconds: 30,
 		},
 	},
 	424: {
@@ -166,7 +174,8 @@ var Presets = map[int]Preset{
 				L1StandardBridgeProxy:       common.HexToAddress("0xD0204B9527C1bA7bD765Fa5CCD9355d38338272b"),
 				L1ERC721BridgeProxy:         common.HexToAddress("0xaFF0F8aaB6Cc9108D34b3B8423C76d2AF434d115"),
 			},
-			L1StartingHeight: 17672702,
+			L1StartingHeight:          17672702,
+			FinalizationPeriodSeconds: 604800,
 		},
 	},
 	58008: {
@@ -182,7 +191,8 @@ var Presets = map[int]Preset{
 				L1StandardBridgeProxy:       common.HexToAddress("0xFaE6abCAF30D23e233AC7faF747F2fC3a5a6Bfa3"),
 				L1ERC721BridgeProxy:         common.HexToAddress("0xBA8397B6f255618D5985d0fB427D8c0496F3a5FA"),
 			},
-			L1StartingHeight: 17672702,
+			L1StartingHeight:          17672702,
+			FinalizationPeriodSeconds: 604800,
 		},
 	},
 }
@@ -10,6 +10,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 /**
@@ -48,10 +49,12 @@ type BlocksView interface {
 	L1BlockHeader(common.Hash) (*L1BlockHeader, error)
 	L1BlockHeaderWithFilter(BlockHeader) (*L1BlockHeader, error)
 	L1LatestBlockHeader() (*L1BlockHeader, error)
+	L1LatestBlockHeaderUpTo(*big.Int) (*L1BlockHeader, error)
 
 	L2BlockHeader(common.Hash) (*L2BlockHeader, error)
 	L2BlockHeaderWithFilter(BlockHeader) (*L2BlockHeader, error)
 	L2LatestBlockHeader() (*L2BlockHeader, error)
+	L2LatestBlockHeaderUpTo(*big.Int) (*L2BlockHeader, error)
 
 	LatestObservedEpoch(*big.Int, uint64) (*Epoch, error)
 }
@@ -61,6 +64,9 @@ type BlocksDB interface {
 
 	StoreL1BlockHeaders([]L1BlockHeader) error
 	StoreL2BlockHeaders([]L2BlockHeader) error
+
+	DeleteL1BlockHeadersAfter(*big.Int, []string) error
+	DeleteL2BlockHeadersAfter(*big.Int) error
 }
 
 /**
@@ -113,6 +119,48 @@ func (db *blocksDB) L1LatestBlockHeader() (*L1BlockHeader, error) {
 	return &l1Header, nil
 }
 
+// L1LatestBlockHeaderUpTo returns the latest indexed L1 header with a height less than or equal
+// to the supplied height. Since L1 blocks are only indexed if they have emitted logs or end a
+// batch, this is not necessarily the header at that height.
+func (db *blocksDB) L1LatestBlockHeaderUpTo(height *big.Int) (*L1BlockHeader, error) {
+	var l1Header L1BlockHeader
+	result := db.gorm.Where("number <= ?", height).Order("number DESC").Take(&l1Header)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, result.Error
+	}
+
+	return &l1Header, nil
+}
+
+// DeleteL1BlockHeadersAfter removes all indexed L1 headers above the supplied height. Contract
+// events and the bridge state derived from them are removed alongside the headers, and
+// withdrawals proven or finalized in the removed blocks are reverted to their prior state.
+// The L1 state is shared by all indexed L2 chains, reverting the primary chain as well as
+// the additional chains partitioned into the supplied schemas.
+func (db *blocksDB) DeleteL1BlockHeadersAfter(height *big.Int, l2ChainSchemas []string) error {
+	// The event references are cleared by the `ON DELETE SET NULL` constraints. The outcome of
+	// a finalization is not a reference and must be cleared alongside
+	schemas := append([]string{"public"}, l2ChainSchemas...)
+	for _, schema := range schemas {
+		removedEvents := db.gorm.Table("l1_contract_events").Select("l1_contract_events.guid")
+		removedEvents = removedEvents.Joins("INNER JOIN l1_block_headers ON l1_block_headers.hash = l1_contract_events.block_hash")
+		removedEvents = removedEvents.Where("l1_block_headers.number > ?", height)
+
+		withdrawals := db.gorm.Table("?.l2_transaction_withdrawals", clause.Table{Name: schema})
+		result := withdrawals.Where("finalized_l1_event_guid IN (?)", removedEvents).Update("succeeded", nil)
+		if result.Error != nil {
+			return result.Error
+		}
+	}
+
+	result := db.gorm.Where("number > ?", height).Delete(&L1BlockHeader{})
+	return result.Error
+}
+
 // L2
 
 func (db *blocksDB) StoreL2BlockHeaders(headers []L2BlockHeader) error {
@@ -150,6 +198,28 @@ func (db *blocksDB) L2LatestBlockHeader() (*L2BlockHeader, error) {
 	return &l2Header, nil
 }
 
+// L2LatestBlockHeaderUpTo returns the latest indexed L2 header with a height less than or equal
+// to the supplied height.
+func (db *blocksDB) L2LatestBlockHeaderUpTo(height *big.Int) (*L2BlockHeader, error) {
+	var l2Header L2BlockHeader
+	result := db.gorm.Where("number <= ?", height).Order("number DESC").Take(&l2Header)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	return &l2Header, nil
+}
+
+// DeleteL2BlockHeadersAfter removes all indexed L2 headers above the supplied height. Contract
+// events and the bridge state derived from them are removed alongside the headers.
+func (db *blocksDB) DeleteL2BlockHeadersAfter(height *big.Int) error {
+	result := db.gorm.Where("number > ?", height).Delete(&L2BlockHeader{})
+	return result.Error
+}
+
 // Auxiliary Methods on both L1 & L2
 
 type Epoch struct {
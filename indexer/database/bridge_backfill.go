@@ -0,0 +1,85 @@
+package database
+
+import (
+	"math/big"
+
+	"gorm.io/gorm"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+/**
+ * Types
+ */
+
+// BridgeBackfillRange is a checkpointed range of epochs indexed by a historical backfill. The block
+// hashes are those of the epoch ending the range.
+type BridgeBackfillRange struct {
+	L1FromHeight *big.Int `gorm:"primaryKey;serializer:u256"`
+	L1ToHeight   *big.Int `gorm:"serializer:u256"`
+	L2FromHeight *big.Int `gorm:"serializer:u256"`
+	L2ToHeight   *big.Int `gorm:"serializer:u256"`
+
+	L1BlockHash common.Hash `gorm:"serializer:bytes"`
+	L2BlockHash common.Hash `gorm:"serializer:bytes"`
+
+	InitiatedIndexed bool
+	FinalizedIndexed bool
+}
+
+type BridgeBackfillView interface {
+	BridgeBackfillRanges() ([]BridgeBackfillRange, error)
+}
+
+type BridgeBackfillDB interface {
+	BridgeBackfillView
+
+	StoreBridgeBackfillRanges([]BridgeBackfillRange) error
+	MarkBridgeBackfillRangeInitiated(*big.Int) error
+	MarkBridgeBackfillRangeFinalized(*big.Int) error
+	DeleteBridgeBackfillRanges() error
+}
+
+/**
+ * Implementation
+ */
+
+type bridgeBackfillDB struct {
+	gorm *gorm.DB
+}
+
+func newBridgeBackfillDB(db *gorm.DB) BridgeBackfillDB {
+	return &bridgeBackfillDB{gorm: db}
+}
+
+func (db *bridgeBackfillDB) StoreBridgeBackfillRanges(ranges []BridgeBackfillRange) error {
+	result := db.gorm.CreateInBatches(&ranges, batchInsertSize)
+	return result.Error
+}
+
+// BridgeBackfillRanges retrieves the ranges of an ongoing backfill, ordered by height
+func (db *bridgeBackfillDB) BridgeBackfillRanges() ([]BridgeBackfillRange, error) {
+	ranges := []BridgeBackfillRange{}
+	result := db.gorm.Order("l1_from_height ASC").Find(&ranges)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return ranges, nil
+}
+
+func (db *bridgeBackfillDB) MarkBridgeBackfillRangeInitiated(l1FromHeight *big.Int) error {
+	result := db.gorm.Model(&BridgeBackfillRange{}).Where("l1_from_height = ?", l1FromHeight).Update("initiated_indexed", true)
+	return result.Error
+}
+
+func (db *bridgeBackfillDB) MarkBridgeBackfillRangeFinalized(l1FromHeight *big.Int) error {
+	result := db.gorm.Model(&BridgeBackfillRange{}).Where("l1_from_height = ?", l1FromHeight).Update("finalized_indexed", true)
+	return result.Error
+}
+
+// DeleteBridgeBackfillRanges removes all ranges once the backfill has completed
+func (db *bridgeBackfillDB) DeleteBridgeBackfillRanges() error {
+	result := db.gorm.Where("1 = 1").Delete(&BridgeBackfillRange{})
+	return result.Error
+}
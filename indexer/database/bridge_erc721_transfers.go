@@ -0,0 +1,287 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+/**
+ * Types
+ */
+
+type ERC721BridgeTransfer struct {
+	CrossDomainMessageHash *common.Hash `gorm:"serializer:bytes"`
+
+	TokenPair   TokenPair      `gorm:"embedded"`
+	FromAddress common.Address `gorm:"serializer:bytes"`
+	ToAddress   common.Address `gorm:"serializer:bytes"`
+	TokenID     *big.Int       `gorm:"serializer:u256"`
+	Data        Bytes          `gorm:"serializer:bytes"`
+	Timestamp   uint64
+}
+
+type L1ERC721BridgeDeposit struct {
+	ERC721BridgeTransfer  `gorm:"embedded"`
+	TransactionSourceHash common.Hash `gorm:"primaryKey;serializer:bytes"`
+}
+
+type L1ERC721BridgeDepositWithTransactionHashes struct {
+	L1ERC721BridgeDeposit L1ERC721BridgeDeposit `gorm:"embedded"`
+
+	L1BlockHash       common.Hash `gorm:"serializer:bytes"`
+	L1BlockNumber     *big.Int    `gorm:"serializer:u256"`
+	L1TransactionHash common.Hash `gorm:"serializer:bytes"`
+	L2TransactionHash common.Hash `gorm:"serializer:bytes"`
+}
+
+type L2ERC721BridgeWithdrawal struct {
+	ERC721BridgeTransfer      `gorm:"embedded"`
+	TransactionWithdrawalHash common.Hash `gorm:"primaryKey;serializer:bytes"`
+}
+
+type L2ERC721BridgeWithdrawalWithTransactionHashes struct {
+	L2ERC721BridgeWithdrawal L2ERC721BridgeWithdrawal `gorm:"embedded"`
+	L2TransactionHash        common.Hash              `gorm:"serializer:bytes"`
+	L2BlockHash              common.Hash              `gorm:"serializer:bytes"`
+	L2BlockNumber            *big.Int                 `gorm:"serializer:u256"`
+
+	ProvenL1TransactionHash    common.Hash `gorm:"serializer:bytes"`
+	ProvenL1Timestamp          *uint64
+	FinalizedL1TransactionHash common.Hash `gorm:"serializer:bytes"`
+
+	// The first output proposal that the withdrawal can be proven against
+	L2OutputIndex     *big.Int `gorm:"serializer:u256"`
+	L2OutputTimestamp *uint64
+
+	// The output proposal the withdrawal was proven against, if known. The timestamp
+	// is empty once the output has been deleted, requiring the withdrawal to be proven again
+	ProvenL2OutputIndex     *big.Int `gorm:"serializer:u256"`
+	ProvenL2OutputTimestamp *uint64
+}
+
+type ERC721BridgeTransfersView interface {
+	L1ERC721BridgeDeposit(common.Hash) (*L1ERC721BridgeDeposit, error)
+	L1ERC721BridgeDepositWithFilter(ERC721BridgeTransfer) (*L1ERC721BridgeDeposit, error)
+	L1ERC721BridgeDepositsByAddress(common.Address, string, int) (*L1ERC721BridgeDepositsResponse, error)
+
+	L2ERC721BridgeWithdrawal(common.Hash) (*L2ERC721BridgeWithdrawal, error)
+	L2ERC721BridgeWithdrawalWithFilter(ERC721BridgeTransfer) (*L2ERC721BridgeWithdrawal, error)
+	L2ERC721BridgeWithdrawalsByAddress(common.Address, string, int) (*L2ERC721BridgeWithdrawalsResponse, error)
+}
+
+type ERC721BridgeTransfersDB interface {
+	ERC721BridgeTransfersView
+
+	StoreL1ERC721BridgeDeposits([]L1ERC721BridgeDeposit) error
+	StoreL2ERC721BridgeWithdrawals([]L2ERC721BridgeWithdrawal) error
+}
+
+/**
+ * Implementation
+ */
+
+type erc721BridgeTransfersDB struct {
+	gorm *gorm.DB
+}
+
+func newERC721BridgeTransfersDB(db *gorm.DB) ERC721BridgeTransfersDB {
+	return &erc721BridgeTransfersDB{gorm: db}
+}
+
+/**
+ * NFTs Bridged (Deposited) from L1
+ */
+
+// StoreL1ERC721BridgeDeposits stores the supplied nft deposits, skipping those already stored such that
+// the bridge processor can safely re-process epochs from its latest bridge markers
+func (db *erc721BridgeTransfersDB) StoreL1ERC721BridgeDeposits(deposits []L1ERC721BridgeDeposit) error {
+	result := db.gorm.Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(&deposits, batchInsertSize)
+	return result.Error
+}
+
+func (db *erc721BridgeTransfersDB) L1ERC721BridgeDeposit(txSourceHash common.Hash) (*L1ERC721BridgeDeposit, error) {
+	var deposit L1ERC721BridgeDeposit
+	result := db.gorm.Where(&L1ERC721BridgeDeposit{TransactionSourceHash: txSourceHash}).Take(&deposit)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	return &deposit, nil
+}
+
+// L1ERC721BridgeDepositWithFilter queries for an nft bridge deposit with set fields in the `ERC721BridgeTransfer` filter
+func (db *erc721BridgeTransfersDB) L1ERC721BridgeDepositWithFilter(filter ERC721BridgeTransfer) (*L1ERC721BridgeDeposit, error) {
+	var deposit L1ERC721BridgeDeposit
+	result := db.gorm.Where(&filter).Take(&deposit)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	return &deposit, nil
+}
+
+type L1ERC721BridgeDepositsResponse struct {
+	Deposits    []L1ERC721BridgeDepositWithTransactionHashes
+	Cursor      string
+	HasNextPage bool
+}
+
+// L1ERC721BridgeDepositsByAddress retrieves a list of nft deposits initiated by the specified address,
+// coupled with the L1/L2 transaction hashes that complete the bridge transaction.
+func (db *erc721BridgeTransfersDB) L1ERC721BridgeDepositsByAddress(address common.Address, cursor string, limit int) (*L1ERC721BridgeDepositsResponse, error) {
+	if limit <= 0 {
+		return nil, fmt.Errorf("limit must be greater than 0")
+	}
+
+	depositsQuery := db.gorm.Model(&L1ERC721BridgeDeposit{})
+	depositsQuery = depositsQuery.Where(&ERC721BridgeTransfer{FromAddress: address})
+	if cursor != "" {
+		sourceHash := common.HexToHash(cursor)
+		var deposit L1ERC721BridgeDeposit
+		result := db.gorm.Where(&L1ERC721BridgeDeposit{TransactionSourceHash: sourceHash}).Take(&deposit)
+		if result.Error != nil || errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("unable to find nft deposit with supplied cursor source hash %s: %w", sourceHash, result.Error)
+		}
+		depositsQuery = depositsQuery.Where("l1_erc721_bridge_deposits.timestamp <= ?", deposit.Timestamp)
+	}
+
+	depositsQuery = depositsQuery.Joins("INNER JOIN l1_transaction_deposits ON l1_transaction_deposits.source_hash = transaction_source_hash")
+	depositsQuery = depositsQuery.Joins("INNER JOIN l1_contract_events ON l1_contract_events.guid = l1_transaction_deposits.initiated_l1_event_guid")
+	depositsQuery = depositsQuery.Joins("INNER JOIN l1_block_headers ON l1_block_headers.hash = l1_contract_events.block_hash")
+	depositsQuery = depositsQuery.Select(`
+l1_erc721_bridge_deposits.from_address, l1_erc721_bridge_deposits.to_address, l1_erc721_bridge_deposits.token_id, l1_erc721_bridge_deposits.data, transaction_source_hash,
+l2_transaction_hash, l1_contract_events.transaction_hash AS l1_transaction_hash, l1_contract_events.block_hash as l1_block_hash, l1_block_headers.number AS l1_block_number,
+l1_erc721_bridge_deposits.timestamp, cross_domain_message_hash, local_token_address, remote_token_address`)
+	depositsQuery = depositsQuery.Order("l1_erc721_bridge_deposits.timestamp DESC").Limit(limit + 1)
+
+	deposits := []L1ERC721BridgeDepositWithTransactionHashes{}
+	result := depositsQuery.Find(&deposits)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	nextCursor := ""
+	hasNextPage := false
+	if len(deposits) > limit {
+		hasNextPage = true
+		nextCursor = deposits[limit].L1ERC721BridgeDeposit.TransactionSourceHash.String()
+		deposits = deposits[:limit]
+	}
+
+	response := &L1ERC721BridgeDepositsResponse{Deposits: deposits, Cursor: nextCursor, HasNextPage: hasNextPage}
+	return response, nil
+}
+
+/**
+ * NFTs Bridged (Withdrawn) from L2
+ */
+
+// StoreL2ERC721BridgeWithdrawals stores the supplied nft withdrawals, skipping those already stored such
+// that the bridge processor can safely re-process epochs from its latest bridge markers
+func (db *erc721BridgeTransfersDB) StoreL2ERC721BridgeWithdrawals(withdrawals []L2ERC721BridgeWithdrawal) error {
+	result := db.gorm.Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(&withdrawals, batchInsertSize)
+	return result.Error
+}
+
+func (db *erc721BridgeTransfersDB) L2ERC721BridgeWithdrawal(txWithdrawalHash common.Hash) (*L2ERC721BridgeWithdrawal, error) {
+	var withdrawal L2ERC721BridgeWithdrawal
+	result := db.gorm.Where(&L2ERC721BridgeWithdrawal{TransactionWithdrawalHash: txWithdrawalHash}).Take(&withdrawal)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	return &withdrawal, nil
+}
+
+// L2ERC721BridgeWithdrawalWithFilter queries for an nft bridge withdrawal with set fields in the `ERC721BridgeTransfer` filter
+func (db *erc721BridgeTransfersDB) L2ERC721BridgeWithdrawalWithFilter(filter ERC721BridgeTransfer) (*L2ERC721BridgeWithdrawal, error) {
+	var withdrawal L2ERC721BridgeWithdrawal
+	result := db.gorm.Where(&filter).Take(&withdrawal)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	return &withdrawal, nil
+}
+
+type L2ERC721BridgeWithdrawalsResponse struct {
+	Withdrawals []L2ERC721BridgeWithdrawalWithTransactionHashes
+	Cursor      string
+	HasNextPage bool
+}
+
+// L2ERC721BridgeWithdrawalsByAddress retrieves a list of nft withdrawals initiated by the specified address, coupled with the L1/L2
+// transaction hashes that complete the bridge transaction. The hashes that correspond with the Bedrock multi-step withdrawal process are also surfaced
+func (db *erc721BridgeTransfersDB) L2ERC721BridgeWithdrawalsByAddress(address common.Address, cursor string, limit int) (*L2ERC721BridgeWithdrawalsResponse, error) {
+	if limit <= 0 {
+		return nil, fmt.Errorf("limit must be greater than 0")
+	}
+
+	withdrawalsQuery := db.gorm.Model(&L2ERC721BridgeWithdrawal{})
+	withdrawalsQuery = withdrawalsQuery.Where(&ERC721BridgeTransfer{FromAddress: address})
+	if cursor != "" {
+		withdrawalHash := common.HexToHash(cursor)
+		var withdrawal L2ERC721BridgeWithdrawal
+		result := db.gorm.Where(&L2ERC721BridgeWithdrawal{TransactionWithdrawalHash: withdrawalHash}).Take(&withdrawal)
+		if result.Error != nil || errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("unable to find nft withdrawal with supplied cursor withdrawal hash %s: %w", withdrawalHash, result.Error)
+		}
+		withdrawalsQuery = withdrawalsQuery.Where("l2_erc721_bridge_withdrawals.timestamp <= ?", withdrawal.Timestamp)
+	}
+
+	withdrawalsQuery = withdrawalsQuery.Joins("INNER JOIN l2_transaction_withdrawals ON withdrawal_hash = l2_erc721_bridge_withdrawals.transaction_withdrawal_hash")
+	withdrawalsQuery = withdrawalsQuery.Joins("INNER JOIN l2_contract_events ON l2_contract_events.guid = l2_transaction_withdrawals.initiated_l2_event_guid")
+	withdrawalsQuery = withdrawalsQuery.Joins("INNER JOIN l2_block_headers ON l2_block_headers.hash = l2_contract_events.block_hash")
+	withdrawalsQuery = withdrawalsQuery.Joins("LEFT JOIN l1_contract_events AS proven_l1_events ON proven_l1_events.guid = l2_transaction_withdrawals.proven_l1_event_guid")
+	withdrawalsQuery = withdrawalsQuery.Joins("LEFT JOIN l1_contract_events AS finalized_l1_events ON finalized_l1_events.guid = l2_transaction_withdrawals.finalized_l1_event_guid")
+	withdrawalsQuery = withdrawalsQuery.Joins(outputProposalJoin)
+	withdrawalsQuery = withdrawalsQuery.Joins(provenOutputProposalJoin)
+	withdrawalsQuery = withdrawalsQuery.Select(`
+l2_erc721_bridge_withdrawals.from_address, l2_erc721_bridge_withdrawals.to_address, l2_erc721_bridge_withdrawals.token_id, l2_erc721_bridge_withdrawals.data, transaction_withdrawal_hash,
+l2_contract_events.transaction_hash AS l2_transaction_hash, l2_contract_events.block_hash as l2_block_hash, l2_block_headers.number AS l2_block_number, proven_l1_events.transaction_hash AS proven_l1_transaction_hash, proven_l1_events.timestamp AS proven_l1_timestamp, finalized_l1_events.transaction_hash AS finalized_l1_transaction_hash,
+output_proposals.l2_output_index, output_proposals.timestamp AS l2_output_timestamp,
+l2_transaction_withdrawals.proven_l2_output_index, proven_output_proposals.timestamp AS proven_l2_output_timestamp,
+l2_erc721_bridge_withdrawals.timestamp, cross_domain_message_hash, local_token_address, remote_token_address`)
+	withdrawalsQuery = withdrawalsQuery.Order("l2_erc721_bridge_withdrawals.timestamp DESC").Limit(limit + 1)
+
+	withdrawals := []L2ERC721BridgeWithdrawalWithTransactionHashes{}
+	result := withdrawalsQuery.Find(&withdrawals)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	nextCursor := ""
+	hasNextPage := false
+	if len(withdrawals) > limit {
+		hasNextPage = true
+		nextCursor = withdrawals[limit].L2ERC721BridgeWithdrawal.TransactionWithdrawalHash.String()
+		withdrawals = withdrawals[:limit]
+	}
+
+	response := &L2ERC721BridgeWithdrawalsResponse{Withdrawals: withdrawals, Cursor: nextCursor, HasNextPage: hasNextPage}
+	return response, nil
+}
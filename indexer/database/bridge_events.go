@@ -0,0 +1,147 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+/**
+ * Types
+ */
+
+// bridgeEventsLockID identifies the advisory lock serializing the writers of bridge events
+const bridgeEventsLockID int64 = 0x6272696467655f65
+
+type BridgeEventType string
+
+const (
+	BridgeEventDepositInitiated    BridgeEventType = "deposit-initiated"
+	BridgeEventDepositFinalized    BridgeEventType = "deposit-finalized"
+	BridgeEventWithdrawalInitiated BridgeEventType = "withdrawal-initiated"
+	BridgeEventWithdrawalProven    BridgeEventType = "withdrawal-proven"
+	BridgeEventWithdrawalFinalized BridgeEventType = "withdrawal-finalized"
+)
+
+// BridgeEvent is a single step in the lifecycle of a deposit or withdrawal. The from & to addresses are
+// those of the user-level transfer, i.e the bridge transfer or cross domain message when present. The
+// TransferHash is the deposit source hash or the withdrawal hash.
+type BridgeEvent struct {
+	ID                  uint64 `gorm:"primaryKey;autoIncrement"`
+	L1ContractEventGUID *uuid.UUID
+	L2ContractEventGUID *uuid.UUID
+
+	EventType       BridgeEventType
+	TransferHash    common.Hash    `gorm:"serializer:bytes"`
+	MessageHash     *common.Hash   `gorm:"serializer:bytes"`
+	FromAddress     common.Address `gorm:"serializer:bytes"`
+	ToAddress       common.Address `gorm:"serializer:bytes"`
+	TransactionHash common.Hash    `gorm:"serializer:bytes"`
+	Timestamp       uint64
+}
+
+// BridgeEventsFilter matches events sent from or to the Address, or part of the transfer with
+// the supplied MessageHash. Unset fields are not filtered on.
+type BridgeEventsFilter struct {
+	Address     *common.Address
+	MessageHash *common.Hash
+}
+
+type BridgeEventsView interface {
+	BridgeEvents(BridgeEventsFilter, uint64, int) ([]BridgeEvent, error)
+	BridgeEventWithFilter(BridgeEvent) (*BridgeEvent, error)
+	LatestBridgeEventID() (uint64, error)
+}
+
+type BridgeEventsDB interface {
+	BridgeEventsView
+
+	StoreBridgeEvents([]BridgeEvent) error
+}
+
+/**
+ * Implementation
+ */
+
+type bridgeEventsDB struct {
+	gorm *gorm.DB
+}
+
+func newBridgeEventsDB(db *gorm.DB) BridgeEventsDB {
+	return &bridgeEventsDB{gorm: db}
+}
+
+// StoreBridgeEvents stores the supplied events, skipping those already stored for the same contract event
+// such that the bridge processor can safely re-process epochs from its latest bridge markers.
+//
+// Event ids serve as the resume cursor of subscribers, and must therefore become visible in order. Writers
+// hold a transaction-scoped advisory lock from storing their events until their transaction commits, such
+// that the events of concurrent writers, i.e the workers of a backfill, never become visible out of id order.
+func (db *bridgeEventsDB) StoreBridgeEvents(events []BridgeEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	return db.gorm.Transaction(func(tx *gorm.DB) error {
+		if result := tx.Exec("SELECT pg_advisory_xact_lock(?)", bridgeEventsLockID); result.Error != nil {
+			return result.Error
+		}
+
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(&events, batchInsertSize)
+		return result.Error
+	})
+}
+
+// BridgeEvents retrieves, in order, up to `limit` events matching the filter with an id greater than `afterID`
+func (db *bridgeEventsDB) BridgeEvents(filter BridgeEventsFilter, afterID uint64, limit int) ([]BridgeEvent, error) {
+	if limit <= 0 {
+		return nil, fmt.Errorf("limit must be greater than 0")
+	}
+
+	query := db.gorm.Where("id > ?", afterID)
+	if filter.Address != nil {
+		address := hexutil.Encode(filter.Address.Bytes())
+		query = query.Where("(from_address = ? OR to_address = ?)", address, address)
+	}
+	if filter.MessageHash != nil {
+		query = query.Where("message_hash = ?", hexutil.Encode(filter.MessageHash.Bytes()))
+	}
+
+	events := []BridgeEvent{}
+	result := query.Order("id ASC").Limit(limit).Find(&events)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return events, nil
+}
+
+func (db *bridgeEventsDB) BridgeEventWithFilter(filter BridgeEvent) (*BridgeEvent, error) {
+	var event BridgeEvent
+	result := db.gorm.Where(&filter).Order("id DESC").Take(&event)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	return &event, nil
+}
+
+// LatestBridgeEventID returns the id of the most recently stored event, zero if there are none
+func (db *bridgeEventsDB) LatestBridgeEventID() (uint64, error) {
+	var id uint64
+	result := db.gorm.Model(&BridgeEvent{}).Select("COALESCE(MAX(id), 0)").Scan(&id)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return id, nil
+}
@@ -41,6 +41,10 @@ type L2TransactionWithdrawal struct {
 	FinalizedL1EventGUID *uuid.UUID
 	Succeeded            *bool
 
+	// The output proposal the withdrawal was proven against, empty if unknown
+	ProvenL2OutputIndex *big.Int     `gorm:"serializer:u256"`
+	ProvenOutputRoot    *common.Hash `gorm:"serializer:bytes"`
+
 	Tx       Transaction `gorm:"embedded"`
 	GasLimit *big.Int    `gorm:"serializer:u256"`
 }
@@ -59,7 +63,7 @@ type BridgeTransactionsDB interface {
 	StoreL1TransactionDeposits([]L1TransactionDeposit) error
 
 	StoreL2TransactionWithdrawals([]L2TransactionWithdrawal) error
-	MarkL2TransactionWithdrawalProvenEvent(common.Hash, uuid.UUID) error
+	MarkL2TransactionWithdrawalProvenEvent(common.Hash, uuid.UUID, *big.Int, *common.Hash) error
 	MarkL2TransactionWithdrawalFinalizedEvent(common.Hash, uuid.UUID, bool) error
 }
 
@@ -150,8 +154,9 @@ func (db *bridgeTransactionsDB) L2TransactionWithdrawal(withdrawalHash common.Ha
 	return &withdrawal, nil
 }
 
-// MarkL2TransactionWithdrawalProvenEvent links a withdrawn transaction with associated Prove action on L1.
-func (db *bridgeTransactionsDB) MarkL2TransactionWithdrawalProvenEvent(withdrawalHash common.Hash, provenL1EventGuid uuid.UUID) error {
+// MarkL2TransactionWithdrawalProvenEvent links a withdrawn transaction with associated Prove action on L1,
+// along with the output proposal it was proven against if known. A subsequent proof replaces the prior one.
+func (db *bridgeTransactionsDB) MarkL2TransactionWithdrawalProvenEvent(withdrawalHash common.Hash, provenL1EventGuid uuid.UUID, l2OutputIndex *big.Int, outputRoot *common.Hash) error {
 	withdrawal, err := db.L2TransactionWithdrawal(withdrawalHash)
 	if err != nil {
 		return err
@@ -161,6 +166,8 @@ func (db *bridgeTransactionsDB) MarkL2TransactionWithdrawalProvenEvent(withdrawa
 	}
 
 	withdrawal.ProvenL1EventGUID = &provenL1EventGuid
+	withdrawal.ProvenL2OutputIndex = l2OutputIndex
+	withdrawal.ProvenOutputRoot = outputRoot
 	result := db.gorm.Save(&withdrawal)
 	return result.Error
 }
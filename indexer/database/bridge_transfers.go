@@ -1,17 +1,32 @@
 package database
 
 import (
+	"database/sql"
 	"errors"
 	"fmt"
+	"math/big"
 
 	"gorm.io/gorm"
 
 	"github.com/ethereum-optimism/optimism/op-bindings/predeploys"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 )
 
 var (
 	ETHTokenPair = TokenPair{LocalTokenAddress: predeploys.LegacyERC20ETHAddr, RemoteTokenAddress: predeploys.LegacyERC20ETHAddr}
+
+	// The first (non-deleted) output proposal covering the L2 block of a withdrawal
+	outputProposalJoin = `LEFT JOIN LATERAL (
+SELECT l2_output_index, timestamp FROM l2_output_proposals
+WHERE l2_output_proposals.l2_block_number >= l2_block_headers.number AND l2_output_proposals.deleted_l1_event_guid IS NULL
+ORDER BY l2_output_proposals.l2_output_index ASC LIMIT 1) AS output_proposals ON TRUE`
+
+	// The output proposal a withdrawal was proven against, as long as it has not been deleted
+	provenOutputProposalJoin = `LEFT JOIN LATERAL (
+SELECT timestamp FROM l2_output_proposals
+WHERE l2_output_proposals.l2_output_index = l2_transaction_withdrawals.proven_l2_output_index AND l2_output_proposals.output_root = l2_transaction_withdrawals.proven_output_root
+AND l2_output_proposals.deleted_l1_event_guid IS NULL LIMIT 1) AS proven_output_proposals ON TRUE`
 )
 
 /**
@@ -39,6 +54,7 @@ type L1BridgeDepositWithTransactionHashes struct {
 	L1BridgeDeposit L1BridgeDeposit `gorm:"embedded"`
 
 	L1BlockHash       common.Hash `gorm:"serializer:bytes"`
+	L1BlockNumber     *big.Int    `gorm:"serializer:u256"`
 	L1TransactionHash common.Hash `gorm:"serializer:bytes"`
 	L2TransactionHash common.Hash `gorm:"serializer:bytes"`
 }
@@ -52,19 +68,76 @@ type L2BridgeWithdrawalWithTransactionHashes struct {
 	L2BridgeWithdrawal L2BridgeWithdrawal `gorm:"embedded"`
 	L2TransactionHash  common.Hash        `gorm:"serializer:bytes"`
 	L2BlockHash        common.Hash        `gorm:"serializer:bytes"`
+	L2BlockNumber      *big.Int           `gorm:"serializer:u256"`
 
 	ProvenL1TransactionHash    common.Hash `gorm:"serializer:bytes"`
+	ProvenL1Timestamp          *uint64
 	FinalizedL1TransactionHash common.Hash `gorm:"serializer:bytes"`
+
+	// The first output proposal that the withdrawal can be proven against
+	L2OutputIndex     *big.Int `gorm:"serializer:u256"`
+	L2OutputTimestamp *uint64
+
+	// The output proposal the withdrawal was proven against, if known. The timestamp
+	// is empty once the output has been deleted, requiring the withdrawal to be proven again
+	ProvenL2OutputIndex     *big.Int `gorm:"serializer:u256"`
+	ProvenL2OutputTimestamp *uint64
+}
+
+// BridgeTransfersFilter narrows down the listed bridge transfers. Unset fields are not filtered on
+type BridgeTransfersFilter struct {
+	FromAddress *common.Address
+
+	// The token the transfer was initiated with. The L1 token for deposits & the L2 token for withdrawals
+	TokenAddress *common.Address
+
+	// Inclusive bounds on the initiated timestamp
+	FromTimestamp uint64
+	ToTimestamp   uint64
+}
+
+// TokenVolume is the number & sum of transfers initiated with a token in a UTC day
+type TokenVolume struct {
+	Day          uint64
+	TokenAddress common.Address `gorm:"serializer:bytes"`
+	Count        uint64
+	Amount       *big.Int `gorm:"serializer:u256"`
+}
+
+// TokenValueLocked is the amount of an L1 token deposited & the amount that has been withdrawn
+// back to L1 via finalized withdrawals
+type TokenValueLocked struct {
+	L1TokenAddress common.Address `gorm:"serializer:bytes"`
+	Deposited      *big.Int       `gorm:"serializer:u256"`
+	Withdrawn      *big.Int       `gorm:"serializer:u256"`
+}
+
+// PendingWithdrawals counts the withdrawals that have not yet been finalized by their stage
+type PendingWithdrawals struct {
+	Initiated    uint64
+	ReadyToProve uint64
+	Proven       uint64
 }
 
 type BridgeTransfersView interface {
 	L1BridgeDeposit(common.Hash) (*L1BridgeDeposit, error)
 	L1BridgeDepositWithFilter(BridgeTransfer) (*L1BridgeDeposit, error)
 	L1BridgeDepositsByAddress(common.Address, string, int) (*L1BridgeDepositsResponse, error)
+	L1BridgeDeposits(BridgeTransfersFilter, string, int) (*L1BridgeDepositsResponse, error)
+	L1BridgeDepositsByTransactionHash(common.Hash) ([]L1BridgeDepositWithTransactionHashes, error)
+	L1BridgeDepositByMessageHash(common.Hash) (*L1BridgeDepositWithTransactionHashes, error)
 
 	L2BridgeWithdrawal(common.Hash) (*L2BridgeWithdrawal, error)
 	L2BridgeWithdrawalWithFilter(BridgeTransfer) (*L2BridgeWithdrawal, error)
 	L2BridgeWithdrawalsByAddress(common.Address, string, int) (*L2BridgeWithdrawalsResponse, error)
+	L2BridgeWithdrawals(BridgeTransfersFilter, string, int) (*L2BridgeWithdrawalsResponse, error)
+	L2BridgeWithdrawalsByTransactionHash(common.Hash) ([]L2BridgeWithdrawalWithTransactionHashes, error)
+	L2BridgeWithdrawalByMessageHash(common.Hash) (*L2BridgeWithdrawalWithTransactionHashes, error)
+
+	L1BridgeDepositsDailyVolume(uint64, uint64) ([]TokenVolume, error)
+	L2BridgeWithdrawalsDailyVolume(uint64, uint64) ([]TokenVolume, error)
+	BridgeValueLocked() ([]TokenValueLocked, error)
+	L2PendingWithdrawals() (*PendingWithdrawals, error)
 }
 
 type BridgeTransfersDB interface {
@@ -131,6 +204,12 @@ type L1BridgeDepositsResponse struct {
 // L1BridgeDepositsByAddress retrieves a list of deposits initiated by the specified address,
 // coupled with the L1/L2 transaction hashes that complete the bridge transaction.
 func (db *bridgeTransfersDB) L1BridgeDepositsByAddress(address common.Address, cursor string, limit int) (*L1BridgeDepositsResponse, error) {
+	return db.L1BridgeDeposits(BridgeTransfersFilter{FromAddress: &address}, cursor, limit)
+}
+
+// L1BridgeDeposits retrieves a list of deposits matching the supplied filter, coupled with the L1/L2
+// transaction hashes that complete the bridge transaction.
+func (db *bridgeTransfersDB) L1BridgeDeposits(filter BridgeTransfersFilter, cursor string, limit int) (*L1BridgeDepositsResponse, error) {
 	if limit <= 0 {
 		return nil, fmt.Errorf("limit must be greater than 0")
 	}
@@ -146,32 +225,11 @@ func (db *bridgeTransfersDB) L1BridgeDepositsByAddress(address common.Address, c
 		cursorClause = fmt.Sprintf("l1_transaction_deposits.timestamp <= %d", txDeposit.Tx.Timestamp)
 	}
 
-	// TODO join with l1_bridged_tokens and l2_bridged_tokens
-	ethAddressString := predeploys.LegacyERC20ETHAddr.String()
-
-	// Coalesce l1 transaction deposits that are simply ETH sends
-	ethTransactionDeposits := db.gorm.Model(&L1TransactionDeposit{})
-	ethTransactionDeposits = ethTransactionDeposits.Where(&Transaction{FromAddress: address}).Where("data = '0x' AND amount > 0")
-	ethTransactionDeposits = ethTransactionDeposits.Joins("INNER JOIN l1_contract_events ON l1_contract_events.guid = initiated_l1_event_guid")
-	ethTransactionDeposits = ethTransactionDeposits.Select(`
-from_address, to_address, amount, data, source_hash AS transaction_source_hash,
-l2_transaction_hash, l1_contract_events.transaction_hash AS l1_transaction_hash, l1_contract_events.block_hash as l1_block_hash,
-l1_transaction_deposits.timestamp, NULL AS cross_domain_message_hash, ? AS local_token_address, ? AS remote_token_address`, ethAddressString, ethAddressString)
+	ethTransactionDeposits, depositsQuery := db.l1BridgeDepositsQueries(filter)
 	ethTransactionDeposits = ethTransactionDeposits.Order("timestamp DESC").Limit(limit + 1)
-	if cursorClause != "" {
-		ethTransactionDeposits = ethTransactionDeposits.Where(cursorClause)
-	}
-
-	depositsQuery := db.gorm.Model(&L1BridgeDeposit{})
-	depositsQuery = depositsQuery.Where(&Transaction{FromAddress: address})
-	depositsQuery = depositsQuery.Joins("INNER JOIN l1_transaction_deposits ON l1_transaction_deposits.source_hash = transaction_source_hash")
-	depositsQuery = depositsQuery.Joins("INNER JOIN l1_contract_events ON l1_contract_events.guid = l1_transaction_deposits.initiated_l1_event_guid")
-	depositsQuery = depositsQuery.Select(`
-l1_bridge_deposits.from_address, l1_bridge_deposits.to_address, l1_bridge_deposits.amount, l1_bridge_deposits.data, transaction_source_hash,
-l2_transaction_hash, l1_contract_events.transaction_hash AS l1_transaction_hash, l1_contract_events.block_hash as l1_block_hash,
-l1_bridge_deposits.timestamp, cross_domain_message_hash, local_token_address, remote_token_address`)
 	depositsQuery = depositsQuery.Order("timestamp DESC").Limit(limit + 1)
 	if cursorClause != "" {
+		ethTransactionDeposits = ethTransactionDeposits.Where(cursorClause)
 		depositsQuery = depositsQuery.Where(cursorClause)
 	}
 
@@ -199,6 +257,95 @@ l1_bridge_deposits.timestamp, cross_domain_message_hash, local_token_address, re
 	return response, nil
 }
 
+// L1BridgeDepositsByTransactionHash retrieves the deposits initiated in the specified L1 transaction, or the
+// deposit executed by the specified L2 transaction.
+func (db *bridgeTransfersDB) L1BridgeDepositsByTransactionHash(txHash common.Hash) ([]L1BridgeDepositWithTransactionHashes, error) {
+	txHashClause := "(l1_contract_events.transaction_hash = @hash OR l1_transaction_deposits.l2_transaction_hash = @hash)"
+	hash := sql.Named("hash", hexutil.Encode(txHash.Bytes()))
+
+	ethTransactionDeposits, depositsQuery := db.l1BridgeDepositsQueries(BridgeTransfersFilter{})
+	ethTransactionDeposits = ethTransactionDeposits.Where(txHashClause, hash)
+	depositsQuery = depositsQuery.Where(txHashClause, hash)
+
+	query := db.gorm.Table("(?) AS deposits", depositsQuery)
+	query = query.Joins("UNION (?)", ethTransactionDeposits)
+	query = query.Select("*").Order("timestamp DESC")
+	deposits := []L1BridgeDepositWithTransactionHashes{}
+	result := query.Find(&deposits)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return deposits, nil
+}
+
+// L1BridgeDepositByMessageHash retrieves the deposit associated with the specified cross domain message
+func (db *bridgeTransfersDB) L1BridgeDepositByMessageHash(msgHash common.Hash) (*L1BridgeDepositWithTransactionHashes, error) {
+	_, depositsQuery := db.l1BridgeDepositsQueries(BridgeTransfersFilter{})
+	depositsQuery = depositsQuery.Where(&BridgeTransfer{CrossDomainMessageHash: &msgHash})
+
+	var deposit L1BridgeDepositWithTransactionHashes
+	result := depositsQuery.Take(&deposit)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	return &deposit, nil
+}
+
+// l1BridgeDepositsQueries generates the two sub-queries that together make up all deposits matching the filter
+//   - (A) ETH sends from L1 to L2
+//   - (B) Bridge deposits from L1 to L2
+func (db *bridgeTransfersDB) l1BridgeDepositsQueries(filter BridgeTransfersFilter) (*gorm.DB, *gorm.DB) {
+	// TODO join with l1_bridged_tokens and l2_bridged_tokens
+	ethAddressString := predeploys.LegacyERC20ETHAddr.String()
+
+	// Coalesce l1 transaction deposits that are simply ETH sends
+	ethTransactionDeposits := db.gorm.Model(&L1TransactionDeposit{})
+	if filter.FromAddress != nil {
+		ethTransactionDeposits = ethTransactionDeposits.Where(&Transaction{FromAddress: *filter.FromAddress})
+	}
+	ethTransactionDeposits = ethTransactionDeposits.Where("data = '0x' AND amount > 0")
+	ethTransactionDeposits = ethTransactionDeposits.Joins("INNER JOIN l1_contract_events ON l1_contract_events.guid = initiated_l1_event_guid")
+	ethTransactionDeposits = ethTransactionDeposits.Joins("INNER JOIN l1_block_headers ON l1_block_headers.hash = l1_contract_events.block_hash")
+	ethTransactionDeposits = ethTransactionDeposits.Select(`
+from_address, to_address, amount, data, source_hash AS transaction_source_hash,
+l2_transaction_hash, l1_contract_events.transaction_hash AS l1_transaction_hash, l1_contract_events.block_hash as l1_block_hash, l1_block_headers.number AS l1_block_number,
+l1_transaction_deposits.timestamp, NULL AS cross_domain_message_hash, ? AS local_token_address, ? AS remote_token_address`, ethAddressString, ethAddressString)
+
+	depositsQuery := db.gorm.Model(&L1BridgeDeposit{})
+	if filter.FromAddress != nil {
+		depositsQuery = depositsQuery.Where(&Transaction{FromAddress: *filter.FromAddress})
+	}
+	depositsQuery = depositsQuery.Joins("INNER JOIN l1_transaction_deposits ON l1_transaction_deposits.source_hash = transaction_source_hash")
+	depositsQuery = depositsQuery.Joins("INNER JOIN l1_contract_events ON l1_contract_events.guid = l1_transaction_deposits.initiated_l1_event_guid")
+	depositsQuery = depositsQuery.Joins("INNER JOIN l1_block_headers ON l1_block_headers.hash = l1_contract_events.block_hash")
+	depositsQuery = depositsQuery.Select(`
+l1_bridge_deposits.from_address, l1_bridge_deposits.to_address, l1_bridge_deposits.amount, l1_bridge_deposits.data, transaction_source_hash,
+l2_transaction_hash, l1_contract_events.transaction_hash AS l1_transaction_hash, l1_contract_events.block_hash as l1_block_hash, l1_block_headers.number AS l1_block_number,
+l1_bridge_deposits.timestamp, cross_domain_message_hash, local_token_address, remote_token_address`)
+
+	if filter.TokenAddress != nil {
+		if *filter.TokenAddress != predeploys.LegacyERC20ETHAddr {
+			ethTransactionDeposits = ethTransactionDeposits.Where("FALSE")
+		}
+		depositsQuery = depositsQuery.Where(&TokenPair{LocalTokenAddress: *filter.TokenAddress})
+	}
+	if filter.FromTimestamp > 0 {
+		ethTransactionDeposits = ethTransactionDeposits.Where("l1_transaction_deposits.timestamp >= ?", filter.FromTimestamp)
+		depositsQuery = depositsQuery.Where("l1_transaction_deposits.timestamp >= ?", filter.FromTimestamp)
+	}
+	if filter.ToTimestamp > 0 {
+		ethTransactionDeposits = ethTransactionDeposits.Where("l1_transaction_deposits.timestamp <= ?", filter.ToTimestamp)
+		depositsQuery = depositsQuery.Where("l1_transaction_deposits.timestamp <= ?", filter.ToTimestamp)
+	}
+
+	return ethTransactionDeposits, depositsQuery
+}
+
 /**
  * Tokens Bridged (Withdrawn) from L2
  */
@@ -241,9 +388,15 @@ type L2BridgeWithdrawalsResponse struct {
 	HasNextPage bool
 }
 
-// L2BridgeDepositsByAddress retrieves a list of deposits initiated by the specified address, coupled with the L1/L2 transaction hashes
+// L2BridgeWithdrawalsByAddress retrieves a list of withdrawals initiated by the specified address, coupled with the L1/L2 transaction hashes
 // that complete the bridge transaction. The hashes that correspond with the Bedrock multi-step withdrawal process are also surfaced
 func (db *bridgeTransfersDB) L2BridgeWithdrawalsByAddress(address common.Address, cursor string, limit int) (*L2BridgeWithdrawalsResponse, error) {
+	return db.L2BridgeWithdrawals(BridgeTransfersFilter{FromAddress: &address}, cursor, limit)
+}
+
+// L2BridgeWithdrawals retrieves a list of withdrawals matching the supplied filter, coupled with the L1/L2 transaction hashes
+// that complete the bridge transaction. The hashes that correspond with the Bedrock multi-step withdrawal process are also surfaced
+func (db *bridgeTransfersDB) L2BridgeWithdrawals(filter BridgeTransfersFilter, cursor string, limit int) (*L2BridgeWithdrawalsResponse, error) {
 	if limit <= 0 {
 		return nil, fmt.Errorf("limit must be greater than 0")
 	}
@@ -261,40 +414,11 @@ func (db *bridgeTransfersDB) L2BridgeWithdrawalsByAddress(address common.Address
 	}
 
 	// (2) Generate query for fetching ETH withdrawal data
-	// This query is a UNION (A | B) of two sub-queries:
-	//   - (A) ETH sends from L2 to L1
-	//   - (B) Bridge withdrawals from L2 to L1
-
-	// TODO join with l1_bridged_tokens and l2_bridged_tokens
-	ethAddressString := predeploys.LegacyERC20ETHAddr.String()
-
-	// Coalesce l2 transaction withdrawals that are simply ETH sends
-	ethTransactionWithdrawals := db.gorm.Model(&L2TransactionWithdrawal{})
-	ethTransactionWithdrawals = ethTransactionWithdrawals.Where(&Transaction{FromAddress: address}).Where(`data = '0x' AND amount > 0`)
-	ethTransactionWithdrawals = ethTransactionWithdrawals.Joins("INNER JOIN l2_contract_events ON l2_contract_events.guid = l2_transaction_withdrawals.initiated_l2_event_guid")
-	ethTransactionWithdrawals = ethTransactionWithdrawals.Joins("LEFT JOIN l1_contract_events AS proven_l1_events ON proven_l1_events.guid = l2_transaction_withdrawals.proven_l1_event_guid")
-	ethTransactionWithdrawals = ethTransactionWithdrawals.Joins("LEFT JOIN l1_contract_events AS finalized_l1_events ON finalized_l1_events.guid = l2_transaction_withdrawals.finalized_l1_event_guid")
-	ethTransactionWithdrawals = ethTransactionWithdrawals.Select(`
-from_address, to_address, amount, data, withdrawal_hash AS transaction_withdrawal_hash,
-l2_contract_events.transaction_hash AS l2_transaction_hash, l2_contract_events.block_hash as l2_block_hash, proven_l1_events.transaction_hash AS proven_l1_transaction_hash, finalized_l1_events.transaction_hash AS finalized_l1_transaction_hash,
-l2_transaction_withdrawals.timestamp, NULL AS cross_domain_message_hash, ? AS local_token_address, ? AS remote_token_address`, ethAddressString, ethAddressString)
+	ethTransactionWithdrawals, withdrawalsQuery := db.l2BridgeWithdrawalsQueries(filter)
 	ethTransactionWithdrawals = ethTransactionWithdrawals.Order("timestamp DESC").Limit(limit + 1)
-	if cursorClause != "" {
-		ethTransactionWithdrawals = ethTransactionWithdrawals.Where(cursorClause)
-	}
-
-	withdrawalsQuery := db.gorm.Model(&L2BridgeWithdrawal{})
-	withdrawalsQuery = withdrawalsQuery.Where(&Transaction{FromAddress: address})
-	withdrawalsQuery = withdrawalsQuery.Joins("INNER JOIN l2_transaction_withdrawals ON withdrawal_hash = l2_bridge_withdrawals.transaction_withdrawal_hash")
-	withdrawalsQuery = withdrawalsQuery.Joins("INNER JOIN l2_contract_events ON l2_contract_events.guid = l2_transaction_withdrawals.initiated_l2_event_guid")
-	withdrawalsQuery = withdrawalsQuery.Joins("LEFT JOIN l1_contract_events AS proven_l1_events ON proven_l1_events.guid = l2_transaction_withdrawals.proven_l1_event_guid")
-	withdrawalsQuery = withdrawalsQuery.Joins("LEFT JOIN l1_contract_events AS finalized_l1_events ON finalized_l1_events.guid = l2_transaction_withdrawals.finalized_l1_event_guid")
-	withdrawalsQuery = withdrawalsQuery.Select(`
-l2_bridge_withdrawals.from_address, l2_bridge_withdrawals.to_address, l2_bridge_withdrawals.amount, l2_bridge_withdrawals.data, transaction_withdrawal_hash,
-l2_contract_events.transaction_hash AS l2_transaction_hash, l2_contract_events.block_hash as l2_block_hash, proven_l1_events.transaction_hash AS proven_l1_transaction_hash, finalized_l1_events.transaction_hash AS finalized_l1_transaction_hash,
-l2_bridge_withdrawals.timestamp, cross_domain_message_hash, local_token_address, remote_token_address`)
 	withdrawalsQuery = withdrawalsQuery.Order("timestamp DESC").Limit(limit + 1)
 	if cursorClause != "" {
+		ethTransactionWithdrawals = ethTransactionWithdrawals.Where(cursorClause)
 		withdrawalsQuery = withdrawalsQuery.Where(cursorClause)
 	}
 
@@ -324,3 +448,214 @@ l2_bridge_withdrawals.timestamp, cross_domain_message_hash, local_token_address,
 	response := &L2BridgeWithdrawalsResponse{Withdrawals: withdrawals, Cursor: nextCursor, HasNextPage: hasNextPage}
 	return response, nil
 }
+
+// L2BridgeWithdrawalsByTransactionHash retrieves the withdrawals initiated in the specified L2 transaction, or
+// proven or finalized in the specified L1 transaction.
+func (db *bridgeTransfersDB) L2BridgeWithdrawalsByTransactionHash(txHash common.Hash) ([]L2BridgeWithdrawalWithTransactionHashes, error) {
+	txHashClause := "(l2_contract_events.transaction_hash = @hash OR proven_l1_events.transaction_hash = @hash OR finalized_l1_events.transaction_hash = @hash)"
+	hash := sql.Named("hash", hexutil.Encode(txHash.Bytes()))
+
+	ethTransactionWithdrawals, withdrawalsQuery := db.l2BridgeWithdrawalsQueries(BridgeTransfersFilter{})
+	ethTransactionWithdrawals = ethTransactionWithdrawals.Where(txHashClause, hash)
+	withdrawalsQuery = withdrawalsQuery.Where(txHashClause, hash)
+
+	query := db.gorm.Table("(?) AS withdrawals", withdrawalsQuery)
+	query = query.Joins("UNION (?)", ethTransactionWithdrawals)
+	query = query.Select("*").Order("timestamp DESC")
+	withdrawals := []L2BridgeWithdrawalWithTransactionHashes{}
+	result := query.Find(&withdrawals)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return withdrawals, nil
+}
+
+// L2BridgeWithdrawalByMessageHash retrieves the withdrawal associated with the specified cross domain message
+func (db *bridgeTransfersDB) L2BridgeWithdrawalByMessageHash(msgHash common.Hash) (*L2BridgeWithdrawalWithTransactionHashes, error) {
+	_, withdrawalsQuery := db.l2BridgeWithdrawalsQueries(BridgeTransfersFilter{})
+	withdrawalsQuery = withdrawalsQuery.Where(&BridgeTransfer{CrossDomainMessageHash: &msgHash})
+
+	var withdrawal L2BridgeWithdrawalWithTransactionHashes
+	result := withdrawalsQuery.Take(&withdrawal)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	return &withdrawal, nil
+}
+
+// l2BridgeWithdrawalsQueries generates the two sub-queries that together make up all withdrawals matching the filter
+//   - (A) ETH sends from L2 to L1
+//   - (B) Bridge withdrawals from L2 to L1
+func (db *bridgeTransfersDB) l2BridgeWithdrawalsQueries(filter BridgeTransfersFilter) (*gorm.DB, *gorm.DB) {
+	// TODO join with l1_bridged_tokens and l2_bridged_tokens
+	ethAddressString := predeploys.LegacyERC20ETHAddr.String()
+
+	// Coalesce l2 transaction withdrawals that are simply ETH sends
+	ethTransactionWithdrawals := db.gorm.Model(&L2TransactionWithdrawal{})
+	if filter.FromAddress != nil {
+		ethTransactionWithdrawals = ethTransactionWithdrawals.Where(&Transaction{FromAddress: *filter.FromAddress})
+	}
+	ethTransactionWithdrawals = ethTransactionWithdrawals.Where(`data = '0x' AND amount > 0`)
+	ethTransactionWithdrawals = ethTransactionWithdrawals.Joins("INNER JOIN l2_contract_events ON l2_contract_events.guid = l2_transaction_withdrawals.initiated_l2_event_guid")
+	ethTransactionWithdrawals = ethTransactionWithdrawals.Joins("INNER JOIN l2_block_headers ON l2_block_headers.hash = l2_contract_events.block_hash")
+	ethTransactionWithdrawals = ethTransactionWithdrawals.Joins("LEFT JOIN l1_contract_events AS proven_l1_events ON proven_l1_events.guid = l2_transaction_withdrawals.proven_l1_event_guid")
+	ethTransactionWithdrawals = ethTransactionWithdrawals.Joins("LEFT JOIN l1_contract_events AS finalized_l1_events ON finalized_l1_events.guid = l2_transaction_withdrawals.finalized_l1_event_guid")
+	ethTransactionWithdrawals = ethTransactionWithdrawals.Joins(outputProposalJoin)
+	ethTransactionWithdrawals = ethTransactionWithdrawals.Joins(provenOutputProposalJoin)
+	ethTransactionWithdrawals = ethTransactionWithdrawals.Select(`
+from_address, to_address, amount, data, withdrawal_hash AS transaction_withdrawal_hash,
+l2_contract_events.transaction_hash AS l2_transaction_hash, l2_contract_events.block_hash as l2_block_hash, l2_block_headers.number AS l2_block_number, proven_l1_events.transaction_hash AS proven_l1_transaction_hash, proven_l1_events.timestamp AS proven_l1_timestamp, finalized_l1_events.transaction_hash AS finalized_l1_transaction_hash,
+output_proposals.l2_output_index, output_proposals.timestamp AS l2_output_timestamp,
+l2_transaction_withdrawals.proven_l2_output_index, proven_output_proposals.timestamp AS proven_l2_output_timestamp,
+l2_transaction_withdrawals.timestamp, NULL AS cross_domain_message_hash, ? AS local_token_address, ? AS remote_token_address`, ethAddressString, ethAddressString)
+
+	withdrawalsQuery := db.gorm.Model(&L2BridgeWithdrawal{})
+	if filter.FromAddress != nil {
+		withdrawalsQuery = withdrawalsQuery.Where(&Transaction{FromAddress: *filter.FromAddress})
+	}
+	withdrawalsQuery = withdrawalsQuery.Joins("INNER JOIN l2_transaction_withdrawals ON withdrawal_hash = l2_bridge_withdrawals.transaction_withdrawal_hash")
+	withdrawalsQuery = withdrawalsQuery.Joins("INNER JOIN l2_contract_events ON l2_contract_events.guid = l2_transaction_withdrawals.initiated_l2_event_guid")
+	withdrawalsQuery = withdrawalsQuery.Joins("INNER JOIN l2_block_headers ON l2_block_headers.hash = l2_contract_events.block_hash")
+	withdrawalsQuery = withdrawalsQuery.Joins("LEFT JOIN l1_contract_events AS proven_l1_events ON proven_l1_events.guid = l2_transaction_withdrawals.proven_l1_event_guid")
+	withdrawalsQuery = withdrawalsQuery.Joins("LEFT JOIN l1_contract_events AS finalized_l1_events ON finalized_l1_events.guid = l2_transaction_withdrawals.finalized_l1_event_guid")
+	withdrawalsQuery = withdrawalsQuery.Joins(outputProposalJoin)
+	withdrawalsQuery = withdrawalsQuery.Joins(provenOutputProposalJoin)
+	withdrawalsQuery = withdrawalsQuery.Select(`
+l2_bridge_withdrawals.from_address, l2_bridge_withdrawals.to_address, l2_bridge_withdrawals.amount, l2_bridge_withdrawals.data, transaction_withdrawal_hash,
+l2_contract_events.transaction_hash AS l2_transaction_hash, l2_contract_events.block_hash as l2_block_hash, l2_block_headers.number AS l2_block_number, proven_l1_events.transaction_hash AS proven_l1_transaction_hash, proven_l1_events.timestamp AS proven_l1_timestamp, finalized_l1_events.transaction_hash AS finalized_l1_transaction_hash,
+output_proposals.l2_output_index, output_proposals.timestamp AS l2_output_timestamp,
+l2_transaction_withdrawals.proven_l2_output_index, proven_output_proposals.timestamp AS proven_l2_output_timestamp,
+l2_bridge_withdrawals.timestamp, cross_domain_message_hash, local_token_address, remote_token_address`)
+
+	if filter.TokenAddress != nil {
+		if *filter.TokenAddress != predeploys.LegacyERC20ETHAddr {
+			ethTransactionWithdrawals = ethTransactionWithdrawals.Where("FALSE")
+		}
+		withdrawalsQuery = withdrawalsQuery.Where(&TokenPair{LocalTokenAddress: *filter.TokenAddress})
+	}
+	if filter.FromTimestamp > 0 {
+		ethTransactionWithdrawals = ethTransactionWithdrawals.Where("l2_transaction_withdrawals.timestamp >= ?", filter.FromTimestamp)
+		withdrawalsQuery = withdrawalsQuery.Where("l2_transaction_withdrawals.timestamp >= ?", filter.FromTimestamp)
+	}
+	if filter.ToTimestamp > 0 {
+		ethTransactionWithdrawals = ethTransactionWithdrawals.Where("l2_transaction_withdrawals.timestamp <= ?", filter.ToTimestamp)
+		withdrawalsQuery = withdrawalsQuery.Where("l2_transaction_withdrawals.timestamp <= ?", filter.ToTimestamp)
+	}
+
+	return ethTransactionWithdrawals, withdrawalsQuery
+}
+
+/**
+ * Aggregates
+ */
+
+// L1BridgeDepositsDailyVolume aggregates the deposits initiated in the inclusive time range by UTC day & L1 token
+func (db *bridgeTransfersDB) L1BridgeDepositsDailyVolume(fromTimestamp, toTimestamp uint64) ([]TokenVolume, error) {
+	query := `
+SELECT (timestamp / 86400) * 86400 AS day, token_address, COUNT(*) AS count, SUM(amount) AS amount FROM (
+	SELECT timestamp, local_token_address AS token_address, amount FROM l1_bridge_deposits WHERE timestamp BETWEEN @from AND @to
+	UNION ALL
+	SELECT timestamp, @eth AS token_address, amount FROM l1_transaction_deposits WHERE data = '0x' AND amount > 0 AND timestamp BETWEEN @from AND @to
+) AS deposits GROUP BY day, token_address ORDER BY day ASC, token_address ASC`
+
+	return db.dailyVolume(query, fromTimestamp, toTimestamp)
+}
+
+// L2BridgeWithdrawalsDailyVolume aggregates the withdrawals initiated in the inclusive time range by UTC day & L2 token
+func (db *bridgeTransfersDB) L2BridgeWithdrawalsDailyVolume(fromTimestamp, toTimestamp uint64) ([]TokenVolume, error) {
+	query := `
+SELECT (timestamp / 86400) * 86400 AS day, token_address, COUNT(*) AS count, SUM(amount) AS amount FROM (
+	SELECT timestamp, local_token_address AS token_address, amount FROM l2_bridge_withdrawals WHERE timestamp BETWEEN @from AND @to
+	UNION ALL
+	SELECT timestamp, @eth AS token_address, amount FROM l2_transaction_withdrawals WHERE data = '0x' AND amount > 0 AND timestamp BETWEEN @from AND @to
+) AS withdrawals GROUP BY day, token_address ORDER BY day ASC, token_address ASC`
+
+	return db.dailyVolume(query, fromTimestamp, toTimestamp)
+}
+
+func (db *bridgeTransfersDB) dailyVolume(query string, fromTimestamp, toTimestamp uint64) ([]TokenVolume, error) {
+	// ETH sends are grouped with ETH bridged via the standard bridge, which is stored in its serialized form
+	eth := hexutil.Encode(predeploys.LegacyERC20ETHAddr.Bytes())
+
+	volumes := []TokenVolume{}
+	result := db.gorm.Raw(query, sql.Named("from", fromTimestamp), sql.Named("to", toTimestamp), sql.Named("eth", eth)).Scan(&volumes)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return volumes, nil
+}
+
+// BridgeValueLocked aggregates, by L1 token, the amount deposited & the amount withdrawn back to L1. Only
+// successfully finalized withdrawals release funds on L1.
+func (db *bridgeTransfersDB) BridgeValueLocked() ([]TokenValueLocked, error) {
+	query := `
+WITH deposited AS (
+	SELECT token_address, SUM(amount) AS amount FROM (
+		SELECT local_token_address AS token_address, amount FROM l1_bridge_deposits
+		UNION ALL
+		SELECT @eth AS token_address, amount FROM l1_transaction_deposits WHERE data = '0x' AND amount > 0
+	) AS deposits GROUP BY token_address
+), withdrawn AS (
+	SELECT token_address, SUM(amount) AS amount FROM (
+		SELECT l2_bridge_withdrawals.remote_token_address AS token_address, l2_bridge_withdrawals.amount FROM l2_bridge_withdrawals
+		INNER JOIN l2_transaction_withdrawals ON withdrawal_hash = l2_bridge_withdrawals.transaction_withdrawal_hash
+		WHERE l2_transaction_withdrawals.succeeded
+		UNION ALL
+		SELECT @eth AS token_address, amount FROM l2_transaction_withdrawals WHERE data = '0x' AND amount > 0 AND succeeded
+	) AS withdrawals GROUP BY token_address
+)
+SELECT COALESCE(deposited.token_address, withdrawn.token_address) AS l1_token_address,
+	COALESCE(deposited.amount, 0) AS deposited, COALESCE(withdrawn.amount, 0) AS withdrawn
+FROM deposited FULL OUTER JOIN withdrawn ON deposited.token_address = withdrawn.token_address
+ORDER BY l1_token_address ASC`
+
+	eth := hexutil.Encode(predeploys.LegacyERC20ETHAddr.Bytes())
+
+	locked := []TokenValueLocked{}
+	result := db.gorm.Raw(query, sql.Named("eth", eth)).Scan(&locked)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return locked, nil
+}
+
+// L2PendingWithdrawals counts the withdrawals that are yet to be finalized. Withdrawals are ready to be
+// proven once an output proposal covering the L2 block they were initiated in has been indexed, and
+// must be proven again if the output proposal they were proven against has since been deleted.
+func (db *bridgeTransfersDB) L2PendingWithdrawals() (*PendingWithdrawals, error) {
+	query := `
+WITH latest_output AS (
+	SELECT MAX(l2_block_number) AS l2_block_number FROM l2_output_proposals WHERE deleted_l1_event_guid IS NULL
+), pending_withdrawals AS (
+	SELECT l2_block_headers.number AS l2_block_number, l2_transaction_withdrawals.proven_l1_event_guid IS NOT NULL AND (
+		l2_transaction_withdrawals.proven_l2_output_index IS NULL OR EXISTS (
+			SELECT 1 FROM l2_output_proposals
+			WHERE l2_output_proposals.l2_output_index = l2_transaction_withdrawals.proven_l2_output_index AND l2_output_proposals.output_root = l2_transaction_withdrawals.proven_output_root
+			AND l2_output_proposals.deleted_l1_event_guid IS NULL)) AS proven
+	FROM l2_transaction_withdrawals
+	INNER JOIN l2_contract_events ON l2_contract_events.guid = l2_transaction_withdrawals.initiated_l2_event_guid
+	INNER JOIN l2_block_headers ON l2_block_headers.hash = l2_contract_events.block_hash
+	WHERE l2_transaction_withdrawals.finalized_l1_event_guid IS NULL
+)
+SELECT
+	COUNT(*) FILTER (WHERE NOT proven AND (latest_output.l2_block_number IS NULL OR pending_withdrawals.l2_block_number > latest_output.l2_block_number)) AS initiated,
+	COUNT(*) FILTER (WHERE NOT proven AND pending_withdrawals.l2_block_number <= latest_output.l2_block_number) AS ready_to_prove,
+	COUNT(*) FILTER (WHERE proven) AS proven
+FROM pending_withdrawals
+CROSS JOIN latest_output`
+
+	var pending PendingWithdrawals
+	result := db.gorm.Raw(query).Scan(&pending)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &pending, nil
+}
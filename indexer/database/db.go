@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 
 	"github.com/ethereum-optimism/optimism/indexer/config"
 	_ "github.com/ethereum-optimism/optimism/indexer/database/serializers"
@@ -16,6 +17,7 @@ import (
 
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 var (
@@ -24,19 +26,52 @@ var (
 	// we'll utilize a batch size of 3k for inserts, well below as long as the the number
 	// of columns < 20.
 	batchInsertSize int = 3_000
+
+	// The L1 tables shared by all indexed L2 chains. The migrations create these unqualified, which
+	// would otherwise resolve to the schema of an additional L2 chain
+	sharedTablesRegexp = regexp.MustCompile(`CREATE TABLE IF NOT EXISTS (l1_block_headers|l1_contract_events)\b`)
 )
 
 type DB struct {
 	gorm *gorm.DB
 
+	// The postgres schema holding the state of an additional L2 chain. The L1 block headers
+	// & contract events are shared by all chains and remain in the `public` schema, alongside
+	// the state of the primary L2 chain
+	schema string
+
 	Blocks             BlocksDB
 	ContractEvents     ContractEventsDB
 	BridgeTransfers    BridgeTransfersDB
 	BridgeMessages     BridgeMessagesDB
 	BridgeTransactions BridgeTransactionsDB
+	L2OutputProposals  L2OutputProposalsDB
+
+	ERC721BridgeTransfers ERC721BridgeTransfersDB
+	BridgeEvents          BridgeEventsDB
+	BridgeBackfill        BridgeBackfillDB
 }
 
+// NewDB connects to the database holding the L1 state, as well as the state of the primary L2 chain.
+// The primary chain is not partitioned into a schema of its own, such that deployments indexing a
+// single chain are unaffected by the support of additional chains.
 func NewDB(log log.Logger, dbConfig config.DBConfig) (*DB, error) {
+	return newDB(log, dbConfig, "")
+}
+
+// NewL2ChainDB connects to the database holding the state of an additional L2 chain, partitioned by
+// the chain id into its own schema. Queries resolve unqualified tables against the chain's schema
+// first and then the `public` schema, such that the shared L1 state is visible to all chains.
+func NewL2ChainDB(log log.Logger, dbConfig config.DBConfig, l2ChainID uint) (*DB, error) {
+	return newDB(log.New("l2_chain_id", l2ChainID), dbConfig, L2ChainSchema(l2ChainID))
+}
+
+// L2ChainSchema is the postgres schema holding the state of an additional L2 chain
+func L2ChainSchema(l2ChainID uint) string {
+	return fmt.Sprintf("chain_%d", l2ChainID)
+}
+
+func newDB(log log.Logger, dbConfig config.DBConfig, schema string) (*DB, error) {
 	retryStrategy := &retry.ExponentialStrategy{Min: 1000, Max: 20_000, MaxJitter: 250}
 
 	dsn := fmt.Sprintf("host=%s dbname=%s sslmode=disable", dbConfig.Host, dbConfig.Name)
@@ -49,6 +84,9 @@ func NewDB(log log.Logger, dbConfig config.DBConfig) (*DB, error) {
 	if dbConfig.Password != "" {
 		dsn += fmt.Sprintf(" password=%s", dbConfig.Password)
 	}
+	if schema != "" {
+		dsn += fmt.Sprintf(" search_path=%s,public", schema)
+	}
 
 	gormConfig := gorm.Config{
 		// The indexer will explicitly manage the transactions
@@ -71,11 +109,17 @@ func NewDB(log log.Logger, dbConfig config.DBConfig) (*DB, error) {
 
 	db := &DB{
 		gorm:               gorm,
+		schema:             schema,
 		Blocks:             newBlocksDB(gorm),
 		ContractEvents:     newContractEventsDB(gorm),
 		BridgeTransfers:    newBridgeTransfersDB(gorm),
 		BridgeMessages:     newBridgeMessagesDB(gorm),
 		BridgeTransactions: newBridgeTransactionsDB(gorm),
+		L2OutputProposals:  newL2OutputProposalsDB(gorm),
+
+		ERC721BridgeTransfers: newERC721BridgeTransfersDB(gorm),
+		BridgeEvents:          newBridgeEventsDB(gorm),
+		BridgeBackfill:        newBridgeBackfillDB(gorm),
 	}
 
 	return db, nil
@@ -85,7 +129,7 @@ func NewDB(log log.Logger, dbConfig config.DBConfig) (*DB, error) {
 // transaction. If the supplied function errors, the transaction is rolled back.
 func (db *DB) Transaction(fn func(db *DB) error) error {
 	return db.gorm.Transaction(func(tx *gorm.DB) error {
-		return fn(dbFromGormTx(tx))
+		return fn(dbFromGormTx(tx, db.schema))
 	})
 }
 
@@ -98,18 +142,33 @@ func (db *DB) Close() error {
 	return sql.Close()
 }
 
-func dbFromGormTx(tx *gorm.DB) *DB {
+func dbFromGormTx(tx *gorm.DB, schema string) *DB {
 	return &DB{
 		gorm:               tx,
+		schema:             schema,
 		Blocks:             newBlocksDB(tx),
 		ContractEvents:     newContractEventsDB(tx),
 		BridgeTransfers:    newBridgeTransfersDB(tx),
 		BridgeMessages:     newBridgeMessagesDB(tx),
 		BridgeTransactions: newBridgeTransactionsDB(tx),
+		L2OutputProposals:  newL2OutputProposalsDB(tx),
+
+		ERC721BridgeTransfers: newERC721BridgeTransfersDB(tx),
+		BridgeEvents:          newBridgeEventsDB(tx),
+		BridgeBackfill:        newBridgeBackfillDB(tx),
 	}
 }
 
+// ExecuteSQLMigration executes the migrations against the schema of the database. For an additional
+// L2 chain, the shared L1 tables are created in the `public` schema instead, which must already have
+// been migrated such that the chain's tables reference the shared L1 state.
 func (db *DB) ExecuteSQLMigration(migrationsFolder string) error {
+	if db.schema != "" {
+		if err := db.gorm.Exec("CREATE SCHEMA IF NOT EXISTS ?", clause.Table{Name: db.schema}).Error; err != nil {
+			return errors.Wrap(err, fmt.Sprintf("Error creating schema: %s", db.schema))
+		}
+	}
+
 	err := filepath.Walk(migrationsFolder, func(path string, info os.FileInfo, err error) error {
 		// Check for any walking error
 		if err != nil {
@@ -127,8 +186,13 @@ func (db *DB) ExecuteSQLMigration(migrationsFolder string) error {
 			return errors.Wrap(readErr, fmt.Sprintf("Error reading SQL file: %s", path))
 		}
 
+		migration := string(fileContent)
+		if db.schema != "" {
+			migration = sharedTablesRegexp.ReplaceAllString(migration, "CREATE TABLE IF NOT EXISTS public.$1")
+		}
+
 		// Execute the migration
-		execErr := db.gorm.Exec(string(fileContent)).Error
+		execErr := db.gorm.Exec(migration).Error
 		if execErr != nil {
 			return errors.Wrap(execErr, fmt.Sprintf("Error executing SQL script: %s", path))
 		}
@@ -0,0 +1,98 @@
+package database
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+/**
+ * Types
+ */
+
+type L2OutputProposal struct {
+	ProposedL1EventGUID uuid.UUID `gorm:"primaryKey"`
+	DeletedL1EventGUID  *uuid.UUID
+
+	OutputRoot    common.Hash `gorm:"serializer:bytes"`
+	L2OutputIndex *big.Int    `gorm:"serializer:u256"`
+	L2BlockNumber *big.Int    `gorm:"serializer:u256"`
+	Timestamp     uint64
+}
+
+type L2OutputProposalsView interface {
+	L2OutputProposal(*big.Int) (*L2OutputProposal, error)
+	L2OutputProposalCovering(*big.Int) (*L2OutputProposal, error)
+}
+
+type L2OutputProposalsDB interface {
+	L2OutputProposalsView
+
+	StoreL2OutputProposals([]L2OutputProposal) error
+	MarkL2OutputProposalsDeleted(*big.Int, *ContractEvent) error
+}
+
+/**
+ * Implementation
+ */
+
+type l2OutputProposalsDB struct {
+	gorm *gorm.DB
+}
+
+func newL2OutputProposalsDB(db *gorm.DB) L2OutputProposalsDB {
+	return &l2OutputProposalsDB{gorm: db}
+}
+
+// StoreL2OutputProposals stores the supplied proposals, skipping those already stored such that
+// the bridge processor can safely re-process epochs from its latest bridge markers
+func (db *l2OutputProposalsDB) StoreL2OutputProposals(proposals []L2OutputProposal) error {
+	result := db.gorm.Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(&proposals, batchInsertSize)
+	return result.Error
+}
+
+// L2OutputProposal retrieves the output proposal at the supplied index, unless it has been deleted
+func (db *l2OutputProposalsDB) L2OutputProposal(index *big.Int) (*L2OutputProposal, error) {
+	var proposal L2OutputProposal
+	result := db.gorm.Where("l2_output_index = ? AND deleted_l1_event_guid IS NULL", index).Take(&proposal)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	return &proposal, nil
+}
+
+// L2OutputProposalCovering retrieves the first output proposal that commits to the supplied L2
+// height, which is the output a withdrawal initiated at that height is proven against.
+func (db *l2OutputProposalsDB) L2OutputProposalCovering(l2BlockNumber *big.Int) (*L2OutputProposal, error) {
+	var proposal L2OutputProposal
+	result := db.gorm.Where("l2_block_number >= ? AND deleted_l1_event_guid IS NULL", l2BlockNumber).Order("l2_output_index ASC").Take(&proposal)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	return &proposal, nil
+}
+
+// MarkL2OutputProposalsDeleted marks all current output proposals from the supplied index onwards, proposed
+// prior to the deletion event, as deleted by the L2OutputOracle challenger.
+func (db *l2OutputProposalsDB) MarkL2OutputProposalsDeleted(fromIndex *big.Int, deletedL1Event *ContractEvent) error {
+	// L1 timestamps are strictly increasing, ordering the events by (timestamp, log index)
+	proposedBefore := db.gorm.Model(&L1ContractEvent{}).Select("guid").
+		Where("timestamp < ? OR (timestamp = ? AND log_index < ?)", deletedL1Event.Timestamp, deletedL1Event.Timestamp, deletedL1Event.LogIndex)
+
+	result := db.gorm.Model(&L2OutputProposal{}).Where("l2_output_index >= ? AND deleted_l1_event_guid IS NULL", fromIndex).
+		Where("proposed_l1_event_guid IN (?)", proposedBefore).Update("deleted_l1_event_guid", deletedL1Event.GUID)
+	return result.Error
+}
@@ -38,6 +38,17 @@ func (m *MockBlocksView) L1LatestBlockHeader() (*L1BlockHeader, error) {
 	return header, args.Error(1)
 }
 
+func (m *MockBlocksView) L1LatestBlockHeaderUpTo(*big.Int) (*L1BlockHeader, error) {
+	args := m.Called()
+
+	header, ok := args.Get(0).(*L1BlockHeader)
+	if !ok {
+		header = nil
+	}
+
+	return header, args.Error(1)
+}
+
 func (m *MockBlocksView) L2BlockHeader(common.Hash) (*L2BlockHeader, error) {
 	args := m.Called()
 	return args.Get(0).(*L2BlockHeader), args.Error(1)
@@ -53,6 +64,11 @@ func (m *MockBlocksView) L2LatestBlockHeader() (*L2BlockHeader, error) {
 	return args.Get(0).(*L2BlockHeader), args.Error(1)
 }
 
+func (m *MockBlocksView) L2LatestBlockHeaderUpTo(*big.Int) (*L2BlockHeader, error) {
+	args := m.Called()
+	return args.Get(0).(*L2BlockHeader), args.Error(1)
+}
+
 func (m *MockBlocksView) LatestObservedEpoch(*big.Int, uint64) (*Epoch, error) {
 	args := m.Called()
 	return args.Get(0).(*Epoch), args.Error(1)
@@ -72,6 +88,16 @@ func (m *MockBlocksDB) StoreL2BlockHeaders(headers []L2BlockHeader) error {
 	return args.Error(1)
 }
 
+func (m *MockBlocksDB) DeleteL1BlockHeadersAfter(height *big.Int, l2ChainSchemas []string) error {
+	args := m.Called(height, l2ChainSchemas)
+	return args.Error(0)
+}
+
+func (m *MockBlocksDB) DeleteL2BlockHeadersAfter(height *big.Int) error {
+	args := m.Called(height)
+	return args.Error(0)
+}
+
 // MockDB is a mock database that can be used for testing
 type MockDB struct {
 	MockBlocks *MockBlocksDB
@@ -0,0 +1,196 @@
+package e2e_tests
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/indexer/database"
+	op_e2e "github.com/ethereum-optimism/optimism/op-e2e"
+	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils/wait"
+	"github.com/ethereum-optimism/optimism/op-node/withdrawals"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-bindings/predeploys"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+
+	"github.com/stretchr/testify/require"
+)
+
+// The subset of the OptimismMintableERC721 interface used to mint, approve & inspect the test nft
+const optimismMintableERC721Abi = `[
+	{"type":"function","name":"safeMint","inputs":[{"name":"_to","type":"address"},{"name":"_tokenId","type":"uint256"}],"outputs":[],"stateMutability":"nonpayable"},
+	{"type":"function","name":"approve","inputs":[{"name":"to","type":"address"},{"name":"tokenId","type":"uint256"}],"outputs":[],"stateMutability":"nonpayable"},
+	{"type":"function","name":"ownerOf","inputs":[{"name":"tokenId","type":"uint256"}],"outputs":[{"name":"","type":"address"}],"stateMutability":"view"}
+]`
+
+// createOptimismMintableERC721 creates an nft via the supplied factory, returning the address of the token
+func createOptimismMintableERC721(t *testing.T, client *ethclient.Client, opts *bind.TransactOpts, factory *bindings.OptimismMintableERC721Factory, remoteToken common.Address) common.Address {
+	tx, err := factory.CreateOptimismMintableERC721(opts, remoteToken, "Test NFT", "TEST")
+	require.NoError(t, err)
+	receipt, err := wait.ForReceiptOK(context.Background(), client, tx.Hash())
+	require.NoError(t, err)
+
+	for _, log := range receipt.Logs {
+		created, err := factory.ParseOptimismMintableERC721Created(*log)
+		if err == nil {
+			return created.LocalToken
+		}
+	}
+
+	t.Fatal("missing OptimismMintableERC721Created event")
+	return common.Address{}
+}
+
+func transactOK(t *testing.T, client *ethclient.Client, tx *types.Transaction, err error) *types.Receipt {
+	require.NoError(t, err)
+	receipt, err := wait.ForReceiptOK(context.Background(), client, tx.Hash())
+	require.NoError(t, err)
+	return receipt
+}
+
+func TestE2EBridgeTransfersERC721DepositAndWithdrawal(t *testing.T) {
+	testSuite := createE2ETestSuite(t)
+
+	l1ERC721Bridge, err := bindings.NewL1ERC721Bridge(testSuite.OpCfg.L1Deployments.L1ERC721BridgeProxy, testSuite.L1Client)
+	require.NoError(t, err)
+	l2ERC721Bridge, err := bindings.NewL2ERC721Bridge(predeploys.L2ERC721BridgeAddr, testSuite.L2Client)
+	require.NoError(t, err)
+	l2ERC721Factory, err := bindings.NewOptimismMintableERC721Factory(predeploys.OptimismMintableERC721FactoryAddr, testSuite.L2Client)
+	require.NoError(t, err)
+	nftAbi, err := abi.JSON(strings.NewReader(optimismMintableERC721Abi))
+	require.NoError(t, err)
+
+	aliceAddr := testSuite.OpCfg.Secrets.Addresses().Alice
+	l1Opts, err := bind.NewKeyedTransactorWithChainID(testSuite.OpCfg.Secrets.Alice, testSuite.OpCfg.L1ChainIDBig())
+	require.NoError(t, err)
+	l2Opts, err := bind.NewKeyedTransactorWithChainID(testSuite.OpCfg.Secrets.Alice, testSuite.OpCfg.L2ChainIDBig())
+	require.NoError(t, err)
+
+	// The L1 nft is an OptimismMintableERC721 created by a factory for which Alice is the bridge,
+	// such that Alice can mint it. The L2 nft is created by the predeployed factory as usual
+	_, l1FactoryTx, l1ERC721Factory, err := bindings.DeployOptimismMintableERC721Factory(l1Opts, testSuite.L1Client, aliceAddr, testSuite.OpCfg.L2ChainIDBig())
+	transactOK(t, testSuite.L1Client, l1FactoryTx, err)
+	l1Token := createOptimismMintableERC721(t, testSuite.L1Client, l1Opts, l1ERC721Factory, predeploys.OptimismMintableERC721FactoryAddr)
+	l2Token := createOptimismMintableERC721(t, testSuite.L2Client, l2Opts, l2ERC721Factory, l1Token)
+
+	l1Nft := bind.NewBoundContract(l1Token, nftAbi, testSuite.L1Client, testSuite.L1Client, testSuite.L1Client)
+	l2Nft := bind.NewBoundContract(l2Token, nftAbi, testSuite.L2Client, testSuite.L2Client, testSuite.L2Client)
+	ownerOf := func(nft *bind.BoundContract, tokenId *big.Int) common.Address {
+		var out []interface{}
+		require.NoError(t, nft.Call(&bind.CallOpts{Context: context.Background()}, &out, "ownerOf", tokenId))
+		return out[0].(common.Address)
+	}
+
+	tokenId := big.NewInt(1)
+	tx, err := l1Nft.Transact(l1Opts, "safeMint", aliceAddr, tokenId)
+	transactOK(t, testSuite.L1Client, tx, err)
+	tx, err = l1Nft.Transact(l1Opts, "approve", testSuite.OpCfg.L1Deployments.L1ERC721BridgeProxy, tokenId)
+	transactOK(t, testSuite.L1Client, tx, err)
+
+	// (1) Test Deposit Initiation
+	depositTx, err := l1ERC721Bridge.BridgeERC721(l1Opts, l1Token, l2Token, tokenId, 200_000, []byte{byte(1)})
+	depositReceipt := transactOK(t, testSuite.L1Client, depositTx, err)
+	require.Equal(t, testSuite.OpCfg.L1Deployments.L1ERC721BridgeProxy, ownerOf(l1Nft, tokenId))
+
+	// wait for processor catchup
+	require.NoError(t, wait.For(context.Background(), 500*time.Millisecond, func() (bool, error) {
+		l1Header := testSuite.Indexer.BridgeProcessor.LatestL1Header
+		return l1Header != nil && l1Header.Number.Uint64() >= depositReceipt.BlockNumber.Uint64(), nil
+	}))
+
+	aliceDeposits, err := testSuite.DB.ERC721BridgeTransfers.L1ERC721BridgeDepositsByAddress(aliceAddr, "", 100)
+	require.NoError(t, err)
+	require.Len(t, aliceDeposits.Deposits, 1)
+	require.Equal(t, depositTx.Hash(), aliceDeposits.Deposits[0].L1TransactionHash)
+	require.Equal(t, depositReceipt.BlockHash, aliceDeposits.Deposits[0].L1BlockHash)
+
+	deposit := aliceDeposits.Deposits[0].L1ERC721BridgeDeposit
+	require.Equal(t, l1Token, deposit.TokenPair.LocalTokenAddress)
+	require.Equal(t, l2Token, deposit.TokenPair.RemoteTokenAddress)
+	require.Equal(t, tokenId.Uint64(), deposit.TokenID.Uint64())
+	require.Equal(t, aliceAddr, deposit.FromAddress)
+	require.Equal(t, aliceAddr, deposit.ToAddress)
+	require.Equal(t, byte(1), deposit.Data[0])
+	require.NotNil(t, deposit.CrossDomainMessageHash)
+
+	// storing an already indexed deposit is a no-op
+	require.NoError(t, testSuite.DB.ERC721BridgeTransfers.StoreL1ERC721BridgeDeposits([]database.L1ERC721BridgeDeposit{deposit}))
+
+	// (2) Test Deposit Finalization via CrossDomainMessenger relayed message
+	l2DepositReceipt, err := wait.ForReceiptOK(context.Background(), testSuite.L2Client, aliceDeposits.Deposits[0].L2TransactionHash)
+	require.NoError(t, err)
+	require.NoError(t, wait.For(context.Background(), 500*time.Millisecond, func() (bool, error) {
+		l2Header := testSuite.Indexer.BridgeProcessor.LatestL2Header
+		return l2Header != nil && l2Header.Number.Uint64() >= l2DepositReceipt.BlockNumber.Uint64(), nil
+	}))
+	require.Equal(t, aliceAddr, ownerOf(l2Nft, tokenId))
+
+	crossDomainBridgeMessage, err := testSuite.DB.BridgeMessages.L1BridgeMessage(*deposit.CrossDomainMessageHash)
+	require.NoError(t, err)
+	require.NotNil(t, crossDomainBridgeMessage)
+	require.NotNil(t, crossDomainBridgeMessage.RelayedMessageEventGUID)
+
+	// (3) Test Withdrawal Initiation
+	withdrawTx, err := l2ERC721Bridge.BridgeERC721(l2Opts, l2Token, l1Token, tokenId, 200_000, []byte{byte(1)})
+	withdrawReceipt := transactOK(t, testSuite.L2Client, withdrawTx, err)
+
+	// wait for processor catchup
+	require.NoError(t, wait.For(context.Background(), 500*time.Millisecond, func() (bool, error) {
+		l2Header := testSuite.Indexer.BridgeProcessor.LatestL2Header
+		return l2Header != nil && l2Header.Number.Uint64() >= withdrawReceipt.BlockNumber.Uint64(), nil
+	}))
+
+	aliceWithdrawals, err := testSuite.DB.ERC721BridgeTransfers.L2ERC721BridgeWithdrawalsByAddress(aliceAddr, "", 100)
+	require.NoError(t, err)
+	require.Len(t, aliceWithdrawals.Withdrawals, 1)
+	require.Equal(t, withdrawTx.Hash(), aliceWithdrawals.Withdrawals[0].L2TransactionHash)
+	require.Equal(t, withdrawReceipt.BlockHash, aliceWithdrawals.Withdrawals[0].L2BlockHash)
+	require.Empty(t, aliceWithdrawals.Withdrawals[0].ProvenL1TransactionHash)
+	require.Empty(t, aliceWithdrawals.Withdrawals[0].FinalizedL1TransactionHash)
+
+	msgPassed, err := withdrawals.ParseMessagePassed(withdrawReceipt)
+	require.NoError(t, err)
+	withdrawalHash, err := withdrawals.WithdrawalHash(msgPassed)
+	require.NoError(t, err)
+
+	withdrawal := aliceWithdrawals.Withdrawals[0].L2ERC721BridgeWithdrawal
+	require.Equal(t, withdrawalHash, withdrawal.TransactionWithdrawalHash)
+	require.Equal(t, l2Token, withdrawal.TokenPair.LocalTokenAddress)
+	require.Equal(t, l1Token, withdrawal.TokenPair.RemoteTokenAddress)
+	require.Equal(t, tokenId.Uint64(), withdrawal.TokenID.Uint64())
+	require.Equal(t, aliceAddr, withdrawal.FromAddress)
+	require.Equal(t, aliceAddr, withdrawal.ToAddress)
+	require.Equal(t, byte(1), withdrawal.Data[0])
+	require.NotNil(t, withdrawal.CrossDomainMessageHash)
+
+	// storing an already indexed withdrawal is a no-op
+	require.NoError(t, testSuite.DB.ERC721BridgeTransfers.StoreL2ERC721BridgeWithdrawals([]database.L2ERC721BridgeWithdrawal{withdrawal}))
+
+	// (4) Test Withdrawal Proven/Finalized
+	proveReceipt, finalizeReceipt := op_e2e.ProveAndFinalizeWithdrawal(t, *testSuite.OpCfg, testSuite.L1Client, testSuite.OpSys.EthInstances["sequencer"], testSuite.OpCfg.Secrets.Alice, withdrawReceipt)
+	require.NoError(t, wait.For(context.Background(), 500*time.Millisecond, func() (bool, error) {
+		l1Header := testSuite.Indexer.BridgeProcessor.LatestL1Header
+		return l1Header != nil && l1Header.Number.Uint64() >= finalizeReceipt.BlockNumber.Uint64(), nil
+	}))
+	require.Equal(t, aliceAddr, ownerOf(l1Nft, tokenId))
+
+	aliceWithdrawals, err = testSuite.DB.ERC721BridgeTransfers.L2ERC721BridgeWithdrawalsByAddress(aliceAddr, "", 100)
+	require.NoError(t, err)
+	require.Len(t, aliceWithdrawals.Withdrawals, 1)
+	require.Equal(t, proveReceipt.TxHash, aliceWithdrawals.Withdrawals[0].ProvenL1TransactionHash)
+	require.Equal(t, finalizeReceipt.TxHash, aliceWithdrawals.Withdrawals[0].FinalizedL1TransactionHash)
+	require.NotNil(t, aliceWithdrawals.Withdrawals[0].ProvenL2OutputIndex)
+	require.NotNil(t, aliceWithdrawals.Withdrawals[0].ProvenL2OutputTimestamp)
+
+	l2CrossDomainBridgeMessage, err := testSuite.DB.BridgeMessages.L2BridgeMessage(*withdrawal.CrossDomainMessageHash)
+	require.NoError(t, err)
+	require.NotNil(t, l2CrossDomainBridgeMessage)
+	require.NotNil(t, l2CrossDomainBridgeMessage.RelayedMessageEventGUID)
+}
@@ -0,0 +1,91 @@
+package e2e_tests
+
+import (
+	"math/big"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/indexer/config"
+	"github.com/ethereum-optimism/optimism/indexer/database"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestE2EBridgeEventsVisibleInOrder(t *testing.T) {
+	dbName := setupTestDatabase(t)
+	silentLog := log.New()
+	silentLog.SetHandler(log.DiscardHandler())
+	db, err := database.NewDB(silentLog, config.DBConfig{Host: "127.0.0.1", Port: 5432, Name: dbName, User: os.Getenv("DB_USER")})
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	// bridge events reference the contract event that emitted them
+	header := database.L1BlockHeader{BlockHeader: database.BlockHeaderFromHeader(&types.Header{Number: big.NewInt(1), Time: 1})}
+	require.NoError(t, db.Blocks.StoreL1BlockHeaders([]database.L1BlockHeader{header}))
+	contractEvents := make([]database.L1ContractEvent, 2)
+	bridgeEvents := make([]database.BridgeEvent, 2)
+	for i := range contractEvents {
+		contractEvents[i] = database.L1ContractEvent{ContractEvent: database.ContractEventFromLog(&types.Log{BlockHash: header.Hash, Index: uint(i)}, 1)}
+		bridgeEvents[i] = database.BridgeEvent{
+			L1ContractEventGUID: &contractEvents[i].GUID,
+			EventType:           database.BridgeEventDepositInitiated,
+			TransferHash:        common.BigToHash(big.NewInt(int64(i))),
+			Timestamp:           1,
+		}
+	}
+	require.NoError(t, db.ContractEvents.StoreL1ContractEvents(contractEvents))
+
+	// The first writer stores its event but is yet to commit. The second writer is
+	// blocked from storing its event, with a greater id, until the first commits
+	firstStored, commitFirst := make(chan struct{}), make(chan struct{})
+	firstDone, secondDone := make(chan error, 1), make(chan error, 1)
+	go func() {
+		firstDone <- db.Transaction(func(tx *database.DB) error {
+			if err := tx.BridgeEvents.StoreBridgeEvents(bridgeEvents[:1]); err != nil {
+				return err
+			}
+			close(firstStored)
+			<-commitFirst
+			return nil
+		})
+	}()
+	<-firstStored
+
+	go func() {
+		secondDone <- db.Transaction(func(tx *database.DB) error {
+			return tx.BridgeEvents.StoreBridgeEvents(bridgeEvents[1:])
+		})
+	}()
+
+	select {
+	case err := <-secondDone:
+		t.Fatalf("second writer committed ahead of the first: %v", err)
+	case <-time.After(time.Second):
+	}
+
+	latestID, err := db.BridgeEvents.LatestBridgeEventID()
+	require.NoError(t, err)
+	require.Zero(t, latestID)
+
+	close(commitFirst)
+	require.NoError(t, <-firstDone)
+	require.NoError(t, <-secondDone)
+
+	events, err := db.BridgeEvents.BridgeEvents(database.BridgeEventsFilter{}, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	require.Less(t, events[0].ID, events[1].ID)
+	require.Equal(t, bridgeEvents[0].TransferHash, events[0].TransferHash)
+	require.Equal(t, bridgeEvents[1].TransferHash, events[1].TransferHash)
+
+	// storing already stored events is a no-op
+	require.NoError(t, db.BridgeEvents.StoreBridgeEvents(bridgeEvents))
+	latestID, err = db.BridgeEvents.LatestBridgeEventID()
+	require.NoError(t, err)
+	require.Equal(t, events[1].ID, latestID)
+}
@@ -147,6 +147,15 @@ func TestE2EBridgeTransactionsL2ToL1MessagePasserWithdrawal(t *testing.T) {
 	require.NotNil(t, event)
 	require.Equal(t, proveEvent.TransactionHash, proveReceipt.TxHash)
 
+	// the output proven against is decoded from the proving transaction
+	require.NotNil(t, withdraw.ProvenL2OutputIndex)
+	require.NotNil(t, withdraw.ProvenOutputRoot)
+	require.Equal(t, withdrawParams.L2OutputIndex.Uint64(), withdraw.ProvenL2OutputIndex.Uint64())
+	provenOutput, err := testSuite.DB.L2OutputProposals.L2OutputProposal(withdrawParams.L2OutputIndex)
+	require.NoError(t, err)
+	require.NotNil(t, provenOutput)
+	require.Equal(t, provenOutput.OutputRoot, *withdraw.ProvenOutputRoot)
+
 	// Test Withdrawal Finalized
 	require.Nil(t, withdraw.FinalizedL1EventGUID)
 
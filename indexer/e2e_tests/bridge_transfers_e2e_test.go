@@ -4,10 +4,12 @@ import (
 	"context"
 	"crypto/ecdsa"
 	"fmt"
+	"math"
 	"math/big"
 	"testing"
 	"time"
 
+	"github.com/ethereum-optimism/optimism/indexer/database"
 	e2etest_utils "github.com/ethereum-optimism/optimism/indexer/e2e_tests/utils"
 	op_e2e "github.com/ethereum-optimism/optimism/op-e2e"
 	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils/transactions"
@@ -516,3 +518,161 @@ func TestClientGetWithdrawals(t *testing.T) {
 	}
 
 }
+
+func TestE2EBridgeTransfersAggregates(t *testing.T) {
+	testSuite := createE2ETestSuite(t)
+
+	l1StandardBridge, err := bindings.NewL1StandardBridge(testSuite.OpCfg.L1Deployments.L1StandardBridgeProxy, testSuite.L1Client)
+	require.NoError(t, err)
+	optimismPortal, err := bindings.NewOptimismPortal(testSuite.OpCfg.L1Deployments.OptimismPortalProxy, testSuite.L1Client)
+	require.NoError(t, err)
+	l2StandardBridge, err := bindings.NewL2StandardBridge(predeploys.L2StandardBridgeAddr, testSuite.L2Client)
+	require.NoError(t, err)
+
+	l1Opts, err := bind.NewKeyedTransactorWithChainID(testSuite.OpCfg.Secrets.Alice, testSuite.OpCfg.L1ChainIDBig())
+	require.NoError(t, err)
+	l2Opts, err := bind.NewKeyedTransactorWithChainID(testSuite.OpCfg.Secrets.Alice, testSuite.OpCfg.L2ChainIDBig())
+	require.NoError(t, err)
+
+	// The aggregates span all indexed transfers, so expectations are relative to the state prior to the test
+	ethDailyVolumes := func() map[uint64]database.TokenVolume {
+		volumes, err := testSuite.DB.BridgeTransfers.L1BridgeDepositsDailyVolume(0, math.MaxInt64)
+		require.NoError(t, err)
+
+		ethVolumes := make(map[uint64]database.TokenVolume)
+		for _, volume := range volumes {
+			require.Zero(t, volume.Day%86400)
+			if volume.TokenAddress == predeploys.LegacyERC20ETHAddr {
+				ethVolumes[volume.Day] = volume
+			}
+		}
+		return ethVolumes
+	}
+	ethValueLocked := func() (*big.Int, *big.Int) {
+		locked, err := testSuite.DB.BridgeTransfers.BridgeValueLocked()
+		require.NoError(t, err)
+		for _, token := range locked {
+			if token.L1TokenAddress == predeploys.LegacyERC20ETHAddr {
+				return token.Deposited, token.Withdrawn
+			}
+		}
+		return big.NewInt(0), big.NewInt(0)
+	}
+	pendingWithdrawals := func() database.PendingWithdrawals {
+		pending, err := testSuite.DB.BridgeTransfers.L2PendingWithdrawals()
+		require.NoError(t, err)
+		require.NotNil(t, pending)
+		return *pending
+	}
+
+	volumesBefore := ethDailyVolumes()
+	depositedBefore, withdrawnBefore := ethValueLocked()
+	pendingBefore := pendingWithdrawals()
+
+	// (1) Deposit 1 ETH via the StandardBridge & 2 ETH via the OptimismPortal
+	l1Opts.Value = big.NewInt(params.Ether)
+	depositTx, err := l1StandardBridge.DepositETH(l1Opts, 200_000, []byte{byte(1)})
+	require.NoError(t, err)
+	depositReceipt, err := wait.ForReceiptOK(context.Background(), testSuite.L1Client, depositTx.Hash())
+	require.NoError(t, err)
+
+	l1Opts.Value = big.NewInt(2 * params.Ether)
+	portalDepositTx, err := optimismPortal.Receive(l1Opts)
+	require.NoError(t, err)
+	portalDepositReceipt, err := wait.ForReceiptOK(context.Background(), testSuite.L1Client, portalDepositTx.Hash())
+	require.NoError(t, err)
+
+	require.NoError(t, wait.For(context.Background(), 500*time.Millisecond, func() (bool, error) {
+		l1Header := testSuite.Indexer.BridgeProcessor.LatestL1Header
+		return l1Header != nil && l1Header.Number.Uint64() >= portalDepositReceipt.BlockNumber.Uint64(), nil
+	}))
+
+	// deposits are grouped by the UTC day they were initiated in
+	expectedVolumes := make(map[uint64]database.TokenVolume)
+	for day, volume := range volumesBefore {
+		expectedVolumes[day] = volume
+	}
+	for _, deposit := range []struct {
+		receipt *types.Receipt
+		amount  int64
+	}{{depositReceipt, params.Ether}, {portalDepositReceipt, 2 * params.Ether}} {
+		header, err := testSuite.L1Client.HeaderByNumber(context.Background(), deposit.receipt.BlockNumber)
+		require.NoError(t, err)
+
+		day := header.Time - header.Time%86400
+		volume, ok := expectedVolumes[day]
+		if !ok {
+			volume = database.TokenVolume{Day: day, TokenAddress: predeploys.LegacyERC20ETHAddr, Amount: big.NewInt(0)}
+		}
+		volume.Count++
+		volume.Amount = new(big.Int).Add(volume.Amount, big.NewInt(deposit.amount))
+		expectedVolumes[day] = volume
+	}
+
+	volumes := ethDailyVolumes()
+	require.Len(t, volumes, len(expectedVolumes))
+	for day, expected := range expectedVolumes {
+		require.Equal(t, expected.Count, volumes[day].Count)
+		require.Equal(t, expected.Amount.String(), volumes[day].Amount.String())
+	}
+
+	// an inclusive range covering only the first deposit
+	header, err := testSuite.L1Client.HeaderByNumber(context.Background(), depositReceipt.BlockNumber)
+	require.NoError(t, err)
+	rangeVolumes, err := testSuite.DB.BridgeTransfers.L1BridgeDepositsDailyVolume(header.Time, header.Time)
+	require.NoError(t, err)
+	require.Len(t, rangeVolumes, 1)
+	require.Equal(t, predeploys.LegacyERC20ETHAddr, rangeVolumes[0].TokenAddress)
+	require.Equal(t, uint64(1), rangeVolumes[0].Count)
+	require.Equal(t, int64(params.Ether), rangeVolumes[0].Amount.Int64())
+
+	deposited, withdrawn := ethValueLocked()
+	require.Equal(t, new(big.Int).Add(depositedBefore, big.NewInt(3*params.Ether)).String(), deposited.String())
+	require.Equal(t, withdrawnBefore.String(), withdrawn.String())
+
+	// (2) Withdraw 1 ETH, which is pending until finalized
+	l2Opts.Value = big.NewInt(params.Ether)
+	withdrawTx, err := l2StandardBridge.Withdraw(l2Opts, predeploys.LegacyERC20ETHAddr, l2Opts.Value, 200_000, []byte{byte(1)})
+	require.NoError(t, err)
+	withdrawReceipt, err := wait.ForReceiptOK(context.Background(), testSuite.L2Client, withdrawTx.Hash())
+	require.NoError(t, err)
+
+	require.NoError(t, wait.For(context.Background(), 500*time.Millisecond, func() (bool, error) {
+		l2Header := testSuite.Indexer.BridgeProcessor.LatestL2Header
+		return l2Header != nil && l2Header.Number.Uint64() >= withdrawReceipt.BlockNumber.Uint64(), nil
+	}))
+
+	pending := pendingWithdrawals()
+	require.Equal(t, pendingBefore.Initiated+pendingBefore.ReadyToProve+1, pending.Initiated+pending.ReadyToProve)
+	require.Equal(t, pendingBefore.Proven, pending.Proven)
+
+	// ready to be proven once an output proposal covering the withdrawal is indexed
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+	require.NoError(t, wait.For(ctx, 500*time.Millisecond, func() (bool, error) {
+		pending := pendingWithdrawals()
+		return pending.ReadyToProve == pendingBefore.ReadyToProve+1, nil
+	}))
+
+	withdrawParams, proveReceipt := op_e2e.ProveWithdrawal(t, *testSuite.OpCfg, testSuite.L1Client, testSuite.OpSys.EthInstances["sequencer"], testSuite.OpCfg.Secrets.Alice, withdrawReceipt)
+	require.NoError(t, wait.For(context.Background(), 500*time.Millisecond, func() (bool, error) {
+		l1Header := testSuite.Indexer.BridgeProcessor.LatestL1Header
+		return l1Header != nil && l1Header.Number.Uint64() >= proveReceipt.BlockNumber.Uint64(), nil
+	}))
+
+	pending = pendingWithdrawals()
+	require.Equal(t, pendingBefore, database.PendingWithdrawals{Initiated: pending.Initiated, ReadyToProve: pending.ReadyToProve, Proven: pending.Proven - 1})
+
+	// (3) Finalized withdrawals are no longer pending & release the locked value
+	finalizeReceipt := op_e2e.FinalizeWithdrawal(t, *testSuite.OpCfg, testSuite.L1Client, testSuite.OpCfg.Secrets.Alice, proveReceipt, withdrawParams)
+	require.NoError(t, wait.For(context.Background(), 500*time.Millisecond, func() (bool, error) {
+		l1Header := testSuite.Indexer.BridgeProcessor.LatestL1Header
+		return l1Header != nil && l1Header.Number.Uint64() >= finalizeReceipt.BlockNumber.Uint64(), nil
+	}))
+
+	require.Equal(t, pendingBefore, pendingWithdrawals())
+
+	deposited, withdrawn = ethValueLocked()
+	require.Equal(t, new(big.Int).Add(depositedBefore, big.NewInt(3*params.Ether)).String(), deposited.String())
+	require.Equal(t, new(big.Int).Add(withdrawnBefore, big.NewInt(params.Ether)).String(), withdrawn.String())
+}
@@ -71,6 +71,18 @@ func TestE2EETL(t *testing.T) {
 		}
 	})
 
+	t.Run("indexes the head of the L1 traversal", func(t *testing.T) {
+		// the last block of every batch is indexed, regardless of contract events, so that the
+		// confirmation depth of indexed state is measured from the head of the traversal
+		latestL1Header, err := testSuite.DB.Blocks.L1LatestBlockHeader()
+		require.NoError(t, err)
+		require.NotNil(t, latestL1Header)
+
+		header, err := testSuite.L1Client.HeaderByNumber(context.Background(), latestL1Header.Number)
+		require.NoError(t, err)
+		require.Equal(t, header.Hash(), latestL1Header.Hash)
+	})
+
 	t.Run("indexes L1 blocks with accompanying contract event", func(t *testing.T) {
 		l1Contracts := []common.Address{}
 		testSuite.OpCfg.L1Deployments.ForEach(func(name string, addr common.Address) { l1Contracts = append(l1Contracts, addr) })
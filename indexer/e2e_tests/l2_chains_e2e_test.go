@@ -0,0 +1,150 @@
+package e2e_tests
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/indexer/client"
+	"github.com/ethereum-optimism/optimism/indexer/config"
+	"github.com/ethereum-optimism/optimism/indexer/database"
+	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils/wait"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestE2EL2ChainsSharedL1ETL(t *testing.T) {
+	l2ChainID := uint(902)
+	testSuite := createE2ETestSuite(t, l2ChainID)
+	l2ChainDB := testSuite.L2ChainDBs[l2ChainID]
+	l2ChainProcessor := testSuite.Indexer.L2Chains[0].BridgeProcessor
+
+	l1StandardBridge, err := bindings.NewL1StandardBridge(testSuite.OpCfg.L1Deployments.L1StandardBridgeProxy, testSuite.L1Client)
+	require.NoError(t, err)
+
+	aliceAddr := testSuite.OpCfg.Secrets.Addresses().Alice
+	l1Opts, err := bind.NewKeyedTransactorWithChainID(testSuite.OpCfg.Secrets.Alice, testSuite.OpCfg.L1ChainIDBig())
+	require.NoError(t, err)
+	l1Opts.Value = big.NewInt(params.Ether)
+
+	depositTx, err := l1StandardBridge.DepositETH(l1Opts, 200_000, []byte{byte(1)})
+	require.NoError(t, err)
+	depositReceipt, err := wait.ForReceiptOK(context.Background(), testSuite.L1Client, depositTx.Hash())
+	require.NoError(t, err)
+
+	// wait for the processors of both chains to catch up
+	require.NoError(t, wait.For(context.Background(), 500*time.Millisecond, func() (bool, error) {
+		l1Header, l2ChainL1Header := testSuite.Indexer.BridgeProcessor.LatestL1Header, l2ChainProcessor.LatestL1Header
+		return l1Header != nil && l1Header.Number.Uint64() >= depositReceipt.BlockNumber.Uint64() &&
+			l2ChainL1Header != nil && l2ChainL1Header.Number.Uint64() >= depositReceipt.BlockNumber.Uint64(), nil
+	}))
+
+	// The deposit is indexed by each chain, in its own schema
+	deposits, err := testSuite.DB.BridgeTransfers.L1BridgeDepositsByAddress(aliceAddr, "", 100)
+	require.NoError(t, err)
+	require.Len(t, deposits.Deposits, 1)
+	l2ChainDeposits, err := l2ChainDB.BridgeTransfers.L1BridgeDepositsByAddress(aliceAddr, "", 100)
+	require.NoError(t, err)
+	require.Len(t, l2ChainDeposits.Deposits, 1)
+	require.Equal(t, depositTx.Hash(), l2ChainDeposits.Deposits[0].L1TransactionHash)
+	require.Equal(t, deposits.Deposits[0].L1BridgeDeposit.TransactionSourceHash, l2ChainDeposits.Deposits[0].L1BridgeDeposit.TransactionSourceHash)
+
+	// against the same L1 state, extracted once by the shared L1ETL
+	deposit, err := testSuite.DB.BridgeTransactions.L1TransactionDeposit(deposits.Deposits[0].L1BridgeDeposit.TransactionSourceHash)
+	require.NoError(t, err)
+	l2ChainDeposit, err := l2ChainDB.BridgeTransactions.L1TransactionDeposit(deposit.SourceHash)
+	require.NoError(t, err)
+	require.Equal(t, deposit.InitiatedL1EventGUID, l2ChainDeposit.InitiatedL1EventGUID)
+
+	event, err := l2ChainDB.ContractEvents.L1ContractEvent(l2ChainDeposit.InitiatedL1EventGUID)
+	require.NoError(t, err)
+	require.NotNil(t, event)
+	require.Equal(t, depositTx.Hash(), event.TransactionHash)
+
+	// Both chains are served by the api, selected by the chain id
+	for _, chainID := range []uint{0, uint(testSuite.OpCfg.DeployConfig.L2ChainID), l2ChainID} {
+		chainClient, err := client.NewClient(&client.Config{
+			PaginationLimit: 100,
+			BaseURL:         fmt.Sprintf("http://127.0.0.1:%d", testSuite.API.Port()),
+			L2ChainID:       chainID,
+		})
+		require.NoError(t, err)
+
+		aliceDeposits, err := chainClient.GetAllDepositsByAddress(aliceAddr)
+		require.NoError(t, err)
+		require.Len(t, aliceDeposits, 1)
+		require.Equal(t, depositTx.Hash().String(), aliceDeposits[0].L1TxHash)
+	}
+}
+
+func TestE2EL2ChainsL1Rewind(t *testing.T) {
+	dbName := setupTestDatabase(t)
+	silentLog := log.New()
+	silentLog.SetHandler(log.DiscardHandler())
+	dbConfig := config.DBConfig{Host: "127.0.0.1", Port: 5432, Name: dbName, User: os.Getenv("DB_USER")}
+
+	db, err := database.NewDB(silentLog, dbConfig)
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	l2ChainID := uint(902)
+	l2ChainDB, err := database.NewL2ChainDB(silentLog, dbConfig, l2ChainID)
+	require.NoError(t, err)
+	t.Cleanup(func() { l2ChainDB.Close() })
+	require.NoError(t, l2ChainDB.ExecuteSQLMigration("../migrations"))
+
+	// L1 state is shared by both chains, finalizing a withdrawal of each
+	l1Headers := []database.L1BlockHeader{
+		{BlockHeader: database.BlockHeaderFromHeader(&types.Header{Number: big.NewInt(1), Time: 1})},
+		{BlockHeader: database.BlockHeaderFromHeader(&types.Header{Number: big.NewInt(2), Time: 2})},
+	}
+	require.NoError(t, db.Blocks.StoreL1BlockHeaders(l1Headers))
+	finalizedEvent := database.L1ContractEvent{ContractEvent: database.ContractEventFromLog(&types.Log{BlockHash: l1Headers[1].Hash}, 2)}
+	require.NoError(t, db.ContractEvents.StoreL1ContractEvents([]database.L1ContractEvent{finalizedEvent}))
+
+	withdrawalHash := common.HexToHash("0x1")
+	for _, chainDB := range []*database.DB{db, l2ChainDB} {
+		l2Header := database.L2BlockHeader{BlockHeader: database.BlockHeaderFromHeader(&types.Header{Number: big.NewInt(1), Time: 1})}
+		require.NoError(t, chainDB.Blocks.StoreL2BlockHeaders([]database.L2BlockHeader{l2Header}))
+		initiatedEvent := database.L2ContractEvent{ContractEvent: database.ContractEventFromLog(&types.Log{BlockHash: l2Header.Hash}, 1)}
+		require.NoError(t, chainDB.ContractEvents.StoreL2ContractEvents([]database.L2ContractEvent{initiatedEvent}))
+
+		withdrawal := database.L2TransactionWithdrawal{
+			WithdrawalHash:       withdrawalHash,
+			Nonce:                big.NewInt(0),
+			InitiatedL2EventGUID: initiatedEvent.GUID,
+			Tx:                   database.Transaction{Amount: big.NewInt(1), Data: []byte{}, Timestamp: 1},
+			GasLimit:             big.NewInt(0),
+		}
+		require.NoError(t, chainDB.BridgeTransactions.StoreL2TransactionWithdrawals([]database.L2TransactionWithdrawal{withdrawal}))
+		require.NoError(t, chainDB.BridgeTransactions.MarkL2TransactionWithdrawalFinalizedEvent(withdrawalHash, finalizedEvent.GUID, true))
+	}
+
+	// Rewinding the finalizing L1 block reverts the withdrawal of both chains
+	require.NoError(t, db.Transaction(func(tx *database.DB) error {
+		return tx.Blocks.DeleteL1BlockHeadersAfter(big.NewInt(1), []string{database.L2ChainSchema(l2ChainID)})
+	}))
+
+	for _, chainDB := range []*database.DB{db, l2ChainDB} {
+		withdrawal, err := chainDB.BridgeTransactions.L2TransactionWithdrawal(withdrawalHash)
+		require.NoError(t, err)
+		require.NotNil(t, withdrawal)
+		require.Nil(t, withdrawal.FinalizedL1EventGUID)
+		require.Nil(t, withdrawal.Succeeded)
+	}
+
+	// The L1 state is only held once
+	l1Header, err := l2ChainDB.Blocks.L1LatestBlockHeader()
+	require.NoError(t, err)
+	require.Equal(t, l1Headers[0].Hash, l1Header.Hash)
+}
@@ -40,6 +40,9 @@ type E2ETestSuite struct {
 	DB      *database.DB
 	Indexer *indexer.Indexer
 
+	// Additional L2 chains, keyed by chain id
+	L2ChainDBs map[uint]*database.DB
+
 	// Rollup
 	OpCfg *op_e2e.SystemConfig
 	OpSys *op_e2e.System
@@ -49,8 +52,9 @@ type E2ETestSuite struct {
 	L2Client *ethclient.Client
 }
 
-// createE2ETestSuite ... Create a new E2E test suite
-func createE2ETestSuite(t *testing.T) E2ETestSuite {
+// createE2ETestSuite ... Create a new E2E test suite. The rollup only runs a single L2 chain, which
+// is additionally indexed under each of the supplied chain ids as if it were another chain
+func createE2ETestSuite(t *testing.T, l2ChainIDs ...uint) E2ETestSuite {
 	dbUser := os.Getenv("DB_USER")
 	dbName := setupTestDatabase(t)
 
@@ -85,9 +89,12 @@ func createE2ETestSuite(t *testing.T) E2ETestSuite {
 			L2RPC: opSys.EthInstances["sequencer"].HTTPEndpoint(),
 		},
 		Chain: config.ChainConfig{
+			L2ChainID:         uint(opCfg.DeployConfig.L2ChainID),
 			L1PollingInterval: uint(opCfg.DeployConfig.L1BlockTime) * 1000,
 			L2PollingInterval: uint(opCfg.DeployConfig.L2BlockTime) * 1000,
 			L2Contracts:       config.L2ContractsFromPredeploys(),
+
+			FinalizationPeriodSeconds: uint(opCfg.DeployConfig.FinalizationPeriodSeconds),
 			L1Contracts: config.L1Contracts{
 				AddressManager:              opCfg.L1Deployments.AddressManager,
 				SystemConfigProxy:           opCfg.L1Deployments.SystemConfigProxy,
@@ -113,8 +120,30 @@ func createE2ETestSuite(t *testing.T) E2ETestSuite {
 	require.NoError(t, err)
 	t.Cleanup(func() { db.Close() })
 
+	l2ChainDBs := make([]indexer.L2ChainDB, len(l2ChainIDs))
+	l2Chains := make([]api.L2ChainViews, len(l2ChainIDs))
+	l2ChainDBsByID := make(map[uint]*database.DB, len(l2ChainIDs))
+	for i, l2ChainID := range l2ChainIDs {
+		l2ChainDB, err := database.NewL2ChainDB(silentLog, indexerCfg.DB, l2ChainID)
+		require.NoError(t, err)
+		t.Cleanup(func() { l2ChainDB.Close() })
+		require.NoError(t, l2ChainDB.ExecuteSQLMigration("../migrations"))
+
+		l2ChainConfig := config.L2ChainConfig{ChainConfig: indexerCfg.Chain, L2RPC: indexerCfg.RPCs.L2RPC}
+		l2ChainConfig.L2ChainID = l2ChainID
+		l2ChainDBs[i] = indexer.L2ChainDB{Config: l2ChainConfig, DB: l2ChainDB}
+		l2Chains[i] = api.L2ChainViews{
+			ChainConfig:           l2ChainConfig.ChainConfig,
+			BridgeTransfers:       l2ChainDB.BridgeTransfers,
+			ERC721BridgeTransfers: l2ChainDB.ERC721BridgeTransfers,
+			BridgeEvents:          l2ChainDB.BridgeEvents,
+			Blocks:                l2ChainDB.Blocks,
+		}
+		l2ChainDBsByID[l2ChainID] = l2ChainDB
+	}
+
 	indexerLog := testlog.Logger(t, log.LvlInfo).New("role", "indexer")
-	indexer, err := indexer.NewIndexer(indexerLog, db, indexerCfg.Chain, indexerCfg.RPCs, indexerCfg.HTTPServer, indexerCfg.MetricsServer)
+	indexer, err := indexer.NewIndexer(indexerLog, db, indexerCfg.Chain, indexerCfg.RPCs, indexerCfg.HTTPServer, indexerCfg.MetricsServer, l2ChainDBs)
 	require.NoError(t, err)
 
 	indexerCtx, indexerStop := context.WithCancel(context.Background())
@@ -139,7 +168,7 @@ func createE2ETestSuite(t *testing.T) E2ETestSuite {
 		Port: 0,
 	}
 
-	api := api.NewApi(apiLog, db.BridgeTransfers, apiCfg, mCfg)
+	api := api.NewApi(apiLog, db.BridgeTransfers, db.ERC721BridgeTransfers, db.BridgeEvents, db.Blocks, indexerCfg.Chain, apiCfg, mCfg, l2Chains)
 	apiCtx, apiStop := context.WithCancel(context.Background())
 	go func() {
 		err := api.Run(apiCtx)
@@ -164,14 +193,16 @@ func createE2ETestSuite(t *testing.T) E2ETestSuite {
 	require.NoError(t, err)
 
 	return E2ETestSuite{
-		t:        t,
-		Client:   client,
-		DB:       db,
-		Indexer:  indexer,
-		OpCfg:    &opCfg,
-		OpSys:    opSys,
-		L1Client: opSys.Clients["l1"],
-		L2Client: opSys.Clients["sequencer"],
+		t:          t,
+		Client:     client,
+		API:        api,
+		DB:         db,
+		Indexer:    indexer,
+		L2ChainDBs: l2ChainDBsByID,
+		OpCfg:      &opCfg,
+		OpSys:      opSys,
+		L1Client:   opSys.Clients["l1"],
+		L2Client:   opSys.Clients["sequencer"],
 	}
 }
 
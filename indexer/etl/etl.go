@@ -3,9 +3,13 @@ package etl
 import (
 	"context"
 	"errors"
+	"fmt"
 	"math/big"
+	"sync"
 	"time"
 
+	"github.com/ethereum-optimism/optimism/indexer/bigint"
+	"github.com/ethereum-optimism/optimism/indexer/database"
 	"github.com/ethereum-optimism/optimism/indexer/node"
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
@@ -17,10 +21,15 @@ type Config struct {
 	LoopIntervalMsec uint
 	HeaderBufferSize uint
 
-	StartHeight       *big.Int
-	ConfirmationDepth *big.Int
+	StartHeight *big.Int
 }
 
+var (
+	// errReorgedBatch is returned when the logs of a batch do not belong to its headers, which
+	// means the headers were reorged out in between the header and log retrieval.
+	errReorgedBatch = errors.New("parsed log with a block hash not in the batch")
+)
+
 type ETL struct {
 	log     log.Logger
 	metrics Metricer
@@ -28,9 +37,14 @@ type ETL struct {
 	loopInterval     time.Duration
 	headerBufferSize uint64
 	headerTraversal  *node.HeaderTraversal
+	startHeight      *big.Int
 
 	contracts  []common.Address
 	etlBatches chan ETLBatch
+	etlReorgs  chan etlReorg
+
+	mu        *sync.Mutex
+	listeners []chan interface{}
 
 	EthClient node.EthClient
 }
@@ -45,6 +59,21 @@ type ETLBatch struct {
 	HeadersWithLog map[common.Hash]bool
 }
 
+// etlReorg is a request to roll back the indexed state past the supplied height, sent to the
+// goroutine persisting batches so that it is serialized with the batches already extracted.
+type etlReorg struct {
+	logger log.Logger
+	height *big.Int
+
+	result chan etlReorgResult
+}
+
+// etlReorgResult holds the header the traversal continues from once the reorg has been rolled back
+type etlReorgResult struct {
+	header *types.Header
+	err    error
+}
+
 func (etl *ETL) Start(ctx context.Context) error {
 	done := ctx.Done()
 	pollTicker := time.NewTicker(etl.loopInterval)
@@ -66,11 +95,15 @@ func (etl *ETL) Start(ctx context.Context) error {
 			if len(headers) > 0 {
 				etl.log.Info("retrying previous batch")
 			} else {
-				newHeaders, err := etl.headerTraversal.NextFinalizedHeaders(etl.headerBufferSize)
-				if err != nil {
+				newHeaders, err := etl.headerTraversal.NextHeaders(etl.headerBufferSize)
+				if errors.Is(err, node.ErrHeaderTraversalAndProviderMismatchedState) {
+					etl.log.Warn("detected reorg", "last_header_number", etl.headerTraversal.LastHeader().Number, "last_header_hash", etl.headerTraversal.LastHeader().Hash())
+					done(etl.reorg(ctx))
+					continue
+				} else if err != nil {
 					etl.log.Error("error querying for headers", "err", err)
 				} else if len(newHeaders) == 0 {
-					etl.log.Warn("no new headers. processor unexpectedly at head...")
+					etl.log.Info("no new headers. etl at head")
 				} else {
 					headers = newHeaders
 					etl.metrics.RecordBatchHeaders(len(newHeaders))
@@ -79,7 +112,11 @@ func (etl *ETL) Start(ctx context.Context) error {
 
 			// only clear the reference if we were able to process this batch
 			err := etl.processBatch(headers)
-			if err == nil {
+			if errors.Is(err, errReorgedBatch) {
+				// The batch is stale and the traversal has to be rewound before extracting again
+				headers = nil
+				err = etl.reorg(ctx)
+			} else if err == nil {
 				headers = nil
 			}
 
@@ -88,6 +125,104 @@ func (etl *ETL) Start(ctx context.Context) error {
 	}
 }
 
+// reorg rolls back the indexed state to the common ancestor with the provider and rewinds the
+// header traversal to continue from it.
+func (etl *ETL) reorg(ctx context.Context) error {
+	lastHeader := etl.headerTraversal.LastHeader()
+	if lastHeader == nil {
+		return nil
+	}
+
+	reorgLog := etl.log.New("reorg_block_number", lastHeader.Number)
+	result := make(chan etlReorgResult, 1)
+	select {
+	case etl.etlReorgs <- etlReorg{logger: reorgLog, height: lastHeader.Number, result: result}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	var res etlReorgResult
+	select {
+	case res = <-result:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	if res.err != nil {
+		reorgLog.Error("unable to roll back reorg", "err", res.err)
+		return res.err
+	}
+
+	depth := lastHeader.Number.Uint64()
+	if res.header != nil {
+		depth -= res.header.Number.Uint64()
+		reorgLog.Info("rewound to common ancestor", "block_number", res.header.Number, "block_hash", res.header.Hash())
+	} else {
+		reorgLog.Info("rewound to genesis")
+	}
+
+	etl.metrics.RecordReorg(depth)
+	etl.headerTraversal.Rewind(res.header)
+	return nil
+}
+
+func (etl *ETL) notifyListeners() {
+	etl.mu.Lock()
+	defer etl.mu.Unlock()
+	for i := range etl.listeners {
+		select {
+		case etl.listeners[i] <- struct{}{}:
+		default:
+			// do nothing if the listener hasn't picked
+			// up the previous notif
+		}
+	}
+}
+
+// Notify returns a channel that'll receive a value every time new data has
+// been persisted, or reorged state has been removed, by the ETL
+func (etl *ETL) Notify() <-chan interface{} {
+	receiver := make(chan interface{})
+	etl.mu.Lock()
+	defer etl.mu.Unlock()
+
+	etl.listeners = append(etl.listeners, receiver)
+	return receiver
+}
+
+// commonAncestor walks back the indexed headers from the supplied height until one is found that
+// is still canonical according to the provider. When none of the indexed headers are canonical, the
+// header at the configured start height is returned instead, nil indicating genesis.
+func (etl *ETL) commonAncestor(height *big.Int, indexedHeader func(*big.Int) (*database.BlockHeader, error)) (*types.Header, error) {
+	for height.Sign() >= 0 {
+		indexed, err := indexedHeader(height)
+		if err != nil {
+			return nil, err
+		} else if indexed == nil {
+			break
+		}
+
+		header, err := etl.EthClient.BlockHeaderByNumber(indexed.Number)
+		if err != nil {
+			return nil, fmt.Errorf("unable to query header %d: %w", indexed.Number, err)
+		}
+		if header.Hash() == indexed.Hash {
+			return header, nil
+		}
+
+		height = new(big.Int).Sub(indexed.Number, bigint.One)
+	}
+
+	if etl.startHeight == nil || etl.startHeight.BitLen() == 0 {
+		return nil, nil
+	}
+
+	header, err := etl.EthClient.BlockHeaderByNumber(etl.startHeight)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch starting block header: %w", err)
+	}
+	return header, nil
+}
+
 func (etl *ETL) processBatch(headers []types.Header) error {
 	if len(headers) == 0 {
 		return nil
@@ -117,11 +252,9 @@ func (etl *ETL) processBatch(headers []types.Header) error {
 	for i := range logs {
 		log := logs[i]
 		if _, ok := headerMap[log.BlockHash]; !ok {
-			// NOTE. Definitely an error state if the none of the headers were re-orged out in between
-			// the blocks and logs retrieval operations. Unlikely as long as the confirmation depth has
-			// been appropriately set or when we get to natively handling reorgs.
-			batchLog.Error("log found with block hash not in the batch", "block_hash", logs[i].BlockHash, "log_index", logs[i].Index)
-			return errors.New("parsed log with a block hash not in the batch")
+			// The headers were re-orged out in between the blocks and logs retrieval operations
+			batchLog.Warn("log found with block hash not in the batch", "block_hash", logs[i].BlockHash, "log_index", logs[i].Index)
+			return errReorgedBatch
 		}
 
 		etl.metrics.RecordBatchLog(log.Address)
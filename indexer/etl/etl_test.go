@@ -0,0 +1,56 @@
+package etl
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/ethereum-optimism/optimism/indexer/bigint"
+	"github.com/ethereum-optimism/optimism/indexer/database"
+	"github.com/ethereum-optimism/optimism/indexer/node"
+)
+
+func TestETLCommonAncestor(t *testing.T) {
+	// sparsely indexed headers, of which only block 10 remains canonical
+	indexed := map[uint64]*types.Header{}
+	for _, number := range []uint64{10, 12, 15} {
+		indexed[number] = &types.Header{Number: new(big.Int).SetUint64(number)}
+	}
+	indexedHeader := func(height *big.Int) (*database.BlockHeader, error) {
+		for number := height.Int64(); number >= 0; number-- {
+			if header, ok := indexed[uint64(number)]; ok {
+				blockHeader := database.BlockHeaderFromHeader(header)
+				return &blockHeader, nil
+			}
+		}
+		return nil, nil
+	}
+
+	client := new(node.MockEthClient)
+	client.On("BlockHeaderByNumber", mock.MatchedBy(bigint.Matcher(10))).Return(indexed[10], nil)
+	client.On("BlockHeaderByNumber", mock.MatchedBy(bigint.Matcher(12))).Return(&types.Header{Number: big.NewInt(12), Time: 1}, nil)
+	client.On("BlockHeaderByNumber", mock.MatchedBy(bigint.Matcher(15))).Return(&types.Header{Number: big.NewInt(15), Time: 1}, nil)
+
+	etl := &ETL{EthClient: client, startHeight: big.NewInt(5)}
+	header, err := etl.commonAncestor(big.NewInt(16), indexedHeader)
+	require.NoError(t, err)
+	require.Equal(t, indexed[10].Hash(), header.Hash())
+
+	// without any canonical indexed headers, the traversal restarts from the start height
+	start := &types.Header{Number: big.NewInt(5)}
+	client.On("BlockHeaderByNumber", mock.MatchedBy(bigint.Matcher(5))).Return(start, nil)
+	delete(indexed, 10)
+	header, err = etl.commonAncestor(big.NewInt(16), indexedHeader)
+	require.NoError(t, err)
+	require.Equal(t, start.Hash(), header.Hash())
+
+	// or genesis when none was configured
+	etl.startHeight = nil
+	header, err = etl.commonAncestor(big.NewInt(16), indexedHeader)
+	require.NoError(t, err)
+	require.Nil(t, header)
+}
@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/big"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/ethereum-optimism/optimism/indexer/bigint"
 	"github.com/ethereum-optimism/optimism/indexer/config"
 	"github.com/ethereum-optimism/optimism/indexer/database"
 	"github.com/ethereum-optimism/optimism/indexer/node"
@@ -20,32 +22,37 @@ import (
 type L1ETL struct {
 	ETL
 
-	db        *database.DB
-	mu        *sync.Mutex
-	listeners []chan interface{}
+	db *database.DB
+
+	// The schemas of the additional L2 chains sharing the L1 state
+	l2ChainSchemas []string
 }
 
 // NewL1ETL creates a new L1ETL instance that will start indexing from different starting points
-// depending on the state of the database and the supplied start height.
-func NewL1ETL(cfg Config, log log.Logger, db *database.DB, metrics Metricer, client node.EthClient, contracts config.L1Contracts) (*L1ETL, error) {
+// depending on the state of the database and the supplied start height. The L1 state is shared by
+// all indexed L2 chains, extracting the logs of the contracts of each chain. The bridge state of the
+// additional chains, partitioned into the supplied schemas, is reverted alongside reorged L1 state.
+func NewL1ETL(cfg Config, log log.Logger, db *database.DB, metrics Metricer, client node.EthClient, contracts []config.L1Contracts, l2ChainSchemas []string) (*L1ETL, error) {
 	log = log.New("etl", "l1")
 
 	zeroAddr := common.Address{}
 	l1Contracts := []common.Address{}
-	if err := contracts.ForEach(func(name string, addr common.Address) error {
-		// Since we dont have backfill support yet, we want to make sure all expected
-		// contracts are specified to ensure consistent behavior. Once backfill support
-		// is ready, we can relax this requirement.
-		if addr == zeroAddr && !strings.HasPrefix(name, "Legacy") {
-			log.Error("address not configured", "name", name)
-			return errors.New("all L1Contracts must be configured")
-		}
+	for i := range contracts {
+		if err := contracts[i].ForEach(func(name string, addr common.Address) error {
+			// Since we dont have backfill support yet, we want to make sure all expected
+			// contracts are specified to ensure consistent behavior. Once backfill support
+			// is ready, we can relax this requirement.
+			if addr == zeroAddr && !strings.HasPrefix(name, "Legacy") {
+				log.Error("address not configured", "name", name)
+				return errors.New("all L1Contracts must be configured")
+			}
 
-		log.Info("configured contract", "name", name, "addr", addr)
-		l1Contracts = append(l1Contracts, addr)
-		return nil
-	}); err != nil {
-		return nil, err
+			log.Info("configured contract", "name", name, "addr", addr)
+			l1Contracts = append(l1Contracts, addr)
+			return nil
+		}); err != nil {
+			return nil, err
+		}
 	}
 
 	latestHeader, err := db.Blocks.L1LatestBlockHeader()
@@ -73,20 +80,24 @@ func NewL1ETL(cfg Config, log log.Logger, db *database.DB, metrics Metricer, cli
 	// NOTE - The use of un-buffered channel here assumes that downstream consumers
 	// will be able to keep up with the rate of incoming batches
 	etlBatches := make(chan ETLBatch)
+	etlReorgs := make(chan etlReorg)
 	etl := ETL{
 		loopInterval:     time.Duration(cfg.LoopIntervalMsec) * time.Millisecond,
 		headerBufferSize: uint64(cfg.HeaderBufferSize),
 
 		log:             log,
 		metrics:         metrics,
-		headerTraversal: node.NewHeaderTraversal(client, fromHeader, cfg.ConfirmationDepth),
+		headerTraversal: node.NewHeaderTraversal(client, fromHeader, bigint.Zero),
+		startHeight:     cfg.StartHeight,
 		contracts:       l1Contracts,
 		etlBatches:      etlBatches,
+		etlReorgs:       etlReorgs,
+		mu:              new(sync.Mutex),
 
 		EthClient: client,
 	}
 
-	return &L1ETL{ETL: etl, db: db, mu: new(sync.Mutex)}, nil
+	return &L1ETL{ETL: etl, db: db, l2ChainSchemas: l2ChainSchemas}, nil
 }
 
 func (l1Etl *L1ETL) Start(ctx context.Context) error {
@@ -100,18 +111,16 @@ func (l1Etl *L1ETL) Start(ctx context.Context) error {
 		case err := <-errCh:
 			return err
 
-		// Index incoming batches (only L1 blocks that have an emitted log)
+		// Index incoming batches (only L1 blocks that have an emitted log, and the last block of the
+		// batch so that the latest indexed header tracks the head of the traversal)
 		case batch := <-l1Etl.etlBatches:
 			l1BlockHeaders := make([]database.L1BlockHeader, 0, len(batch.Headers))
 			for i := range batch.Headers {
-				if _, ok := batch.HeadersWithLog[batch.Headers[i].Hash()]; ok {
+				_, hasLog := batch.HeadersWithLog[batch.Headers[i].Hash()]
+				if hasLog || i == len(batch.Headers)-1 {
 					l1BlockHeaders = append(l1BlockHeaders, database.L1BlockHeader{BlockHeader: database.BlockHeaderFromHeader(&batch.Headers[i])})
 				}
 			}
-			if len(l1BlockHeaders) == 0 {
-				batch.Logger.Info("no l1 blocks with logs in batch")
-				continue
-			}
 
 			l1ContractEvents := make([]database.L1ContractEvent, len(batch.Logs))
 			for i := range batch.Logs {
@@ -126,9 +135,10 @@ func (l1Etl *L1ETL) Start(ctx context.Context) error {
 					if err := tx.Blocks.StoreL1BlockHeaders(l1BlockHeaders); err != nil {
 						return err
 					}
-					// we must have logs if we have l1 blocks
-					if err := tx.ContractEvents.StoreL1ContractEvents(l1ContractEvents); err != nil {
-						return err
+					if len(l1ContractEvents) > 0 {
+						if err := tx.ContractEvents.StoreL1ContractEvents(l1ContractEvents); err != nil {
+							return err
+						}
 					}
 					return nil
 				}); err != nil {
@@ -138,7 +148,9 @@ func (l1Etl *L1ETL) Start(ctx context.Context) error {
 
 				l1Etl.ETL.metrics.RecordIndexedHeaders(len(l1BlockHeaders))
 				l1Etl.ETL.metrics.RecordIndexedLatestHeight(l1BlockHeaders[len(l1BlockHeaders)-1].Number)
-				l1Etl.ETL.metrics.RecordIndexedLogs(len(l1ContractEvents))
+				if len(l1ContractEvents) > 0 {
+					l1Etl.ETL.metrics.RecordIndexedLogs(len(l1ContractEvents))
+				}
 
 				// a-ok!
 				return nil, nil
@@ -147,29 +159,45 @@ func (l1Etl *L1ETL) Start(ctx context.Context) error {
 			}
 
 			batch.Logger.Info("indexed batch")
-
-			// Notify Listeners
-			l1Etl.mu.Lock()
-			for i := range l1Etl.listeners {
-				select {
-				case l1Etl.listeners[i] <- struct{}{}:
-				default:
-					// do nothing if the listener hasn't picked
-					// up the previous notif
-				}
+			l1Etl.notifyListeners()
+
+		// Roll back reorged blocks, after all previously extracted batches have been indexed
+		case reorg := <-l1Etl.etlReorgs:
+			header, err := l1Etl.rewind(reorg)
+			reorg.result <- etlReorgResult{header: header, err: err}
+			if err == nil {
+				l1Etl.notifyListeners()
 			}
-			l1Etl.mu.Unlock()
 		}
 	}
 }
 
-// Notify returns a channel that'll receive a value every time new data has
-// been persisted by the L1ETL
-func (l1Etl *L1ETL) Notify() <-chan interface{} {
-	receiver := make(chan interface{})
-	l1Etl.mu.Lock()
-	defer l1Etl.mu.Unlock()
+// rewind removes all indexed L1 state above the common ancestor with the provider in a
+// single transaction, returning the header indexing continues from.
+func (l1Etl *L1ETL) rewind(reorg etlReorg) (*types.Header, error) {
+	header, err := l1Etl.commonAncestor(reorg.height, func(height *big.Int) (*database.BlockHeader, error) {
+		l1Header, err := l1Etl.db.Blocks.L1LatestBlockHeaderUpTo(height)
+		if err != nil || l1Header == nil {
+			return nil, err
+		}
+		return &l1Header.BlockHeader, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Without a header, none of the indexed state remains from genesis
+	toHeight := big.NewInt(-1)
+	if header != nil {
+		toHeight = header.Number
+	}
+
+	reorg.logger.Info("removing reorged state", "to_block_number", toHeight)
+	if err := l1Etl.db.Transaction(func(tx *database.DB) error {
+		return tx.Blocks.DeleteL1BlockHeadersAfter(toHeight, l1Etl.l2ChainSchemas)
+	}); err != nil {
+		return nil, err
+	}
 
-	l1Etl.listeners = append(l1Etl.listeners, receiver)
-	return receiver
+	return header, nil
 }
@@ -3,8 +3,11 @@ package etl
 import (
 	"context"
 	"errors"
+	"math/big"
+	"sync"
 	"time"
 
+	"github.com/ethereum-optimism/optimism/indexer/bigint"
 	"github.com/ethereum-optimism/optimism/indexer/config"
 	"github.com/ethereum-optimism/optimism/indexer/database"
 	"github.com/ethereum-optimism/optimism/indexer/node"
@@ -55,15 +58,18 @@ func NewL2ETL(cfg Config, log log.Logger, db *database.DB, metrics Metricer, cli
 	}
 
 	etlBatches := make(chan ETLBatch)
+	etlReorgs := make(chan etlReorg)
 	etl := ETL{
 		loopInterval:     time.Duration(cfg.LoopIntervalMsec) * time.Millisecond,
 		headerBufferSize: uint64(cfg.HeaderBufferSize),
 
 		log:             log,
 		metrics:         metrics,
-		headerTraversal: node.NewHeaderTraversal(client, fromHeader, cfg.ConfirmationDepth),
+		headerTraversal: node.NewHeaderTraversal(client, fromHeader, bigint.Zero),
 		contracts:       l2Contracts,
 		etlBatches:      etlBatches,
+		etlReorgs:       etlReorgs,
+		mu:              new(sync.Mutex),
 
 		EthClient: client,
 	}
@@ -126,6 +132,45 @@ func (l2Etl *L2ETL) Start(ctx context.Context) error {
 			}
 
 			batch.Logger.Info("indexed batch")
+			l2Etl.notifyListeners()
+
+		// Roll back reorged blocks, after all previously extracted batches have been indexed
+		case reorg := <-l2Etl.etlReorgs:
+			header, err := l2Etl.rewind(reorg)
+			reorg.result <- etlReorgResult{header: header, err: err}
+			if err == nil {
+				l2Etl.notifyListeners()
+			}
 		}
 	}
 }
+
+// rewind removes all indexed L2 state above the common ancestor with the provider in a
+// single transaction, returning the header indexing continues from.
+func (l2Etl *L2ETL) rewind(reorg etlReorg) (*types.Header, error) {
+	header, err := l2Etl.commonAncestor(reorg.height, func(height *big.Int) (*database.BlockHeader, error) {
+		l2Header, err := l2Etl.db.Blocks.L2LatestBlockHeaderUpTo(height)
+		if err != nil || l2Header == nil {
+			return nil, err
+		}
+		return &l2Header.BlockHeader, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Without a header, none of the indexed state remains from genesis
+	toHeight := big.NewInt(-1)
+	if header != nil {
+		toHeight = header.Number
+	}
+
+	reorg.logger.Info("removing reorged state", "to_block_number", toHeight)
+	if err := l2Etl.db.Transaction(func(tx *database.DB) error {
+		return tx.Blocks.DeleteL2BlockHeadersAfter(toHeight)
+	}); err != nil {
+		return nil, err
+	}
+
+	return header, nil
+}
@@ -24,6 +24,9 @@ type Metricer interface {
 	RecordIndexedLatestHeight(height *big.Int)
 	RecordIndexedHeaders(size int)
 	RecordIndexedLogs(size int)
+
+	// Reorgs
+	RecordReorg(depth uint64)
 }
 
 type etlMetrics struct {
@@ -38,6 +41,9 @@ type etlMetrics struct {
 	indexedLatestHeight prometheus.Gauge
 	indexedHeaders      prometheus.Counter
 	indexedLogs         prometheus.Counter
+
+	reorgs     prometheus.Counter
+	reorgDepth prometheus.Gauge
 }
 
 func NewMetrics(registry *prometheus.Registry, subsystem string) Metricer {
@@ -99,6 +105,18 @@ func NewMetrics(registry *prometheus.Registry, subsystem string) Metricer {
 			Name:      "indexed_logs_total",
 			Help:      "number of logs indexed by the etl",
 		}),
+		reorgs: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: MetricsNamespace,
+			Subsystem: subsystem,
+			Name:      "reorgs_total",
+			Help:      "number of reorgs rolled back by the etl",
+		}),
+		reorgDepth: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: MetricsNamespace,
+			Subsystem: subsystem,
+			Name:      "reorg_depth",
+			Help:      "the number of blocks rolled back by the latest reorg",
+		}),
 	}
 }
 
@@ -137,3 +155,8 @@ func (m *etlMetrics) RecordIndexedHeaders(size int) {
 func (m *etlMetrics) RecordIndexedLogs(size int) {
 	m.indexedLogs.Add(float64(size))
 }
+
+func (m *etlMetrics) RecordReorg(depth uint64) {
+	m.reorgs.Inc()
+	m.reorgDepth.Set(float64(depth))
+}
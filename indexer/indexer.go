@@ -39,6 +39,22 @@ type Indexer struct {
 	L1ETL           *etl.L1ETL
 	L2ETL           *etl.L2ETL
 	BridgeProcessor *processors.BridgeProcessor
+
+	// Additional L2 chains, indexed against the shared L1ETL
+	L2Chains []*L2ChainIndexer
+}
+
+// L2ChainDB pairs an additional L2 chain with the database partition holding its state
+type L2ChainDB struct {
+	Config config.L2ChainConfig
+	DB     *database.DB
+}
+
+// L2ChainIndexer contains the resources indexing an additional L2 chain
+type L2ChainIndexer struct {
+	L2ChainID       uint
+	L2ETL           *etl.L2ETL
+	BridgeProcessor *processors.BridgeProcessor
 }
 
 // NewIndexer initializes an instance of the Indexer
@@ -49,6 +65,7 @@ func NewIndexer(
 	rpcsConfig config.RPCsConfig,
 	httpConfig config.ServerConfig,
 	metricsConfig config.ServerConfig,
+	l2ChainDBs []L2ChainDB,
 ) (*Indexer, error) {
 	metricsRegistry := metrics.NewRegistry()
 
@@ -57,13 +74,21 @@ func NewIndexer(
 	if err != nil {
 		return nil, err
 	}
+	// The L1 state is shared by all chains, starting from the earliest chain
+	l1StartingHeight := chainConfig.L1StartingHeight
+	l1Contracts := []config.L1Contracts{chainConfig.L1Contracts}
+	l2ChainSchemas := make([]string, len(l2ChainDBs))
+	for i := range l2ChainDBs {
+		l1StartingHeight = min(l1StartingHeight, l2ChainDBs[i].Config.L1StartingHeight)
+		l1Contracts = append(l1Contracts, l2ChainDBs[i].Config.L1Contracts)
+		l2ChainSchemas[i] = database.L2ChainSchema(l2ChainDBs[i].Config.L2ChainID)
+	}
 	l1Cfg := etl.Config{
-		LoopIntervalMsec:  chainConfig.L1PollingInterval,
-		HeaderBufferSize:  chainConfig.L1HeaderBufferSize,
-		ConfirmationDepth: big.NewInt(int64(chainConfig.L1ConfirmationDepth)),
-		StartHeight:       big.NewInt(int64(chainConfig.L1StartingHeight)),
+		LoopIntervalMsec: chainConfig.L1PollingInterval,
+		HeaderBufferSize: chainConfig.L1HeaderBufferSize,
+		StartHeight:      big.NewInt(int64(l1StartingHeight)),
 	}
-	l1Etl, err := etl.NewL1ETL(l1Cfg, log, db, etl.NewMetrics(metricsRegistry, "l1"), l1EthClient, chainConfig.L1Contracts)
+	l1Etl, err := etl.NewL1ETL(l1Cfg, log, db, etl.NewMetrics(metricsRegistry, "l1"), l1EthClient, l1Contracts, l2ChainSchemas)
 	if err != nil {
 		return nil, err
 	}
@@ -74,9 +99,8 @@ func NewIndexer(
 		return nil, err
 	}
 	l2Cfg := etl.Config{
-		LoopIntervalMsec:  chainConfig.L2PollingInterval,
-		HeaderBufferSize:  chainConfig.L2HeaderBufferSize,
-		ConfirmationDepth: big.NewInt(int64(chainConfig.L2ConfirmationDepth)),
+		LoopIntervalMsec: chainConfig.L2PollingInterval,
+		HeaderBufferSize: chainConfig.L2HeaderBufferSize,
 	}
 	l2Etl, err := etl.NewL2ETL(l2Cfg, log, db, etl.NewMetrics(metricsRegistry, "l2"), l2EthClient, chainConfig.L2Contracts)
 	if err != nil {
@@ -84,11 +108,39 @@ func NewIndexer(
 	}
 
 	// Bridge
-	bridgeProcessor, err := processors.NewBridgeProcessor(log, db, bridge.NewMetrics(metricsRegistry), l1Etl, chainConfig)
+	bridgeProcessor, err := processors.NewBridgeProcessor(log, db, bridge.NewMetrics(metricsRegistry), l1Etl, l2Etl, chainConfig)
 	if err != nil {
 		return nil, err
 	}
 
+	// Additional L2 chains, with metrics distinguished by the chain id
+	l2Chains := make([]*L2ChainIndexer, len(l2ChainDBs))
+	for i := range l2ChainDBs {
+		l2ChainConfig, l2ChainDB := l2ChainDBs[i].Config, l2ChainDBs[i].DB
+		l2ChainLog := log.New("l2_chain_id", l2ChainConfig.L2ChainID)
+		subsystem := fmt.Sprintf("l2_%d", l2ChainConfig.L2ChainID)
+
+		l2EthClient, err := node.DialEthClient(l2ChainConfig.L2RPC, node.NewMetrics(metricsRegistry, subsystem))
+		if err != nil {
+			return nil, err
+		}
+		l2Cfg := etl.Config{
+			LoopIntervalMsec: l2ChainConfig.L2PollingInterval,
+			HeaderBufferSize: l2ChainConfig.L2HeaderBufferSize,
+		}
+		l2Etl, err := etl.NewL2ETL(l2Cfg, l2ChainLog, l2ChainDB, etl.NewMetrics(metricsRegistry, subsystem), l2EthClient, l2ChainConfig.L2Contracts)
+		if err != nil {
+			return nil, err
+		}
+
+		bridgeProcessor, err := processors.NewBridgeProcessor(l2ChainLog, l2ChainDB, bridge.NewL2ChainMetrics(metricsRegistry, l2ChainConfig.L2ChainID), l1Etl, l2Etl, l2ChainConfig.ChainConfig)
+		if err != nil {
+			return nil, err
+		}
+
+		l2Chains[i] = &L2ChainIndexer{L2ChainID: l2ChainConfig.L2ChainID, L2ETL: l2Etl, BridgeProcessor: bridgeProcessor}
+	}
+
 	indexer := &Indexer{
 		log: log,
 		db:  db,
@@ -100,6 +152,7 @@ func NewIndexer(
 		L1ETL:           l1Etl,
 		L2ETL:           l2Etl,
 		BridgeProcessor: bridgeProcessor,
+		L2Chains:        l2Chains,
 	}
 
 	return indexer, nil
@@ -137,7 +190,7 @@ func (i *Indexer) startMetricsServer(ctx context.Context) error {
 // Start starts the indexing service on L1 and L2 chains
 func (i *Indexer) Run(ctx context.Context) error {
 	var wg sync.WaitGroup
-	errCh := make(chan error, 5)
+	errCh := make(chan error, 5+2*len(i.L2Chains))
 
 	// if any goroutine halts, we stop the entire indexer
 	processCtx, processCancel := context.WithCancel(ctx)
@@ -163,6 +216,10 @@ func (i *Indexer) Run(ctx context.Context) error {
 	runProcess(i.L1ETL.Start)
 	runProcess(i.L2ETL.Start)
 	runProcess(i.BridgeProcessor.Start)
+	for _, l2Chain := range i.L2Chains {
+		runProcess(l2Chain.L2ETL.Start)
+		runProcess(l2Chain.BridgeProcessor.Start)
+	}
 	runProcess(i.startMetricsServer)
 	runProcess(i.startHttpServer)
 	wg.Wait()
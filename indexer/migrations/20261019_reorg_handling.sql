@@ -0,0 +1,23 @@
+/**
+ * Reorg Handling
+ *
+ * Reorged blocks are removed from the `*_block_headers` tables, cascading to their contract events
+ * and the bridge state initiated by them. Bridge state finalized by a removed event on the other
+ * layer must instead revert to its unfinalized state, to be finalized again by the canonical event.
+ */
+
+ALTER TABLE l2_transaction_withdrawals DROP CONSTRAINT IF EXISTS l2_transaction_withdrawals_proven_l1_event_guid_fkey;
+ALTER TABLE l2_transaction_withdrawals ADD CONSTRAINT l2_transaction_withdrawals_proven_l1_event_guid_fkey
+    FOREIGN KEY (proven_l1_event_guid) REFERENCES l1_contract_events(guid) ON DELETE SET NULL;
+
+ALTER TABLE l2_transaction_withdrawals DROP CONSTRAINT IF EXISTS l2_transaction_withdrawals_finalized_l1_event_guid_fkey;
+ALTER TABLE l2_transaction_withdrawals ADD CONSTRAINT l2_transaction_withdrawals_finalized_l1_event_guid_fkey
+    FOREIGN KEY (finalized_l1_event_guid) REFERENCES l1_contract_events(guid) ON DELETE SET NULL;
+
+ALTER TABLE l1_bridge_messages DROP CONSTRAINT IF EXISTS l1_bridge_messages_relayed_message_event_guid_fkey;
+ALTER TABLE l1_bridge_messages ADD CONSTRAINT l1_bridge_messages_relayed_message_event_guid_fkey
+    FOREIGN KEY (relayed_message_event_guid) REFERENCES l2_contract_events(guid) ON DELETE SET NULL;
+
+ALTER TABLE l2_bridge_messages DROP CONSTRAINT IF EXISTS l2_bridge_messages_relayed_message_event_guid_fkey;
+ALTER TABLE l2_bridge_messages ADD CONSTRAINT l2_bridge_messages_relayed_message_event_guid_fkey
+    FOREIGN KEY (relayed_message_event_guid) REFERENCES l1_contract_events(guid) ON DELETE SET NULL;
@@ -0,0 +1,18 @@
+/**
+ * L2 Output Proposals
+ *
+ * Output roots proposed to the L2OutputOracle, against which withdrawals are proven. Outputs deleted by the
+ * challenger are marked rather than removed, such that they are restored if the deletion is reorged out.
+ */
+
+CREATE TABLE IF NOT EXISTS l2_output_proposals (
+    proposed_l1_event_guid VARCHAR PRIMARY KEY REFERENCES l1_contract_events(guid) ON DELETE CASCADE,
+    deleted_l1_event_guid  VARCHAR REFERENCES l1_contract_events(guid) ON DELETE SET NULL,
+
+    output_root     VARCHAR NOT NULL,
+    l2_output_index UINT256 NOT NULL,
+    l2_block_number UINT256 NOT NULL,
+    timestamp       INTEGER NOT NULL CHECK (timestamp > 0)
+);
+CREATE INDEX IF NOT EXISTS l2_output_proposals_l2_output_index ON l2_output_proposals(l2_output_index);
+CREATE INDEX IF NOT EXISTS l2_output_proposals_l2_block_number ON l2_output_proposals(l2_block_number);
@@ -0,0 +1,38 @@
+/**
+ * ERC721 Bridge Transfers
+ *
+ * NFTs bridged through the L1ERC721Bridge & L2ERC721Bridge. Like the standard bridge, these are
+ * wrappers over the CrossDomainMessenger and are linked to the underlying cross domain message.
+ */
+
+CREATE TABLE IF NOT EXISTS l1_erc721_bridge_deposits (
+    transaction_source_hash   VARCHAR PRIMARY KEY REFERENCES l1_transaction_deposits(source_hash) ON DELETE CASCADE,
+    cross_domain_message_hash VARCHAR NOT NULL UNIQUE REFERENCES l1_bridge_messages(message_hash) ON DELETE CASCADE,
+
+    -- Deposit information
+    from_address         VARCHAR NOT NULL,
+    to_address           VARCHAR NOT NULL,
+    local_token_address  VARCHAR NOT NULL,
+    remote_token_address VARCHAR NOT NULL,
+    token_id             UINT256 NOT NULL,
+    data                 VARCHAR NOT NULL,
+    timestamp            INTEGER NOT NULL CHECK (timestamp > 0)
+);
+CREATE INDEX IF NOT EXISTS l1_erc721_bridge_deposits_timestamp ON l1_erc721_bridge_deposits(timestamp);
+CREATE INDEX IF NOT EXISTS l1_erc721_bridge_deposits_from_address ON l1_erc721_bridge_deposits(from_address);
+
+CREATE TABLE IF NOT EXISTS l2_erc721_bridge_withdrawals (
+    transaction_withdrawal_hash VARCHAR PRIMARY KEY REFERENCES l2_transaction_withdrawals(withdrawal_hash) ON DELETE CASCADE,
+    cross_domain_message_hash   VARCHAR NOT NULL UNIQUE REFERENCES l2_bridge_messages(message_hash) ON DELETE CASCADE,
+
+    -- Withdrawal information
+    from_address         VARCHAR NOT NULL,
+    to_address           VARCHAR NOT NULL,
+    local_token_address  VARCHAR NOT NULL,
+    remote_token_address VARCHAR NOT NULL,
+    token_id             UINT256 NOT NULL,
+    data                 VARCHAR NOT NULL,
+    timestamp            INTEGER NOT NULL CHECK (timestamp > 0)
+);
+CREATE INDEX IF NOT EXISTS l2_erc721_bridge_withdrawals_timestamp ON l2_erc721_bridge_withdrawals(timestamp);
+CREATE INDEX IF NOT EXISTS l2_erc721_bridge_withdrawals_from_address ON l2_erc721_bridge_withdrawals(from_address);
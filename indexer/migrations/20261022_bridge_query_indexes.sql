@@ -0,0 +1,14 @@
+/**
+ * Bridge Query Indexes
+ *
+ * Supports looking up bridge transfers by transaction & message hash and filtering them by token.
+ */
+
+CREATE INDEX IF NOT EXISTS l1_contract_events_transaction_hash ON l1_contract_events(transaction_hash);
+CREATE INDEX IF NOT EXISTS l2_contract_events_transaction_hash ON l2_contract_events(transaction_hash);
+
+CREATE INDEX IF NOT EXISTS l1_transaction_deposits_l2_transaction_hash ON l1_transaction_deposits(l2_transaction_hash);
+
+CREATE INDEX IF NOT EXISTS l1_bridge_deposits_local_token_address_timestamp ON l1_bridge_deposits(local_token_address, timestamp);
+CREATE INDEX IF NOT EXISTS l2_bridge_withdrawals_local_token_address_timestamp ON l2_bridge_withdrawals(local_token_address, timestamp);
+CREATE INDEX IF NOT EXISTS l2_bridge_withdrawals_remote_token_address ON l2_bridge_withdrawals(remote_token_address);
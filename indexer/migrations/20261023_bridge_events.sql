@@ -0,0 +1,32 @@
+/**
+ * Bridge Events
+ *
+ * Append-only log of the bridge lifecycle events observed by the BridgeProcessor, streamed to API
+ * subscribers. The monotonic id serves as the resume cursor. Events are removed alongside the contract
+ * event that emitted them, either L1 or L2, when that event is reorged out.
+ */
+
+CREATE TABLE IF NOT EXISTS bridge_events (
+    id                     BIGSERIAL PRIMARY KEY,
+    l1_contract_event_guid VARCHAR REFERENCES l1_contract_events(guid) ON DELETE CASCADE,
+    l2_contract_event_guid VARCHAR REFERENCES l2_contract_events(guid) ON DELETE CASCADE,
+
+    event_type       VARCHAR NOT NULL,
+    transfer_hash    VARCHAR NOT NULL,
+    message_hash     VARCHAR,
+    from_address     VARCHAR NOT NULL,
+    to_address       VARCHAR NOT NULL,
+    transaction_hash VARCHAR NOT NULL,
+    timestamp        INTEGER NOT NULL CHECK (timestamp > 0),
+
+    CHECK ((l1_contract_event_guid IS NULL) != (l2_contract_event_guid IS NULL))
+);
+
+-- A contract event results in at most one bridge event, which allows epochs to be re-processed
+CREATE UNIQUE INDEX IF NOT EXISTS bridge_events_l1_contract_event_guid ON bridge_events(l1_contract_event_guid);
+CREATE UNIQUE INDEX IF NOT EXISTS bridge_events_l2_contract_event_guid ON bridge_events(l2_contract_event_guid);
+
+CREATE INDEX IF NOT EXISTS bridge_events_from_address ON bridge_events(from_address);
+CREATE INDEX IF NOT EXISTS bridge_events_to_address ON bridge_events(to_address);
+CREATE INDEX IF NOT EXISTS bridge_events_message_hash ON bridge_events(message_hash);
+CREATE INDEX IF NOT EXISTS bridge_events_transfer_hash ON bridge_events(transfer_hash);
@@ -0,0 +1,21 @@
+/**
+ * Bridge Backfill Ranges
+ *
+ * Checkpoints of a historical backfill by the BridgeProcessor. Unindexed history is split into ranges of
+ * epochs, whose initiated bridge events are indexed concurrently. Finalization events are then indexed
+ * in order, correlating against the initiated events of any range. Ranges are removed once all are indexed.
+ */
+
+CREATE TABLE IF NOT EXISTS bridge_backfill_ranges (
+    l1_from_height UINT256 PRIMARY KEY,
+    l1_to_height   UINT256 NOT NULL,
+    l2_from_height UINT256 NOT NULL,
+    l2_to_height   UINT256 NOT NULL,
+
+    -- The epoch ending the range
+    l1_block_hash VARCHAR NOT NULL,
+    l2_block_hash VARCHAR NOT NULL,
+
+    initiated_indexed BOOLEAN NOT NULL DEFAULT FALSE,
+    finalized_indexed BOOLEAN NOT NULL DEFAULT FALSE
+);
@@ -0,0 +1,10 @@
+/**
+ * Proven Withdrawal Outputs
+ *
+ * The output proposal a withdrawal was proven against, decoded from the calldata of the proving transaction.
+ * A withdrawal must be proven again once this output is deleted by the challenger. Empty for withdrawals
+ * proven via another contract, or proven before these columns were added.
+ */
+
+ALTER TABLE l2_transaction_withdrawals ADD COLUMN IF NOT EXISTS proven_l2_output_index UINT256;
+ALTER TABLE l2_transaction_withdrawals ADD COLUMN IF NOT EXISTS proven_output_root VARCHAR;
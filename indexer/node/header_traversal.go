@@ -33,9 +33,17 @@ func (f *HeaderTraversal) LastHeader() *types.Header {
 	return f.lastHeader
 }
 
-// NextFinalizedHeaders retrives the next set of headers that have been
-// marked as finalized by the connected client, bounded by the supplied size
-func (f *HeaderTraversal) NextFinalizedHeaders(maxSize uint64) ([]types.Header, error) {
+// Rewind resets the HeaderTraversal to continue fetching headers after the supplied header,
+// which is expected to be the common ancestor with the provider after a reorg. A nil header
+// restarts the traversal from genesis.
+func (f *HeaderTraversal) Rewind(header *types.Header) {
+	f.lastHeader = header
+}
+
+// NextHeaders retrives the next set of headers that are at least the configured confirmation
+// depth behind the head of the connected client, bounded by the supplied size. The headers
+// are only guaranteed to extend the last fetched header, and may be reorged out afterwards.
+func (f *HeaderTraversal) NextHeaders(maxSize uint64) ([]types.Header, error) {
 	latestBlockHeader, err := f.ethClient.BlockHeaderByNumber(nil)
 	if err != nil {
 		return nil, fmt.Errorf("unable to query latest block: %w", err)
@@ -71,8 +79,8 @@ func (f *HeaderTraversal) NextFinalizedHeaders(maxSize uint64) ([]types.Header,
 	if numHeaders == 0 {
 		return nil, nil
 	} else if f.lastHeader != nil && headers[0].ParentHash != f.lastHeader.Hash() {
-		// The indexer's state is in an irrecoverable state relative to the provider. This
-		// should never happen since the indexer is dealing with only finalized blocks.
+		// The last fetched header has been reorged out by the provider. The caller
+		// is expected to Rewind to the common ancestor before continuing
 		return nil, ErrHeaderTraversalAndProviderMismatchedState
 	}
 
@@ -33,7 +33,7 @@ func makeHeaders(numHeaders uint64, prevHeader *types.Header) []types.Header {
 	return headers
 }
 
-func TestHeaderTraversalNextFinalizedHeadersNoOp(t *testing.T) {
+func TestHeaderTraversalNextHeadersNoOp(t *testing.T) {
 	client := new(MockEthClient)
 
 	// start from block 10 as the latest fetched block
@@ -42,12 +42,12 @@ func TestHeaderTraversalNextFinalizedHeadersNoOp(t *testing.T) {
 
 	// no new headers when matched with head
 	client.On("BlockHeaderByNumber", (*big.Int)(nil)).Return(lastHeader, nil)
-	headers, err := headerTraversal.NextFinalizedHeaders(100)
+	headers, err := headerTraversal.NextHeaders(100)
 	require.NoError(t, err)
 	require.Empty(t, headers)
 }
 
-func TestHeaderTraversalNextFinalizedHeadersCursored(t *testing.T) {
+func TestHeaderTraversalNextHeadersCursored(t *testing.T) {
 	client := new(MockEthClient)
 
 	// start from genesis
@@ -57,7 +57,7 @@ func TestHeaderTraversalNextFinalizedHeadersCursored(t *testing.T) {
 	headers := makeHeaders(5, nil)
 	client.On("BlockHeaderByNumber", (*big.Int)(nil)).Return(&headers[4], nil).Times(1) // Times so that we can override next
 	client.On("BlockHeadersByRange", mock.MatchedBy(bigint.Matcher(0)), mock.MatchedBy(bigint.Matcher(4))).Return(headers, nil)
-	headers, err := headerTraversal.NextFinalizedHeaders(5)
+	headers, err := headerTraversal.NextHeaders(5)
 	require.NoError(t, err)
 	require.Len(t, headers, 5)
 
@@ -65,12 +65,12 @@ func TestHeaderTraversalNextFinalizedHeadersCursored(t *testing.T) {
 	headers = makeHeaders(5, &headers[len(headers)-1])
 	client.On("BlockHeaderByNumber", (*big.Int)(nil)).Return(&headers[4], nil)
 	client.On("BlockHeadersByRange", mock.MatchedBy(bigint.Matcher(5)), mock.MatchedBy(bigint.Matcher(9))).Return(headers, nil)
-	headers, err = headerTraversal.NextFinalizedHeaders(5)
+	headers, err = headerTraversal.NextHeaders(5)
 	require.NoError(t, err)
 	require.Len(t, headers, 5)
 }
 
-func TestHeaderTraversalNextFinalizedHeadersMaxSize(t *testing.T) {
+func TestHeaderTraversalNextHeadersMaxSize(t *testing.T) {
 	client := new(MockEthClient)
 
 	// start from genesis
@@ -82,14 +82,14 @@ func TestHeaderTraversalNextFinalizedHeadersMaxSize(t *testing.T) {
 	// clamped by the supplied size
 	headers := makeHeaders(5, nil)
 	client.On("BlockHeadersByRange", mock.MatchedBy(bigint.Matcher(0)), mock.MatchedBy(bigint.Matcher(4))).Return(headers, nil)
-	headers, err := headerTraversal.NextFinalizedHeaders(5)
+	headers, err := headerTraversal.NextHeaders(5)
 	require.NoError(t, err)
 	require.Len(t, headers, 5)
 
 	// clamped by the supplied size. FinalizedHeight == 100
 	headers = makeHeaders(10, &headers[len(headers)-1])
 	client.On("BlockHeadersByRange", mock.MatchedBy(bigint.Matcher(5)), mock.MatchedBy(bigint.Matcher(14))).Return(headers, nil)
-	headers, err = headerTraversal.NextFinalizedHeaders(10)
+	headers, err = headerTraversal.NextHeaders(10)
 	require.NoError(t, err)
 	require.Len(t, headers, 10)
 }
@@ -104,7 +104,7 @@ func TestHeaderTraversalMismatchedProviderStateError(t *testing.T) {
 	headers := makeHeaders(5, nil)
 	client.On("BlockHeaderByNumber", (*big.Int)(nil)).Return(&headers[4], nil).Times(1) // Times so that we can override next
 	client.On("BlockHeadersByRange", mock.MatchedBy(bigint.Matcher(0)), mock.MatchedBy(bigint.Matcher(4))).Return(headers, nil)
-	headers, err := headerTraversal.NextFinalizedHeaders(5)
+	headers, err := headerTraversal.NextHeaders(5)
 	require.NoError(t, err)
 	require.Len(t, headers, 5)
 
@@ -112,7 +112,44 @@ func TestHeaderTraversalMismatchedProviderStateError(t *testing.T) {
 	headers = makeHeaders(5, nil)
 	client.On("BlockHeaderByNumber", (*big.Int)(nil)).Return(&types.Header{Number: big.NewInt(9)}, nil)
 	client.On("BlockHeadersByRange", mock.MatchedBy(bigint.Matcher(5)), mock.MatchedBy(bigint.Matcher(9))).Return(headers, nil)
-	headers, err = headerTraversal.NextFinalizedHeaders(5)
+	headers, err = headerTraversal.NextHeaders(5)
 	require.Nil(t, headers)
 	require.Equal(t, ErrHeaderTraversalAndProviderMismatchedState, err)
 }
+
+func TestHeaderTraversalRewind(t *testing.T) {
+	client := new(MockEthClient)
+
+	// start from genesis
+	headerTraversal := NewHeaderTraversal(client, nil, bigint.Zero)
+
+	// blocks [0..4]
+	headers := makeHeaders(5, nil)
+	client.On("BlockHeaderByNumber", (*big.Int)(nil)).Return(&headers[4], nil).Times(1) // Times so that we can override next
+	client.On("BlockHeadersByRange", mock.MatchedBy(bigint.Matcher(0)), mock.MatchedBy(bigint.Matcher(4))).Return(headers, nil)
+	_, err := headerTraversal.NextHeaders(5)
+	require.NoError(t, err)
+
+	// blocks [3..6] are reorged onto block 2
+	reorgedHeaders := make([]types.Header, 4)
+	for i := range reorgedHeaders {
+		reorgedHeaders[i] = types.Header{Number: big.NewInt(int64(i + 3)), Time: 1}
+		if i == 0 {
+			reorgedHeaders[i].ParentHash = headers[2].Hash()
+		} else {
+			reorgedHeaders[i].ParentHash = reorgedHeaders[i-1].Hash()
+		}
+	}
+	client.On("BlockHeaderByNumber", (*big.Int)(nil)).Return(&reorgedHeaders[3], nil)
+	client.On("BlockHeadersByRange", mock.MatchedBy(bigint.Matcher(5)), mock.MatchedBy(bigint.Matcher(6))).Return(reorgedHeaders[2:], nil)
+	_, err = headerTraversal.NextHeaders(5)
+	require.Equal(t, ErrHeaderTraversalAndProviderMismatchedState, err)
+
+	// continues from the common ancestor
+	headerTraversal.Rewind(&headers[2])
+	client.On("BlockHeadersByRange", mock.MatchedBy(bigint.Matcher(3)), mock.MatchedBy(bigint.Matcher(6))).Return(reorgedHeaders, nil)
+	nextHeaders, err := headerTraversal.NextHeaders(5)
+	require.NoError(t, err)
+	require.Equal(t, reorgedHeaders, nextHeaders)
+	require.Equal(t, reorgedHeaders[3].Hash(), headerTraversal.LastHeader().Hash())
+}
@@ -21,55 +21,94 @@ type BridgeProcessor struct {
 	metrics bridge.Metricer
 
 	l1Etl       *etl.L1ETL
+	l2Etl       *etl.L2ETL
 	chainConfig config.ChainConfig
 
 	LatestL1Header *types.Header
 	LatestL2Header *types.Header
 }
 
-func NewBridgeProcessor(log log.Logger, db *database.DB, metrics bridge.Metricer, l1Etl *etl.L1ETL, chainConfig config.ChainConfig) (*BridgeProcessor, error) {
+func NewBridgeProcessor(log log.Logger, db *database.DB, metrics bridge.Metricer, l1Etl *etl.L1ETL, l2Etl *etl.L2ETL, chainConfig config.ChainConfig) (*BridgeProcessor, error) {
 	log = log.New("processor", "bridge")
 
-	latestL1Header, err := db.BridgeTransactions.L1LatestBlockHeader()
-	if err != nil {
+	b := &BridgeProcessor{log: log, db: db, metrics: metrics, l1Etl: l1Etl, l2Etl: l2Etl, chainConfig: chainConfig}
+	if err := b.loadLatestHeaders(); err != nil {
 		return nil, err
 	}
-	latestL2Header, err := db.BridgeTransactions.L2LatestBlockHeader()
+
+	return b, nil
+}
+
+// loadLatestHeaders sets the L1 & L2 markers from the latest indexed bridge state
+func (b *BridgeProcessor) loadLatestHeaders() error {
+	latestL1Header, err := b.db.BridgeTransactions.L1LatestBlockHeader()
 	if err != nil {
-		return nil, err
+		return err
+	}
+	latestL2Header, err := b.db.BridgeTransactions.L2LatestBlockHeader()
+	if err != nil {
+		return err
 	}
 
 	var l1Header, l2Header *types.Header
 	if latestL1Header == nil && latestL2Header == nil {
-		log.Info("no indexed state, starting from rollup genesis")
+		b.log.Info("no indexed state, starting from rollup genesis")
 	} else {
 		l1Height, l2Height := bigint.Zero, bigint.Zero
 		if latestL1Header != nil {
 			l1Height = latestL1Header.Number
 			l1Header = latestL1Header.RLPHeader.Header()
-			metrics.RecordLatestIndexedL1Height(l1Height)
+			b.metrics.RecordLatestIndexedL1Height(l1Height)
 		}
 		if latestL2Header != nil {
 			l2Height = latestL2Header.Number
 			l2Header = latestL2Header.RLPHeader.Header()
-			metrics.RecordLatestIndexedL2Height(l2Height)
+			b.metrics.RecordLatestIndexedL2Height(l2Height)
 		}
-		log.Info("detected latest indexed bridge state", "l1_block_number", l1Height, "l2_block_number", l2Height)
+		b.log.Info("detected latest indexed bridge state", "l1_block_number", l1Height, "l2_block_number", l2Height)
 	}
 
-	return &BridgeProcessor{log, db, metrics, l1Etl, chainConfig, l1Header, l2Header}, nil
+	b.LatestL1Header, b.LatestL2Header = l1Header, l2Header
+	return nil
+}
+
+// reorgedLatestHeaders checks if either of the L1 & L2 markers have been removed by the ETL
+// when rolling back a reorg. The bridge state derived from the removed blocks is removed as well.
+func (b *BridgeProcessor) reorgedLatestHeaders() (bool, error) {
+	if b.LatestL1Header != nil {
+		l1Header, err := b.db.Blocks.L1BlockHeader(b.LatestL1Header.Hash())
+		if err != nil {
+			return false, err
+		} else if l1Header == nil {
+			return true, nil
+		}
+	}
+	if b.LatestL2Header != nil {
+		l2Header, err := b.db.Blocks.L2BlockHeader(b.LatestL2Header.Hash())
+		if err != nil {
+			return false, err
+		} else if l2Header == nil {
+			return true, nil
+		}
+	}
+	return false, nil
 }
 
 func (b *BridgeProcessor) Start(ctx context.Context) error {
 	done := ctx.Done()
 
-	// Fire off independently on startup to check for
-	// new data or if we've indexed new L1 data.
+	// Fire off independently on startup to check for new data or if we've indexed new
+	// L1 or L2 data. Both ETLs also notify once reorged state has been removed.
 	l1EtlUpdates := b.l1Etl.Notify()
+	l2EtlUpdates := b.l2Etl.Notify()
 	startup := make(chan interface{}, 1)
 	startup <- nil
 
 	b.log.Info("starting bridge processor...")
+	if err := b.backfill(ctx); err != nil {
+		return err
+	}
+
 	for {
 		select {
 		case <-done:
@@ -79,6 +118,7 @@ func (b *BridgeProcessor) Start(ctx context.Context) error {
 		// Tickers
 		case <-startup:
 		case <-l1EtlUpdates:
+		case <-l2EtlUpdates:
 		}
 
 		done := b.metrics.RecordInterval()
@@ -91,9 +131,19 @@ func (b *BridgeProcessor) Start(ctx context.Context) error {
 // L1 and L2 when processing events. The lastest shared indexed time (epochs) between
 // L1 and L2 serves as this shared marker.
 func (b *BridgeProcessor) run() error {
+	reorged, err := b.reorgedLatestHeaders()
+	if err != nil {
+		return err
+	} else if reorged {
+		b.log.Warn("latest indexed bridge state reorged. reloading markers")
+		if err := b.loadLatestHeaders(); err != nil {
+			return err
+		}
+	}
+
 	// In the event where we have a large number of un-observed epochs, we cap the search
-	// of epochs by 10k. If this turns out to be a bottleneck, we can parallelize the processing
-	// of epochs to significantly speed up sync times.
+	// of epochs by 10k. Large amounts of history are instead indexed concurrently by the backfill
+	// on startup, when enabled.
 	maxEpochRange := uint64(10_000)
 	var lastEpoch *big.Int
 	if b.LatestL1Header != nil {
@@ -143,80 +193,18 @@ func (b *BridgeProcessor) run() error {
 		fromL2Height = new(big.Int).Add(b.LatestL2Header.Number, bigint.One)
 	}
 
-	l1BedrockStartingHeight := big.NewInt(int64(b.chainConfig.L1BedrockStartingHeight))
-	l2BedrockStartingHeight := big.NewInt(int64(b.chainConfig.L2BedrockStartingHeight))
-
 	batchLog := b.log.New("epoch_start_number", fromL1Height, "epoch_end_number", toL1Height)
 	batchLog.Info("unobserved epochs", "latest_l1_block_number", fromL1Height, "latest_l2_block_number", fromL2Height)
-	if err := b.db.Transaction(func(tx *database.DB) error {
-		l1BridgeLog := b.log.New("bridge", "l1")
-		l2BridgeLog := b.log.New("bridge", "l2")
-
-		// FOR OP-MAINNET, OP-GOERLI ONLY! Specially handle the existence of pre-bedrock blocks
-		if l1BedrockStartingHeight.Cmp(fromL1Height) > 0 {
-			legacyFromL1Height, legacyToL1Height := fromL1Height, toL1Height
-			legacyFromL2Height, legacyToL2Height := fromL2Height, toL2Height
-			if l1BedrockStartingHeight.Cmp(toL1Height) <= 0 {
-				legacyToL1Height = new(big.Int).Sub(l1BedrockStartingHeight, bigint.One)
-				legacyToL2Height = new(big.Int).Sub(l2BedrockStartingHeight, bigint.One)
-			}
-
-			l1BridgeLog = l1BridgeLog.New("mode", "legacy", "from_l1_block_number", legacyFromL1Height, "to_l1_block_number", legacyToL1Height)
-			l1BridgeLog.Info("scanning for bridge events")
-
-			l2BridgeLog = l2BridgeLog.New("mode", "legacy", "from_l2_block_number", legacyFromL2Height, "to_l2_block_number", legacyToL2Height)
-			l2BridgeLog.Info("scanning for bridge events")
-
-			// First, find all possible initiated bridge events
-			if err := bridge.LegacyL1ProcessInitiatedBridgeEvents(l1BridgeLog, tx, b.metrics, b.chainConfig.L1Contracts, legacyFromL1Height, legacyToL1Height); err != nil {
-				return err
-			}
-			if err := bridge.LegacyL2ProcessInitiatedBridgeEvents(l2BridgeLog, tx, b.metrics, b.chainConfig.L2Contracts, legacyFromL2Height, legacyToL2Height); err != nil {
-				return err
-			}
-
-			// Now that all initiated events have been indexed, it is ensured that all finalization can find their counterpart.
-			if err := bridge.LegacyL1ProcessFinalizedBridgeEvents(l1BridgeLog, tx, b.metrics, b.l1Etl.EthClient, b.chainConfig.L1Contracts, legacyFromL1Height, legacyToL1Height); err != nil {
-				return err
-			}
-			if err := bridge.LegacyL2ProcessFinalizedBridgeEvents(l2BridgeLog, tx, b.metrics, b.chainConfig.L2Contracts, legacyFromL2Height, legacyToL2Height); err != nil {
-				return err
-			}
-
-			if legacyToL1Height.Cmp(toL1Height) == 0 {
-				// a-ok! entire batch was legacy blocks
-				return nil
-			}
-
-			batchLog.Info("detected switch to bedrock", "l1_bedrock_starting_height", l1BedrockStartingHeight, "l2_bedrock_starting_height", l2BedrockStartingHeight)
-			fromL1Height = l1BedrockStartingHeight
-			fromL2Height = l2BedrockStartingHeight
-		}
-
-		l1BridgeLog = l1BridgeLog.New("from_l1_block_number", fromL1Height, "to_l1_block_number", toL1Height)
-		l1BridgeLog.Info("scanning for bridge events")
-
-		l2BridgeLog = l2BridgeLog.New("from_l2_block_number", fromL2Height, "to_l2_block_number", toL2Height)
-		l2BridgeLog.Info("scanning for bridge events")
 
+	epochs := epochRange{fromL1Height: fromL1Height, toL1Height: toL1Height, fromL2Height: fromL2Height, toL2Height: toL2Height}
+	if err := b.db.Transaction(func(tx *database.DB) error {
 		// First, find all possible initiated bridge events
-		if err := bridge.L1ProcessInitiatedBridgeEvents(l1BridgeLog, tx, b.metrics, b.chainConfig.L1Contracts, fromL1Height, toL1Height); err != nil {
-			return err
-		}
-		if err := bridge.L2ProcessInitiatedBridgeEvents(l2BridgeLog, tx, b.metrics, b.chainConfig.L2Contracts, fromL2Height, toL2Height); err != nil {
-			return err
-		}
-
-		// Now all finalization events can find their counterpart.
-		if err := bridge.L1ProcessFinalizedBridgeEvents(l1BridgeLog, tx, b.metrics, b.chainConfig.L1Contracts, fromL1Height, toL1Height); err != nil {
-			return err
-		}
-		if err := bridge.L2ProcessFinalizedBridgeEvents(l2BridgeLog, tx, b.metrics, b.chainConfig.L2Contracts, fromL2Height, toL2Height); err != nil {
+		if err := b.processInitiatedBridgeEvents(tx, epochs); err != nil {
 			return err
 		}
 
-		// a-ok
-		return nil
+		// Now that all initiated events have been indexed, it is ensured that all finalization can find their counterpart.
+		return b.processFinalizedBridgeEvents(tx, epochs)
 	}); err != nil {
 		batchLog.Error("failed to index bridge events", "err", err)
 		return err
@@ -227,3 +215,106 @@ func (b *BridgeProcessor) run() error {
 	b.LatestL2Header = latestEpoch.L2BlockHeader.RLPHeader.Header()
 	return nil
 }
+
+// epochRange is an inclusive range of L1 & L2 blocks, ending at an observed epoch
+type epochRange struct {
+	fromL1Height, toL1Height *big.Int
+	fromL2Height, toL2Height *big.Int
+}
+
+// splitAtBedrock splits the range into its pre-bedrock and bedrock parts, either of which may be nil.
+//
+// FOR OP-MAINNET, OP-GOERLI ONLY! Other chains start at bedrock, with zero starting heights.
+func (b *BridgeProcessor) splitAtBedrock(epochs epochRange) (*epochRange, *epochRange) {
+	l1BedrockStartingHeight := big.NewInt(int64(b.chainConfig.L1BedrockStartingHeight))
+	l2BedrockStartingHeight := big.NewInt(int64(b.chainConfig.L2BedrockStartingHeight))
+	if l1BedrockStartingHeight.Cmp(epochs.fromL1Height) <= 0 {
+		return nil, &epochs
+	} else if l1BedrockStartingHeight.Cmp(epochs.toL1Height) > 0 {
+		return &epochs, nil
+	}
+
+	b.log.Info("detected switch to bedrock", "l1_bedrock_starting_height", l1BedrockStartingHeight, "l2_bedrock_starting_height", l2BedrockStartingHeight)
+	legacy := epochRange{
+		fromL1Height: epochs.fromL1Height, toL1Height: new(big.Int).Sub(l1BedrockStartingHeight, bigint.One),
+		fromL2Height: epochs.fromL2Height, toL2Height: new(big.Int).Sub(l2BedrockStartingHeight, bigint.One),
+	}
+	bedrock := epochRange{
+		fromL1Height: l1BedrockStartingHeight, toL1Height: epochs.toL1Height,
+		fromL2Height: l2BedrockStartingHeight, toL2Height: epochs.toL2Height,
+	}
+	return &legacy, &bedrock
+}
+
+// bridgeLogs returns the L1 & L2 loggers scoped to the range
+func (b *BridgeProcessor) bridgeLogs(epochs *epochRange, ctx ...interface{}) (log.Logger, log.Logger) {
+	l1BridgeLog := b.log.New("bridge", "l1", "from_l1_block_number", epochs.fromL1Height, "to_l1_block_number", epochs.toL1Height).New(ctx...)
+	l2BridgeLog := b.log.New("bridge", "l2", "from_l2_block_number", epochs.fromL2Height, "to_l2_block_number", epochs.toL2Height).New(ctx...)
+	return l1BridgeLog, l2BridgeLog
+}
+
+// processInitiatedBridgeEvents indexes the bridge events initiated within the range. The initiated events of
+// a range are independent of any other range.
+func (b *BridgeProcessor) processInitiatedBridgeEvents(tx *database.DB, epochs epochRange) error {
+	legacy, bedrock := b.splitAtBedrock(epochs)
+	if legacy != nil {
+		l1BridgeLog, l2BridgeLog := b.bridgeLogs(legacy, "mode", "legacy")
+		l1BridgeLog.Info("scanning for initiated bridge events")
+		if err := bridge.LegacyL1ProcessInitiatedBridgeEvents(l1BridgeLog, tx, b.metrics, b.chainConfig.L1Contracts, legacy.fromL1Height, legacy.toL1Height); err != nil {
+			return err
+		}
+		l2BridgeLog.Info("scanning for initiated bridge events")
+		if err := bridge.LegacyL2ProcessInitiatedBridgeEvents(l2BridgeLog, tx, b.metrics, b.chainConfig.L2Contracts, legacy.fromL2Height, legacy.toL2Height); err != nil {
+			return err
+		}
+	}
+	if bedrock != nil {
+		l1BridgeLog, l2BridgeLog := b.bridgeLogs(bedrock)
+		l1BridgeLog.Info("scanning for initiated bridge events")
+		if err := bridge.L1ProcessInitiatedBridgeEvents(l1BridgeLog, tx, b.metrics, b.chainConfig.L1Contracts, bedrock.fromL1Height, bedrock.toL1Height); err != nil {
+			return err
+		}
+		l2BridgeLog.Info("scanning for initiated bridge events")
+		if err := bridge.L2ProcessInitiatedBridgeEvents(l2BridgeLog, tx, b.metrics, b.chainConfig.L2Contracts, bedrock.fromL2Height, bedrock.toL2Height); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// processFinalizedBridgeEvents indexes the bridge events finalized within the range. The initiated events of
+// this range, and all ranges prior, must already be indexed such that all finalizations find their counterpart.
+func (b *BridgeProcessor) processFinalizedBridgeEvents(tx *database.DB, epochs epochRange) error {
+	legacy, bedrock := b.splitAtBedrock(epochs)
+	if legacy != nil {
+		l1BridgeLog, l2BridgeLog := b.bridgeLogs(legacy, "mode", "legacy")
+		l1BridgeLog.Info("scanning for finalized bridge events")
+		if err := bridge.LegacyL1ProcessFinalizedBridgeEvents(l1BridgeLog, tx, b.metrics, b.l1Etl.EthClient, b.chainConfig.L1Contracts, legacy.fromL1Height, legacy.toL1Height); err != nil {
+			return err
+		}
+		l2BridgeLog.Info("scanning for finalized bridge events")
+		if err := bridge.LegacyL2ProcessFinalizedBridgeEvents(l2BridgeLog, tx, b.metrics, b.chainConfig.L2Contracts, legacy.fromL2Height, legacy.toL2Height); err != nil {
+			return err
+		}
+	}
+	if bedrock != nil {
+		l1BridgeLog, l2BridgeLog := b.bridgeLogs(bedrock)
+
+		// Output proposals are independent of the bridge events, but withdrawals are proven against them. Since
+		// deletions apply to the outputs proposed prior, these are indexed in order alongside the finalizations
+		l1BridgeLog.Info("scanning for output proposals")
+		if err := bridge.L1ProcessOutputProposals(l1BridgeLog, tx, b.metrics, b.chainConfig.L1Contracts, bedrock.fromL1Height, bedrock.toL1Height); err != nil {
+			return err
+		}
+
+		l1BridgeLog.Info("scanning for finalized bridge events")
+		if err := bridge.L1ProcessFinalizedBridgeEvents(l1BridgeLog, tx, b.metrics, b.l1Etl.EthClient, b.chainConfig.L1Contracts, bedrock.fromL1Height, bedrock.toL1Height); err != nil {
+			return err
+		}
+		l2BridgeLog.Info("scanning for finalized bridge events")
+		if err := bridge.L2ProcessFinalizedBridgeEvents(l2BridgeLog, tx, b.metrics, b.chainConfig.L2Contracts, bedrock.fromL2Height, bedrock.toL2Height); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package bridge
+
+import (
+	"github.com/ethereum-optimism/optimism/indexer/database"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// completedBridgeEvent constructs the event for a step completing a transfer, i.e proving or finalizing it. The
+// user-level from & to addresses are carried over from the initiated event of the transfer, falling back to the
+// underlying transaction for transfers initiated before bridge events were stored.
+func completedBridgeEvent(db *database.DB, eventType, initiatedType database.BridgeEventType, transferHash common.Hash, tx database.Transaction, contractEvent *database.ContractEvent) (database.BridgeEvent, error) {
+	event := database.BridgeEvent{
+		EventType:       eventType,
+		TransferHash:    transferHash,
+		FromAddress:     tx.FromAddress,
+		ToAddress:       tx.ToAddress,
+		TransactionHash: contractEvent.TransactionHash,
+		Timestamp:       contractEvent.Timestamp,
+	}
+
+	initiated, err := db.BridgeEvents.BridgeEventWithFilter(database.BridgeEvent{EventType: initiatedType, TransferHash: transferHash})
+	if err != nil {
+		return event, err
+	} else if initiated != nil {
+		event.MessageHash = initiated.MessageHash
+		event.FromAddress = initiated.FromAddress
+		event.ToAddress = initiated.ToAddress
+	}
+
+	return event, nil
+}
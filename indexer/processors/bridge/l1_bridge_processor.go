@@ -6,6 +6,7 @@ import (
 
 	"github.com/ethereum-optimism/optimism/indexer/config"
 	"github.com/ethereum-optimism/optimism/indexer/database"
+	"github.com/ethereum-optimism/optimism/indexer/node"
 	"github.com/ethereum-optimism/optimism/indexer/processors/contracts"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -18,6 +19,7 @@ import (
 //  1. OptimismPortal
 //  2. L1CrossDomainMessenger
 //  3. L1StandardBridge
+//  4. L1ERC721Bridge
 func L1ProcessInitiatedBridgeEvents(log log.Logger, db *database.DB, metrics L1Metricer, l1Contracts config.L1Contracts, fromHeight, toHeight *big.Int) error {
 	// (1) OptimismPortal
 	optimismPortalTxDeposits, err := contracts.OptimismPortalTransactionDepositEvents(l1Contracts.OptimismPortalProxy, db, fromHeight, toHeight)
@@ -28,8 +30,12 @@ func L1ProcessInitiatedBridgeEvents(log log.Logger, db *database.DB, metrics L1M
 		log.Info("detected transaction deposits", "size", len(optimismPortalTxDeposits))
 	}
 
+	// Every deposit is streamed as an initiated event. The from & to addresses are refined by the
+	// higher layers of the stack as the deposit is matched against messages & bridge transfers
 	portalDeposits := make(map[logKey]*contracts.OptimismPortalTransactionDepositEvent, len(optimismPortalTxDeposits))
 	transactionDeposits := make([]database.L1TransactionDeposit, len(optimismPortalTxDeposits))
+	depositEvents := make(map[common.Hash]*database.BridgeEvent, len(optimismPortalTxDeposits))
+	bridgeEvents := make([]database.BridgeEvent, len(optimismPortalTxDeposits))
 	for i := range optimismPortalTxDeposits {
 		depositTx := optimismPortalTxDeposits[i]
 		portalDeposits[logKey{depositTx.Event.BlockHash, depositTx.Event.LogIndex}] = &depositTx
@@ -40,6 +46,17 @@ func L1ProcessInitiatedBridgeEvents(log log.Logger, db *database.DB, metrics L1M
 			GasLimit:             depositTx.GasLimit,
 			Tx:                   depositTx.Tx,
 		}
+
+		bridgeEvents[i] = database.BridgeEvent{
+			L1ContractEventGUID: &depositTx.Event.GUID,
+			EventType:           database.BridgeEventDepositInitiated,
+			TransferHash:        depositTx.DepositTx.SourceHash,
+			FromAddress:         depositTx.Tx.FromAddress,
+			ToAddress:           depositTx.Tx.ToAddress,
+			TransactionHash:     depositTx.Event.TransactionHash,
+			Timestamp:           depositTx.Event.Timestamp,
+		}
+		depositEvents[depositTx.DepositTx.SourceHash] = &bridgeEvents[i]
 	}
 	if len(transactionDeposits) > 0 {
 		if err := db.BridgeTransactions.StoreL1TransactionDeposits(transactionDeposits); err != nil {
@@ -71,6 +88,11 @@ func L1ProcessInitiatedBridgeEvents(log log.Logger, db *database.DB, metrics L1M
 		}
 
 		bridgeMessages[i] = database.L1BridgeMessage{TransactionSourceHash: portalDeposit.DepositTx.SourceHash, BridgeMessage: sentMessage.BridgeMessage}
+
+		depositEvent := depositEvents[portalDeposit.DepositTx.SourceHash]
+		depositEvent.MessageHash = &sentMessage.BridgeMessage.MessageHash
+		depositEvent.FromAddress = sentMessage.BridgeMessage.Tx.FromAddress
+		depositEvent.ToAddress = sentMessage.BridgeMessage.Tx.ToAddress
 	}
 	if len(bridgeMessages) > 0 {
 		if err := db.BridgeMessages.StoreL1BridgeMessages(bridgeMessages); err != nil {
@@ -111,6 +133,10 @@ func L1ProcessInitiatedBridgeEvents(log log.Logger, db *database.DB, metrics L1M
 			TransactionSourceHash: portalDeposit.DepositTx.SourceHash,
 			BridgeTransfer:        initiatedBridge.BridgeTransfer,
 		}
+
+		depositEvent := depositEvents[portalDeposit.DepositTx.SourceHash]
+		depositEvent.FromAddress = initiatedBridge.BridgeTransfer.Tx.FromAddress
+		depositEvent.ToAddress = initiatedBridge.BridgeTransfer.Tx.ToAddress
 	}
 	if len(bridgeDeposits) > 0 {
 		if err := db.BridgeTransfers.StoreL1BridgeDeposits(bridgeDeposits); err != nil {
@@ -121,6 +147,60 @@ func L1ProcessInitiatedBridgeEvents(log log.Logger, db *database.DB, metrics L1M
 		}
 	}
 
+	// (4) L1ERC721Bridge
+	initiatedERC721Bridges, err := contracts.ERC721BridgeInitiatedEvents("l1", l1Contracts.L1ERC721BridgeProxy, db, fromHeight, toHeight)
+	if err != nil {
+		return err
+	}
+	if len(initiatedERC721Bridges) > 0 {
+		log.Info("detected nft bridge deposits", "size", len(initiatedERC721Bridges))
+	}
+
+	bridgedNFTs := make(map[common.Address]int)
+	erc721BridgeDeposits := make([]database.L1ERC721BridgeDeposit, len(initiatedERC721Bridges))
+	for i := range initiatedERC721Bridges {
+		initiatedBridge := initiatedERC721Bridges[i]
+
+		// Unlike the StandardBridge, the ERC721Bridge emits the initiated event after sending the message. The
+		// preceding events are the TransactionDeposit, SentMessage & SentMessageExtension1 events
+		sentMessage, ok := sentMessages[logKey{initiatedBridge.Event.BlockHash, initiatedBridge.Event.LogIndex - 2}]
+		if !ok {
+			log.Error("expected SentMessage preceding ERC721BridgeInitiated event", "tx_hash", initiatedBridge.Event.TransactionHash.String())
+			return fmt.Errorf("expected SentMessage preceding ERC721BridgeInitiated event. tx_hash = %s", initiatedBridge.Event.TransactionHash.String())
+		}
+		portalDeposit, ok := portalDeposits[logKey{initiatedBridge.Event.BlockHash, initiatedBridge.Event.LogIndex - 3}]
+		if !ok {
+			log.Error("expected TransactionDeposit preceding SentMessage event", "tx_hash", initiatedBridge.Event.TransactionHash.String())
+			return fmt.Errorf("expected TransactionDeposit preceding SentMessage event. tx_hash = %s", initiatedBridge.Event.TransactionHash.String())
+		}
+
+		initiatedBridge.BridgeTransfer.CrossDomainMessageHash = &sentMessage.BridgeMessage.MessageHash
+		bridgedNFTs[initiatedBridge.BridgeTransfer.TokenPair.LocalTokenAddress]++
+		erc721BridgeDeposits[i] = database.L1ERC721BridgeDeposit{
+			TransactionSourceHash: portalDeposit.DepositTx.SourceHash,
+			ERC721BridgeTransfer:  initiatedBridge.BridgeTransfer,
+		}
+
+		depositEvent := depositEvents[portalDeposit.DepositTx.SourceHash]
+		depositEvent.FromAddress = initiatedBridge.BridgeTransfer.FromAddress
+		depositEvent.ToAddress = initiatedBridge.BridgeTransfer.ToAddress
+	}
+	if len(erc721BridgeDeposits) > 0 {
+		if err := db.ERC721BridgeTransfers.StoreL1ERC721BridgeDeposits(erc721BridgeDeposits); err != nil {
+			return err
+		}
+		for tokenAddr, size := range bridgedNFTs {
+			metrics.RecordL1InitiatedERC721BridgeTransfers(tokenAddr, size)
+		}
+	}
+
+	// (5) Stream the initiated deposits
+	if len(bridgeEvents) > 0 {
+		if err := db.BridgeEvents.StoreBridgeEvents(bridgeEvents); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -129,7 +209,8 @@ func L1ProcessInitiatedBridgeEvents(log log.Logger, db *database.DB, metrics L1M
 //  1. OptimismPortal (Bedrock prove & finalize steps)
 //  2. L1CrossDomainMessenger (relayMessage marker)
 //  3. L1StandardBridge (no-op, since this is simply a wrapper over the L1CrossDomainMessenger)
-func L1ProcessFinalizedBridgeEvents(log log.Logger, db *database.DB, metrics L1Metricer, l1Contracts config.L1Contracts, fromHeight, toHeight *big.Int) error {
+//  4. L1ERC721Bridge (no-op, since this is simply a wrapper over the L1CrossDomainMessenger)
+func L1ProcessFinalizedBridgeEvents(log log.Logger, db *database.DB, metrics L1Metricer, l1Client node.EthClient, l1Contracts config.L1Contracts, fromHeight, toHeight *big.Int) error {
 	// (1) OptimismPortal (proven withdrawals)
 	provenWithdrawals, err := contracts.OptimismPortalWithdrawalProvenEvents(l1Contracts.OptimismPortalProxy, db, fromHeight, toHeight)
 	if err != nil {
@@ -139,6 +220,7 @@ func L1ProcessFinalizedBridgeEvents(log log.Logger, db *database.DB, metrics L1M
 		log.Info("detected proven withdrawals", "size", len(provenWithdrawals))
 	}
 
+	bridgeEvents := make([]database.BridgeEvent, 0, len(provenWithdrawals))
 	for i := range provenWithdrawals {
 		proven := provenWithdrawals[i]
 		withdrawal, err := db.BridgeTransactions.L2TransactionWithdrawal(proven.WithdrawalHash)
@@ -149,10 +231,38 @@ func L1ProcessFinalizedBridgeEvents(log log.Logger, db *database.DB, metrics L1M
 			return fmt.Errorf("missing indexed withdrawal! tx_hash = %s", proven.Event.TransactionHash.String())
 		}
 
-		if err := db.BridgeTransactions.MarkL2TransactionWithdrawalProvenEvent(proven.WithdrawalHash, provenWithdrawals[i].Event.GUID); err != nil {
+		// The output proven against is only part of the calldata. Withdrawals proven via another
+		// contract are still marked as proven, without tracking the output proposal
+		tx, err := l1Client.TxByHash(proven.Event.TransactionHash)
+		if err != nil {
+			return err
+		} else if tx == nil {
+			log.Error("missing tx for proven withdrawal", "tx_hash", proven.Event.TransactionHash.String())
+			return fmt.Errorf("missing tx for proven withdrawal. tx_hash = %s", proven.Event.TransactionHash.String())
+		}
+
+		var l2OutputIndex *big.Int
+		var outputRoot *common.Hash
+		provenOutput, err := contracts.OptimismPortalProvenWithdrawalOutput(l1Contracts.OptimismPortalProxy, tx, proven.Event)
+		if err != nil {
+			return err
+		} else if provenOutput != nil {
+			l2OutputIndex, outputRoot = provenOutput.L2OutputIndex, (*common.Hash)(&provenOutput.OutputRoot)
+		} else {
+			log.Warn("unable to decode the output proposal of a withdrawal proven via another contract", "tx_hash", proven.Event.TransactionHash.String())
+		}
+
+		if err := db.BridgeTransactions.MarkL2TransactionWithdrawalProvenEvent(proven.WithdrawalHash, provenWithdrawals[i].Event.GUID, l2OutputIndex, outputRoot); err != nil {
 			log.Error("failed to mark withdrawal as proven", "err", err, "tx_hash", proven.Event.TransactionHash.String())
 			return err
 		}
+
+		provenEvent, err := completedBridgeEvent(db, database.BridgeEventWithdrawalProven, database.BridgeEventWithdrawalInitiated, proven.WithdrawalHash, withdrawal.Tx, proven.Event)
+		if err != nil {
+			return err
+		}
+		provenEvent.L1ContractEventGUID = &proven.Event.GUID
+		bridgeEvents = append(bridgeEvents, provenEvent)
 	}
 	if len(provenWithdrawals) > 0 {
 		metrics.RecordL1ProvenWithdrawals(len(provenWithdrawals))
@@ -181,10 +291,22 @@ func L1ProcessFinalizedBridgeEvents(log log.Logger, db *database.DB, metrics L1M
 			log.Error("failed to mark withdrawal as finalized", "err", err, "tx_hash", finalized.Event.TransactionHash.String())
 			return err
 		}
+
+		finalizedEvent, err := completedBridgeEvent(db, database.BridgeEventWithdrawalFinalized, database.BridgeEventWithdrawalInitiated, finalized.WithdrawalHash, withdrawal.Tx, finalized.Event)
+		if err != nil {
+			return err
+		}
+		finalizedEvent.L1ContractEventGUID = &finalized.Event.GUID
+		bridgeEvents = append(bridgeEvents, finalizedEvent)
 	}
 	if len(finalizedWithdrawals) > 0 {
 		metrics.RecordL1FinalizedWithdrawals(len(finalizedWithdrawals))
 	}
+	if len(bridgeEvents) > 0 {
+		if err := db.BridgeEvents.StoreBridgeEvents(bridgeEvents); err != nil {
+			return err
+		}
+	}
 
 	// (3) L1CrossDomainMessenger
 	crossDomainRelayedMessages, err := contracts.CrossDomainMessengerRelayedMessageEvents("l1", l1Contracts.L1CrossDomainMessengerProxy, db, fromHeight, toHeight)
@@ -253,6 +375,107 @@ func L1ProcessFinalizedBridgeEvents(log log.Logger, db *database.DB, metrics L1M
 		}
 	}
 
+	// (5) L1ERC721Bridge
+	finalizedERC721Bridges, err := contracts.ERC721BridgeFinalizedEvents("l1", l1Contracts.L1ERC721BridgeProxy, db, fromHeight, toHeight)
+	if err != nil {
+		return err
+	}
+	if len(finalizedERC721Bridges) > 0 {
+		log.Info("detected finalized nft bridge withdrawals", "size", len(finalizedERC721Bridges))
+	}
+
+	finalizedNFTs := make(map[common.Address]int)
+	for i := range finalizedERC721Bridges {
+		finalizedBridge := finalizedERC721Bridges[i]
+		relayedMessage, ok := relayedMessages[logKey{finalizedBridge.Event.BlockHash, finalizedBridge.Event.LogIndex + 1}]
+		if !ok {
+			log.Error("expected RelayedMessage following ERC721BridgeFinalized event", "tx_hash", finalizedBridge.Event.TransactionHash.String())
+			return fmt.Errorf("expected RelayedMessage following ERC721BridgeFinalized event. tx_hash = %s", finalizedBridge.Event.TransactionHash.String())
+		}
+
+		withdrawal, err := db.ERC721BridgeTransfers.L2ERC721BridgeWithdrawalWithFilter(database.ERC721BridgeTransfer{CrossDomainMessageHash: &relayedMessage.MessageHash})
+		if err != nil {
+			return err
+		} else if withdrawal == nil {
+			log.Error("missing L2ERC721Bridge withdrawal on L1 finalization", "tx_hash", finalizedBridge.Event.TransactionHash.String())
+			return fmt.Errorf("missing L2ERC721Bridge withdrawal on L1 finalization. tx_hash: %s", finalizedBridge.Event.TransactionHash.String())
+		}
+
+		finalizedNFTs[finalizedBridge.BridgeTransfer.TokenPair.LocalTokenAddress]++
+	}
+	if len(finalizedERC721Bridges) > 0 {
+		for tokenAddr, size := range finalizedNFTs {
+			metrics.RecordL1FinalizedERC721BridgeTransfers(tokenAddr, size)
+		}
+	}
+
 	// a-ok!
 	return nil
 }
+
+// L1ProcessOutputProposals will query the database for output roots proposed to, and deleted from, the
+// L2OutputOracle between the specified block range. Withdrawals are proven against these proposals.
+func L1ProcessOutputProposals(log log.Logger, db *database.DB, metrics L1Metricer, l1Contracts config.L1Contracts, fromHeight, toHeight *big.Int) error {
+	proposedOutputs, err := contracts.L2OutputOracleOutputProposedEvents(l1Contracts.L2OutputOracleProxy, db, fromHeight, toHeight)
+	if err != nil {
+		return err
+	}
+	if len(proposedOutputs) > 0 {
+		log.Info("detected output proposals", "size", len(proposedOutputs))
+	}
+
+	deletedOutputs, err := contracts.L2OutputOracleOutputsDeletedEvents(l1Contracts.L2OutputOracleProxy, db, fromHeight, toHeight)
+	if err != nil {
+		return err
+	}
+	if len(deletedOutputs) > 0 {
+		log.Warn("detected deleted outputs", "size", len(deletedOutputs))
+	}
+
+	// A deletion only applies to the outputs proposed before it. L1 timestamps are strictly
+	// increasing, ordering the events by (timestamp, log index)
+	before := func(a, b *database.ContractEvent) bool {
+		return a.Timestamp < b.Timestamp || (a.Timestamp == b.Timestamp && a.LogIndex < b.LogIndex)
+	}
+
+	proposals := make([]database.L2OutputProposal, 0, len(proposedOutputs))
+	storeProposals := func() error {
+		if len(proposals) == 0 {
+			return nil
+		}
+		if err := db.L2OutputProposals.StoreL2OutputProposals(proposals); err != nil {
+			return err
+		}
+		metrics.RecordL1OutputProposals(len(proposals))
+		proposals = proposals[:0]
+		return nil
+	}
+
+	for i, j := 0, 0; i < len(proposedOutputs) || j < len(deletedOutputs); {
+		if i < len(proposedOutputs) && (j == len(deletedOutputs) || before(proposedOutputs[i].Event, deletedOutputs[j].Event)) {
+			proposed := proposedOutputs[i]
+			proposals = append(proposals, database.L2OutputProposal{
+				ProposedL1EventGUID: proposed.Event.GUID,
+				OutputRoot:          proposed.OutputRoot,
+				L2OutputIndex:       proposed.L2OutputIndex,
+				L2BlockNumber:       proposed.L2BlockNumber,
+				Timestamp:           proposed.L1Timestamp.Uint64(),
+			})
+			i++
+			continue
+		}
+
+		deleted := deletedOutputs[j]
+		if err := storeProposals(); err != nil {
+			return err
+		}
+		if err := db.L2OutputProposals.MarkL2OutputProposalsDeleted(deleted.NewNextOutputIndex, deleted.Event); err != nil {
+			log.Error("failed to mark outputs as deleted", "err", err, "tx_hash", deleted.Event.TransactionHash.String())
+			return err
+		}
+		j++
+	}
+
+	// a-ok!
+	return storeProposals()
+}
@@ -18,6 +18,7 @@ import (
 //  1. OptimismPortal
 //  2. L2CrossDomainMessenger
 //  3. L2StandardBridge
+//  4. L2ERC721Bridge
 func L2ProcessInitiatedBridgeEvents(log log.Logger, db *database.DB, metrics L2Metricer, l2Contracts config.L2Contracts, fromHeight, toHeight *big.Int) error {
 	// (1) L2ToL1MessagePasser
 	l2ToL1MPMessagesPassed, err := contracts.L2ToL1MessagePasserMessagePassedEvents(l2Contracts.L2ToL1MessagePasser, db, fromHeight, toHeight)
@@ -28,8 +29,12 @@ func L2ProcessInitiatedBridgeEvents(log log.Logger, db *database.DB, metrics L2M
 		log.Info("detected transaction withdrawals", "size", len(l2ToL1MPMessagesPassed))
 	}
 
+	// Every withdrawal is streamed as an initiated event. The from & to addresses are refined by the
+	// higher layers of the stack as the withdrawal is matched against messages & bridge transfers
 	messagesPassed := make(map[logKey]*contracts.L2ToL1MessagePasserMessagePassed, len(l2ToL1MPMessagesPassed))
 	transactionWithdrawals := make([]database.L2TransactionWithdrawal, len(l2ToL1MPMessagesPassed))
+	withdrawalEvents := make(map[common.Hash]*database.BridgeEvent, len(l2ToL1MPMessagesPassed))
+	bridgeEvents := make([]database.BridgeEvent, len(l2ToL1MPMessagesPassed))
 	for i := range l2ToL1MPMessagesPassed {
 		messagePassed := l2ToL1MPMessagesPassed[i]
 		messagesPassed[logKey{messagePassed.Event.BlockHash, messagePassed.Event.LogIndex}] = &messagePassed
@@ -40,6 +45,17 @@ func L2ProcessInitiatedBridgeEvents(log log.Logger, db *database.DB, metrics L2M
 			GasLimit:             messagePassed.GasLimit,
 			Tx:                   messagePassed.Tx,
 		}
+
+		bridgeEvents[i] = database.BridgeEvent{
+			L2ContractEventGUID: &messagePassed.Event.GUID,
+			EventType:           database.BridgeEventWithdrawalInitiated,
+			TransferHash:        messagePassed.WithdrawalHash,
+			FromAddress:         messagePassed.Tx.FromAddress,
+			ToAddress:           messagePassed.Tx.ToAddress,
+			TransactionHash:     messagePassed.Event.TransactionHash,
+			Timestamp:           messagePassed.Event.Timestamp,
+		}
+		withdrawalEvents[messagePassed.WithdrawalHash] = &bridgeEvents[i]
 	}
 	if len(messagesPassed) > 0 {
 		if err := db.BridgeTransactions.StoreL2TransactionWithdrawals(transactionWithdrawals); err != nil {
@@ -71,6 +87,11 @@ func L2ProcessInitiatedBridgeEvents(log log.Logger, db *database.DB, metrics L2M
 		}
 
 		bridgeMessages[i] = database.L2BridgeMessage{TransactionWithdrawalHash: messagePassed.WithdrawalHash, BridgeMessage: sentMessage.BridgeMessage}
+
+		withdrawalEvent := withdrawalEvents[messagePassed.WithdrawalHash]
+		withdrawalEvent.MessageHash = &sentMessage.BridgeMessage.MessageHash
+		withdrawalEvent.FromAddress = sentMessage.BridgeMessage.Tx.FromAddress
+		withdrawalEvent.ToAddress = sentMessage.BridgeMessage.Tx.ToAddress
 	}
 	if len(bridgeMessages) > 0 {
 		if err := db.BridgeMessages.StoreL2BridgeMessages(bridgeMessages); err != nil {
@@ -111,6 +132,10 @@ func L2ProcessInitiatedBridgeEvents(log log.Logger, db *database.DB, metrics L2M
 			TransactionWithdrawalHash: messagePassed.WithdrawalHash,
 			BridgeTransfer:            initiatedBridge.BridgeTransfer,
 		}
+
+		withdrawalEvent := withdrawalEvents[messagePassed.WithdrawalHash]
+		withdrawalEvent.FromAddress = initiatedBridge.BridgeTransfer.Tx.FromAddress
+		withdrawalEvent.ToAddress = initiatedBridge.BridgeTransfer.Tx.ToAddress
 	}
 	if len(bridgeWithdrawals) > 0 {
 		if err := db.BridgeTransfers.StoreL2BridgeWithdrawals(bridgeWithdrawals); err != nil {
@@ -121,6 +146,60 @@ func L2ProcessInitiatedBridgeEvents(log log.Logger, db *database.DB, metrics L2M
 		}
 	}
 
+	// (4) L2ERC721Bridge
+	initiatedERC721Bridges, err := contracts.ERC721BridgeInitiatedEvents("l2", l2Contracts.L2ERC721Bridge, db, fromHeight, toHeight)
+	if err != nil {
+		return err
+	}
+	if len(initiatedERC721Bridges) > 0 {
+		log.Info("detected nft bridge withdrawals", "size", len(initiatedERC721Bridges))
+	}
+
+	bridgedNFTs := make(map[common.Address]int)
+	erc721BridgeWithdrawals := make([]database.L2ERC721BridgeWithdrawal, len(initiatedERC721Bridges))
+	for i := range initiatedERC721Bridges {
+		initiatedBridge := initiatedERC721Bridges[i]
+
+		// Unlike the StandardBridge, the ERC721Bridge emits the initiated event after sending the message. The
+		// preceding events are the MessagePassed, SentMessage & SentMessageExtension1 events
+		sentMessage, ok := sentMessages[logKey{initiatedBridge.Event.BlockHash, initiatedBridge.Event.LogIndex - 2}]
+		if !ok {
+			log.Error("expected SentMessage preceding ERC721BridgeInitiated event", "tx_hash", initiatedBridge.Event.TransactionHash.String())
+			return fmt.Errorf("expected SentMessage preceding ERC721BridgeInitiated event. tx_hash = %s", initiatedBridge.Event.TransactionHash.String())
+		}
+		messagePassed, ok := messagesPassed[logKey{initiatedBridge.Event.BlockHash, initiatedBridge.Event.LogIndex - 3}]
+		if !ok {
+			log.Error("expected MessagePassed preceding SentMessage event", "tx_hash", initiatedBridge.Event.TransactionHash.String())
+			return fmt.Errorf("expected MessagePassed preceding SentMessage event. tx_hash = %s", initiatedBridge.Event.TransactionHash.String())
+		}
+
+		initiatedBridge.BridgeTransfer.CrossDomainMessageHash = &sentMessage.BridgeMessage.MessageHash
+		bridgedNFTs[initiatedBridge.BridgeTransfer.TokenPair.LocalTokenAddress]++
+		erc721BridgeWithdrawals[i] = database.L2ERC721BridgeWithdrawal{
+			TransactionWithdrawalHash: messagePassed.WithdrawalHash,
+			ERC721BridgeTransfer:      initiatedBridge.BridgeTransfer,
+		}
+
+		withdrawalEvent := withdrawalEvents[messagePassed.WithdrawalHash]
+		withdrawalEvent.FromAddress = initiatedBridge.BridgeTransfer.FromAddress
+		withdrawalEvent.ToAddress = initiatedBridge.BridgeTransfer.ToAddress
+	}
+	if len(erc721BridgeWithdrawals) > 0 {
+		if err := db.ERC721BridgeTransfers.StoreL2ERC721BridgeWithdrawals(erc721BridgeWithdrawals); err != nil {
+			return err
+		}
+		for tokenAddr, size := range bridgedNFTs {
+			metrics.RecordL2InitiatedERC721BridgeTransfers(tokenAddr, size)
+		}
+	}
+
+	// (5) Stream the initiated withdrawals
+	if len(bridgeEvents) > 0 {
+		if err := db.BridgeEvents.StoreBridgeEvents(bridgeEvents); err != nil {
+			return err
+		}
+	}
+
 	// a-ok!
 	return nil
 }
@@ -129,6 +208,7 @@ func L2ProcessInitiatedBridgeEvents(log log.Logger, db *database.DB, metrics L2M
 // bridge events. This covers every part of the multi-layered stack:
 //  1. L2CrossDomainMessenger (relayMessage marker)
 //  2. L2StandardBridge (no-op, since this is simply a wrapper over the L2CrossDomainMEssenger)
+//  3. L2ERC721Bridge (no-op, since this is simply a wrapper over the L2CrossDomainMessenger)
 //
 // NOTE: Unlike L1, there's no L2ToL1MessagePasser stage since transaction deposits are apart of the block derivation process.
 func L2ProcessFinalizedBridgeEvents(log log.Logger, db *database.DB, metrics L2Metricer, l2Contracts config.L2Contracts, fromHeight, toHeight *big.Int) error {
@@ -141,7 +221,10 @@ func L2ProcessFinalizedBridgeEvents(log log.Logger, db *database.DB, metrics L2M
 		log.Info("detected relayed messages", "size", len(crossDomainRelayedMessages))
 	}
 
+	// Deposits without a cross domain message are executed as part of the L2 derivation process. Only
+	// deposits relayed by the messenger are streamed as finalized events
 	relayedMessages := make(map[logKey]*contracts.CrossDomainMessengerRelayedMessageEvent, len(crossDomainRelayedMessages))
+	bridgeEvents := make([]database.BridgeEvent, len(crossDomainRelayedMessages))
 	for i := range crossDomainRelayedMessages {
 		relayed := crossDomainRelayedMessages[i]
 		relayedMessages[logKey{BlockHash: relayed.Event.BlockHash, LogIndex: relayed.Event.LogIndex}] = &relayed
@@ -157,8 +240,19 @@ func L2ProcessFinalizedBridgeEvents(log log.Logger, db *database.DB, metrics L2M
 			log.Error("failed to relay cross domain message", "err", err, "tx_hash", relayed.Event.TransactionHash.String())
 			return err
 		}
+
+		finalizedEvent, err := completedBridgeEvent(db, database.BridgeEventDepositFinalized, database.BridgeEventDepositInitiated, message.TransactionSourceHash, message.Tx, relayed.Event)
+		if err != nil {
+			return err
+		}
+		finalizedEvent.L2ContractEventGUID = &relayed.Event.GUID
+		finalizedEvent.MessageHash = &relayed.MessageHash
+		bridgeEvents[i] = finalizedEvent
 	}
 	if len(relayedMessages) > 0 {
+		if err := db.BridgeEvents.StoreBridgeEvents(bridgeEvents); err != nil {
+			return err
+		}
 		metrics.RecordL2CrossDomainRelayedMessages(len(relayedMessages))
 	}
 
@@ -199,6 +293,40 @@ func L2ProcessFinalizedBridgeEvents(log log.Logger, db *database.DB, metrics L2M
 		}
 	}
 
+	// (3) L2ERC721Bridge
+	finalizedERC721Bridges, err := contracts.ERC721BridgeFinalizedEvents("l2", l2Contracts.L2ERC721Bridge, db, fromHeight, toHeight)
+	if err != nil {
+		return err
+	}
+	if len(finalizedERC721Bridges) > 0 {
+		log.Info("detected finalized nft bridge deposits", "size", len(finalizedERC721Bridges))
+	}
+
+	finalizedNFTs := make(map[common.Address]int)
+	for i := range finalizedERC721Bridges {
+		finalizedBridge := finalizedERC721Bridges[i]
+		relayedMessage, ok := relayedMessages[logKey{finalizedBridge.Event.BlockHash, finalizedBridge.Event.LogIndex + 1}]
+		if !ok {
+			log.Error("expected RelayedMessage following ERC721BridgeFinalized event", "tx_hash", finalizedBridge.Event.TransactionHash.String())
+			return fmt.Errorf("expected RelayedMessage following ERC721BridgeFinalized event. tx_hash = %s", finalizedBridge.Event.TransactionHash.String())
+		}
+
+		deposit, err := db.ERC721BridgeTransfers.L1ERC721BridgeDepositWithFilter(database.ERC721BridgeTransfer{CrossDomainMessageHash: &relayedMessage.MessageHash})
+		if err != nil {
+			return err
+		} else if deposit == nil {
+			log.Error("missing L1ERC721Bridge deposit on L2 finalization", "tx_hash", finalizedBridge.Event.TransactionHash.String())
+			return errors.New("missing L1ERC721Bridge deposit on L2 finalization")
+		}
+
+		finalizedNFTs[finalizedBridge.BridgeTransfer.TokenPair.LocalTokenAddress]++
+	}
+	if len(finalizedERC721Bridges) > 0 {
+		for tokenAddr, size := range finalizedNFTs {
+			metrics.RecordL2FinalizedERC721BridgeTransfers(tokenAddr, size)
+		}
+	}
+
 	// a-ok!
 	return nil
 }
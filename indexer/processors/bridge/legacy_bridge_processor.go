@@ -286,7 +286,7 @@ func LegacyL1ProcessFinalizedBridgeEvents(log log.Logger, db *database.DB, metri
 		}
 
 		// Mark the associated tx withdrawal as proven/finalized with the same event
-		if err := db.BridgeTransactions.MarkL2TransactionWithdrawalProvenEvent(relayedMessage.MessageHash, relayedMessage.Event.GUID); err != nil {
+		if err := db.BridgeTransactions.MarkL2TransactionWithdrawalProvenEvent(relayedMessage.MessageHash, relayedMessage.Event.GUID, nil, nil); err != nil {
 			log.Error("failed to mark withdrawal as proven", "err", err)
 			return err
 		}
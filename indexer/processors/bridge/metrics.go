@@ -1,6 +1,7 @@
 package bridge
 
 import (
+	"fmt"
 	"math/big"
 
 	"github.com/ethereum-optimism/optimism/op-service/metrics"
@@ -19,12 +20,16 @@ type L1Metricer interface {
 	RecordL1TransactionDeposits(size int)
 	RecordL1ProvenWithdrawals(size int)
 	RecordL1FinalizedWithdrawals(size int)
+	RecordL1OutputProposals(size int)
 
 	RecordL1CrossDomainSentMessages(size int)
 	RecordL1CrossDomainRelayedMessages(size int)
 
 	RecordL1InitiatedBridgeTransfers(token common.Address, size int)
 	RecordL1FinalizedBridgeTransfers(token common.Address, size int)
+
+	RecordL1InitiatedERC721BridgeTransfers(token common.Address, size int)
+	RecordL1FinalizedERC721BridgeTransfers(token common.Address, size int)
 }
 
 type L2Metricer interface {
@@ -37,6 +42,9 @@ type L2Metricer interface {
 
 	RecordL2InitiatedBridgeTransfers(token common.Address, size int)
 	RecordL2FinalizedBridgeTransfers(token common.Address, size int)
+
+	RecordL2InitiatedERC721BridgeTransfers(token common.Address, size int)
+	RecordL2FinalizedERC721BridgeTransfers(token common.Address, size int)
 }
 
 type Metricer interface {
@@ -58,80 +66,99 @@ type bridgeMetrics struct {
 	txWithdrawals        prometheus.Counter
 	provenWithdrawals    prometheus.Counter
 	finalizedWithdrawals prometheus.Counter
+	outputProposals      prometheus.Counter
 
 	sentMessages    *prometheus.CounterVec
 	relayedMessages *prometheus.CounterVec
 
 	initiatedBridgeTransfers *prometheus.CounterVec
 	finalizedBridgeTransfers *prometheus.CounterVec
+
+	initiatedERC721BridgeTransfers *prometheus.CounterVec
+	finalizedERC721BridgeTransfers *prometheus.CounterVec
 }
 
 func NewMetrics(registry *prometheus.Registry) Metricer {
+	return newMetrics(registry, MetricsNamespace)
+}
+
+// NewL2ChainMetrics constructs the metrics of an additional L2 chain, namespaced by the chain id
+// such that they are distinguished from those of the other chains in the shared registry
+func NewL2ChainMetrics(registry *prometheus.Registry, l2ChainID uint) Metricer {
+	return newMetrics(registry, fmt.Sprintf("%s_l2_%d", MetricsNamespace, l2ChainID))
+}
+
+func newMetrics(registry *prometheus.Registry, namespace string) Metricer {
 	factory := metrics.With(registry)
 	return &bridgeMetrics{
 		intervalTick: factory.NewCounter(prometheus.CounterOpts{
-			Namespace: MetricsNamespace,
+			Namespace: namespace,
 			Name:      "intervals_total",
 			Help:      "number of times processing loop has run",
 		}),
 		intervalDuration: factory.NewHistogram(prometheus.HistogramOpts{
-			Namespace: MetricsNamespace,
+			Namespace: namespace,
 			Name:      "interval_seconds",
 			Help:      "duration elapsed in the processing loop",
 		}),
 		intervalFailures: factory.NewCounter(prometheus.CounterOpts{
-			Namespace: MetricsNamespace,
+			Namespace: namespace,
 			Name:      "failures_total",
 			Help:      "number of failures encountered",
 		}),
 		latestL1Height: factory.NewGauge(prometheus.GaugeOpts{
-			Namespace: MetricsNamespace,
+			Namespace: namespace,
 			Subsystem: "l1",
 			Name:      "height",
 			Help:      "the latest processed l1 block height",
 		}),
 		latestL2Height: factory.NewGauge(prometheus.GaugeOpts{
-			Namespace: MetricsNamespace,
+			Namespace: namespace,
 			Subsystem: "l2",
 			Name:      "height",
 			Help:      "the latest processed l2 block height",
 		}),
 		txDeposits: factory.NewCounter(prometheus.CounterOpts{
-			Namespace: MetricsNamespace,
+			Namespace: namespace,
 			Name:      "tx_deposits",
 			Help:      "number of processed transactions deposited from l1",
 		}),
 		txWithdrawals: factory.NewCounter(prometheus.CounterOpts{
-			Namespace: MetricsNamespace,
+			Namespace: namespace,
 			Name:      "tx_withdrawals",
 			Help:      "number of processed transactions withdrawn from l2",
 		}),
 		provenWithdrawals: factory.NewCounter(prometheus.CounterOpts{
-			Namespace: MetricsNamespace,
+			Namespace: namespace,
 			Name:      "proven_withdrawals",
 			Help:      "number of proven tx withdrawals on l1",
 		}),
 		finalizedWithdrawals: factory.NewCounter(prometheus.CounterOpts{
-			Namespace: MetricsNamespace,
+			Namespace: namespace,
 			Name:      "finalized_withdrawals",
 			Help:      "number of finalized tx withdrawals on l1",
 		}),
+		outputProposals: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "output_proposals",
+			Help:      "number of processed l2 output proposals on l1",
+		}),
 		sentMessages: factory.NewCounterVec(prometheus.CounterOpts{
-			Namespace: MetricsNamespace,
+			Namespace: namespace,
 			Name:      "sent_messages",
 			Help:      "number of bridged messages between l1 and l2",
 		}, []string{
 			"chain",
 		}),
 		relayedMessages: factory.NewCounterVec(prometheus.CounterOpts{
-			Namespace: MetricsNamespace,
+			Namespace: namespace,
 			Name:      "relayed_messages",
 			Help:      "number of relayed messages between l1 and l2",
 		}, []string{
 			"chain",
 		}),
 		initiatedBridgeTransfers: factory.NewCounterVec(prometheus.CounterOpts{
-			Namespace: MetricsNamespace,
+			Namespace: namespace,
 			Name:      "initiated_token_transfers",
 			Help:      "number of bridged tokens between l1 and l2",
 		}, []string{
@@ -139,13 +166,29 @@ func NewMetrics(registry *prometheus.Registry) Metricer {
 			"token_address",
 		}),
 		finalizedBridgeTransfers: factory.NewCounterVec(prometheus.CounterOpts{
-			Namespace: MetricsNamespace,
+			Namespace: namespace,
 			Name:      "finalized_token_transfers",
 			Help:      "number of finalized token transfers between l1 and l2",
 		}, []string{
 			"chain",
 			"token_address",
 		}),
+		initiatedERC721BridgeTransfers: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "initiated_nft_transfers",
+			Help:      "number of bridged nfts between l1 and l2",
+		}, []string{
+			"chain",
+			"token_address",
+		}),
+		finalizedERC721BridgeTransfers: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "finalized_nft_transfers",
+			Help:      "number of finalized nft transfers between l1 and l2",
+		}, []string{
+			"chain",
+			"token_address",
+		}),
 	}
 }
 
@@ -178,6 +221,10 @@ func (m *bridgeMetrics) RecordL1FinalizedWithdrawals(size int) {
 	m.finalizedWithdrawals.Add(float64(size))
 }
 
+func (m *bridgeMetrics) RecordL1OutputProposals(size int) {
+	m.outputProposals.Add(float64(size))
+}
+
 func (m *bridgeMetrics) RecordL1CrossDomainSentMessages(size int) {
 	m.sentMessages.WithLabelValues("l1").Add(float64(size))
 }
@@ -194,6 +241,14 @@ func (m *bridgeMetrics) RecordL1FinalizedBridgeTransfers(tokenAddr common.Addres
 	m.finalizedBridgeTransfers.WithLabelValues("l1", tokenAddr.String()).Add(float64(size))
 }
 
+func (m *bridgeMetrics) RecordL1InitiatedERC721BridgeTransfers(tokenAddr common.Address, size int) {
+	m.initiatedERC721BridgeTransfers.WithLabelValues("l1", tokenAddr.String()).Add(float64(size))
+}
+
+func (m *bridgeMetrics) RecordL1FinalizedERC721BridgeTransfers(tokenAddr common.Address, size int) {
+	m.finalizedERC721BridgeTransfers.WithLabelValues("l1", tokenAddr.String()).Add(float64(size))
+}
+
 // L2Metricer
 
 func (m *bridgeMetrics) RecordLatestIndexedL2Height(height *big.Int) {
@@ -219,3 +274,11 @@ func (m *bridgeMetrics) RecordL2InitiatedBridgeTransfers(tokenAddr common.Addres
 func (m *bridgeMetrics) RecordL2FinalizedBridgeTransfers(tokenAddr common.Address, size int) {
 	m.finalizedBridgeTransfers.WithLabelValues("l2", tokenAddr.String()).Add(float64(size))
 }
+
+func (m *bridgeMetrics) RecordL2InitiatedERC721BridgeTransfers(tokenAddr common.Address, size int) {
+	m.initiatedERC721BridgeTransfers.WithLabelValues("l2", tokenAddr.String()).Add(float64(size))
+}
+
+func (m *bridgeMetrics) RecordL2FinalizedERC721BridgeTransfers(tokenAddr common.Address, size int) {
+	m.finalizedERC721BridgeTransfers.WithLabelValues("l2", tokenAddr.String()).Add(float64(size))
+}
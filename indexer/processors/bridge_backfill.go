@@ -0,0 +1,221 @@
+package processors
+
+import (
+	"context"
+	"math/big"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/ethereum-optimism/optimism/indexer/bigint"
+	"github.com/ethereum-optimism/optimism/indexer/database"
+)
+
+// backfillMinRanges is the least number of ranges worth backfilling concurrently. Anything
+// less is left to the sequential processing loop.
+const backfillMinRanges = 2
+
+// backfill concurrently indexes the unindexed history, split into ranges of epochs. The initiated
+// bridge events of each range are independent and indexed in parallel. Once all have been indexed,
+// the finalized bridge events are indexed in order such that all finalizations find their counterpart.
+//
+// Progress is checkpointed per range and an interrupted backfill is resumed on startup, even if the
+// backfill has since been disabled, as the latest bridge markers can't be derived from a partial backfill.
+func (b *BridgeProcessor) backfill(ctx context.Context) error {
+	for ctx.Err() == nil {
+		ranges, err := b.db.BridgeBackfill.BridgeBackfillRanges()
+		if err != nil {
+			return err
+		}
+
+		if len(ranges) > 0 {
+			b.log.Info("resuming bridge events backfill", "ranges", len(ranges), "from_l1_block_number", ranges[0].L1FromHeight, "to_l1_block_number", ranges[len(ranges)-1].L1ToHeight)
+		} else {
+			if b.chainConfig.BackfillWorkers == 0 {
+				return nil
+			}
+
+			ranges, err = b.planBackfill()
+			if err != nil {
+				return err
+			} else if len(ranges) < backfillMinRanges {
+				return nil
+			}
+
+			b.log.Info("backfilling bridge events", "ranges", len(ranges), "from_l1_block_number", ranges[0].L1FromHeight, "to_l1_block_number", ranges[len(ranges)-1].L1ToHeight)
+			if err := b.db.BridgeBackfill.StoreBridgeBackfillRanges(ranges); err != nil {
+				return err
+			}
+		}
+
+		if err := b.backfillRanges(ctx, ranges); err != nil {
+			b.log.Error("failed to backfill bridge events", "err", err)
+			return err
+		}
+
+		// The ETL may have indexed far ahead in the meantime. Continue backfilling until caught up
+	}
+
+	return nil
+}
+
+// planBackfill splits the confirmed history after the latest bridge markers into ranges of
+// observed epochs, each spanning up to the configured number of L1 blocks.
+func (b *BridgeProcessor) planBackfill() ([]database.BridgeBackfillRange, error) {
+	latestL1Header, err := b.db.Blocks.L1LatestBlockHeader()
+	if err != nil {
+		return nil, err
+	} else if latestL1Header == nil {
+		return nil, nil
+	}
+
+	// Stay clear of the tip, where the ETL may roll back reorged blocks
+	confirmedL1Height := new(big.Int).Sub(latestL1Header.Number, big.NewInt(int64(b.chainConfig.L1ConfirmationDepth)))
+
+	fromL1Height, fromL2Height := big.NewInt(int64(b.chainConfig.L1StartingHeight)), bigint.Zero
+	var lastEpoch, lastL2Height *big.Int
+	if b.LatestL1Header != nil {
+		lastEpoch = b.LatestL1Header.Number
+		fromL1Height = new(big.Int).Add(lastEpoch, bigint.One)
+	}
+	if b.LatestL2Header != nil {
+		lastL2Height = b.LatestL2Header.Number
+		fromL2Height = new(big.Int).Add(lastL2Height, bigint.One)
+	}
+
+	rangeSize := big.NewInt(int64(b.chainConfig.BackfillRangeSize))
+	ranges := []database.BridgeBackfillRange{}
+	for {
+		// `LatestObservedEpoch` searches up to the supplied range past the last epoch, or genesis. The
+		// search is widened when there's no new epoch within the range, i.e sparse or missing blocks
+		searchFrom := bigint.Zero
+		if lastEpoch != nil {
+			searchFrom = lastEpoch
+		}
+
+		var epoch *database.Epoch
+		for toL1Height := new(big.Int).Add(new(big.Int).Sub(fromL1Height, bigint.One), rangeSize); ; toL1Height.Add(toL1Height, rangeSize) {
+			if toL1Height.Cmp(confirmedL1Height) > 0 {
+				toL1Height.Set(confirmedL1Height)
+			}
+			if toL1Height.Cmp(searchFrom) <= 0 {
+				return ranges, nil
+			}
+
+			latestEpoch, err := b.db.Blocks.LatestObservedEpoch(lastEpoch, new(big.Int).Sub(toL1Height, searchFrom).Uint64())
+			if err != nil {
+				return nil, err
+			}
+
+			newL1Epoch := latestEpoch != nil && (lastEpoch == nil || latestEpoch.L1BlockHeader.Number.Cmp(lastEpoch) > 0)
+			newL2Epoch := latestEpoch != nil && (lastL2Height == nil || latestEpoch.L2BlockHeader.Number.Cmp(lastL2Height) > 0)
+			if newL1Epoch && newL2Epoch && latestEpoch.L1BlockHeader.Number.Cmp(fromL1Height) >= 0 {
+				epoch = latestEpoch
+				break
+			}
+			if toL1Height.Cmp(confirmedL1Height) == 0 {
+				return ranges, nil
+			}
+		}
+
+		ranges = append(ranges, database.BridgeBackfillRange{
+			L1FromHeight: fromL1Height,
+			L1ToHeight:   epoch.L1BlockHeader.Number,
+			L2FromHeight: fromL2Height,
+			L2ToHeight:   epoch.L2BlockHeader.Number,
+			L1BlockHash:  epoch.L1BlockHeader.Hash,
+			L2BlockHash:  epoch.L2BlockHeader.Hash,
+		})
+
+		lastEpoch, lastL2Height = epoch.L1BlockHeader.Number, epoch.L2BlockHeader.Number
+		fromL1Height = new(big.Int).Add(lastEpoch, bigint.One)
+		fromL2Height = new(big.Int).Add(lastL2Height, bigint.One)
+	}
+}
+
+// backfillRanges indexes the pending ranges, skipping any work checkpointed by a prior attempt. Once
+// complete, the ranges are removed and the bridge markers advanced to the end of the last range.
+func (b *BridgeProcessor) backfillRanges(ctx context.Context, ranges []database.BridgeBackfillRange) error {
+	// (1) Initiated bridge events, in parallel
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(max(int(b.chainConfig.BackfillWorkers), 1))
+	for i := range ranges {
+		backfillRange := ranges[i]
+		if backfillRange.InitiatedIndexed {
+			continue
+		}
+
+		group.Go(func() error {
+			if groupCtx.Err() != nil {
+				return nil
+			}
+
+			rangeLog := b.log.New("epoch_start_number", backfillRange.L1FromHeight, "epoch_end_number", backfillRange.L1ToHeight)
+			rangeLog.Info("backfilling initiated bridge events")
+			return b.db.Transaction(func(tx *database.DB) error {
+				if err := b.processInitiatedBridgeEvents(tx, newBackfillEpochRange(&backfillRange)); err != nil {
+					return err
+				}
+				return tx.BridgeBackfill.MarkBridgeBackfillRangeInitiated(backfillRange.L1FromHeight)
+			})
+		})
+	}
+	if err := group.Wait(); err != nil {
+		return err
+	}
+
+	// (2) Finalized bridge events, in order. The last range completes the backfill
+	for i := range ranges {
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		backfillRange := ranges[i]
+		if backfillRange.FinalizedIndexed {
+			continue
+		}
+
+		rangeLog := b.log.New("epoch_start_number", backfillRange.L1FromHeight, "epoch_end_number", backfillRange.L1ToHeight)
+		rangeLog.Info("backfilling finalized bridge events")
+		if err := b.db.Transaction(func(tx *database.DB) error {
+			if err := b.processFinalizedBridgeEvents(tx, newBackfillEpochRange(&backfillRange)); err != nil {
+				return err
+			}
+			if i == len(ranges)-1 {
+				return tx.BridgeBackfill.DeleteBridgeBackfillRanges()
+			}
+			return tx.BridgeBackfill.MarkBridgeBackfillRangeFinalized(backfillRange.L1FromHeight)
+		}); err != nil {
+			return err
+		}
+
+		b.metrics.RecordLatestIndexedL1Height(backfillRange.L1ToHeight)
+		b.metrics.RecordLatestIndexedL2Height(backfillRange.L2ToHeight)
+	}
+
+	lastRange := ranges[len(ranges)-1]
+	b.log.Info("backfilled bridge events", "latest_l1_block_number", lastRange.L1ToHeight, "latest_l2_block_number", lastRange.L2ToHeight)
+
+	l1Header, err := b.db.Blocks.L1BlockHeader(lastRange.L1BlockHash)
+	if err != nil {
+		return err
+	}
+	l2Header, err := b.db.Blocks.L2BlockHeader(lastRange.L2BlockHash)
+	if err != nil {
+		return err
+	}
+	if l1Header == nil || l2Header == nil {
+		b.log.Warn("backfilled epoch reorged. reloading markers")
+		return b.loadLatestHeaders()
+	}
+
+	b.LatestL1Header = l1Header.RLPHeader.Header()
+	b.LatestL2Header = l2Header.RLPHeader.Header()
+	return nil
+}
+
+func newBackfillEpochRange(backfillRange *database.BridgeBackfillRange) epochRange {
+	return epochRange{
+		fromL1Height: backfillRange.L1FromHeight, toL1Height: backfillRange.L1ToHeight,
+		fromL2Height: backfillRange.L2FromHeight, toL2Height: backfillRange.L2ToHeight,
+	}
+}
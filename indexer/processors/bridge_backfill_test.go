@@ -0,0 +1,330 @@
+package processors
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math/big"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/indexer/config"
+	"github.com/ethereum-optimism/optimism/indexer/database"
+	"github.com/ethereum-optimism/optimism/indexer/etl"
+	"github.com/ethereum-optimism/optimism/indexer/node"
+	"github.com/ethereum-optimism/optimism/indexer/processors/bridge"
+	"github.com/ethereum-optimism/optimism/op-service/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+)
+
+// fakeBlocksDB serves the indexed L1 & L2 headers from memory, with `LatestObservedEpoch`
+// following the same bounds as the database query
+type fakeBlocksDB struct {
+	database.MockBlocksDB
+
+	l1Headers []database.L1BlockHeader
+	l2Headers []database.L2BlockHeader
+}
+
+func (f *fakeBlocksDB) L1BlockHeader(hash common.Hash) (*database.L1BlockHeader, error) {
+	for i := range f.l1Headers {
+		if f.l1Headers[i].Hash == hash {
+			return &f.l1Headers[i], nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *fakeBlocksDB) L1LatestBlockHeader() (*database.L1BlockHeader, error) {
+	if len(f.l1Headers) == 0 {
+		return nil, nil
+	}
+	return &f.l1Headers[len(f.l1Headers)-1], nil
+}
+
+func (f *fakeBlocksDB) L2BlockHeader(hash common.Hash) (*database.L2BlockHeader, error) {
+	for i := range f.l2Headers {
+		if f.l2Headers[i].Hash == hash {
+			return &f.l2Headers[i], nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *fakeBlocksDB) LatestObservedEpoch(fromL1Height *big.Int, maxL1Range uint64) (*database.Epoch, error) {
+	var fromTimestamp, toTimestamp uint64
+	if fromL1Height == nil {
+		fromL1Height = big.NewInt(0)
+	}
+
+	if fromL1Height.BitLen() > 0 {
+		var found bool
+		for _, header := range f.l1Headers {
+			if header.Number.Cmp(fromL1Height) == 0 {
+				fromTimestamp, found = header.Timestamp, true
+			}
+		}
+		if !found {
+			return nil, nil
+		}
+	}
+
+	maxHeight := new(big.Int).Add(fromL1Height, new(big.Int).SetUint64(maxL1Range))
+	var l1Found, l2Found bool
+	for _, header := range f.l1Headers {
+		if header.Timestamp >= fromTimestamp && (maxL1Range == 0 || header.Number.Cmp(maxHeight) <= 0) {
+			toTimestamp, l1Found = max(toTimestamp, header.Timestamp), true
+		}
+	}
+	var l2Timestamp uint64
+	for _, header := range f.l2Headers {
+		if header.Timestamp <= toTimestamp {
+			l2Timestamp, l2Found = max(l2Timestamp, header.Timestamp), true
+		}
+	}
+	if !l1Found || !l2Found {
+		return nil, nil
+	}
+	toTimestamp = min(toTimestamp, l2Timestamp)
+
+	var epoch *database.Epoch
+	for _, l1Header := range f.l1Headers {
+		for _, l2Header := range f.l2Headers {
+			if l1Header.Timestamp != l2Header.Timestamp || l1Header.Timestamp < fromTimestamp || l1Header.Timestamp > toTimestamp {
+				continue
+			}
+			if epoch == nil || l2Header.Number.Cmp(epoch.L2BlockHeader.Number) > 0 {
+				epoch = &database.Epoch{L1BlockHeader: l1Header, L2BlockHeader: l2Header}
+			}
+		}
+	}
+	return epoch, nil
+}
+
+// fakeBridgeTransactionsDB serves the headers of the latest indexed bridge state
+type fakeBridgeTransactionsDB struct {
+	database.BridgeTransactionsDB
+
+	l1Header *database.L1BlockHeader
+	l2Header *database.L2BlockHeader
+}
+
+func (f *fakeBridgeTransactionsDB) L1LatestBlockHeader() (*database.L1BlockHeader, error) {
+	return f.l1Header, nil
+}
+
+func (f *fakeBridgeTransactionsDB) L2LatestBlockHeader() (*database.L2BlockHeader, error) {
+	return f.l2Header, nil
+}
+
+// L1 blocks every 12s and L2 blocks every 2s, such that every L1 block is an epoch
+func testL1Header(number uint64) database.L1BlockHeader {
+	header := types.Header{Number: new(big.Int).SetUint64(number), Time: number * 12}
+	return database.L1BlockHeader{BlockHeader: database.BlockHeaderFromHeader(&header)}
+}
+
+func testL2Header(number uint64) database.L2BlockHeader {
+	header := types.Header{Number: new(big.Int).SetUint64(number), Time: number * 2, Extra: []byte("l2")}
+	return database.L2BlockHeader{BlockHeader: database.BlockHeaderFromHeader(&header)}
+}
+
+func testBlocksDB(l1Numbers []uint64, l2Latest uint64) *fakeBlocksDB {
+	blocks := &fakeBlocksDB{}
+	for _, number := range l1Numbers {
+		blocks.l1Headers = append(blocks.l1Headers, testL1Header(number))
+	}
+	for number := uint64(0); number <= l2Latest; number++ {
+		blocks.l2Headers = append(blocks.l2Headers, testL2Header(number))
+	}
+	return blocks
+}
+
+func heightsUpTo(latest uint64) []uint64 {
+	heights := make([]uint64, latest+1)
+	for i := range heights {
+		heights[i] = uint64(i)
+	}
+	return heights
+}
+
+func requireBackfillRange(t *testing.T, backfillRange database.BridgeBackfillRange, l1From, l1To, l2From, l2To uint64) {
+	require.Equal(t, new(big.Int).SetUint64(l1From), backfillRange.L1FromHeight)
+	require.Equal(t, new(big.Int).SetUint64(l1To), backfillRange.L1ToHeight)
+	require.Equal(t, new(big.Int).SetUint64(l2From), backfillRange.L2FromHeight)
+	require.Equal(t, new(big.Int).SetUint64(l2To), backfillRange.L2ToHeight)
+	require.Equal(t, testL1Header(l1To).Hash, backfillRange.L1BlockHash)
+	require.Equal(t, testL2Header(l2To).Hash, backfillRange.L2BlockHash)
+}
+
+func TestBridgeProcessorPlanBackfill(t *testing.T) {
+	blocks := testBlocksDB(heightsUpTo(50), 300)
+	b := &BridgeProcessor{
+		log:         testlog.Logger(t, log.LvlInfo),
+		db:          &database.DB{Blocks: blocks},
+		chainConfig: config.ChainConfig{L1ConfirmationDepth: 5, BackfillRangeSize: 10},
+	}
+
+	// from genesis, up to the confirmed L1 height
+	ranges, err := b.planBackfill()
+	require.NoError(t, err)
+	require.Len(t, ranges, 5)
+	requireBackfillRange(t, ranges[0], 0, 9, 0, 54)
+	requireBackfillRange(t, ranges[1], 10, 19, 55, 114)
+	requireBackfillRange(t, ranges[2], 20, 29, 115, 174)
+	requireBackfillRange(t, ranges[3], 30, 39, 175, 234)
+	requireBackfillRange(t, ranges[4], 40, 45, 235, 270)
+
+	// or else after the latest bridge markers
+	b.LatestL1Header = blocks.l1Headers[29].RLPHeader.Header()
+	b.LatestL2Header = blocks.l2Headers[174].RLPHeader.Header()
+	ranges, err = b.planBackfill()
+	require.NoError(t, err)
+	require.Len(t, ranges, 2)
+	requireBackfillRange(t, ranges[0], 30, 39, 175, 234)
+	requireBackfillRange(t, ranges[1], 40, 45, 235, 270)
+
+	// nothing to plan once caught up with the confirmed height
+	b.LatestL1Header = blocks.l1Headers[45].RLPHeader.Header()
+	b.LatestL2Header = blocks.l2Headers[270].RLPHeader.Header()
+	ranges, err = b.planBackfill()
+	require.NoError(t, err)
+	require.Empty(t, ranges)
+
+	// nor without any indexed L1 state
+	b.db = &database.DB{Blocks: &fakeBlocksDB{}}
+	b.LatestL1Header, b.LatestL2Header = nil, nil
+	ranges, err = b.planBackfill()
+	require.NoError(t, err)
+	require.Empty(t, ranges)
+}
+
+func TestBridgeProcessorPlanBackfillWidensSearch(t *testing.T) {
+	// Only L1 blocks with logs are indexed, leaving a gap wider than the range size
+	blocks := testBlocksDB([]uint64{0, 1, 2, 35, 36}, 300)
+	b := &BridgeProcessor{
+		log:         testlog.Logger(t, log.LvlInfo),
+		db:          &database.DB{Blocks: blocks},
+		chainConfig: config.ChainConfig{BackfillRangeSize: 10},
+	}
+
+	// the range following the gap extends to the next observed epoch
+	ranges, err := b.planBackfill()
+	require.NoError(t, err)
+	require.Len(t, ranges, 2)
+	requireBackfillRange(t, ranges[0], 0, 2, 0, 12)
+	requireBackfillRange(t, ranges[1], 3, 36, 13, 216)
+
+	// the search ends at the confirmed height when no epoch has since been observed, i.e the L2 ETL is behind
+	blocks = testBlocksDB([]uint64{0, 1, 2, 35, 36}, 100)
+	b.db = &database.DB{Blocks: blocks}
+	ranges, err = b.planBackfill()
+	require.NoError(t, err)
+	require.Len(t, ranges, 1)
+	requireBackfillRange(t, ranges[0], 0, 2, 0, 12)
+}
+
+func TestBridgeProcessorReloadsReorgedMarkers(t *testing.T) {
+	blocks := testBlocksDB(heightsUpTo(29), 174)
+	bridgeTransactions := &fakeBridgeTransactionsDB{l1Header: &blocks.l1Headers[29], l2Header: &blocks.l2Headers[174]}
+	b := &BridgeProcessor{
+		log:     testlog.Logger(t, log.LvlInfo),
+		db:      &database.DB{Blocks: blocks, BridgeTransactions: bridgeTransactions},
+		metrics: bridge.NewMetrics(metrics.NewRegistry()),
+	}
+
+	// The markers reference blocks since removed by the ETL
+	reorgedL1Header := types.Header{Number: big.NewInt(30), Time: 360, Extra: []byte("reorged")}
+	reorgedL2Header := types.Header{Number: big.NewInt(175), Time: 350, Extra: []byte("reorged")}
+	b.LatestL1Header, b.LatestL2Header = &reorgedL1Header, &reorgedL2Header
+
+	reorged, err := b.reorgedLatestHeaders()
+	require.NoError(t, err)
+	require.True(t, reorged)
+
+	// reloaded from the latest indexed bridge state, which is also the latest observed epoch
+	require.NoError(t, b.run())
+	require.Equal(t, blocks.l1Headers[29].Hash, b.LatestL1Header.Hash())
+	require.Equal(t, blocks.l2Headers[174].Hash, b.LatestL2Header.Hash())
+
+	reorged, err = b.reorgedLatestHeaders()
+	require.NoError(t, err)
+	require.False(t, reorged)
+
+	// a reorged L2 marker alone also triggers a reload
+	b.LatestL2Header = &reorgedL2Header
+	reorged, err = b.reorgedLatestHeaders()
+	require.NoError(t, err)
+	require.True(t, reorged)
+
+	require.NoError(t, b.run())
+	require.Equal(t, blocks.l2Headers[174].Hash, b.LatestL2Header.Hash())
+}
+
+func TestBridgeProcessorResumesBackfill(t *testing.T) {
+	db := setupTestDatabase(t)
+
+	l1Headers := []database.L1BlockHeader{testL1Header(10), testL1Header(20), testL1Header(30)}
+	l2Headers := []database.L2BlockHeader{testL2Header(60), testL2Header(120), testL2Header(180)}
+	require.NoError(t, db.Blocks.StoreL1BlockHeaders(l1Headers))
+	require.NoError(t, db.Blocks.StoreL2BlockHeaders(l2Headers))
+
+	// An interrupted backfill, with the first range completed and the initiated events of the second indexed
+	ranges := []database.BridgeBackfillRange{
+		{L1FromHeight: big.NewInt(0), L1ToHeight: big.NewInt(10), L2FromHeight: big.NewInt(0), L2ToHeight: big.NewInt(60), L1BlockHash: l1Headers[0].Hash, L2BlockHash: l2Headers[0].Hash, InitiatedIndexed: true, FinalizedIndexed: true},
+		{L1FromHeight: big.NewInt(11), L1ToHeight: big.NewInt(20), L2FromHeight: big.NewInt(61), L2ToHeight: big.NewInt(120), L1BlockHash: l1Headers[1].Hash, L2BlockHash: l2Headers[1].Hash, InitiatedIndexed: true},
+		{L1FromHeight: big.NewInt(21), L1ToHeight: big.NewInt(30), L2FromHeight: big.NewInt(121), L2ToHeight: big.NewInt(180), L1BlockHash: l1Headers[2].Hash, L2BlockHash: l2Headers[2].Hash},
+	}
+	require.NoError(t, db.BridgeBackfill.StoreBridgeBackfillRanges(ranges))
+
+	// resumed even with the backfill disabled
+	b := &BridgeProcessor{
+		log:     testlog.Logger(t, log.LvlInfo),
+		db:      db,
+		metrics: bridge.NewMetrics(metrics.NewRegistry()),
+		l1Etl:   &etl.L1ETL{ETL: etl.ETL{EthClient: new(node.MockEthClient)}},
+	}
+
+	// completed, advancing the markers to the end of the last range
+	require.NoError(t, b.backfill(context.Background()))
+	storedRanges, err := db.BridgeBackfill.BridgeBackfillRanges()
+	require.NoError(t, err)
+	require.Empty(t, storedRanges)
+	require.Equal(t, l1Headers[2].Hash, b.LatestL1Header.Hash())
+	require.Equal(t, l2Headers[2].Hash, b.LatestL2Header.Hash())
+}
+
+func setupTestDatabase(t *testing.T) *database.DB {
+	user := os.Getenv("DB_USER")
+	require.NotEmpty(t, user, "DB_USER env variable expected to instantiate test database")
+
+	pg, err := sql.Open("pgx", fmt.Sprintf("postgres://%s@localhost:5432?sslmode=disable", user))
+	require.NoError(t, err)
+	require.NoError(t, pg.Ping())
+
+	dbName := fmt.Sprintf("indexer_processors_test_%d", time.Now().UnixNano())
+	_, err = pg.Exec("CREATE DATABASE " + dbName)
+	require.NoError(t, err)
+
+	silentLog := log.New()
+	silentLog.SetHandler(log.DiscardHandler())
+	db, err := database.NewDB(silentLog, config.DBConfig{Host: "127.0.0.1", Port: 5432, Name: dbName, User: user})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, db.Close())
+		_, err := pg.Exec("DROP DATABASE " + dbName)
+		require.NoError(t, err)
+		pg.Close()
+	})
+
+	require.NoError(t, db.ExecuteSQLMigration("../migrations"))
+	return db
+}
@@ -0,0 +1,103 @@
+package contracts
+
+import (
+	"math/big"
+
+	"github.com/ethereum-optimism/optimism/indexer/database"
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type ERC721BridgeInitiatedEvent struct {
+	Event          *database.ContractEvent
+	BridgeTransfer database.ERC721BridgeTransfer
+}
+
+type ERC721BridgeFinalizedEvent struct {
+	Event          *database.ContractEvent
+	BridgeTransfer database.ERC721BridgeTransfer
+}
+
+// ERC721BridgeInitiatedEvents extracts all initiated NFT bridge events from the contracts that follow the ERC721Bridge ABI.
+func ERC721BridgeInitiatedEvents(chainSelector string, contractAddress common.Address, db *database.DB, fromHeight, toHeight *big.Int) ([]ERC721BridgeInitiatedEvent, error) {
+	events, err := _erc721BridgeEvents(chainSelector, "ERC721BridgeInitiated", contractAddress, db, fromHeight, toHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	initiatedEvents := make([]ERC721BridgeInitiatedEvent, len(events))
+	for i := range events {
+		initiatedEvents[i] = ERC721BridgeInitiatedEvent(events[i])
+	}
+
+	return initiatedEvents, nil
+}
+
+// ERC721BridgeFinalizedEvents extracts all finalized NFT bridge events from the contracts that follow the ERC721Bridge ABI.
+func ERC721BridgeFinalizedEvents(chainSelector string, contractAddress common.Address, db *database.DB, fromHeight, toHeight *big.Int) ([]ERC721BridgeFinalizedEvent, error) {
+	events, err := _erc721BridgeEvents(chainSelector, "ERC721BridgeFinalized", contractAddress, db, fromHeight, toHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	finalizedEvents := make([]ERC721BridgeFinalizedEvent, len(events))
+	for i := range events {
+		finalizedEvents[i] = ERC721BridgeFinalizedEvent(events[i])
+	}
+
+	return finalizedEvents, nil
+}
+
+type erc721BridgeEvent struct {
+	Event          *database.ContractEvent
+	BridgeTransfer database.ERC721BridgeTransfer
+}
+
+// parse out initiated or finalized nft bridge events. Both events share the same fields
+func _erc721BridgeEvents(chainSelector, eventName string, contractAddress common.Address, db *database.DB, fromHeight, toHeight *big.Int) ([]erc721BridgeEvent, error) {
+	var erc721BridgeAbi *abi.ABI
+	var err error
+	switch chainSelector {
+	case "l1":
+		erc721BridgeAbi, err = bindings.L1ERC721BridgeMetaData.GetAbi()
+	case "l2":
+		erc721BridgeAbi, err = bindings.L2ERC721BridgeMetaData.GetAbi()
+	default:
+		panic("should not be here")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	bridgeEventAbi := erc721BridgeAbi.Events[eventName]
+	contractEventFilter := database.ContractEvent{ContractAddress: contractAddress, EventSignature: bridgeEventAbi.ID}
+	bridgeEvents, err := db.ContractEvents.ContractEventsWithFilter(contractEventFilter, chainSelector, fromHeight, toHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	erc721BridgeEvents := make([]erc721BridgeEvent, len(bridgeEvents))
+	for i := range bridgeEvents {
+		erc721Bridge := bindings.L1ERC721BridgeERC721BridgeInitiated{Raw: *bridgeEvents[i].RLPLog}
+		err := UnpackLog(&erc721Bridge, bridgeEvents[i].RLPLog, eventName, erc721BridgeAbi)
+		if err != nil {
+			return nil, err
+		}
+
+		erc721BridgeEvents[i] = erc721BridgeEvent{
+			Event: &bridgeEvents[i],
+			BridgeTransfer: database.ERC721BridgeTransfer{
+				TokenPair:   database.TokenPair{LocalTokenAddress: erc721Bridge.LocalToken, RemoteTokenAddress: erc721Bridge.RemoteToken},
+				FromAddress: erc721Bridge.From,
+				ToAddress:   erc721Bridge.To,
+				TokenID:     erc721Bridge.TokenId,
+				Data:        erc721Bridge.ExtraData,
+				Timestamp:   bridgeEvents[i].Timestamp,
+			},
+		}
+	}
+
+	return erc721BridgeEvents, nil
+}
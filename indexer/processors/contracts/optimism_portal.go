@@ -1,6 +1,7 @@
 package contracts
 
 import (
+	"bytes"
 	"errors"
 	"math/big"
 
@@ -8,8 +9,10 @@ import (
 	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 )
 
 type OptimismPortalTransactionDepositEvent struct {
@@ -140,3 +143,33 @@ func OptimismPortalWithdrawalFinalizedEvents(contractAddress common.Address, db
 
 	return finalizedWithdrawals, nil
 }
+
+// OptimismPortalProvenWithdrawalOutput decodes the output proposal a withdrawal was proven against from the
+// calldata of the proving transaction. Nil is returned if the transaction does not directly call
+// `proveWithdrawalTransaction`, i.e the withdrawal was proven via another contract.
+func OptimismPortalProvenWithdrawalOutput(contractAddress common.Address, tx *types.Transaction, provenEvent *database.ContractEvent) (*OptimismPortalProvenWithdrawal, error) {
+	optimismPortalAbi, err := bindings.OptimismPortalMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+
+	proveWithdrawalAbi := optimismPortalAbi.Methods["proveWithdrawalTransaction"]
+	if tx.To() == nil || *tx.To() != contractAddress || len(tx.Data()) < 4 || !bytes.Equal(tx.Data()[:4], proveWithdrawalAbi.ID) {
+		return nil, nil
+	}
+
+	inputs, err := proveWithdrawalAbi.Inputs.Unpack(tx.Data()[4:])
+	if err != nil {
+		return nil, err
+	}
+
+	// Output roots are the hash of the abi-encoded proof, matching `Hashing.hashOutputRootProof`
+	outputRootProof := abi.ConvertType(inputs[2], new(bindings.TypesOutputRootProof)).(*bindings.TypesOutputRootProof)
+	outputRoot := crypto.Keccak256Hash(outputRootProof.Version[:], outputRootProof.StateRoot[:], outputRootProof.MessagePasserStorageRoot[:], outputRootProof.LatestBlockhash[:])
+
+	return &OptimismPortalProvenWithdrawal{
+		OutputRoot:    outputRoot,
+		Timestamp:     new(big.Int).SetUint64(provenEvent.Timestamp),
+		L2OutputIndex: inputs[1].(*big.Int),
+	}, nil
+}
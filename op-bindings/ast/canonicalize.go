@@ -84,23 +84,7 @@ func CanonicalizeASTIDs(in *solc.StorageLayout, monorepoBase string) *solc.Stora
 		Types: make(map[string]solc.StorageLayoutType),
 	}
 	for _, slot := range in.Storage {
-		contract := slot.Contract
-
-		// Normalize the name of the contract since absolute paths
-		// are used when there are 2 contracts imported with the same
-		// name
-		if filepath.IsAbs(contract) {
-			contract = strings.TrimPrefix(strings.Replace(contract, monorepoBase, "", 1), "/")
-		}
-
-		outLayout.Storage = append(outLayout.Storage, solc.StorageLayoutEntry{
-			AstId:    astIDRemappings[slot.AstId],
-			Contract: contract,
-			Label:    slot.Label,
-			Offset:   slot.Offset,
-			Slot:     slot.Slot,
-			Type:     replaceType(typeRemappings, slot.Type),
-		})
+		outLayout.Storage = append(outLayout.Storage, canonicalizeEntry(slot, astIDRemappings[slot.AstId], typeRemappings, monorepoBase))
 	}
 
 	for _, oldType := range sortedOldTypes {
@@ -116,12 +100,40 @@ func CanonicalizeASTIDs(in *solc.StorageLayout, monorepoBase string) *solc.Stora
 		if value.Base != "" {
 			layout.Base = replaceType(typeRemappings, value.Base)
 		}
+		// The AST IDs of struct members are numbered after the types, so that
+		// the canonical IDs of layouts without members do not change.
+		for _, member := range value.Members {
+			layout.Members = append(layout.Members, canonicalizeEntry(member, lastId, typeRemappings, monorepoBase))
+			lastId++
+		}
 		outLayout.Types[newType] = layout
 
 	}
 	return outLayout
 }
 
+// canonicalizeEntry returns a copy of the storage entry with the given AST ID,
+// its type canonicalized, and the contract name normalized.
+func canonicalizeEntry(slot solc.StorageLayoutEntry, astId uint, typeRemappings map[string]string, monorepoBase string) solc.StorageLayoutEntry {
+	contract := slot.Contract
+
+	// Normalize the name of the contract since absolute paths
+	// are used when there are 2 contracts imported with the same
+	// name
+	if filepath.IsAbs(contract) {
+		contract = strings.TrimPrefix(strings.Replace(contract, monorepoBase, "", 1), "/")
+	}
+
+	return solc.StorageLayoutEntry{
+		AstId:    astId,
+		Contract: contract,
+		Label:    slot.Label,
+		Offset:   slot.Offset,
+		Slot:     slot.Slot,
+		Type:     replaceType(typeRemappings, slot.Type),
+	}
+}
+
 func replaceType(typeRemappings map[string]string, in string) string {
 	if remap := typeRemappings[in]; remap != "" {
 		return remap
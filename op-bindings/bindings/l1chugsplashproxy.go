@@ -0,0 +1,350 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package bindings
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// L1ChugSplashProxyMetaData contains all meta data concerning the L1ChugSplashProxy contract.
+var L1ChugSplashProxyMetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_owner\",\"type\":\"address\"}],\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"stateMutability\":\"payable\",\"type\":\"fallback\"},{\"inputs\":[],\"name\":\"getImplementation\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"getOwner\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes\",\"name\":\"_code\",\"type\":\"bytes\"}],\"name\":\"setCode\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_owner\",\"type\":\"address\"}],\"name\":\"setOwner\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"_key\",\"type\":\"bytes32\"},{\"internalType\":\"bytes32\",\"name\":\"_value\",\"type\":\"bytes32\"}],\"name\":\"setStorage\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"stateMutability\":\"payable\",\"type\":\"receive\"}]",
+	Bin: "0x608060405234801561001057600080fd5b50604051610a44380380610a4483398101604081905261002f9161005d565b610057817fb53127684a568b3173ae13b9f8a6016e243e63b6e8ee1178d6a717850b5d610355565b5061008d565b60006020828403121561006f57600080fd5b81516001600160a01b038116811461008657600080fd5b9392505050565b6109a88061009c6000396000f3fe60806040526004361061005e5760003560e01c8063893d20e811610043578063893d20e8146100b55780639b0b0fda146100f3578063aaf10f42146101135761006d565b806313af4035146100755780636c5d4ad0146100955761006d565b3661006d5761006b610128565b005b61006b610128565b34801561008157600080fd5b5061006b6100903660046107a2565b6103cb565b3480156100a157600080fd5b5061006b6100b036600461080e565b61045c565b3480156100c157600080fd5b506100ca610611565b60405173ffffffffffffffffffffffffffffffffffffffff909116815260200160405180910390f35b3480156100ff57600080fd5b5061006b61010e3660046108dd565b6106a8565b34801561011f57600080fd5b506100ca610716565b60006101527fb53127684a568b3173ae13b9f8a6016e243e63b6e8ee1178d6a717850b5d61035490565b60408051600481526024810182526020810180517bffffffffffffffffffffffffffffffffffffffffffffffffffffffff167fb7947262000000000000000000000000000000000000000000000000000000001790529051919250600091829173ffffffffffffffffffffffffffffffffffffffff8516916101d4919061093a565b600060405180830381855afa9150503d806000811461020f576040519150601f19603f3d011682016040523d82523d6000602084013e610214565b606091505b5091509150818015610227575080516020145b156102d9576000818060200190518101906102429190610946565b905080156102d7576040517f08c379a000000000000000000000000000000000000000000000000000000000815260206004820152603560248201527f4c314368756753706c61736850726f78793a2073797374656d2069732063757260448201527f72656e746c79206265696e67207570677261646564000000000000000000000060648201526084015b60405180910390fd5b505b60006103037f360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc5490565b905073ffffffffffffffffffffffffffffffffffffffff81166103a8576040517f08c379a000000000000000000000000000000000000000000000000000000000815260206004820152603060248201527f4c314368756753706c61736850726f78793a20696d706c656d656e746174696f60448201527f6e206973206e6f7420736574207965740000000000000000000000000000000060648201526084016102ce565b3660008037600080366000845af43d6000803e806103c5573d6000fd5b503d6000f35b7fb53127684a568b3173ae13b9f8a6016e243e63b6e8ee1178d6a717850b5d61035473ffffffffffffffffffffffffffffffffffffffff163373ffffffffffffffffffffffffffffffffffffffff161480610424575033155b1561045457610451817fb53127684a568b3173ae13b9f8a6016e243e63b6e8ee1178d6a717850b5d610355565b50565b610451610128565b7fb53127684a568b3173ae13b9f8a6016e243e63b6e8ee1178d6a717850b5d61035473ffffffffffffffffffffffffffffffffffffffff163373ffffffffffffffffffffffffffffffffffffffff1614806104b5575033155b156104545760006104e47f360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc5490565b9050803f82516020840120036104f8575050565b60405160009061052e907f600d380380600d6000396000f30000000000000000000000000000000000000090859060200161095f565b604051602081830303815290604052905060008151602083016000f084516020860120909150813f146105e3576040517f08c379a000000000000000000000000000000000000000000000000000000000815260206004820152603260248201527f4c314368756753706c61736850726f78793a20636f646520776173206e6f742060448201527f636f72726563746c79206465706c6f796564000000000000000000000000000060648201526084016102ce565b61060b817f360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc55565b50505050565b600061063b7fb53127684a568b3173ae13b9f8a6016e243e63b6e8ee1178d6a717850b5d61035490565b73ffffffffffffffffffffffffffffffffffffffff163373ffffffffffffffffffffffffffffffffffffffff161480610672575033155b1561069d57507fb53127684a568b3173ae13b9f8a6016e243e63b6e8ee1178d6a717850b5d61035490565b6106a5610128565b90565b7fb53127684a568b3173ae13b9f8a6016e243e63b6e8ee1178d6a717850b5d61035473ffffffffffffffffffffffffffffffffffffffff163373ffffffffffffffffffffffffffffffffffffffff161480610701575033155b1561070a579055565b610712610128565b5050565b60006107407fb53127684a568b3173ae13b9f8a6016e243e63b6e8ee1178d6a717850b5d61035490565b73ffffffffffffffffffffffffffffffffffffffff163373ffffffffffffffffffffffffffffffffffffffff161480610777575033155b1561069d57507f360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc5490565b6000602082840312156107b457600080fd5b813573ffffffffffffffffffffffffffffffffffffffff811681146107d857600080fd5b9392505050565b7f4e487b7100000000000000000000000000000000000000000000000000000000600052604160045260246000fd5b60006020828403121561082057600080fd5b813567ffffffffffffffff8082111561083857600080fd5b818401915084601f83011261084c57600080fd5b81358181111561085e5761085e6107df565b604051601f82017fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffe0908116603f011681019083821181831017156108a4576108a46107df565b816040528281528760208487010111156108bd57600080fd5b826020860160208301376000928101602001929092525095945050505050565b600080604083850312156108f057600080fd5b50508035926020909101359150565b6000815160005b818110156109205760208185018101518683015201610906565b8181111561092f576000828601525b509290920192915050565b60006107d882846108ff565b60006020828403121561095857600080fd5b5051919050565b7fffffffffffffffffffffffffff00000000000000000000000000000000000000831681526000610993600d8301846108ff565b94935050505056fea164736f6c634300080f000a",
+}
+
+// L1ChugSplashProxyABI is the input ABI used to generate the binding from.
+// Deprecated: Use L1ChugSplashProxyMetaData.ABI instead.
+var L1ChugSplashProxyABI = L1ChugSplashProxyMetaData.ABI
+
+// L1ChugSplashProxyBin is the compiled bytecode used for deploying new contracts.
+// Deprecated: Use L1ChugSplashProxyMetaData.Bin instead.
+var L1ChugSplashProxyBin = L1ChugSplashProxyMetaData.Bin
+
+// DeployL1ChugSplashProxy deploys a new Ethereum contract, binding an instance of L1ChugSplashProxy to it.
+func DeployL1ChugSplashProxy(auth *bind.TransactOpts, backend bind.ContractBackend, _owner common.Address) (common.Address, *types.Transaction, *L1ChugSplashProxy, error) {
+	parsed, err := L1ChugSplashProxyMetaData.GetAbi()
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	if parsed == nil {
+		return common.Address{}, nil, nil, errors.New("GetABI returned nil")
+	}
+
+	address, tx, contract, err := bind.DeployContract(auth, *parsed, common.FromHex(L1ChugSplashProxyBin), backend, _owner)
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	return address, tx, &L1ChugSplashProxy{L1ChugSplashProxyCaller: L1ChugSplashProxyCaller{contract: contract}, L1ChugSplashProxyTransactor: L1ChugSplashProxyTransactor{contract: contract}, L1ChugSplashProxyFilterer: L1ChugSplashProxyFilterer{contract: contract}}, nil
+}
+
+// L1ChugSplashProxy is an auto generated Go binding around an Ethereum contract.
+type L1ChugSplashProxy struct {
+	L1ChugSplashProxyCaller     // Read-only binding to the contract
+	L1ChugSplashProxyTransactor // Write-only binding to the contract
+	L1ChugSplashProxyFilterer   // Log filterer for contract events
+}
+
+// L1ChugSplashProxyCaller is an auto generated read-only Go binding around an Ethereum contract.
+type L1ChugSplashProxyCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// L1ChugSplashProxyTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type L1ChugSplashProxyTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// L1ChugSplashProxyFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type L1ChugSplashProxyFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// L1ChugSplashProxySession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type L1ChugSplashProxySession struct {
+	Contract     *L1ChugSplashProxy // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts      // Call options to use throughout this session
+	TransactOpts bind.TransactOpts  // Transaction auth options to use throughout this session
+}
+
+// L1ChugSplashProxyCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type L1ChugSplashProxyCallerSession struct {
+	Contract *L1ChugSplashProxyCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts            // Call options to use throughout this session
+}
+
+// L1ChugSplashProxyTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type L1ChugSplashProxyTransactorSession struct {
+	Contract     *L1ChugSplashProxyTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts            // Transaction auth options to use throughout this session
+}
+
+// L1ChugSplashProxyRaw is an auto generated low-level Go binding around an Ethereum contract.
+type L1ChugSplashProxyRaw struct {
+	Contract *L1ChugSplashProxy // Generic contract binding to access the raw methods on
+}
+
+// L1ChugSplashProxyCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type L1ChugSplashProxyCallerRaw struct {
+	Contract *L1ChugSplashProxyCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// L1ChugSplashProxyTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type L1ChugSplashProxyTransactorRaw struct {
+	Contract *L1ChugSplashProxyTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewL1ChugSplashProxy creates a new instance of L1ChugSplashProxy, bound to a specific deployed contract.
+func NewL1ChugSplashProxy(address common.Address, backend bind.ContractBackend) (*L1ChugSplashProxy, error) {
+	contract, err := bindL1ChugSplashProxy(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &L1ChugSplashProxy{L1ChugSplashProxyCaller: L1ChugSplashProxyCaller{contract: contract}, L1ChugSplashProxyTransactor: L1ChugSplashProxyTransactor{contract: contract}, L1ChugSplashProxyFilterer: L1ChugSplashProxyFilterer{contract: contract}}, nil
+}
+
+// NewL1ChugSplashProxyCaller creates a new read-only instance of L1ChugSplashProxy, bound to a specific deployed contract.
+func NewL1ChugSplashProxyCaller(address common.Address, caller bind.ContractCaller) (*L1ChugSplashProxyCaller, error) {
+	contract, err := bindL1ChugSplashProxy(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &L1ChugSplashProxyCaller{contract: contract}, nil
+}
+
+// NewL1ChugSplashProxyTransactor creates a new write-only instance of L1ChugSplashProxy, bound to a specific deployed contract.
+func NewL1ChugSplashProxyTransactor(address common.Address, transactor bind.ContractTransactor) (*L1ChugSplashProxyTransactor, error) {
+	contract, err := bindL1ChugSplashProxy(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &L1ChugSplashProxyTransactor{contract: contract}, nil
+}
+
+// NewL1ChugSplashProxyFilterer creates a new log filterer instance of L1ChugSplashProxy, bound to a specific deployed contract.
+func NewL1ChugSplashProxyFilterer(address common.Address, filterer bind.ContractFilterer) (*L1ChugSplashProxyFilterer, error) {
+	contract, err := bindL1ChugSplashProxy(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &L1ChugSplashProxyFilterer{contract: contract}, nil
+}
+
+// bindL1ChugSplashProxy binds a generic wrapper to an already deployed contract.
+func bindL1ChugSplashProxy(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := L1ChugSplashProxyMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_L1ChugSplashProxy *L1ChugSplashProxyRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _L1ChugSplashProxy.Contract.L1ChugSplashProxyCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_L1ChugSplashProxy *L1ChugSplashProxyRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _L1ChugSplashProxy.Contract.L1ChugSplashProxyTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_L1ChugSplashProxy *L1ChugSplashProxyRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _L1ChugSplashProxy.Contract.L1ChugSplashProxyTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_L1ChugSplashProxy *L1ChugSplashProxyCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _L1ChugSplashProxy.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_L1ChugSplashProxy *L1ChugSplashProxyTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _L1ChugSplashProxy.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_L1ChugSplashProxy *L1ChugSplashProxyTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _L1ChugSplashProxy.Contract.contract.Transact(opts, method, params...)
+}
+
+// GetImplementation is a paid mutator transaction binding the contract method 0xaaf10f42.
+//
+// Solidity: function getImplementation() returns(address)
+func (_L1ChugSplashProxy *L1ChugSplashProxyTransactor) GetImplementation(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _L1ChugSplashProxy.contract.Transact(opts, "getImplementation")
+}
+
+// GetImplementation is a paid mutator transaction binding the contract method 0xaaf10f42.
+//
+// Solidity: function getImplementation() returns(address)
+func (_L1ChugSplashProxy *L1ChugSplashProxySession) GetImplementation() (*types.Transaction, error) {
+	return _L1ChugSplashProxy.Contract.GetImplementation(&_L1ChugSplashProxy.TransactOpts)
+}
+
+// GetImplementation is a paid mutator transaction binding the contract method 0xaaf10f42.
+//
+// Solidity: function getImplementation() returns(address)
+func (_L1ChugSplashProxy *L1ChugSplashProxyTransactorSession) GetImplementation() (*types.Transaction, error) {
+	return _L1ChugSplashProxy.Contract.GetImplementation(&_L1ChugSplashProxy.TransactOpts)
+}
+
+// GetOwner is a paid mutator transaction binding the contract method 0x893d20e8.
+//
+// Solidity: function getOwner() returns(address)
+func (_L1ChugSplashProxy *L1ChugSplashProxyTransactor) GetOwner(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _L1ChugSplashProxy.contract.Transact(opts, "getOwner")
+}
+
+// GetOwner is a paid mutator transaction binding the contract method 0x893d20e8.
+//
+// Solidity: function getOwner() returns(address)
+func (_L1ChugSplashProxy *L1ChugSplashProxySession) GetOwner() (*types.Transaction, error) {
+	return _L1ChugSplashProxy.Contract.GetOwner(&_L1ChugSplashProxy.TransactOpts)
+}
+
+// GetOwner is a paid mutator transaction binding the contract method 0x893d20e8.
+//
+// Solidity: function getOwner() returns(address)
+func (_L1ChugSplashProxy *L1ChugSplashProxyTransactorSession) GetOwner() (*types.Transaction, error) {
+	return _L1ChugSplashProxy.Contract.GetOwner(&_L1ChugSplashProxy.TransactOpts)
+}
+
+// SetCode is a paid mutator transaction binding the contract method 0x6c5d4ad0.
+//
+// Solidity: function setCode(bytes _code) returns()
+func (_L1ChugSplashProxy *L1ChugSplashProxyTransactor) SetCode(opts *bind.TransactOpts, _code []byte) (*types.Transaction, error) {
+	return _L1ChugSplashProxy.contract.Transact(opts, "setCode", _code)
+}
+
+// SetCode is a paid mutator transaction binding the contract method 0x6c5d4ad0.
+//
+// Solidity: function setCode(bytes _code) returns()
+func (_L1ChugSplashProxy *L1ChugSplashProxySession) SetCode(_code []byte) (*types.Transaction, error) {
+	return _L1ChugSplashProxy.Contract.SetCode(&_L1ChugSplashProxy.TransactOpts, _code)
+}
+
+// SetCode is a paid mutator transaction binding the contract method 0x6c5d4ad0.
+//
+// Solidity: function setCode(bytes _code) returns()
+func (_L1ChugSplashProxy *L1ChugSplashProxyTransactorSession) SetCode(_code []byte) (*types.Transaction, error) {
+	return _L1ChugSplashProxy.Contract.SetCode(&_L1ChugSplashProxy.TransactOpts, _code)
+}
+
+// SetOwner is a paid mutator transaction binding the contract method 0x13af4035.
+//
+// Solidity: function setOwner(address _owner) returns()
+func (_L1ChugSplashProxy *L1ChugSplashProxyTransactor) SetOwner(opts *bind.TransactOpts, _owner common.Address) (*types.Transaction, error) {
+	return _L1ChugSplashProxy.contract.Transact(opts, "setOwner", _owner)
+}
+
+// SetOwner is a paid mutator transaction binding the contract method 0x13af4035.
+//
+// Solidity: function setOwner(address _owner) returns()
+func (_L1ChugSplashProxy *L1ChugSplashProxySession) SetOwner(_owner common.Address) (*types.Transaction, error) {
+	return _L1ChugSplashProxy.Contract.SetOwner(&_L1ChugSplashProxy.TransactOpts, _owner)
+}
+
+// SetOwner is a paid mutator transaction binding the contract method 0x13af4035.
+//
+// Solidity: function setOwner(address _owner) returns()
+func (_L1ChugSplashProxy *L1ChugSplashProxyTransactorSession) SetOwner(_owner common.Address) (*types.Transaction, error) {
+	return _L1ChugSplashProxy.Contract.SetOwner(&_L1ChugSplashProxy.TransactOpts, _owner)
+}
+
+// SetStorage is a paid mutator transaction binding the contract method 0x9b0b0fda.
+//
+// Solidity: function setStorage(bytes32 _key, bytes32 _value) returns()
+func (_L1ChugSplashProxy *L1ChugSplashProxyTransactor) SetStorage(opts *bind.TransactOpts, _key [32]byte, _value [32]byte) (*types.Transaction, error) {
+	return _L1ChugSplashProxy.contract.Transact(opts, "setStorage", _key, _value)
+}
+
+// SetStorage is a paid mutator transaction binding the contract method 0x9b0b0fda.
+//
+// Solidity: function setStorage(bytes32 _key, bytes32 _value) returns()
+func (_L1ChugSplashProxy *L1ChugSplashProxySession) SetStorage(_key [32]byte, _value [32]byte) (*types.Transaction, error) {
+	return _L1ChugSplashProxy.Contract.SetStorage(&_L1ChugSplashProxy.TransactOpts, _key, _value)
+}
+
+// SetStorage is a paid mutator transaction binding the contract method 0x9b0b0fda.
+//
+// Solidity: function setStorage(bytes32 _key, bytes32 _value) returns()
+func (_L1ChugSplashProxy *L1ChugSplashProxyTransactorSession) SetStorage(_key [32]byte, _value [32]byte) (*types.Transaction, error) {
+	return _L1ChugSplashProxy.Contract.SetStorage(&_L1ChugSplashProxy.TransactOpts, _key, _value)
+}
+
+// Fallback is a paid mutator transaction binding the contract fallback function.
+//
+// Solidity: fallback() payable returns()
+func (_L1ChugSplashProxy *L1ChugSplashProxyTransactor) Fallback(opts *bind.TransactOpts, calldata []byte) (*types.Transaction, error) {
+	return _L1ChugSplashProxy.contract.RawTransact(opts, calldata)
+}
+
+// Fallback is a paid mutator transaction binding the contract fallback function.
+//
+// Solidity: fallback() payable returns()
+func (_L1ChugSplashProxy *L1ChugSplashProxySession) Fallback(calldata []byte) (*types.Transaction, error) {
+	return _L1ChugSplashProxy.Contract.Fallback(&_L1ChugSplashProxy.TransactOpts, calldata)
+}
+
+// Fallback is a paid mutator transaction binding the contract fallback function.
+//
+// Solidity: fallback() payable returns()
+func (_L1ChugSplashProxy *L1ChugSplashProxyTransactorSession) Fallback(calldata []byte) (*types.Transaction, error) {
+	return _L1ChugSplashProxy.Contract.Fallback(&_L1ChugSplashProxy.TransactOpts, calldata)
+}
+
+// Receive is a paid mutator transaction binding the contract receive function.
+//
+// Solidity: receive() payable returns()
+func (_L1ChugSplashProxy *L1ChugSplashProxyTransactor) Receive(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _L1ChugSplashProxy.contract.RawTransact(opts, nil) // calldata is disallowed for receive function
+}
+
+// Receive is a paid mutator transaction binding the contract receive function.
+//
+// Solidity: receive() payable returns()
+func (_L1ChugSplashProxy *L1ChugSplashProxySession) Receive() (*types.Transaction, error) {
+	return _L1ChugSplashProxy.Contract.Receive(&_L1ChugSplashProxy.TransactOpts)
+}
+
+// Receive is a paid mutator transaction binding the contract receive function.
+//
+// Solidity: receive() payable returns()
+func (_L1ChugSplashProxy *L1ChugSplashProxyTransactorSession) Receive() (*types.Transaction, error) {
+	return _L1ChugSplashProxy.Contract.Receive(&_L1ChugSplashProxy.TransactOpts)
+}
@@ -0,0 +1,224 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package bindings
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// ResolvedDelegateProxyMetaData contains all meta data concerning the ResolvedDelegateProxy contract.
+var ResolvedDelegateProxyMetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[{\"internalType\":\"contractAddressManager\",\"name\":\"_addressManager\",\"type\":\"address\"},{\"internalType\":\"string\",\"name\":\"_implementationName\",\"type\":\"string\"}],\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"stateMutability\":\"payable\",\"type\":\"fallback\"}]",
+	Bin: "0x608060405234801561001057600080fd5b506040516105f03803806105f083398101604081905261002f91610088565b30600090815260016020908152604080832080546001600160a01b0319166001600160a01b03871617905590829052902061006a8282610203565b5050506102c2565b634e487b7160e01b600052604160045260246000fd5b6000806040838503121561009b57600080fd5b82516001600160a01b03811681146100b257600080fd5b602084810151919350906001600160401b03808211156100d157600080fd5b818601915086601f8301126100e557600080fd5b8151818111156100f7576100f7610072565b604051601f8201601f19908116603f0116810190838211818310171561011f5761011f610072565b81604052828152898684870101111561013757600080fd5b600093505b82841015610159578484018601518185018701529285019261013c565b8284111561016a5760008684830101525b8096505050505050509250929050565b600181811c9082168061018e57607f821691505b6020821081036101ae57634e487b7160e01b600052602260045260246000fd5b50919050565b601f8211156101fe57600081815260208120601f850160051c810160208610156101db5750805b601f850160051c820191505b818110156101fa578281556001016101e7565b5050505b505050565b81516001600160401b0381111561021c5761021c610072565b6102308161022a845461017a565b846101b4565b602080601f831160018114610265576000841561024d5750858301515b600019600386901b1c1916600185901b1785556101fa565b600085815260208120601f198616915b8281101561029457888601518255948401946001909101908401610275565b50858210156102b25787850151600019600388901b60f8161c191681555b5050505050600190811b01905550565b61031f806102d16000396000f3fe608060408181523060009081526001602090815282822054908290529181207fbf40fac1000000000000000000000000000000000000000000000000000000009093529173ffffffffffffffffffffffffffffffffffffffff9091169063bf40fac19061006d9060846101e2565b602060405180830381865afa15801561008a573d6000803e3d6000fd5b505050506040513d601f19601f820116820180604052508101906100ae91906102c5565b905073ffffffffffffffffffffffffffffffffffffffff8116610157576040517f08c379a000000000000000000000000000000000000000000000000000000000815260206004820152603960248201527f5265736f6c76656444656c656761746550726f78793a2074617267657420616460448201527f6472657373206d75737420626520696e697469616c697a656400000000000000606482015260840160405180910390fd5b6000808273ffffffffffffffffffffffffffffffffffffffff16600036604051610182929190610302565b600060405180830381855af49150503d80600081146101bd576040519150601f19603f3d011682016040523d82523d6000602084013e6101c2565b606091505b5090925090508115156001036101da57805160208201f35b805160208201fd5b600060208083526000845481600182811c91508083168061020457607f831692505b858310810361023a577f4e487b710000000000000000000000000000000000000000000000000000000085526022600452602485fd5b878601838152602001818015610257576001811461028b576102b6565b7fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff008616825284151560051b820196506102b6565b60008b81526020902060005b868110156102b057815484820152908501908901610297565b83019750505b50949998505050505050505050565b6000602082840312156102d757600080fd5b815173ffffffffffffffffffffffffffffffffffffffff811681146102fb57600080fd5b9392505050565b818382376000910190815291905056fea164736f6c634300080f000a",
+}
+
+// ResolvedDelegateProxyABI is the input ABI used to generate the binding from.
+// Deprecated: Use ResolvedDelegateProxyMetaData.ABI instead.
+var ResolvedDelegateProxyABI = ResolvedDelegateProxyMetaData.ABI
+
+// ResolvedDelegateProxyBin is the compiled bytecode used for deploying new contracts.
+// Deprecated: Use ResolvedDelegateProxyMetaData.Bin instead.
+var ResolvedDelegateProxyBin = ResolvedDelegateProxyMetaData.Bin
+
+// DeployResolvedDelegateProxy deploys a new Ethereum contract, binding an instance of ResolvedDelegateProxy to it.
+func DeployResolvedDelegateProxy(auth *bind.TransactOpts, backend bind.ContractBackend, _addressManager common.Address, _implementationName string) (common.Address, *types.Transaction, *ResolvedDelegateProxy, error) {
+	parsed, err := ResolvedDelegateProxyMetaData.GetAbi()
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	if parsed == nil {
+		return common.Address{}, nil, nil, errors.New("GetABI returned nil")
+	}
+
+	address, tx, contract, err := bind.DeployContract(auth, *parsed, common.FromHex(ResolvedDelegateProxyBin), backend, _addressManager, _implementationName)
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	return address, tx, &ResolvedDelegateProxy{ResolvedDelegateProxyCaller: ResolvedDelegateProxyCaller{contract: contract}, ResolvedDelegateProxyTransactor: ResolvedDelegateProxyTransactor{contract: contract}, ResolvedDelegateProxyFilterer: ResolvedDelegateProxyFilterer{contract: contract}}, nil
+}
+
+// ResolvedDelegateProxy is an auto generated Go binding around an Ethereum contract.
+type ResolvedDelegateProxy struct {
+	ResolvedDelegateProxyCaller     // Read-only binding to the contract
+	ResolvedDelegateProxyTransactor // Write-only binding to the contract
+	ResolvedDelegateProxyFilterer   // Log filterer for contract events
+}
+
+// ResolvedDelegateProxyCaller is an auto generated read-only Go binding around an Ethereum contract.
+type ResolvedDelegateProxyCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ResolvedDelegateProxyTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type ResolvedDelegateProxyTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ResolvedDelegateProxyFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type ResolvedDelegateProxyFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ResolvedDelegateProxySession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type ResolvedDelegateProxySession struct {
+	Contract     *ResolvedDelegateProxy // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts          // Call options to use throughout this session
+	TransactOpts bind.TransactOpts      // Transaction auth options to use throughout this session
+}
+
+// ResolvedDelegateProxyCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type ResolvedDelegateProxyCallerSession struct {
+	Contract *ResolvedDelegateProxyCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts                // Call options to use throughout this session
+}
+
+// ResolvedDelegateProxyTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type ResolvedDelegateProxyTransactorSession struct {
+	Contract     *ResolvedDelegateProxyTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts                // Transaction auth options to use throughout this session
+}
+
+// ResolvedDelegateProxyRaw is an auto generated low-level Go binding around an Ethereum contract.
+type ResolvedDelegateProxyRaw struct {
+	Contract *ResolvedDelegateProxy // Generic contract binding to access the raw methods on
+}
+
+// ResolvedDelegateProxyCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type ResolvedDelegateProxyCallerRaw struct {
+	Contract *ResolvedDelegateProxyCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// ResolvedDelegateProxyTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type ResolvedDelegateProxyTransactorRaw struct {
+	Contract *ResolvedDelegateProxyTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewResolvedDelegateProxy creates a new instance of ResolvedDelegateProxy, bound to a specific deployed contract.
+func NewResolvedDelegateProxy(address common.Address, backend bind.ContractBackend) (*ResolvedDelegateProxy, error) {
+	contract, err := bindResolvedDelegateProxy(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &ResolvedDelegateProxy{ResolvedDelegateProxyCaller: ResolvedDelegateProxyCaller{contract: contract}, ResolvedDelegateProxyTransactor: ResolvedDelegateProxyTransactor{contract: contract}, ResolvedDelegateProxyFilterer: ResolvedDelegateProxyFilterer{contract: contract}}, nil
+}
+
+// NewResolvedDelegateProxyCaller creates a new read-only instance of ResolvedDelegateProxy, bound to a specific deployed contract.
+func NewResolvedDelegateProxyCaller(address common.Address, caller bind.ContractCaller) (*ResolvedDelegateProxyCaller, error) {
+	contract, err := bindResolvedDelegateProxy(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &ResolvedDelegateProxyCaller{contract: contract}, nil
+}
+
+// NewResolvedDelegateProxyTransactor creates a new write-only instance of ResolvedDelegateProxy, bound to a specific deployed contract.
+func NewResolvedDelegateProxyTransactor(address common.Address, transactor bind.ContractTransactor) (*ResolvedDelegateProxyTransactor, error) {
+	contract, err := bindResolvedDelegateProxy(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &ResolvedDelegateProxyTransactor{contract: contract}, nil
+}
+
+// NewResolvedDelegateProxyFilterer creates a new log filterer instance of ResolvedDelegateProxy, bound to a specific deployed contract.
+func NewResolvedDelegateProxyFilterer(address common.Address, filterer bind.ContractFilterer) (*ResolvedDelegateProxyFilterer, error) {
+	contract, err := bindResolvedDelegateProxy(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &ResolvedDelegateProxyFilterer{contract: contract}, nil
+}
+
+// bindResolvedDelegateProxy binds a generic wrapper to an already deployed contract.
+func bindResolvedDelegateProxy(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := ResolvedDelegateProxyMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_ResolvedDelegateProxy *ResolvedDelegateProxyRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _ResolvedDelegateProxy.Contract.ResolvedDelegateProxyCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_ResolvedDelegateProxy *ResolvedDelegateProxyRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _ResolvedDelegateProxy.Contract.ResolvedDelegateProxyTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_ResolvedDelegateProxy *ResolvedDelegateProxyRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _ResolvedDelegateProxy.Contract.ResolvedDelegateProxyTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_ResolvedDelegateProxy *ResolvedDelegateProxyCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _ResolvedDelegateProxy.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_ResolvedDelegateProxy *ResolvedDelegateProxyTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _ResolvedDelegateProxy.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_ResolvedDelegateProxy *ResolvedDelegateProxyTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _ResolvedDelegateProxy.Contract.contract.Transact(opts, method, params...)
+}
+
+// Fallback is a paid mutator transaction binding the contract fallback function.
+//
+// Solidity: fallback() payable returns()
+func (_ResolvedDelegateProxy *ResolvedDelegateProxyTransactor) Fallback(opts *bind.TransactOpts, calldata []byte) (*types.Transaction, error) {
+	return _ResolvedDelegateProxy.contract.RawTransact(opts, calldata)
+}
+
+// Fallback is a paid mutator transaction binding the contract fallback function.
+//
+// Solidity: fallback() payable returns()
+func (_ResolvedDelegateProxy *ResolvedDelegateProxySession) Fallback(calldata []byte) (*types.Transaction, error) {
+	return _ResolvedDelegateProxy.Contract.Fallback(&_ResolvedDelegateProxy.TransactOpts, calldata)
+}
+
+// Fallback is a paid mutator transaction binding the contract fallback function.
+//
+// Solidity: fallback() payable returns()
+func (_ResolvedDelegateProxy *ResolvedDelegateProxyTransactorSession) Fallback(calldata []byte) (*types.Transaction, error) {
+	return _ResolvedDelegateProxy.Contract.Fallback(&_ResolvedDelegateProxy.TransactOpts, calldata)
+}
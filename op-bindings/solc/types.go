@@ -89,6 +89,8 @@ type StorageLayoutType struct {
 	Key           string `json:"key,omitempty"`
 	Value         string `json:"value,omitempty"`
 	Base          string `json:"base,omitempty"`
+	// Members are the members of a struct type, with slots relative to the slot of the struct
+	Members []StorageLayoutEntry `json:"members,omitempty"`
 }
 
 type CompilerOutputEvm struct {
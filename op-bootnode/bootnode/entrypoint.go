@@ -34,12 +34,20 @@ func (g *gossipConfig) P2PSequencerAddress() common.Address {
 	return common.Address{}
 }
 
+func (g *gossipConfig) P2PSequencerKeys() eth.SequencerKeys {
+	return nil
+}
+
 type l2Chain struct{}
 
 func (l *l2Chain) PayloadByNumber(_ context.Context, _ uint64) (*eth.ExecutionPayload, error) {
 	return nil, nil
 }
 
+func (l *l2Chain) PayloadByHash(_ context.Context, _ common.Hash) (*eth.ExecutionPayload, error) {
+	return nil, nil
+}
+
 func Main(cliCtx *cli.Context) error {
 	log.Info("Initializing bootnode")
 	logCfg := oplog.ReadCLIConfig(cliCtx)
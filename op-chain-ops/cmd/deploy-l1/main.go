@@ -0,0 +1,118 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/mattn/go-isatty"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/ethereum-optimism/optimism/op-chain-ops/genesis"
+	"github.com/ethereum-optimism/optimism/op-chain-ops/l1deployer"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	txmetrics "github.com/ethereum-optimism/optimism/op-service/txmgr/metrics"
+)
+
+// txDefaults are the default flag values of the transaction manager. Deployment transactions
+// depend on each other, so they are confirmed quicker than the transactions of the services.
+var txDefaults = txmgr.DefaultFlagValues{
+	NumConfirmations:          uint64(1),
+	SafeAbortNonceTooLowCount: uint64(3),
+	ResubmissionTimeout:       48 * time.Second,
+	NetworkTimeout:            10 * time.Second,
+	TxSendTimeout:             5 * time.Minute,
+	TxNotInMempoolTimeout:     2 * time.Minute,
+	ReceiptQueryInterval:      4 * time.Second,
+}
+
+func main() {
+	log.Root().SetHandler(log.StreamHandler(os.Stderr, log.TerminalFormat(isatty.IsTerminal(os.Stderr.Fd()))))
+
+	app := &cli.App{
+		Name:  "deploy-l1",
+		Usage: "Deploy the L1 contracts of an OP Stack chain",
+		Flags: append([]cli.Flag{
+			&cli.PathFlag{
+				Name:     "deploy-config",
+				Usage:    "Path to the deploy config of the chain",
+				Required: true,
+				EnvVars:  []string{"DEPLOY_CONFIG"},
+			},
+			&cli.StringFlag{
+				Name:     txmgr.L1RPCFlagName,
+				Usage:    "L1 RPC URL",
+				Required: true,
+				EnvVars:  []string{"L1_RPC_URL"},
+			},
+			&cli.PathFlag{
+				Name:     "outfile",
+				Usage:    "Path to the L1 deployments file. If it exists, the deployment is resumed",
+				Required: true,
+				EnvVars:  []string{"OUTFILE"},
+			},
+			&cli.StringFlag{
+				Name:    "salt",
+				Usage:   "Salt of the CREATE2 deployments",
+				Value:   l1deployer.DefaultSalt,
+				EnvVars: []string{"IMPL_SALT"},
+			},
+		}, txmgr.CLIFlagsWithDefaults("DEPLOY_L1", txDefaults)...),
+		Action: entrypoint,
+	}
+
+	if err := app.Run(os.Args); err != nil {
+		log.Crit("error deploying l1", "err", err)
+	}
+}
+
+func entrypoint(ctx *cli.Context) error {
+	config, err := genesis.NewDeployConfig(ctx.Path("deploy-config"))
+	if err != nil {
+		return err
+	}
+
+	client, err := ethclient.Dial(ctx.String(txmgr.L1RPCFlagName))
+	if err != nil {
+		return fmt.Errorf("cannot dial L1: %w", err)
+	}
+
+	// A negative starting timestamp is the timestamp of the L1 starting block,
+	// as in the Foundry deploy config
+	if config.L2OutputOracleStartingTimestamp < 0 {
+		if config.L1StartingBlockTag == nil {
+			return errors.New("L2OutputOracleStartingTimestamp is negative, but there is no L1 starting block")
+		}
+		block, err := genesis.GetBlockFromTag(client, (*rpc.BlockNumberOrHash)(config.L1StartingBlockTag))
+		if err != nil {
+			return fmt.Errorf("cannot fetch L1 starting block: %w", err)
+		}
+		config.L2OutputOracleStartingTimestamp = int(block.Time())
+		log.Info("Using timestamp of the L1 starting block", "number", block.Number(), "timestamp", block.Time())
+	}
+	if err := config.Check(); err != nil {
+		return err
+	}
+
+	txMgrConfig := txmgr.ReadCLIConfig(ctx)
+	if err := txMgrConfig.Check(); err != nil {
+		return err
+	}
+	txMgr, err := txmgr.NewSimpleTxManager("deployer", log.Root(), &txmetrics.NoopTxMetrics{}, txMgrConfig)
+	if err != nil {
+		return fmt.Errorf("cannot create tx manager: %w", err)
+	}
+	log.Info("Deploying L1 contracts", "deployer", txMgr.From())
+
+	deployer, err := l1deployer.NewDeployer(log.Root(), config, client, txMgr, ctx.String("salt"), ctx.Path("outfile"))
+	if err != nil {
+		return err
+	}
+	_, err = deployer.Deploy(ctx.Context)
+	return err
+}
@@ -9,11 +9,15 @@ import (
 
 	"golang.org/x/exp/maps"
 
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/mattn/go-isatty"
 	"github.com/urfave/cli/v2"
 
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
 	"github.com/ethereum-optimism/optimism/op-chain-ops/clients"
 	"github.com/ethereum-optimism/optimism/op-chain-ops/genesis"
 	"github.com/ethereum-optimism/optimism/op-chain-ops/safe"
@@ -55,6 +59,21 @@ func main() {
 				Usage:   "The file to write the output to. If not specified, output is written to stdout",
 				EnvVars: []string{"OUTFILE"},
 			},
+			&cli.BoolFlag{
+				Name:    "simulate",
+				Usage:   "Simulate the batch against a fork of the latest L1 block before writing it. Requires --safe-address",
+				EnvVars: []string{"SIMULATE"},
+			},
+			&cli.StringFlag{
+				Name:    "safe-address",
+				Usage:   "The address of the Safe that will execute the batch, used as the sender of the simulated calls",
+				EnvVars: []string{"SAFE_ADDRESS"},
+			},
+			&cli.PathFlag{
+				Name:    "simulation-outfile",
+				Usage:   "The file to write the simulation report to, with the events and state changes of every call",
+				EnvVars: []string{"SIMULATION_OUTFILE"},
+			},
 		},
 		Action: entrypoint,
 	}
@@ -200,6 +219,13 @@ func entrypoint(ctx *cli.Context) error {
 		}
 	}
 
+	// Execute the batch against a fork of L1, so that the signers can review its effects
+	if ctx.Bool("simulate") {
+		if err := simulate(ctx, &batch, client); err != nil {
+			return err
+		}
+	}
+
 	// Write the batch to disk or stdout
 	if outfile := ctx.Path("outfile"); outfile != "" {
 		if err := writeJSON(outfile, batch); err != nil {
@@ -215,6 +241,64 @@ func entrypoint(ctx *cli.Context) error {
 	return nil
 }
 
+// simulate executes the batch against a fork of L1 and logs the outcome of every call.
+// An error is returned if any of the calls fails, as the Safe executes the batch atomically.
+func simulate(ctx *cli.Context, batch *safe.Batch, client *ethclient.Client) error {
+	safeAddress := ctx.String("safe-address")
+	if !common.IsHexAddress(safeAddress) {
+		return fmt.Errorf("invalid safe address: %q", safeAddress)
+	}
+
+	abis := make([]*abi.ABI, 0)
+	for _, metadata := range []*bind.MetaData{
+		bindings.ProxyAdminMetaData,
+		bindings.ProxyMetaData,
+		bindings.AddressManagerMetaData,
+		bindings.L1CrossDomainMessengerMetaData,
+		bindings.L1ERC721BridgeMetaData,
+		bindings.L1StandardBridgeMetaData,
+		bindings.L2OutputOracleMetaData,
+		bindings.OptimismMintableERC20FactoryMetaData,
+		bindings.OptimismPortalMetaData,
+		bindings.SystemConfigMetaData,
+	} {
+		iface, err := metadata.GetAbi()
+		if err != nil {
+			return err
+		}
+		abis = append(abis, iface)
+	}
+
+	log.Info("Simulating batch", "calls", len(batch.Transactions), "sender", safeAddress)
+	result, err := batch.Simulate(ctx.Context, client, common.HexToAddress(safeAddress), abis...)
+	if err != nil {
+		return fmt.Errorf("cannot simulate batch: %w", err)
+	}
+	for _, call := range result.Calls {
+		log.Info("Simulated call", "index", call.Index, "to", call.To, "method", call.Method, "success", call.Success, "gas", call.GasUsed, "events", len(call.Events), "touched", len(call.StateDiff))
+		for _, event := range call.Events {
+			log.Info("Event", "address", event.Address, "name", event.Name)
+		}
+		for _, change := range call.ImplementationChanges {
+			log.Info("Implementation change", "proxy", change.Proxy, "before", change.Before, "after", change.After)
+		}
+		if !call.Success {
+			log.Error("Call failed", "index", call.Index, "err", call.Error)
+		}
+	}
+
+	if outfile := ctx.Path("simulation-outfile"); outfile != "" {
+		if err := writeJSON(outfile, result); err != nil {
+			return err
+		}
+	}
+	if !result.Success {
+		return fmt.Errorf("batch simulation failed at block %d", result.BlockNumber)
+	}
+	log.Info("Batch simulation succeeded", "block", result.BlockNumber, "touched", len(result.StateDiff))
+	return nil
+}
+
 // toDeployConfigName is a temporary function that maps the chain config names
 // to deploy config names. This should be able to be removed in the future
 // with a canonical naming scheme. If an empty string is returned, then
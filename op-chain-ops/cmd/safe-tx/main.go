@@ -0,0 +1,224 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/mattn/go-isatty"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-chain-ops/safe"
+	"github.com/ethereum-optimism/optimism/op-service/signer"
+)
+
+func main() {
+	log.Root().SetHandler(log.StreamHandler(os.Stderr, log.TerminalFormat(isatty.IsTerminal(os.Stderr.Fd()))))
+
+	txFlag := &cli.PathFlag{
+		Name:     "tx",
+		Usage:    "The path to the Safe transaction file",
+		Required: true,
+		EnvVars:  []string{"SAFE_TX"},
+	}
+
+	app := &cli.App{
+		Name:  "safe-tx",
+		Usage: "Build, sign and encode Safe transactions offline",
+		Commands: []*cli.Command{
+			{
+				Name:  "build",
+				Usage: "Build the Safe transaction of a tx-builder batch, and write it to the transaction file",
+				Flags: []cli.Flag{
+					&cli.PathFlag{
+						Name:     "batch",
+						Usage:    "The path to the tx-builder batch, e.g. as written by op-upgrade",
+						Required: true,
+						EnvVars:  []string{"BATCH"},
+					},
+					&cli.StringFlag{
+						Name:     "safe-address",
+						Usage:    "The address of the Safe that executes the batch",
+						Required: true,
+						EnvVars:  []string{"SAFE_ADDRESS"},
+					},
+					&cli.Uint64Flag{
+						Name:     "nonce",
+						Usage:    "The nonce of the Safe transaction",
+						Required: true,
+						EnvVars:  []string{"SAFE_NONCE"},
+					},
+					&cli.Uint64Flag{
+						Name:    "chain-id",
+						Usage:   "The chain ID of the Safe, if the batch does not have one",
+						EnvVars: []string{"CHAIN_ID"},
+					},
+					txFlag,
+				},
+				Action: build,
+			},
+			{
+				Name:  "sign",
+				Usage: "Sign the Safe transaction with a private key or a remote signer, and add the signature to the transaction file",
+				Flags: append([]cli.Flag{
+					txFlag,
+					&cli.StringFlag{
+						Name:    "private-key",
+						Usage:   "The hex-encoded private key of the owner",
+						EnvVars: []string{"PRIVATE_KEY"},
+					},
+				}, signer.CLIFlags("SAFE_TX")...),
+				Action: sign,
+			},
+			{
+				Name:  "exec",
+				Usage: "Verify the signatures of the Safe transaction, and print the execTransaction calldata",
+				Flags: []cli.Flag{
+					txFlag,
+					&cli.StringSliceFlag{
+						Name:    "owners",
+						Usage:   "The owners of the Safe. If set, the signatures must be by owners and meet the threshold",
+						EnvVars: []string{"SAFE_OWNERS"},
+					},
+					&cli.Uint64Flag{
+						Name:    "threshold",
+						Usage:   "The signature threshold of the Safe",
+						Value:   1,
+						EnvVars: []string{"SAFE_THRESHOLD"},
+					},
+				},
+				Action: exec,
+			},
+		},
+	}
+
+	if err := app.Run(os.Args); err != nil {
+		log.Crit("error safe-tx", "err", err)
+	}
+}
+
+func build(ctx *cli.Context) error {
+	var batch safe.Batch
+	if err := readJSON(ctx.Path("batch"), &batch); err != nil {
+		return fmt.Errorf("cannot read batch: %w", err)
+	}
+	if batch.ChainID == nil || batch.ChainID.Sign() == 0 {
+		if !ctx.IsSet("chain-id") {
+			return errors.New("batch has no chain ID, --chain-id is required")
+		}
+		batch.ChainID = new(big.Int).SetUint64(ctx.Uint64("chain-id"))
+	}
+	safeAddress := ctx.String("safe-address")
+	if !common.IsHexAddress(safeAddress) {
+		return fmt.Errorf("invalid safe address: %q", safeAddress)
+	}
+
+	tx, err := batch.SafeTx(common.HexToAddress(safeAddress), ctx.Uint64("nonce"))
+	if err != nil {
+		return err
+	}
+	hash, err := tx.Hash()
+	if err != nil {
+		return err
+	}
+	log.Info("Built Safe transaction", "calls", len(batch.Transactions), "to", tx.To, "operation", tx.Operation, "nonce", tx.Nonce, "hash", hash)
+	return writeJSON(ctx.Path("tx"), tx)
+}
+
+func sign(ctx *cli.Context) error {
+	var tx safe.SafeTx
+	if err := readJSON(ctx.Path("tx"), &tx); err != nil {
+		return fmt.Errorf("cannot read transaction: %w", err)
+	}
+
+	var txSigner safe.TxSigner
+	signerConfig := signer.ReadCLIConfig(ctx)
+	if err := signerConfig.Check(); err != nil {
+		return err
+	}
+	switch {
+	case ctx.IsSet("private-key") && signerConfig.Enabled():
+		return errors.New("only one of --private-key and the remote signer can be used")
+	case ctx.IsSet("private-key"):
+		key, err := crypto.HexToECDSA(strings.TrimPrefix(ctx.String("private-key"), "0x"))
+		if err != nil {
+			return fmt.Errorf("invalid private key: %w", err)
+		}
+		txSigner = safe.NewLocalSigner(key)
+	case signerConfig.Enabled():
+		client, err := signer.NewSignerClientFromConfig(log.Root(), signerConfig)
+		if err != nil {
+			return fmt.Errorf("cannot create signer client: %w", err)
+		}
+		txSigner = safe.NewRemoteSigner(client, common.HexToAddress(signerConfig.Address))
+	default:
+		return errors.New("either --private-key or the remote signer is required")
+	}
+
+	hash, err := tx.Hash()
+	if err != nil {
+		return err
+	}
+	log.Info("Signing Safe transaction", "signer", txSigner.Address(), "hash", hash)
+	if err := tx.Sign(ctx.Context, txSigner); err != nil {
+		return err
+	}
+	log.Info("Signed Safe transaction", "signatures", len(tx.Signatures))
+	return writeJSON(ctx.Path("tx"), tx)
+}
+
+func exec(ctx *cli.Context) error {
+	var tx safe.SafeTx
+	if err := readJSON(ctx.Path("tx"), &tx); err != nil {
+		return fmt.Errorf("cannot read transaction: %w", err)
+	}
+
+	if owners := ctx.StringSlice("owners"); len(owners) > 0 {
+		addresses := make([]common.Address, len(owners))
+		for i, owner := range owners {
+			if !common.IsHexAddress(owner) {
+				return fmt.Errorf("invalid owner address: %q", owner)
+			}
+			addresses[i] = common.HexToAddress(owner)
+		}
+		if err := tx.VerifySignatures(addresses, ctx.Uint64("threshold")); err != nil {
+			return fmt.Errorf("invalid signatures: %w", err)
+		}
+		log.Info("Verified signatures", "signatures", len(tx.Signatures), "threshold", ctx.Uint64("threshold"))
+	}
+
+	calldata, err := tx.ExecTransactionCalldata()
+	if err != nil {
+		return err
+	}
+	fmt.Println(hexutil.Encode(calldata))
+	return nil
+}
+
+func readJSON(path string, out interface{}) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
+
+func writeJSON(outfile string, input interface{}) error {
+	f, err := os.OpenFile(outfile, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o666)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	return enc.Encode(input)
+}
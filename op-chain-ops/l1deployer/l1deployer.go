@@ -0,0 +1,537 @@
+package l1deployer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-chain-ops/genesis"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+)
+
+// DeterministicDeployerAddress is the address of the deterministic deployment proxy
+// (https://github.com/Arachnid/deterministic-deployment-proxy). It deploys contracts with
+// CREATE2, at addresses that only depend on the salt and the init code.
+var DeterministicDeployerAddress = common.HexToAddress("0x4e59b44847b379578588920cA78FbF26c0B4956C")
+
+// DefaultSalt is the default salt of the CREATE2 deployments. It is the same as the
+// default implementation salt of the Foundry deploy script.
+const DefaultSalt = "ethers phoenix"
+
+// l1CrossDomainMessengerName is the name of the L1CrossDomainMessenger in the AddressManager
+const l1CrossDomainMessengerName = "OVM_L1CrossDomainMessenger"
+
+// Proxy types of the ProxyAdmin
+const (
+	proxyTypeERC1967 uint8 = iota
+	proxyTypeChugSplash
+	proxyTypeResolved
+)
+
+// Client is the L1 client of the Deployer
+type Client interface {
+	bind.ContractCaller
+	NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error)
+}
+
+// TxManager sends the deployment transactions. It is implemented by the txmgr.SimpleTxManager.
+type TxManager interface {
+	Send(ctx context.Context, candidate txmgr.TxCandidate) (*types.Receipt, error)
+	From() common.Address
+}
+
+// Deployer deploys the L1 contracts of an OP Stack chain: the AddressManager, the ProxyAdmin,
+// the proxies and the implementations, and then initializes the proxies and transfers the
+// ownership of the ProxyAdmin to the final system owner.
+//
+// Every step checks the L1 state first and is skipped when it is already done, so an interrupted
+// deployment is resumed by running the deployer again with the same deployments file. The
+// AddressManager is deployed with CREATE, as it is owned by its deployer, and its address is
+// derived from the deployer nonce and saved before the deployment is sent. All other contracts
+// are deployed with CREATE2 through the deterministic deployer, so that their addresses only
+// depend on the deployer account, the salt and the deploy config.
+//
+// Like the Foundry deploy script, the L1StandardBridgeProxy is a legacy L1ChugSplashProxy and the
+// L1CrossDomainMessengerProxy is a ResolvedDelegateProxy, which resolves its implementation
+// in the AddressManager. All other proxies are ERC-1967 proxies.
+type Deployer struct {
+	log     log.Logger
+	config  *genesis.DeployConfig
+	client  Client
+	txMgr   TxManager
+	salt    common.Hash
+	outfile string
+
+	deployments *genesis.L1Deployments
+}
+
+// NewDeployer creates a Deployer that writes the deployments to the outfile after every step.
+// If the outfile exists, the deployments in it are resumed.
+// The L2OutputOracle starting timestamp of the config must be resolved, it cannot be negative.
+func NewDeployer(lgr log.Logger, config *genesis.DeployConfig, client Client, txMgr TxManager, salt string, outfile string) (*Deployer, error) {
+	if config.L2OutputOracleStartingTimestamp < 0 {
+		return nil, errors.New("L2OutputOracleStartingTimestamp must be set to the timestamp of the L1 starting block")
+	}
+	deployments := new(genesis.L1Deployments)
+	if _, err := os.Stat(outfile); err == nil {
+		deployments, err = genesis.NewL1Deployments(outfile)
+		if err != nil {
+			return nil, err
+		}
+		lgr.Info("Resuming deployment", "deployments", outfile)
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	return &Deployer{
+		log:         lgr,
+		config:      config,
+		client:      client,
+		txMgr:       txMgr,
+		salt:        crypto.Keccak256Hash([]byte(salt)),
+		outfile:     outfile,
+		deployments: deployments,
+	}, nil
+}
+
+// Deploy runs all steps of the deployment, and returns the deployed contracts.
+func (d *Deployer) Deploy(ctx context.Context) (*genesis.L1Deployments, error) {
+	code, err := d.client.CodeAt(ctx, DeterministicDeployerAddress, nil)
+	if err != nil {
+		return nil, fmt.Errorf("cannot fetch code of the deterministic deployer: %w", err)
+	}
+	if len(code) == 0 {
+		return nil, fmt.Errorf("deterministic deployer is not deployed at %s", DeterministicDeployerAddress)
+	}
+
+	steps := []struct {
+		name string
+		fn   func(context.Context) error
+	}{
+		{"AddressManager", d.deployAddressManager},
+		{"ProxyAdmin", d.deployProxyAdmin},
+		{"proxies", d.deployProxies},
+		{"AddressManager config", d.configureAddressManager},
+		{"implementations", d.deployImplementations},
+		{"proxy initialization", d.initializeProxies},
+		{"ProxyAdmin ownership", d.transferProxyAdminOwnership},
+	}
+	for _, step := range steps {
+		d.log.Info("Running deployment step", "step", step.name)
+		if err := step.fn(ctx); err != nil {
+			return nil, fmt.Errorf("%s: %w", step.name, err)
+		}
+	}
+	if err := d.deployments.Check(); err != nil {
+		return nil, err
+	}
+	d.log.Info("Deployed L1 contracts", "deployments", d.outfile)
+	return d.deployments, nil
+}
+
+// deployAddressManager deploys the AddressManager with CREATE. The address is derived from the
+// nonce of the next deployer transaction, and saved before the deployment is sent: if the deployer
+// is interrupted, it finds the AddressManager at the saved address once the transaction is
+// included, or deploys it again with the same nonce while it is not.
+func (d *Deployer) deployAddressManager(ctx context.Context) error {
+	if d.deployments.AddressManager != (common.Address{}) {
+		if ok, err := d.hasCode(ctx, d.deployments.AddressManager); err != nil {
+			return err
+		} else if ok {
+			d.log.Info("Contract is already deployed", "name", "AddressManager", "address", d.deployments.AddressManager)
+			return nil
+		}
+	}
+	code, err := initCode(bindings.AddressManagerMetaData)
+	if err != nil {
+		return err
+	}
+	nonce, err := d.client.NonceAt(ctx, d.txMgr.From(), nil)
+	if err != nil {
+		return fmt.Errorf("cannot fetch deployer nonce: %w", err)
+	}
+	addr := crypto.CreateAddress(d.txMgr.From(), nonce)
+	if saved := d.deployments.AddressManager; saved != (common.Address{}) && saved != addr {
+		d.log.Warn("AddressManager was not deployed at the saved address, deploying it again", "saved", saved, "address", addr)
+	}
+	d.deployments.AddressManager = addr
+	if err := d.save(); err != nil {
+		return err
+	}
+
+	receipt, err := d.send(ctx, "deploy AddressManager", nil, code)
+	if err != nil {
+		return err
+	}
+	if receipt.ContractAddress != addr {
+		return fmt.Errorf("AddressManager deployed at %s instead of %s, the deployer account sent another transaction", receipt.ContractAddress, addr)
+	}
+	d.log.Info("Deployed contract", "name", "AddressManager", "address", addr)
+	return nil
+}
+
+func (d *Deployer) deployProxyAdmin(ctx context.Context) error {
+	addr, err := d.create2(ctx, "ProxyAdmin", d.salt, bindings.ProxyAdminMetaData, d.txMgr.From())
+	if err != nil {
+		return err
+	}
+	d.deployments.ProxyAdmin = addr
+	if err := d.save(); err != nil {
+		return err
+	}
+
+	proxyAdmin, err := bindings.NewProxyAdminCaller(addr, d.client)
+	if err != nil {
+		return err
+	}
+	addressManager, err := proxyAdmin.AddressManager(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return err
+	}
+	if addressManager == d.deployments.AddressManager {
+		return nil
+	}
+	return d.call(ctx, "set address manager", addr, bindings.ProxyAdminMetaData, "setAddressManager", d.deployments.AddressManager)
+}
+
+// deployProxies deploys the proxy of every contract, in the order of the Foundry deploy script,
+// and sets the type of the legacy proxies in the ProxyAdmin. The ERC-1967 proxies have the same
+// init code, so the salt of each proxy includes its name.
+func (d *Deployer) deployProxies(ctx context.Context) error {
+	proxies := []struct {
+		name      string
+		addr      *common.Address
+		meta      *bind.MetaData
+		args      []any
+		proxyType uint8
+	}{
+		{"OptimismPortalProxy", &d.deployments.OptimismPortalProxy, bindings.ProxyMetaData, []any{d.deployments.ProxyAdmin}, proxyTypeERC1967},
+		{"L2OutputOracleProxy", &d.deployments.L2OutputOracleProxy, bindings.ProxyMetaData, []any{d.deployments.ProxyAdmin}, proxyTypeERC1967},
+		{"SystemConfigProxy", &d.deployments.SystemConfigProxy, bindings.ProxyMetaData, []any{d.deployments.ProxyAdmin}, proxyTypeERC1967},
+		{"L1StandardBridgeProxy", &d.deployments.L1StandardBridgeProxy, bindings.L1ChugSplashProxyMetaData, []any{d.deployments.ProxyAdmin}, proxyTypeChugSplash},
+		{"L1CrossDomainMessengerProxy", &d.deployments.L1CrossDomainMessengerProxy, bindings.ResolvedDelegateProxyMetaData, []any{d.deployments.AddressManager, l1CrossDomainMessengerName}, proxyTypeResolved},
+		{"OptimismMintableERC20FactoryProxy", &d.deployments.OptimismMintableERC20FactoryProxy, bindings.ProxyMetaData, []any{d.deployments.ProxyAdmin}, proxyTypeERC1967},
+		{"L1ERC721BridgeProxy", &d.deployments.L1ERC721BridgeProxy, bindings.ProxyMetaData, []any{d.deployments.ProxyAdmin}, proxyTypeERC1967},
+		{"ProtocolVersionsProxy", &d.deployments.ProtocolVersionsProxy, bindings.ProxyMetaData, []any{d.deployments.ProxyAdmin}, proxyTypeERC1967},
+	}
+	for _, proxy := range proxies {
+		salt := crypto.Keccak256Hash(d.salt[:], []byte(proxy.name))
+		addr, err := d.create2(ctx, proxy.name, salt, proxy.meta, proxy.args...)
+		if err != nil {
+			return err
+		}
+		*proxy.addr = addr
+		if err := d.save(); err != nil {
+			return err
+		}
+		if proxy.proxyType == proxyTypeERC1967 {
+			continue
+		}
+		if err := d.setProxyType(ctx, proxy.name, addr, proxy.proxyType); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setProxyType sets the type of a legacy proxy in the ProxyAdmin, so that the ProxyAdmin upgrades
+// it the way the proxy requires. The ProxyAdmin upgrades a ResolvedDelegateProxy by setting its
+// implementation name in the AddressManager, so that name is set too.
+func (d *Deployer) setProxyType(ctx context.Context, name string, proxy common.Address, proxyType uint8) error {
+	proxyAdmin, err := bindings.NewProxyAdminCaller(d.deployments.ProxyAdmin, d.client)
+	if err != nil {
+		return err
+	}
+	opts := &bind.CallOpts{Context: ctx}
+	current, err := proxyAdmin.ProxyType(opts, proxy)
+	if err != nil {
+		return err
+	}
+	if current != proxyType {
+		if err := d.call(ctx, "set proxy type of "+name, d.deployments.ProxyAdmin, bindings.ProxyAdminMetaData, "setProxyType", proxy, proxyType); err != nil {
+			return err
+		}
+	}
+	if proxyType != proxyTypeResolved {
+		return nil
+	}
+
+	implName, err := proxyAdmin.ImplementationName(opts, proxy)
+	if err != nil {
+		return err
+	}
+	if implName == l1CrossDomainMessengerName {
+		return nil
+	}
+	return d.call(ctx, "set implementation name of "+name, d.deployments.ProxyAdmin, bindings.ProxyAdminMetaData, "setImplementationName", proxy, l1CrossDomainMessengerName)
+}
+
+// configureAddressManager registers the L1CrossDomainMessengerProxy in the AddressManager, like
+// the Foundry deploy script, and transfers the ownership of the AddressManager to the ProxyAdmin.
+// The ResolvedDelegateProxy resolves its implementation with this entry, the ProxyAdmin sets it
+// to the L1CrossDomainMessenger when the proxy is initialized.
+func (d *Deployer) configureAddressManager(ctx context.Context) error {
+	addressManager, err := bindings.NewAddressManagerCaller(d.deployments.AddressManager, d.client)
+	if err != nil {
+		return err
+	}
+	opts := &bind.CallOpts{Context: ctx}
+	owner, err := addressManager.Owner(opts)
+	if err != nil {
+		return err
+	}
+	if owner == d.deployments.ProxyAdmin {
+		return nil
+	}
+
+	messenger, err := addressManager.GetAddress(opts, l1CrossDomainMessengerName)
+	if err != nil {
+		return err
+	}
+	if messenger != d.deployments.L1CrossDomainMessengerProxy {
+		if err := d.call(ctx, "set L1CrossDomainMessenger address", d.deployments.AddressManager, bindings.AddressManagerMetaData, "setAddress", l1CrossDomainMessengerName, d.deployments.L1CrossDomainMessengerProxy); err != nil {
+			return err
+		}
+	}
+	return d.call(ctx, "transfer AddressManager ownership", d.deployments.AddressManager, bindings.AddressManagerMetaData, "transferOwnership", d.deployments.ProxyAdmin)
+}
+
+func (d *Deployer) deployImplementations(ctx context.Context) error {
+	implementations := []struct {
+		name string
+		addr *common.Address
+		meta *bind.MetaData
+		args []any
+	}{
+		{"OptimismPortal", &d.deployments.OptimismPortal, bindings.OptimismPortalMetaData, nil},
+		{"L1CrossDomainMessenger", &d.deployments.L1CrossDomainMessenger, bindings.L1CrossDomainMessengerMetaData, nil},
+		{"L2OutputOracle", &d.deployments.L2OutputOracle, bindings.L2OutputOracleMetaData, []any{
+			new(big.Int).SetUint64(d.config.L2OutputOracleSubmissionInterval),
+			new(big.Int).SetUint64(d.config.L2BlockTime),
+			new(big.Int).SetUint64(d.config.FinalizationPeriodSeconds),
+		}},
+		{"OptimismMintableERC20Factory", &d.deployments.OptimismMintableERC20Factory, bindings.OptimismMintableERC20FactoryMetaData, nil},
+		{"SystemConfig", &d.deployments.SystemConfig, bindings.SystemConfigMetaData, nil},
+		{"L1StandardBridge", &d.deployments.L1StandardBridge, bindings.L1StandardBridgeMetaData, nil},
+		{"L1ERC721Bridge", &d.deployments.L1ERC721Bridge, bindings.L1ERC721BridgeMetaData, nil},
+		{"ProtocolVersions", &d.deployments.ProtocolVersions, bindings.ProtocolVersionsMetaData, nil},
+	}
+	for _, impl := range implementations {
+		addr, err := d.create2(ctx, impl.name, d.salt, impl.meta, impl.args...)
+		if err != nil {
+			return err
+		}
+		*impl.addr = addr
+		if err := d.save(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// initializeProxies upgrades every proxy to its implementation, and initializes it
+// with the values of the deploy config.
+func (d *Deployer) initializeProxies(ctx context.Context) error {
+	cfg := d.config
+	deployments := d.deployments
+	initializations := []struct {
+		name  string
+		proxy common.Address
+		impl  common.Address
+		meta  *bind.MetaData
+		args  []any
+	}{
+		{"SystemConfig", deployments.SystemConfigProxy, deployments.SystemConfig, bindings.SystemConfigMetaData, []any{
+			cfg.FinalSystemOwner,
+			new(big.Int).SetUint64(cfg.GasPriceOracleOverhead),
+			new(big.Int).SetUint64(cfg.GasPriceOracleScalar),
+			cfg.BatchSenderAddress.Hash(),
+			uint64(cfg.L2GenesisBlockGasLimit),
+			cfg.P2PSequencerAddress,
+			genesis.DefaultResourceConfig,
+			new(big.Int).SetUint64(cfg.SystemConfigStartBlock),
+			cfg.BatchInboxAddress,
+			bindings.SystemConfigAddresses{
+				L1CrossDomainMessenger:       deployments.L1CrossDomainMessengerProxy,
+				L1ERC721Bridge:               deployments.L1ERC721BridgeProxy,
+				L1StandardBridge:             deployments.L1StandardBridgeProxy,
+				L2OutputOracle:               deployments.L2OutputOracleProxy,
+				OptimismPortal:               deployments.OptimismPortalProxy,
+				OptimismMintableERC20Factory: deployments.OptimismMintableERC20FactoryProxy,
+			},
+		}},
+		{"L1StandardBridge", deployments.L1StandardBridgeProxy, deployments.L1StandardBridge, bindings.L1StandardBridgeMetaData, []any{
+			deployments.L1CrossDomainMessengerProxy,
+		}},
+		{"L1ERC721Bridge", deployments.L1ERC721BridgeProxy, deployments.L1ERC721Bridge, bindings.L1ERC721BridgeMetaData, []any{
+			deployments.L1CrossDomainMessengerProxy,
+		}},
+		{"OptimismMintableERC20Factory", deployments.OptimismMintableERC20FactoryProxy, deployments.OptimismMintableERC20Factory, bindings.OptimismMintableERC20FactoryMetaData, []any{
+			deployments.L1StandardBridgeProxy,
+		}},
+		{"L1CrossDomainMessenger", deployments.L1CrossDomainMessengerProxy, deployments.L1CrossDomainMessenger, bindings.L1CrossDomainMessengerMetaData, []any{
+			deployments.OptimismPortalProxy,
+		}},
+		{"L2OutputOracle", deployments.L2OutputOracleProxy, deployments.L2OutputOracle, bindings.L2OutputOracleMetaData, []any{
+			new(big.Int).SetUint64(cfg.L2OutputOracleStartingBlockNumber),
+			big.NewInt(int64(cfg.L2OutputOracleStartingTimestamp)),
+			cfg.L2OutputOracleProposer,
+			cfg.L2OutputOracleChallenger,
+		}},
+		{"OptimismPortal", deployments.OptimismPortalProxy, deployments.OptimismPortal, bindings.OptimismPortalMetaData, []any{
+			deployments.L2OutputOracleProxy,
+			cfg.PortalGuardian,
+			deployments.SystemConfigProxy,
+			false,
+		}},
+		{"ProtocolVersions", deployments.ProtocolVersionsProxy, deployments.ProtocolVersions, bindings.ProtocolVersionsMetaData, []any{
+			cfg.FinalSystemOwner,
+			new(big.Int).SetBytes(cfg.RequiredProtocolVersion[:]),
+			new(big.Int).SetBytes(cfg.RecommendedProtocolVersion[:]),
+		}},
+	}
+
+	proxyAdmin, err := bindings.NewProxyAdminCaller(deployments.ProxyAdmin, d.client)
+	if err != nil {
+		return err
+	}
+	for _, init := range initializations {
+		impl, err := proxyAdmin.GetProxyImplementation(&bind.CallOpts{Context: ctx}, init.proxy)
+		if err != nil {
+			return fmt.Errorf("cannot fetch implementation of %s: %w", init.name, err)
+		}
+		if impl == init.impl {
+			d.log.Info("Proxy is already initialized", "name", init.name, "proxy", init.proxy, "implementation", impl)
+			continue
+		}
+		contractABI, err := init.meta.GetAbi()
+		if err != nil {
+			return err
+		}
+		data, err := contractABI.Pack("initialize", init.args...)
+		if err != nil {
+			return fmt.Errorf("cannot encode initialize of %s: %w", init.name, err)
+		}
+		if err := d.call(ctx, "initialize "+init.name, deployments.ProxyAdmin, bindings.ProxyAdminMetaData, "upgradeAndCall", init.proxy, init.impl, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *Deployer) transferProxyAdminOwnership(ctx context.Context) error {
+	proxyAdmin, err := bindings.NewProxyAdminCaller(d.deployments.ProxyAdmin, d.client)
+	if err != nil {
+		return err
+	}
+	owner, err := proxyAdmin.Owner(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return err
+	}
+	if owner == d.config.FinalSystemOwner {
+		return nil
+	}
+	return d.call(ctx, "transfer ProxyAdmin ownership", d.deployments.ProxyAdmin, bindings.ProxyAdminMetaData, "transferOwnership", d.config.FinalSystemOwner)
+}
+
+// create2 deploys a contract through the deterministic deployer, unless it is already deployed,
+// and returns its address.
+func (d *Deployer) create2(ctx context.Context, name string, salt common.Hash, meta *bind.MetaData, args ...any) (common.Address, error) {
+	code, err := initCode(meta, args...)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("cannot encode init code of %s: %w", name, err)
+	}
+	addr := crypto.CreateAddress2(DeterministicDeployerAddress, salt, crypto.Keccak256(code))
+	if ok, err := d.hasCode(ctx, addr); err != nil {
+		return common.Address{}, err
+	} else if ok {
+		d.log.Info("Contract is already deployed", "name", name, "address", addr)
+		return addr, nil
+	}
+
+	if _, err := d.send(ctx, "deploy "+name, &DeterministicDeployerAddress, append(salt.Bytes(), code...)); err != nil {
+		return common.Address{}, err
+	}
+	if ok, err := d.hasCode(ctx, addr); err != nil {
+		return common.Address{}, err
+	} else if !ok {
+		return common.Address{}, fmt.Errorf("no code at %s after deploying %s", addr, name)
+	}
+	d.log.Info("Deployed contract", "name", name, "address", addr)
+	return addr, nil
+}
+
+// call sends a transaction that calls a contract method
+func (d *Deployer) call(ctx context.Context, description string, to common.Address, meta *bind.MetaData, method string, args ...any) error {
+	contractABI, err := meta.GetAbi()
+	if err != nil {
+		return err
+	}
+	data, err := contractABI.Pack(method, args...)
+	if err != nil {
+		return fmt.Errorf("cannot encode %s: %w", method, err)
+	}
+	_, err = d.send(ctx, description, &to, data)
+	return err
+}
+
+func (d *Deployer) send(ctx context.Context, description string, to *common.Address, data []byte) (*types.Receipt, error) {
+	d.log.Info("Sending transaction", "description", description)
+	receipt, err := d.txMgr.Send(ctx, txmgr.TxCandidate{TxData: data, To: to})
+	if err != nil {
+		return nil, fmt.Errorf("cannot %s: %w", description, err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return nil, fmt.Errorf("cannot %s: transaction %s failed", description, receipt.TxHash)
+	}
+	d.log.Info("Transaction confirmed", "description", description, "tx", receipt.TxHash, "block", receipt.BlockNumber)
+	return receipt, nil
+}
+
+func (d *Deployer) hasCode(ctx context.Context, addr common.Address) (bool, error) {
+	code, err := d.client.CodeAt(ctx, addr, nil)
+	if err != nil {
+		return false, fmt.Errorf("cannot fetch code at %s: %w", addr, err)
+	}
+	return len(code) > 0, nil
+}
+
+// save writes the deployments to the outfile
+func (d *Deployer) save() error {
+	f, err := os.OpenFile(d.outfile, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o666)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	return enc.Encode(d.deployments)
+}
+
+// initCode returns the creation code of a contract with the encoded constructor arguments
+func initCode(meta *bind.MetaData, args ...any) ([]byte, error) {
+	contractABI, err := meta.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	bin, err := hexutil.Decode(meta.Bin)
+	if err != nil {
+		return nil, err
+	}
+	encoded, err := contractABI.Pack("", args...)
+	if err != nil {
+		return nil, err
+	}
+	return append(bin, encoded...), nil
+}
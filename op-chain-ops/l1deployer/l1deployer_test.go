@@ -0,0 +1,222 @@
+package l1deployer
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-chain-ops/deployer"
+	"github.com/ethereum-optimism/optimism/op-chain-ops/genesis"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+)
+
+// deterministicDeployerCode is the runtime code of the deterministic deployment proxy
+var deterministicDeployerCode = hexutil.MustDecode("0x7fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffe03601600081602082378035828234f58015156039578182fd5b8082525050506014600cf3")
+
+// simTxManager sends transactions to a simulated backend, and commits a block for each
+type simTxManager struct {
+	t       *testing.T
+	backend *backends.SimulatedBackend
+	sent    int
+	// interrupt makes Send fail after the next transaction is included, like an interrupted deployer
+	interrupt bool
+}
+
+func (m *simTxManager) From() common.Address {
+	return deployer.TestAddress
+}
+
+func (m *simTxManager) Send(ctx context.Context, candidate txmgr.TxCandidate) (*types.Receipt, error) {
+	nonce, err := m.backend.PendingNonceAt(ctx, m.From())
+	require.NoError(m.t, err)
+	gas, err := m.backend.EstimateGas(ctx, ethereum.CallMsg{From: m.From(), To: candidate.To, Data: candidate.TxData})
+	if err != nil {
+		return nil, err
+	}
+	head, err := m.backend.HeaderByNumber(ctx, nil)
+	require.NoError(m.t, err)
+	chainID := m.backend.Blockchain().Config().ChainID
+	tx, err := types.SignNewTx(deployer.TestKey, types.LatestSignerForChainID(chainID), &types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     nonce,
+		GasTipCap: big.NewInt(params.GWei),
+		GasFeeCap: new(big.Int).Add(new(big.Int).Mul(head.BaseFee, big.NewInt(2)), big.NewInt(params.GWei)),
+		Gas:       gas,
+		To:        candidate.To,
+		Data:      candidate.TxData,
+	})
+	require.NoError(m.t, err)
+	require.NoError(m.t, m.backend.SendTransaction(ctx, tx))
+	m.backend.Commit()
+	m.sent++
+	if m.interrupt {
+		m.interrupt = false
+		return nil, errors.New("interrupted")
+	}
+	return m.backend.TransactionReceipt(ctx, tx.Hash())
+}
+
+func TestDeploy(t *testing.T) {
+	config, err := genesis.NewDeployConfig("../genesis/testdata/test-deploy-config-full.json")
+	require.NoError(t, err)
+	config.L2OutputOracleStartingTimestamp = 0
+
+	backend := backends.NewSimulatedBackend(core.GenesisAlloc{
+		deployer.TestAddress:         {Balance: new(big.Int).Mul(big.NewInt(params.Ether), big.NewInt(1000))},
+		DeterministicDeployerAddress: {Code: deterministicDeployerCode},
+	}, 30_000_000)
+	txMgr := &simTxManager{t: t, backend: backend}
+	outfile := filepath.Join(t.TempDir(), "deployments.json")
+
+	d, err := NewDeployer(log.New(), config, backend, txMgr, DefaultSalt, outfile)
+	require.NoError(t, err)
+	deployments, err := d.Deploy(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, deployments.Check())
+
+	// the deployments file is the same as the result, and can be used to build the L2 genesis
+	written, err := genesis.NewL1Deployments(outfile)
+	require.NoError(t, err)
+	require.Equal(t, deployments, written)
+
+	opts := &bind.CallOpts{}
+	proxyAdmin, err := bindings.NewProxyAdminCaller(deployments.ProxyAdmin, backend)
+	require.NoError(t, err)
+	owner, err := proxyAdmin.Owner(opts)
+	require.NoError(t, err)
+	require.Equal(t, config.FinalSystemOwner, owner)
+	impl, err := proxyAdmin.GetProxyImplementation(opts, deployments.OptimismPortalProxy)
+	require.NoError(t, err)
+	require.Equal(t, deployments.OptimismPortal, impl)
+
+	systemConfig, err := bindings.NewSystemConfigCaller(deployments.SystemConfigProxy, backend)
+	require.NoError(t, err)
+	owner, err = systemConfig.Owner(opts)
+	require.NoError(t, err)
+	require.Equal(t, config.FinalSystemOwner, owner)
+	batcherHash, err := systemConfig.BatcherHash(opts)
+	require.NoError(t, err)
+	require.Equal(t, config.BatchSenderAddress.Hash(), common.Hash(batcherHash))
+
+	portal, err := bindings.NewOptimismPortalCaller(deployments.OptimismPortalProxy, backend)
+	require.NoError(t, err)
+	oracle, err := portal.L2ORACLE(opts)
+	require.NoError(t, err)
+	require.Equal(t, deployments.L2OutputOracleProxy, oracle)
+
+	// the bridge and messenger proxies are the legacy proxies of the Foundry deploy script
+	proxyType, err := proxyAdmin.ProxyType(opts, deployments.L1StandardBridgeProxy)
+	require.NoError(t, err)
+	require.Equal(t, proxyTypeChugSplash, proxyType)
+	impl, err = proxyAdmin.GetProxyImplementation(opts, deployments.L1StandardBridgeProxy)
+	require.NoError(t, err)
+	require.Equal(t, deployments.L1StandardBridge, impl)
+	bridge, err := bindings.NewL1StandardBridgeCaller(deployments.L1StandardBridgeProxy, backend)
+	require.NoError(t, err)
+	bridgeMessenger, err := bridge.MESSENGER(opts)
+	require.NoError(t, err)
+	require.Equal(t, deployments.L1CrossDomainMessengerProxy, bridgeMessenger)
+
+	proxyType, err = proxyAdmin.ProxyType(opts, deployments.L1CrossDomainMessengerProxy)
+	require.NoError(t, err)
+	require.Equal(t, proxyTypeResolved, proxyType)
+	implName, err := proxyAdmin.ImplementationName(opts, deployments.L1CrossDomainMessengerProxy)
+	require.NoError(t, err)
+	require.Equal(t, l1CrossDomainMessengerName, implName)
+	messenger, err := bindings.NewL1CrossDomainMessengerCaller(deployments.L1CrossDomainMessengerProxy, backend)
+	require.NoError(t, err)
+	messengerPortal, err := messenger.PORTAL(opts)
+	require.NoError(t, err)
+	require.Equal(t, deployments.OptimismPortalProxy, messengerPortal)
+
+	addressManager, err := bindings.NewAddressManagerCaller(deployments.AddressManager, backend)
+	require.NoError(t, err)
+	owner, err = addressManager.Owner(opts)
+	require.NoError(t, err)
+	require.Equal(t, deployments.ProxyAdmin, owner)
+	messengerImpl, err := addressManager.GetAddress(opts, l1CrossDomainMessengerName)
+	require.NoError(t, err)
+	require.Equal(t, deployments.L1CrossDomainMessenger, messengerImpl)
+
+	// resuming a complete deployment sends no transactions
+	sent := txMgr.sent
+	d, err = NewDeployer(log.New(), config, backend, txMgr, DefaultSalt, outfile)
+	require.NoError(t, err)
+	resumed, err := d.Deploy(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, deployments, resumed)
+	require.Equal(t, sent, txMgr.sent)
+}
+
+func TestDeployResumesCreate2Addresses(t *testing.T) {
+	config, err := genesis.NewDeployConfig("../genesis/testdata/test-deploy-config-full.json")
+	require.NoError(t, err)
+	config.L2OutputOracleStartingTimestamp = 0
+
+	backend := backends.NewSimulatedBackend(core.GenesisAlloc{
+		deployer.TestAddress:         {Balance: new(big.Int).Mul(big.NewInt(params.Ether), big.NewInt(1000))},
+		DeterministicDeployerAddress: {Code: deterministicDeployerCode},
+	}, 30_000_000)
+	txMgr := &simTxManager{t: t, backend: backend}
+
+	// a deployment that is interrupted after the proxies, and resumed without the deployments file
+	d, err := NewDeployer(log.New(), config, backend, txMgr, DefaultSalt, filepath.Join(t.TempDir(), "deployments.json"))
+	require.NoError(t, err)
+	require.NoError(t, d.deployAddressManager(context.Background()))
+	require.NoError(t, d.deployProxyAdmin(context.Background()))
+	require.NoError(t, d.deployProxies(context.Background()))
+	first := d.deployments.Copy()
+
+	sent := txMgr.sent
+	d, err = NewDeployer(log.New(), config, backend, txMgr, DefaultSalt, filepath.Join(t.TempDir(), "deployments.json"))
+	require.NoError(t, err)
+	d.deployments.AddressManager = first.AddressManager
+	require.NoError(t, d.deployProxyAdmin(context.Background()))
+	require.NoError(t, d.deployProxies(context.Background()))
+	require.Equal(t, first, d.deployments)
+	require.Equal(t, sent, txMgr.sent)
+}
+
+func TestDeployResumesAddressManager(t *testing.T) {
+	config, err := genesis.NewDeployConfig("../genesis/testdata/test-deploy-config-full.json")
+	require.NoError(t, err)
+	config.L2OutputOracleStartingTimestamp = 0
+
+	backend := backends.NewSimulatedBackend(core.GenesisAlloc{
+		deployer.TestAddress:         {Balance: new(big.Int).Mul(big.NewInt(params.Ether), big.NewInt(1000))},
+		DeterministicDeployerAddress: {Code: deterministicDeployerCode},
+	}, 30_000_000)
+	txMgr := &simTxManager{t: t, backend: backend, interrupt: true}
+	outfile := filepath.Join(t.TempDir(), "deployments.json")
+
+	// the deployer is interrupted after the AddressManager deployment is included
+	d, err := NewDeployer(log.New(), config, backend, txMgr, DefaultSalt, outfile)
+	require.NoError(t, err)
+	require.ErrorContains(t, d.deployAddressManager(context.Background()), "interrupted")
+	require.Equal(t, 1, txMgr.sent)
+
+	// the address was saved before the deployment was sent, so it is not deployed again
+	d, err = NewDeployer(log.New(), config, backend, txMgr, DefaultSalt, outfile)
+	require.NoError(t, err)
+	require.Equal(t, crypto.CreateAddress(deployer.TestAddress, 0), d.deployments.AddressManager)
+	require.NoError(t, d.deployAddressManager(context.Background()))
+	require.Equal(t, 1, txMgr.sent)
+	ok, err := d.hasCode(context.Background(), d.deployments.AddressManager)
+	require.NoError(t, err)
+	require.True(t, ok)
+}
@@ -229,6 +229,35 @@ func (bt *BatchTransaction) Check() error {
 	return nil
 }
 
+// Calldata returns the calldata of the batch transaction. If the batch was built
+// with SkipCalldata, the calldata is encoded from the method and input values.
+func (bt *BatchTransaction) Calldata() ([]byte, error) {
+	if len(bt.Data) > 0 || bt.Method.Name == "" || bt.Method.Name == "fallback" {
+		return bt.Data, nil
+	}
+	values := make([]any, len(bt.Method.Inputs))
+	for i, input := range bt.Method.Inputs {
+		value, ok := bt.InputValues[input.Name]
+		if !ok {
+			return nil, fmt.Errorf("missing input %s", input.Name)
+		}
+		if input.Type == "tuple" {
+			return nil, fmt.Errorf("cannot encode tuple input %s", input.Name)
+		}
+		arg, err := unstringifyArg(value, input.Type)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = arg
+	}
+	encoded, err := bt.Arguments().PackValues(values)
+	if err != nil {
+		return nil, err
+	}
+	selector := crypto.Keccak256([]byte(bt.Signature()))[0:4]
+	return append(selector, encoded...), nil
+}
+
 // Signature returns the function signature of the batch transaction.
 func (bt *BatchTransaction) Signature() string {
 	types := make([]string, len(bt.Method.Inputs))
@@ -0,0 +1,339 @@
+package safe
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-service/signer"
+)
+
+// MultiSendCallOnlyAddress is the canonical address of the MultiSendCallOnly v1.3.0 contract,
+// which the Safe delegatecalls to execute the calls of a batch atomically.
+var MultiSendCallOnlyAddress = common.HexToAddress("0x40A2aCCbd92BCA938b02010E17A5b8929b49130D")
+
+// Operation is the type of call that a Safe makes to the target of a transaction.
+type Operation uint8
+
+const (
+	OperationCall         Operation = 0
+	OperationDelegateCall Operation = 1
+)
+
+// multiSendSignature is the signature of the function that executes a packed list of calls
+const multiSendSignature = "multiSend(bytes)"
+
+// MultiSendCalldata encodes the calls of the batch as the calldata of MultiSendCallOnly.multiSend.
+// Each call is packed as the operation, target, value, data length and data, without padding.
+func (b *Batch) MultiSendCalldata() ([]byte, error) {
+	var packed []byte
+	for i, tx := range b.Transactions {
+		data, err := tx.Calldata()
+		if err != nil {
+			return nil, fmt.Errorf("cannot encode call %d: %w", i, err)
+		}
+		value := tx.Value
+		if value == nil {
+			value = common.Big0
+		}
+		packed = append(packed, byte(OperationCall))
+		packed = append(packed, tx.To.Bytes()...)
+		packed = append(packed, math.U256Bytes(new(big.Int).Set(value))...)
+		packed = append(packed, math.U256Bytes(new(big.Int).SetUint64(uint64(len(data))))...)
+		packed = append(packed, data...)
+	}
+	bytesType, err := abi.NewType("bytes", "", nil)
+	if err != nil {
+		return nil, err
+	}
+	encoded, err := abi.Arguments{{Type: bytesType}}.Pack(packed)
+	if err != nil {
+		return nil, err
+	}
+	return append(crypto.Keccak256([]byte(multiSendSignature))[:4], encoded...), nil
+}
+
+// SafeTx is a transaction of a Safe, as signed by its owners. The zero values of the
+// gas and refund fields are used, so that the transaction is executed without refunds.
+type SafeTx struct {
+	Safe           common.Address `json:"safe"`
+	ChainID        *big.Int       `json:"chainId"`
+	To             common.Address `json:"to"`
+	Value          *big.Int       `json:"value"`
+	Data           hexutil.Bytes  `json:"data"`
+	Operation      Operation      `json:"operation"`
+	SafeTxGas      *big.Int       `json:"safeTxGas"`
+	BaseGas        *big.Int       `json:"baseGas"`
+	GasPrice       *big.Int       `json:"gasPrice"`
+	GasToken       common.Address `json:"gasToken"`
+	RefundReceiver common.Address `json:"refundReceiver"`
+	Nonce          *big.Int       `json:"nonce"`
+	Signatures     []Signature    `json:"signatures"`
+}
+
+// Signature is the signature of a Safe transaction hash by one of the owners.
+type Signature struct {
+	Signer    common.Address `json:"signer"`
+	Signature hexutil.Bytes  `json:"signature"`
+}
+
+// SafeTx returns the transaction that the Safe executes the batch with. A batch of a single
+// call is executed with a call to its target, and larger batches with a delegatecall to MultiSendCallOnly.
+// MultiSendCallOnly runs in the context of the Safe, so the value of each call is sent from the balance of the Safe.
+func (b *Batch) SafeTx(safe common.Address, nonce uint64) (*SafeTx, error) {
+	if b.ChainID == nil {
+		return nil, errors.New("batch has no chain ID")
+	}
+	if len(b.Transactions) == 0 {
+		return nil, errors.New("batch has no transactions")
+	}
+	tx := &SafeTx{
+		Safe:      safe,
+		ChainID:   new(big.Int).Set(b.ChainID),
+		Value:     new(big.Int),
+		SafeTxGas: new(big.Int),
+		BaseGas:   new(big.Int),
+		GasPrice:  new(big.Int),
+		Nonce:     new(big.Int).SetUint64(nonce),
+	}
+	if len(b.Transactions) == 1 {
+		call := b.Transactions[0]
+		data, err := call.Calldata()
+		if err != nil {
+			return nil, err
+		}
+		tx.To, tx.Data, tx.Operation = call.To, data, OperationCall
+		if call.Value != nil {
+			tx.Value.Set(call.Value)
+		}
+		return tx, nil
+	}
+	data, err := b.MultiSendCalldata()
+	if err != nil {
+		return nil, err
+	}
+	tx.To, tx.Data, tx.Operation = MultiSendCallOnlyAddress, data, OperationDelegateCall
+	return tx, nil
+}
+
+// TypedData returns the EIP-712 typed data of the transaction, as hashed by Safe v1.3.0 and later.
+func (tx *SafeTx) TypedData() apitypes.TypedData {
+	return apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": {
+				{Name: "chainId", Type: "uint256"},
+				{Name: "verifyingContract", Type: "address"},
+			},
+			"SafeTx": {
+				{Name: "to", Type: "address"},
+				{Name: "value", Type: "uint256"},
+				{Name: "data", Type: "bytes"},
+				{Name: "operation", Type: "uint8"},
+				{Name: "safeTxGas", Type: "uint256"},
+				{Name: "baseGas", Type: "uint256"},
+				{Name: "gasPrice", Type: "uint256"},
+				{Name: "gasToken", Type: "address"},
+				{Name: "refundReceiver", Type: "address"},
+				{Name: "nonce", Type: "uint256"},
+			},
+		},
+		PrimaryType: "SafeTx",
+		Domain: apitypes.TypedDataDomain{
+			ChainId:           (*math.HexOrDecimal256)(tx.ChainID),
+			VerifyingContract: tx.Safe.Hex(),
+		},
+		Message: apitypes.TypedDataMessage{
+			"to":             tx.To.Hex(),
+			"value":          tx.Value.String(),
+			"data":           hexutil.Encode(tx.Data),
+			"operation":      fmt.Sprintf("%d", tx.Operation),
+			"safeTxGas":      tx.SafeTxGas.String(),
+			"baseGas":        tx.BaseGas.String(),
+			"gasPrice":       tx.GasPrice.String(),
+			"gasToken":       tx.GasToken.Hex(),
+			"refundReceiver": tx.RefundReceiver.Hex(),
+			"nonce":          tx.Nonce.String(),
+		},
+	}
+}
+
+// Hash returns the hash that the owners sign, which is the same as Safe.getTransactionHash.
+func (tx *SafeTx) Hash() (common.Hash, error) {
+	hash, _, err := apitypes.TypedDataAndHash(tx.TypedData())
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return common.BytesToHash(hash), nil
+}
+
+// AddSignature verifies that the signature is a signature of the transaction hash by the signer,
+// and adds it to the transaction, keeping the signatures sorted by signer.
+// The recovery id of the signature may be 0/1 or 27/28.
+func (tx *SafeTx) AddSignature(signer common.Address, sig []byte) error {
+	if len(sig) != crypto.SignatureLength {
+		return fmt.Errorf("signature must be %d bytes, got %d", crypto.SignatureLength, len(sig))
+	}
+	for _, s := range tx.Signatures {
+		if s.Signer == signer {
+			return fmt.Errorf("already signed by %s", signer)
+		}
+	}
+	hash, err := tx.Hash()
+	if err != nil {
+		return err
+	}
+	sig = common.CopyBytes(sig)
+	// The Safe expects ECDSA signatures of the hash with a recovery id of 27 or 28,
+	// as 0 and 1 are used for contract signatures and approved hashes.
+	if sig[64] < 27 {
+		sig[64] += 27
+	}
+	recovered, err := recoverSigner(hash, sig)
+	if err != nil {
+		return err
+	}
+	if recovered != signer {
+		return fmt.Errorf("signature is by %s, expected %s", recovered, signer)
+	}
+	i := sort.Search(len(tx.Signatures), func(i int) bool {
+		return bytes.Compare(tx.Signatures[i].Signer[:], signer[:]) > 0
+	})
+	tx.Signatures = append(tx.Signatures, Signature{})
+	copy(tx.Signatures[i+1:], tx.Signatures[i:])
+	tx.Signatures[i] = Signature{Signer: signer, Signature: sig}
+	return nil
+}
+
+func recoverSigner(hash common.Hash, sig []byte) (common.Address, error) {
+	if sig[64] != 27 && sig[64] != 28 {
+		return common.Address{}, fmt.Errorf("invalid recovery id %d", sig[64])
+	}
+	normalized := common.CopyBytes(sig)
+	normalized[64] -= 27
+	pub, err := crypto.SigToPub(hash[:], normalized)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("cannot recover signer: %w", err)
+	}
+	return crypto.PubkeyToAddress(*pub), nil
+}
+
+// VerifySignatures checks that all the signatures are valid and by owners of the Safe,
+// that the signers are strictly ascending, and that the threshold of signatures is met.
+// Like the Safe, it rejects duplicate signers, so that they are not counted twice.
+func (tx *SafeTx) VerifySignatures(owners []common.Address, threshold uint64) error {
+	hash, err := tx.Hash()
+	if err != nil {
+		return err
+	}
+	isOwner := make(map[common.Address]bool, len(owners))
+	for _, owner := range owners {
+		isOwner[owner] = true
+	}
+	for i, s := range tx.Signatures {
+		if i > 0 && bytes.Compare(s.Signer[:], tx.Signatures[i-1].Signer[:]) <= 0 {
+			return fmt.Errorf("signers must be strictly ascending, got %s after %s", s.Signer, tx.Signatures[i-1].Signer)
+		}
+		recovered, err := recoverSigner(hash, s.Signature)
+		if err != nil {
+			return fmt.Errorf("signature of %s: %w", s.Signer, err)
+		}
+		if recovered != s.Signer {
+			return fmt.Errorf("signature of %s is by %s", s.Signer, recovered)
+		}
+		if !isOwner[s.Signer] {
+			return fmt.Errorf("%s is not an owner", s.Signer)
+		}
+	}
+	if uint64(len(tx.Signatures)) < threshold {
+		return fmt.Errorf("got %d signatures, threshold is %d", len(tx.Signatures), threshold)
+	}
+	return nil
+}
+
+// EncodedSignatures returns the concatenated signatures, sorted by signer, as expected by the Safe.
+func (tx *SafeTx) EncodedSignatures() []byte {
+	sigs := make([]Signature, len(tx.Signatures))
+	copy(sigs, tx.Signatures)
+	sort.Slice(sigs, func(i, j int) bool { return bytes.Compare(sigs[i].Signer[:], sigs[j].Signer[:]) < 0 })
+	encoded := make([]byte, 0, len(sigs)*crypto.SignatureLength)
+	for _, s := range sigs {
+		encoded = append(encoded, s.Signature...)
+	}
+	return encoded
+}
+
+// ExecTransactionCalldata returns the calldata of the Safe.execTransaction call
+// that executes the transaction with the collected signatures.
+func (tx *SafeTx) ExecTransactionCalldata() ([]byte, error) {
+	safeABI, err := bindings.SafeMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return safeABI.Pack("execTransaction", tx.To, tx.Value, []byte(tx.Data), uint8(tx.Operation), tx.SafeTxGas, tx.BaseGas, tx.GasPrice, tx.GasToken, tx.RefundReceiver, tx.EncodedSignatures())
+}
+
+// TxSigner signs Safe transactions on behalf of an owner.
+type TxSigner interface {
+	Address() common.Address
+	SignSafeTx(ctx context.Context, tx *SafeTx) ([]byte, error)
+}
+
+// Sign signs the transaction with the signer, and adds the signature.
+func (tx *SafeTx) Sign(ctx context.Context, s TxSigner) error {
+	sig, err := s.SignSafeTx(ctx, tx)
+	if err != nil {
+		return fmt.Errorf("cannot sign with %s: %w", s.Address(), err)
+	}
+	return tx.AddSignature(s.Address(), sig)
+}
+
+// LocalSigner signs Safe transactions with a private key.
+type LocalSigner struct {
+	key *ecdsa.PrivateKey
+}
+
+func NewLocalSigner(key *ecdsa.PrivateKey) *LocalSigner {
+	return &LocalSigner{key: key}
+}
+
+func (s *LocalSigner) Address() common.Address {
+	return crypto.PubkeyToAddress(s.key.PublicKey)
+}
+
+func (s *LocalSigner) SignSafeTx(ctx context.Context, tx *SafeTx) ([]byte, error) {
+	hash, err := tx.Hash()
+	if err != nil {
+		return nil, err
+	}
+	return crypto.Sign(hash[:], s.key)
+}
+
+// RemoteSigner signs Safe transactions with the EIP-712 typed data signing of a remote signer.
+type RemoteSigner struct {
+	client *signer.SignerClient
+	from   common.Address
+}
+
+func NewRemoteSigner(client *signer.SignerClient, from common.Address) *RemoteSigner {
+	return &RemoteSigner{client: client, from: from}
+}
+
+func (s *RemoteSigner) Address() common.Address {
+	return s.from
+}
+
+func (s *RemoteSigner) SignSafeTx(ctx context.Context, tx *SafeTx) ([]byte, error) {
+	return s.client.SignTypedData(ctx, s.from, tx.TypedData())
+}
@@ -0,0 +1,157 @@
+package safe
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm/runtime"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testBatch(t *testing.T) *Batch {
+	proxyAdminABI, err := bindings.ProxyAdminMetaData.GetAbi()
+	require.NoError(t, err)
+	batch := &Batch{ChainID: big.NewInt(1)}
+	proxy := common.Address{19: 0x01}
+	require.NoError(t, batch.AddCall(common.Address{19: 0x0a}, common.Big0, "upgrade", []any{proxy, common.Address{19: 0x02}}, proxyAdminABI))
+	require.NoError(t, batch.AddCall(common.Address{19: 0x0a}, common.Big0, "changeProxyAdmin", []any{proxy, common.Address{19: 0x03}}, proxyAdminABI))
+	return batch
+}
+
+func TestMultiSendCalldata(t *testing.T) {
+	batch := testBatch(t)
+	data, err := batch.MultiSendCalldata()
+	require.NoError(t, err)
+
+	multiSendABI, err := abi.JSON(bytes.NewReader([]byte(`[{"type":"function","name":"multiSend","inputs":[{"name":"transactions","type":"bytes"}]}]`)))
+	require.NoError(t, err)
+	args, err := multiSendABI.Methods["multiSend"].Inputs.Unpack(data[4:])
+	require.NoError(t, err)
+	packed := args[0].([]byte)
+
+	// each call is packed as operation (1), to (20), value (32), data length (32) and data
+	for _, tx := range batch.Transactions {
+		require.Equal(t, byte(OperationCall), packed[0])
+		require.Equal(t, tx.To, common.BytesToAddress(packed[1:21]))
+		require.Zero(t, new(big.Int).SetBytes(packed[21:53]).Sign())
+		length := new(big.Int).SetBytes(packed[53:85]).Uint64()
+		require.Equal(t, []byte(tx.Data), packed[85:85+length])
+		packed = packed[85+length:]
+	}
+	require.Empty(t, packed)
+}
+
+func TestSafeTxMultiCallValue(t *testing.T) {
+	batch := testBatch(t)
+	batch.Transactions[1].Value = big.NewInt(100)
+	tx, err := batch.SafeTx(common.HexToAddress("0x5555"), 0)
+	require.NoError(t, err)
+	require.Equal(t, OperationDelegateCall, tx.Operation)
+	// the value is sent by the delegatecalled MultiSendCallOnly, not with the Safe transaction
+	require.Zero(t, tx.Value.Sign())
+
+	multiSendABI, err := abi.JSON(bytes.NewReader([]byte(`[{"type":"function","name":"multiSend","inputs":[{"name":"transactions","type":"bytes"}]}]`)))
+	require.NoError(t, err)
+	args, err := multiSendABI.Methods["multiSend"].Inputs.Unpack(tx.Data[4:])
+	require.NoError(t, err)
+	packed := args[0].([]byte)
+	second := packed[85+len(batch.Transactions[0].Data):]
+	require.Equal(t, big.NewInt(100), new(big.Int).SetBytes(second[21:53]))
+}
+
+// TestSafeTxHash checks the hash against the getTransactionHash function of the Safe contract
+func TestSafeTxHash(t *testing.T) {
+	safeAddr := common.HexToAddress("0x5555")
+	tx, err := testBatch(t).SafeTx(safeAddr, 42)
+	require.NoError(t, err)
+	require.Equal(t, MultiSendCallOnlyAddress, tx.To)
+	require.Equal(t, OperationDelegateCall, tx.Operation)
+	hash, err := tx.Hash()
+	require.NoError(t, err)
+
+	db, err := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	require.NoError(t, err)
+	db.SetCode(safeAddr, hexutil.MustDecode(bindings.SafeDeployedBin))
+	safeABI, err := bindings.SafeMetaData.GetAbi()
+	require.NoError(t, err)
+	input, err := safeABI.Pack("getTransactionHash", tx.To, tx.Value, []byte(tx.Data), uint8(tx.Operation), tx.SafeTxGas, tx.BaseGas, tx.GasPrice, tx.GasToken, tx.RefundReceiver, tx.Nonce)
+	require.NoError(t, err)
+	ret, _, err := runtime.Call(safeAddr, input, &runtime.Config{ChainConfig: params.MainnetChainConfig, State: db, BlockNumber: big.NewInt(20_000_000), Time: 1_700_000_000})
+	require.NoError(t, err)
+	require.Equal(t, common.BytesToHash(ret), hash)
+}
+
+func TestSafeTxSignatures(t *testing.T) {
+	tx, err := testBatch(t).SafeTx(common.HexToAddress("0x5555"), 0)
+	require.NoError(t, err)
+
+	owners := make([]common.Address, 3)
+	signers := make([]*LocalSigner, 3)
+	for i := range signers {
+		key, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		signers[i] = NewLocalSigner(key)
+		owners[i] = signers[i].Address()
+	}
+
+	require.NoError(t, tx.Sign(context.Background(), signers[0]))
+	require.ErrorContains(t, tx.Sign(context.Background(), signers[0]), "already signed")
+	require.ErrorContains(t, tx.VerifySignatures(owners, 2), "threshold")
+	require.NoError(t, tx.Sign(context.Background(), signers[1]))
+	require.NoError(t, tx.VerifySignatures(owners, 2))
+	require.ErrorContains(t, tx.VerifySignatures(owners[1:], 2), "not an owner")
+
+	// a signature by another key is rejected
+	hash, err := tx.Hash()
+	require.NoError(t, err)
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	sig, err := crypto.Sign(hash[:], key)
+	require.NoError(t, err)
+	require.ErrorContains(t, tx.AddSignature(owners[2], sig), "signature is by")
+
+	// signatures are kept sorted by signer, with a recovery id of 27 or 28
+	require.NoError(t, tx.Sign(context.Background(), signers[2]))
+	require.Len(t, tx.Signatures, 3)
+	for i := 1; i < len(tx.Signatures); i++ {
+		require.Negative(t, bytes.Compare(tx.Signatures[i-1].Signer[:], tx.Signatures[i].Signer[:]))
+	}
+	require.NoError(t, tx.VerifySignatures(owners, 3))
+	tx.Signatures = tx.Signatures[:2]
+	encoded := tx.EncodedSignatures()
+	require.Len(t, encoded, 2*crypto.SignatureLength)
+	first, second := tx.Signatures[0], tx.Signatures[1]
+	require.Equal(t, []byte(first.Signature), encoded[:65])
+	require.Equal(t, []byte(second.Signature), encoded[65:])
+	require.GreaterOrEqual(t, encoded[64], byte(27))
+
+	calldata, err := tx.ExecTransactionCalldata()
+	require.NoError(t, err)
+	safeABI, err := bindings.SafeMetaData.GetAbi()
+	require.NoError(t, err)
+	args, err := safeABI.Methods["execTransaction"].Inputs.Unpack(calldata[4:])
+	require.NoError(t, err)
+	require.Equal(t, tx.To, args[0])
+	require.Equal(t, encoded, args[9])
+
+	// like the Safe, verification requires strictly ascending signers, so duplicates are not counted twice
+	dup := *tx
+	dup.Signatures = []Signature{first, first}
+	require.ErrorContains(t, dup.VerifySignatures(owners, 2), "strictly ascending")
+	unsorted := *tx
+	unsorted.Signatures = []Signature{second, first}
+	require.ErrorContains(t, unsorted.VerifySignatures(owners, 2), "strictly ascending")
+}
@@ -0,0 +1,228 @@
+package safe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/ethereum-optimism/optimism/op-chain-ops/genesis"
+	"github.com/ethereum-optimism/optimism/op-chain-ops/state"
+)
+
+// l1BlockTime is the block time of the L1 chains, used to set the time of the simulated block
+const l1BlockTime = 12
+
+// SimulationBackend is the L1 chain that a batch is simulated against. It is satisfied by an ethclient.Client.
+type SimulationBackend interface {
+	state.ForkSource
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	ChainID(ctx context.Context) (*big.Int, error)
+}
+
+// SimulationResult is the outcome of executing a batch against a fork of the L1 chain.
+type SimulationResult struct {
+	// BlockNumber is the L1 block that the state was forked from. The batch is executed in the next block.
+	BlockNumber uint64         `json:"blockNumber"`
+	Sender      common.Address `json:"sender"`
+	// Success is true if all the calls succeeded. The batch is executed atomically by the Safe,
+	// so a single failing call reverts the whole batch on chain.
+	Success bool         `json:"success"`
+	Calls   []CallResult `json:"calls"`
+	// StateDiff holds the changes of all the calls, for every touched account
+	StateDiff []state.AccountDiff `json:"stateDiff"`
+}
+
+// CallResult is the outcome of a single call of a batch.
+type CallResult struct {
+	Index   int            `json:"index"`
+	To      common.Address `json:"to"`
+	Method  string         `json:"method"`
+	Success bool           `json:"success"`
+	// Error is the revert reason, or the execution error, of a failed call
+	Error                 string                 `json:"error,omitempty"`
+	GasUsed               uint64                 `json:"gasUsed"`
+	Events                []SimulatedEvent       `json:"events"`
+	ImplementationChanges []ImplementationChange `json:"implementationChanges"`
+	StateDiff             []state.AccountDiff    `json:"stateDiff"`
+}
+
+// SimulatedEvent is a log emitted by a call. The name and arguments are only set
+// if the event is found in the ABIs passed to the simulation.
+type SimulatedEvent struct {
+	Address common.Address    `json:"address"`
+	Name    string            `json:"name,omitempty"`
+	Args    map[string]string `json:"args,omitempty"`
+	Topics  []common.Hash     `json:"topics"`
+	Data    hexutil.Bytes     `json:"data"`
+}
+
+// ImplementationChange is a change of the EIP-1967 implementation slot of a proxy.
+type ImplementationChange struct {
+	Proxy  common.Address `json:"proxy"`
+	Before common.Address `json:"before"`
+	After  common.Address `json:"after"`
+}
+
+// Simulate executes the calls of the batch, in order, against an in-memory fork of the latest
+// L1 block. Each call is sent by the sender, which should be the Safe that will execute the batch.
+// The ABIs are used to decode the emitted events. No transactions are sent to the backend.
+func (b *Batch) Simulate(ctx context.Context, backend SimulationBackend, sender common.Address, abis ...*abi.ABI) (*SimulationResult, error) {
+	header, err := backend.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("cannot fetch L1 head: %w", err)
+	}
+	chainID, err := backend.ChainID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot fetch L1 chain ID: %w", err)
+	}
+	if b.ChainID != nil && b.ChainID.Cmp(chainID) != 0 {
+		return nil, fmt.Errorf("batch is for chain %d, but the backend is chain %d", b.ChainID, chainID)
+	}
+
+	db := state.NewForkStateDB(ctx, backend, header.Number)
+	chainConfig := l1ChainConfig(chainID)
+	random := header.MixDigest
+	blockCtx := vm.BlockContext{
+		CanTransfer: core.CanTransfer,
+		Transfer:    core.Transfer,
+		GetHash: func(n uint64) common.Hash {
+			h, err := backend.HeaderByNumber(ctx, new(big.Int).SetUint64(n))
+			if err != nil {
+				return common.Hash{}
+			}
+			return h.Hash()
+		},
+		Coinbase:    header.Coinbase,
+		GasLimit:    header.GasLimit,
+		BlockNumber: new(big.Int).Add(header.Number, common.Big1),
+		Time:        header.Time + l1BlockTime,
+		Difficulty:  common.Big0,
+		BaseFee:     header.BaseFee,
+		Random:      &random,
+	}
+	// The gas price is zero, so the base fee must not be enforced
+	evm := vm.NewEVM(blockCtx, vm.TxContext{Origin: sender, GasPrice: common.Big0}, db, chainConfig, vm.Config{NoBaseFee: true})
+	rules := chainConfig.Rules(blockCtx.BlockNumber, true, blockCtx.Time)
+
+	result := &SimulationResult{
+		BlockNumber: header.Number.Uint64(),
+		Sender:      sender,
+		Success:     true,
+	}
+	for i, tx := range b.Transactions {
+		data, err := tx.Calldata()
+		if err != nil {
+			return nil, fmt.Errorf("cannot encode call %d: %w", i, err)
+		}
+		value := tx.Value
+		if value == nil {
+			value = common.Big0
+		}
+
+		db.Prepare(rules, sender, blockCtx.Coinbase, &tx.To, vm.ActivePrecompiles(rules), nil)
+		ret, leftOverGas, err := evm.Call(vm.AccountRef(sender), tx.To, data, blockCtx.GasLimit, value)
+		if dbErr := db.Error(); dbErr != nil {
+			return nil, fmt.Errorf("cannot simulate call %d: %w", i, dbErr)
+		}
+
+		call := CallResult{
+			Index:   i,
+			To:      tx.To,
+			Method:  tx.Method.Name,
+			Success: err == nil,
+			GasUsed: blockCtx.GasLimit - leftOverGas,
+			Events:  decodeEvents(db.Logs(), abis),
+		}
+		if err != nil {
+			call.Error = err.Error()
+			if reason, unpackErr := abi.UnpackRevert(ret); errors.Is(err, vm.ErrExecutionReverted) && unpackErr == nil {
+				call.Error = fmt.Sprintf("%s: %s", err, reason)
+			}
+			result.Success = false
+		}
+		call.StateDiff = db.Finalise()
+		call.ImplementationChanges = implementationChanges(call.StateDiff)
+		result.Calls = append(result.Calls, call)
+	}
+	result.StateDiff = db.Diff()
+	return result, nil
+}
+
+// l1ChainConfig returns the config of the known L1 chains, and enables all forks for other chains, like devnets
+func l1ChainConfig(chainID *big.Int) *params.ChainConfig {
+	for _, config := range []*params.ChainConfig{params.MainnetChainConfig, params.GoerliChainConfig, params.SepoliaChainConfig} {
+		if config.ChainID.Cmp(chainID) == 0 {
+			return config
+		}
+	}
+	config := *params.AllDevChainProtocolChanges
+	config.ChainID = chainID
+	return &config
+}
+
+// decodeEvents decodes the logs with the first ABI that has a matching event
+func decodeEvents(logs []*types.Log, abis []*abi.ABI) []SimulatedEvent {
+	events := make([]SimulatedEvent, 0, len(logs))
+	for _, log := range logs {
+		event := SimulatedEvent{Address: log.Address, Topics: log.Topics, Data: log.Data}
+		if len(log.Topics) > 0 {
+			for _, iface := range abis {
+				ev, err := iface.EventByID(log.Topics[0])
+				if err != nil {
+					continue
+				}
+				args := make(map[string]any)
+				if err := ev.Inputs.UnpackIntoMap(args, log.Data); err != nil {
+					continue
+				}
+				var indexed abi.Arguments
+				for _, input := range ev.Inputs {
+					if input.Indexed {
+						indexed = append(indexed, input)
+					}
+				}
+				if err := abi.ParseTopicsIntoMap(args, indexed, log.Topics[1:]); err != nil {
+					continue
+				}
+				event.Name = ev.Name
+				event.Args = make(map[string]string, len(args))
+				for name, arg := range args {
+					str, err := stringifyArg(arg)
+					if err != nil {
+						str = fmt.Sprintf("%v", arg)
+					}
+					event.Args[name] = str
+				}
+				break
+			}
+		}
+		events = append(events, event)
+	}
+	return events
+}
+
+// implementationChanges returns the proxies of which the implementation changed
+func implementationChanges(diffs []state.AccountDiff) []ImplementationChange {
+	changes := make([]ImplementationChange, 0)
+	for _, diff := range diffs {
+		for _, slot := range diff.Storage {
+			if slot.Key == genesis.ImplementationSlot {
+				changes = append(changes, ImplementationChange{
+					Proxy:  diff.Address,
+					Before: common.BytesToAddress(slot.Before[:]),
+					After:  common.BytesToAddress(slot.After[:]),
+				})
+			}
+		}
+	}
+	return changes
+}
@@ -0,0 +1,100 @@
+package safe
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/ethereum-optimism/optimism/op-chain-ops/genesis"
+
+	"github.com/stretchr/testify/require"
+)
+
+const proxyABI = `[
+	{"type":"function","name":"upgradeTo","inputs":[{"name":"_implementation","type":"address"}],"outputs":[],"stateMutability":"nonpayable"},
+	{"type":"event","name":"Upgraded","inputs":[{"name":"implementation","type":"address","indexed":true}],"anonymous":false}
+]`
+
+// fakeL1 serves the code of a few contracts, and empty state otherwise
+type fakeL1 struct {
+	code map[common.Address][]byte
+}
+
+func (f *fakeL1) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	return new(big.Int), nil
+}
+
+func (f *fakeL1) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	return 0, nil
+}
+
+func (f *fakeL1) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	return f.code[account], nil
+}
+
+func (f *fakeL1) StorageAt(ctx context.Context, account common.Address, key common.Hash, blockNumber *big.Int) ([]byte, error) {
+	return make([]byte, 32), nil
+}
+
+func (f *fakeL1) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return &types.Header{Number: big.NewInt(100), GasLimit: 30_000_000, Time: 1_700_000_000, BaseFee: big.NewInt(1), Difficulty: common.Big0}, nil
+}
+
+func (f *fakeL1) ChainID(ctx context.Context) (*big.Int, error) {
+	return big.NewInt(900), nil
+}
+
+func TestSimulate(t *testing.T) {
+	iface, err := abi.JSON(strings.NewReader(proxyABI))
+	require.NoError(t, err)
+
+	// upgradeTo stores the implementation in the EIP-1967 slot, and emits Upgraded(implementation)
+	proxyCode := []byte{0x60, 0x04, 0x35, 0x80, 0x7f}
+	proxyCode = append(proxyCode, genesis.ImplementationSlot[:]...)
+	proxyCode = append(proxyCode, 0x55, 0x7f)
+	proxyCode = append(proxyCode, crypto.Keccak256([]byte("Upgraded(address)"))...)
+	proxyCode = append(proxyCode, 0x60, 0x00, 0x60, 0x00, 0xa2, 0x00)
+
+	proxy := common.Address{18: 0x10, 19: 0x01}
+	reverter := common.Address{18: 0x10, 19: 0x02}
+	impl := common.Address{19: 0xaa}
+	backend := &fakeL1{code: map[common.Address][]byte{
+		proxy:    proxyCode,
+		reverter: {0x60, 0x00, 0x60, 0x00, 0xfd},
+	}}
+
+	batch := &Batch{SkipCalldata: true}
+	require.NoError(t, batch.AddCall(proxy, common.Big0, "upgradeTo", []any{impl}, &iface))
+	require.NoError(t, batch.AddCall(reverter, common.Big0, "upgradeTo", []any{impl}, &iface))
+
+	sender := common.Address{19: 0x0f}
+	result, err := batch.Simulate(context.Background(), backend, sender, &iface)
+	require.NoError(t, err)
+	require.Equal(t, uint64(100), result.BlockNumber)
+	require.False(t, result.Success)
+	require.Len(t, result.Calls, 2)
+
+	upgrade := result.Calls[0]
+	require.True(t, upgrade.Success)
+	require.NotZero(t, upgrade.GasUsed)
+	require.Len(t, upgrade.Events, 1)
+	require.Equal(t, "Upgraded", upgrade.Events[0].Name)
+	require.Equal(t, impl.String(), upgrade.Events[0].Args["implementation"])
+	require.Equal(t, []ImplementationChange{{Proxy: proxy, After: impl}}, upgrade.ImplementationChanges)
+
+	failed := result.Calls[1]
+	require.False(t, failed.Success)
+	require.Contains(t, failed.Error, "execution reverted")
+	require.Empty(t, failed.Events)
+	require.Empty(t, failed.StateDiff)
+
+	require.Len(t, result.StateDiff, 1)
+	require.Equal(t, proxy, result.StateDiff[0].Address)
+	require.Equal(t, genesis.ImplementationSlot, result.StateDiff[0].Storage[0].Key)
+}
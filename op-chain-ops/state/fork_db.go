@@ -0,0 +1,525 @@
+package state
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+var _ vm.StateDB = (*ForkStateDB)(nil)
+
+// ForkSource is the remote state that a ForkStateDB is forked from.
+// It is satisfied by an ethclient.Client.
+type ForkSource interface {
+	BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error)
+	NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error)
+	CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error)
+	StorageAt(ctx context.Context, account common.Address, key common.Hash, blockNumber *big.Int) ([]byte, error)
+}
+
+// accountState is a layer of the state of an account. The storage only holds
+// the slots that were read or written in the layer. If reset is set, the slots of
+// the layers below are ignored, e.g. because the account was created in the layer.
+type accountState struct {
+	exists  bool
+	balance *big.Int
+	nonce   uint64
+	code    []byte
+	storage map[common.Hash]common.Hash
+	reset   bool
+}
+
+func (s *accountState) copy() *accountState {
+	return &accountState{
+		exists:  s.exists,
+		balance: new(big.Int).Set(s.balance),
+		nonce:   s.nonce,
+		code:    s.code,
+		storage: make(map[common.Hash]common.Hash),
+	}
+}
+
+const (
+	layerOrigin = iota
+	layerCommitted
+	layerCurrent
+)
+
+// forkAccount holds the state of an account at the fork block, at the start of the
+// current transaction, and as modified by the current transaction.
+type forkAccount struct {
+	layers [3]*accountState
+}
+
+// ForkStateDB implements geth's StateDB interface on top of the state of a remote
+// chain at a fixed block. Accounts and storage slots are fetched from the remote chain
+// on first access, and all modifications are kept in memory, so that transactions can be
+// simulated against the remote state without sending them.
+//
+// The StateDB interface cannot return errors, so errors of the remote chain are recorded
+// and must be checked with Error after execution. The ForkStateDB is not safe for concurrent use.
+type ForkStateDB struct {
+	ctx         context.Context
+	source      ForkSource
+	blockNumber *big.Int
+
+	accounts map[common.Address]*forkAccount
+	err      error
+
+	journal        []func()
+	refund         uint64
+	logs           []*types.Log
+	preimages      map[common.Hash][]byte
+	selfDestructed map[common.Address]bool
+
+	accessAddresses map[common.Address]bool
+	accessSlots     map[common.Address]map[common.Hash]bool
+	transient       map[common.Address]map[common.Hash]common.Hash
+}
+
+func NewForkStateDB(ctx context.Context, source ForkSource, blockNumber *big.Int) *ForkStateDB {
+	return &ForkStateDB{
+		ctx:             ctx,
+		source:          source,
+		blockNumber:     blockNumber,
+		accounts:        make(map[common.Address]*forkAccount),
+		preimages:       make(map[common.Hash][]byte),
+		selfDestructed:  make(map[common.Address]bool),
+		accessAddresses: make(map[common.Address]bool),
+		accessSlots:     make(map[common.Address]map[common.Hash]bool),
+		transient:       make(map[common.Address]map[common.Hash]common.Hash),
+	}
+}
+
+// Error returns the first error of the remote chain that was encountered, if any.
+// Execution results are invalid if this is not nil.
+func (db *ForkStateDB) Error() error {
+	return db.err
+}
+
+func (db *ForkStateDB) setError(err error) {
+	if db.err == nil {
+		db.err = err
+	}
+}
+
+// account returns the account, fetching it from the remote chain on first access
+func (db *ForkStateDB) account(addr common.Address) *forkAccount {
+	if acc, ok := db.accounts[addr]; ok {
+		return acc
+	}
+	origin := &accountState{balance: new(big.Int), storage: make(map[common.Hash]common.Hash)}
+	balance, err := db.source.BalanceAt(db.ctx, addr, db.blockNumber)
+	if err != nil {
+		db.setError(fmt.Errorf("cannot fetch balance of %s: %w", addr, err))
+	} else {
+		origin.balance = balance
+	}
+	if origin.nonce, err = db.source.NonceAt(db.ctx, addr, db.blockNumber); err != nil {
+		db.setError(fmt.Errorf("cannot fetch nonce of %s: %w", addr, err))
+	}
+	if origin.code, err = db.source.CodeAt(db.ctx, addr, db.blockNumber); err != nil {
+		db.setError(fmt.Errorf("cannot fetch code of %s: %w", addr, err))
+	}
+	origin.exists = origin.nonce != 0 || origin.balance.Sign() != 0 || len(origin.code) != 0
+
+	acc := &forkAccount{}
+	acc.layers[layerOrigin] = origin
+	acc.layers[layerCommitted] = origin.copy()
+	acc.layers[layerCurrent] = origin.copy()
+	db.accounts[addr] = acc
+	return acc
+}
+
+func (db *ForkStateDB) current(addr common.Address) *accountState {
+	return db.account(addr).layers[layerCurrent]
+}
+
+// storageAt returns the value of a storage slot as of the given layer
+func (db *ForkStateDB) storageAt(addr common.Address, layer int, key common.Hash) common.Hash {
+	acc := db.account(addr)
+	for l := layer; l > layerOrigin; l-- {
+		if value, ok := acc.layers[l].storage[key]; ok {
+			return value
+		}
+		if acc.layers[l].reset {
+			return common.Hash{}
+		}
+	}
+	origin := acc.layers[layerOrigin]
+	if value, ok := origin.storage[key]; ok {
+		return value
+	}
+	value, err := db.source.StorageAt(db.ctx, addr, key, db.blockNumber)
+	if err != nil {
+		db.setError(fmt.Errorf("cannot fetch storage slot %s of %s: %w", key, addr, err))
+		return common.Hash{}
+	}
+	origin.storage[key] = common.BytesToHash(value)
+	return origin.storage[key]
+}
+
+// StateDB interface implemented below
+
+func (db *ForkStateDB) CreateAccount(addr common.Address) {
+	acc := db.account(addr)
+	prev := acc.layers[layerCurrent]
+	db.journal = append(db.journal, func() { acc.layers[layerCurrent] = prev })
+	// the balance is carried over, like in geth, in case ether was sent to the address before its creation
+	acc.layers[layerCurrent] = &accountState{
+		exists:  true,
+		balance: new(big.Int).Set(prev.balance),
+		storage: make(map[common.Hash]common.Hash),
+		reset:   true,
+	}
+}
+
+func (db *ForkStateDB) SubBalance(addr common.Address, amount *big.Int) {
+	db.setBalance(addr, new(big.Int).Sub(db.GetBalance(addr), amount))
+}
+
+func (db *ForkStateDB) AddBalance(addr common.Address, amount *big.Int) {
+	db.setBalance(addr, new(big.Int).Add(db.GetBalance(addr), amount))
+}
+
+func (db *ForkStateDB) setBalance(addr common.Address, balance *big.Int) {
+	acc := db.current(addr)
+	prev, prevExists := acc.balance, acc.exists
+	db.journal = append(db.journal, func() { acc.balance, acc.exists = prev, prevExists })
+	acc.balance, acc.exists = balance, true
+}
+
+func (db *ForkStateDB) GetBalance(addr common.Address) *big.Int {
+	return new(big.Int).Set(db.current(addr).balance)
+}
+
+func (db *ForkStateDB) GetNonce(addr common.Address) uint64 {
+	return db.current(addr).nonce
+}
+
+func (db *ForkStateDB) SetNonce(addr common.Address, value uint64) {
+	acc := db.current(addr)
+	prev, prevExists := acc.nonce, acc.exists
+	db.journal = append(db.journal, func() { acc.nonce, acc.exists = prev, prevExists })
+	acc.nonce, acc.exists = value, true
+}
+
+func (db *ForkStateDB) GetCodeHash(addr common.Address) common.Hash {
+	acc := db.current(addr)
+	if !acc.exists {
+		return common.Hash{}
+	}
+	return crypto.Keccak256Hash(acc.code)
+}
+
+func (db *ForkStateDB) GetCode(addr common.Address) []byte {
+	return db.current(addr).code
+}
+
+func (db *ForkStateDB) SetCode(addr common.Address, code []byte) {
+	acc := db.current(addr)
+	prev, prevExists := acc.code, acc.exists
+	db.journal = append(db.journal, func() { acc.code, acc.exists = prev, prevExists })
+	acc.code, acc.exists = code, true
+}
+
+func (db *ForkStateDB) GetCodeSize(addr common.Address) int {
+	return len(db.current(addr).code)
+}
+
+func (db *ForkStateDB) AddRefund(gas uint64) {
+	prev := db.refund
+	db.journal = append(db.journal, func() { db.refund = prev })
+	db.refund += gas
+}
+
+func (db *ForkStateDB) SubRefund(gas uint64) {
+	prev := db.refund
+	db.journal = append(db.journal, func() { db.refund = prev })
+	if gas > db.refund {
+		panic(fmt.Sprintf("refund counter below zero (gas: %d > refund: %d)", gas, db.refund))
+	}
+	db.refund -= gas
+}
+
+func (db *ForkStateDB) GetRefund() uint64 {
+	return db.refund
+}
+
+func (db *ForkStateDB) GetCommittedState(addr common.Address, key common.Hash) common.Hash {
+	return db.storageAt(addr, layerCommitted, key)
+}
+
+func (db *ForkStateDB) GetState(addr common.Address, key common.Hash) common.Hash {
+	return db.storageAt(addr, layerCurrent, key)
+}
+
+func (db *ForkStateDB) SetState(addr common.Address, key, value common.Hash) {
+	acc := db.current(addr)
+	prev, prevOk := acc.storage[key]
+	prevExists := acc.exists
+	db.journal = append(db.journal, func() {
+		if prevOk {
+			acc.storage[key] = prev
+		} else {
+			delete(acc.storage, key)
+		}
+		acc.exists = prevExists
+	})
+	acc.storage[key] = value
+	acc.exists = true
+}
+
+func (db *ForkStateDB) GetTransientState(addr common.Address, key common.Hash) common.Hash {
+	return db.transient[addr][key]
+}
+
+func (db *ForkStateDB) SetTransientState(addr common.Address, key, value common.Hash) {
+	if _, ok := db.transient[addr]; !ok {
+		db.transient[addr] = make(map[common.Hash]common.Hash)
+	}
+	slots := db.transient[addr]
+	prev := slots[key]
+	db.journal = append(db.journal, func() { slots[key] = prev })
+	slots[key] = value
+}
+
+func (db *ForkStateDB) SelfDestruct(addr common.Address) {
+	acc := db.account(addr)
+	prev := acc.layers[layerCurrent]
+	db.journal = append(db.journal, func() { acc.layers[layerCurrent] = prev })
+	// the account is kept until the end of the transaction, but its balance is burned immediately
+	acc.layers[layerCurrent] = &accountState{
+		exists:  prev.exists,
+		balance: new(big.Int),
+		nonce:   prev.nonce,
+		code:    prev.code,
+		storage: prev.storage,
+		reset:   prev.reset,
+	}
+	db.markSelfDestructed(addr)
+}
+
+func (db *ForkStateDB) markSelfDestructed(addr common.Address) {
+	if db.selfDestructed[addr] {
+		return
+	}
+	db.journal = append(db.journal, func() { delete(db.selfDestructed, addr) })
+	db.selfDestructed[addr] = true
+}
+
+func (db *ForkStateDB) HasSelfDestructed(addr common.Address) bool {
+	return db.selfDestructed[addr]
+}
+
+// Selfdestruct6780 only self-destructs accounts that were created in the current transaction, see EIP-6780
+func (db *ForkStateDB) Selfdestruct6780(addr common.Address) {
+	acc := db.account(addr)
+	if acc.layers[layerCurrent].reset && !acc.layers[layerCommitted].exists {
+		db.SelfDestruct(addr)
+	}
+}
+
+// Exist reports whether the given account exists in state.
+// Notably this should also return true for self-destructed accounts.
+func (db *ForkStateDB) Exist(addr common.Address) bool {
+	return db.current(addr).exists
+}
+
+// Empty returns whether the given account is empty. Empty
+// is defined according to EIP161 (balance = nonce = code = 0).
+func (db *ForkStateDB) Empty(addr common.Address) bool {
+	acc := db.current(addr)
+	return !acc.exists || (acc.nonce == 0 && acc.balance.Sign() == 0 && len(acc.code) == 0)
+}
+
+func (db *ForkStateDB) AddressInAccessList(addr common.Address) bool {
+	return db.accessAddresses[addr]
+}
+
+func (db *ForkStateDB) SlotInAccessList(addr common.Address, slot common.Hash) (addressOk bool, slotOk bool) {
+	return db.accessAddresses[addr], db.accessSlots[addr][slot]
+}
+
+// AddAddressToAccessList adds the given address to the access list. This operation is safe to perform
+// even if the feature/fork is not active yet
+func (db *ForkStateDB) AddAddressToAccessList(addr common.Address) {
+	if db.accessAddresses[addr] {
+		return
+	}
+	db.journal = append(db.journal, func() { delete(db.accessAddresses, addr) })
+	db.accessAddresses[addr] = true
+}
+
+// AddSlotToAccessList adds the given (address,slot) to the access list. This operation is safe to perform
+// even if the feature/fork is not active yet
+func (db *ForkStateDB) AddSlotToAccessList(addr common.Address, slot common.Hash) {
+	db.AddAddressToAccessList(addr)
+	if db.accessSlots[addr][slot] {
+		return
+	}
+	if _, ok := db.accessSlots[addr]; !ok {
+		db.accessSlots[addr] = make(map[common.Hash]bool)
+	}
+	slots := db.accessSlots[addr]
+	db.journal = append(db.journal, func() { delete(slots, slot) })
+	slots[slot] = true
+}
+
+func (db *ForkStateDB) Prepare(rules params.Rules, sender, coinbase common.Address, dest *common.Address, precompiles []common.Address, txAccesses types.AccessList) {
+	db.accessAddresses = make(map[common.Address]bool)
+	db.accessSlots = make(map[common.Address]map[common.Hash]bool)
+	db.transient = make(map[common.Address]map[common.Hash]common.Hash)
+	if !rules.IsBerlin {
+		return
+	}
+	db.AddAddressToAccessList(sender)
+	if dest != nil {
+		db.AddAddressToAccessList(*dest)
+	}
+	for _, addr := range precompiles {
+		db.AddAddressToAccessList(addr)
+	}
+	for _, el := range txAccesses {
+		db.AddAddressToAccessList(el.Address)
+		for _, key := range el.StorageKeys {
+			db.AddSlotToAccessList(el.Address, key)
+		}
+	}
+	if rules.IsShanghai {
+		db.AddAddressToAccessList(coinbase)
+	}
+}
+
+func (db *ForkStateDB) RevertToSnapshot(id int) {
+	if id < 0 || id > len(db.journal) {
+		panic(fmt.Sprintf("revision id %d cannot be reverted", id))
+	}
+	for i := len(db.journal) - 1; i >= id; i-- {
+		db.journal[i]()
+	}
+	db.journal = db.journal[:id]
+}
+
+// Snapshot returns the length of the journal, which is the revision to revert to
+func (db *ForkStateDB) Snapshot() int {
+	return len(db.journal)
+}
+
+func (db *ForkStateDB) AddLog(log *types.Log) {
+	log.Index = uint(len(db.logs))
+	db.journal = append(db.journal, func() { db.logs = db.logs[:len(db.logs)-1] })
+	db.logs = append(db.logs, log)
+}
+
+// Logs returns the logs emitted since the last call to Finalise
+func (db *ForkStateDB) Logs() []*types.Log {
+	return db.logs
+}
+
+func (db *ForkStateDB) AddPreimage(hash common.Hash, preimage []byte) {
+	if _, ok := db.preimages[hash]; !ok {
+		db.preimages[hash] = common.CopyBytes(preimage)
+	}
+}
+
+// StorageDiff is a storage slot that was changed
+type StorageDiff struct {
+	Key    common.Hash `json:"key"`
+	Before common.Hash `json:"before"`
+	After  common.Hash `json:"after"`
+}
+
+// AccountDiff holds the changes of an account. The balance and nonce fields are only set if they changed.
+type AccountDiff struct {
+	Address       common.Address `json:"address"`
+	BalanceBefore *big.Int       `json:"balanceBefore,omitempty"`
+	BalanceAfter  *big.Int       `json:"balanceAfter,omitempty"`
+	NonceBefore   *uint64        `json:"nonceBefore,omitempty"`
+	NonceAfter    *uint64        `json:"nonceAfter,omitempty"`
+	CodeChanged   bool           `json:"codeChanged,omitempty"`
+	Storage       []StorageDiff  `json:"storage,omitempty"`
+}
+
+// diff returns the changes between two layers of the accounts, sorted by address and slot
+func (db *ForkStateDB) diff(before, after int) []AccountDiff {
+	addrs := make([]common.Address, 0, len(db.accounts))
+	for addr := range db.accounts {
+		addrs = append(addrs, addr)
+	}
+	sort.Slice(addrs, func(i, j int) bool { return bytes.Compare(addrs[i][:], addrs[j][:]) < 0 })
+
+	var diffs []AccountDiff
+	for _, addr := range addrs {
+		acc := db.accounts[addr]
+		a, b := acc.layers[before], acc.layers[after]
+		d := AccountDiff{Address: addr, CodeChanged: !bytes.Equal(a.code, b.code)}
+		if a.balance.Cmp(b.balance) != 0 {
+			d.BalanceBefore, d.BalanceAfter = new(big.Int).Set(a.balance), new(big.Int).Set(b.balance)
+		}
+		if a.nonce != b.nonce {
+			nonceBefore, nonceAfter := a.nonce, b.nonce
+			d.NonceBefore, d.NonceAfter = &nonceBefore, &nonceAfter
+		}
+		keys := make(map[common.Hash]bool)
+		for l := after; l > before; l-- {
+			for key := range acc.layers[l].storage {
+				keys[key] = true
+			}
+		}
+		for key := range keys {
+			valueBefore, valueAfter := db.storageAt(addr, before, key), db.storageAt(addr, after, key)
+			if valueBefore != valueAfter {
+				d.Storage = append(d.Storage, StorageDiff{Key: key, Before: valueBefore, After: valueAfter})
+			}
+		}
+		sort.Slice(d.Storage, func(i, j int) bool { return bytes.Compare(d.Storage[i].Key[:], d.Storage[j].Key[:]) < 0 })
+		if d.BalanceBefore != nil || d.NonceBefore != nil || d.CodeChanged || len(d.Storage) > 0 {
+			diffs = append(diffs, d)
+		}
+	}
+	return diffs
+}
+
+// Finalise ends the current transaction: it removes the self-destructed accounts, commits the changes
+// of the transaction, and clears its logs and refund. It returns the changes of the transaction.
+func (db *ForkStateDB) Finalise() []AccountDiff {
+	for addr := range db.selfDestructed {
+		db.accounts[addr].layers[layerCurrent] = &accountState{balance: new(big.Int), storage: make(map[common.Hash]common.Hash), reset: true}
+	}
+	diffs := db.diff(layerCommitted, layerCurrent)
+	for _, acc := range db.accounts {
+		committed, current := acc.layers[layerCommitted], acc.layers[layerCurrent]
+		next := current.copy()
+		next.reset = committed.reset || current.reset
+		if !current.reset {
+			for key, value := range committed.storage {
+				next.storage[key] = value
+			}
+		}
+		for key, value := range current.storage {
+			next.storage[key] = value
+		}
+		acc.layers[layerCommitted] = next
+		acc.layers[layerCurrent] = next.copy()
+	}
+	db.journal = nil
+	db.refund = 0
+	db.logs = nil
+	db.selfDestructed = make(map[common.Address]bool)
+	db.transient = make(map[common.Address]map[common.Hash]common.Hash)
+	return diffs
+}
+
+// Diff returns the changes of all the finalised transactions since the fork block
+func (db *ForkStateDB) Diff() []AccountDiff {
+	return db.diff(layerOrigin, layerCommitted)
+}
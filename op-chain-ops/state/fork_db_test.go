@@ -0,0 +1,107 @@
+package state_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-chain-ops/state"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeForkSource struct {
+	balances map[common.Address]*big.Int
+	code     map[common.Address][]byte
+	storage  map[common.Address]map[common.Hash]common.Hash
+	fetches  int
+	err      error
+}
+
+func (f *fakeForkSource) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	f.fetches++
+	if b, ok := f.balances[account]; ok {
+		return b, f.err
+	}
+	return new(big.Int), f.err
+}
+
+func (f *fakeForkSource) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	return 0, f.err
+}
+
+func (f *fakeForkSource) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	return f.code[account], f.err
+}
+
+func (f *fakeForkSource) StorageAt(ctx context.Context, account common.Address, key common.Hash, blockNumber *big.Int) ([]byte, error) {
+	f.fetches++
+	value := f.storage[account][key]
+	return value[:], f.err
+}
+
+func TestForkStateDB(t *testing.T) {
+	t.Parallel()
+
+	contract := common.HexToAddress("0x1234")
+	user := common.HexToAddress("0x5678")
+	slot := common.Hash{31: 1}
+	source := &fakeForkSource{
+		balances: map[common.Address]*big.Int{user: big.NewInt(100)},
+		code:     map[common.Address][]byte{contract: {0x60, 0x00}},
+		storage:  map[common.Address]map[common.Hash]common.Hash{contract: {slot: {31: 7}}},
+	}
+	db := state.NewForkStateDB(context.Background(), source, big.NewInt(1))
+
+	// remote state is fetched once
+	require.True(t, db.Exist(contract))
+	require.False(t, db.Exist(common.HexToAddress("0x9999")))
+	require.Equal(t, common.Hash{31: 7}, db.GetState(contract, slot))
+	require.Equal(t, common.Hash{31: 7}, db.GetState(contract, slot))
+	require.Equal(t, []byte{0x60, 0x00}, db.GetCode(contract))
+	require.Equal(t, 3, source.fetches)
+
+	// reverted changes are discarded
+	snap := db.Snapshot()
+	db.SetState(contract, slot, common.Hash{31: 8})
+	db.SubBalance(user, big.NewInt(40))
+	db.AddLog(&types.Log{Address: contract})
+	require.Equal(t, common.Hash{31: 8}, db.GetState(contract, slot))
+	require.Equal(t, common.Hash{31: 7}, db.GetCommittedState(contract, slot))
+	db.RevertToSnapshot(snap)
+	require.Equal(t, common.Hash{31: 7}, db.GetState(contract, slot))
+	require.Equal(t, big.NewInt(100), db.GetBalance(user))
+	require.Empty(t, db.Logs())
+
+	// finalised changes are committed, and reported per transaction and since the fork
+	db.SetState(contract, slot, common.Hash{31: 8})
+	diffs := db.Finalise()
+	require.Len(t, diffs, 1)
+	require.Equal(t, contract, diffs[0].Address)
+	require.Equal(t, []state.StorageDiff{{Key: slot, Before: common.Hash{31: 7}, After: common.Hash{31: 8}}}, diffs[0].Storage)
+	require.Equal(t, common.Hash{31: 8}, db.GetCommittedState(contract, slot))
+
+	db.SetState(contract, slot, common.Hash{31: 9})
+	db.SubBalance(user, big.NewInt(40))
+	diffs = db.Finalise()
+	require.Len(t, diffs, 2)
+	require.Equal(t, common.Hash{31: 8}, diffs[0].Storage[0].Before)
+	require.Equal(t, big.NewInt(60), diffs[1].BalanceAfter)
+
+	diffs = db.Diff()
+	require.Len(t, diffs, 2)
+	require.Equal(t, []state.StorageDiff{{Key: slot, Before: common.Hash{31: 7}, After: common.Hash{31: 9}}}, diffs[0].Storage)
+	require.Equal(t, big.NewInt(100), diffs[1].BalanceBefore)
+	require.NoError(t, db.Error())
+}
+
+func TestForkStateDBError(t *testing.T) {
+	t.Parallel()
+
+	source := &fakeForkSource{err: errors.New("boom")}
+	db := state.NewForkStateDB(context.Background(), source, big.NewInt(1))
+	require.Equal(t, common.Hash{}, db.GetState(common.HexToAddress("0x1234"), common.Hash{}))
+	require.ErrorIs(t, db.Error(), source.err)
+}
@@ -0,0 +1,198 @@
+package verifier
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-bindings/predeploys"
+	"github.com/ethereum-optimism/optimism/op-bindings/solc"
+	"github.com/ethereum-optimism/optimism/op-chain-ops/genesis"
+)
+
+// codeNamespace is the namespace of the implementations of the predeploys
+var codeNamespace = common.HexToAddress("0xc0D3C0d3C0d3C0D3c0d3C0d3c0D3C0d3c0d30000")
+
+// DefaultIgnore is the set of storage variables that are set in the genesis,
+// but are expected to change as the chain progresses.
+var DefaultIgnore = []string{
+	"L1Block.number",
+	"L1Block.timestamp",
+	"L1Block.basefee",
+	"L1Block.hash",
+	"L1Block.sequenceNumber",
+	"L1Block.batcherHash",
+	"L1Block.l1FeeOverhead",
+	"L1Block.l1FeeScalar",
+	"L2ToL1MessagePasser.msgNonce",
+	"L2CrossDomainMessenger.msgNonce",
+}
+
+// Expectation is the expected code and storage of a single account.
+type Expectation struct {
+	// Name is a human readable name of the account
+	Name string
+	// Contract is the name of the contract whose storage layout the
+	// account uses. It is empty if the layout is unknown.
+	Contract string
+	Address  common.Address
+	CodeHash common.Hash
+	Storage  map[common.Hash]common.Hash
+
+	// ignored holds a mask of the bits of a storage slot that are not compared
+	ignored map[common.Hash]common.Hash
+}
+
+// Expectations is the expected state of all accounts that are checked.
+type Expectations []*Expectation
+
+// NewExpectations builds the L2 genesis of the deploy config, and returns the expected
+// state of every account in the predeploy and the implementation namespaces. The code
+// hash covers the immutables, and the storage covers the proxy admin, the proxy
+// implementation and every storage variable that is set in the genesis.
+func NewExpectations(config *genesis.DeployConfig, l1StartBlock *types.Block) (Expectations, error) {
+	gen, err := genesis.BuildL2Genesis(config, l1StartBlock)
+	if err != nil {
+		return nil, fmt.Errorf("cannot build L2 genesis: %w", err)
+	}
+
+	// name the predeploys and their implementations, the other proxies are unnamed
+	names := make(map[common.Address]string)
+	for name, addr := range predeploys.Predeploys {
+		if *addr == predeploys.GovernanceTokenAddr && !config.EnableGovernance {
+			continue
+		}
+		names[*addr] = name
+		if predeploys.IsProxied(*addr) {
+			impl, err := genesis.AddressToCodeNamespace(*addr)
+			if err != nil {
+				return nil, err
+			}
+			names[impl] = name
+		}
+	}
+
+	var expectations Expectations
+	for addr, account := range gen.Alloc {
+		if !genesis.IsL2DevPredeploy(addr) && !bytes.Equal(addr[:18], codeNamespace[:18]) {
+			continue
+		}
+		e := &Expectation{
+			Name:     "Proxy",
+			Contract: names[addr],
+			Address:  addr,
+			CodeHash: crypto.Keccak256Hash(account.Code),
+			Storage:  make(map[common.Hash]common.Hash, len(account.Storage)),
+			ignored:  make(map[common.Hash]common.Hash),
+		}
+		switch {
+		case e.Contract != "" && genesis.IsL2DevPredeploy(addr):
+			e.Name = e.Contract
+		case e.Contract != "":
+			e.Name = e.Contract + " implementation"
+		case !genesis.IsL2DevPredeploy(addr):
+			e.Name = "Implementation"
+		}
+		for key, value := range account.Storage {
+			e.Storage[key] = value
+		}
+		expectations = append(expectations, e)
+	}
+	sort.Slice(expectations, func(i, j int) bool {
+		return expectations[i].Address.Cmp(expectations[j].Address) < 0
+	})
+	return expectations, nil
+}
+
+// Ignore excludes storage variables from the comparison. Each variable is given as
+// Contract.variable, e.g. L1Block.number. Variables that share a slot with other
+// variables are masked, so that the rest of the slot is still compared.
+func (es Expectations) Ignore(variables ...string) error {
+	for _, variable := range variables {
+		contract, label, ok := strings.Cut(variable, ".")
+		if !ok {
+			return fmt.Errorf("invalid variable %q, expected Contract.variable", variable)
+		}
+		layout, err := bindings.GetStorageLayout(contract)
+		if err != nil {
+			return fmt.Errorf("cannot ignore %s: %w", variable, err)
+		}
+		entry, err := layout.GetStorageLayoutEntry(label)
+		if err != nil {
+			return fmt.Errorf("cannot ignore %s: %w", variable, err)
+		}
+		slot, mask := variableMask(layout, entry)
+		for _, e := range es {
+			if e.Contract != contract {
+				continue
+			}
+			e.ignored[slot] = orHash(e.ignored[slot], mask)
+		}
+	}
+	return nil
+}
+
+// variableMask returns the slot of a storage variable, and the mask of its bits in the slot.
+// Variables that are not stored in place, or fill the slot, mask the complete slot.
+func variableMask(layout *solc.StorageLayout, entry solc.StorageLayoutEntry) (common.Hash, common.Hash) {
+	slot := common.BigToHash(new(big.Int).SetUint64(uint64(entry.Slot)))
+	var mask common.Hash
+	typ, err := layout.GetStorageLayoutType(entry.Type)
+	if err != nil || typ.Encoding != "inplace" || entry.Offset+typ.NumberOfBytes > 32 {
+		for i := range mask {
+			mask[i] = 0xff
+		}
+		return slot, mask
+	}
+	// the offset is counted in bytes from the right of the slot
+	for i := entry.Offset; i < entry.Offset+typ.NumberOfBytes; i++ {
+		mask[31-i] = 0xff
+	}
+	return slot, mask
+}
+
+func orHash(a, b common.Hash) common.Hash {
+	for i := range a {
+		a[i] |= b[i]
+	}
+	return a
+}
+
+// Label returns the names of the storage variables that are stored in the slot.
+func (e *Expectation) Label(slot common.Hash) string {
+	switch slot {
+	case genesis.AdminSlot:
+		return "EIP-1967 admin"
+	case genesis.ImplementationSlot:
+		return "EIP-1967 implementation"
+	}
+	if e.Contract == "" {
+		return ""
+	}
+	layout, err := bindings.GetStorageLayout(e.Contract)
+	if err != nil {
+		return ""
+	}
+	index := new(big.Int).SetBytes(slot[:])
+	if !index.IsUint64() {
+		return ""
+	}
+	var labels []string
+	for _, entry := range layout.Storage {
+		size := uint64(1)
+		if typ, err := layout.GetStorageLayoutType(entry.Type); err == nil && typ.Encoding == "inplace" {
+			size = (uint64(typ.NumberOfBytes) + 31) / 32
+		}
+		if index.Uint64() >= uint64(entry.Slot) && index.Uint64() < uint64(entry.Slot)+size {
+			labels = append(labels, entry.Label)
+		}
+	}
+	return strings.Join(labels, ",")
+}
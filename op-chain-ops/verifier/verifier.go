@@ -0,0 +1,175 @@
+package verifier
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// StateReader reads the state that is verified.
+type StateReader interface {
+	CodeAt(ctx context.Context, addr common.Address) ([]byte, error)
+	StorageAt(ctx context.Context, addr common.Address, key common.Hash) (common.Hash, error)
+}
+
+// RPCClient is the subset of the ethclient that the RPCReader uses.
+type RPCClient interface {
+	CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error)
+	StorageAt(ctx context.Context, account common.Address, key common.Hash, blockNumber *big.Int) ([]byte, error)
+}
+
+// RPCReader reads the state of a live chain at a block. A nil block is the latest block.
+type RPCReader struct {
+	client RPCClient
+	block  *big.Int
+}
+
+func NewRPCReader(client RPCClient, block *big.Int) *RPCReader {
+	return &RPCReader{client: client, block: block}
+}
+
+func (r *RPCReader) CodeAt(ctx context.Context, addr common.Address) ([]byte, error) {
+	return r.client.CodeAt(ctx, addr, r.block)
+}
+
+func (r *RPCReader) StorageAt(ctx context.Context, addr common.Address, key common.Hash) (common.Hash, error) {
+	value, err := r.client.StorageAt(ctx, addr, key, r.block)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return common.BytesToHash(value), nil
+}
+
+// AllocReader reads the state of a genesis allocation.
+type AllocReader core.GenesisAlloc
+
+func (r AllocReader) CodeAt(ctx context.Context, addr common.Address) ([]byte, error) {
+	return r[addr].Code, nil
+}
+
+func (r AllocReader) StorageAt(ctx context.Context, addr common.Address, key common.Hash) (common.Hash, error) {
+	return r[addr].Storage[key], nil
+}
+
+// Diff is a difference between the expected and the actual state.
+type Diff struct {
+	Name    string         `json:"name"`
+	Address common.Address `json:"address"`
+	// Field is either code, for a difference of the code hash, or storage
+	Field    string       `json:"field"`
+	Slot     *common.Hash `json:"slot,omitempty"`
+	Label    string       `json:"label,omitempty"`
+	Expected common.Hash  `json:"expected"`
+	Actual   common.Hash  `json:"actual"`
+}
+
+func (d Diff) String() string {
+	if d.Slot == nil {
+		return fmt.Sprintf("%s (%s): %s is %s, expected %s", d.Name, d.Address, d.Field, d.Actual, d.Expected)
+	}
+	return fmt.Sprintf("%s (%s): %s slot %s (%s) is %s, expected %s", d.Name, d.Address, d.Field, *d.Slot, d.Label, d.Actual, d.Expected)
+}
+
+// Report is the result of a verification.
+type Report struct {
+	// Accounts is the number of accounts that were checked
+	Accounts int `json:"accounts"`
+	// Slots is the number of storage slots that were checked
+	Slots int    `json:"slots"`
+	Diffs []Diff `json:"diffs"`
+}
+
+// Verify compares the code hash and the storage of every expected account against the state,
+// reading at most concurrency accounts at a time.
+func Verify(ctx context.Context, lgr log.Logger, reader StateReader, expectations Expectations, concurrency int) (*Report, error) {
+	report := &Report{Accounts: len(expectations), Diffs: []Diff{}}
+	var mu sync.Mutex
+	add := func(diffs []Diff, slots int) {
+		mu.Lock()
+		defer mu.Unlock()
+		report.Diffs = append(report.Diffs, diffs...)
+		report.Slots += slots
+	}
+
+	g, ctx := errgroup.WithContext(ctx)
+	if concurrency > 0 {
+		g.SetLimit(concurrency)
+	}
+	for i, e := range expectations {
+		i, e := i, e
+		if i%100 == 0 {
+			lgr.Info("Verifying accounts", "index", i, "total", len(expectations))
+		}
+		g.Go(func() error {
+			diffs, slots, err := verifyAccount(ctx, reader, e)
+			if err != nil {
+				return fmt.Errorf("cannot verify %s (%s): %w", e.Name, e.Address, err)
+			}
+			add(diffs, slots)
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(report.Diffs, func(i, j int) bool {
+		a, b := report.Diffs[i], report.Diffs[j]
+		if a.Address != b.Address {
+			return a.Address.Cmp(b.Address) < 0
+		}
+		if a.Slot == nil || b.Slot == nil {
+			return a.Slot == nil && b.Slot != nil
+		}
+		return bytes.Compare(a.Slot[:], b.Slot[:]) < 0
+	})
+	return report, nil
+}
+
+func verifyAccount(ctx context.Context, reader StateReader, e *Expectation) ([]Diff, int, error) {
+	var diffs []Diff
+	code, err := reader.CodeAt(ctx, e.Address)
+	if err != nil {
+		return nil, 0, err
+	}
+	if codeHash := crypto.Keccak256Hash(code); codeHash != e.CodeHash {
+		diffs = append(diffs, Diff{Name: e.Name, Address: e.Address, Field: "code", Expected: e.CodeHash, Actual: codeHash})
+	}
+
+	slots := 0
+	for key, expected := range e.Storage {
+		mask := e.ignored[key]
+		if mask == allIgnored {
+			continue
+		}
+		actual, err := reader.StorageAt(ctx, e.Address, key)
+		if err != nil {
+			return nil, 0, err
+		}
+		slots++
+		if andNotHash(actual, mask) != andNotHash(expected, mask) {
+			key := key
+			diffs = append(diffs, Diff{Name: e.Name, Address: e.Address, Field: "storage", Slot: &key, Label: e.Label(key), Expected: expected, Actual: actual})
+		}
+	}
+	return diffs, slots, nil
+}
+
+var allIgnored = common.HexToHash("0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+
+func andNotHash(a, mask common.Hash) common.Hash {
+	for i := range a {
+		a[i] &^= mask[i]
+	}
+	return a
+}
@@ -0,0 +1,86 @@
+package verifier
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/predeploys"
+	"github.com/ethereum-optimism/optimism/op-chain-ops/genesis"
+)
+
+func TestVerify(t *testing.T) {
+	config, err := genesis.NewDeployConfig("../genesis/testdata/test-deploy-config-devnet-l1.json")
+	require.NoError(t, err)
+	config.EnableGovernance = true
+	config.FundDevAccounts = false
+	block := types.NewBlockWithHeader(&types.Header{Number: big.NewInt(10), BaseFee: big.NewInt(7), Time: 1000})
+
+	expectations, err := NewExpectations(config, block)
+	require.NoError(t, err)
+	require.NoError(t, expectations.Ignore(DefaultIgnore...))
+	gen, err := genesis.BuildL2Genesis(config, block)
+	require.NoError(t, err)
+	alloc := AllocReader(gen.Alloc)
+
+	report, err := Verify(context.Background(), log.New(), alloc, expectations, 8)
+	require.NoError(t, err)
+	require.Empty(t, report.Diffs)
+	// all proxies, and the implementations of the proxied predeploys
+	require.Greater(t, report.Accounts, 2048)
+	require.NotZero(t, report.Slots)
+
+	// L1Block values change as the chain progresses, and are ignored by default
+	alloc[predeploys.L1BlockAddr].Storage[common.Hash{}] = common.HexToHash("0x1234")
+
+	// the owner of the proxy admin is compared
+	owner := common.HexToAddress("0x0123")
+	alloc[predeploys.ProxyAdminAddr].Storage[common.Hash{}] = owner.Hash()
+
+	// the msgNonce of the messenger is ignored, but xDomainMsgSender is not
+	messengerSlot := common.BigToHash(big.NewInt(205))
+	alloc[predeploys.L2CrossDomainMessengerAddr].Storage[messengerSlot] = common.HexToHash("0x05")
+	senderSlot := common.BigToHash(big.NewInt(204))
+	alloc[predeploys.L2CrossDomainMessengerAddr].Storage[senderSlot] = common.Hash{}
+
+	// an upgraded implementation
+	impl, err := genesis.AddressToCodeNamespace(predeploys.GasPriceOracleAddr)
+	require.NoError(t, err)
+	account := alloc[impl]
+	account.Code = []byte{0x00}
+	alloc[impl] = account
+
+	report, err = Verify(context.Background(), log.New(), alloc, expectations, 8)
+	require.NoError(t, err)
+	require.Len(t, report.Diffs, 3)
+
+	require.Equal(t, "L2CrossDomainMessenger", report.Diffs[0].Name)
+	require.Equal(t, senderSlot, *report.Diffs[0].Slot)
+	require.Equal(t, "xDomainMsgSender", report.Diffs[0].Label)
+
+	require.Equal(t, "ProxyAdmin", report.Diffs[1].Name)
+	require.Equal(t, "storage", report.Diffs[1].Field)
+	require.Equal(t, "_owner", report.Diffs[1].Label)
+	require.Equal(t, config.ProxyAdminOwner.Hash(), report.Diffs[1].Expected)
+	require.Equal(t, owner.Hash(), report.Diffs[1].Actual)
+
+	require.Equal(t, "GasPriceOracle implementation", report.Diffs[2].Name)
+	require.Equal(t, "code", report.Diffs[2].Field)
+	require.Nil(t, report.Diffs[2].Slot)
+}
+
+func TestIgnore(t *testing.T) {
+	e := &Expectation{Contract: "L2CrossDomainMessenger", ignored: make(map[common.Hash]common.Hash)}
+	require.NoError(t, Expectations{e}.Ignore("L2CrossDomainMessenger._initialized"))
+	// _initialized is a uint8 at offset 20 of slot 0
+	require.Equal(t, common.HexToHash("0xff0000000000000000000000000000000000000000"), e.ignored[common.Hash{}])
+	require.Equal(t, "spacer_0_0_20,_initialized,_initializing", e.Label(common.Hash{}))
+
+	require.ErrorContains(t, Expectations{e}.Ignore("L2CrossDomainMessenger"), "expected Contract.variable")
+	require.ErrorContains(t, Expectations{e}.Ignore("L2CrossDomainMessenger.unknown"), "not found")
+}
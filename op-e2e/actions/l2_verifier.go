@@ -127,6 +127,11 @@ func (s *l2VerifierBackend) SequencerActive(ctx context.Context) (bool, error) {
 	return false, nil
 }
 
+func (s *l2VerifierBackend) PipelineState(ctx context.Context) (*derive.PipelineState, error) {
+	state := s.verifier.derivation.State()
+	return &state, nil
+}
+
 func (s *L2Verifier) L2Finalized() eth.L2BlockRef {
 	return s.derivation.Finalized()
 }
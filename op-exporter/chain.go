@@ -0,0 +1,297 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+	log "github.com/sirupsen/logrus"
+)
+
+// Checks, as used in the check label of the scrape errors metric
+const (
+	CheckSyncStatus  = "sync_status"
+	CheckBlockNumber = "block_number"
+	CheckBatchInbox  = "batch_inbox"
+	CheckOutputs     = "output_oracle"
+	CheckBalances    = "balances"
+)
+
+// ChainMonitor periodically scrapes the health of a single OP chain
+type ChainMonitor struct {
+	cfg *ChainConfig
+	log *log.Entry
+
+	l1     *ethclient.Client
+	l2     *ethclient.Client
+	rollup *rpc.Client
+
+	l1Signer types.Signer
+	// lastScanned is the latest L1 block that was scanned for batcher transactions
+	lastScanned uint64
+	// lastBatchTime is the time of the L1 block of the latest batcher transaction, zero if none was found yet
+	lastBatchTime time.Time
+
+	mu         sync.RWMutex
+	unsafeHead uint64
+	updateTime time.Time
+	healthy    bool
+}
+
+// ChainHealth is the health of a chain, as served by the health endpoint
+type ChainHealth struct {
+	Healthy    bool   `json:"healthy"`
+	UnsafeHead uint64 `json:"unsafe_head"`
+}
+
+func NewChainMonitor(ctx context.Context, cfg *ChainConfig) (*ChainMonitor, error) {
+	m := &ChainMonitor{
+		cfg:        cfg,
+		log:        log.WithField("chain", cfg.Name),
+		updateTime: time.Now(),
+	}
+	var err error
+	if cfg.L1RPC != "" {
+		if m.l1, err = ethclient.DialContext(ctx, cfg.L1RPC); err != nil {
+			return nil, fmt.Errorf("failed to dial L1 RPC: %w", err)
+		}
+	}
+	if cfg.L2RPC != "" {
+		if m.l2, err = ethclient.DialContext(ctx, cfg.L2RPC); err != nil {
+			return nil, fmt.Errorf("failed to dial L2 RPC: %w", err)
+		}
+	}
+	if cfg.RollupRPC != "" {
+		if m.rollup, err = rpc.DialContext(ctx, cfg.RollupRPC); err != nil {
+			return nil, fmt.Errorf("failed to dial rollup RPC: %w", err)
+		}
+	}
+	return m, nil
+}
+
+// Run scrapes the chain every poll interval, until the context is cancelled
+func (m *ChainMonitor) Run(ctx context.Context) {
+	ticker := time.NewTicker(time.Duration(m.cfg.PollInterval))
+	defer ticker.Stop()
+	for {
+		m.scrape(ctx)
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (m *ChainMonitor) scrape(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(m.cfg.PollInterval))
+	defer cancel()
+
+	checks := []struct {
+		name    string
+		enabled bool
+		fn      func(context.Context) error
+	}{
+		{CheckSyncStatus, m.rollup != nil, m.checkSyncStatus},
+		// the L2 block number is only used for the health of the chain if the rollup node is not monitored
+		{CheckBlockNumber, m.l2 != nil, m.checkBlockNumber},
+		{CheckBatchInbox, m.l1 != nil && m.cfg.BatchInboxAddress != (common.Address{}), m.checkBatchInbox},
+		{CheckOutputs, m.l1 != nil && m.cfg.L2OutputOracleAddress != (common.Address{}), m.checkOutputs},
+		{CheckBalances, len(m.cfg.Balances) > 0, m.checkBalances},
+	}
+	for _, check := range checks {
+		if !check.enabled {
+			continue
+		}
+		if err := check.fn(ctx); err != nil {
+			m.log.WithField("check", check.name).Warnf("Scrape failed: %s", err)
+			scrapeErrors.WithLabelValues(m.cfg.Name, check.name).Inc()
+		}
+	}
+}
+
+func (m *ChainMonitor) checkSyncStatus(ctx context.Context) error {
+	var status SyncStatus
+	if err := m.rollup.CallContext(ctx, &status, "optimism_syncStatus"); err != nil {
+		m.updateHealth(0, false)
+		return err
+	}
+	now := time.Now()
+	for head, ref := range map[string]BlockRef{
+		"unsafe":    status.UnsafeL2,
+		"safe":      status.SafeL2,
+		"finalized": status.FinalizedL2,
+	} {
+		syncStatusBlockNumber.WithLabelValues(m.cfg.Name, head).Set(float64(ref.Number))
+		syncStatusLag.WithLabelValues(m.cfg.Name, head).Set(now.Sub(time.Unix(int64(ref.Time), 0)).Seconds())
+	}
+	syncStatusL1Lag.WithLabelValues(m.cfg.Name).Set(float64(status.HeadL1.Number) - float64(status.CurrentL1.Number))
+	m.updateHealth(status.UnsafeL2.Number, true)
+	return nil
+}
+
+func (m *ChainMonitor) checkBlockNumber(ctx context.Context) error {
+	number, err := m.l2.BlockNumber(ctx)
+	if err != nil {
+		if m.rollup == nil {
+			m.updateHealth(0, false)
+		}
+		return err
+	}
+	blockNumber.WithLabelValues(m.cfg.Name, LayerL2).Set(float64(number))
+	if m.rollup == nil {
+		m.updateHealth(number, true)
+	}
+	return nil
+}
+
+// updateHealth marks the chain unhealthy if the unsafe head did not advance within the unhealthy period,
+// or if the head could not be retrieved.
+func (m *ChainMonitor) updateHealth(head uint64, ok bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := time.Now()
+	switch {
+	case !ok:
+		m.healthy = false
+	case head != m.unsafeHead:
+		m.unsafeHead = head
+		m.updateTime = now
+		m.healthy = true
+	case m.updateTime.Add(time.Duration(m.cfg.UnhealthyAfter)).Before(now):
+		if m.healthy {
+			m.log.Warnln("Unsafe head did not advance for the unhealthy period, setting unhealthy", head)
+		}
+		m.healthy = false
+	}
+	if m.healthy {
+		healthySequencer.WithLabelValues(m.cfg.Name).Set(1)
+	} else {
+		healthySequencer.WithLabelValues(m.cfg.Name).Set(0)
+	}
+}
+
+func (m *ChainMonitor) Health() ChainHealth {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return ChainHealth{Healthy: m.healthy, UnsafeHead: m.unsafeHead}
+}
+
+// checkBatchInbox scans the new L1 blocks for batcher transactions, and reports the time since the latest one.
+// On startup, up to the configured lookback of blocks is scanned.
+func (m *ChainMonitor) checkBatchInbox(ctx context.Context) error {
+	if m.l1Signer == nil {
+		chainID, err := m.l1.ChainID(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get L1 chain ID: %w", err)
+		}
+		m.l1Signer = types.LatestSignerForChainID(chainID)
+	}
+	head, err := m.l1.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get L1 head: %w", err)
+	}
+	blockNumber.WithLabelValues(m.cfg.Name, LayerL1).Set(float64(head.Number.Uint64()))
+
+	from := m.lastScanned + 1
+	if to := head.Number.Uint64(); to >= m.cfg.BatchLookbackBlocks && from+m.cfg.BatchLookbackBlocks <= to {
+		from = to - m.cfg.BatchLookbackBlocks + 1
+	}
+	for n := from; n <= head.Number.Uint64(); n++ {
+		block, err := m.l1.BlockByNumber(ctx, new(big.Int).SetUint64(n))
+		if err != nil {
+			return fmt.Errorf("failed to get L1 block %d: %w", n, err)
+		}
+		if m.hasBatch(block) {
+			m.lastBatchTime = time.Unix(int64(block.Time()), 0)
+			batchLastBlock.WithLabelValues(m.cfg.Name).Set(float64(n))
+		}
+		m.lastScanned = n
+	}
+	if !m.lastBatchTime.IsZero() {
+		batchAge.WithLabelValues(m.cfg.Name).Set(time.Since(m.lastBatchTime).Seconds())
+	}
+	return nil
+}
+
+func (m *ChainMonitor) hasBatch(block *types.Block) bool {
+	for _, tx := range block.Transactions() {
+		if to := tx.To(); to == nil || *to != m.cfg.BatchInboxAddress {
+			continue
+		}
+		if m.cfg.BatcherAddress == (common.Address{}) {
+			return true
+		}
+		sender, err := types.Sender(m.l1Signer, tx)
+		if err != nil {
+			m.log.Warnln("Failed to recover the sender of a batch inbox transaction", tx.Hash(), err)
+			continue
+		}
+		if sender == m.cfg.BatcherAddress {
+			return true
+		}
+	}
+	return false
+}
+
+// checkOutputs reports the latest output proposal of the L2OutputOracle.
+// The timestamp of an output proposal is the L1 time it was submitted at, which is used for its age.
+func (m *ChainMonitor) checkOutputs(ctx context.Context) error {
+	res, err := m.callOutputOracle(ctx, "latestOutputIndex")
+	if err != nil {
+		return err
+	}
+	index := res[0].(*big.Int)
+	res, err = m.callOutputOracle(ctx, "getL2Output", index)
+	if err != nil {
+		return err
+	}
+	timestamp, l2BlockNumber := res[1].(*big.Int), res[2].(*big.Int)
+	outputIndex.WithLabelValues(m.cfg.Name).Set(float64(index.Uint64()))
+	outputL2Block.WithLabelValues(m.cfg.Name).Set(float64(l2BlockNumber.Uint64()))
+	outputAge.WithLabelValues(m.cfg.Name).Set(time.Since(time.Unix(timestamp.Int64(), 0)).Seconds())
+	return nil
+}
+
+func (m *ChainMonitor) callOutputOracle(ctx context.Context, method string, args ...interface{}) ([]interface{}, error) {
+	data, err := l2OutputOracle.Pack(method, args...)
+	if err != nil {
+		return nil, err
+	}
+	out, err := m.l1.CallContract(ctx, ethereum.CallMsg{To: &m.cfg.L2OutputOracleAddress, Data: data}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call %s: %w", method, err)
+	}
+	res, err := l2OutputOracle.Unpack(method, out)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unpack %s: %w", method, err)
+	}
+	return res, nil
+}
+
+func (m *ChainMonitor) checkBalances(ctx context.Context) error {
+	var result error
+	for _, b := range m.cfg.Balances {
+		cl := m.l1
+		if b.Layer == LayerL2 {
+			cl = m.l2
+		}
+		wei, err := cl.BalanceAt(ctx, b.Address, nil)
+		if err != nil {
+			result = errors.Join(result, fmt.Errorf("failed to get balance of %s: %w", b.Name, err))
+			continue
+		}
+		eth, _ := new(big.Float).Quo(new(big.Float).SetInt(wei), big.NewFloat(params.Ether)).Float64()
+		balance.WithLabelValues(m.cfg.Name, b.Name, b.Address.Hex(), b.Layer).Set(eth)
+	}
+	return result
+}
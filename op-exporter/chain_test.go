@@ -0,0 +1,364 @@
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+var (
+	testInbox  = common.HexToAddress("0xff00000000000000000000000000000000000010")
+	testOracle = common.HexToAddress("0xdfe97868233d1aa22e815a266982f2cf17685a27")
+)
+
+// testBackend serves a fake L1, L2 and rollup node over JSON-RPC
+type testBackend struct {
+	mu       sync.Mutex
+	chainID  *big.Int
+	blocks   []*types.Block
+	balances map[common.Address]*big.Int
+	status   SyncStatus
+	// outputs are the L2 block numbers and L1 timestamps of the output proposals
+	outputs []struct{ l2Block, timestamp uint64 }
+	err     error
+}
+
+type testEthAPI struct {
+	b *testBackend
+}
+
+func (api *testEthAPI) ChainId() *hexutil.Big {
+	return (*hexutil.Big)(api.b.chainID)
+}
+
+func (api *testEthAPI) BlockNumber() (hexutil.Uint64, error) {
+	api.b.mu.Lock()
+	defer api.b.mu.Unlock()
+	if api.b.err != nil {
+		return 0, api.b.err
+	}
+	return hexutil.Uint64(len(api.b.blocks) - 1), nil
+}
+
+func (api *testEthAPI) GetBlockByNumber(number rpc.BlockNumber, full bool) (map[string]interface{}, error) {
+	api.b.mu.Lock()
+	defer api.b.mu.Unlock()
+	if api.b.err != nil {
+		return nil, api.b.err
+	}
+	block := api.b.blocks[len(api.b.blocks)-1]
+	if number >= 0 {
+		block = api.b.blocks[number]
+	}
+	data, err := json.Marshal(block.Header())
+	if err != nil {
+		return nil, err
+	}
+	var res map[string]interface{}
+	if err := json.Unmarshal(data, &res); err != nil {
+		return nil, err
+	}
+	res["hash"] = block.Hash()
+	res["uncles"] = []common.Hash{}
+	if full {
+		res["transactions"] = block.Transactions()
+	} else {
+		hashes := make([]common.Hash, 0, len(block.Transactions()))
+		for _, tx := range block.Transactions() {
+			hashes = append(hashes, tx.Hash())
+		}
+		res["transactions"] = hashes
+	}
+	return res, nil
+}
+
+func (api *testEthAPI) GetBalance(addr common.Address, block string) (*hexutil.Big, error) {
+	api.b.mu.Lock()
+	defer api.b.mu.Unlock()
+	if api.b.err != nil {
+		return nil, api.b.err
+	}
+	bal := api.b.balances[addr]
+	if bal == nil {
+		bal = new(big.Int)
+	}
+	return (*hexutil.Big)(bal), nil
+}
+
+func (api *testEthAPI) Call(args map[string]interface{}, block *string) (hexutil.Bytes, error) {
+	api.b.mu.Lock()
+	defer api.b.mu.Unlock()
+	if common.HexToAddress(args["to"].(string)) != testOracle {
+		return nil, errors.New("unexpected call target")
+	}
+	input, ok := args["input"].(string)
+	if !ok {
+		input = args["data"].(string)
+	}
+	data := hexutil.MustDecode(input)
+	method, err := l2OutputOracle.MethodById(data[:4])
+	if err != nil {
+		return nil, err
+	}
+	switch method.Name {
+	case "latestOutputIndex":
+		return method.Outputs.Pack(big.NewInt(int64(len(api.b.outputs) - 1)))
+	case "getL2Output":
+		args, err := method.Inputs.Unpack(data[4:])
+		if err != nil {
+			return nil, err
+		}
+		output := api.b.outputs[args[0].(*big.Int).Uint64()]
+		return method.Outputs.Pack([32]byte{1}, new(big.Int).SetUint64(output.timestamp), new(big.Int).SetUint64(output.l2Block))
+	default:
+		return nil, errors.New("unexpected method")
+	}
+}
+
+type testRollupAPI struct {
+	b *testBackend
+}
+
+func (api *testRollupAPI) SyncStatus() (*SyncStatus, error) {
+	api.b.mu.Lock()
+	defer api.b.mu.Unlock()
+	if api.b.err != nil {
+		return nil, api.b.err
+	}
+	status := api.b.status
+	return &status, nil
+}
+
+func newTestBackend(t *testing.T) (*testBackend, string) {
+	b := &testBackend{chainID: big.NewInt(900), balances: make(map[common.Address]*big.Int)}
+	b.addBlock(t, nil)
+	srv := rpc.NewServer()
+	require.NoError(t, srv.RegisterName("eth", &testEthAPI{b: b}))
+	require.NoError(t, srv.RegisterName("optimism", &testRollupAPI{b: b}))
+	httpSrv := httptest.NewServer(srv)
+	t.Cleanup(func() {
+		httpSrv.Close()
+		srv.Stop()
+	})
+	return b, httpSrv.URL
+}
+
+// addBlock adds a block with the given transactions, a minute after the previous block
+func (b *testBackend) addBlock(t *testing.T, txs []*types.Transaction) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	header := &types.Header{
+		Number:     big.NewInt(int64(len(b.blocks))),
+		Time:       uint64(time.Now().Unix()) - 3600 + uint64(len(b.blocks))*60,
+		Difficulty: new(big.Int),
+	}
+	b.blocks = append(b.blocks, types.NewBlock(header, txs, nil, nil, trie.NewStackTrie(nil)))
+}
+
+func (b *testBackend) setErr(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.err = err
+}
+
+func signedTx(t *testing.T, b *testBackend, key *ecdsa.PrivateKey, nonce uint64, to common.Address) *types.Transaction {
+	tx, err := types.SignNewTx(key, types.LatestSignerForChainID(b.chainID), &types.DynamicFeeTx{
+		ChainID:   b.chainID,
+		Nonce:     nonce,
+		To:        &to,
+		Gas:       21_000,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(1),
+	})
+	require.NoError(t, err)
+	return tx
+}
+
+func newTestMonitor(t *testing.T, cfg *ChainConfig) *ChainMonitor {
+	require.NoError(t, cfg.Check())
+	m, err := NewChainMonitor(context.Background(), cfg)
+	require.NoError(t, err)
+	return m
+}
+
+func TestCheckSyncStatus(t *testing.T) {
+	b, url := newTestBackend(t)
+	now := uint64(time.Now().Unix())
+	b.status = SyncStatus{
+		CurrentL1:   BlockRef{Number: 95},
+		HeadL1:      BlockRef{Number: 100},
+		UnsafeL2:    BlockRef{Number: 1000, Time: now - 2},
+		SafeL2:      BlockRef{Number: 900, Time: now - 200},
+		FinalizedL2: BlockRef{Number: 800, Time: now - 400},
+	}
+	m := newTestMonitor(t, &ChainConfig{Name: "sync-status", RollupRPC: url})
+
+	require.NoError(t, m.checkSyncStatus(context.Background()))
+	require.Equal(t, 1000.0, testutil.ToFloat64(syncStatusBlockNumber.WithLabelValues("sync-status", "unsafe")))
+	require.Equal(t, 900.0, testutil.ToFloat64(syncStatusBlockNumber.WithLabelValues("sync-status", "safe")))
+	require.Equal(t, 800.0, testutil.ToFloat64(syncStatusBlockNumber.WithLabelValues("sync-status", "finalized")))
+	require.InDelta(t, 200, testutil.ToFloat64(syncStatusLag.WithLabelValues("sync-status", "safe")), 2)
+	require.Equal(t, 5.0, testutil.ToFloat64(syncStatusL1Lag.WithLabelValues("sync-status")))
+	require.Equal(t, ChainHealth{Healthy: true, UnsafeHead: 1000}, m.Health())
+	require.Equal(t, 1.0, testutil.ToFloat64(healthySequencer.WithLabelValues("sync-status")))
+
+	b.setErr(errors.New("offline"))
+	require.Error(t, m.checkSyncStatus(context.Background()))
+	require.Equal(t, ChainHealth{Healthy: false, UnsafeHead: 1000}, m.Health())
+	require.Equal(t, 0.0, testutil.ToFloat64(healthySequencer.WithLabelValues("sync-status")))
+}
+
+func TestCheckBlockNumber(t *testing.T) {
+	b, url := newTestBackend(t)
+	b.addBlock(t, nil)
+	m := newTestMonitor(t, &ChainConfig{Name: "block-number", L2RPC: url})
+
+	require.NoError(t, m.checkBlockNumber(context.Background()))
+	require.Equal(t, 1.0, testutil.ToFloat64(blockNumber.WithLabelValues("block-number", LayerL2)))
+	require.Equal(t, ChainHealth{Healthy: true, UnsafeHead: 1}, m.Health())
+
+	t.Run("rollup node determines health", func(t *testing.T) {
+		m := newTestMonitor(t, &ChainConfig{Name: "block-number-rollup", L2RPC: url, RollupRPC: url})
+		require.NoError(t, m.checkBlockNumber(context.Background()))
+		require.Equal(t, ChainHealth{}, m.Health())
+	})
+}
+
+func TestUpdateHealth(t *testing.T) {
+	m := newTestMonitor(t, &ChainConfig{Name: "health", L2RPC: "http://localhost:1", UnhealthyAfter: Duration(time.Minute)})
+	m.updateHealth(10, true)
+	require.True(t, m.Health().Healthy)
+
+	// the head did not advance, but the unhealthy period did not pass yet
+	m.updateHealth(10, true)
+	require.True(t, m.Health().Healthy)
+
+	m.mu.Lock()
+	m.updateTime = time.Now().Add(-2 * time.Minute)
+	m.mu.Unlock()
+	m.updateHealth(10, true)
+	require.Equal(t, ChainHealth{Healthy: false, UnsafeHead: 10}, m.Health())
+	require.Equal(t, 0.0, testutil.ToFloat64(healthySequencer.WithLabelValues("health")))
+
+	m.updateHealth(11, true)
+	require.Equal(t, ChainHealth{Healthy: true, UnsafeHead: 11}, m.Health())
+	require.Equal(t, 1.0, testutil.ToFloat64(healthySequencer.WithLabelValues("health")))
+
+	m.updateHealth(0, false)
+	require.Equal(t, ChainHealth{Healthy: false, UnsafeHead: 11}, m.Health())
+}
+
+func TestCheckBatchInbox(t *testing.T) {
+	b, url := newTestBackend(t)
+	batcherKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	otherKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	batcher := crypto.PubkeyToAddress(batcherKey.PublicKey)
+
+	b.addBlock(t, []*types.Transaction{signedTx(t, b, batcherKey, 0, testInbox)})
+	b.addBlock(t, []*types.Transaction{signedTx(t, b, otherKey, 0, testInbox)})
+	b.addBlock(t, []*types.Transaction{signedTx(t, b, batcherKey, 1, common.Address{0x01})})
+	m := newTestMonitor(t, &ChainConfig{Name: "batch-inbox", L1RPC: url, L2RPC: url, BatchInboxAddress: testInbox, BatcherAddress: batcher})
+
+	// transactions from other senders, or to other addresses, are not batches
+	require.NoError(t, m.checkBatchInbox(context.Background()))
+	require.Equal(t, 1.0, testutil.ToFloat64(batchLastBlock.WithLabelValues("batch-inbox")))
+	require.InDelta(t, 3540, testutil.ToFloat64(batchAge.WithLabelValues("batch-inbox")), 5)
+	require.Equal(t, 3.0, testutil.ToFloat64(blockNumber.WithLabelValues("batch-inbox", LayerL1)))
+	require.Equal(t, uint64(3), m.lastScanned)
+
+	// only new blocks are scanned
+	b.addBlock(t, []*types.Transaction{signedTx(t, b, batcherKey, 2, testInbox)})
+	require.NoError(t, m.checkBatchInbox(context.Background()))
+	require.Equal(t, 4.0, testutil.ToFloat64(batchLastBlock.WithLabelValues("batch-inbox")))
+	require.Equal(t, uint64(4), m.lastScanned)
+
+	t.Run("lookback", func(t *testing.T) {
+		m := newTestMonitor(t, &ChainConfig{Name: "batch-inbox-lookback", L1RPC: url, L2RPC: url, BatchInboxAddress: testInbox, BatchLookbackBlocks: 2})
+		require.NoError(t, m.checkBatchInbox(context.Background()))
+		// block 4 has the latest batch, without a batcher filter block 2 is a batch too
+		require.Equal(t, 4.0, testutil.ToFloat64(batchLastBlock.WithLabelValues("batch-inbox-lookback")))
+		require.Equal(t, uint64(4), m.lastScanned)
+	})
+
+	t.Run("no batches", func(t *testing.T) {
+		m := newTestMonitor(t, &ChainConfig{Name: "batch-inbox-none", L1RPC: url, L2RPC: url, BatchInboxAddress: common.Address{0x02}})
+		require.NoError(t, m.checkBatchInbox(context.Background()))
+		require.True(t, m.lastBatchTime.IsZero())
+		require.Equal(t, uint64(4), m.lastScanned)
+	})
+}
+
+func TestCheckOutputs(t *testing.T) {
+	b, url := newTestBackend(t)
+	now := uint64(time.Now().Unix())
+	b.outputs = append(b.outputs,
+		struct{ l2Block, timestamp uint64 }{1800, now - 7200},
+		struct{ l2Block, timestamp uint64 }{3600, now - 60})
+	m := newTestMonitor(t, &ChainConfig{Name: "outputs", L1RPC: url, L2RPC: url, L2OutputOracleAddress: testOracle})
+
+	require.NoError(t, m.checkOutputs(context.Background()))
+	require.Equal(t, 1.0, testutil.ToFloat64(outputIndex.WithLabelValues("outputs")))
+	require.Equal(t, 3600.0, testutil.ToFloat64(outputL2Block.WithLabelValues("outputs")))
+	require.InDelta(t, 60, testutil.ToFloat64(outputAge.WithLabelValues("outputs")), 2)
+}
+
+func TestCheckBalances(t *testing.T) {
+	b, url := newTestBackend(t)
+	batcher, proposer := common.Address{0x0b}, common.Address{0x0c}
+	b.balances[batcher] = new(big.Int).Mul(big.NewInt(3), big.NewInt(params.Ether))
+	b.balances[proposer] = big.NewInt(params.Ether / 2)
+	m := newTestMonitor(t, &ChainConfig{Name: "balances", L1RPC: url, L2RPC: url, Balances: []*BalanceConfig{
+		{Name: "batcher", Address: batcher, Layer: LayerL1},
+		{Name: "proposer", Address: proposer, Layer: LayerL2},
+	}})
+
+	require.NoError(t, m.checkBalances(context.Background()))
+	require.Equal(t, 3.0, testutil.ToFloat64(balance.WithLabelValues("balances", "batcher", batcher.Hex(), LayerL1)))
+	require.Equal(t, 0.5, testutil.ToFloat64(balance.WithLabelValues("balances", "proposer", proposer.Hex(), LayerL2)))
+
+	b.setErr(errors.New("offline"))
+	err := m.checkBalances(context.Background())
+	require.ErrorContains(t, err, "failed to get balance of batcher")
+	require.ErrorContains(t, err, "failed to get balance of proposer")
+}
+
+func TestScrape(t *testing.T) {
+	b, url := newTestBackend(t)
+	b.addBlock(t, nil)
+	m := newTestMonitor(t, &ChainConfig{Name: "scrape", L1RPC: url, L2RPC: url, RollupRPC: url, PollInterval: Duration(time.Second)})
+
+	syncStatusErrors := scrapeErrors.WithLabelValues("scrape", CheckSyncStatus)
+	blockNumberErrors := scrapeErrors.WithLabelValues("scrape", CheckBlockNumber)
+	errorsBefore := testutil.ToFloat64(syncStatusErrors)
+
+	// only the enabled checks run
+	m.scrape(context.Background())
+	require.Equal(t, errorsBefore, testutil.ToFloat64(syncStatusErrors))
+	require.Equal(t, 1.0, testutil.ToFloat64(blockNumber.WithLabelValues("scrape", LayerL2)))
+	require.Equal(t, uint64(0), m.lastScanned, "batch inbox check is not configured")
+
+	b.setErr(errors.New("offline"))
+	m.scrape(context.Background())
+	require.Equal(t, errorsBefore+1, testutil.ToFloat64(syncStatusErrors))
+	require.Equal(t, errorsBefore+1, testutil.ToFloat64(blockNumberErrors))
+	require.False(t, m.Health().Healthy)
+}
@@ -4,37 +4,79 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 )
 
-//Define the metrics we wish to expose
+// Define the metrics we wish to expose
 var (
-	gasBaseFeeMetric = prometheus.NewGaugeVec(
+	blockNumber = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
-			Name: "op_baseFee",
-			Help: "Gas base fee."},
-		[]string{"network", "layer"},
+			Name: "op_blocknumber",
+			Help: "Current block number."},
+		[]string{"chain", "layer"},
 	)
-	gasUsedMetric = prometheus.NewGaugeVec(
+	healthySequencer = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
-			Name: "op_gasUsed",
-			Help: "Gas Used."},
-		[]string{"network", "layer"},
+			Name: "op_healthy_sequencer",
+			Help: "Is the sequencer healthy? Unhealthy if the unsafe L2 head did not advance within the configured period."},
+		[]string{"chain"},
 	)
-	gasPrice = prometheus.NewGaugeVec(
+	syncStatusBlockNumber = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
-			Name: "op_gasPrice",
-			Help: "Gas price."},
-		[]string{"network", "layer"},
+			Name: "op_sync_status_block_number",
+			Help: "Block number of the L2 heads reported by optimism_syncStatus, by head type."},
+		[]string{"chain", "head"},
 	)
-	blockNumber = prometheus.NewGaugeVec(
+	syncStatusLag = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
-			Name: "op_blocknumber",
-			Help: "Current block number."},
-		[]string{"network", "layer"},
+			Name: "op_sync_status_lag_seconds",
+			Help: "Seconds between now and the timestamp of the L2 heads reported by optimism_syncStatus, by head type."},
+		[]string{"chain", "head"},
 	)
-	healthySequencer = prometheus.NewGaugeVec(
+	syncStatusL1Lag = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
-			Name: "op_healthy_sequencer",
-			Help: "Is the sequencer healthy?"},
-		[]string{"network"},
+			Name: "op_sync_status_l1_lag_blocks",
+			Help: "Number of L1 blocks the derivation of the rollup node is behind its L1 head."},
+		[]string{"chain"},
+	)
+	batchLastBlock = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "op_batch_inbox_last_tx_block",
+			Help: "L1 block number of the latest batcher transaction to the batch inbox."},
+		[]string{"chain"},
+	)
+	batchAge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "op_batch_inbox_last_tx_age_seconds",
+			Help: "Seconds since the L1 block of the latest batcher transaction to the batch inbox."},
+		[]string{"chain"},
+	)
+	outputIndex = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "op_output_oracle_latest_index",
+			Help: "Index of the latest output proposal in the L2OutputOracle."},
+		[]string{"chain"},
+	)
+	outputL2Block = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "op_output_oracle_latest_l2_block",
+			Help: "L2 block number of the latest output proposal in the L2OutputOracle."},
+		[]string{"chain"},
+	)
+	outputAge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "op_output_oracle_latest_age_seconds",
+			Help: "Seconds since the latest output proposal was submitted to the L2OutputOracle."},
+		[]string{"chain"},
+	)
+	balance = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "op_balance_eth",
+			Help: "Balance of an operator address, in ETH."},
+		[]string{"chain", "name", "address", "layer"},
+	)
+	scrapeErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "op_scrape_errors_total",
+			Help: "Number of failed scrapes, by check."},
+		[]string{"chain", "check"},
 	)
 	opExporterVersion = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
@@ -45,11 +87,18 @@ var (
 )
 
 func init() {
-	//Register metrics with prometheus
-	prometheus.MustRegister(gasPrice)
+	// Register metrics with prometheus
 	prometheus.MustRegister(blockNumber)
 	prometheus.MustRegister(healthySequencer)
+	prometheus.MustRegister(syncStatusBlockNumber)
+	prometheus.MustRegister(syncStatusLag)
+	prometheus.MustRegister(syncStatusL1Lag)
+	prometheus.MustRegister(batchLastBlock)
+	prometheus.MustRegister(batchAge)
+	prometheus.MustRegister(outputIndex)
+	prometheus.MustRegister(outputL2Block)
+	prometheus.MustRegister(outputAge)
+	prometheus.MustRegister(balance)
+	prometheus.MustRegister(scrapeErrors)
 	prometheus.MustRegister(opExporterVersion)
-	prometheus.MustRegister(gasBaseFeeMetric)
-	prometheus.MustRegister(gasUsedMetric)
 }
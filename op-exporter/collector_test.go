@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricsRegistered(t *testing.T) {
+	for _, c := range []prometheus.Collector{
+		blockNumber, healthySequencer, syncStatusBlockNumber, syncStatusLag, syncStatusL1Lag,
+		batchLastBlock, batchAge, outputIndex, outputL2Block, outputAge, balance, scrapeErrors, opExporterVersion,
+	} {
+		err := prometheus.Register(c)
+		require.IsType(t, prometheus.AlreadyRegisteredError{}, err)
+	}
+}
+
+// TestMetricsChainLabel checks that all chain metrics carry the chain label, so that multiple chains can be exported
+func TestMetricsChainLabel(t *testing.T) {
+	_, url := newTestBackend(t)
+	m := newTestMonitor(t, &ChainConfig{Name: "labels", L1RPC: url, L2RPC: url, RollupRPC: url})
+	m.scrape(context.Background())
+
+	families, err := prometheus.DefaultGatherer.Gather()
+	require.NoError(t, err)
+	seen := 0
+	for _, family := range families {
+		switch family.GetName() {
+		case "op_exporter_version":
+			continue
+		case "op_blocknumber", "op_healthy_sequencer", "op_sync_status_block_number", "op_sync_status_lag_seconds",
+			"op_sync_status_l1_lag_blocks", "op_batch_inbox_last_tx_block", "op_batch_inbox_last_tx_age_seconds",
+			"op_output_oracle_latest_index", "op_output_oracle_latest_l2_block", "op_output_oracle_latest_age_seconds",
+			"op_balance_eth", "op_scrape_errors_total":
+			seen++
+		default:
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			hasChain := false
+			for _, label := range metric.GetLabel() {
+				hasChain = hasChain || (label.GetName() == "chain" && label.GetValue() != "")
+			}
+			require.True(t, hasChain, "metric %s has no chain label", family.GetName())
+		}
+	}
+	require.NotZero(t, seen)
+}
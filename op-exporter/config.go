@@ -0,0 +1,138 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Layers of the balance checks
+const (
+	LayerL1 = "layer1"
+	LayerL2 = "layer2"
+)
+
+// Config is the declarative configuration of the exporter, loaded from a TOML file.
+// Each chain is monitored independently, and all its metrics are labelled with the chain name.
+type Config struct {
+	Chains []*ChainConfig `toml:"chains"`
+}
+
+type ChainConfig struct {
+	// Name is used as the chain label of all metrics
+	Name string `toml:"name"`
+
+	L1RPC     string `toml:"l1_rpc"`
+	L2RPC     string `toml:"l2_rpc"`
+	RollupRPC string `toml:"rollup_rpc"`
+
+	PollInterval Duration `toml:"poll_interval"`
+	// UnhealthyAfter is the time after which the chain is reported unhealthy if the unsafe L2 head does not advance
+	UnhealthyAfter Duration `toml:"unhealthy_after"`
+
+	BatchInboxAddress common.Address `toml:"batch_inbox_address"`
+	// BatcherAddress filters the batch inbox transactions by sender, optional
+	BatcherAddress common.Address `toml:"batcher_address"`
+	// BatchLookbackBlocks is the number of L1 blocks to scan for batches on startup
+	BatchLookbackBlocks uint64 `toml:"batch_lookback_blocks"`
+
+	L2OutputOracleAddress common.Address `toml:"l2_output_oracle_address"`
+
+	Balances []*BalanceConfig `toml:"balances"`
+}
+
+// BalanceConfig is an operator address of which the balance is exported, e.g. the batcher or proposer
+type BalanceConfig struct {
+	Name    string         `toml:"name"`
+	Address common.Address `toml:"address"`
+	// Layer is either layer1 or layer2
+	Layer string `toml:"layer"`
+}
+
+// Duration is a time.Duration that is decoded from a TOML string like "30s"
+type Duration time.Duration
+
+func (d *Duration) UnmarshalText(b []byte) error {
+	dur, err := time.ParseDuration(string(b))
+	if err != nil {
+		return err
+	}
+	*d = Duration(dur)
+	return nil
+}
+
+func LoadConfig(path string) (*Config, error) {
+	cfg := &Config{}
+	if _, err := toml.DecodeFile(path, cfg); err != nil {
+		return nil, err
+	}
+	if err := cfg.Check(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// Check validates the config, and applies the defaults of optional settings
+func (c *Config) Check() error {
+	if len(c.Chains) == 0 {
+		return errors.New("at least one chain must be configured")
+	}
+	names := make(map[string]bool)
+	for _, chain := range c.Chains {
+		if chain.Name == "" {
+			return errors.New("chain name is missing")
+		}
+		if names[chain.Name] {
+			return fmt.Errorf("duplicate chain [%s]", chain.Name)
+		}
+		names[chain.Name] = true
+		if err := chain.Check(); err != nil {
+			return fmt.Errorf("chain [%s]: %w", chain.Name, err)
+		}
+	}
+	return nil
+}
+
+func (c *ChainConfig) Check() error {
+	if c.PollInterval == 0 {
+		c.PollInterval = Duration(30 * time.Second)
+	}
+	if c.UnhealthyAfter == 0 {
+		c.UnhealthyAfter = Duration(10 * time.Minute)
+	}
+	if c.BatchLookbackBlocks == 0 {
+		c.BatchLookbackBlocks = 300
+	}
+	if c.RollupRPC == "" && c.L2RPC == "" {
+		return errors.New("at least one of rollup_rpc and l2_rpc must be set")
+	}
+	if c.L1RPC == "" {
+		if c.BatchInboxAddress != (common.Address{}) {
+			return errors.New("batch_inbox_address requires l1_rpc")
+		}
+		if c.L2OutputOracleAddress != (common.Address{}) {
+			return errors.New("l2_output_oracle_address requires l1_rpc")
+		}
+	}
+	for _, b := range c.Balances {
+		if b.Name == "" {
+			return errors.New("balance name is missing")
+		}
+		switch b.Layer {
+		case LayerL1:
+			if c.L1RPC == "" {
+				return fmt.Errorf("balance [%s] requires l1_rpc", b.Name)
+			}
+		case LayerL2:
+			if c.L2RPC == "" {
+				return fmt.Errorf("balance [%s] requires l2_rpc", b.Name)
+			}
+		default:
+			return fmt.Errorf("balance [%s] has invalid layer %q, expected %s or %s", b.Name, b.Layer, LayerL1, LayerL2)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestLoadExampleConfig(t *testing.T) {
+	cfg, err := LoadConfig("example.config.toml")
+	require.NoError(t, err)
+	require.Len(t, cfg.Chains, 2)
+
+	mainnet := cfg.Chains[0]
+	require.Equal(t, "op-mainnet", mainnet.Name)
+	require.Equal(t, "http://localhost:8545", mainnet.L1RPC)
+	require.Equal(t, "http://localhost:9545", mainnet.L2RPC)
+	require.Equal(t, "http://localhost:7545", mainnet.RollupRPC)
+	require.Equal(t, Duration(30*time.Second), mainnet.PollInterval)
+	require.Equal(t, Duration(10*time.Minute), mainnet.UnhealthyAfter)
+	require.Equal(t, common.HexToAddress("0xff00000000000000000000000000000000000010"), mainnet.BatchInboxAddress)
+	require.Equal(t, common.HexToAddress("0x6887246668a3b87F54DeB3b94Ba47a6f63F32985"), mainnet.BatcherAddress)
+	require.Equal(t, uint64(300), mainnet.BatchLookbackBlocks)
+	require.Equal(t, common.HexToAddress("0xdfe97868233d1aa22e815a266982f2cf17685a27"), mainnet.L2OutputOracleAddress)
+	require.Equal(t, []*BalanceConfig{
+		{Name: "batcher", Address: common.HexToAddress("0x6887246668a3b87F54DeB3b94Ba47a6f63F32985"), Layer: LayerL1},
+		{Name: "proposer", Address: common.HexToAddress("0x473300df21D047806A082244b417f96b32f13A33"), Layer: LayerL1},
+	}, mainnet.Balances)
+
+	// optional settings get their defaults
+	goerli := cfg.Chains[1]
+	require.Equal(t, &ChainConfig{
+		Name:                "op-goerli",
+		L2RPC:               "http://localhost:9546",
+		PollInterval:        Duration(30 * time.Second),
+		UnhealthyAfter:      Duration(10 * time.Minute),
+		BatchLookbackBlocks: 300,
+	}, goerli)
+}
+
+func TestLoadConfigErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+		err    string
+	}{
+		{"no chains", ``, "at least one chain must be configured"},
+		{"no name", "[[chains]]\nl2_rpc = \"http://l2\"", "chain name is missing"},
+		{"duplicate", "[[chains]]\nname = \"a\"\nl2_rpc = \"http://l2\"\n[[chains]]\nname = \"a\"\nl2_rpc = \"http://l2\"", "duplicate chain [a]"},
+		{"no l2", "[[chains]]\nname = \"a\"\nl1_rpc = \"http://l1\"", "chain [a]: at least one of rollup_rpc and l2_rpc must be set"},
+		{"inbox without l1", "[[chains]]\nname = \"a\"\nl2_rpc = \"http://l2\"\nbatch_inbox_address = \"0xff00000000000000000000000000000000000010\"", "batch_inbox_address requires l1_rpc"},
+		{"oracle without l1", "[[chains]]\nname = \"a\"\nl2_rpc = \"http://l2\"\nl2_output_oracle_address = \"0xdfe97868233d1aa22e815a266982f2cf17685a27\"", "l2_output_oracle_address requires l1_rpc"},
+		{"l1 balance without l1", "[[chains]]\nname = \"a\"\nl2_rpc = \"http://l2\"\n[[chains.balances]]\nname = \"batcher\"\nlayer = \"layer1\"", "balance [batcher] requires l1_rpc"},
+		{"l2 balance without l2", "[[chains]]\nname = \"a\"\nrollup_rpc = \"http://node\"\n[[chains.balances]]\nname = \"batcher\"\nlayer = \"layer2\"", "balance [batcher] requires l2_rpc"},
+		{"invalid layer", "[[chains]]\nname = \"a\"\nl2_rpc = \"http://l2\"\n[[chains.balances]]\nname = \"batcher\"\nlayer = \"l1\"", "balance [batcher] has invalid layer \"l1\""},
+		{"no balance name", "[[chains]]\nname = \"a\"\nl2_rpc = \"http://l2\"\n[[chains.balances]]\nlayer = \"layer2\"", "balance name is missing"},
+		{"invalid duration", "[[chains]]\nname = \"a\"\nl2_rpc = \"http://l2\"\npoll_interval = \"30\"", "missing unit in duration"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.toml")
+			require.NoError(t, os.WriteFile(path, []byte(test.config), 0o644))
+			_, err := LoadConfig(path)
+			require.ErrorContains(t, err, test.err)
+		})
+	}
+}
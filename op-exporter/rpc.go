@@ -1,11 +1,39 @@
 package main
 
-// GetRollupGasPrices returns the rpc `rollup_gasPrices` status
-type GetRollupGasPrices struct {
-	L1GasPrice string `json:"l1GasPrice"`
-	L2GasPrice string `json:"l2GasPrice"`
+import (
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+// SyncStatus is the subset of the op-node `optimism_syncStatus` result that is exported
+type SyncStatus struct {
+	CurrentL1   BlockRef `json:"current_l1"`
+	HeadL1      BlockRef `json:"head_l1"`
+	UnsafeL2    BlockRef `json:"unsafe_l2"`
+	SafeL2      BlockRef `json:"safe_l2"`
+	FinalizedL2 BlockRef `json:"finalized_l2"`
+}
+
+type BlockRef struct {
+	Number uint64 `json:"number"`
+	Time   uint64 `json:"timestamp"`
 }
 
-type GetBlockNumber struct {
-	BlockNumber string `json:"result"`
+// l2OutputOracleABI is the subset of the L2OutputOracle ABI used to check the proposal freshness.
+// getL2Output returns a static OutputProposal struct, which is ABI-encoded the same as its flattened fields.
+const l2OutputOracleABI = `[
+	{"type":"function","name":"latestOutputIndex","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"uint256"}]},
+	{"type":"function","name":"getL2Output","stateMutability":"view","inputs":[{"name":"_l2OutputIndex","type":"uint256"}],
+	 "outputs":[{"name":"outputRoot","type":"bytes32"},{"name":"timestamp","type":"uint128"},{"name":"l2BlockNumber","type":"uint128"}]}
+]`
+
+var l2OutputOracle = mustParseABI(l2OutputOracleABI)
+
+func mustParseABI(s string) abi.ABI {
+	a, err := abi.JSON(strings.NewReader(s))
+	if err != nil {
+		panic(err)
+	}
+	return a
 }
@@ -2,6 +2,8 @@ package op_heartbeat
 
 import (
 	"errors"
+	"fmt"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-heartbeat/flags"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
@@ -14,6 +16,11 @@ type Config struct {
 	HTTPAddr string
 	HTTPPort int
 
+	// SignatureRequired rejects unsigned heartbeats, instead of only excluding them from the unique node counts.
+	SignatureRequired bool
+	// DedupWindow is the window over which signed heartbeats are deduplicated by peer ID.
+	DedupWindow time.Duration
+
 	Log oplog.CLIConfig
 
 	Metrics opmetrics.CLIConfig
@@ -28,6 +35,9 @@ func (c Config) Check() error {
 	if c.HTTPPort <= 0 {
 		return errors.New("must specify a valid HTTP port")
 	}
+	if c.DedupWindow < MinHeartbeatInterval {
+		return fmt.Errorf("dedup window must be at least the heartbeat interval of %s", MinHeartbeatInterval)
+	}
 	if err := c.Metrics.Check(); err != nil {
 		return err
 	}
@@ -39,10 +49,12 @@ func (c Config) Check() error {
 
 func NewConfig(ctx *cli.Context) Config {
 	return Config{
-		HTTPAddr: ctx.String(flags.HTTPAddrFlag.Name),
-		HTTPPort: ctx.Int(flags.HTTPPortFlag.Name),
-		Log:      oplog.ReadCLIConfig(ctx),
-		Metrics:  opmetrics.ReadCLIConfig(ctx),
-		Pprof:    oppprof.ReadCLIConfig(ctx),
+		HTTPAddr:          ctx.String(flags.HTTPAddrFlag.Name),
+		HTTPPort:          ctx.Int(flags.HTTPPortFlag.Name),
+		SignatureRequired: ctx.Bool(flags.SignatureRequiredFlag.Name),
+		DedupWindow:       ctx.Duration(flags.DedupWindowFlag.Name),
+		Log:               oplog.ReadCLIConfig(ctx),
+		Metrics:           opmetrics.ReadCLIConfig(ctx),
+		Pprof:             oppprof.ReadCLIConfig(ctx),
 	}
 }
@@ -1,6 +1,8 @@
 package flags
 
 import (
+	"time"
+
 	opservice "github.com/ethereum-optimism/optimism/op-service"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
@@ -14,8 +16,10 @@ func prefixEnvVars(name string) []string {
 }
 
 const (
-	HTTPAddrFlagName = "http.addr"
-	HTTPPortFlagName = "http.port"
+	HTTPAddrFlagName          = "http.addr"
+	HTTPPortFlagName          = "http.port"
+	SignatureRequiredFlagName = "signature.required"
+	DedupWindowFlagName       = "dedup.window"
 )
 
 var (
@@ -31,6 +35,17 @@ var (
 		Value:   8080,
 		EnvVars: prefixEnvVars("HTTP_PORT"),
 	}
+	SignatureRequiredFlag = &cli.BoolFlag{
+		Name:    SignatureRequiredFlagName,
+		Usage:   "Reject heartbeats that are not signed with the p2p identity key of the sender",
+		EnvVars: prefixEnvVars("SIGNATURE_REQUIRED"),
+	}
+	DedupWindowFlag = &cli.DurationFlag{
+		Name:    DedupWindowFlagName,
+		Usage:   "Window over which signed heartbeats are deduplicated by peer ID, when counting unique nodes",
+		Value:   30 * time.Minute,
+		EnvVars: prefixEnvVars("DEDUP_WINDOW"),
+	}
 )
 
 var Flags []cli.Flag
@@ -39,6 +54,8 @@ func init() {
 	Flags = []cli.Flag{
 		HTTPAddrFlag,
 		HTTPPortFlag,
+		SignatureRequiredFlag,
+		DedupWindowFlag,
 	}
 
 	Flags = append(Flags, oplog.CLIFlags(envPrefix)...)
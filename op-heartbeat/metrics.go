@@ -3,6 +3,7 @@ package op_heartbeat
 import (
 	"fmt"
 	"strconv"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -11,16 +12,22 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promauto"
 
 	"github.com/ethereum-optimism/optimism/op-node/heartbeat"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
 )
 
 const (
 	MetricsNamespace     = "op_heartbeat"
 	MinHeartbeatInterval = 10*time.Minute - 10*time.Second
 	UsersCacheSize       = 10_000
+	NodesCacheSize       = 100_000
 )
 
 type Metrics interface {
 	RecordHeartbeat(payload heartbeat.Payload, ip string)
+	// RecordSignedHeartbeat records a heartbeat of which the signature has been verified,
+	// deduplicated by peer ID over the dedup window.
+	RecordSignedHeartbeat(payload heartbeat.Payload)
+	RecordInvalidHeartbeat(reason string)
 	RecordVersion(version string)
 }
 
@@ -32,6 +39,18 @@ type metrics struct {
 	// Groups heartbeats per unique IP, version and chain ID combination.
 	// string(IP ++ version ++ chainID) -> *heartbeatEntry
 	heartbeatUsers *lru.Cache[string, *heartbeatEntry]
+
+	signedHeartbeats  *prometheus.CounterVec
+	uniqueNodes       *prometheus.GaugeVec
+	invalidHeartbeats *prometheus.CounterVec
+
+	clock       clock.Clock
+	dedupWindow time.Duration
+	// Tracks the nodes that sent a signed heartbeat within the dedup window, ordered by last heartbeat.
+	// Nodes are removed from the unique node counts when evicted.
+	// peer ID -> *nodeEntry
+	nodes     *lru.Cache[string, *nodeEntry]
+	nodesLock sync.Mutex
 }
 
 type heartbeatEntry struct {
@@ -41,7 +60,15 @@ type heartbeatEntry struct {
 	Time time.Time
 }
 
-func NewMetrics(r *prometheus.Registry) Metrics {
+type nodeEntry struct {
+	ChainID string
+	Version string
+	// Start of the dedup window in which the node was last counted
+	WindowStart time.Time
+	LastSeen    time.Time
+}
+
+func NewMetrics(r *prometheus.Registry, clock clock.Clock, dedupWindow time.Duration) Metrics {
 	lruCache, _ := lru.New[string, *heartbeatEntry](UsersCacheSize)
 	m := &metrics{
 		heartbeats: promauto.With(r).NewCounterVec(prometheus.CounterOpts{
@@ -69,23 +96,54 @@ func NewMetrics(r *prometheus.Registry) Metrics {
 			"version",
 		}),
 		heartbeatUsers: lruCache,
+		signedHeartbeats: promauto.With(r).NewCounterVec(prometheus.CounterOpts{
+			Namespace: MetricsNamespace,
+			Name:      "signed_heartbeats",
+			Help:      "Counts number of signed heartbeats by chain ID and version, counting each peer ID once per dedup window",
+		}, []string{
+			"chain_id",
+			"version",
+		}),
+		uniqueNodes: promauto.With(r).NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: MetricsNamespace,
+			Name:      "unique_nodes",
+			Help:      "Number of unique peer IDs that sent a signed heartbeat within the dedup window, by chain ID and version",
+		}, []string{
+			"chain_id",
+			"version",
+		}),
+		invalidHeartbeats: promauto.With(r).NewCounterVec(prometheus.CounterOpts{
+			Namespace: MetricsNamespace,
+			Name:      "invalid_heartbeats",
+			Help:      "Counts number of rejected heartbeats, by reason",
+		}, []string{
+			"reason",
+		}),
+		clock:       clock,
+		dedupWindow: dedupWindow,
 	}
+	m.nodes, _ = lru.NewWithEvict[string, *nodeEntry](NodesCacheSize, func(_ string, entry *nodeEntry) {
+		m.uniqueNodes.WithLabelValues(entry.ChainID, entry.Version).Dec()
+	})
 	return m
 }
 
-func (m *metrics) RecordHeartbeat(payload heartbeat.Payload, ip string) {
-	var chainID string
+func heartbeatLabels(payload heartbeat.Payload) (chainID string, version string) {
 	if AllowedChainIDs[payload.ChainID] {
 		chainID = strconv.FormatUint(payload.ChainID, 10)
 	} else {
 		chainID = "unknown"
 	}
-	var version string
 	if AllowedVersions[payload.Version] {
 		version = payload.Version
 	} else {
 		version = "unknown"
 	}
+	return chainID, version
+}
+
+func (m *metrics) RecordHeartbeat(payload heartbeat.Payload, ip string) {
+	chainID, version := heartbeatLabels(payload)
 
 	key := fmt.Sprintf("%s;%s;%s", ip, version, chainID)
 	now := time.Now()
@@ -113,6 +171,51 @@ func (m *metrics) RecordHeartbeat(payload heartbeat.Payload, ip string) {
 	m.heartbeatUsers.Add(key, entry)
 }
 
+func (m *metrics) RecordSignedHeartbeat(payload heartbeat.Payload) {
+	m.nodesLock.Lock()
+	defer m.nodesLock.Unlock()
+
+	now := m.clock.Now()
+	m.pruneNodes(now)
+
+	chainID, version := heartbeatLabels(payload)
+	entry, ok := m.nodes.Peek(payload.PeerID)
+	if ok && entry.ChainID == chainID && entry.Version == version {
+		if now.Sub(entry.WindowStart) >= m.dedupWindow {
+			// count the node again once per window
+			entry.WindowStart = now
+			m.signedHeartbeats.WithLabelValues(chainID, version).Inc()
+		}
+		entry.LastSeen = now
+		// always add, to keep the LRU ordered by last heartbeat
+		m.nodes.Add(payload.PeerID, entry)
+		return
+	}
+	if ok {
+		// the node changed version or chain, remove it from the counts of the previous labels
+		m.nodes.Remove(payload.PeerID)
+	}
+	m.nodes.Add(payload.PeerID, &nodeEntry{ChainID: chainID, Version: version, WindowStart: now, LastSeen: now})
+	m.uniqueNodes.WithLabelValues(chainID, version).Inc()
+	m.signedHeartbeats.WithLabelValues(chainID, version).Inc()
+}
+
+// pruneNodes removes the nodes of which the last heartbeat is older than the dedup window.
+// Nodes are pruned as heartbeats come in, which happens frequently enough to keep the unique node counts accurate.
+func (m *metrics) pruneNodes(now time.Time) {
+	for {
+		id, entry, ok := m.nodes.GetOldest()
+		if !ok || now.Sub(entry.LastSeen) < m.dedupWindow {
+			return
+		}
+		m.nodes.Remove(id)
+	}
+}
+
+func (m *metrics) RecordInvalidHeartbeat(reason string) {
+	m.invalidHeartbeats.WithLabelValues(reason).Inc()
+}
+
 func (m *metrics) RecordVersion(version string) {
 	m.version.WithLabelValues(version).Set(1)
 }
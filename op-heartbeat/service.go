@@ -20,6 +20,7 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/ethereum-optimism/optimism/op-node/heartbeat"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
 	"github.com/ethereum-optimism/optimism/op-service/httputil"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
@@ -29,6 +30,8 @@ import (
 const (
 	HTTPMaxHeaderSize = 10 * 1024
 	HTTPMaxBodySize   = 1024 * 1024
+	// MaxTimestampDrift is the maximum difference between the timestamp of a signed heartbeat and the time it is received.
+	MaxTimestampDrift = 5 * time.Minute
 )
 
 func Main(version string) func(ctx *cli.Context) error {
@@ -103,11 +106,11 @@ func Start(ctx context.Context, l log.Logger, cfg Config, version string) (*Hear
 		hs.pprof = pprofSrv
 	}
 
-	metrics := NewMetrics(registry)
+	metrics := NewMetrics(registry, clock.SystemClock, cfg.DedupWindow)
 	metrics.RecordVersion(version)
 	mux := http.NewServeMux()
 	mux.HandleFunc("/healthz", HealthzHandler)
-	mux.Handle("/", Handler(l, metrics))
+	mux.Handle("/", Handler(l, metrics, clock.SystemClock, cfg.SignatureRequired))
 	recorder := opmetrics.NewPromHTTPRecorder(registry, MetricsNamespace)
 	mw := opmetrics.NewHTTPRecordingMiddleware(recorder, mux)
 
@@ -129,7 +132,10 @@ func Start(ctx context.Context, l log.Logger, cfg Config, version string) (*Hear
 	return hs, nil
 }
 
-func Handler(l log.Logger, metrics Metrics) http.HandlerFunc {
+// Handler records heartbeats. Signed heartbeats are verified against the public key of their peer ID,
+// and are rejected if invalid. Unsigned heartbeats are rejected if signatures are required,
+// and otherwise only recorded in the per-IP heartbeat metrics.
+func Handler(l log.Logger, metrics Metrics, clock clock.Clock, signatureRequired bool) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ipStr := r.Header.Get("X-Forwarded-For")
 		// XFF can be a comma-separated list. Left-most is the original client.
@@ -151,6 +157,7 @@ func Handler(l log.Logger, metrics Metrics) http.HandlerFunc {
 			return
 		}
 
+		signed := len(payload.Signature) > 0
 		innerL.Info(
 			"got heartbeat",
 			"version", payload.Version,
@@ -158,9 +165,35 @@ func Handler(l log.Logger, metrics Metrics) http.HandlerFunc {
 			"moniker", payload.Moniker,
 			"peer_id", payload.PeerID,
 			"chain_id", payload.ChainID,
+			"signed", signed,
 		)
 
+		if !signed && signatureRequired {
+			innerL.Info("rejected unsigned heartbeat")
+			metrics.RecordInvalidHeartbeat("unsigned")
+			w.WriteHeader(401)
+			return
+		}
+		if signed {
+			if err := payload.Verify(); err != nil {
+				innerL.Info("rejected heartbeat with invalid signature", "err", err)
+				metrics.RecordInvalidHeartbeat("invalid_signature")
+				w.WriteHeader(401)
+				return
+			}
+			drift := clock.Now().Sub(time.Unix(int64(payload.Timestamp), 0))
+			if drift > MaxTimestampDrift || drift < -MaxTimestampDrift {
+				innerL.Info("rejected signed heartbeat with stale timestamp", "timestamp", payload.Timestamp)
+				metrics.RecordInvalidHeartbeat("stale_timestamp")
+				w.WriteHeader(401)
+				return
+			}
+		}
+
 		metrics.RecordHeartbeat(payload, ipStr)
+		if signed {
+			metrics.RecordSignedHeartbeat(payload)
+		}
 
 		w.WriteHeader(204)
 	}
@@ -3,17 +3,24 @@ package op_heartbeat
 import (
 	"bytes"
 	"context"
+	"crypto/rand"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net"
 	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/prometheus/client_golang/prometheus"
+	promtest "github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/require"
 
 	"github.com/ethereum-optimism/optimism/op-node/heartbeat"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 )
 
@@ -21,8 +28,9 @@ func TestService(t *testing.T) {
 	httpPort := freePort(t)
 	metricsPort := freePort(t)
 	cfg := Config{
-		HTTPAddr: "127.0.0.1",
-		HTTPPort: httpPort,
+		HTTPAddr:    "127.0.0.1",
+		HTTPPort:    httpPort,
+		DedupWindow: 30 * time.Minute,
 		Metrics: opmetrics.CLIConfig{
 			Enabled:    true,
 			ListenAddr: "127.0.0.1",
@@ -129,6 +137,90 @@ func TestService(t *testing.T) {
 	}
 }
 
+func TestSignedHeartbeats(t *testing.T) {
+	clk := clock.NewDeterministicClock(time.Unix(10_000, 0))
+	registry := prometheus.NewRegistry()
+	m := NewMetrics(registry, clk, 30*time.Minute).(*metrics)
+
+	newKey := func() crypto.PrivKey {
+		priv, _, err := crypto.GenerateSecp256k1Key(rand.Reader)
+		require.NoError(t, err)
+		return priv
+	}
+	sign := func(priv crypto.PrivKey, version string, at time.Time) heartbeat.Payload {
+		p := heartbeat.Payload{Version: version, Meta: "whatever", Moniker: "whatever", ChainID: 10}
+		require.NoError(t, p.Sign(priv, at))
+		return p
+	}
+	post := func(t *testing.T, signatureRequired bool, p heartbeat.Payload) int {
+		data, err := json.Marshal(p)
+		require.NoError(t, err)
+		req := httptest.NewRequest("POST", "/", bytes.NewReader(data))
+		rec := httptest.NewRecorder()
+		Handler(log.New(), m, clk, signatureRequired)(rec, req)
+		return rec.Code
+	}
+	uniqueNodes := func(version string) float64 {
+		return promtest.ToFloat64(m.uniqueNodes.WithLabelValues("10", version))
+	}
+	invalid := func(reason string) float64 {
+		return promtest.ToFloat64(m.invalidHeartbeats.WithLabelValues(reason))
+	}
+	const v1, v2 = "v0.10.14", "v0.11.0"
+
+	alice, bob := newKey(), newKey()
+	t.Run("valid", func(t *testing.T) {
+		require.Equal(t, 204, post(t, true, sign(alice, v1, clk.Now())))
+		require.Equal(t, 204, post(t, true, sign(bob, v1, clk.Now())))
+		require.Equal(t, 2.0, uniqueNodes(v1))
+	})
+	t.Run("deduplicated by peer ID", func(t *testing.T) {
+		clk.AdvanceTime(10 * time.Minute)
+		require.Equal(t, 204, post(t, true, sign(alice, v1, clk.Now())))
+		require.Equal(t, 2.0, uniqueNodes(v1))
+		require.Equal(t, 2.0, promtest.ToFloat64(m.signedHeartbeats.WithLabelValues("10", v1)))
+	})
+	t.Run("version upgrade", func(t *testing.T) {
+		require.Equal(t, 204, post(t, true, sign(alice, v2, clk.Now())))
+		require.Equal(t, 1.0, uniqueNodes(v1))
+		require.Equal(t, 1.0, uniqueNodes(v2))
+	})
+	t.Run("expired", func(t *testing.T) {
+		// bob has not been seen for the full window, and is pruned with the next heartbeat
+		clk.AdvanceTime(20 * time.Minute)
+		require.Equal(t, 204, post(t, true, sign(alice, v2, clk.Now())))
+		require.Equal(t, 0.0, uniqueNodes(v1))
+		require.Equal(t, 1.0, uniqueNodes(v2))
+		// alice is counted again in the new window
+		clk.AdvanceTime(30 * time.Minute)
+		require.Equal(t, 204, post(t, true, sign(alice, v2, clk.Now())))
+		require.Equal(t, 1.0, uniqueNodes(v2))
+		require.Equal(t, 2.0, promtest.ToFloat64(m.signedHeartbeats.WithLabelValues("10", v2)))
+	})
+	t.Run("unsigned", func(t *testing.T) {
+		p := heartbeat.Payload{Version: v1, PeerID: "1X2398ug", ChainID: 10}
+		require.Equal(t, 401, post(t, true, p))
+		require.Equal(t, 1.0, invalid("unsigned"))
+		require.Equal(t, 204, post(t, false, p), "unsigned heartbeats are accepted if signatures are not required")
+		require.Equal(t, 0.0, uniqueNodes(v1), "unsigned heartbeats are not counted as unique nodes")
+	})
+	t.Run("invalid signature", func(t *testing.T) {
+		p := sign(newKey(), v1, clk.Now())
+		p.Version = v2
+		require.Equal(t, 401, post(t, false, p))
+		p = sign(newKey(), v1, clk.Now())
+		p.PeerID = "1X2398ug"
+		require.Equal(t, 401, post(t, false, p))
+		require.Equal(t, 2.0, invalid("invalid_signature"))
+	})
+	t.Run("stale timestamp", func(t *testing.T) {
+		require.Equal(t, 401, post(t, false, sign(newKey(), v1, clk.Now().Add(-MaxTimestampDrift-time.Second))))
+		require.Equal(t, 401, post(t, false, sign(newKey(), v1, clk.Now().Add(MaxTimestampDrift+time.Second))))
+		require.Equal(t, 2.0, invalid("stale_timestamp"))
+		require.Equal(t, 0.0, uniqueNodes(v1))
+	})
+}
+
 func freePort(t *testing.T) int {
 	addr, err := net.ResolveTCPAddr("tcp", "localhost:0")
 	require.NoError(t, err)
@@ -0,0 +1,362 @@
+// Package altsync implements the alternative sync of unsafe L2 payloads:
+// gaps between the unsafe L2 head and the gossip sync target are filled with payloads
+// fetched from backup L2 RPCs and P2P peers, as an alternative to waiting for the payloads to be gossiped.
+package altsync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+const (
+	// Maximum number of payloads to fetch, walking back from the sync target, for a single range request.
+	// The driver repeats the range request with an updated range while there is a gap.
+	maxHashWalk = 256
+	// Maximum number of payloads to fetch by number, for a single open-ended range request.
+	maxForwardSync = 512
+	// Number of payloads to request from a source at once.
+	forwardBatch = 10
+	// Number of payloads an RPC source fetches for a single by-number request.
+	maxRPCBatch = forwardBatch
+	// Number of different sources to try for a single fetch, before giving up.
+	maxSourceAttempts = 3
+	// Timeout for a single fetch from a single source.
+	fetchTimeout = time.Second * 10
+)
+
+// ErrInvalidPayload is returned when a source serves a payload that does not match the expected chain.
+var ErrInvalidPayload = errors.New("invalid payload")
+
+// ErrNoSources is returned when there are no sources available to sync from.
+var ErrNoSources = errors.New("no alt-sync sources available")
+
+// ReceivePayloadFn passes a verified payload on for processing.
+type ReceivePayloadFn func(ctx context.Context, from peer.ID, payload *eth.ExecutionPayload) error
+
+type Metrics interface {
+	RecordAltSyncResult(source string, result string)
+}
+
+type rangeRequest struct {
+	start eth.L2BlockRef
+	end   eth.L2BlockRef
+}
+
+// Manager implements the driver AltSync interface, by fetching missing unsafe payloads
+// from multiple scored sources. Payloads are only passed on if they are verified to connect,
+// by parent-hash, to either the sync target or the current unsafe head.
+//
+// Range requests are processed one at a time by a background worker.
+// Only the latest range request is retained while the worker is busy:
+// the driver repeats the request with an updated range while the gap persists.
+type Manager struct {
+	log     log.Logger
+	cfg     *rollup.Config
+	metrics Metrics
+
+	sources SourcesFn
+	receive ReceivePayloadFn
+	scores  *sourceScores
+
+	requests chan rangeRequest
+
+	resCtx    context.Context
+	resCancel context.CancelFunc
+	wg        sync.WaitGroup
+}
+
+func NewManager(log log.Logger, cfg *rollup.Config, sources SourcesFn, receive ReceivePayloadFn, metrics Metrics) *Manager {
+	resCtx, resCancel := context.WithCancel(context.Background())
+	return &Manager{
+		log:       log,
+		cfg:       cfg,
+		metrics:   metrics,
+		sources:   sources,
+		receive:   receive,
+		scores:    newSourceScores(),
+		requests:  make(chan rangeRequest, 1),
+		resCtx:    resCtx,
+		resCancel: resCancel,
+	}
+}
+
+// Start starts the background sync worker. This may not be called after Close().
+func (m *Manager) Start() error {
+	m.wg.Add(1)
+	go m.eventLoop()
+	return nil
+}
+
+// Close stops the background sync worker, and waits for it to exit.
+func (m *Manager) Close() error {
+	m.resCancel()
+	m.wg.Wait()
+	return nil
+}
+
+// RequestL2Range signals that the payloads between start and end (both exclusive) are missing.
+// If end is empty, the range is open-ended, and payloads up to the current expected L2 block are fetched.
+func (m *Manager) RequestL2Range(ctx context.Context, start, end eth.L2BlockRef) error {
+	// Drop any pending request, the new request has more up-to-date information
+	select {
+	case <-m.requests:
+	default:
+	}
+	select {
+	case m.requests <- rangeRequest{start: start, end: end}:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("too busy with alt-sync requests: %w", ctx.Err())
+	}
+}
+
+func (m *Manager) eventLoop() {
+	defer m.wg.Done()
+	m.log.Info("Starting alt-sync worker")
+	for {
+		select {
+		case req := <-m.requests:
+			m.onRangeRequest(m.resCtx, req)
+		case <-m.resCtx.Done():
+			m.log.Info("Stopped alt-sync worker")
+			return
+		}
+	}
+}
+
+func (m *Manager) onRangeRequest(ctx context.Context, req rangeRequest) {
+	sources := m.sources()
+	m.scores.prune(sources)
+	if len(sources) == 0 {
+		m.log.Debug("ignoring request to sync L2 range, no alt-sync sources available", "start", req.start, "end", req.end)
+		return
+	}
+	var err error
+	if req.end != (eth.L2BlockRef{}) {
+		if req.end.Number <= req.start.Number+1 {
+			return
+		}
+		err = m.syncByHash(ctx, req.start, req.end)
+	} else {
+		target, terr := m.cfg.TargetBlockNumber(uint64(time.Now().Unix()))
+		if terr != nil {
+			m.log.Warn("cannot determine target block number for open-ended alt-sync", "err", terr)
+			return
+		}
+		if target <= req.start.Number {
+			return
+		}
+		err = m.syncByNumber(ctx, req.start, target+1)
+	}
+	if err != nil && !errors.Is(err, context.Canceled) {
+		m.log.Warn("failed to fill unsafe L2 gap with alt-sync", "start", req.start, "end", req.end, "err", err)
+	}
+}
+
+// syncByHash walks back from the sync target towards the start, in batches of consecutive payloads.
+// Every batch is verified to end with the expected block hash, the parent-hash of the payloads above it,
+// so the fetched chain is guaranteed to connect to the sync target. If no source serves a matching batch,
+// e.g. because the chain of the sync target is not canonical to the sources, the single expected payload
+// is fetched by hash instead, and the walk continues from its parent.
+// The payloads are passed on in ascending order, even if the walk is interrupted.
+// Large gaps are filled from the top, up to maxHashWalk payloads at a time.
+func (m *Manager) syncByHash(ctx context.Context, start, end eth.L2BlockRef) error {
+	m.log.Info("Filling unsafe L2 gap by hash", "start", start, "end", end, "size", end.Number-start.Number-1)
+	lowest := start.Number + 1
+	if end.Number-lowest > maxHashWalk {
+		lowest = end.Number - maxHashWalk
+	}
+	// chain and froms are in descending order
+	var chain []*eth.ExecutionPayload
+	var froms []peer.ID
+	hash := end.ParentHash
+	var err error
+	for top := end.Number - 1; top >= lowest; {
+		count := top - lowest + 1
+		if count > forwardBatch {
+			count = forwardBatch
+		}
+		payloads, from, berr := m.fetchBatchTo(ctx, top+1-count, count, hash)
+		if berr != nil {
+			if errors.Is(berr, context.Canceled) {
+				err = berr
+				break
+			}
+			m.log.Debug("failed to fetch batch of payloads, falling back to fetching by hash", "num", top, "hash", hash, "err", berr)
+			var payload *eth.ExecutionPayload
+			payload, from, err = m.fetchByHash(ctx, top, hash)
+			if err != nil {
+				err = fmt.Errorf("failed to fetch block %d by hash %s: %w", top, hash, err)
+				break
+			}
+			payloads = []*eth.ExecutionPayload{payload}
+		}
+		for i := len(payloads) - 1; i >= 0; i-- {
+			chain = append(chain, payloads[i])
+			froms = append(froms, from)
+		}
+		hash = payloads[0].ParentHash
+		top -= uint64(len(payloads))
+	}
+	if err == nil && lowest == start.Number+1 && hash != start.Hash {
+		// The target chain does not build on the unsafe head: the unsafe head was reorged out.
+		// The payloads are still canonical w.r.t. the sync target, the engine queue decides what to do with them.
+		m.log.Warn("alt-synced chain does not connect to unsafe head", "start", start, "parent", hash)
+	}
+	for i := len(chain) - 1; i >= 0; i-- {
+		if rerr := m.receive(ctx, froms[i], chain[i]); rerr != nil {
+			return fmt.Errorf("failed to pass on payload %s: %w", chain[i].ID(), rerr)
+		}
+	}
+	return err
+}
+
+// fetchBatchTo fetches the count payloads from block number first, verified to be consecutive,
+// and to end with the payload with the given block hash.
+func (m *Manager) fetchBatchTo(ctx context.Context, first uint64, count uint64, hash common.Hash) ([]*eth.ExecutionPayload, peer.ID, error) {
+	return fetch(ctx, m, func(ctx context.Context, src Source) ([]*eth.ExecutionPayload, error) {
+		res, err := src.PayloadsByNumber(ctx, first, count)
+		if err != nil {
+			return nil, err
+		}
+		// a partial result cannot be verified against the hash of the last payload
+		if uint64(len(res)) != count {
+			return nil, ethereum.NotFound
+		}
+		if err := verifyChain(eth.BlockID{Hash: res[0].ParentHash, Number: first - 1}, res); err != nil {
+			return nil, err
+		}
+		if last := res[len(res)-1]; last.BlockHash != hash {
+			return nil, fmt.Errorf("%w: expected block %d with hash %s, got %s", ErrInvalidPayload, last.BlockNumber, hash, last.ID())
+		}
+		return res, nil
+	})
+}
+
+// fetchByHash fetches the payload with the given block hash, verified to have the given block number.
+func (m *Manager) fetchByHash(ctx context.Context, num uint64, hash common.Hash) (*eth.ExecutionPayload, peer.ID, error) {
+	return fetch(ctx, m, func(ctx context.Context, src Source) (*eth.ExecutionPayload, error) {
+		p, err := src.PayloadByHash(ctx, hash)
+		if err != nil {
+			return nil, err
+		}
+		if p.BlockHash != hash || uint64(p.BlockNumber) != num {
+			return nil, fmt.Errorf("%w: expected block %d with hash %s, got %s", ErrInvalidPayload, num, hash, p.ID())
+		}
+		return p, nil
+	})
+}
+
+// syncByNumber fetches payloads forward from the start, up to but not including the end block number.
+// This is used when there is no sync target to walk back from.
+// Every payload is verified to build on the previous one, starting at the start block,
+// so the fetched chain is guaranteed to connect to the unsafe head.
+func (m *Manager) syncByNumber(ctx context.Context, start eth.L2BlockRef, end uint64) error {
+	if end > start.Number+1+maxForwardSync {
+		end = start.Number + 1 + maxForwardSync
+	}
+	m.log.Info("Filling unsafe L2 gap by number", "start", start, "end", end, "size", end-start.Number-1)
+	parent := start.ID()
+	for parent.Number+1 < end {
+		count := end - parent.Number - 1
+		if count > forwardBatch {
+			count = forwardBatch
+		}
+		prev := parent
+		payloads, from, err := fetch(ctx, m, func(ctx context.Context, src Source) ([]*eth.ExecutionPayload, error) {
+			res, err := src.PayloadsByNumber(ctx, prev.Number+1, count)
+			if err != nil {
+				return nil, err
+			}
+			if len(res) == 0 {
+				return nil, ethereum.NotFound
+			}
+			if err := verifyChain(prev, res); err != nil {
+				return nil, err
+			}
+			return res, nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to fetch blocks from %d: %w", prev.Number+1, err)
+		}
+		for _, p := range payloads {
+			if err := m.receive(ctx, from, p); err != nil {
+				return fmt.Errorf("failed to pass on payload %s: %w", p.ID(), err)
+			}
+		}
+		parent = payloads[len(payloads)-1].ID()
+	}
+	return nil
+}
+
+// verifyChain checks that the payloads are consecutive, and build on the given parent block.
+func verifyChain(parent eth.BlockID, payloads []*eth.ExecutionPayload) error {
+	prev := parent
+	for _, p := range payloads {
+		if uint64(p.BlockNumber) != prev.Number+1 {
+			return fmt.Errorf("%w: expected block %d, got %s", ErrInvalidPayload, prev.Number+1, p.ID())
+		}
+		if p.ParentHash != prev.Hash {
+			return fmt.Errorf("%w: block %s does not build on %s", ErrInvalidPayload, p.ID(), prev)
+		}
+		prev = p.ID()
+	}
+	return nil
+}
+
+// fetch tries the fn with the best scored sources, until it succeeds, or the maximum number of attempts is reached.
+// The source that served the result is returned with it. Every attempt updates the score of the source.
+func fetch[T any](ctx context.Context, m *Manager, fn func(ctx context.Context, src Source) (T, error)) (out T, from peer.ID, err error) {
+	ranked := m.scores.rank(m.sources())
+	if len(ranked) == 0 {
+		return out, "", ErrNoSources
+	}
+	if len(ranked) > maxSourceAttempts {
+		ranked = ranked[:maxSourceAttempts]
+	}
+	for _, src := range ranked {
+		fetchCtx, cancel := context.WithTimeout(ctx, fetchTimeout)
+		out, err = fn(fetchCtx, src)
+		cancel()
+		if ctx.Err() != nil {
+			return out, "", ctx.Err()
+		}
+		m.onResult(src, err)
+		if err == nil {
+			return out, src.Peer(), nil
+		}
+		m.log.Debug("alt-sync source failed to serve request", "source", src.Peer(), "err", err)
+	}
+	return out, "", err
+}
+
+func (m *Manager) onResult(src Source, err error) {
+	var result string
+	switch {
+	case err == nil:
+		result = "ok"
+		m.scores.update(src.Peer(), scoreValid)
+	case errors.Is(err, ethereum.NotFound):
+		result = "not_found"
+		m.scores.update(src.Peer(), scoreNotFound)
+	case errors.Is(err, ErrInvalidPayload):
+		result = "invalid"
+		m.scores.update(src.Peer(), scoreInvalid)
+	default:
+		result = "error"
+		m.scores.update(src.Peer(), scoreError)
+	}
+	m.metrics.RecordAltSyncResult(src.Kind(), result)
+}
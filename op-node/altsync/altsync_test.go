@@ -0,0 +1,259 @@
+package altsync
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+type testSource struct {
+	id       peer.ID
+	byNumber map[uint64]*eth.ExecutionPayload
+	byHash   map[common.Hash]*eth.ExecutionPayload
+}
+
+func newTestSource(id peer.ID, chain []*eth.ExecutionPayload) *testSource {
+	s := &testSource{
+		id:       id,
+		byNumber: make(map[uint64]*eth.ExecutionPayload),
+		byHash:   make(map[common.Hash]*eth.ExecutionPayload),
+	}
+	for _, p := range chain {
+		s.byNumber[uint64(p.BlockNumber)] = p
+		s.byHash[p.BlockHash] = p
+	}
+	return s
+}
+
+func (s *testSource) Peer() peer.ID {
+	return s.id
+}
+
+func (s *testSource) Kind() string {
+	return "test"
+}
+
+func (s *testSource) PayloadByHash(ctx context.Context, hash common.Hash) (*eth.ExecutionPayload, error) {
+	p, ok := s.byHash[hash]
+	if !ok {
+		return nil, ethereum.NotFound
+	}
+	return p, nil
+}
+
+func (s *testSource) PayloadsByNumber(ctx context.Context, start uint64, count uint64) ([]*eth.ExecutionPayload, error) {
+	var out []*eth.ExecutionPayload
+	for i := uint64(0); i < count; i++ {
+		p, ok := s.byNumber[start+i]
+		if !ok {
+			break
+		}
+		out = append(out, p)
+	}
+	if len(out) == 0 {
+		return nil, ethereum.NotFound
+	}
+	return out, nil
+}
+
+// makeChain creates a chain of payloads from block 0 up to and including block n.
+func makeChain(rng *rand.Rand, n uint64) []*eth.ExecutionPayload {
+	chain := make([]*eth.ExecutionPayload, 0, n+1)
+	parent := common.Hash{}
+	for i := uint64(0); i <= n; i++ {
+		p := &eth.ExecutionPayload{
+			ParentHash:  parent,
+			BlockNumber: eth.Uint64Quantity(i),
+			BlockHash:   testutils.RandomHash(rng),
+		}
+		chain = append(chain, p)
+		parent = p.BlockHash
+	}
+	return chain
+}
+
+// forkChain copies the chain, but replaces all blocks from the given number onwards with a different chain.
+func forkChain(rng *rand.Rand, chain []*eth.ExecutionPayload, from uint64) []*eth.ExecutionPayload {
+	out := make([]*eth.ExecutionPayload, len(chain))
+	copy(out, chain)
+	for i := from; i < uint64(len(out)); i++ {
+		out[i] = &eth.ExecutionPayload{
+			ParentHash:  out[i-1].BlockHash,
+			BlockNumber: eth.Uint64Quantity(i),
+			BlockHash:   testutils.RandomHash(rng),
+		}
+	}
+	return out
+}
+
+func ref(p *eth.ExecutionPayload) eth.L2BlockRef {
+	return eth.L2BlockRef{Hash: p.BlockHash, Number: uint64(p.BlockNumber), ParentHash: p.ParentHash}
+}
+
+type testReceiver struct {
+	payloads []*eth.ExecutionPayload
+	from     []peer.ID
+}
+
+func (r *testReceiver) receive(ctx context.Context, from peer.ID, payload *eth.ExecutionPayload) error {
+	r.payloads = append(r.payloads, payload)
+	r.from = append(r.from, from)
+	return nil
+}
+
+func (r *testReceiver) requireRange(t *testing.T, chain []*eth.ExecutionPayload, start, end uint64) {
+	require.Len(t, r.payloads, int(end-start), "expected blocks %d to %d", start, end-1)
+	for i, p := range r.payloads {
+		require.Equal(t, chain[start+uint64(i)].BlockHash, p.BlockHash, "expected block %d of the canonical chain", start+uint64(i))
+	}
+}
+
+// newTestManager creates a manager for a chain that is expected to be at block target at the current time.
+func newTestManager(t *testing.T, target uint64, sources []Source, rcv *testReceiver) *Manager {
+	logger := testlog.Logger(t, log.LvlError)
+	cfg := &rollup.Config{BlockTime: 2, L2ChainID: big.NewInt(901)}
+	cfg.Genesis.L2Time = uint64(time.Now().Unix()) - target*cfg.BlockTime
+	return NewManager(logger, cfg, func() []Source { return sources }, rcv.receive, metrics.NoopMetrics)
+}
+
+func TestSyncByHash(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := makeChain(rng, 50)
+
+	t.Run("single source", func(t *testing.T) {
+		src := newTestSource("a", chain)
+		rcv := &testReceiver{}
+		m := newTestManager(t, 0, []Source{src}, rcv)
+		m.onRangeRequest(context.Background(), rangeRequest{start: ref(chain[10]), end: ref(chain[20])})
+		rcv.requireRange(t, chain, 11, 20)
+		// the gap is fetched with a single batch
+		require.Equal(t, 1, m.scores.get("a"))
+	})
+
+	t.Run("skip bad source", func(t *testing.T) {
+		// the bad source serves a fork for every number and hash we ask for
+		fork := forkChain(rng, chain, 12)
+		bad := newTestSource("bad", fork)
+		for i, p := range fork[12:] {
+			bad.byHash[chain[12+i].BlockHash] = p
+		}
+		good := newTestSource("good", chain)
+		rcv := &testReceiver{}
+		m := newTestManager(t, 0, []Source{bad, good}, rcv)
+		// make sure the bad source is tried first
+		m.scores.update("bad", 5)
+		m.onRangeRequest(context.Background(), rangeRequest{start: ref(chain[10]), end: ref(chain[20])})
+		rcv.requireRange(t, chain, 11, 20)
+		for _, from := range rcv.from {
+			require.Equal(t, peer.ID("good"), from)
+		}
+		require.Less(t, m.scores.get("bad"), 0)
+		require.Greater(t, m.scores.get("good"), 0)
+	})
+
+	t.Run("fallback to hash", func(t *testing.T) {
+		// the source serves a fork by number, but still has the chain of the sync target by hash
+		src := newTestSource("a", forkChain(rng, chain, 12))
+		for _, p := range chain {
+			src.byHash[p.BlockHash] = p
+		}
+		rcv := &testReceiver{}
+		m := newTestManager(t, 0, []Source{src}, rcv)
+		m.onRangeRequest(context.Background(), rangeRequest{start: ref(chain[10]), end: ref(chain[20])})
+		rcv.requireRange(t, chain, 11, 20)
+	})
+
+	t.Run("large gap", func(t *testing.T) {
+		chain := makeChain(rng, maxHashWalk+100)
+		end := uint64(len(chain) - 1)
+		src := newTestSource("a", chain)
+		rcv := &testReceiver{}
+		m := newTestManager(t, 0, []Source{src}, rcv)
+		// only the top of the gap is filled, the next request will have a lower sync target
+		m.onRangeRequest(context.Background(), rangeRequest{start: ref(chain[10]), end: ref(chain[end])})
+		rcv.requireRange(t, chain, end-maxHashWalk, end)
+	})
+
+	t.Run("partial", func(t *testing.T) {
+		// the source does not have the lower part of the gap: the verified upper part is still delivered
+		src := newTestSource("a", chain[15:])
+		rcv := &testReceiver{}
+		m := newTestManager(t, 0, []Source{src}, rcv)
+		m.onRangeRequest(context.Background(), rangeRequest{start: ref(chain[10]), end: ref(chain[20])})
+		rcv.requireRange(t, chain, 15, 20)
+	})
+}
+
+func TestSyncByNumber(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := makeChain(rng, 100)
+	target := uint64(len(chain) - 1)
+
+	t.Run("open-ended", func(t *testing.T) {
+		src := newTestSource("a", chain)
+		rcv := &testReceiver{}
+		m := newTestManager(t, target, []Source{src}, rcv)
+		m.onRangeRequest(context.Background(), rangeRequest{start: ref(chain[10])})
+		rcv.requireRange(t, chain, 11, target+1)
+	})
+
+	t.Run("reject fork", func(t *testing.T) {
+		// the fork source serves a chain that does not build on the unsafe head
+		forked := newTestSource("fork", forkChain(rng, chain, 5))
+		good := newTestSource("good", chain)
+		rcv := &testReceiver{}
+		m := newTestManager(t, target, []Source{forked, good}, rcv)
+		m.scores.update("fork", 5)
+		m.onRangeRequest(context.Background(), rangeRequest{start: ref(chain[10])})
+		rcv.requireRange(t, chain, 11, target+1)
+		require.Less(t, m.scores.get("fork"), 0)
+	})
+
+	t.Run("no sources", func(t *testing.T) {
+		rcv := &testReceiver{}
+		m := newTestManager(t, target, nil, rcv)
+		m.onRangeRequest(context.Background(), rangeRequest{start: ref(chain[10])})
+		require.Empty(t, rcv.payloads)
+	})
+}
+
+func TestSourceScores(t *testing.T) {
+	scores := newSourceScores()
+	var sources []Source
+	for i := 0; i < 5; i++ {
+		sources = append(sources, newTestSource(peer.ID(fmt.Sprintf("peer-%d", i)), nil))
+	}
+	scores.update("peer-3", 2)
+	scores.update("peer-1", scoreInvalid)
+	ranked := scores.rank(sources)
+	require.Equal(t, peer.ID("peer-3"), ranked[0].Peer())
+	require.Equal(t, peer.ID("peer-1"), ranked[len(ranked)-1].Peer())
+
+	for i := 0; i < 100; i++ {
+		scores.update("peer-3", scoreValid)
+		scores.update("peer-1", scoreInvalid)
+	}
+	require.Equal(t, maxScore, scores.get("peer-3"))
+	require.Equal(t, minScore, scores.get("peer-1"))
+
+	// pruning drops the scores of removed sources, unless negative
+	scores.prune(sources[:1])
+	require.Zero(t, scores.get("peer-3"))
+	require.Equal(t, minScore, scores.get("peer-1"))
+}
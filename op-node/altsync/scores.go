@@ -0,0 +1,81 @@
+package altsync
+
+import (
+	"math/rand"
+	"sort"
+	"sync"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+const (
+	scoreValid    = 1
+	scoreNotFound = -1
+	scoreError    = -2
+	scoreInvalid  = -10
+
+	maxScore = 10
+	minScore = -100
+)
+
+// sourceScores keeps track of how well each source serves us.
+// Sources that serve us well are tried first; sources that served invalid data are tried last.
+// Scores are bounded, so a source can recover from a bad period, and a good source cannot build up unlimited credit.
+type sourceScores struct {
+	lock   sync.Mutex
+	scores map[peer.ID]int
+}
+
+func newSourceScores() *sourceScores {
+	return &sourceScores{scores: make(map[peer.ID]int)}
+}
+
+func (s *sourceScores) update(id peer.ID, delta int) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	score := s.scores[id] + delta
+	if score > maxScore {
+		score = maxScore
+	} else if score < minScore {
+		score = minScore
+	}
+	s.scores[id] = score
+}
+
+func (s *sourceScores) get(id peer.ID) int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.scores[id]
+}
+
+// rank orders the sources from highest to lowest score. Sources with an equal score are shuffled,
+// to spread the load between them.
+func (s *sourceScores) rank(sources []Source) []Source {
+	out := make([]Source, len(sources))
+	copy(out, sources)
+	rand.Shuffle(len(out), func(i, j int) {
+		out[i], out[j] = out[j], out[i]
+	})
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	sort.SliceStable(out, func(i, j int) bool {
+		return s.scores[out[i].Peer()] > s.scores[out[j].Peer()]
+	})
+	return out
+}
+
+// prune drops the scores of sources that are no longer available.
+// Negative scores are retained, so a misbehaving peer cannot reset its score by reconnecting.
+func (s *sourceScores) prune(sources []Source) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	keep := make(map[peer.ID]struct{}, len(sources))
+	for _, src := range sources {
+		keep[src.Peer()] = struct{}{}
+	}
+	for id, score := range s.scores {
+		if _, ok := keep[id]; !ok && score >= 0 {
+			delete(s.scores, id)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package altsync
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/sources"
+)
+
+// Source is a source of unsafe L2 payloads to fill gaps in the unsafe chain with.
+// Sources do not have to be trusted: all payloads are verified by the Manager before they are passed on.
+type Source interface {
+	// Peer identifies the source, for scoring, and when passing on payloads to the receiver.
+	Peer() peer.ID
+	// Kind describes the type of source, for metrics: "rpc" or "p2p".
+	Kind() string
+	// PayloadByHash returns the payload with the given block hash, or ethereum.NotFound if the source does not have it.
+	PayloadByHash(ctx context.Context, hash common.Hash) (*eth.ExecutionPayload, error)
+	// PayloadsByNumber returns up to count consecutive payloads, starting at the given block number.
+	// The result may be shorter than requested, but not empty without error.
+	PayloadsByNumber(ctx context.Context, start uint64, count uint64) ([]*eth.ExecutionPayload, error)
+}
+
+// SourcesFn returns the currently available sources to sync from.
+type SourcesFn func() []Source
+
+// L2Payloads is the subset of the L2 RPC client used by an RPCSource.
+type L2Payloads interface {
+	PayloadByHash(ctx context.Context, hash common.Hash) (*eth.ExecutionPayload, error)
+	PayloadByNumber(ctx context.Context, number uint64) (*eth.ExecutionPayload, error)
+}
+
+// RPCSource fetches payloads from an L2 execution-engine RPC, like a backup L2 node.
+type RPCSource struct {
+	id peer.ID
+	cl L2Payloads
+}
+
+var _ Source = (*RPCSource)(nil)
+
+// NewRPCSource creates a source for the given L2 RPC client.
+// The first RPC source is identified as sources.RpcSyncPeer, like the single backup sync RPC was before.
+// Additional RPC sources get an index suffix, to distinguish them for scoring purposes.
+func NewRPCSource(index int, cl L2Payloads) *RPCSource {
+	id := sources.RpcSyncPeer
+	if index > 0 {
+		id = peer.ID(fmt.Sprintf("%s/%d", sources.RpcSyncPeer, index))
+	}
+	return &RPCSource{id: id, cl: cl}
+}
+
+func (s *RPCSource) Peer() peer.ID {
+	return s.id
+}
+
+func (s *RPCSource) Kind() string {
+	return "rpc"
+}
+
+func (s *RPCSource) PayloadByHash(ctx context.Context, hash common.Hash) (*eth.ExecutionPayload, error) {
+	return s.cl.PayloadByHash(ctx, hash)
+}
+
+func (s *RPCSource) PayloadsByNumber(ctx context.Context, start uint64, count uint64) ([]*eth.ExecutionPayload, error) {
+	if count > maxRPCBatch {
+		count = maxRPCBatch
+	}
+	out := make([]*eth.ExecutionPayload, 0, count)
+	for i := uint64(0); i < count; i++ {
+		payload, err := s.cl.PayloadByNumber(ctx, start+i)
+		if err != nil {
+			// return what we have, and let the next request start at the error
+			if i > 0 {
+				return out, nil
+			}
+			return nil, err
+		}
+		out = append(out, payload)
+	}
+	return out, nil
+}
+
+// PeerSyncClient is the subset of the P2P sync client used by a PeerSource.
+type PeerSyncClient interface {
+	PayloadByHash(ctx context.Context, id peer.ID, hash common.Hash) (*eth.ExecutionPayload, error)
+	PayloadsByRange(ctx context.Context, id peer.ID, start uint64, count uint64) ([]*eth.ExecutionPayload, error)
+}
+
+// PeerSource fetches payloads from a single P2P peer, with the req-resp sync protocols.
+type PeerSource struct {
+	id peer.ID
+	cl PeerSyncClient
+}
+
+var _ Source = (*PeerSource)(nil)
+
+func NewPeerSource(id peer.ID, cl PeerSyncClient) *PeerSource {
+	return &PeerSource{id: id, cl: cl}
+}
+
+func (s *PeerSource) Peer() peer.ID {
+	return s.id
+}
+
+func (s *PeerSource) Kind() string {
+	return "p2p"
+}
+
+func (s *PeerSource) PayloadByHash(ctx context.Context, hash common.Hash) (*eth.ExecutionPayload, error) {
+	return s.cl.PayloadByHash(ctx, s.id, hash)
+}
+
+func (s *PeerSource) PayloadsByNumber(ctx context.Context, start uint64, count uint64) ([]*eth.ExecutionPayload, error) {
+	return s.cl.PayloadsByRange(ctx, s.id, start, count)
+}
@@ -104,6 +104,10 @@ var Subcommands = cli.Commands{
 				Name:  "deployment-dir",
 				Usage: "Path to network deployment directory",
 			},
+			&cli.StringFlag{
+				Name:  "l1-deployments",
+				Usage: "Path to L1 deployments file, used instead of the network deployment directory",
+			},
 			&cli.StringFlag{
 				Name:  "outfile.l2",
 				Usage: "Path to L2 genesis output file",
@@ -121,21 +125,30 @@ var Subcommands = cli.Commands{
 				return err
 			}
 
-			deployDir := ctx.String("deployment-dir")
-			if deployDir == "" {
-				return errors.New("Must specify --deployment-dir")
-			}
+			if l1Deployments := ctx.String("l1-deployments"); l1Deployments != "" {
+				log.Info("L1 deployments", "path", l1Deployments)
+				deployments, err := genesis.NewL1Deployments(l1Deployments)
+				if err != nil {
+					return err
+				}
+				config.SetDeployments(deployments)
+			} else {
+				deployDir := ctx.String("deployment-dir")
+				if deployDir == "" {
+					return errors.New("Must specify --deployment-dir or --l1-deployments")
+				}
 
-			log.Info("Deployment directory", "path", deployDir)
-			depPath, network := filepath.Split(deployDir)
-			hh, err := hardhat.New(network, nil, []string{depPath})
-			if err != nil {
-				return err
-			}
+				log.Info("Deployment directory", "path", deployDir)
+				depPath, network := filepath.Split(deployDir)
+				hh, err := hardhat.New(network, nil, []string{depPath})
+				if err != nil {
+					return err
+				}
 
-			// Read the appropriate deployment addresses from disk
-			if err := config.GetDeployedAddresses(hh); err != nil {
-				return err
+				// Read the appropriate deployment addresses from disk
+				if err := config.GetDeployedAddresses(hh); err != nil {
+					return err
+				}
 			}
 
 			client, err := ethclient.Dial(ctx.String("l1-rpc"))
@@ -0,0 +1,111 @@
+function prettyHex(hash) {
+    return `${hash.slice(0, 8)}..${hash.slice(56)}`;
+}
+
+function blockRef(v) {
+    if (!v || v["hash"] === undefined) {
+        return "-";
+    }
+    return `<code title="${v["hash"]}">${v["number"]} (${prettyHex(v["hash"])})</code>`;
+}
+
+function table(headers, rows) {
+    let out = `<table class="table table-sm table-bordered mb-0"><thead><tr>`;
+    for (const h of headers) {
+        out += `<th>${h}</th>`;
+    }
+    out += `</tr></thead><tbody>`;
+    if (rows.length === 0) {
+        out += `<tr><td colspan="${headers.length}"><em>empty</em></td></tr>`;
+    }
+    for (const r of rows) {
+        out += `<tr>${r.map(c => `<td>${c}</td>`).join("")}</tr>`;
+    }
+    out += `</tbody></table>`;
+    return out;
+}
+
+function stage(name, index, resetting, body) {
+    const cls = index >= resetting ? "stage-resetting" : "";
+    const badge = index === resetting ? ` <span class="badge bg-warning">resetting</span>` : "";
+    return `<div class="col-12 col-xl-6 mb-2"><div class="card ${cls}">
+        <div class="card-header">${name}${badge}</div>
+        <div class="card-body p-1">${body}</div></div></div>`;
+}
+
+// Stage indices, in the order of the reset of the pipeline.
+const stageIndex = {
+    engineQueue: 0,
+    l1Traversal: 1,
+    frameQueue: 3,
+    channelBank: 4,
+    batchQueue: 6,
+};
+
+function renderStatus(state) {
+    const el = $("#status");
+    if (state.err) {
+        el.html(`<div class="alert alert-danger mb-0">${state.time}: ${state.err}</div>`);
+        return;
+    }
+    const s = state.syncStatus;
+    el.html(table(["updated", "L1 head", "L1 current", "L1 safe", "L1 finalized", "unsafe L2", "safe L2", "finalized L2"],
+        [[state.time, blockRef(s.head_l1), blockRef(s.current_l1), blockRef(s.safe_l1), blockRef(s.finalized_l1),
+            blockRef(s.unsafe_l2), blockRef(s.safe_l2), blockRef(s.finalized_l2)]]));
+}
+
+function renderPipeline(p) {
+    const r = Math.min(p.resetting, p.stagesCount);
+    let out = "";
+
+    out += stage("L1 traversal", stageIndex.l1Traversal, r,
+        table(["origin", "done"], [[blockRef(p.l1Traversal.origin), p.l1Traversal.done]]));
+
+    out += stage("Frame queue", stageIndex.frameQueue, r,
+        table(["channel", "frame", "data", "last"],
+            p.frameQueue.frames.map(f => [`<code>${f.id}</code>`, f.frameNumber, f.dataLen, f.isLast])));
+
+    const cb = p.channelBank;
+    out += stage(`Channel bank (${cb.totalSize} bytes)`, stageIndex.channelBank, r,
+        `<div>origin: ${blockRef(cb.origin)}</div>` +
+        table(["channel", "opened", "timeout", "frames", "size", "closed", "ready"],
+            cb.channels.map(c => [`<code>${c.id}</code>`, blockRef(c.openBlock), c.timeoutBlock,
+                `${c.framesCount} (highest ${c.highestFrameNumber})`, c.size, c.closed, c.ready])));
+
+    const bq = p.batchQueue;
+    out += stage("Batch queue", stageIndex.batchQueue, r,
+        `<div>origin: ${blockRef(bq.origin)}, L1 window: ${bq.l1Blocks.length} blocks</div>` +
+        table(["type", "timestamp", "epoch", "txs", "included in"],
+            (bq.batches || []).map(b => [b.batchType, b.timestamp, b.epochNum || "-", b.txCount || 0,
+                blockRef(b.l1InclusionBlock)])));
+
+    const eq = p.engineQueue;
+    let attrs = "<em>none</em>";
+    if (eq.safeAttributes) {
+        const a = eq.safeAttributes;
+        attrs = `parent ${blockRef(a.parent)}, time ${a.timestamp}, ${a.txCount} txs, gas limit ${a.gasLimit}`;
+    }
+    out += stage("Engine queue", stageIndex.engineQueue, r,
+        table(["origin", "finalized L1", "finalized", "safe", "unsafe", "sync target", "building onto"],
+            [[blockRef(eq.origin), blockRef(eq.finalizedL1), blockRef(eq.finalized), blockRef(eq.safeHead),
+                blockRef(eq.unsafeHead), blockRef(eq.engineSyncTarget),
+                `${blockRef(eq.buildingOnto)}${eq.buildingSafe ? " (safe)" : ""}`]]) +
+        `<div>pending safe attributes: ${attrs}</div>` +
+        `<div>unsafe payloads (${eq.unsafePayloadsMemSize} bytes):</div>` +
+        table(["number", "hash", "parent", "timestamp", "txs"],
+            (eq.unsafePayloads || []).map(u => [u.number, `<code>${prettyHex(u.hash)}</code>`,
+                `<code>${prettyHex(u.parentHash)}</code>`, u.timestamp, u.txCount])));
+
+    $("#pipeline").html(out);
+}
+
+function render(state) {
+    renderStatus(state);
+    if (state.pipeline) {
+        renderPipeline(state.pipeline);
+    }
+}
+
+const source = new EventSource("/pipeline/stream");
+source.onmessage = (e) => render(JSON.parse(e.data));
+source.onerror = () => $("#status").html(`<div class="alert alert-warning mb-0">disconnected, reconnecting...</div>`);
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/sources"
+)
+
+// LiveState is a single observation of a running rollup node.
+type LiveState struct {
+	Time       time.Time             `json:"time"`
+	SyncStatus *eth.SyncStatus       `json:"syncStatus"`
+	Pipeline   *derive.PipelineState `json:"pipeline"`
+	Err        string                `json:"err,omitempty"`
+}
+
+// livePoller polls the derivation pipeline state of a rollup node,
+// and fans out every observation to the connected stream subscribers.
+type livePoller struct {
+	log    log.Logger
+	client *sources.RollupClient
+
+	mu     sync.Mutex
+	latest *LiveState
+	subs   map[chan *LiveState]struct{}
+}
+
+func newLivePoller(ctx context.Context, log log.Logger, addr string) (*livePoller, error) {
+	rpc, err := client.NewRPC(ctx, log, addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial rollup node: %w", err)
+	}
+	return &livePoller{
+		log:    log,
+		client: sources.NewRollupClient(rpc),
+		subs:   make(map[chan *LiveState]struct{}),
+	}, nil
+}
+
+func (p *livePoller) run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		p.poll(ctx, interval)
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (p *livePoller) poll(ctx context.Context, timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	state := &LiveState{Time: time.Now()}
+	status, err := p.client.SyncStatus(ctx)
+	if err == nil {
+		state.SyncStatus = status
+		state.Pipeline, err = p.client.PipelineState(ctx)
+	}
+	if err != nil {
+		p.log.Warn("failed to poll rollup node", "err", err)
+		state.Err = err.Error()
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.latest = state
+	for sub := range p.subs {
+		select {
+		case sub <- state:
+		default:
+			// the subscriber is slow, it will catch up with the next observation
+		}
+	}
+}
+
+func (p *livePoller) subscribe() chan *LiveState {
+	ch := make(chan *LiveState, 1)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.subs[ch] = struct{}{}
+	if p.latest != nil {
+		ch <- p.latest
+	}
+	return ch
+}
+
+func (p *livePoller) unsubscribe(ch chan *LiveState) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.subs, ch)
+}
+
+// pipelineHandler serves the latest observation of the pipeline state.
+func (p *livePoller) pipelineHandler(w http.ResponseWriter, r *http.Request) {
+	p.mu.Lock()
+	latest := p.latest
+	p.mu.Unlock()
+	if latest == nil {
+		http.Error(w, "no pipeline state available yet", http.StatusServiceUnavailable)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-cache")
+	if err := json.NewEncoder(w).Encode(latest); err != nil {
+		log.Warn("failed to encode pipeline state", "message", err)
+	}
+}
+
+// streamHandler pushes every new observation of the pipeline state as server-sent event.
+func (p *livePoller) streamHandler(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	sub := p.subscribe()
+	defer p.unsubscribe(sub)
+	for {
+		select {
+		case state := <-sub:
+			data, err := json.Marshal(state)
+			if err != nil {
+				log.Warn("failed to encode pipeline state", "message", err)
+				return
+			}
+			if _, err := fmt.Fprintf(w, "data: %s\n\n", data); err != nil {
+				return
+			}
+			flusher.Flush()
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"bufio"
 	"compress/gzip"
+	"context"
 	"embed"
 	"encoding/json"
 	"errors"
@@ -27,6 +28,8 @@ var (
 	snapshot   = flag.String("snapshot", "", "path to snapshot log")
 	listenAddr = flag.String("addr", "", "listen address of webserver")
 	refresh    = flag.Duration("refresh", 10*time.Second, "snapshot refresh rate")
+	rollupRPC  = flag.String("rollup-rpc", "", "rollup node RPC to inspect the derivation pipeline of, requires the admin API")
+	pollRate   = flag.Duration("poll", 2*time.Second, "rollup node pipeline poll rate")
 )
 
 var (
@@ -34,6 +37,8 @@ var (
 	entriesMutex sync.Mutex
 
 	assetFS fs.FS
+
+	live *livePoller
 )
 
 type SnapshotState struct {
@@ -98,8 +103,8 @@ func main() {
 		log.LvlFilterHandler(log.LvlDebug, log.StreamHandler(os.Stdout, log.TerminalFormat(true))),
 	)
 
-	if *snapshot == "" {
-		log.Crit("missing required -snapshot flag")
+	if *snapshot == "" && *rollupRPC == "" {
+		log.Crit("missing required -snapshot or -rollup-rpc flag")
 	}
 
 	sub, err := fs.Sub(embeddedAssets, "assets")
@@ -108,17 +113,27 @@ func main() {
 	}
 	assetFS = sub
 
-	go func() {
-		ticker := time.NewTicker(*refresh)
-		defer ticker.Stop()
-		for range ticker.C {
-			// TODO: incremental load
-			log.Info("loading snapshot...")
-			if err := loadSnapshot(); err != nil {
-				log.Error("failed to load snapshot", "err", err)
+	if *snapshot != "" {
+		go func() {
+			ticker := time.NewTicker(*refresh)
+			defer ticker.Stop()
+			for range ticker.C {
+				// TODO: incremental load
+				log.Info("loading snapshot...")
+				if err := loadSnapshot(); err != nil {
+					log.Error("failed to load snapshot", "err", err)
+				}
 			}
+		}()
+	}
+
+	if *rollupRPC != "" {
+		live, err = newLivePoller(context.Background(), log.Root(), *rollupRPC)
+		if err != nil {
+			log.Crit("Failed to connect to rollup node", "message", err)
 		}
-	}()
+		go live.run(context.Background(), *pollRate)
+	}
 
 	runServer()
 }
@@ -160,6 +175,10 @@ func runServer() {
 	mux := http.NewServeMux()
 	mux.Handle("/", http.FileServer(http.FS(assetFS)))
 	mux.HandleFunc("/logs", makeGzipHandler(logsHandler))
+	if live != nil {
+		mux.HandleFunc("/pipeline", live.pipelineHandler)
+		mux.HandleFunc("/pipeline/stream", live.streamHandler)
+	}
 
 	log.Info("running webserver...")
 	httpServer := ophttp.NewHttpServer(mux)
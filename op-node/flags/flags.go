@@ -208,9 +208,9 @@ var (
 		EnvVars: prefixEnvVars("HEARTBEAT_URL"),
 		Value:   "https://heartbeat.optimism.io",
 	}
-	BackupL2UnsafeSyncRPC = &cli.StringFlag{
+	BackupL2UnsafeSyncRPC = &cli.StringSliceFlag{
 		Name:     "l2.backup-unsafe-sync-rpc",
-		Usage:    "Set the backup L2 unsafe sync RPC endpoint.",
+		Usage:    "Set the backup L2 unsafe sync RPC endpoints. May be repeated, or comma-separated, to sync from multiple endpoints.",
 		EnvVars:  prefixEnvVars("L2_BACKUP_UNSAFE_SYNC_RPC"),
 		Required: false,
 	}
@@ -221,6 +221,19 @@ var (
 		EnvVars:  prefixEnvVars("L2_BACKUP_UNSAFE_SYNC_RPC_TRUST_RPC"),
 		Required: false,
 	}
+	L2ShadowEngineAddr = &cli.StringFlag{
+		Name: "l2.shadow",
+		Usage: "Address of a secondary L2 Engine JSON-RPC endpoint, to mirror all engine API calls to and compare the results with. " +
+			"The secondary engine never affects the primary L2 chain, the node runs without it if it cannot be set up. Disabled if empty.",
+		EnvVars:  prefixEnvVars("L2_SHADOW_ENGINE_RPC"),
+		Required: false,
+	}
+	L2ShadowEngineJWTSecret = &cli.StringFlag{
+		Name:     "l2.shadow.jwt-secret",
+		Usage:    "Path to JWT secret key of the secondary L2 Engine. Defaults to the l2.jwt-secret key if empty.",
+		EnvVars:  prefixEnvVars("L2_SHADOW_ENGINE_AUTH"),
+		Required: false,
+	}
 	L2EngineSyncEnabled = &cli.BoolFlag{
 		Name:     "l2.engine-sync",
 		Usage:    "Enables or disables execution engine P2P sync",
@@ -236,6 +249,19 @@ var (
 		Required: false,
 		Value:    false,
 	}
+	PipelineCheckpointFile = &cli.StringFlag{
+		Name: "pipeline.checkpoint-file",
+		Usage: "File path used to periodically checkpoint the derivation pipeline state, to restore it on restart " +
+			"instead of re-deriving from a full channel timeout back. Compressed with gzip if the path ends with .gz. Disabled if not set.",
+		EnvVars: prefixEnvVars("PIPELINE_CHECKPOINT_FILE"),
+	}
+	PipelineCheckpointInterval = &cli.Uint64Flag{
+		Name:     "pipeline.checkpoint-interval",
+		Usage:    "Number of L1 blocks to traverse between derivation pipeline checkpoints.",
+		EnvVars:  prefixEnvVars("PIPELINE_CHECKPOINT_INTERVAL"),
+		Required: false,
+		Value:    10,
+	}
 	BetaExtraNetworks = &cli.BoolFlag{
 		Name: "beta.extra-networks",
 		Usage: fmt.Sprintf("Beta feature: enable selection of a predefined-network from the superchain-registry. "+
@@ -297,8 +323,12 @@ var optionalFlags = []cli.Flag{
 	HeartbeatURLFlag,
 	BackupL2UnsafeSyncRPC,
 	BackupL2UnsafeSyncRPCTrustRPC,
+	L2ShadowEngineAddr,
+	L2ShadowEngineJWTSecret,
 	L2EngineSyncEnabled,
 	SkipSyncStartCheck,
+	PipelineCheckpointFile,
+	PipelineCheckpointInterval,
 	BetaExtraNetworks,
 	RollupHalt,
 	RollupLoadProtocolVersions,
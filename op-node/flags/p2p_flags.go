@@ -14,45 +14,49 @@ func p2pEnv(envprefix, v string) []string {
 }
 
 var (
-	DisableP2PName         = "p2p.disable"
-	NoDiscoveryName        = "p2p.no-discovery"
-	ScoringName            = "p2p.scoring"
-	PeerScoringName        = "p2p.scoring.peers"
-	PeerScoreBandsName     = "p2p.score.bands"
-	BanningName            = "p2p.ban.peers"
-	BanningThresholdName   = "p2p.ban.threshold"
-	BanningDurationName    = "p2p.ban.duration"
-	TopicScoringName       = "p2p.scoring.topics"
-	P2PPrivPathName        = "p2p.priv.path"
-	P2PPrivRawName         = "p2p.priv.raw"
-	ListenIPName           = "p2p.listen.ip"
-	ListenTCPPortName      = "p2p.listen.tcp"
-	ListenUDPPortName      = "p2p.listen.udp"
-	AdvertiseIPName        = "p2p.advertise.ip"
-	AdvertiseTCPPortName   = "p2p.advertise.tcp"
-	AdvertiseUDPPortName   = "p2p.advertise.udp"
-	BootnodesName          = "p2p.bootnodes"
-	StaticPeersName        = "p2p.static"
-	NetRestrictName        = "p2p.netrestrict"
-	HostMuxName            = "p2p.mux"
-	HostSecurityName       = "p2p.security"
-	PeersLoName            = "p2p.peers.lo"
-	PeersHiName            = "p2p.peers.hi"
-	PeersGraceName         = "p2p.peers.grace"
-	NATName                = "p2p.nat"
-	UserAgentName          = "p2p.useragent"
-	TimeoutNegotiationName = "p2p.timeout.negotiation"
-	TimeoutAcceptName      = "p2p.timeout.accept"
-	TimeoutDialName        = "p2p.timeout.dial"
-	PeerstorePathName      = "p2p.peerstore.path"
-	DiscoveryPathName      = "p2p.discovery.path"
-	SequencerP2PKeyName    = "p2p.sequencer.key"
-	GossipMeshDName        = "p2p.gossip.mesh.d"
-	GossipMeshDloName      = "p2p.gossip.mesh.lo"
-	GossipMeshDhiName      = "p2p.gossip.mesh.dhi"
-	GossipMeshDlazyName    = "p2p.gossip.mesh.dlazy"
-	GossipFloodPublishName = "p2p.gossip.mesh.floodpublish"
-	SyncReqRespName        = "p2p.sync.req-resp"
+	DisableP2PName              = "p2p.disable"
+	NoDiscoveryName             = "p2p.no-discovery"
+	ScoringName                 = "p2p.scoring"
+	PeerScoringName             = "p2p.scoring.peers"
+	PeerScoreBandsName          = "p2p.score.bands"
+	BanningName                 = "p2p.ban.peers"
+	BanningThresholdName        = "p2p.ban.threshold"
+	BanningDurationName         = "p2p.ban.duration"
+	BanPolicyName               = "p2p.ban.policy"
+	BanPolicyWindowName         = "p2p.ban.policy.window"
+	BanPolicyIPDurationName     = "p2p.ban.policy.ip-duration"
+	BanPolicySubnetDurationName = "p2p.ban.policy.subnet-duration"
+	TopicScoringName            = "p2p.scoring.topics"
+	P2PPrivPathName             = "p2p.priv.path"
+	P2PPrivRawName              = "p2p.priv.raw"
+	ListenIPName                = "p2p.listen.ip"
+	ListenTCPPortName           = "p2p.listen.tcp"
+	ListenUDPPortName           = "p2p.listen.udp"
+	AdvertiseIPName             = "p2p.advertise.ip"
+	AdvertiseTCPPortName        = "p2p.advertise.tcp"
+	AdvertiseUDPPortName        = "p2p.advertise.udp"
+	BootnodesName               = "p2p.bootnodes"
+	StaticPeersName             = "p2p.static"
+	NetRestrictName             = "p2p.netrestrict"
+	HostMuxName                 = "p2p.mux"
+	HostSecurityName            = "p2p.security"
+	PeersLoName                 = "p2p.peers.lo"
+	PeersHiName                 = "p2p.peers.hi"
+	PeersGraceName              = "p2p.peers.grace"
+	NATName                     = "p2p.nat"
+	UserAgentName               = "p2p.useragent"
+	TimeoutNegotiationName      = "p2p.timeout.negotiation"
+	TimeoutAcceptName           = "p2p.timeout.accept"
+	TimeoutDialName             = "p2p.timeout.dial"
+	PeerstorePathName           = "p2p.peerstore.path"
+	DiscoveryPathName           = "p2p.discovery.path"
+	SequencerP2PKeyName         = "p2p.sequencer.key"
+	GossipMeshDName             = "p2p.gossip.mesh.d"
+	GossipMeshDloName           = "p2p.gossip.mesh.lo"
+	GossipMeshDhiName           = "p2p.gossip.mesh.dhi"
+	GossipMeshDlazyName         = "p2p.gossip.mesh.dlazy"
+	GossipFloodPublishName      = "p2p.gossip.mesh.floodpublish"
+	SyncReqRespName             = "p2p.sync.req-resp"
 )
 
 // None of these flags are strictly required.
@@ -113,6 +117,34 @@ func P2PFlags(envPrefix string) []cli.Flag {
 			Value:    1 * time.Hour,
 			EnvVars:  p2pEnv(envPrefix, "PEER_BANNING_DURATION"),
 		},
+		&cli.BoolFlag{
+			Name: BanPolicyName,
+			Usage: "Enables the misbehaviour-based ban policy: repeated invalid payloads, failed responses, rate-limit violations and invalid requests " +
+				"escalate from descoring, to banning the peer for a doubling duration, to banning its IP and eventually its subnet.",
+			Required: false,
+			EnvVars:  p2pEnv(envPrefix, "BAN_POLICY"),
+		},
+		&cli.DurationFlag{
+			Name:     BanPolicyWindowName,
+			Usage:    "The duration that misbehaviour and bans count towards escalation of the ban policy.",
+			Required: false,
+			Value:    1 * time.Hour,
+			EnvVars:  p2pEnv(envPrefix, "BAN_POLICY_WINDOW"),
+		},
+		&cli.DurationFlag{
+			Name:     BanPolicyIPDurationName,
+			Usage:    "The duration that IPs are banned for by the ban policy.",
+			Required: false,
+			Value:    24 * time.Hour,
+			EnvVars:  p2pEnv(envPrefix, "BAN_POLICY_IP_DURATION"),
+		},
+		&cli.DurationFlag{
+			Name:     BanPolicySubnetDurationName,
+			Usage:    "The duration that subnets are banned for by the ban policy.",
+			Required: false,
+			Value:    24 * time.Hour,
+			EnvVars:  p2pEnv(envPrefix, "BAN_POLICY_SUBNET_DURATION"),
+		},
 		&cli.StringFlag{
 			Name:     TopicScoringName,
 			Usage:    fmt.Sprintf("Deprecated: Use %v instead", ScoringName),
@@ -5,42 +5,122 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
 
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/log"
 )
 
 // SendInterval determines the delay between requests. This must be larger than the MinHeartbeatInterval in the server.
 const SendInterval = 10 * time.Minute
 
+// SigningDomain prefixes the signed heartbeat message, to prevent signatures over other data from being replayed as heartbeats.
+const SigningDomain = "optimism-heartbeat-v1:"
+
 type Payload struct {
 	Version string `json:"version"`
 	Meta    string `json:"meta"`
 	Moniker string `json:"moniker"`
 	PeerID  string `json:"peerID"`
 	ChainID uint64 `json:"chainID"`
+	// Timestamp is the unix time the payload was signed at, to limit the replay of signed payloads. Only set on signed payloads.
+	Timestamp uint64 `json:"timestamp,omitempty"`
+	// Signature is the signature over the payload by the p2p identity key of the PeerID.
+	Signature hexutil.Bytes `json:"signature,omitempty"`
+}
+
+// SigningMessage returns the message that is signed, i.e. the domain-prefixed JSON encoding of the payload without signature.
+func (p *Payload) SigningMessage() ([]byte, error) {
+	unsigned := *p
+	unsigned.Signature = nil
+	data, err := json.Marshal(&unsigned)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(SigningDomain), data...), nil
+}
+
+// Sign sets the timestamp and signs the payload with the given p2p identity key.
+// The PeerID of the payload is set to the peer ID of the key.
+func (p *Payload) Sign(priv crypto.PrivKey, now time.Time) error {
+	id, err := peer.IDFromPrivateKey(priv)
+	if err != nil {
+		return fmt.Errorf("failed to derive peer ID: %w", err)
+	}
+	p.PeerID = id.String()
+	p.Timestamp = uint64(now.Unix())
+	msg, err := p.SigningMessage()
+	if err != nil {
+		return err
+	}
+	sig, err := priv.Sign(msg)
+	if err != nil {
+		return fmt.Errorf("failed to sign heartbeat: %w", err)
+	}
+	p.Signature = sig
+	return nil
+}
+
+// Verify checks that the payload is signed by the p2p identity key of its PeerID.
+func (p *Payload) Verify() error {
+	if len(p.Signature) == 0 {
+		return errors.New("payload is not signed")
+	}
+	id, err := peer.Decode(p.PeerID)
+	if err != nil {
+		return fmt.Errorf("invalid peer ID: %w", err)
+	}
+	pub, err := id.ExtractPublicKey()
+	if err != nil {
+		return fmt.Errorf("failed to extract public key from peer ID: %w", err)
+	}
+	msg, err := p.SigningMessage()
+	if err != nil {
+		return err
+	}
+	ok, err := pub.Verify(msg, p.Signature)
+	if err != nil {
+		return fmt.Errorf("failed to verify signature: %w", err)
+	}
+	if !ok {
+		return errors.New("invalid signature")
+	}
+	return nil
 }
 
 // Beat sends a heartbeat to the server at the given URL. It will send a heartbeat immediately, and then every SendInterval.
 // Beat spawns a goroutine that will send heartbeats until the context is canceled.
+// If a p2p identity key is given, every heartbeat is signed with it. Otherwise the heartbeats are sent unsigned.
 func Beat(
 	ctx context.Context,
 	log log.Logger,
 	url string,
 	payload *Payload,
+	priv crypto.PrivKey,
 ) error {
-	payloadJSON, err := json.Marshal(payload)
-	if err != nil {
-		return fmt.Errorf("telemetry crashed: %w", err)
-	}
-
 	client := &http.Client{
 		Timeout: 10 * time.Second,
 	}
 
 	send := func() {
+		signed := *payload
+		if priv != nil {
+			if err := signed.Sign(priv, time.Now()); err != nil {
+				log.Error("error signing heartbeat", "err", err)
+				return
+			}
+		}
+		payloadJSON, err := json.Marshal(&signed)
+		if err != nil {
+			log.Error("error encoding heartbeat", "err", err)
+			return
+		}
 		req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(payloadJSON))
 		req.Header.Set("User-Agent", fmt.Sprintf("op-node/%s", payload.Version))
 		req.Header.Set("Content-Type", "application/json")
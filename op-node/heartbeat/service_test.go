@@ -2,12 +2,16 @@ package heartbeat
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/json"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
 
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/stretchr/testify/require"
 
 	"github.com/ethereum/go-ethereum/log"
@@ -43,7 +47,7 @@ func TestBeat(t *testing.T) {
 			Moniker: "yeet",
 			PeerID:  "1UiUfoobar",
 			ChainID: 1234,
-		})
+		}, nil)
 		doneCh <- struct{}{}
 	}()
 
@@ -56,3 +60,94 @@ func TestBeat(t *testing.T) {
 		t.Fatalf("error: %v", ctx.Err())
 	}
 }
+
+func TestBeatSigned(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	priv, _, err := crypto.GenerateSecp256k1Key(rand.Reader)
+	require.NoError(t, err)
+	id, err := peer.IDFromPrivateKey(priv)
+	require.NoError(t, err)
+
+	reqCh := make(chan []byte, 2)
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(204)
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		reqCh <- body
+		r.Body.Close()
+	}))
+	defer s.Close()
+
+	doneCh := make(chan struct{})
+	go func() {
+		_ = Beat(ctx, log.Root(), s.URL, &Payload{
+			Version: "v1.2.3",
+			Meta:    "meta",
+			Moniker: "yeet",
+			PeerID:  id.String(),
+			ChainID: 1234,
+		}, priv)
+		doneCh <- struct{}{}
+	}()
+
+	select {
+	case hb := <-reqCh:
+		var payload Payload
+		require.NoError(t, json.Unmarshal(hb, &payload))
+		require.Equal(t, id.String(), payload.PeerID)
+		require.NotZero(t, payload.Timestamp)
+		require.NoError(t, payload.Verify())
+		cancel()
+		<-doneCh
+	case <-ctx.Done():
+		t.Fatalf("error: %v", ctx.Err())
+	}
+}
+
+func TestVerify(t *testing.T) {
+	priv, _, err := crypto.GenerateSecp256k1Key(rand.Reader)
+	require.NoError(t, err)
+	otherPriv, _, err := crypto.GenerateSecp256k1Key(rand.Reader)
+	require.NoError(t, err)
+	otherID, err := peer.IDFromPrivateKey(otherPriv)
+	require.NoError(t, err)
+
+	signed := func() Payload {
+		p := Payload{Version: "v1.2.3", Meta: "meta", Moniker: "yeet", ChainID: 1234}
+		require.NoError(t, p.Sign(priv, time.Unix(1000, 0)))
+		return p
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		p := signed()
+		require.Equal(t, uint64(1000), p.Timestamp)
+		require.NoError(t, p.Verify())
+	})
+	t.Run("unsigned", func(t *testing.T) {
+		p := signed()
+		p.Signature = nil
+		require.ErrorContains(t, p.Verify(), "not signed")
+	})
+	t.Run("modified version", func(t *testing.T) {
+		p := signed()
+		p.Version = "v9.9.9"
+		require.ErrorContains(t, p.Verify(), "invalid signature")
+	})
+	t.Run("modified timestamp", func(t *testing.T) {
+		p := signed()
+		p.Timestamp += 1
+		require.ErrorContains(t, p.Verify(), "invalid signature")
+	})
+	t.Run("other peer ID", func(t *testing.T) {
+		p := signed()
+		p.PeerID = otherID.String()
+		require.ErrorContains(t, p.Verify(), "invalid signature")
+	})
+	t.Run("invalid peer ID", func(t *testing.T) {
+		p := signed()
+		p.PeerID = "disabled"
+		require.ErrorContains(t, p.Verify(), "invalid peer ID")
+	})
+}
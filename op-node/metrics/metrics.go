@@ -67,12 +67,19 @@ type Metricer interface {
 	SetPeerScores(allScores []store.PeerScores)
 	ClientPayloadByNumberEvent(num uint64, resultCode byte, duration time.Duration)
 	ServerPayloadByNumberEvent(num uint64, resultCode byte, duration time.Duration)
+	ClientPayloadsByRangeEvent(start uint64, count uint64, resultCode byte, duration time.Duration)
+	ServerPayloadsByRangeEvent(start uint64, count uint64, resultCode byte, duration time.Duration)
+	ClientPayloadByHashEvent(resultCode byte, duration time.Duration)
+	ServerPayloadByHashEvent(resultCode byte, duration time.Duration)
 	PayloadsQuarantineSize(n int)
+	RecordAltSyncResult(source string, result string)
 	RecordPeerUnban()
 	RecordIPUnban()
+	RecordBanPolicyDecision(action string)
 	RecordDial(allow bool)
 	RecordAccept(allow bool)
 	ReportProtocolVersions(local, engine, recommended, required params.ProtocolVersion)
+	RecordShadowEngineResult(method string, result string)
 }
 
 // Metrics tracks all the metrics for the op-node.
@@ -125,15 +132,16 @@ type Metrics struct {
 	frameAddedEvent        *metrics.Event
 
 	// P2P Metrics
-	PeerCount         prometheus.Gauge
-	StreamCount       prometheus.Gauge
-	GossipEventsTotal *prometheus.CounterVec
-	BandwidthTotal    *prometheus.GaugeVec
-	PeerUnbans        prometheus.Counter
-	IPUnbans          prometheus.Counter
-	Dials             *prometheus.CounterVec
-	Accepts           *prometheus.CounterVec
-	PeerScores        *prometheus.HistogramVec
+	PeerCount          prometheus.Gauge
+	StreamCount        prometheus.Gauge
+	GossipEventsTotal  *prometheus.CounterVec
+	BandwidthTotal     *prometheus.GaugeVec
+	PeerUnbans         prometheus.Counter
+	IPUnbans           prometheus.Counter
+	BanPolicyDecisions *prometheus.CounterVec
+	Dials              *prometheus.CounterVec
+	Accepts            *prometheus.CounterVec
+	PeerScores         *prometheus.HistogramVec
 
 	ChannelInputBytes prometheus.Counter
 
@@ -143,6 +151,12 @@ type Metrics struct {
 	// ProtocolVersions is pseudo-metric to report the exact protocol version info
 	ProtocolVersions *prometheus.GaugeVec
 
+	// Shadow engine comparison results, by engine API method and result
+	ShadowEngineResults *prometheus.CounterVec
+
+	// Alt-sync fetch results, by source kind and result
+	AltSyncResults *prometheus.CounterVec
+
 	registry *prometheus.Registry
 	factory  metrics.Factory
 }
@@ -267,6 +281,12 @@ func NewMetrics(procName string) *Metrics {
 			Name:      "ip_unbans",
 			Help:      "Count of IP unbans",
 		}),
+		BanPolicyDecisions: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Subsystem: "p2p",
+			Name:      "ban_policy_decisions",
+			Help:      "Count of ban-policy decisions, by escalation action",
+		}, []string{"action"}),
 		Dials: factory.NewCounterVec(prometheus.CounterOpts{
 			Namespace: ns,
 			Subsystem: "p2p",
@@ -379,6 +399,26 @@ func NewMetrics(procName string) *Metrics {
 			"required",
 		}),
 
+		ShadowEngineResults: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Subsystem: "shadow_engine",
+			Name:      "results_total",
+			Help:      "Count of engine API calls mirrored to the secondary engine, by method and comparison result",
+		}, []string{
+			"method",
+			"result", // "match", "diverged", "syncing", "error" or "dropped"
+		}),
+
+		AltSyncResults: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Subsystem: "alt_sync",
+			Name:      "results_total",
+			Help:      "Count of alt-sync fetches of unsafe L2 payloads, by source kind and result",
+		}, []string{
+			"source", // "rpc" or "p2p"
+			"result", // "ok", "not_found", "error" or "invalid"
+		}),
+
 		registry: registry,
 		factory:  factory,
 	}
@@ -553,6 +593,44 @@ func (m *Metrics) ServerPayloadByNumberEvent(num uint64, resultCode byte, durati
 	m.P2PPayloadByNumber.WithLabelValues("server").Set(float64(num))
 }
 
+func (m *Metrics) ClientPayloadsByRangeEvent(start uint64, count uint64, resultCode byte, duration time.Duration) {
+	if resultCode > 4 { // summarize all high codes to reduce metrics overhead
+		resultCode = 5
+	}
+	code := strconv.FormatUint(uint64(resultCode), 10)
+	m.P2PReqTotal.WithLabelValues("client", "payloads_by_range", code).Inc()
+	m.P2PReqDurationSeconds.WithLabelValues("client", "payloads_by_range", code).Observe(float64(duration) / float64(time.Second))
+	m.P2PPayloadByNumber.WithLabelValues("client").Set(float64(start + count - 1))
+}
+
+func (m *Metrics) ServerPayloadsByRangeEvent(start uint64, count uint64, resultCode byte, duration time.Duration) {
+	code := strconv.FormatUint(uint64(resultCode), 10)
+	m.P2PReqTotal.WithLabelValues("server", "payloads_by_range", code).Inc()
+	m.P2PReqDurationSeconds.WithLabelValues("server", "payloads_by_range", code).Observe(float64(duration) / float64(time.Second))
+	if count > 0 {
+		m.P2PPayloadByNumber.WithLabelValues("server").Set(float64(start + count - 1))
+	}
+}
+
+func (m *Metrics) ClientPayloadByHashEvent(resultCode byte, duration time.Duration) {
+	if resultCode > 4 { // summarize all high codes to reduce metrics overhead
+		resultCode = 5
+	}
+	code := strconv.FormatUint(uint64(resultCode), 10)
+	m.P2PReqTotal.WithLabelValues("client", "payload_by_hash", code).Inc()
+	m.P2PReqDurationSeconds.WithLabelValues("client", "payload_by_hash", code).Observe(float64(duration) / float64(time.Second))
+}
+
+func (m *Metrics) ServerPayloadByHashEvent(resultCode byte, duration time.Duration) {
+	code := strconv.FormatUint(uint64(resultCode), 10)
+	m.P2PReqTotal.WithLabelValues("server", "payload_by_hash", code).Inc()
+	m.P2PReqDurationSeconds.WithLabelValues("server", "payload_by_hash", code).Observe(float64(duration) / float64(time.Second))
+}
+
+func (m *Metrics) RecordAltSyncResult(source string, result string) {
+	m.AltSyncResults.WithLabelValues(source, result).Inc()
+}
+
 func (m *Metrics) PayloadsQuarantineSize(n int) {
 	m.PayloadsQuarantineTotal.Set(float64(n))
 }
@@ -581,6 +659,10 @@ func (m *Metrics) RecordIPUnban() {
 	m.IPUnbans.Inc()
 }
 
+func (m *Metrics) RecordBanPolicyDecision(action string) {
+	m.BanPolicyDecisions.WithLabelValues(action).Inc()
+}
+
 func (m *Metrics) RecordDial(allow bool) {
 	if allow {
 		m.Dials.WithLabelValues("true").Inc()
@@ -604,6 +686,10 @@ func (m *Metrics) ReportProtocolVersions(local, engine, recommended, required pa
 	m.ProtocolVersions.WithLabelValues(local.String(), engine.String(), recommended.String(), required.String()).Set(1)
 }
 
+func (m *Metrics) RecordShadowEngineResult(method string, result string) {
+	m.ShadowEngineResults.WithLabelValues(method, result).Inc()
+}
+
 type noopMetricer struct{}
 
 var NoopMetrics Metricer = new(noopMetricer)
@@ -704,6 +790,21 @@ func (n *noopMetricer) ClientPayloadByNumberEvent(num uint64, resultCode byte, d
 func (n *noopMetricer) ServerPayloadByNumberEvent(num uint64, resultCode byte, duration time.Duration) {
 }
 
+func (n *noopMetricer) ClientPayloadsByRangeEvent(start uint64, count uint64, resultCode byte, duration time.Duration) {
+}
+
+func (n *noopMetricer) ServerPayloadsByRangeEvent(start uint64, count uint64, resultCode byte, duration time.Duration) {
+}
+
+func (n *noopMetricer) ClientPayloadByHashEvent(resultCode byte, duration time.Duration) {
+}
+
+func (n *noopMetricer) ServerPayloadByHashEvent(resultCode byte, duration time.Duration) {
+}
+
+func (n *noopMetricer) RecordAltSyncResult(source string, result string) {
+}
+
 func (n *noopMetricer) PayloadsQuarantineSize(int) {
 }
 
@@ -725,6 +826,9 @@ func (n *noopMetricer) RecordPeerUnban() {
 func (n *noopMetricer) RecordIPUnban() {
 }
 
+func (n *noopMetricer) RecordBanPolicyDecision(action string) {
+}
+
 func (n *noopMetricer) RecordDial(allow bool) {
 }
 
@@ -732,3 +836,6 @@ func (n *noopMetricer) RecordAccept(allow bool) {
 }
 func (n *noopMetricer) ReportProtocolVersions(local, engine, recommended, required params.ProtocolVersion) {
 }
+
+func (n *noopMetricer) RecordShadowEngineResult(method string, result string) {
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-node/version"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/metrics"
@@ -30,6 +31,7 @@ type driverClient interface {
 	StartSequencer(ctx context.Context, blockHash common.Hash) error
 	StopSequencer(context.Context) (common.Hash, error)
 	SequencerActive(context.Context) (bool, error)
+	PipelineState(ctx context.Context) (*derive.PipelineState, error)
 }
 
 type adminAPI struct {
@@ -68,6 +70,13 @@ func (n *adminAPI) SequencerActive(ctx context.Context) (bool, error) {
 	return n.dr.SequencerActive(ctx)
 }
 
+// PipelineState returns a snapshot of the internal state of the derivation pipeline stages, for inspection.
+func (n *adminAPI) PipelineState(ctx context.Context) (*derive.PipelineState, error) {
+	recordDur := n.M.RecordRPCServerRequest("admin_pipelineState")
+	defer recordDur()
+	return n.dr.PipelineState(ctx)
+}
+
 type nodeAPI struct {
 	config *rollup.Config
 	client l2EthClient
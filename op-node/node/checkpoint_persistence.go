@@ -0,0 +1,71 @@
+package node
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/ioutil"
+)
+
+// FileCheckpointer persists derivation pipeline checkpoints to a JSON file,
+// gzip compressed if the file name ends with .gz.
+type FileCheckpointer struct {
+	lock sync.Mutex
+	file string
+}
+
+var _ derive.Checkpointer = (*FileCheckpointer)(nil)
+
+func NewFileCheckpointer(file string) *FileCheckpointer {
+	return &FileCheckpointer{file: file}
+}
+
+func (c *FileCheckpointer) SaveCheckpoint(cp *derive.PipelineCheckpoint) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	data, err := json.Marshal(cp)
+	if err != nil {
+		return fmt.Errorf("marshal pipeline checkpoint: %w", err)
+	}
+	if ioutil.IsGzip(c.file) {
+		var buf bytes.Buffer
+		gz := gzip.NewWriter(&buf)
+		if _, err := gz.Write(data); err != nil {
+			return fmt.Errorf("compress pipeline checkpoint: %w", err)
+		}
+		if err := gz.Close(); err != nil {
+			return fmt.Errorf("compress pipeline checkpoint: %w", err)
+		}
+		data = buf.Bytes()
+	}
+	return writeFileAtomic(c.file, data)
+}
+
+// LoadCheckpoint reads the last checkpoint, or returns nil if no checkpoint was saved yet.
+func (c *FileCheckpointer) LoadCheckpoint() (*derive.PipelineCheckpoint, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	r, err := ioutil.OpenDecompressed(c.file)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("open checkpoint file (%v): %w", c.file, err)
+	}
+	defer r.Close()
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("read checkpoint file (%v): %w", c.file, err)
+	}
+	var cp derive.PipelineCheckpoint
+	if err := json.Unmarshal(data, &cp); err != nil {
+		return nil, fmt.Errorf("invalid checkpoint file (%v): %w", c.file, err)
+	}
+	return &cp, nil
+}
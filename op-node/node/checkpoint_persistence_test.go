@@ -0,0 +1,51 @@
+package node
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/ioutil"
+)
+
+func TestFileCheckpointer(t *testing.T) {
+	checkpoint := &derive.PipelineCheckpoint{
+		Version: derive.PipelineCheckpointVersion,
+		L1Traversal: derive.L1TraversalCheckpoint{
+			Origin: eth.L1BlockRef{Hash: [32]byte{1}, Number: 123},
+		},
+		ChannelBank: derive.ChannelBankCheckpoint{
+			Channels: []derive.ChannelCheckpoint{{
+				ID:     derive.ChannelID{0xaa},
+				Frames: []derive.Frame{{ID: derive.ChannelID{0xaa}, FrameNumber: 0, Data: []byte("hello")}},
+			}},
+		},
+		BatchQueue: derive.BatchQueueCheckpoint{
+			Batches: []derive.BatchCheckpoint{{Batch: []byte{0, 1, 2}}},
+		},
+	}
+
+	for _, name := range []string{"checkpoint.json", "checkpoint.json.gz"} {
+		name := name
+		t.Run(name, func(t *testing.T) {
+			file := t.TempDir() + "/" + name
+			c := NewFileCheckpointer(file)
+			cp, err := c.LoadCheckpoint()
+			require.NoError(t, err)
+			require.Nil(t, cp, "no checkpoint before saving one")
+
+			require.NoError(t, c.SaveCheckpoint(checkpoint))
+			data, err := os.ReadFile(file)
+			require.NoError(t, err)
+			require.Equal(t, ioutil.IsGzip(file), bytes.HasPrefix(data, []byte{0x1f, 0x8b}), "compressed only if the file name ends with .gz")
+
+			cp, err = NewFileCheckpointer(file).LoadCheckpoint()
+			require.NoError(t, err)
+			require.Equal(t, checkpoint, cp)
+		})
+	}
+}
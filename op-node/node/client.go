@@ -22,9 +22,9 @@ type L2EndpointSetup interface {
 }
 
 type L2SyncEndpointSetup interface {
-	// Setup a RPC client to another L2 node to sync L2 blocks from.
-	// It may return a nil client with nil error if RPC based sync is not enabled.
-	Setup(ctx context.Context, log log.Logger, rollupCfg *rollup.Config) (cl client.RPC, rpcCfg *sources.SyncClientConfig, err error)
+	// Setup RPC clients to other L2 nodes to sync L2 blocks from.
+	// It may return no clients with nil error if RPC based sync is not enabled.
+	Setup(ctx context.Context, log log.Logger, rollupCfg *rollup.Config) (cls []client.RPC, rpcCfg *sources.SyncClientConfig, err error)
 	Check() error
 }
 
@@ -89,27 +89,36 @@ func (p *PreparedL2Endpoints) Setup(ctx context.Context, log log.Logger, rollupC
 	return p.Client, sources.EngineClientDefaultConfig(rollupCfg), nil
 }
 
-// L2SyncEndpointConfig contains configuration for the fallback sync endpoint
+// L2SyncEndpointConfig contains configuration for the fallback sync endpoints
 type L2SyncEndpointConfig struct {
-	// Address of the L2 RPC to use for backup sync, may be empty if RPC alt-sync is disabled.
-	L2NodeAddr string
-	TrustRPC   bool
+	// Addresses of the L2 RPCs to use for backup sync, may be empty if RPC alt-sync is disabled.
+	L2NodeAddrs []string
+	TrustRPC    bool
 }
 
 var _ L2SyncEndpointSetup = (*L2SyncEndpointConfig)(nil)
 
-// Setup creates an RPC client to sync from.
-// It will return nil without error if no sync method is configured.
-func (cfg *L2SyncEndpointConfig) Setup(ctx context.Context, log log.Logger, rollupCfg *rollup.Config) (client.RPC, *sources.SyncClientConfig, error) {
-	if cfg.L2NodeAddr == "" {
-		return nil, nil, nil
+// Setup creates an RPC client for each of the endpoints to sync from.
+// It will return no clients without error if no sync endpoint is configured.
+func (cfg *L2SyncEndpointConfig) Setup(ctx context.Context, log log.Logger, rollupCfg *rollup.Config) ([]client.RPC, *sources.SyncClientConfig, error) {
+	var out []client.RPC
+	for _, addr := range cfg.L2NodeAddrs {
+		if addr == "" {
+			continue
+		}
+		l2Node, err := client.NewRPC(ctx, log, addr)
+		if err != nil {
+			for _, cl := range out {
+				cl.Close()
+			}
+			return nil, nil, fmt.Errorf("failed to dial backup sync RPC %q: %w", addr, err)
+		}
+		out = append(out, l2Node)
 	}
-	l2Node, err := client.NewRPC(ctx, log, cfg.L2NodeAddr)
-	if err != nil {
-		return nil, nil, err
+	if len(out) == 0 {
+		return nil, nil, nil
 	}
-
-	return l2Node, sources.SyncClientDefaultConfig(rollupCfg, cfg.TrustRPC), nil
+	return out, sources.SyncClientDefaultConfig(rollupCfg, cfg.TrustRPC), nil
 }
 
 func (cfg *L2SyncEndpointConfig) Check() error {
@@ -125,8 +134,11 @@ type PreparedL2SyncEndpoint struct {
 
 var _ L2SyncEndpointSetup = (*PreparedL2SyncEndpoint)(nil)
 
-func (cfg *PreparedL2SyncEndpoint) Setup(ctx context.Context, log log.Logger, rollupCfg *rollup.Config) (client.RPC, *sources.SyncClientConfig, error) {
-	return cfg.Client, sources.SyncClientDefaultConfig(rollupCfg, cfg.TrustRPC), nil
+func (cfg *PreparedL2SyncEndpoint) Setup(ctx context.Context, log log.Logger, rollupCfg *rollup.Config) ([]client.RPC, *sources.SyncClientConfig, error) {
+	if cfg.Client == nil {
+		return nil, nil, nil
+	}
+	return []client.RPC{cfg.Client}, sources.SyncClientDefaultConfig(rollupCfg, cfg.TrustRPC), nil
 }
 
 func (cfg *PreparedL2SyncEndpoint) Check() error {
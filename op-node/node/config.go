@@ -10,6 +10,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/flags"
 	"github.com/ethereum-optimism/optimism/op-node/p2p"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/driver"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
 	oppprof "github.com/ethereum-optimism/optimism/op-service/pprof"
@@ -21,6 +22,10 @@ type Config struct {
 	L2     L2EndpointSetup
 	L2Sync L2SyncEndpointSetup
 
+	// L2Shadow is an optional secondary L2 engine, that all engine API calls are mirrored to for comparison.
+	// It never affects the primary L2 chain, and a failure to set it up is not fatal. May be nil.
+	L2Shadow L2EndpointSetup
+
 	Driver driver.Config
 
 	Rollup rollup.Config
@@ -42,6 +47,9 @@ type Config struct {
 
 	ConfigPersistence ConfigPersistence
 
+	// Checkpointer persists derivation pipeline checkpoints, to restore the pipeline after a restart. May be nil.
+	Checkpointer derive.Checkpointer
+
 	// RuntimeConfigReloadInterval defines the interval between runtime config reloads.
 	// Disabled if <= 0.
 	// Runtime config changes should be picked up from log-events,
@@ -122,6 +130,11 @@ func (cfg *Config) Check() error {
 	if err := cfg.L2Sync.Check(); err != nil {
 		return fmt.Errorf("sync config error: %w", err)
 	}
+	if cfg.L2Shadow != nil {
+		if err := cfg.L2Shadow.Check(); err != nil {
+			return fmt.Errorf("l2 shadow endpoint config error: %w", err)
+		}
+	}
 	if err := cfg.Rollup.Check(); err != nil {
 		return fmt.Errorf("rollup config error: %w", err)
 	}
@@ -49,9 +49,6 @@ func (p *ActiveConfigPersistence) SequencerStopped() error {
 }
 
 // persist writes the new config state to the file as safely as possible.
-// It uses sync to ensure the data is actually persisted to disk and initially writes to a temp file
-// before renaming it into place. On UNIX systems this rename is typically atomic, ensuring the
-// actual file isn't corrupted if IO errors occur during writing.
 func (p *ActiveConfigPersistence) persist(sequencerStarted bool) error {
 	p.lock.Lock()
 	defer p.lock.Unlock()
@@ -59,30 +56,39 @@ func (p *ActiveConfigPersistence) persist(sequencerStarted bool) error {
 	if err != nil {
 		return fmt.Errorf("marshall new config: %w", err)
 	}
-	dir := filepath.Dir(p.file)
+	return writeFileAtomic(p.file, data)
+}
+
+// writeFileAtomic writes the data to the file as safely as possible.
+// It uses sync to ensure the data is actually persisted to disk and initially writes to a temp file
+// before renaming it into place. On UNIX systems this rename is typically atomic, ensuring the
+// actual file isn't corrupted if IO errors occur during writing.
+// The data is written as-is, callers are responsible for any encoding or compression.
+func writeFileAtomic(path string, data []byte) error {
+	dir := filepath.Dir(path)
 	if err := os.MkdirAll(dir, 0755); err != nil {
-		return fmt.Errorf("create config dir (%v): %w", p.file, err)
+		return fmt.Errorf("create dir (%v): %w", path, err)
 	}
 	// Write the new content to a temp file first, then rename into place
 	// Avoids corrupting the content if the disk is full or there are IO errors
-	tmpFile := p.file + ".tmp"
+	tmpFile := path + ".tmp"
 	file, err := os.OpenFile(tmpFile, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
 	if err != nil {
 		return fmt.Errorf("open file (%v) for writing: %w", tmpFile, err)
 	}
 	defer file.Close() // Ensure file is closed even if write or sync fails
 	if _, err = file.Write(data); err != nil {
-		return fmt.Errorf("write new config to temp file (%v): %w", tmpFile, err)
+		return fmt.Errorf("write to temp file (%v): %w", tmpFile, err)
 	}
 	if err := file.Sync(); err != nil {
-		return fmt.Errorf("sync new config temp file (%v): %w", tmpFile, err)
+		return fmt.Errorf("sync temp file (%v): %w", tmpFile, err)
 	}
 	if err := file.Close(); err != nil {
-		return fmt.Errorf("close new config temp file (%v): %w", tmpFile, err)
+		return fmt.Errorf("close temp file (%v): %w", tmpFile, err)
 	}
 	// Rename to replace the previous file
-	if err := os.Rename(tmpFile, p.file); err != nil {
-		return fmt.Errorf("rename temp config file to final destination: %w", err)
+	if err := os.Rename(tmpFile, path); err != nil {
+		return fmt.Errorf("rename temp file to final destination: %w", err)
 	}
 	return nil
 }
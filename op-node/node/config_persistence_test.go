@@ -1,6 +1,7 @@
 package node
 
 import (
+	"os"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -76,6 +77,20 @@ func TestActive(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, StateStarted, state)
 	})
+
+	t.Run("GzipFileNameIsNotCompressed", func(t *testing.T) {
+		// Only the checkpointer compresses its file, the config file is always read back as plain JSON.
+		config1 := NewConfigPersistence(t.TempDir() + "/state.gz")
+		require.NoError(t, config1.SequencerStarted())
+		data, err := os.ReadFile(config1.file)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"sequencerStarted":true}`, string(data))
+
+		config2 := NewConfigPersistence(config1.file)
+		state, err := config2.SequencerState()
+		require.NoError(t, err)
+		require.Equal(t, StateStarted, state)
+	})
 }
 
 func TestDisabledConfigPersistence_AlwaysUnset(t *testing.T) {
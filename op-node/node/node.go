@@ -12,12 +12,14 @@ import (
 	"github.com/ethereum-optimism/optimism/op-service/httputil"
 
 	"github.com/hashicorp/go-multierror"
+	"github.com/libp2p/go-libp2p/core/crypto"
 	"github.com/libp2p/go-libp2p/core/peer"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/log"
 
+	"github.com/ethereum-optimism/optimism/op-node/altsync"
 	"github.com/ethereum-optimism/optimism/op-node/heartbeat"
 	"github.com/ethereum-optimism/optimism/op-node/metrics"
 	"github.com/ethereum-optimism/optimism/op-node/p2p"
@@ -39,15 +41,18 @@ type OpNode struct {
 	l1SafeSub      ethereum.Subscription // Subscription to get L1 safe blocks, a.k.a. justified data (polling)
 	l1FinalizedSub ethereum.Subscription // Subscription to get L1 safe blocks, a.k.a. justified data (polling)
 
-	l1Source  *sources.L1Client     // L1 Client to fetch data from
-	l2Driver  *driver.Driver        // L2 Engine to Sync
-	l2Source  *sources.EngineClient // L2 Execution Engine RPC bindings
-	rpcSync   *sources.SyncClient   // Alt-sync RPC client, optional (may be nil)
-	server    *rpcServer            // RPC server hosting the rollup-node API
-	p2pNode   *p2p.NodeP2P          // P2P node functionality
-	p2pSigner p2p.Signer            // p2p gogssip application messages will be signed with this signer
-	tracer    Tracer                // tracer to get events for testing/debugging
-	runCfg    *RuntimeConfig        // runtime configurables
+	l1Source   *sources.L1Client     // L1 Client to fetch data from
+	l2Driver   *driver.Driver        // L2 Engine to Sync
+	l2Source   *sources.EngineClient // L2 Execution Engine RPC bindings
+	l2Shadow   *ShadowEngine         // Mirrors engine calls to a secondary L2 Execution Engine, optional (may be nil)
+	altSync    *altsync.Manager      // Alt-sync of unsafe L2 payloads from backup RPCs and P2P peers, fallback to P2P range sync, optional (may be nil)
+	altSyncRPC bool                  // Whether alt-sync includes backup RPCs, which can serve older ranges than P2P peers
+	p2pGap     p2pGap                // Tracks the unsafe L2 gap that is requested from P2P peers, to fall back to alt-sync
+	server     *rpcServer            // RPC server hosting the rollup-node API
+	p2pNode    *p2p.NodeP2P          // P2P node functionality
+	p2pSigner  p2p.Signer            // p2p gogssip application messages will be signed with this signer
+	tracer     Tracer                // tracer to get events for testing/debugging
+	runCfg     *RuntimeConfig        // runtime configurables
 
 	rollupHalt string // when to halt the rollup, disabled if empty
 
@@ -116,15 +121,15 @@ func (n *OpNode) init(ctx context.Context, cfg *Config, snapshotLog log.Logger)
 	if err := n.initRuntimeConfig(ctx, cfg); err != nil { // depends on L2, to signal initial runtime values to
 		return fmt.Errorf("failed to init the runtime config: %w", err)
 	}
-	if err := n.initRPCSync(ctx, cfg); err != nil {
-		return fmt.Errorf("failed to init RPC sync: %w", err)
-	}
 	if err := n.initP2PSigner(ctx, cfg); err != nil {
 		return fmt.Errorf("failed to init the P2P signer: %w", err)
 	}
 	if err := n.initP2P(ctx, cfg); err != nil {
 		return fmt.Errorf("failed to init the P2P stack: %w", err)
 	}
+	if err := n.initAltSync(ctx, cfg); err != nil { // depends on P2P, to sync from peers
+		return fmt.Errorf("failed to init alt-sync: %w", err)
+	}
 	// Only expose the server at the end, ensuring all RPC backend components are initialized.
 	if err := n.initRPCServer(ctx, cfg); err != nil {
 		return fmt.Errorf("failed to init the RPC server: %w", err)
@@ -301,27 +306,84 @@ func (n *OpNode) initL2(ctx context.Context, cfg *Config, snapshotLog log.Logger
 		return err
 	}
 
-	n.l2Driver = driver.NewDriver(&cfg.Driver, &cfg.Rollup, n.l2Source, n.l1Source, n, n, n.log, snapshotLog, n.metrics, cfg.ConfigPersistence, &cfg.Sync)
+	var engine driver.L2Chain = n.l2Source
+	if cfg.L2Shadow != nil {
+		// The shadow engine is only used for comparison, and must not prevent the node from running.
+		if shadow, err := n.initL2Shadow(ctx, cfg); err != nil {
+			n.log.Warn("Failed to initialize L2 shadow engine, running without it", "err", err)
+		} else {
+			n.l2Shadow = shadow
+			engine = shadow
+		}
+	}
+
+	n.l2Driver = driver.NewDriver(&cfg.Driver, &cfg.Rollup, engine, n.l1Source, n, n, n.log, snapshotLog, n.metrics, cfg.ConfigPersistence, cfg.Checkpointer, &cfg.Sync)
 
 	return nil
 }
 
-func (n *OpNode) initRPCSync(ctx context.Context, cfg *Config) error {
-	rpcSyncClient, rpcCfg, err := cfg.L2Sync.Setup(ctx, n.log, &cfg.Rollup)
+func (n *OpNode) initL2Shadow(ctx context.Context, cfg *Config) (*ShadowEngine, error) {
+	log := n.log.New("engine", "shadow")
+	rpcClient, rpcCfg, err := cfg.L2Shadow.Setup(ctx, log, &cfg.Rollup)
 	if err != nil {
-		return fmt.Errorf("failed to setup L2 execution-engine RPC client for backup sync: %w", err)
+		return nil, fmt.Errorf("failed to setup L2 shadow execution-engine RPC client: %w", err)
 	}
-	if rpcSyncClient == nil { // if no RPC client is configured to sync from, then don't add the RPC sync client
-		return nil
+	// The secondary engine is not instrumented with the primary RPC and cache metrics,
+	// to not distort the metrics of the primary engine.
+	secondary, err := sources.NewEngineClient(rpcClient, log, nil, rpcCfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create shadow Engine client: %w", err)
 	}
-	syncClient, err := sources.NewSyncClient(n.OnUnsafeL2Payload, rpcSyncClient, n.log, n.metrics.L2SourceCache, rpcCfg)
+	if err := cfg.Rollup.ValidateL2Config(ctx, secondary); err != nil {
+		secondary.Close()
+		return nil, fmt.Errorf("shadow engine does not match rollup config: %w", err)
+	}
+	log.Info("Mirroring engine API calls to shadow engine")
+	return NewShadowEngine(log, n.l2Source, secondary, n.metrics), nil
+}
+
+func (n *OpNode) initAltSync(ctx context.Context, cfg *Config) error {
+	rpcSyncClients, rpcCfg, err := cfg.L2Sync.Setup(ctx, n.log, &cfg.Rollup)
 	if err != nil {
-		return fmt.Errorf("failed to create sync client: %w", err)
+		return fmt.Errorf("failed to setup L2 execution-engine RPC clients for backup sync: %w", err)
+	}
+	var rpcSources []altsync.Source
+	for i, cl := range rpcSyncClients {
+		syncClient, err := sources.NewSyncClient(n.OnUnsafeL2Payload, cl, n.log, n.metrics.L2SourceCache, rpcCfg)
+		if err != nil {
+			return fmt.Errorf("failed to create sync client: %w", err)
+		}
+		rpcSources = append(rpcSources, altsync.NewRPCSource(i, syncClient))
+	}
+	var p2pSync *p2p.SyncClient
+	if n.p2pNode != nil {
+		p2pSync = n.p2pNode.SyncClient()
+	}
+	// if there is nothing to sync from, then don't add the alt-sync
+	if len(rpcSources) == 0 && p2pSync == nil {
+		return nil
 	}
-	n.rpcSync = syncClient
+	sourcesFn := func() []altsync.Source {
+		out := rpcSources
+		if p2pSync != nil {
+			out = append(out[:len(out):len(out)], peerSources(p2pSync)...)
+		}
+		return out
+	}
+	n.altSyncRPC = len(rpcSources) > 0
+	n.altSync = altsync.NewManager(n.log.New("module", "altsync"), &cfg.Rollup, sourcesFn, n.OnUnsafeL2Payload, n.metrics)
 	return nil
 }
 
+func peerSources(cl *p2p.SyncClient) []altsync.Source {
+	peers := cl.Peers()
+	out := make([]altsync.Source, 0, len(peers))
+	for _, id := range peers {
+		out = append(out, altsync.NewPeerSource(id, cl))
+	}
+	return out
+}
+
 func (n *OpNode) initRPCServer(ctx context.Context, cfg *Config) error {
 	server, err := newRPCServer(ctx, &cfg.RPC, &cfg.Rollup, n.l2Source.L2Client, n.l2Driver, n.log, n.appVersion, n.metrics)
 	if err != nil {
@@ -362,10 +424,14 @@ func (n *OpNode) initHeartbeat(cfg *Config) {
 		return
 	}
 	var peerID string
+	var priv crypto.PrivKey
 	if cfg.P2P.Disabled() {
 		peerID = "disabled"
 	} else {
-		peerID = n.P2P().Host().ID().String()
+		h := n.P2P().Host()
+		peerID = h.ID().String()
+		// heartbeats are signed with the p2p identity key, so the heartbeat server can authenticate the peer ID
+		priv = h.Peerstore().PrivKey(h.ID())
 	}
 
 	payload := &heartbeat.Payload{
@@ -377,7 +443,7 @@ func (n *OpNode) initHeartbeat(cfg *Config) {
 	}
 
 	go func(url string) {
-		if err := heartbeat.Beat(n.resourcesCtx, n.log, url, payload); err != nil {
+		if err := heartbeat.Beat(n.resourcesCtx, n.log, url, payload, priv); err != nil {
 			log.Error("heartbeat goroutine crashed", "err", err)
 		}
 	}(cfg.Heartbeat.URL)
@@ -431,13 +497,13 @@ func (n *OpNode) Start(ctx context.Context) error {
 		return err
 	}
 
-	// If the backup unsafe sync client is enabled, start its event loop
-	if n.rpcSync != nil {
-		if err := n.rpcSync.Start(); err != nil {
-			n.log.Error("Could not start the backup sync client", "err", err)
+	// If alt-sync is enabled, start its event loop
+	if n.altSync != nil {
+		if err := n.altSync.Start(); err != nil {
+			n.log.Error("Could not start the alt-sync service", "err", err)
 			return err
 		}
-		n.log.Info("Started L2-RPC sync service")
+		n.log.Info("Started alt-sync service")
 	}
 
 	log.Info("Rollup node started")
@@ -517,21 +583,54 @@ func (n *OpNode) OnUnsafeL2Payload(ctx context.Context, from peer.ID, payload *e
 	return nil
 }
 
+// RequestL2Range requests the missing unsafe L2 blocks between start and end.
+// The P2P req-resp sync client is preferred, it syncs towards the end block from multiple peers in parallel.
+// Alt-sync is used for what P2P range sync cannot serve: open-ended ranges, ranges too old for P2P peers,
+// and gaps that P2P range sync did not fill within altSyncFallbackDelay.
 func (n *OpNode) RequestL2Range(ctx context.Context, start, end eth.L2BlockRef) error {
-	if n.rpcSync != nil {
-		return n.rpcSync.RequestL2Range(ctx, start, end)
+	stale := unixTimeStale(start.Time, 12*time.Hour)
+	if n.p2pNode != nil && n.p2pNode.AltSyncEnabled() && end != (eth.L2BlockRef{}) && !stale {
+		if err := n.p2pNode.RequestL2Range(ctx, start, end); err != nil {
+			return err
+		}
+		if n.altSync == nil || !n.p2pGap.stalled(start, time.Now()) {
+			return nil
+		}
+		n.log.Info("unsafe L2 gap was not filled by p2p range sync, falling back to alt-sync", "start", start, "end", end)
 	}
-	if n.p2pNode != nil && n.p2pNode.AltSyncEnabled() {
-		if unixTimeStale(start.Time, 12*time.Hour) {
+	if n.altSync != nil {
+		if !n.altSyncRPC && stale {
 			n.log.Debug("ignoring request to sync L2 range, timestamp is too old for p2p", "start", start, "end", end, "start_time", start.Time)
 			return nil
 		}
-		return n.p2pNode.RequestL2Range(ctx, start, end)
+		return n.altSync.RequestL2Range(ctx, start, end)
 	}
 	n.log.Debug("ignoring request to sync L2 range, no sync method available", "start", start, "end", end)
 	return nil
 }
 
+// altSyncFallbackDelay is how long an unsafe L2 gap may be requested from P2P peers with range sync,
+// without the unsafe head progressing, before alt-sync is used to fill the gap as well.
+const altSyncFallbackDelay = 30 * time.Second
+
+// p2pGap tracks since when the gap above the unsafe head is requested from P2P peers.
+// It is only accessed by the driver event loop, through RequestL2Range.
+type p2pGap struct {
+	start eth.BlockID
+	since time.Time
+}
+
+// stalled returns true if the gap above the given unsafe head has been requested
+// for at least altSyncFallbackDelay, i.e. if the unsafe head did not progress.
+func (g *p2pGap) stalled(start eth.L2BlockRef, now time.Time) bool {
+	if g.start != start.ID() {
+		g.start = start.ID()
+		g.since = now
+		return false
+	}
+	return now.Sub(g.since) >= altSyncFallbackDelay
+}
+
 // unixTimeStale returns true if the unix timestamp is before the current time minus the supplied duration.
 func unixTimeStale(timestamp uint64, duration time.Duration) bool {
 	return time.Unix(int64(timestamp), 0).Before(time.Now().Add(-1 * duration))
@@ -585,10 +684,10 @@ func (n *OpNode) Stop(ctx context.Context) error {
 			result = multierror.Append(result, fmt.Errorf("failed to close L2 engine driver cleanly: %w", err))
 		}
 
-		// If the L2 sync client is present & running, close it.
-		if n.rpcSync != nil {
-			if err := n.rpcSync.Close(); err != nil {
-				result = multierror.Append(result, fmt.Errorf("failed to close L2 engine backup sync client cleanly: %w", err))
+		// If the alt-sync is present & running, close it.
+		if n.altSync != nil {
+			if err := n.altSync.Close(); err != nil {
+				result = multierror.Append(result, fmt.Errorf("failed to close alt-sync cleanly: %w", err))
 			}
 		}
 	}
@@ -603,6 +702,11 @@ func (n *OpNode) Stop(ctx context.Context) error {
 		n.l2Source.Close()
 	}
 
+	// stop mirroring to the L2 shadow engine, after the driver has stopped using it
+	if n.l2Shadow != nil {
+		_ = n.l2Shadow.Close()
+	}
+
 	// close L1 data source
 	if n.l1Source != nil {
 		n.l1Source.Close()
@@ -5,9 +5,27 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
 
 func TestUnixTimeStale(t *testing.T) {
 	require.True(t, unixTimeStale(1_600_000_000, 1*time.Hour))
 	require.False(t, unixTimeStale(uint64(time.Now().Unix()), 1*time.Hour))
 }
+
+func TestP2PGapStalled(t *testing.T) {
+	var gap p2pGap
+	now := time.Now()
+	head := eth.L2BlockRef{Hash: common.Hash{1}, Number: 10}
+	require.False(t, gap.stalled(head, now), "new gap")
+	require.False(t, gap.stalled(head, now.Add(altSyncFallbackDelay-time.Second)))
+	require.True(t, gap.stalled(head, now.Add(altSyncFallbackDelay)), "unsafe head did not progress")
+
+	// progress of the unsafe head resets the fallback delay
+	next := eth.L2BlockRef{Hash: common.Hash{2}, Number: 11}
+	require.False(t, gap.stalled(next, now.Add(altSyncFallbackDelay)))
+	require.True(t, gap.stalled(next, now.Add(2*altSyncFallbackDelay)))
+}
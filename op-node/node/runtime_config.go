@@ -3,9 +3,11 @@ package node
 import (
 	"context"
 	"fmt"
+	"math/big"
 	"sync"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/params"
 
@@ -19,6 +21,12 @@ var (
 	// `address` storage value in the SystemConfig L1 contract. Computed as `keccak256("systemconfig.unsafeblocksigner")`
 	UnsafeBlockSignerAddressSystemConfigStorageSlot = common.HexToHash("0x65a7ed542fb37fe237fdfbdd70b31598523fe5b32879e307bae27a0bd9581c08")
 
+	// UnsafeBlockSignersSystemConfigStorageSlot is the storage slot of the dynamic array of authorised unsafe block signing keys
+	// in the SystemConfig L1 contract. Computed as `keccak256("systemconfig.unsafeblocksigners")`.
+	// The slot holds the length of the array, the elements are stored consecutively from `keccak256(slot)`,
+	// each packed as documented by eth.DecodeSequencerKey.
+	UnsafeBlockSignersSystemConfigStorageSlot = common.HexToHash("0x52995b53b1b1c0eb04679a97060ec323a8de8a2adf8f06735ddd5c1675359630")
+
 	// RequiredProtocolVersionStorageSlot is the storage slot that the required protocol version is stored at.
 	// Computed as: `bytes32(uint256(keccak256("protocolversion.required")) - 1)`
 	RequiredProtocolVersionStorageSlot = common.HexToHash("0x4aaefe95bd84fd3f32700cf3b7566bc944b73138e41958b5785826df2aecace0")
@@ -28,12 +36,16 @@ var (
 	RecommendedProtocolVersionStorageSlot = common.HexToHash("0xe314dfc40f0025322aacc0ba8ef420b62fb3b702cf01e0cdf3d829117ac2ff1a")
 )
 
+// maxUnsafeBlockSigners limits the number of unsafe block signing keys that are loaded from L1.
+const maxUnsafeBlockSigners = 16
+
 type RuntimeCfgL1Source interface {
 	ReadStorageAt(ctx context.Context, address common.Address, storageSlot common.Hash, blockHash common.Hash) (common.Hash, error)
 }
 
 type ReadonlyRuntimeConfig interface {
 	P2PSequencerAddress() common.Address
+	P2PSequencerKeys() eth.SequencerKeys
 	RequiredProtocolVersion() params.ProtocolVersion
 	RecommendedProtocolVersion() params.ProtocolVersion
 }
@@ -60,6 +72,7 @@ type RuntimeConfig struct {
 // runtimeConfigData is a flat bundle of configurable data, easy and light to copy around.
 type runtimeConfigData struct {
 	p2pBlockSignerAddr common.Address
+	p2pBlockSignerKeys eth.SequencerKeys
 
 	// superchain protocol version signals
 	recommended params.ProtocolVersion
@@ -82,6 +95,12 @@ func (r *RuntimeConfig) P2PSequencerAddress() common.Address {
 	return r.p2pBlockSignerAddr
 }
 
+func (r *RuntimeConfig) P2PSequencerKeys() eth.SequencerKeys {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.p2pBlockSignerKeys
+}
+
 func (r *RuntimeConfig) RequiredProtocolVersion() params.ProtocolVersion {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
@@ -102,6 +121,10 @@ func (r *RuntimeConfig) Load(ctx context.Context, l1Ref eth.L1BlockRef) error {
 	if err != nil {
 		return fmt.Errorf("failed to fetch unsafe block signing address from system config: %w", err)
 	}
+	p2pSignerKeys, err := r.loadSequencerKeys(ctx, l1Ref)
+	if err != nil {
+		return err
+	}
 	// The superchain protocol version data is optional; only applicable to rollup configs that specify a ProtocolVersions address.
 	var requiredProtVersion, recommendedProtoVersion params.ProtocolVersion
 	if r.rollupCfg.ProtocolVersionsAddress != (common.Address{}) {
@@ -120,8 +143,33 @@ func (r *RuntimeConfig) Load(ctx context.Context, l1Ref eth.L1BlockRef) error {
 	defer r.mu.Unlock()
 	r.l1Ref = l1Ref
 	r.p2pBlockSignerAddr = common.BytesToAddress(p2pSignerVal[:])
+	r.p2pBlockSignerKeys = p2pSignerKeys
 	r.required = requiredProtVersion
 	r.recommended = recommendedProtoVersion
-	r.log.Info("loaded new runtime config values!", "p2p_seq_address", r.p2pBlockSignerAddr)
+	r.log.Info("loaded new runtime config values!", "p2p_seq_address", r.p2pBlockSignerAddr, "p2p_seq_keys", r.p2pBlockSignerKeys)
 	return nil
 }
+
+// loadSequencerKeys loads the set of authorised unsafe block signing keys from the system config.
+// The set is empty if the system config does not configure any keys.
+func (r *RuntimeConfig) loadSequencerKeys(ctx context.Context, l1Ref eth.L1BlockRef) (eth.SequencerKeys, error) {
+	lengthVal, err := r.l1Client.ReadStorageAt(ctx, r.rollupCfg.L1SystemConfigAddress, UnsafeBlockSignersSystemConfigStorageSlot, l1Ref.Hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch unsafe block signing keys count from system config: %w", err)
+	}
+	length := new(big.Int).SetBytes(lengthVal[:])
+	if !length.IsUint64() || length.Uint64() > maxUnsafeBlockSigners {
+		return nil, fmt.Errorf("too many unsafe block signing keys in system config: %s", length)
+	}
+	start := new(big.Int).SetBytes(crypto.Keccak256(UnsafeBlockSignersSystemConfigStorageSlot[:]))
+	keys := make(eth.SequencerKeys, 0, length.Uint64())
+	for i := uint64(0); i < length.Uint64(); i++ {
+		slot := common.BigToHash(new(big.Int).Add(start, new(big.Int).SetUint64(i)))
+		val, err := r.l1Client.ReadStorageAt(ctx, r.rollupCfg.L1SystemConfigAddress, slot, l1Ref.Hash)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch unsafe block signing key %d from system config: %w", i, err)
+		}
+		keys = append(keys, eth.DecodeSequencerKey(val))
+	}
+	return keys, nil
+}
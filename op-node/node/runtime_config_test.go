@@ -0,0 +1,62 @@
+package node
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+type storageL1Source map[common.Hash]common.Hash
+
+func (s storageL1Source) ReadStorageAt(ctx context.Context, address common.Address, storageSlot common.Hash, blockHash common.Hash) (common.Hash, error) {
+	return s[storageSlot], nil
+}
+
+// setSequencerKeys lays out the keys as the dynamic array of the system config.
+func (s storageL1Source) setSequencerKeys(keys eth.SequencerKeys) {
+	s[UnsafeBlockSignersSystemConfigStorageSlot] = common.BigToHash(big.NewInt(int64(len(keys))))
+	start := new(big.Int).SetBytes(crypto.Keccak256(UnsafeBlockSignersSystemConfigStorageSlot[:]))
+	for i, k := range keys {
+		s[common.BigToHash(new(big.Int).Add(start, big.NewInt(int64(i))))] = k.Encode()
+	}
+}
+
+func TestRuntimeConfigSequencerKeys(t *testing.T) {
+	require.Equal(t, crypto.Keccak256Hash([]byte("systemconfig.unsafeblocksigners")), UnsafeBlockSignersSystemConfigStorageSlot)
+
+	signer := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	l1 := storageL1Source{UnsafeBlockSignerAddressSystemConfigStorageSlot: common.BytesToHash(signer[:])}
+	runCfg := NewRuntimeConfig(testlog.Logger(t, log.LvlError), l1, &rollup.Config{})
+
+	// without keys in the system config, no keys are loaded
+	require.NoError(t, runCfg.Load(context.Background(), eth.L1BlockRef{Number: 1}))
+	require.Empty(t, runCfg.P2PSequencerKeys())
+	require.Equal(t, signer, runCfg.P2PSequencerAddress())
+
+	// a rotation: the new key activates before the old key expires
+	expected := eth.SequencerKeys{
+		{Address: signer, Activation: 0, Expiry: 110},
+		{Address: common.HexToAddress("0x2222222222222222222222222222222222222222"), Activation: 100},
+	}
+	l1.setSequencerKeys(expected)
+	require.NoError(t, runCfg.Load(context.Background(), eth.L1BlockRef{Number: 2}))
+	require.Equal(t, expected, runCfg.P2PSequencerKeys())
+	require.True(t, runCfg.P2PSequencerKeys().Authorized(expected[0].Address, 105))
+	require.True(t, runCfg.P2PSequencerKeys().Authorized(expected[1].Address, 105))
+	require.False(t, runCfg.P2PSequencerKeys().Authorized(expected[0].Address, 110))
+
+	// too many keys fail the load, and the last loaded keys are retained
+	l1.setSequencerKeys(make(eth.SequencerKeys, maxUnsafeBlockSigners+1))
+	require.ErrorContains(t, runCfg.Load(context.Background(), eth.L1BlockRef{Number: 3}), "too many")
+	require.Equal(t, expected, runCfg.P2PSequencerKeys())
+}
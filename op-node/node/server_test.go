@@ -16,6 +16,7 @@ import (
 
 	"github.com/ethereum-optimism/optimism/op-node/metrics"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-node/version"
 	rpcclient "github.com/ethereum-optimism/optimism/op-service/client"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
@@ -197,6 +198,93 @@ func TestSyncStatus(t *testing.T) {
 	assert.Equal(t, status, out)
 }
 
+func TestPipelineState(t *testing.T) {
+	log := testlog.Logger(t, log.LvlError)
+	l2Client := &testutils.MockL2Client{}
+	drClient := &mockDriverClient{}
+	rng := rand.New(rand.NewSource(1234))
+	origin := testutils.RandomBlockRef(rng)
+	safeHead := testutils.RandomL2BlockRef(rng)
+	state := &derive.PipelineState{
+		Resetting:   5,
+		StagesCount: 5,
+		L1Traversal: derive.L1TraversalState{Origin: origin, Done: true},
+		FrameQueue: derive.FrameQueueState{Frames: []derive.FrameState{
+			{ID: derive.ChannelID{0x01}, FrameNumber: 1, DataLen: 100, IsLast: true},
+		}},
+		ChannelBank: derive.ChannelBankState{
+			Origin: origin,
+			Channels: []derive.ChannelState{{
+				ID:                      derive.ChannelID{0x02},
+				OpenBlock:               origin,
+				TimeoutBlock:            origin.Number + 10,
+				Size:                    300,
+				FramesCount:             1,
+				HighestFrameNumber:      1,
+				HighestL1InclusionBlock: origin,
+			}},
+			TotalSize: 300,
+		},
+		BatchQueue: derive.BatchQueueState{
+			Origin:   origin,
+			L1Blocks: []eth.L1BlockRef{origin},
+			Batches: []derive.BatchState{
+				{BatchType: derive.SpanBatchType, Timestamp: safeHead.Time + 2, L1InclusionBlock: origin},
+				{
+					BatchType:        derive.SingularBatchType,
+					Timestamp:        safeHead.Time + 4,
+					L1InclusionBlock: origin,
+					ParentHash:       testutils.RandomHash(rng),
+					EpochNum:         rollup.Epoch(origin.Number),
+					EpochHash:        origin.Hash,
+					TxCount:          2,
+				},
+			},
+		},
+		EngineQueue: derive.EngineQueueState{
+			Origin:       origin,
+			SafeHead:     safeHead,
+			UnsafeHead:   testutils.RandomL2BlockRef(rng),
+			BuildingOnto: safeHead,
+			SafeAttributes: &derive.AttributesState{
+				Parent:       safeHead,
+				Timestamp:    safeHead.Time + 2,
+				TxCount:      1,
+				GasLimit:     30_000_000,
+				FeeRecipient: testutils.RandomAddress(rng),
+			},
+			UnsafePayloads:        []derive.PayloadState{{Hash: testutils.RandomHash(rng), Number: safeHead.Number + 2, TxCount: 3}},
+			UnsafePayloadsMemSize: 1000,
+			FinalityData:          []derive.FinalityData{{L2Block: safeHead, L1Block: origin.ID()}},
+		},
+	}
+	drClient.On("PipelineState").Return(state)
+
+	rpcCfg := &RPCConfig{
+		ListenAddr: "localhost",
+		ListenPort: 0,
+	}
+	rollupCfg := &rollup.Config{
+		// ignore other rollup config info in this test
+	}
+	server, err := newRPCServer(context.Background(), rpcCfg, rollupCfg, l2Client, drClient, log, "0.0", metrics.NoopMetrics)
+	require.NoError(t, err)
+	server.EnableAdminAPI(NewAdminAPI(drClient, metrics.NoopMetrics, log))
+	require.NoError(t, server.Start())
+	defer func() {
+		require.NoError(t, server.Stop(context.Background()))
+	}()
+
+	client, err := rpcclient.NewRPC(context.Background(), log, "http://"+server.Addr().String(), rpcclient.WithDialBackoff(3))
+	require.NoError(t, err)
+
+	var out *derive.PipelineState
+	err = client.CallContext(context.Background(), &out, "admin_pipelineState")
+	require.NoError(t, err)
+	require.Equal(t, state, out)
+	drClient.Mock.AssertExpectations(t)
+}
+
 type mockDriverClient struct {
 	mock.Mock
 }
@@ -229,3 +317,7 @@ func (c *mockDriverClient) StopSequencer(ctx context.Context) (common.Hash, erro
 func (c *mockDriverClient) SequencerActive(ctx context.Context) (bool, error) {
 	return c.Mock.MethodCalled("SequencerActive").Get(0).(bool), nil
 }
+
+func (c *mockDriverClient) PipelineState(ctx context.Context) (*derive.PipelineState, error) {
+	return c.Mock.MethodCalled("PipelineState").Get(0).(*derive.PipelineState), nil
+}
@@ -0,0 +1,265 @@
+package node
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/driver"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+const (
+	// shadowQueueSize is the number of engine calls that may be buffered for the secondary engine.
+	// Calls are dropped when the secondary engine falls behind further than this.
+	shadowQueueSize = 128
+	// shadowCallTimeout limits the time the secondary engine gets to process a single call.
+	shadowCallTimeout = time.Second * 10
+	// shadowMaxPayloadIDs limits how many primary payload IDs are mapped to secondary payload IDs,
+	// in case payload building is started but the payloads are never retrieved.
+	shadowMaxPayloadIDs = 16
+)
+
+// Results of a shadowed engine call, as reported in metrics.
+const (
+	ShadowResultMatch    = "match"
+	ShadowResultDiverged = "diverged"
+	ShadowResultSyncing  = "syncing"
+	ShadowResultError    = "error"
+	ShadowResultDropped  = "dropped"
+)
+
+type ShadowEngineMetrics interface {
+	RecordShadowEngineResult(method string, result string)
+}
+
+// ShadowEngineClient is the subset of the engine API that is mirrored to the secondary engine.
+type ShadowEngineClient interface {
+	ForkchoiceUpdate(ctx context.Context, fc *eth.ForkchoiceState, attributes *eth.PayloadAttributes) (*eth.ForkchoiceUpdatedResult, error)
+	NewPayload(ctx context.Context, payload *eth.ExecutionPayload) (*eth.PayloadStatusV1, error)
+	GetPayload(ctx context.Context, payloadId eth.PayloadID) (*eth.ExecutionPayload, error)
+}
+
+// ShadowEngine wraps the primary L2 engine, and mirrors every forkchoice update and new payload
+// to a secondary engine, e.g. a different execution-layer client that is being trialed.
+//
+// The results of the primary engine are always returned as-is: the secondary engine is called asynchronously,
+// in the same order as the primary engine, and can never affect the primary chain.
+// The results of both engines are compared: payload statuses, and the block hash and state root
+// of payloads that both engines build from the same attributes. Divergence is reported through logs and metrics.
+type ShadowEngine struct {
+	driver.L2Chain
+
+	log       log.Logger
+	secondary ShadowEngineClient
+	metrics   ShadowEngineMetrics
+
+	calls chan func(ctx context.Context)
+
+	// payloadIDs maps payload IDs of the primary engine to those of the secondary engine.
+	// Only accessed by the shadow loop.
+	payloadIDs map[eth.PayloadID]eth.PayloadID
+	// payloadIDsOrder tracks insertion order of payloadIDs, to prune the oldest entries.
+	payloadIDsOrder []eth.PayloadID
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+var _ driver.L2Chain = (*ShadowEngine)(nil)
+
+func NewShadowEngine(log log.Logger, primary driver.L2Chain, secondary ShadowEngineClient, metrics ShadowEngineMetrics) *ShadowEngine {
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &ShadowEngine{
+		L2Chain:    primary,
+		log:        log,
+		secondary:  secondary,
+		metrics:    metrics,
+		calls:      make(chan func(ctx context.Context), shadowQueueSize),
+		payloadIDs: make(map[eth.PayloadID]eth.PayloadID),
+		ctx:        ctx,
+		cancel:     cancel,
+	}
+	s.wg.Add(1)
+	go s.loop()
+	return s
+}
+
+func (s *ShadowEngine) loop() {
+	defer s.wg.Done()
+	for {
+		select {
+		case call := <-s.calls:
+			ctx, cancel := context.WithTimeout(s.ctx, shadowCallTimeout)
+			call(ctx)
+			cancel()
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
+// schedule queues the call for the secondary engine, without ever blocking the primary engine.
+func (s *ShadowEngine) schedule(method string, call func(ctx context.Context)) {
+	select {
+	case s.calls <- call:
+	default:
+		s.log.Warn("Secondary engine is too far behind, dropping shadowed call", "method", method)
+		s.metrics.RecordShadowEngineResult(method, ShadowResultDropped)
+	}
+}
+
+func (s *ShadowEngine) ForkchoiceUpdate(ctx context.Context, fc *eth.ForkchoiceState, attributes *eth.PayloadAttributes) (*eth.ForkchoiceUpdatedResult, error) {
+	res, err := s.L2Chain.ForkchoiceUpdate(ctx, fc, attributes)
+	if err != nil {
+		// Don't shadow calls that the primary engine could not process: there is nothing to compare against.
+		return res, err
+	}
+	fcCopy := *fc
+	primary := *res
+	s.schedule("forkchoiceUpdated", func(ctx context.Context) {
+		s.shadowForkchoiceUpdate(ctx, &fcCopy, attributes, &primary)
+	})
+	return res, err
+}
+
+func (s *ShadowEngine) shadowForkchoiceUpdate(ctx context.Context, fc *eth.ForkchoiceState, attributes *eth.PayloadAttributes, primary *eth.ForkchoiceUpdatedResult) {
+	const method = "forkchoiceUpdated"
+	log := s.log.New("method", method, "head", fc.HeadBlockHash, "safe", fc.SafeBlockHash, "finalized", fc.FinalizedBlockHash)
+	res, err := s.secondary.ForkchoiceUpdate(ctx, fc, attributes)
+	if err != nil {
+		log.Warn("Secondary engine failed to process forkchoice update", "err", err)
+		s.metrics.RecordShadowEngineResult(method, ShadowResultError)
+		return
+	}
+	if primary.PayloadID != nil && res.PayloadID != nil {
+		s.trackPayloadID(*primary.PayloadID, *res.PayloadID)
+	}
+	s.comparePayloadStatus(log, method, &primary.PayloadStatus, &res.PayloadStatus)
+}
+
+func (s *ShadowEngine) NewPayload(ctx context.Context, payload *eth.ExecutionPayload) (*eth.PayloadStatusV1, error) {
+	res, err := s.L2Chain.NewPayload(ctx, payload)
+	if err != nil {
+		return res, err
+	}
+	primary := *res
+	s.schedule("newPayload", func(ctx context.Context) {
+		s.shadowNewPayload(ctx, payload, &primary)
+	})
+	return res, err
+}
+
+func (s *ShadowEngine) shadowNewPayload(ctx context.Context, payload *eth.ExecutionPayload, primary *eth.PayloadStatusV1) {
+	const method = "newPayload"
+	log := s.log.New("method", method, "payload", payload.ID())
+	res, err := s.secondary.NewPayload(ctx, payload)
+	if err != nil {
+		log.Warn("Secondary engine failed to process new payload", "err", err)
+		s.metrics.RecordShadowEngineResult(method, ShadowResultError)
+		return
+	}
+	s.comparePayloadStatus(log, method, primary, res)
+}
+
+func (s *ShadowEngine) GetPayload(ctx context.Context, payloadId eth.PayloadID) (*eth.ExecutionPayload, error) {
+	res, err := s.L2Chain.GetPayload(ctx, payloadId)
+	if err != nil {
+		return res, err
+	}
+	s.schedule("getPayload", func(ctx context.Context) {
+		s.shadowGetPayload(ctx, payloadId, res)
+	})
+	return res, err
+}
+
+func (s *ShadowEngine) shadowGetPayload(ctx context.Context, primaryID eth.PayloadID, primary *eth.ExecutionPayload) {
+	const method = "getPayload"
+	log := s.log.New("method", method, "payload", primary.ID())
+	id, ok := s.payloadIDs[primaryID]
+	if !ok {
+		// The secondary engine may not have been building, e.g. if the forkchoice update was dropped.
+		log.Debug("No secondary payload ID known, cannot compare built payload", "payload_id", primaryID)
+		return
+	}
+	delete(s.payloadIDs, primaryID)
+	res, err := s.secondary.GetPayload(ctx, id)
+	if err != nil {
+		log.Warn("Secondary engine failed to get payload", "payload_id", id, "err", err)
+		s.metrics.RecordShadowEngineResult(method, ShadowResultError)
+		return
+	}
+	if res.BlockHash != primary.BlockHash || res.StateRoot != primary.StateRoot || res.ReceiptsRoot != primary.ReceiptsRoot {
+		log.Error("Secondary engine built a diverging payload",
+			"secondary", res.ID(),
+			"primary_state_root", primary.StateRoot, "secondary_state_root", res.StateRoot,
+			"primary_receipts_root", primary.ReceiptsRoot, "secondary_receipts_root", res.ReceiptsRoot,
+			"primary_gas_used", primary.GasUsed, "secondary_gas_used", res.GasUsed)
+		s.metrics.RecordShadowEngineResult(method, ShadowResultDiverged)
+		return
+	}
+	log.Debug("Secondary engine built matching payload")
+	s.metrics.RecordShadowEngineResult(method, ShadowResultMatch)
+}
+
+// trackPayloadID is exclusively called by the shadow loop.
+func (s *ShadowEngine) trackPayloadID(primary, secondary eth.PayloadID) {
+	s.payloadIDs[primary] = secondary
+	s.payloadIDsOrder = append(s.payloadIDsOrder, primary)
+	for len(s.payloadIDsOrder) > shadowMaxPayloadIDs {
+		delete(s.payloadIDs, s.payloadIDsOrder[0])
+		s.payloadIDsOrder = s.payloadIDsOrder[1:]
+	}
+}
+
+func (s *ShadowEngine) comparePayloadStatus(log log.Logger, method string, primary, secondary *eth.PayloadStatusV1) {
+	switch {
+	case isSyncingStatus(primary.Status) || isSyncingStatus(secondary.Status):
+		// One of the engines cannot tell yet, e.g. because it missed an earlier payload or is still syncing.
+		log.Debug("Engine is syncing, cannot compare results", "primary_status", primary.Status, "secondary_status", secondary.Status)
+		s.metrics.RecordShadowEngineResult(method, ShadowResultSyncing)
+	case primary.Status != secondary.Status:
+		log.Error("Secondary engine diverged from primary engine",
+			"primary_status", primary.Status, "secondary_status", secondary.Status,
+			"primary_latest_valid", latestValidHash(primary), "secondary_latest_valid", latestValidHash(secondary),
+			"secondary_err", validationError(secondary))
+		s.metrics.RecordShadowEngineResult(method, ShadowResultDiverged)
+	case primary.LatestValidHash != nil && secondary.LatestValidHash != nil && *primary.LatestValidHash != *secondary.LatestValidHash:
+		log.Error("Secondary engine diverged from primary engine, latest valid hash differs",
+			"status", primary.Status, "primary_latest_valid", *primary.LatestValidHash, "secondary_latest_valid", *secondary.LatestValidHash)
+		s.metrics.RecordShadowEngineResult(method, ShadowResultDiverged)
+	default:
+		s.metrics.RecordShadowEngineResult(method, ShadowResultMatch)
+	}
+}
+
+// latestValidHash returns the latest valid hash of the status, or the zero hash if the engine did not return any.
+func latestValidHash(status *eth.PayloadStatusV1) common.Hash {
+	if status.LatestValidHash == nil {
+		return common.Hash{}
+	}
+	return *status.LatestValidHash
+}
+
+// validationError returns the validation error message of the status, or an empty string if there is none.
+func validationError(status *eth.PayloadStatusV1) string {
+	if status.ValidationError == nil {
+		return ""
+	}
+	return *status.ValidationError
+}
+
+func isSyncingStatus(status eth.ExecutePayloadStatus) bool {
+	return status == eth.ExecutionSyncing || status == eth.ExecutionAccepted
+}
+
+// Close stops mirroring calls to the secondary engine. Buffered calls are dropped.
+func (s *ShadowEngine) Close() error {
+	s.cancel()
+	s.wg.Wait()
+	return nil
+}
@@ -0,0 +1,113 @@
+package node
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+type shadowResult struct {
+	method string
+	result string
+}
+
+type testShadowMetrics chan shadowResult
+
+func (m testShadowMetrics) RecordShadowEngineResult(method string, result string) {
+	m <- shadowResult{method: method, result: result}
+}
+
+func (m testShadowMetrics) next(t *testing.T) shadowResult {
+	select {
+	case res := <-m:
+		return res
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for shadow engine result")
+		return shadowResult{}
+	}
+}
+
+func TestShadowEngine(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlDebug)
+
+	setup := func() (*testutils.MockEngine, *testutils.MockEngine, testShadowMetrics, *ShadowEngine) {
+		primary := &testutils.MockEngine{}
+		secondary := &testutils.MockEngine{}
+		m := make(testShadowMetrics, 10)
+		s := NewShadowEngine(logger, primary, secondary, m)
+		t.Cleanup(func() {
+			require.NoError(t, s.Close())
+		})
+		return primary, secondary, m, s
+	}
+
+	validHash := common.Hash{0xaa}
+	payload := &eth.ExecutionPayload{BlockHash: validHash, BlockNumber: 10}
+
+	t.Run("matching new payload", func(t *testing.T) {
+		primary, secondary, m, s := setup()
+		status := &eth.PayloadStatusV1{Status: eth.ExecutionValid, LatestValidHash: &validHash}
+		primary.ExpectNewPayload(payload, status, nil)
+		secondary.ExpectNewPayload(payload, &eth.PayloadStatusV1{Status: eth.ExecutionValid, LatestValidHash: &validHash}, nil)
+
+		res, err := s.NewPayload(context.Background(), payload)
+		require.NoError(t, err)
+		require.Same(t, status, res, "primary result is returned as-is")
+		require.Equal(t, shadowResult{"newPayload", ShadowResultMatch}, m.next(t))
+	})
+
+	t.Run("diverging new payload", func(t *testing.T) {
+		primary, secondary, m, s := setup()
+		primary.ExpectNewPayload(payload, &eth.PayloadStatusV1{Status: eth.ExecutionValid, LatestValidHash: &validHash}, nil)
+		secondary.ExpectNewPayload(payload, &eth.PayloadStatusV1{Status: eth.ExecutionInvalid}, nil)
+
+		res, err := s.NewPayload(context.Background(), payload)
+		require.NoError(t, err)
+		require.Equal(t, eth.ExecutionValid, res.Status, "secondary never affects the primary result")
+		require.Equal(t, shadowResult{"newPayload", ShadowResultDiverged}, m.next(t))
+	})
+
+	t.Run("syncing secondary", func(t *testing.T) {
+		primary, secondary, m, s := setup()
+		primary.ExpectNewPayload(payload, &eth.PayloadStatusV1{Status: eth.ExecutionValid, LatestValidHash: &validHash}, nil)
+		secondary.ExpectNewPayload(payload, &eth.PayloadStatusV1{Status: eth.ExecutionSyncing}, nil)
+
+		_, err := s.NewPayload(context.Background(), payload)
+		require.NoError(t, err)
+		require.Equal(t, shadowResult{"newPayload", ShadowResultSyncing}, m.next(t))
+	})
+
+	t.Run("built payload comparison", func(t *testing.T) {
+		primary, secondary, m, s := setup()
+		fc := &eth.ForkchoiceState{HeadBlockHash: validHash}
+		attrs := &eth.PayloadAttributes{Timestamp: 123}
+		primaryID := eth.PayloadID{1}
+		secondaryID := eth.PayloadID{2}
+		fcStatus := eth.PayloadStatusV1{Status: eth.ExecutionValid, LatestValidHash: &validHash}
+		primary.ExpectForkchoiceUpdate(fc, attrs, &eth.ForkchoiceUpdatedResult{PayloadStatus: fcStatus, PayloadID: &primaryID}, nil)
+		secondary.ExpectForkchoiceUpdate(fc, attrs, &eth.ForkchoiceUpdatedResult{PayloadStatus: fcStatus, PayloadID: &secondaryID}, nil)
+
+		res, err := s.ForkchoiceUpdate(context.Background(), fc, attrs)
+		require.NoError(t, err)
+		require.Equal(t, primaryID, *res.PayloadID, "primary payload ID is returned")
+		require.Equal(t, shadowResult{"forkchoiceUpdated", ShadowResultMatch}, m.next(t))
+
+		built := &eth.ExecutionPayload{BlockHash: common.Hash{0xbb}, StateRoot: eth.Bytes32{0x01}}
+		primary.ExpectGetPayload(primaryID, built, nil)
+		secondary.ExpectGetPayload(secondaryID, &eth.ExecutionPayload{BlockHash: common.Hash{0xcc}, StateRoot: eth.Bytes32{0x02}}, nil)
+
+		out, err := s.GetPayload(context.Background(), primaryID)
+		require.NoError(t, err)
+		require.Same(t, built, out)
+		require.Equal(t, shadowResult{"getPayload", ShadowResultDiverged}, m.next(t))
+	})
+}
@@ -20,6 +20,8 @@ type ApplicationScorer interface {
 	onValidResponse(id peer.ID)
 	onResponseError(id peer.ID)
 	onRejectedPayload(id peer.ID)
+	onRateLimited(id peer.ID)
+	onInvalidRequest(id peer.ID)
 	start()
 	stop()
 }
@@ -87,6 +89,14 @@ func (s *peerApplicationScorer) onRejectedPayload(id peer.ID) {
 	}
 }
 
+// onRateLimited is not part of the persisted application score, it is only accounted for by the ban policy.
+func (s *peerApplicationScorer) onRateLimited(_ peer.ID) {
+}
+
+// onInvalidRequest is not part of the persisted application score, it is only accounted for by the ban policy.
+func (s *peerApplicationScorer) onInvalidRequest(_ peer.ID) {
+}
+
 func (s *peerApplicationScorer) decayScores(id peer.ID) {
 	_, err := s.scorebook.SetScore(id, &store.DecayApplicationScores{
 		ValidResponseDecay:   s.params.ValidResponseDecay,
@@ -142,6 +152,12 @@ func (n *NoopApplicationScorer) onResponseError(_ peer.ID) {
 func (n *NoopApplicationScorer) onRejectedPayload(_ peer.ID) {
 }
 
+func (n *NoopApplicationScorer) onRateLimited(_ peer.ID) {
+}
+
+func (n *NoopApplicationScorer) onInvalidRequest(_ peer.ID) {
+}
+
 func (n *NoopApplicationScorer) start() {
 }
 
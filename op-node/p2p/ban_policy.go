@@ -0,0 +1,489 @@
+package p2p
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/golang-lru/v2/simplelru"
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+)
+
+// Offense is a type of misbehaviour of a peer, as observed by the application scorer.
+type Offense string
+
+const (
+	OffenseRejectedPayload Offense = "rejected_payload" // served a payload that failed verification, or never turned out canonical
+	OffenseErrorResponse   Offense = "error_response"   // failed to serve a sync request
+	OffenseRateLimited     Offense = "rate_limited"     // exceeded the rate-limits of our sync server
+	OffenseInvalidRequest  Offense = "invalid_request"  // made a sync request that could never be served
+)
+
+// BanAction is the escalation level of a ban-policy decision.
+type BanAction string
+
+const (
+	BanActionDescore   BanAction = "descore"
+	BanActionBanPeer   BanAction = "ban_peer"
+	BanActionBanIP     BanAction = "ban_ip"
+	BanActionBanSubnet BanAction = "ban_subnet"
+)
+
+const (
+	// Maximum number of peers, IPs and subnets to track offenses of.
+	banPolicyTrackedEntries = 1000
+	// Maximum number of recent decisions to keep for inspection.
+	banPolicyDecisionsLimit = 256
+	// Interval to check for expired subnet bans.
+	subnetBanExpiryInterval = time.Minute
+)
+
+// BanPolicyConfig configures how peer misbehaviour escalates from descoring to peer, IP and subnet bans.
+type BanPolicyConfig struct {
+	// Window is the duration that offenses, and bans of peers and IPs, count towards further escalation.
+	Window time.Duration
+	// Weights is the weight of each offense type. Offenses without weight are ignored.
+	Weights map[Offense]float64
+
+	// DescoreThreshold is the total weight of offenses within the window at which the peer is descored.
+	DescoreThreshold float64
+	// DescorePenalty is subtracted from the application score of the peer, per unit of offense weight,
+	// while the peer is above the DescoreThreshold.
+	DescorePenalty float64
+
+	// PeerBanThreshold is the total weight of offenses within the window at which the peer is banned.
+	PeerBanThreshold float64
+	// PeerBanDuration is the duration of the first ban of a peer. Each repeated ban doubles the duration.
+	PeerBanDuration time.Duration
+	// MaxBanDuration caps the duration of repeated peer bans.
+	MaxBanDuration time.Duration
+
+	// IPBanPeers is the number of distinct peers banned from the same IP within the window, at which the IP is banned.
+	IPBanPeers    int
+	IPBanDuration time.Duration
+
+	// SubnetBanIPs is the number of distinct IPs banned in the same subnet within the window, at which the subnet is banned.
+	SubnetBanIPs      int
+	SubnetBanDuration time.Duration
+	// Subnet prefix lengths, for IPv4 and IPv6 addresses respectively.
+	SubnetIPv4Bits int
+	SubnetIPv6Bits int
+}
+
+func DefaultBanPolicyConfig() *BanPolicyConfig {
+	return &BanPolicyConfig{
+		Window: time.Hour,
+		Weights: map[Offense]float64{
+			OffenseRejectedPayload: 10,
+			OffenseErrorResponse:   1,
+			OffenseRateLimited:     2,
+			OffenseInvalidRequest:  5,
+		},
+		DescoreThreshold:  10,
+		DescorePenalty:    1,
+		PeerBanThreshold:  50,
+		PeerBanDuration:   time.Hour,
+		MaxBanDuration:    time.Hour * 24 * 7,
+		IPBanPeers:        3,
+		IPBanDuration:     time.Hour * 24,
+		SubnetBanIPs:      4,
+		SubnetBanDuration: time.Hour * 24,
+		SubnetIPv4Bits:    24,
+		SubnetIPv6Bits:    64,
+	}
+}
+
+func (c *BanPolicyConfig) Check() error {
+	if c.Window <= 0 {
+		return errors.New("ban policy window must be positive")
+	}
+	if c.PeerBanThreshold <= 0 {
+		return errors.New("ban policy peer-ban threshold must be positive")
+	}
+	if c.DescoreThreshold > c.PeerBanThreshold {
+		return fmt.Errorf("ban policy descore threshold %f must not exceed peer-ban threshold %f", c.DescoreThreshold, c.PeerBanThreshold)
+	}
+	if c.PeerBanDuration <= 0 || c.MaxBanDuration < c.PeerBanDuration {
+		return errors.New("ban policy peer-ban duration must be positive, and not exceed the max ban duration")
+	}
+	if c.IPBanPeers > 0 && c.IPBanDuration <= 0 {
+		return errors.New("ban policy IP-ban duration must be positive")
+	}
+	if c.SubnetBanIPs > 0 && c.SubnetBanDuration <= 0 {
+		return errors.New("ban policy subnet-ban duration must be positive")
+	}
+	if c.SubnetIPv4Bits <= 0 || c.SubnetIPv4Bits > 32 || c.SubnetIPv6Bits <= 0 || c.SubnetIPv6Bits > 128 {
+		return errors.New("invalid ban policy subnet prefix length")
+	}
+	return nil
+}
+
+// BanDecision is an escalation decision of the ban policy.
+type BanDecision struct {
+	Time    time.Time `json:"time"`
+	Action  BanAction `json:"action"`
+	Peer    peer.ID   `json:"peer,omitempty"`
+	IP      net.IP    `json:"ip,omitempty"`
+	Subnet  string    `json:"subnet,omitempty"`
+	Offense Offense   `json:"offense,omitempty"` // the offense that triggered the escalation
+	Weight  float64   `json:"weight"`            // total weight of offenses, or number of bans, that triggered the decision
+	Expiry  time.Time `json:"expiry"`
+}
+
+// BanPolicyBackend applies the decisions of the ban policy.
+type BanPolicyBackend interface {
+	IsStatic(peer.ID) bool
+	// PeerIPs returns the IPs of the current connections to the peer.
+	PeerIPs(peer.ID) []net.IP
+	// BanPeer bans the peer until the specified time and disconnects any existing connections.
+	BanPeer(peer.ID, time.Time) error
+	// BanIP bans the IP until the specified time and disconnects any existing connections.
+	BanIP(net.IP, time.Time) error
+	// BanSubnet bans the subnet until the specified time and disconnects any existing connections.
+	BanSubnet(*net.IPNet, time.Time) error
+	// ExpireSubnetBans lifts the subnet bans that have expired.
+	ExpireSubnetBans() error
+}
+
+type BanPolicyMetrics interface {
+	RecordBanPolicyDecision(action string)
+}
+
+type timedOffense struct {
+	time   time.Time
+	weight float64
+}
+
+type timedEntry[T comparable] struct {
+	time time.Time
+	key  T
+}
+
+// BanPolicy escalates misbehaviour of peers, as reported by the application scorer,
+// from descoring to time-limited bans of the peer, its IP, and eventually its subnet.
+// Bans are persisted by the backend, and are thus retained across restarts,
+// while the offense accounting is kept in memory.
+type BanPolicy struct {
+	log     log.Logger
+	clock   clock.Clock
+	cfg     *BanPolicyConfig
+	backend BanPolicyBackend
+	metrics BanPolicyMetrics
+
+	lock sync.Mutex
+	// offenses within the window, per peer
+	offenses *simplelru.LRU[peer.ID, []timedOffense]
+	// number of previous bans per peer, to escalate the ban duration
+	peerBans *simplelru.LRU[peer.ID, int]
+	// recently banned peers, per IP
+	ipBans *simplelru.LRU[string, []timedEntry[peer.ID]]
+	// recently banned IPs, per subnet
+	subnetBans *simplelru.LRU[string, []timedEntry[string]]
+	// recent decisions, oldest first
+	decisions []BanDecision
+
+	ctx      context.Context
+	cancelFn context.CancelFunc
+	bgTasks  sync.WaitGroup
+}
+
+func NewBanPolicy(ctx context.Context, log log.Logger, clock clock.Clock, cfg *BanPolicyConfig, backend BanPolicyBackend, metrics BanPolicyMetrics) *BanPolicy {
+	ctx, cancelFn := context.WithCancel(ctx)
+	// never errors with positive LRU cache size
+	offenses, _ := simplelru.NewLRU[peer.ID, []timedOffense](banPolicyTrackedEntries, nil)
+	peerBans, _ := simplelru.NewLRU[peer.ID, int](banPolicyTrackedEntries, nil)
+	ipBans, _ := simplelru.NewLRU[string, []timedEntry[peer.ID]](banPolicyTrackedEntries, nil)
+	subnetBans, _ := simplelru.NewLRU[string, []timedEntry[string]](banPolicyTrackedEntries, nil)
+	return &BanPolicy{
+		log:        log,
+		clock:      clock,
+		cfg:        cfg,
+		backend:    backend,
+		metrics:    metrics,
+		offenses:   offenses,
+		peerBans:   peerBans,
+		ipBans:     ipBans,
+		subnetBans: subnetBans,
+		ctx:        ctx,
+		cancelFn:   cancelFn,
+	}
+}
+
+// Start starts the background process that lifts expired subnet bans.
+// Peer and IP ban expiry is handled by the connection gater.
+func (p *BanPolicy) Start() {
+	p.bgTasks.Add(1)
+	go func() {
+		defer p.bgTasks.Done()
+		ticker := p.clock.NewTicker(subnetBanExpiryInterval)
+		defer ticker.Stop()
+		for {
+			if err := p.backend.ExpireSubnetBans(); err != nil {
+				p.log.Warn("Failed to lift expired subnet bans", "err", err)
+			}
+			select {
+			case <-p.ctx.Done():
+				return
+			case <-ticker.Ch():
+			}
+		}
+	}()
+}
+
+func (p *BanPolicy) Stop() {
+	p.cancelFn()
+	p.bgTasks.Wait()
+}
+
+// Record accounts an offense of the peer, and escalates if the policy thresholds are hit.
+// The escalation decisions are made while holding the lock, but the bans are applied by the backend,
+// which persists them and disconnects the peers, after releasing the lock.
+func (p *BanPolicy) Record(id peer.ID, offense Offense) {
+	weight := p.cfg.Weights[offense]
+	if weight <= 0 {
+		return
+	}
+	if p.backend.IsStatic(id) {
+		return
+	}
+	peerBan, ok := p.recordOffense(id, offense, weight)
+	if !ok {
+		return
+	}
+	// collect the IPs before the ban disconnects the peer
+	ips := p.backend.PeerIPs(id)
+	if err := p.backend.BanPeer(id, peerBan.Expiry); err != nil {
+		p.log.Warn("Failed to ban peer", "peer", id, "err", err)
+		return
+	}
+	for _, ipBan := range p.onPeerBanned(peerBan, ips) {
+		if err := p.backend.BanIP(ipBan.IP, ipBan.Expiry); err != nil {
+			p.log.Warn("Failed to ban IP", "ip", ipBan.IP, "err", err)
+			continue
+		}
+		subnetBan, ok := p.onIPBanned(ipBan)
+		if !ok {
+			continue
+		}
+		if err := p.backend.BanSubnet(p.subnetOf(ipBan.IP), subnetBan.Expiry); err != nil {
+			p.log.Warn("Failed to ban subnet", "subnet", subnetBan.Subnet, "err", err)
+			continue
+		}
+		p.lock.Lock()
+		p.decide(subnetBan)
+		p.lock.Unlock()
+	}
+}
+
+// recordOffense accounts the offense, and returns the peer-ban decision if the peer is to be banned.
+func (p *BanPolicy) recordOffense(id peer.ID, offense Offense, weight float64) (BanDecision, bool) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	now := p.clock.Now()
+	offenses, _ := p.offenses.Get(id)
+	offenses = append(pruneOffenses(offenses, now.Add(-p.cfg.Window)), timedOffense{time: now, weight: weight})
+	total := sumOffenses(offenses)
+	if total >= p.cfg.PeerBanThreshold {
+		// start with a clean slate after the ban, repeated bans are escalated separately
+		p.offenses.Remove(id)
+		prevBans, _ := p.peerBans.Get(id)
+		duration := p.cfg.PeerBanDuration
+		for i := 0; i < prevBans && duration < p.cfg.MaxBanDuration; i++ {
+			duration *= 2
+		}
+		if duration > p.cfg.MaxBanDuration {
+			duration = p.cfg.MaxBanDuration
+		}
+		p.peerBans.Add(id, prevBans+1)
+		return BanDecision{Time: now, Action: BanActionBanPeer, Peer: id, Offense: offense, Weight: total, Expiry: now.Add(duration)}, true
+	}
+	p.offenses.Add(id, offenses)
+	if total >= p.cfg.DescoreThreshold && total-weight < p.cfg.DescoreThreshold {
+		p.decide(BanDecision{Time: now, Action: BanActionDescore, Peer: id, Offense: offense, Weight: total, Expiry: now.Add(p.cfg.Window)})
+	}
+	return BanDecision{}, false
+}
+
+// Penalty returns the application score penalty of the peer, a negative number if the peer is descored.
+func (p *BanPolicy) Penalty(id peer.ID) float64 {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	offenses, ok := p.offenses.Peek(id)
+	if !ok {
+		return 0
+	}
+	total := sumOffenses(pruneOffenses(offenses, p.clock.Now().Add(-p.cfg.Window)))
+	if total < p.cfg.DescoreThreshold {
+		return 0
+	}
+	return -total * p.cfg.DescorePenalty
+}
+
+// ActiveDecisions returns the decisions that have not expired yet, oldest first.
+func (p *BanPolicy) ActiveDecisions() []BanDecision {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	now := p.clock.Now()
+	out := make([]BanDecision, 0)
+	for _, d := range p.decisions {
+		if d.Expiry.After(now) {
+			out = append(out, d)
+		}
+	}
+	return out
+}
+
+// onPeerBanned records the applied peer ban, and returns the IP-ban decisions of the IPs of the peer
+// that hit the IP-ban threshold.
+func (p *BanPolicy) onPeerBanned(d BanDecision, ips []net.IP) (ipBans []BanDecision) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.decide(d)
+	if p.cfg.IPBanPeers <= 0 {
+		return nil
+	}
+	for _, ip := range ips {
+		key := ip.String()
+		bans, _ := p.ipBans.Get(key)
+		bans = pruneEntries(bans, d.Time.Add(-p.cfg.Window))
+		if !containsEntry(bans, d.Peer) {
+			bans = append(bans, timedEntry[peer.ID]{time: d.Time, key: d.Peer})
+		}
+		if len(bans) >= p.cfg.IPBanPeers {
+			p.ipBans.Remove(key)
+			ipBans = append(ipBans, BanDecision{Time: d.Time, Action: BanActionBanIP, IP: ip, Offense: d.Offense,
+				Weight: float64(len(bans)), Expiry: d.Time.Add(p.cfg.IPBanDuration)})
+		} else {
+			p.ipBans.Add(key, bans)
+		}
+	}
+	return ipBans
+}
+
+// onIPBanned records the applied IP ban, and returns the subnet-ban decision if the subnet of the IP
+// hit the subnet-ban threshold.
+func (p *BanPolicy) onIPBanned(d BanDecision) (BanDecision, bool) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.decide(d)
+	if p.cfg.SubnetBanIPs <= 0 {
+		return BanDecision{}, false
+	}
+	key := p.subnetOf(d.IP).String()
+	bans, _ := p.subnetBans.Get(key)
+	bans = pruneEntries(bans, d.Time.Add(-p.cfg.Window))
+	if !containsEntry(bans, d.IP.String()) {
+		bans = append(bans, timedEntry[string]{time: d.Time, key: d.IP.String()})
+	}
+	if len(bans) < p.cfg.SubnetBanIPs {
+		p.subnetBans.Add(key, bans)
+		return BanDecision{}, false
+	}
+	p.subnetBans.Remove(key)
+	return BanDecision{Time: d.Time, Action: BanActionBanSubnet, Subnet: key, Offense: d.Offense,
+		Weight: float64(len(bans)), Expiry: d.Time.Add(p.cfg.SubnetBanDuration)}, true
+}
+
+func (p *BanPolicy) subnetOf(ip net.IP) *net.IPNet {
+	if ip4 := ip.To4(); ip4 != nil {
+		mask := net.CIDRMask(p.cfg.SubnetIPv4Bits, 32)
+		return &net.IPNet{IP: ip4.Mask(mask), Mask: mask}
+	}
+	mask := net.CIDRMask(p.cfg.SubnetIPv6Bits, 128)
+	return &net.IPNet{IP: ip.Mask(mask), Mask: mask}
+}
+
+// decide records the decision. The lock must be held.
+func (p *BanPolicy) decide(d BanDecision) {
+	p.log.Info("Ban policy decision", "action", d.Action, "peer", d.Peer, "ip", d.IP, "subnet", d.Subnet,
+		"offense", d.Offense, "weight", d.Weight, "expiry", d.Expiry)
+	p.metrics.RecordBanPolicyDecision(string(d.Action))
+	p.decisions = append(p.decisions, d)
+	if len(p.decisions) > banPolicyDecisionsLimit {
+		p.decisions = p.decisions[len(p.decisions)-banPolicyDecisionsLimit:]
+	}
+}
+
+func pruneOffenses(offenses []timedOffense, since time.Time) []timedOffense {
+	for len(offenses) > 0 && offenses[0].time.Before(since) {
+		offenses = offenses[1:]
+	}
+	return offenses
+}
+
+func sumOffenses(offenses []timedOffense) (total float64) {
+	for _, o := range offenses {
+		total += o.weight
+	}
+	return total
+}
+
+func pruneEntries[T comparable](entries []timedEntry[T], since time.Time) []timedEntry[T] {
+	for len(entries) > 0 && entries[0].time.Before(since) {
+		entries = entries[1:]
+	}
+	return entries
+}
+
+func containsEntry[T comparable](entries []timedEntry[T], key T) bool {
+	for _, e := range entries {
+		if e.key == key {
+			return true
+		}
+	}
+	return false
+}
+
+// banPolicyScorer wraps an ApplicationScorer, to report the misbehaviour events to the ban policy,
+// and to descore peers according to the ban policy.
+type banPolicyScorer struct {
+	ApplicationScorer
+	policy *BanPolicy
+}
+
+var _ ApplicationScorer = (*banPolicyScorer)(nil)
+
+func newBanPolicyScorer(inner ApplicationScorer, policy *BanPolicy) *banPolicyScorer {
+	return &banPolicyScorer{ApplicationScorer: inner, policy: policy}
+}
+
+func (s *banPolicyScorer) ApplicationScore(id peer.ID) float64 {
+	return s.ApplicationScorer.ApplicationScore(id) + s.policy.Penalty(id)
+}
+
+func (s *banPolicyScorer) onResponseError(id peer.ID) {
+	s.ApplicationScorer.onResponseError(id)
+	s.policy.Record(id, OffenseErrorResponse)
+}
+
+func (s *banPolicyScorer) onRejectedPayload(id peer.ID) {
+	s.ApplicationScorer.onRejectedPayload(id)
+	s.policy.Record(id, OffenseRejectedPayload)
+}
+
+func (s *banPolicyScorer) onRateLimited(id peer.ID) {
+	s.ApplicationScorer.onRateLimited(id)
+	s.policy.Record(id, OffenseRateLimited)
+}
+
+func (s *banPolicyScorer) onInvalidRequest(id peer.ID) {
+	s.ApplicationScorer.onInvalidRequest(id)
+	s.policy.Record(id, OffenseInvalidRequest)
+}
+
+func (s *banPolicyScorer) start() {
+	s.ApplicationScorer.start()
+	s.policy.Start()
+}
+
+func (s *banPolicyScorer) stop() {
+	s.ApplicationScorer.stop()
+	s.policy.Stop()
+}
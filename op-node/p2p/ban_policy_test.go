@@ -0,0 +1,208 @@
+package p2p
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+type testBanBackend struct {
+	static     map[peer.ID]bool
+	ips        map[peer.ID][]net.IP
+	peerBans   map[peer.ID]time.Time
+	ipBans     map[string]time.Time
+	subnetBans map[string]time.Time
+	// onBan, if not nil, is called by every ban
+	onBan func()
+}
+
+func newTestBanBackend() *testBanBackend {
+	return &testBanBackend{
+		static:     make(map[peer.ID]bool),
+		ips:        make(map[peer.ID][]net.IP),
+		peerBans:   make(map[peer.ID]time.Time),
+		ipBans:     make(map[string]time.Time),
+		subnetBans: make(map[string]time.Time),
+	}
+}
+
+func (b *testBanBackend) IsStatic(id peer.ID) bool {
+	return b.static[id]
+}
+
+func (b *testBanBackend) PeerIPs(id peer.ID) []net.IP {
+	return b.ips[id]
+}
+
+func (b *testBanBackend) BanPeer(id peer.ID, expiry time.Time) error {
+	if b.onBan != nil {
+		b.onBan()
+	}
+	b.peerBans[id] = expiry
+	return nil
+}
+
+func (b *testBanBackend) BanIP(ip net.IP, expiry time.Time) error {
+	if b.onBan != nil {
+		b.onBan()
+	}
+	b.ipBans[ip.String()] = expiry
+	return nil
+}
+
+func (b *testBanBackend) BanSubnet(subnet *net.IPNet, expiry time.Time) error {
+	if b.onBan != nil {
+		b.onBan()
+	}
+	b.subnetBans[subnet.String()] = expiry
+	return nil
+}
+
+func (b *testBanBackend) ExpireSubnetBans() error {
+	return nil
+}
+
+func setupBanPolicyTest(t *testing.T) (*BanPolicy, *testBanBackend, *clock.DeterministicClock) {
+	logger := testlog.Logger(t, log.LvlError)
+	clk := clock.NewDeterministicClock(time.UnixMilli(1000))
+	backend := newTestBanBackend()
+	policy := NewBanPolicy(context.Background(), logger, clk, DefaultBanPolicyConfig(), backend, metrics.NoopMetrics)
+	return policy, backend, clk
+}
+
+// offend records the rejected-payload offense n times, each worth 10 with the default config.
+func offend(policy *BanPolicy, id peer.ID, n int) {
+	for i := 0; i < n; i++ {
+		policy.Record(id, OffenseRejectedPayload)
+	}
+}
+
+func TestBanPolicyDescore(t *testing.T) {
+	policy, backend, clk := setupBanPolicyTest(t)
+	policy.Record("aaa", OffenseErrorResponse)
+	require.Zero(t, policy.Penalty("aaa"), "below descore threshold")
+
+	offend(policy, "aaa", 1)
+	require.Equal(t, float64(-11), policy.Penalty("aaa"))
+	require.Empty(t, backend.peerBans)
+	decisions := policy.ActiveDecisions()
+	require.Len(t, decisions, 1)
+	require.Equal(t, BanActionDescore, decisions[0].Action)
+
+	clk.AdvanceTime(time.Hour + time.Second)
+	require.Zero(t, policy.Penalty("aaa"), "offenses expire after the window")
+	require.Empty(t, policy.ActiveDecisions())
+}
+
+func TestBanPolicyIgnoresStaticPeers(t *testing.T) {
+	policy, backend, _ := setupBanPolicyTest(t)
+	backend.static["aaa"] = true
+	offend(policy, "aaa", 10)
+	require.Zero(t, policy.Penalty("aaa"))
+	require.Empty(t, backend.peerBans)
+	require.Empty(t, policy.ActiveDecisions())
+}
+
+func TestBanPolicyRepeatedPeerBans(t *testing.T) {
+	policy, backend, clk := setupBanPolicyTest(t)
+	for _, expected := range []time.Duration{time.Hour, 2 * time.Hour, 4 * time.Hour} {
+		offend(policy, "aaa", 5)
+		require.Equal(t, clk.Now().Add(expected), backend.peerBans["aaa"])
+		require.Zero(t, policy.Penalty("aaa"), "offenses are reset by the ban")
+		clk.AdvanceTime(expected)
+	}
+
+	// ban duration is capped
+	for i := 0; i < 10; i++ {
+		offend(policy, "aaa", 5)
+	}
+	require.Equal(t, clk.Now().Add(DefaultBanPolicyConfig().MaxBanDuration), backend.peerBans["aaa"])
+}
+
+func TestBanPolicyEscalateToIPAndSubnet(t *testing.T) {
+	policy, backend, _ := setupBanPolicyTest(t)
+	cfg := DefaultBanPolicyConfig()
+	for i := 0; i < cfg.SubnetBanIPs; i++ {
+		ip := net.IPv4(10, 0, 0, byte(i+1))
+		for j := 0; j < cfg.IPBanPeers; j++ {
+			id := peer.ID([]byte{byte(i), byte(j)})
+			backend.ips[id] = []net.IP{ip}
+			offend(policy, id, 5)
+			require.Contains(t, backend.peerBans, id)
+		}
+		require.Contains(t, backend.ipBans, ip.String(), "IP is banned after %d peer bans", cfg.IPBanPeers)
+		if i < cfg.SubnetBanIPs-1 {
+			require.Empty(t, backend.subnetBans)
+		}
+	}
+	require.Contains(t, backend.subnetBans, "10.0.0.0/24")
+
+	var actions []BanAction
+	for _, d := range policy.ActiveDecisions() {
+		if d.Action != BanActionBanPeer && d.Action != BanActionDescore {
+			actions = append(actions, d.Action)
+		}
+	}
+	require.Equal(t, []BanAction{BanActionBanIP, BanActionBanIP, BanActionBanIP, BanActionBanIP, BanActionBanSubnet}, actions)
+}
+
+func TestBanPolicyBansWithoutLock(t *testing.T) {
+	policy, backend, _ := setupBanPolicyTest(t)
+	bans := 0
+	backend.onBan = func() {
+		// the backend persists bans and disconnects peers, this must not block the policy
+		require.True(t, policy.lock.TryLock(), "ban policy lock must not be held while banning")
+		policy.lock.Unlock()
+		bans++
+	}
+	cfg := DefaultBanPolicyConfig()
+	for i := 0; i < cfg.SubnetBanIPs; i++ {
+		ip := net.IPv4(10, 0, 1, byte(i+1))
+		for j := 0; j < cfg.IPBanPeers; j++ {
+			id := peer.ID([]byte{byte(i), byte(j)})
+			backend.ips[id] = []net.IP{ip}
+			offend(policy, id, 5)
+		}
+	}
+	require.Equal(t, cfg.SubnetBanIPs*cfg.IPBanPeers+cfg.SubnetBanIPs+1, bans, "peer, IP and subnet bans")
+	require.Contains(t, backend.subnetBans, "10.0.1.0/24")
+}
+
+func TestBanPolicyIPBansExpireFromWindow(t *testing.T) {
+	policy, backend, clk := setupBanPolicyTest(t)
+	cfg := DefaultBanPolicyConfig()
+	ip := net.ParseIP("2001:db8::1")
+	for j := 0; j < cfg.IPBanPeers; j++ {
+		id := peer.ID([]byte{byte(j)})
+		backend.ips[id] = []net.IP{ip}
+		offend(policy, id, 5)
+		// spread the peer bans beyond the window
+		clk.AdvanceTime(cfg.Window)
+	}
+	require.Empty(t, backend.ipBans)
+}
+
+func TestBanPolicyScorer(t *testing.T) {
+	policy, backend, _ := setupBanPolicyTest(t)
+	scorer := newBanPolicyScorer(&NoopApplicationScorer{}, policy)
+	for i := 0; i < 2; i++ {
+		scorer.onInvalidRequest("aaa")
+	}
+	require.Equal(t, float64(-10), scorer.ApplicationScore("aaa"))
+	for i := 0; i < 20; i++ {
+		scorer.onRateLimited("aaa")
+	}
+	require.Contains(t, backend.peerBans, peer.ID("aaa"))
+	scorer.onValidResponse("bbb")
+	require.Zero(t, scorer.ApplicationScore("bbb"))
+}
@@ -108,6 +108,17 @@ func loadBanningOptions(conf *p2p.Config, ctx *cli.Context) error {
 	conf.BanningEnabled = ctx.Bool(flags.BanningName)
 	conf.BanningThreshold = ctx.Float64(flags.BanningThresholdName)
 	conf.BanningDuration = ctx.Duration(flags.BanningDurationName)
+	if ctx.Bool(flags.BanPolicyName) {
+		policy := p2p.DefaultBanPolicyConfig()
+		policy.Window = ctx.Duration(flags.BanPolicyWindowName)
+		policy.PeerBanDuration = conf.BanningDuration
+		if policy.MaxBanDuration < policy.PeerBanDuration {
+			policy.MaxBanDuration = policy.PeerBanDuration
+		}
+		policy.IPBanDuration = ctx.Duration(flags.BanPolicyIPDurationName)
+		policy.SubnetBanDuration = ctx.Duration(flags.BanPolicySubnetDurationName)
+		conf.BanPolicyConfig = policy
+	}
 	return nil
 }
 
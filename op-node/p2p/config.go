@@ -48,6 +48,8 @@ type SetupP2P interface {
 	BanPeers() bool
 	BanThreshold() float64
 	BanDuration() time.Duration
+	// BanPolicy returns the misbehaviour-based ban policy configuration. Returns nil if the ban policy is disabled.
+	BanPolicy() *BanPolicyConfig
 	GossipSetupConfigurables
 	ReqRespSyncEnabled() bool
 }
@@ -74,6 +76,9 @@ type Config struct {
 	BanningThreshold float64
 	BanningDuration  time.Duration
 
+	// Misbehaviour-based escalation of peer descoring and bans. Disabled if nil.
+	BanPolicyConfig *BanPolicyConfig
+
 	ListenIP      net.IP
 	ListenTCPPort uint16
 
@@ -156,6 +161,10 @@ func (conf *Config) BanDuration() time.Duration {
 	return conf.BanningDuration
 }
 
+func (conf *Config) BanPolicy() *BanPolicyConfig {
+	return conf.BanPolicyConfig
+}
+
 func (conf *Config) ReqRespSyncEnabled() bool {
 	return conf.EnableReqRespSync
 }
@@ -189,5 +198,10 @@ func (conf *Config) Check() error {
 	if conf.MeshDLazy <= 0 || conf.MeshDLazy > maxMeshParam {
 		return fmt.Errorf("mesh Dlazy param must not be 0 or exceed %d, but got %d", maxMeshParam, conf.MeshDLazy)
 	}
+	if conf.BanPolicyConfig != nil {
+		if err := conf.BanPolicyConfig.Check(); err != nil {
+			return fmt.Errorf("invalid ban policy: %w", err)
+		}
+	}
 	return nil
 }
@@ -59,6 +59,9 @@ type GossipSetupConfigurables interface {
 
 type GossipRuntimeConfig interface {
 	P2PSequencerAddress() common.Address
+	// P2PSequencerKeys returns the keys that are authorised to sign blocks on the blocks v2 topic.
+	// If empty, the P2PSequencerAddress is authorised for all blocks instead.
+	P2PSequencerKeys() eth.SequencerKeys
 }
 
 //go:generate mockery --name GossipMetricer
@@ -70,10 +73,17 @@ func blocksTopicV1(cfg *rollup.Config) string {
 	return fmt.Sprintf("/optimism/%s/0/blocks", cfg.L2ChainID.String())
 }
 
+// blocksV2HeaderSize is the size of the blocks v2 envelope header: the signature, followed by the signer address.
+const blocksV2HeaderSize = 65 + common.AddressLength
+
+func blocksTopicV2(cfg *rollup.Config) string {
+	return fmt.Sprintf("/optimism/%s/1/blocks", cfg.L2ChainID.String())
+}
+
 // BuildSubscriptionFilter builds a simple subscription filter,
 // to help protect against peers spamming useless subscriptions.
 func BuildSubscriptionFilter(cfg *rollup.Config) pubsub.SubscriptionFilter {
-	return pubsub.NewAllowlistSubscriptionFilter(blocksTopicV1(cfg)) // add more topics here in the future, if any.
+	return pubsub.NewAllowlistSubscriptionFilter(blocksTopicV1(cfg), blocksTopicV2(cfg)) // add more topics here in the future, if any.
 }
 
 var msgBufPool = sync.Pool{New: func() any {
@@ -239,7 +249,25 @@ func (sb *seenBlocks) markSeen(h common.Hash) {
 	sb.blockHashes = append(sb.blockHashes, h)
 }
 
+// BlocksVersion identifies the version of the blocks gossip topic, and thereby the message envelope.
+type BlocksVersion int
+
+const (
+	// BlocksV1 messages are a signature, followed by the SSZ-encoded payload.
+	// The signer must be the single P2PSequencerAddress of the runtime config.
+	BlocksV1 BlocksVersion = iota
+	// BlocksV2 messages are a signature, followed by the signer address, followed by the SSZ-encoded payload.
+	// The signer must be authorised for the block height by the P2PSequencerKeys of the runtime config.
+	BlocksV2
+)
+
+// BuildBlocksValidator builds the validator of the blocks v1 gossip topic.
 func BuildBlocksValidator(log log.Logger, cfg *rollup.Config, runCfg GossipRuntimeConfig) pubsub.ValidatorEx {
+	return BuildVersionedBlocksValidator(log, cfg, runCfg, BlocksV1)
+}
+
+// BuildVersionedBlocksValidator builds the validator of the blocks gossip topic of the given version.
+func BuildVersionedBlocksValidator(log log.Logger, cfg *rollup.Config, runCfg GossipRuntimeConfig, version BlocksVersion) pubsub.ValidatorEx {
 
 	// Seen block hashes per block height
 	// uint64 -> *seenBlocks
@@ -259,7 +287,7 @@ func BuildBlocksValidator(log log.Logger, cfg *rollup.Config, runCfg GossipRunti
 			log.Warn("possible snappy zip bomb, decoded length is too large", "decoded_length", outLen, "peer", id)
 			return pubsub.ValidationReject
 		}
-		if outLen < minGossipSize {
+		if outLen < minGossipSize || (version == BlocksV2 && outLen < blocksV2HeaderSize+1) {
 			log.Warn("rejecting undersized gossip payload")
 			return pubsub.ValidationReject
 		}
@@ -275,9 +303,19 @@ func BuildBlocksValidator(log log.Logger, cfg *rollup.Config, runCfg GossipRunti
 
 		// message starts with compact-encoding secp256k1 encoded signature
 		signatureBytes, payloadBytes := data[:65], data[65:]
+		var signer common.Address
+		if version == BlocksV2 {
+			// the v2 envelope carries the identity of the signer after the signature
+			signer, payloadBytes = common.BytesToAddress(data[65:blocksV2HeaderSize]), data[blocksV2HeaderSize:]
+		}
 
 		// [REJECT] if the signature by the sequencer is not valid
-		result := verifyBlockSignature(log, cfg, runCfg, id, signatureBytes, payloadBytes)
+		var result pubsub.ValidationResult
+		if version == BlocksV2 {
+			result = verifyBlockSignatureV2(log, cfg, id, signatureBytes, signer, payloadBytes)
+		} else {
+			result = verifyBlockSignature(log, cfg, runCfg, id, signatureBytes, payloadBytes)
+		}
 		if result != pubsub.ValidationAccept {
 			return result
 		}
@@ -289,6 +327,13 @@ func BuildBlocksValidator(log log.Logger, cfg *rollup.Config, runCfg GossipRunti
 			return pubsub.ValidationReject
 		}
 
+		// [REJECT] if the v2 signer is not authorised to sign blocks at the height of the payload
+		if version == BlocksV2 {
+			if result := verifyBlockSigner(log, runCfg, id, signer, uint64(payload.BlockNumber)); result != pubsub.ValidationAccept {
+				return result
+			}
+		}
+
 		// rounding down to seconds is fine here.
 		now := uint64(time.Now().Unix())
 
@@ -365,6 +410,50 @@ func verifyBlockSignature(log log.Logger, cfg *rollup.Config, runCfg GossipRunti
 	return pubsub.ValidationAccept
 }
 
+// verifyBlockSignatureV2 checks that the signature over the payload was made by the signer of the v2 envelope.
+// The v2 envelope carries the same signature as the v1 message, the payload is the same on both topics.
+// Whether the signer is authorised is checked separately by verifyBlockSigner, once the block height is known.
+func verifyBlockSignatureV2(log log.Logger, cfg *rollup.Config, id peer.ID, signatureBytes []byte, signer common.Address, payloadBytes []byte) pubsub.ValidationResult {
+	signingHash, err := BlockSigningHash(cfg, payloadBytes)
+	if err != nil {
+		log.Warn("failed to compute block signing hash", "err", err, "peer", id)
+		return pubsub.ValidationReject
+	}
+	pub, err := crypto.SigToPub(signingHash[:], signatureBytes)
+	if err != nil {
+		log.Warn("invalid block signature", "err", err, "peer", id)
+		return pubsub.ValidationReject
+	}
+	if addr := crypto.PubkeyToAddress(*pub); addr != signer {
+		log.Warn("block signature does not match signer identity", "peer", id, "addr", addr, "signer", signer)
+		return pubsub.ValidationReject
+	}
+	return pubsub.ValidationAccept
+}
+
+// verifyBlockSigner checks that the signer is authorised to sign the block with the given number.
+// Without a configured key set, the single p2p sequencer address is authorised for all blocks.
+func verifyBlockSigner(log log.Logger, runCfg GossipRuntimeConfig, id peer.ID, signer common.Address, num uint64) pubsub.ValidationResult {
+	keys := runCfg.P2PSequencerKeys()
+	if len(keys) == 0 {
+		if expected := runCfg.P2PSequencerAddress(); expected == (common.Address{}) {
+			log.Warn("no configured p2p sequencer keys, ignoring gossiped block", "peer", id, "signer", signer)
+			return pubsub.ValidationIgnore
+		} else {
+			keys = eth.SequencerKeys{{Address: expected}}
+		}
+	}
+	if keys.Authorized(signer, num) {
+		return pubsub.ValidationAccept
+	}
+	if keys.Contains(signer) {
+		log.Warn("block signer is not authorised at block height", "peer", id, "signer", signer, "block", num, "keys", keys)
+	} else {
+		log.Warn("unexpected block author", "peer", id, "signer", signer, "block", num, "keys", keys)
+	}
+	return pubsub.ValidationReject
+}
+
 type GossipIn interface {
 	OnUnsafeL2Payload(ctx context.Context, from peer.ID, msg *eth.ExecutionPayload) error
 }
@@ -395,15 +484,34 @@ type publisher struct {
 	// block subscriptions, to be cancelled before closing blocks topic.
 	blocksSub *pubsub.Subscription
 
+	// blocks v2 topic, with its event handler and subscription, like the v1 topic.
+	blocksV2Topic  *pubsub.Topic
+	blocksV2Events *pubsub.TopicEventHandler
+	blocksV2Sub    *pubsub.Subscription
+
 	runCfg GossipRuntimeConfig
 }
 
 var _ GossipOut = (*publisher)(nil)
 
+// BlocksTopicPeers returns the peers of the v1 and v2 blocks topics.
 func (p *publisher) BlocksTopicPeers() []peer.ID {
-	return p.blocksTopic.ListPeers()
+	peers := p.blocksTopic.ListPeers()
+	seen := make(map[peer.ID]struct{}, len(peers))
+	for _, id := range peers {
+		seen[id] = struct{}{}
+	}
+	for _, id := range p.blocksV2Topic.ListPeers() {
+		if _, ok := seen[id]; !ok {
+			peers = append(peers, id)
+		}
+	}
+	return peers
 }
 
+// PublishL2Payload publishes the payload on both the v1 and the v2 blocks topic,
+// so peers that do not support the v2 topic yet continue to receive blocks.
+// The payload is signed once, the signature is the same on both topics.
 func (p *publisher) PublishL2Payload(ctx context.Context, payload *eth.ExecutionPayload, signer Signer) error {
 	res := msgBufPool.Get().(*[]byte)
 	buf := bytes.NewBuffer((*res)[:0])
@@ -412,71 +520,111 @@ func (p *publisher) PublishL2Payload(ctx context.Context, payload *eth.Execution
 		defer msgBufPool.Put(res)
 	}()
 
-	buf.Write(make([]byte, 65))
+	// Reserve space for the v2 envelope header, the v1 message is a sub-slice of the v2 message.
+	buf.Write(make([]byte, blocksV2HeaderSize))
 	if _, err := payload.MarshalSSZ(buf); err != nil {
 		return fmt.Errorf("failed to encoded execution payload to publish: %w", err)
 	}
 	data := buf.Bytes()
-	payloadData := data[65:]
+	payloadData := data[blocksV2HeaderSize:]
+
 	sig, err := signer.Sign(ctx, SigningDomainBlocksV1, p.cfg.L2ChainID, payloadData)
 	if err != nil {
 		return fmt.Errorf("failed to sign execution payload with signer: %w", err)
 	}
+	// The signer identity is recovered from the signature, so remote signers do not have to expose their address.
+	signingHash, err := BlockSigningHash(p.cfg, payloadData)
+	if err != nil {
+		return fmt.Errorf("failed to compute block signing hash: %w", err)
+	}
+	pub, err := crypto.SigToPub(signingHash[:], sig[:])
+	if err != nil {
+		return fmt.Errorf("failed to recover signer of execution payload: %w", err)
+	}
 	copy(data[:65], sig[:])
-
+	copy(data[65:blocksV2HeaderSize], crypto.PubkeyToAddress(*pub).Bytes())
 	// compress the full message
 	// This also copies the data, freeing up the original buffer to go back into the pool
-	out := snappy.Encode(nil, data)
+	outV2 := snappy.Encode(nil, data)
+
+	dataV1 := data[blocksV2HeaderSize-65:]
+	copy(dataV1[:65], sig[:])
+	outV1 := snappy.Encode(nil, dataV1)
 
-	return p.blocksTopic.Publish(ctx, out)
+	return errors.Join(
+		p.blocksTopic.Publish(ctx, outV1),
+		p.blocksV2Topic.Publish(ctx, outV2),
+	)
 }
 
 func (p *publisher) Close() error {
 	p.p2pCancel()
 	p.blocksEvents.Cancel()
 	p.blocksSub.Cancel()
-	return p.blocksTopic.Close()
+	p.blocksV2Events.Cancel()
+	p.blocksV2Sub.Cancel()
+	return errors.Join(p.blocksTopic.Close(), p.blocksV2Topic.Close())
 }
 
-func JoinGossip(self peer.ID, ps *pubsub.PubSub, log log.Logger, cfg *rollup.Config, runCfg GossipRuntimeConfig, gossipIn GossipIn) (GossipOut, error) {
-	val := guardGossipValidator(log, logValidationResult(self, "validated block", log, BuildBlocksValidator(log, cfg, runCfg)))
-	blocksTopicName := blocksTopicV1(cfg)
-	err := ps.RegisterTopicValidator(blocksTopicName,
+// joinBlocksTopic registers the validator of the blocks topic, joins it, logs its events,
+// and passes validated payloads of the topic on to the gossipIn.
+func joinBlocksTopic(p2pCtx context.Context, self peer.ID, ps *pubsub.PubSub, log log.Logger, cfg *rollup.Config, runCfg GossipRuntimeConfig, gossipIn GossipIn, version BlocksVersion, topicName string) (*pubsub.Topic, *pubsub.TopicEventHandler, *pubsub.Subscription, error) {
+	val := guardGossipValidator(log, logValidationResult(self, "validated block", log, BuildVersionedBlocksValidator(log, cfg, runCfg, version)))
+	err := ps.RegisterTopicValidator(topicName,
 		val,
 		pubsub.WithValidatorTimeout(3*time.Second),
 		pubsub.WithValidatorConcurrency(4))
 	if err != nil {
-		return nil, fmt.Errorf("failed to register blocks gossip topic: %w", err)
+		return nil, nil, nil, fmt.Errorf("failed to register blocks gossip topic: %w", err)
 	}
-	blocksTopic, err := ps.Join(blocksTopicName)
+	blocksTopic, err := ps.Join(topicName)
 	if err != nil {
-		return nil, fmt.Errorf("failed to join blocks gossip topic: %w", err)
+		return nil, nil, nil, fmt.Errorf("failed to join blocks gossip topic: %w", err)
 	}
 	blocksTopicEvents, err := blocksTopic.EventHandler()
 	if err != nil {
-		return nil, fmt.Errorf("failed to create blocks gossip topic handler: %w", err)
+		return nil, nil, nil, errors.Join(fmt.Errorf("failed to create blocks gossip topic handler: %w", err), blocksTopic.Close())
 	}
-	p2pCtx, p2pCancel := context.WithCancel(context.Background())
-	go LogTopicEvents(p2pCtx, log.New("topic", "blocks"), blocksTopicEvents)
+	go LogTopicEvents(p2pCtx, log.New("topic", topicName), blocksTopicEvents)
 
 	subscription, err := blocksTopic.Subscribe()
 	if err != nil {
-		p2pCancel()
+		blocksTopicEvents.Cancel()
 		err = errors.Join(err, blocksTopic.Close())
-		return nil, fmt.Errorf("failed to subscribe to blocks gossip topic: %w", err)
+		return nil, nil, nil, fmt.Errorf("failed to subscribe to blocks gossip topic: %w", err)
 	}
 
 	subscriber := MakeSubscriber(log, BlocksHandler(gossipIn.OnUnsafeL2Payload))
 	go subscriber(p2pCtx, subscription)
+	return blocksTopic, blocksTopicEvents, subscription, nil
+}
+
+func JoinGossip(self peer.ID, ps *pubsub.PubSub, log log.Logger, cfg *rollup.Config, runCfg GossipRuntimeConfig, gossipIn GossipIn) (GossipOut, error) {
+	p2pCtx, p2pCancel := context.WithCancel(context.Background())
+	blocksTopic, blocksEvents, blocksSub, err := joinBlocksTopic(p2pCtx, self, ps, log, cfg, runCfg, gossipIn, BlocksV1, blocksTopicV1(cfg))
+	if err != nil {
+		p2pCancel()
+		return nil, err
+	}
+	blocksV2Topic, blocksV2Events, blocksV2Sub, err := joinBlocksTopic(p2pCtx, self, ps, log, cfg, runCfg, gossipIn, BlocksV2, blocksTopicV2(cfg))
+	if err != nil {
+		p2pCancel()
+		blocksEvents.Cancel()
+		blocksSub.Cancel()
+		return nil, errors.Join(fmt.Errorf("failed to join blocks v2 topic: %w", err), blocksTopic.Close())
+	}
 
 	return &publisher{
-		log:          log,
-		cfg:          cfg,
-		blocksTopic:  blocksTopic,
-		blocksEvents: blocksTopicEvents,
-		blocksSub:    subscription,
-		p2pCancel:    p2pCancel,
-		runCfg:       runCfg,
+		log:            log,
+		cfg:            cfg,
+		blocksTopic:    blocksTopic,
+		blocksEvents:   blocksEvents,
+		blocksSub:      blocksSub,
+		blocksV2Topic:  blocksV2Topic,
+		blocksV2Events: blocksV2Events,
+		blocksV2Sub:    blocksV2Sub,
+		p2pCancel:      p2pCancel,
+		runCfg:         runCfg,
 	}, nil
 }
 
@@ -1,18 +1,24 @@
 package p2p
 
 import (
+	"bytes"
 	"context"
 	"math/big"
 	"testing"
+	"time"
+
+	"github.com/golang/snappy"
 
 	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/testutils"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 
 	"github.com/ethereum/go-ethereum/log"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pb "github.com/libp2p/go-libp2p-pubsub/pb"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/stretchr/testify/require"
 
@@ -84,3 +90,119 @@ func TestVerifyBlockSignature(t *testing.T) {
 		require.Equal(t, pubsub.ValidationIgnore, result)
 	})
 }
+
+func TestVerifyBlockSignatureV2(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlCrit)
+	cfg := &rollup.Config{
+		L2ChainID: big.NewInt(100),
+	}
+	peerId := peer.ID("foo")
+	secrets, err := e2eutils.DefaultMnemonicConfig.Secrets()
+	require.NoError(t, err)
+	msg := []byte("any msg")
+	signer := &PreparedSigner{Signer: NewLocalSigner(secrets.SequencerP2P)}
+	signerAddr := crypto.PubkeyToAddress(secrets.SequencerP2P.PublicKey)
+
+	t.Run("Valid", func(t *testing.T) {
+		sig, err := signer.Sign(context.Background(), SigningDomainBlocksV1, cfg.L2ChainID, msg)
+		require.NoError(t, err)
+		result := verifyBlockSignatureV2(logger, cfg, peerId, sig[:65], signerAddr, msg)
+		require.Equal(t, pubsub.ValidationAccept, result)
+	})
+
+	t.Run("WrongIdentity", func(t *testing.T) {
+		sig, err := signer.Sign(context.Background(), SigningDomainBlocksV1, cfg.L2ChainID, msg)
+		require.NoError(t, err)
+		result := verifyBlockSignatureV2(logger, cfg, peerId, sig[:65], common.HexToAddress("0x1234"), msg)
+		require.Equal(t, pubsub.ValidationReject, result)
+	})
+
+	t.Run("InvalidSignature", func(t *testing.T) {
+		result := verifyBlockSignatureV2(logger, cfg, peerId, make([]byte, 65), signerAddr, msg)
+		require.Equal(t, pubsub.ValidationReject, result)
+	})
+}
+
+func TestVerifyBlockSigner(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlCrit)
+	peerId := peer.ID("foo")
+	oldKey := common.HexToAddress("0x1111")
+	newKey := common.HexToAddress("0x2222")
+	// the new key is activated at block 100, the old key expires at block 110: blocks 100-109 are an overlap period
+	runCfg := &testutils.MockRuntimeConfig{
+		P2PSeqAddress: oldKey,
+		P2PSeqKeys: eth.SequencerKeys{
+			{Address: oldKey, Activation: 0, Expiry: 110},
+			{Address: newKey, Activation: 100},
+		},
+	}
+
+	require.Equal(t, pubsub.ValidationAccept, verifyBlockSigner(logger, runCfg, peerId, oldKey, 99))
+	require.Equal(t, pubsub.ValidationReject, verifyBlockSigner(logger, runCfg, peerId, newKey, 99), "new key is not active yet")
+	require.Equal(t, pubsub.ValidationAccept, verifyBlockSigner(logger, runCfg, peerId, oldKey, 105), "overlap")
+	require.Equal(t, pubsub.ValidationAccept, verifyBlockSigner(logger, runCfg, peerId, newKey, 105), "overlap")
+	require.Equal(t, pubsub.ValidationReject, verifyBlockSigner(logger, runCfg, peerId, oldKey, 110), "old key expired")
+	require.Equal(t, pubsub.ValidationAccept, verifyBlockSigner(logger, runCfg, peerId, newKey, 110))
+	require.Equal(t, pubsub.ValidationReject, verifyBlockSigner(logger, runCfg, peerId, common.HexToAddress("0x3333"), 105))
+
+	t.Run("LegacyAddress", func(t *testing.T) {
+		runCfg := &testutils.MockRuntimeConfig{P2PSeqAddress: oldKey}
+		require.Equal(t, pubsub.ValidationAccept, verifyBlockSigner(logger, runCfg, peerId, oldKey, 1000))
+		require.Equal(t, pubsub.ValidationReject, verifyBlockSigner(logger, runCfg, peerId, newKey, 1000))
+	})
+
+	t.Run("NoSequencer", func(t *testing.T) {
+		runCfg := &testutils.MockRuntimeConfig{}
+		require.Equal(t, pubsub.ValidationIgnore, verifyBlockSigner(logger, runCfg, peerId, oldKey, 1000))
+	})
+}
+
+func TestBlocksV2Validator(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlCrit)
+	cfg := &rollup.Config{
+		L2ChainID: big.NewInt(100),
+	}
+	secrets, err := e2eutils.DefaultMnemonicConfig.Secrets()
+	require.NoError(t, err)
+	signer := &PreparedSigner{Signer: NewLocalSigner(secrets.SequencerP2P)}
+	signerAddr := crypto.PubkeyToAddress(secrets.SequencerP2P.PublicKey)
+
+	payload := &eth.ExecutionPayload{
+		BlockNumber:  100,
+		Timestamp:    eth.Uint64Quantity(time.Now().Unix()),
+		ExtraData:    []byte{},
+		Transactions: []eth.Data{},
+	}
+	payload.BlockHash, _ = payload.CheckBlockHash()
+	var buf bytes.Buffer
+	_, err = payload.MarshalSSZ(&buf)
+	require.NoError(t, err)
+	payloadBytes := buf.Bytes()
+
+	encode := func(claimed common.Address) *pubsub.Message {
+		sig, err := signer.Sign(context.Background(), SigningDomainBlocksV1, cfg.L2ChainID, payloadBytes)
+		require.NoError(t, err)
+		data := append(append(sig[:], claimed.Bytes()...), payloadBytes...)
+		return &pubsub.Message{Message: &pb.Message{Data: snappy.Encode(nil, data)}}
+	}
+
+	t.Run("Authorised", func(t *testing.T) {
+		runCfg := &testutils.MockRuntimeConfig{P2PSeqKeys: eth.SequencerKeys{{Address: signerAddr, Activation: 100}}}
+		val := BuildVersionedBlocksValidator(logger, cfg, runCfg, BlocksV2)
+		msg := encode(signerAddr)
+		require.Equal(t, pubsub.ValidationAccept, val(context.Background(), "foo", msg))
+		require.Equal(t, payload.BlockHash, msg.ValidatorData.(*eth.ExecutionPayload).BlockHash)
+	})
+
+	t.Run("NotYetActive", func(t *testing.T) {
+		runCfg := &testutils.MockRuntimeConfig{P2PSeqKeys: eth.SequencerKeys{{Address: signerAddr, Activation: 101}}}
+		val := BuildVersionedBlocksValidator(logger, cfg, runCfg, BlocksV2)
+		require.Equal(t, pubsub.ValidationReject, val(context.Background(), "foo", encode(signerAddr)))
+	})
+
+	t.Run("WrongIdentity", func(t *testing.T) {
+		runCfg := &testutils.MockRuntimeConfig{P2PSeqKeys: eth.SequencerKeys{{Address: signerAddr}}}
+		val := BuildVersionedBlocksValidator(logger, cfg, runCfg, BlocksV2)
+		require.Equal(t, pubsub.ValidationReject, val(context.Background(), "foo", encode(common.HexToAddress("0x1234"))))
+	})
+}
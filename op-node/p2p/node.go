@@ -40,6 +40,7 @@ type NodeP2P struct {
 	peerMonitor *monitor.PeerMonitor           // peer monitor to disconnect bad peers, may be nil even with p2p enabled
 	store       store.ExtendedPeerstore        // peerstore of host, with extra bindings for scoring and banning
 	appScorer   ApplicationScorer
+	banPolicy   *BanPolicy // escalates misbehaviour to descoring and bans, may be nil
 	log         log.Logger
 	// the below components are all optional, and may be nil. They require the host to not be nil.
 	dv5Local *enode.LocalNode // p2p discovery identity
@@ -104,6 +105,10 @@ func (n *NodeP2P) init(resourcesCtx context.Context, rollupCfg *rollup.Config, l
 		} else {
 			n.appScorer = &NoopApplicationScorer{}
 		}
+		if policyCfg := setup.BanPolicy(); policyCfg != nil {
+			n.banPolicy = NewBanPolicy(resourcesCtx, log, clock.SystemClock, policyCfg, n, metrics)
+			n.appScorer = newBanPolicyScorer(n.appScorer, n.banPolicy)
+		}
 		// Activate the P2P req-resp sync if enabled by feature-flag.
 		if setup.ReqRespSyncEnabled() {
 			n.syncCl = NewSyncClient(log, rollupCfg, n.host.NewStream, gossipIn.OnUnsafeL2Payload, metrics, n.appScorer)
@@ -121,10 +126,14 @@ func (n *NodeP2P) init(resourcesCtx context.Context, rollupCfg *rollup.Config, l
 				n.syncCl.AddPeer(peerID)
 			}
 			if l2Chain != nil { // Only enable serving side of req-resp sync if we have a data-source, to make minimal P2P testing easy
-				n.syncSrv = NewReqRespServer(rollupCfg, l2Chain, metrics)
+				n.syncSrv = NewReqRespServer(rollupCfg, l2Chain, metrics, n.appScorer)
 				// register the sync protocol with libp2p host
 				payloadByNumber := MakeStreamHandler(resourcesCtx, log.New("serve", "payloads_by_number"), n.syncSrv.HandleSyncRequest)
 				n.host.SetStreamHandler(PayloadByNumberProtocolID(rollupCfg.L2ChainID), payloadByNumber)
+				payloadsByRange := MakeStreamHandler(resourcesCtx, log.New("serve", "payloads_by_range"), n.syncSrv.HandleRangeSyncRequest)
+				n.host.SetStreamHandler(PayloadsByRangeProtocolID(rollupCfg.L2ChainID), payloadsByRange)
+				payloadByHash := MakeStreamHandler(resourcesCtx, log.New("serve", "payload_by_hash"), n.syncSrv.HandleHashSyncRequest)
+				n.host.SetStreamHandler(PayloadByHashProtocolID(rollupCfg.L2ChainID), payloadByHash)
 			}
 		}
 		n.scorer = NewScorer(rollupCfg, eps, metrics, n.appScorer, log)
@@ -176,6 +185,11 @@ func (n *NodeP2P) RequestL2Range(ctx context.Context, start, end eth.L2BlockRef)
 	return n.syncCl.RequestL2Range(ctx, start, end)
 }
 
+// SyncClient returns the req-resp sync client, or nil if req-resp sync is not enabled.
+func (n *NodeP2P) SyncClient() *SyncClient {
+	return n.syncCl
+}
+
 func (n *NodeP2P) Host() host.Host {
 	return n.host
 }
@@ -246,6 +260,82 @@ func (n *NodeP2P) BanIP(ip net.IP, expiration time.Time) error {
 	return nil
 }
 
+// BanSubnet bans the subnet until the given expiration time, and disconnects all peers within the subnet.
+// The subnet is blocked in the connection gater, and unblocked by ExpireSubnetBans after the expiration.
+func (n *NodeP2P) BanSubnet(subnet *net.IPNet, expiration time.Time) error {
+	if n.gater == nil {
+		return errors.New("cannot ban subnet without connection gater")
+	}
+	if err := n.store.SetSubnetBanExpiration(subnet, expiration); err != nil {
+		return fmt.Errorf("failed to set subnet ban expiry: %w", err)
+	}
+	if err := n.gater.BlockSubnet(subnet); err != nil {
+		return fmt.Errorf("failed to block subnet: %w", err)
+	}
+	for _, conn := range n.host.Network().Conns() {
+		remoteIP, err := manet.ToIP(conn.RemoteMultiaddr())
+		if err != nil {
+			continue
+		}
+		if subnet.Contains(remoteIP) {
+			if err := conn.Close(); err != nil {
+				n.log.Error("failed to close connection to peer in banned subnet", "peer", conn.RemotePeer(), "subnet", subnet)
+			}
+		}
+	}
+	return nil
+}
+
+// ExpireSubnetBans unblocks the blocked subnets of which the ban has expired.
+// Subnets that were blocked without expiry, e.g. manually through the RPC, stay blocked.
+func (n *NodeP2P) ExpireSubnetBans() error {
+	if n.gater == nil {
+		return nil
+	}
+	now := time.Now()
+	var result *multierror.Error
+	for _, subnet := range n.gater.ListBlockedSubnets() {
+		expiry, err := n.store.GetSubnetBanExpiration(subnet)
+		if errors.Is(err, store.UnknownBanErr) {
+			continue
+		} else if err != nil {
+			result = multierror.Append(result, fmt.Errorf("failed to get ban expiry of subnet %s: %w", subnet, err))
+			continue
+		}
+		if expiry.After(now) {
+			continue
+		}
+		if err := n.gater.UnblockSubnet(subnet); err != nil {
+			result = multierror.Append(result, fmt.Errorf("failed to unblock subnet %s: %w", subnet, err))
+			continue
+		}
+		if err := n.store.SetSubnetBanExpiration(subnet, time.Time{}); err != nil {
+			result = multierror.Append(result, fmt.Errorf("failed to clear ban expiry of subnet %s: %w", subnet, err))
+			continue
+		}
+		n.log.Info("Subnet ban expired", "subnet", subnet)
+	}
+	return result.ErrorOrNil()
+}
+
+// PeerIPs returns the remote IPs of the current connections to the peer.
+func (n *NodeP2P) PeerIPs(id peer.ID) []net.IP {
+	var out []net.IP
+	for _, conn := range n.host.Network().ConnsToPeer(id) {
+		remoteIP, err := manet.ToIP(conn.RemoteMultiaddr())
+		if err != nil {
+			continue
+		}
+		out = append(out, remoteIP)
+	}
+	return out
+}
+
+// BanPolicy returns the misbehaviour-based ban policy, or nil if the ban policy is disabled.
+func (n *NodeP2P) BanPolicy() *BanPolicy {
+	return n.banPolicy
+}
+
 func (n *NodeP2P) Close() error {
 	var result *multierror.Error
 	if n.peerMonitor != nil {
@@ -43,27 +43,33 @@ func LightPeerScoreParams(cfg *rollup.Config) pubsub.PeerScoreParams {
 	tenEpochs := 10 * epoch
 	oneHundredEpochs := 100 * epoch
 	invalidDecayPeriod := 50 * epoch
+	// the v1 and v2 blocks topics carry the same blocks, and are scored the same,
+	// but each topic has its own params, so they can be tuned independently.
+	blocksTopicParams := func() *pubsub.TopicScoreParams {
+		return &pubsub.TopicScoreParams{
+			TopicWeight:                     0.8,
+			TimeInMeshWeight:                MaxInMeshScore / inMeshCap(slot),
+			TimeInMeshQuantum:               slot,
+			TimeInMeshCap:                   inMeshCap(slot),
+			FirstMessageDeliveriesWeight:    1,
+			FirstMessageDeliveriesDecay:     ScoreDecay(20*epoch, slot),
+			FirstMessageDeliveriesCap:       23,
+			MeshMessageDeliveriesWeight:     MeshWeight,
+			MeshMessageDeliveriesDecay:      ScoreDecay(DecayEpoch*epoch, slot),
+			MeshMessageDeliveriesCap:        float64(uint64(epoch/slot) * uint64(DecayEpoch)),
+			MeshMessageDeliveriesThreshold:  float64(uint64(epoch/slot) * uint64(DecayEpoch) / 10),
+			MeshMessageDeliveriesWindow:     2 * time.Second,
+			MeshMessageDeliveriesActivation: 4 * epoch,
+			MeshFailurePenaltyWeight:        MeshWeight,
+			MeshFailurePenaltyDecay:         ScoreDecay(DecayEpoch*epoch, slot),
+			InvalidMessageDeliveriesWeight:  -140.4475,
+			InvalidMessageDeliveriesDecay:   ScoreDecay(invalidDecayPeriod, slot),
+		}
+	}
 	return pubsub.PeerScoreParams{
 		Topics: map[string]*pubsub.TopicScoreParams{
-			blocksTopicV1(cfg): {
-				TopicWeight:                     0.8,
-				TimeInMeshWeight:                MaxInMeshScore / inMeshCap(slot),
-				TimeInMeshQuantum:               slot,
-				TimeInMeshCap:                   inMeshCap(slot),
-				FirstMessageDeliveriesWeight:    1,
-				FirstMessageDeliveriesDecay:     ScoreDecay(20*epoch, slot),
-				FirstMessageDeliveriesCap:       23,
-				MeshMessageDeliveriesWeight:     MeshWeight,
-				MeshMessageDeliveriesDecay:      ScoreDecay(DecayEpoch*epoch, slot),
-				MeshMessageDeliveriesCap:        float64(uint64(epoch/slot) * uint64(DecayEpoch)),
-				MeshMessageDeliveriesThreshold:  float64(uint64(epoch/slot) * uint64(DecayEpoch) / 10),
-				MeshMessageDeliveriesWindow:     2 * time.Second,
-				MeshMessageDeliveriesActivation: 4 * epoch,
-				MeshFailurePenaltyWeight:        MeshWeight,
-				MeshFailurePenaltyDecay:         ScoreDecay(DecayEpoch*epoch, slot),
-				InvalidMessageDeliveriesWeight:  -140.4475,
-				InvalidMessageDeliveriesDecay:   ScoreDecay(invalidDecayPeriod, slot),
-			},
+			blocksTopicV1(cfg): blocksTopicParams(),
+			blocksTopicV2(cfg): blocksTopicParams(),
 		},
 		TopicScoreCap: 34,
 		AppSpecificScore: func(p peer.ID) float64 {
@@ -65,11 +65,15 @@ func (testSuite *PeerParamsTestSuite) TestGetPeerScoreParams_Light() {
 	scoringParams, err := GetScoringParams("light", cfg)
 	peerParams := scoringParams.PeerScoring
 	testSuite.NoError(err)
-	// Topics should contain options for block topic
-	testSuite.Len(peerParams.Topics, 1)
+	// Topics should contain options for the v1 and v2 block topics
+	testSuite.Len(peerParams.Topics, 2)
 	topicParams, ok := peerParams.Topics[blocksTopicV1(cfg)]
 	testSuite.True(ok, "should have block topic params")
 	testSuite.NotZero(topicParams.TimeInMeshQuantum)
+	topicParamsV2, ok := peerParams.Topics[blocksTopicV2(cfg)]
+	testSuite.True(ok, "should have block v2 topic params")
+	testSuite.Equal(topicParams, topicParamsV2)
+	testSuite.NotSame(topicParams, topicParamsV2, "each topic has its own params")
 	testSuite.Equal(peerParams.TopicScoreCap, float64(34))
 	testSuite.Equal(peerParams.AppSpecificWeight, float64(1))
 	testSuite.Equal(peerParams.IPColocationFactorWeight, float64(-35))
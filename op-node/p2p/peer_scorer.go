@@ -61,7 +61,7 @@ func NewScorer(cfg *rollup.Config, peerStore Peerstore, metricer ScoreMetrics, a
 // The returned [pubsub.ExtendedPeerScoreInspectFn] is called with a mapping of peer IDs to peer score snapshots.
 // The incoming peer score snapshots only contain gossip-score components.
 func (s *scorer) SnapshotHook() pubsub.ExtendedPeerScoreInspectFn {
-	blocksTopicName := blocksTopicV1(s.cfg)
+	blocksTopicNames := []string{blocksTopicV1(s.cfg), blocksTopicV2(s.cfg)}
 	return func(m map[peer.ID]*pubsub.PeerScoreSnapshot) {
 		allScores := make([]store.PeerScores, 0, len(m))
 		// Now set the new scores.
@@ -72,11 +72,17 @@ func (s *scorer) SnapshotHook() pubsub.ExtendedPeerScoreInspectFn {
 				IPColocationFactor: snap.IPColocationFactor,
 				BehavioralPenalty:  snap.BehaviourPenalty,
 			}
-			if topSnap, ok := snap.Topics[blocksTopicName]; ok {
-				diff.Blocks.TimeInMesh = float64(topSnap.TimeInMesh) / float64(time.Second)
-				diff.Blocks.MeshMessageDeliveries = topSnap.MeshMessageDeliveries
-				diff.Blocks.FirstMessageDeliveries = topSnap.FirstMessageDeliveries
-				diff.Blocks.InvalidMessageDeliveries = topSnap.InvalidMessageDeliveries
+			// The v1 and v2 blocks topics carry the same blocks: the deliveries are combined,
+			// and the time in mesh is the longest of the two.
+			for _, blocksTopicName := range blocksTopicNames {
+				if topSnap, ok := snap.Topics[blocksTopicName]; ok {
+					if timeInMesh := float64(topSnap.TimeInMesh) / float64(time.Second); timeInMesh > diff.Blocks.TimeInMesh {
+						diff.Blocks.TimeInMesh = timeInMesh
+					}
+					diff.Blocks.MeshMessageDeliveries += topSnap.MeshMessageDeliveries
+					diff.Blocks.FirstMessageDeliveries += topSnap.FirstMessageDeliveries
+					diff.Blocks.InvalidMessageDeliveries += topSnap.InvalidMessageDeliveries
+				}
 			}
 			if peerScores, err := s.peerStore.SetScore(id, &diff); err != nil {
 				s.log.Warn("Unable to update peer gossip score", "err", err)
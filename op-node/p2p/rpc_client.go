@@ -92,6 +92,12 @@ func (c *Client) ListBlockedSubnets(ctx context.Context) ([]*net.IPNet, error) {
 	return out, err
 }
 
+func (c *Client) ListBanPolicyDecisions(ctx context.Context) ([]BanDecision, error) {
+	var out []BanDecision
+	err := c.c.CallContext(ctx, &out, prefixRPC("listBanPolicyDecisions"))
+	return out, err
+}
+
 func (c *Client) ProtectPeer(ctx context.Context, p peer.ID) error {
 	return c.c.CallContext(ctx, nil, prefixRPC("protectPeer"), p)
 }
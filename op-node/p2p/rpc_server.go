@@ -53,6 +53,8 @@ type Node interface {
 	ConnectionGater() gating.BlockingConnectionGater
 	// ConnectionManager returns the connection manager, to protect peers with, may be nil
 	ConnectionManager() connmgr.ConnManager
+	// BanPolicy returns the misbehaviour-based ban policy, may be nil
+	BanPolicy() *BanPolicy
 }
 
 type APIBackend struct {
@@ -342,6 +344,16 @@ func (s *APIBackend) ListBlockedSubnets(_ context.Context) ([]*net.IPNet, error)
 	}
 }
 
+func (s *APIBackend) ListBanPolicyDecisions(_ context.Context) ([]BanDecision, error) {
+	recordDur := s.m.RecordRPCServerRequest("opp2p_listBanPolicyDecisions")
+	defer recordDur()
+	if policy := s.node.BanPolicy(); policy == nil {
+		return []BanDecision{}, nil
+	} else {
+		return policy.ActiveDecisions(), nil
+	}
+}
+
 func (s *APIBackend) ProtectPeer(_ context.Context, p peer.ID) error {
 	recordDur := s.m.RecordRPCServerRequest("opp2p_protectPeer")
 	defer recordDur()
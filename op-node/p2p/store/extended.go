@@ -18,6 +18,7 @@ type extendedStore struct {
 	*scoreBook
 	*peerBanBook
 	*ipBanBook
+	*subnetBanBook
 }
 
 func NewExtendedPeerstore(ctx context.Context, logger log.Logger, clock clock.Clock, ps peerstore.Peerstore, store ds.Batching, scoreRetention time.Duration) (ExtendedPeerstore, error) {
@@ -40,12 +41,18 @@ func NewExtendedPeerstore(ctx context.Context, logger log.Logger, clock clock.Cl
 		return nil, fmt.Errorf("create IP ban book: %w", err)
 	}
 	ib.startGC()
+	snb, err := newSubnetBanBook(ctx, logger, clock, store)
+	if err != nil {
+		return nil, fmt.Errorf("create subnet ban book: %w", err)
+	}
+	snb.startGC()
 	return &extendedStore{
 		Peerstore:         ps,
 		CertifiedAddrBook: cab,
 		scoreBook:         sb,
 		peerBanBook:       pb,
 		ipBanBook:         ib,
+		subnetBanBook:     snb,
 	}, nil
 }
 
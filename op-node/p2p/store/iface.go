@@ -120,6 +120,14 @@ type IPBanStore interface {
 	GetIPBanExpiration(ip net.IP) (time.Time, error)
 }
 
+type SubnetBanStore interface {
+	// SetSubnetBanExpiration create the subnet ban with expiration time.
+	// If expiry == time.Time{} then the ban is deleted.
+	SetSubnetBanExpiration(subnet *net.IPNet, expiry time.Time) error
+	// GetSubnetBanExpiration gets the subnet ban expiration time, or UnknownBanErr error if none exists.
+	GetSubnetBanExpiration(subnet *net.IPNet) (time.Time, error)
+}
+
 // ExtendedPeerstore defines a type-safe API to work with additional peer metadata based on a libp2p peerstore.Peerstore
 type ExtendedPeerstore interface {
 	peerstore.Peerstore
@@ -127,4 +135,5 @@ type ExtendedPeerstore interface {
 	peerstore.CertifiedAddrBook
 	PeerBanStore
 	IPBanStore
+	SubnetBanStore
 }
@@ -0,0 +1,96 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum/go-ethereum/log"
+	ds "github.com/ipfs/go-datastore"
+)
+
+const (
+	subnetBanCacheSize        = 100
+	subnetBanRecordExpiration = time.Hour * 24 * 7
+)
+
+var subnetBanExpirationsBase = ds.NewKey("/subnets/ban_expiration")
+
+type subnetBanRecord struct {
+	Expiry     int64 `json:"expiry"`     // unix timestamp in seconds
+	LastUpdate int64 `json:"lastUpdate"` // unix timestamp in seconds
+}
+
+func (s *subnetBanRecord) SetLastUpdated(t time.Time) {
+	s.LastUpdate = t.Unix()
+}
+
+func (s *subnetBanRecord) LastUpdated() time.Time {
+	return time.Unix(s.LastUpdate, 0)
+}
+
+func (s *subnetBanRecord) MarshalBinary() (data []byte, err error) {
+	return json.Marshal(s)
+}
+
+func (s *subnetBanRecord) UnmarshalBinary(data []byte) error {
+	return json.Unmarshal(data, s)
+}
+
+type subnetBanUpdate time.Time
+
+func (p subnetBanUpdate) Apply(rec *subnetBanRecord) {
+	rec.Expiry = time.Time(p).Unix()
+}
+
+type subnetBanBook struct {
+	book *recordsBook[string, *subnetBanRecord]
+}
+
+func newSubnetBanRecord() *subnetBanRecord {
+	return new(subnetBanRecord)
+}
+
+// subnetKey creates a datastore key for the CIDR notation of a subnet.
+// The "/" of the prefix length is replaced, since it separates namespaces in datastore keys.
+func subnetKey(subnet string) ds.Key {
+	return ds.NewKey(strings.ReplaceAll(subnet, "/", "_"))
+}
+
+func newSubnetBanBook(ctx context.Context, logger log.Logger, clock clock.Clock, store ds.Batching) (*subnetBanBook, error) {
+	book, err := newRecordsBook[string, *subnetBanRecord](ctx, logger, clock, store, subnetBanCacheSize, subnetBanRecordExpiration, subnetBanExpirationsBase, newSubnetBanRecord, subnetKey)
+	if err != nil {
+		return nil, err
+	}
+	return &subnetBanBook{book: book}, nil
+}
+
+func (d *subnetBanBook) startGC() {
+	d.book.startGC()
+}
+
+func (d *subnetBanBook) GetSubnetBanExpiration(subnet *net.IPNet) (time.Time, error) {
+	rec, err := d.book.getRecord(subnet.String())
+	if err == UnknownRecordErr {
+		return time.Time{}, UnknownBanErr
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(rec.Expiry, 0), nil
+}
+
+func (d *subnetBanBook) SetSubnetBanExpiration(subnet *net.IPNet, expirationTime time.Time) error {
+	if expirationTime == (time.Time{}) {
+		return d.book.deleteRecord(subnet.String())
+	}
+	_, err := d.book.SetRecord(subnet.String(), subnetBanUpdate(expirationTime))
+	return err
+}
+
+func (d *subnetBanBook) Close() {
+	d.book.Close()
+}
@@ -0,0 +1,51 @@
+package store
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/log"
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/sync"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetUnknownSubnetBan(t *testing.T) {
+	book := createMemorySubnetBanBook(t)
+	defer book.Close()
+	_, subnet, err := net.ParseCIDR("1.2.3.0/24")
+	require.NoError(t, err)
+	exp, err := book.GetSubnetBanExpiration(subnet)
+	require.Same(t, UnknownBanErr, err)
+	require.Equal(t, time.Time{}, exp)
+}
+
+func TestRoundTripSubnetBan(t *testing.T) {
+	book := createMemorySubnetBanBook(t)
+	defer book.Close()
+	expiry := time.Unix(2484924, 0)
+	_, subnet, err := net.ParseCIDR("2001:db8::/64")
+	require.NoError(t, err)
+	require.NoError(t, book.SetSubnetBanExpiration(subnet, expiry))
+	result, err := book.GetSubnetBanExpiration(subnet)
+	require.NoError(t, err)
+	require.Equal(t, result, expiry)
+
+	// deleting the ban
+	require.NoError(t, book.SetSubnetBanExpiration(subnet, time.Time{}))
+	_, err = book.GetSubnetBanExpiration(subnet)
+	require.Same(t, UnknownBanErr, err)
+}
+
+func createMemorySubnetBanBook(t *testing.T) *subnetBanBook {
+	store := sync.MutexWrap(ds.NewMapDatastore())
+	logger := testlog.Logger(t, log.LvlInfo)
+	c := clock.NewDeterministicClock(time.UnixMilli(100))
+	book, err := newSubnetBanBook(context.Background(), logger, c, store)
+	require.NoError(t, err)
+	return book
+}
@@ -59,12 +59,26 @@ const (
 	// and eventually kick the peer based on degraded scoring if it's really not serving us well.
 	// TODO(CLI-4009): Use a backoff rather than this mechanism.
 	clientErrRateCost = peerServerBlocksBurst
+	// Do not request or serve more than 10 payloads in a single payloads_by_range request.
+	// Each payload in the range costs a rate-limit token, so this must not exceed peerServerBlocksBurst.
+	maxPayloadsByRangeCount = 10
+	// Limit the total size of a payloads_by_range response. The server stops adding payloads to the response
+	// before hitting this limit, and the client does not read beyond it.
+	maxPayloadsByRangeResponseSize = 2 * maxGossipSize
 )
 
 func PayloadByNumberProtocolID(l2ChainID *big.Int) protocol.ID {
 	return protocol.ID(fmt.Sprintf("/opstack/req/payload_by_number/%d/0", l2ChainID))
 }
 
+func PayloadsByRangeProtocolID(l2ChainID *big.Int) protocol.ID {
+	return protocol.ID(fmt.Sprintf("/opstack/req/payloads_by_range/%d/0", l2ChainID))
+}
+
+func PayloadByHashProtocolID(l2ChainID *big.Int) protocol.ID {
+	return protocol.ID(fmt.Sprintf("/opstack/req/payload_by_hash/%d/0", l2ChainID))
+}
+
 type requestHandlerFn func(ctx context.Context, log log.Logger, stream network.Stream)
 
 func MakeStreamHandler(resourcesCtx context.Context, log log.Logger, fn requestHandlerFn) network.StreamHandler {
@@ -94,12 +108,19 @@ type syncResult struct {
 	peer    peer.ID
 }
 
+// peerRequest is a request for a range of consecutive blocks, served by a single peer.
 type peerRequest struct {
-	num uint64
+	start uint64
+	count uint64
 
 	complete *atomic.Bool
 }
 
+// last returns the highest block number of the request range.
+func (pr peerRequest) last() uint64 {
+	return pr.start + pr.count - 1
+}
+
 type inFlightCheck struct {
 	num uint64
 
@@ -108,6 +129,8 @@ type inFlightCheck struct {
 
 type SyncClientMetrics interface {
 	ClientPayloadByNumberEvent(num uint64, resultCode byte, duration time.Duration)
+	ClientPayloadsByRangeEvent(start uint64, count uint64, resultCode byte, duration time.Duration)
+	ClientPayloadByHashEvent(resultCode byte, duration time.Duration)
 	PayloadsQuarantineSize(n int)
 }
 
@@ -117,6 +140,11 @@ type SyncPeerScorer interface {
 	onRejectedPayload(id peer.ID)
 }
 
+type SyncServerPeerScorer interface {
+	onRateLimited(id peer.ID)
+	onInvalidRequest(id peer.ID)
+}
+
 // SyncClient implements a reverse chain sync with a minimal interface:
 // signal the desired range, and receive blocks within this range back.
 // Through parent-hash verification, received blocks are all ensured to be part of the canonical chain at one point,
@@ -131,6 +159,7 @@ type SyncPeerScorer interface {
 // - User sends range request: blocks on sync main loop (with ctx timeout)
 // - Main loop processes range request (from high to low), dividing block requests by number between parallel peers.
 //   - The high part of the range has a known block-hash, and is marked as trusted.
+//   - Consecutive block numbers are batched into peer requests of up to maxPayloadsByRangeCount blocks.
 //   - Once there are no more peers available for buffering requests, we stop the range request processing.
 //   - Every request buffered for a peer is tracked as in-flight, by block number.
 //   - In-flight requests are not repeated
@@ -138,7 +167,9 @@ type SyncPeerScorer interface {
 //   - Data already in the quarantine that is trusted is attempted to be promoted.
 //
 // - Peers each have their own routine for processing requests.
-//   - They fetch the requested block by number, parse and validate it, and then send it back to the main loop
+//   - They fetch the requested blocks with a single payloads_by_range request,
+//     or one by one with payload_by_number requests if the peer does not support the range protocol.
+//   - They parse and validate the blocks, and then send them back to the main loop
 //   - If peers fail to fetch or process it, or fail to send it back to the main loop within timeout,
 //     then the doRequest returns an error. It then marks the in-flight request as completed.
 //
@@ -191,10 +222,14 @@ type SyncClient struct {
 
 	newStreamFn     newStreamFn
 	payloadByNumber protocol.ID
+	payloadsByRange protocol.ID
+	payloadByHash   protocol.ID
 
 	peersLock sync.Mutex
 	// syncing worker per peer
 	peers map[peer.ID]context.CancelFunc
+	// rate limiter per peer, shared by the syncing worker and the synchronous requests to the peer
+	peerRLs map[peer.ID]*rate.Limiter
 
 	// trusted blocks are, or have been, canonical at one point.
 	// Everything that's trusted is acceptable to pass to the sync receiver,
@@ -244,7 +279,10 @@ func NewSyncClient(log log.Logger, cfg *rollup.Config, newStream newStreamFn, rc
 		appScorer:       appScorer,
 		newStreamFn:     newStream,
 		payloadByNumber: PayloadByNumberProtocolID(cfg.L2ChainID),
+		payloadsByRange: PayloadsByRangeProtocolID(cfg.L2ChainID),
+		payloadByHash:   PayloadByHashProtocolID(cfg.L2ChainID),
 		peers:           make(map[peer.ID]context.CancelFunc),
+		peerRLs:         make(map[peer.ID]*rate.Limiter),
 		quarantineByNum: make(map[uint64]common.Hash),
 		inFlight:        make(map[uint64]*atomic.Bool),
 		requests:        make(chan rangeRequest), // blocking
@@ -287,7 +325,11 @@ func (s *SyncClient) AddPeer(id peer.ID) {
 	// add new peer routine
 	ctx, cancel := context.WithCancel(s.resCtx)
 	s.peers[id] = cancel
-	go s.peerLoop(ctx, id)
+	// Implement the same rate limits as the server does per-peer,
+	// so we don't be too aggressive to the server.
+	rl := rate.NewLimiter(peerServerBlocksRateLimit, peerServerBlocksBurst)
+	s.peerRLs[id] = rl
+	go s.peerLoop(ctx, id, rl)
 }
 
 func (s *SyncClient) RemovePeer(id peer.ID) {
@@ -300,6 +342,7 @@ func (s *SyncClient) RemovePeer(id peer.ID) {
 	}
 	cancel() // once loop exits
 	delete(s.peers, id)
+	delete(s.peerRLs, id)
 }
 
 // Close will shut down the sync client and all attached work, and block until shutdown is complete.
@@ -327,6 +370,152 @@ func (s *SyncClient) RequestL2Range(ctx context.Context, start, end eth.L2BlockR
 	}
 }
 
+// Peers returns the peers that are currently registered for sync duties.
+func (s *SyncClient) Peers() []peer.ID {
+	s.peersLock.Lock()
+	defer s.peersLock.Unlock()
+	out := make([]peer.ID, 0, len(s.peers))
+	for id := range s.peers {
+		out = append(out, id)
+	}
+	return out
+}
+
+// PayloadByHash fetches the payload with the given block hash from the given peer, with a payload_by_hash request.
+// This is a synchronous alternative to RequestL2Range: the block hash is verified,
+// but the result is returned to the caller rather than passed to the sync receiver.
+// Returns ethereum.NotFound if the peer does not have the payload.
+func (s *SyncClient) PayloadByHash(ctx context.Context, id peer.ID, hash common.Hash) (*eth.ExecutionPayload, error) {
+	if err := s.waitRateLimit(ctx, id, 1); err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	payload, err := s.doHashRequest(ctx, id, hash)
+	s.metrics.ClientPayloadByHashEvent(requestResultCode(err), time.Since(start))
+	return payload, s.scoreRequest(id, err)
+}
+
+func (s *SyncClient) doHashRequest(ctx context.Context, id peer.ID, hash common.Hash) (*eth.ExecutionPayload, error) {
+	reqCtx, reqCancel := context.WithTimeout(ctx, streamTimeout)
+	str, err := s.newStreamFn(reqCtx, id, s.payloadByHash)
+	reqCancel()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open stream: %w", err)
+	}
+	defer str.Close()
+	_ = str.SetWriteDeadline(time.Now().Add(clientWriteRequestTimeout))
+	if _, err := str.Write(hash[:]); err != nil {
+		return nil, fmt.Errorf("failed to write request (%s): %w", hash, err)
+	}
+	if err := str.CloseWrite(); err != nil {
+		return nil, fmt.Errorf("failed to close writer side while making request: %w", err)
+	}
+	payload, err := readPayloadResponse(str)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifyBlock(payload, uint64(payload.BlockNumber)); err != nil {
+		return nil, fmt.Errorf("%w: %w", errInvalidPayload, err)
+	}
+	if payload.BlockHash != hash {
+		return nil, fmt.Errorf("%w: received execution payload %s, but requested block %s", errInvalidPayload, payload.ID(), hash)
+	}
+	return payload, nil
+}
+
+// PayloadsByRange fetches up to count consecutive payloads, starting at block number start, from the given peer.
+// The payloads_by_range protocol is preferred, with a fallback to payload_by_number requests.
+// The count is capped to the maximum range of a single request, and the result may be shorter
+// if the peer does not have the full range. The returned payloads are ordered by number,
+// and verified to build on each other, but not verified against any known trusted block.
+func (s *SyncClient) PayloadsByRange(ctx context.Context, id peer.ID, start uint64, count uint64) ([]*eth.ExecutionPayload, error) {
+	if count == 0 {
+		return nil, nil
+	}
+	if count > maxPayloadsByRangeCount {
+		count = maxPayloadsByRangeCount
+	}
+	if err := s.waitRateLimit(ctx, id, int(count)); err != nil {
+		return nil, err
+	}
+	t := time.Now()
+	payloads, err := s.doPayloadsByRange(ctx, id, start, count)
+	s.metrics.ClientPayloadsByRangeEvent(start, count, requestResultCode(err), time.Since(t))
+	return payloads, s.scoreRequest(id, err)
+}
+
+func (s *SyncClient) doPayloadsByRange(ctx context.Context, id peer.ID, start uint64, count uint64) ([]*eth.ExecutionPayload, error) {
+	reqCtx, reqCancel := context.WithTimeout(ctx, streamTimeout)
+	str, err := s.newStreamFn(reqCtx, id, s.payloadsByRange, s.payloadByNumber)
+	reqCancel()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open stream: %w", err)
+	}
+	if str.Protocol() != s.payloadByNumber {
+		defer str.Close()
+		return fetchPayloadsByRange(str, start, count)
+	}
+	// The peer only supports payload_by_number: request the payloads one by one, in ascending order.
+	payloads := make([]*eth.ExecutionPayload, 0, count)
+	for i := uint64(0); i < count; i++ {
+		if i > 0 {
+			reqCtx, reqCancel := context.WithTimeout(ctx, streamTimeout)
+			str, err = s.newStreamFn(reqCtx, id, s.payloadByNumber)
+			reqCancel()
+			if err != nil {
+				return payloads, fmt.Errorf("failed to open stream: %w", err)
+			}
+		}
+		payload, err := fetchPayloadByNumber(str, start+i)
+		_ = str.Close()
+		if err != nil {
+			// Like with the range protocol, return what we have if the peer cannot serve the full range.
+			// An invalid payload is passed through, for the peer to be penalized.
+			if i > 0 && !errors.Is(err, errInvalidPayload) {
+				return payloads, nil
+			}
+			return nil, err
+		}
+		if i > 0 && payloads[i-1].BlockHash != payload.ParentHash {
+			return nil, fmt.Errorf("%w: payload %s does not build on previous payload %s of the range", errInvalidPayload, payload.ID(), payloads[i-1].ID())
+		}
+		payloads = append(payloads, payload)
+	}
+	return payloads, nil
+}
+
+// waitRateLimit waits for n blocks worth of tokens from both the global and the per-peer rate limiter,
+// like the peer sync loop does. Only peers registered for sync duties can be requested from.
+func (s *SyncClient) waitRateLimit(ctx context.Context, id peer.ID, n int) error {
+	s.peersLock.Lock()
+	rl, ok := s.peerRLs[id]
+	s.peersLock.Unlock()
+	if !ok {
+		return fmt.Errorf("peer %s is not registered for sync duties", id)
+	}
+	if err := s.globalRL.WaitN(ctx, n); err != nil {
+		return err
+	}
+	return rl.WaitN(ctx, n)
+}
+
+// scoreRequest updates the application score of the peer with the result of a request.
+// A peer that does not have the requested data is not penalized: it is not expected to have everything.
+// An invalid payload is scored as a rejected payload, any other error as an error response.
+func (s *SyncClient) scoreRequest(id peer.ID, err error) error {
+	switch {
+	case err == nil:
+		s.appScorer.onValidResponse(id)
+	case errors.Is(err, errInvalidPayload):
+		s.appScorer.onRejectedPayload(id)
+	case errors.Is(err, requestResultErr(1)):
+		return fmt.Errorf("peer %s: %w", id, ethereum.NotFound)
+	default:
+		s.appScorer.onResponseError(id)
+	}
+	return err
+}
+
 const (
 	maxRequestScheduling = time.Second * 3
 	maxResultProcessing  = time.Second * 3
@@ -391,10 +580,12 @@ func (s *SyncClient) onRangeRequest(ctx context.Context, req rangeRequest) {
 	}
 
 	// Now try to fetch lower numbers than current end, to traverse back towards the updated start.
+	// Consecutive numbers are batched together, so a peer can serve them with a single request.
+	var batch *peerRequest
 	for i := uint64(0); ; i++ {
 		num := req.end.Number - 1 - i
 		if num <= req.start {
-			return
+			break
 		}
 		// check if we have something in quarantine already
 		if h, ok := s.quarantineByNum[num]; ok {
@@ -402,28 +593,56 @@ func (s *SyncClient) onRangeRequest(ctx context.Context, req rangeRequest) {
 				s.tryPromote(h)
 			}
 			// Don't fetch things that we have a candidate for already.
-			// We'll evict it from quarantine by finding a conflict, or if we sync enough other blocks
+			// We'll evict it from quarantine by finding a conflict, or if we sync enough other blocks.
+			// The batch cannot extend past this number, so schedule what we have.
+			if batch != nil && !s.schedulePeerRequest(ctx, log, *batch) {
+				return
+			}
+			batch = nil
 			continue
 		}
 
 		if _, ok := s.inFlight[num]; ok {
 			log.Debug("request still in-flight, not rescheduling sync request", "num", num)
+			if batch != nil && !s.schedulePeerRequest(ctx, log, *batch) {
+				return
+			}
+			batch = nil
 			continue // request still in flight
 		}
-		pr := peerRequest{num: num, complete: new(atomic.Bool)}
 
-		log.Debug("Scheduling P2P block request", "num", num)
-		// schedule number
-		select {
-		case s.peerRequests <- pr:
-			s.inFlight[num] = pr.complete
-		case <-ctx.Done():
-			log.Info("did not schedule full P2P sync range", "current", num, "err", ctx.Err())
-			return
-		default: // peers may all be busy processing requests already
-			log.Info("no peers ready to handle block requests for more P2P requests for L2 block history", "current", num)
+		// extend the batch downwards if there is room
+		if batch != nil && batch.count < maxPayloadsByRangeCount {
+			batch.start = num
+			batch.count += 1
+			continue
+		}
+		if batch != nil && !s.schedulePeerRequest(ctx, log, *batch) {
 			return
 		}
+		batch = &peerRequest{start: num, count: 1, complete: new(atomic.Bool)}
+	}
+	if batch != nil {
+		s.schedulePeerRequest(ctx, log, *batch)
+	}
+}
+
+// schedulePeerRequest is exclusively called by the main loop, and has thus direct access to the request bookkeeping state.
+// This function hands the request to the first available peer, and returns false if no peer is available.
+func (s *SyncClient) schedulePeerRequest(ctx context.Context, log log.Logger, pr peerRequest) bool {
+	log.Debug("Scheduling P2P block request", "start", pr.start, "count", pr.count)
+	select {
+	case s.peerRequests <- pr:
+		for i := uint64(0); i < pr.count; i++ {
+			s.inFlight[pr.start+i] = pr.complete
+		}
+		return true
+	case <-ctx.Done():
+		log.Info("did not schedule full P2P sync range", "current", pr.last(), "err", ctx.Err())
+		return false
+	default: // peers may all be busy processing requests already
+		log.Info("no peers ready to handle block requests for more P2P requests for L2 block history", "current", pr.last())
+		return false
 	}
 }
 
@@ -496,11 +715,12 @@ func (s *SyncClient) onResult(ctx context.Context, res syncResult) {
 	}
 }
 
-// peerLoop for syncing from a single peer
-func (s *SyncClient) peerLoop(ctx context.Context, id peer.ID) {
+// peerLoop for syncing from a single peer, rate-limited by the given per-peer limiter
+func (s *SyncClient) peerLoop(ctx context.Context, id peer.ID, rl *rate.Limiter) {
 	defer func() {
 		s.peersLock.Lock()
 		delete(s.peers, id) // clean up
+		delete(s.peerRLs, id)
 		s.log.Debug("stopped syncing loop of peer", "id", id)
 		s.wg.Done()
 		s.peersLock.Unlock()
@@ -509,9 +729,9 @@ func (s *SyncClient) peerLoop(ctx context.Context, id peer.ID) {
 	log := s.log.New("peer", id)
 	log.Info("Starting P2P sync client event loop")
 
-	// Implement the same rate limits as the server does per-peer,
-	// so we don't be too aggressive to the server.
-	rl := rate.NewLimiter(peerServerBlocksRateLimit, peerServerBlocksBurst)
+	// We assume the peer supports the payloads_by_range protocol,
+	// until the protocol negotiation shows it only supports payload_by_number.
+	rangeSupported := true
 
 	for {
 		// wait for a global allocation to be available
@@ -526,35 +746,53 @@ func (s *SyncClient) peerLoop(ctx context.Context, id peer.ID) {
 		// once the peer is available, wait for a sync request.
 		select {
 		case pr := <-s.peerRequests:
-			// We already established the peer is available w.r.t. rate-limiting,
-			// and this is the only loop over this peer, so we can request now.
+			// We already established the peer is available w.r.t. rate-limiting for a single block.
+			// Every additional block in the range costs another token, like it does on the server side.
+			if pr.count > 1 {
+				if err := s.globalRL.WaitN(ctx, int(pr.count-1)); err != nil {
+					pr.complete.Store(true)
+					return
+				}
+				if err := rl.WaitN(ctx, int(pr.count-1)); err != nil {
+					pr.complete.Store(true)
+					return
+				}
+			}
+			// This is the only loop over this peer, so we can request now.
 			start := time.Now()
-			err := s.doRequest(ctx, id, pr.num)
+			var err error
+			if rangeSupported {
+				var legacy bool
+				legacy, err = s.doRangeRequest(ctx, id, pr)
+				if legacy {
+					log.Info("peer does not support payloads_by_range, falling back to payload_by_number requests")
+					rangeSupported = false
+				}
+			} else {
+				err = s.doRequests(ctx, id, pr)
+			}
+			// Results of a range request may not cover the full range:
+			// the in-flight tracking of any missing blocks is cleared, so they can be requested again.
+			pr.complete.Store(true)
+			_ = s.scoreRequest(id, err)
 			if err != nil {
-				// mark as complete if there's an error: we are not sending any result and can complete immediately.
-				pr.complete.Store(true)
-				log.Warn("failed p2p sync request", "num", pr.num, "err", err)
-				s.appScorer.onResponseError(id)
+				log.Warn("failed p2p sync request", "start", pr.start, "count", pr.count, "err", err)
 				// If we hit an error, then count it as many requests.
 				// We'd like to avoid making more requests for a while, to back off.
 				if err := rl.WaitN(ctx, clientErrRateCost); err != nil {
 					return
 				}
 			} else {
-				log.Debug("completed p2p sync request", "num", pr.num)
-				s.appScorer.onValidResponse(id)
+				log.Debug("completed p2p sync request", "start", pr.start, "count", pr.count)
 			}
 			took := time.Since(start)
 
-			resultCode := byte(0)
-			if err != nil {
-				if re, ok := err.(requestResultErr); ok {
-					resultCode = re.ResultCode()
-				} else {
-					resultCode = 1
-				}
+			resultCode := requestResultCode(err)
+			if rangeSupported {
+				s.metrics.ClientPayloadsByRangeEvent(pr.start, pr.count, resultCode, took)
+			} else {
+				s.metrics.ClientPayloadByNumberEvent(pr.last(), resultCode, took)
 			}
-			s.metrics.ClientPayloadByNumberEvent(pr.num, resultCode, took)
 		case <-ctx.Done():
 			return
 		}
@@ -571,6 +809,55 @@ func (r requestResultErr) ResultCode() byte {
 	return byte(r)
 }
 
+// requestResultCode summarizes the error of a request into a result code for metrics.
+func requestResultCode(err error) byte {
+	if err == nil {
+		return 0
+	}
+	var re requestResultErr
+	if errors.As(err, &re) {
+		return re.ResultCode()
+	}
+	return 1
+}
+
+// doRangeRequest requests the range of payloads from the peer with a single payloads_by_range request.
+// If the peer does not support the range protocol, the stream is negotiated down to the payload_by_number protocol,
+// the range is requested block by block instead, and legacy is returned as true.
+func (s *SyncClient) doRangeRequest(ctx context.Context, id peer.ID, pr peerRequest) (legacy bool, err error) {
+	// open stream to peer, preferring the range protocol
+	reqCtx, reqCancel := context.WithTimeout(ctx, streamTimeout)
+	str, err := s.newStreamFn(reqCtx, id, s.payloadsByRange, s.payloadByNumber)
+	reqCancel()
+	if err != nil {
+		return false, fmt.Errorf("failed to open stream: %w", err)
+	}
+	if str.Protocol() == s.payloadByNumber {
+		// Use the stream we already negotiated for the highest block, and request the rest one by one.
+		err := s.requestPayloadByNumber(ctx, str, id, pr.last())
+		_ = str.Close()
+		if err != nil {
+			return true, err
+		}
+		if pr.count > 1 {
+			return true, s.doRequests(ctx, id, peerRequest{start: pr.start, count: pr.count - 1})
+		}
+		return true, nil
+	}
+	defer str.Close()
+	return false, s.requestPayloadsByRange(ctx, str, id, pr)
+}
+
+// doRequests requests the range of payloads from the peer with payload_by_number requests, from high to low.
+func (s *SyncClient) doRequests(ctx context.Context, id peer.ID, pr peerRequest) error {
+	for i := uint64(0); i < pr.count; i++ {
+		if err := s.doRequest(ctx, id, pr.last()-i); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (s *SyncClient) doRequest(ctx context.Context, id peer.ID, n uint64) error {
 	// open stream to peer
 	reqCtx, reqCancel := context.WithTimeout(ctx, streamTimeout)
@@ -580,15 +867,45 @@ func (s *SyncClient) doRequest(ctx context.Context, id peer.ID, n uint64) error
 		return fmt.Errorf("failed to open stream: %w", err)
 	}
 	defer str.Close()
+	return s.requestPayloadByNumber(ctx, str, id, n)
+}
+
+func (s *SyncClient) requestPayloadByNumber(ctx context.Context, str network.Stream, id peer.ID, n uint64) error {
+	res, err := fetchPayloadByNumber(str, n)
+	if err != nil {
+		return err
+	}
+	select {
+	case s.results <- syncResult{payload: res, peer: id}:
+	case <-ctx.Done():
+		return fmt.Errorf("failed to process response, sync client is too busy: %w", ctx.Err())
+	}
+	return nil
+}
+
+// fetchPayloadByNumber makes a payload_by_number request on the stream, and returns the verified payload.
+func fetchPayloadByNumber(str network.Stream, n uint64) (*eth.ExecutionPayload, error) {
 	// set write timeout (if available)
 	_ = str.SetWriteDeadline(time.Now().Add(clientWriteRequestTimeout))
 	if err := binary.Write(str, binary.LittleEndian, n); err != nil {
-		return fmt.Errorf("failed to write request (%d): %w", n, err)
+		return nil, fmt.Errorf("failed to write request (%d): %w", n, err)
 	}
 	if err := str.CloseWrite(); err != nil {
-		return fmt.Errorf("failed to close writer side while making request: %w", err)
+		return nil, fmt.Errorf("failed to close writer side while making request: %w", err)
+	}
+	res, err := readPayloadResponse(str)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifyBlock(res, n); err != nil {
+		return nil, fmt.Errorf("%w: %w", errInvalidPayload, err)
 	}
+	return res, nil
+}
 
+// readPayloadResponse reads the <result><version><payload> response of a single-payload request,
+// as used by the payload_by_number and payload_by_hash protocols.
+func readPayloadResponse(str network.Stream) (*eth.ExecutionPayload, error) {
 	// set read timeout (if available)
 	_ = str.SetReadDeadline(time.Now().Add(clientReadResponsetimeout))
 
@@ -598,18 +915,18 @@ func (s *SyncClient) doRequest(ctx context.Context, id peer.ID, n uint64) error
 	r := io.LimitReader(str, maxGossipSize)
 	var result [1]byte
 	if _, err := io.ReadFull(r, result[:]); err != nil {
-		return fmt.Errorf("failed to read result part of response: %w", err)
+		return nil, fmt.Errorf("failed to read result part of response: %w", err)
 	}
 	if res := result[0]; res != 0 {
-		return requestResultErr(res)
+		return nil, requestResultErr(res)
 	}
 	var versionData [4]byte
 	if _, err := io.ReadFull(r, versionData[:]); err != nil {
-		return fmt.Errorf("failed to read version part of response: %w", err)
+		return nil, fmt.Errorf("failed to read version part of response: %w", err)
 	}
 	version := binary.LittleEndian.Uint32(versionData[:])
 	if version != 0 {
-		return fmt.Errorf("unrecognized ExecutionPayload version: %d", version)
+		return nil, fmt.Errorf("unrecognized ExecutionPayload version: %d", version)
 	}
 	// payload is SSZ encoded with Snappy framed compression
 	r = snappy.NewReader(r)
@@ -618,26 +935,121 @@ func (s *SyncClient) doRequest(ctx context.Context, id peer.ID, n uint64) error
 	// The server does not prepend it, nor would we trust a claimed length anyway, so we buffer the data we get.
 	data, err := io.ReadAll(r)
 	if err != nil {
-		return fmt.Errorf("failed to read response: %w", err)
+		return nil, fmt.Errorf("failed to read response: %w", err)
 	}
 	var res eth.ExecutionPayload
 	if err := res.UnmarshalSSZ(uint32(len(data)), bytes.NewReader(data)); err != nil {
-		return fmt.Errorf("failed to decode response: %w", err)
+		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
 	if err := str.CloseRead(); err != nil {
-		return fmt.Errorf("failed to close reading side")
+		return nil, fmt.Errorf("failed to close reading side")
 	}
-	if err := verifyBlock(&res, n); err != nil {
-		return fmt.Errorf("received execution payload is invalid: %w", err)
+	return &res, nil
+}
+
+func (s *SyncClient) requestPayloadsByRange(ctx context.Context, str network.Stream, id peer.ID, pr peerRequest) error {
+	payloads, err := fetchPayloadsByRange(str, pr.start, pr.count)
+	if err != nil {
+		return err
 	}
-	select {
-	case s.results <- syncResult{payload: &res, peer: id}:
-	case <-ctx.Done():
-		return fmt.Errorf("failed to process response, sync client is too busy: %w", err)
+	// Pass on the results from high to low: the highest block is the most likely to be trusted already,
+	// after which each parent can be promoted as soon as it arrives.
+	for i := len(payloads) - 1; i >= 0; i-- {
+		select {
+		case s.results <- syncResult{payload: payloads[i], peer: id}:
+		case <-ctx.Done():
+			return fmt.Errorf("failed to process response, sync client is too busy: %w", ctx.Err())
+		}
 	}
 	return nil
 }
 
+// fetchPayloadsByRange makes a payloads_by_range request on the stream, and returns the payloads of the response.
+// The payloads are verified to be consecutive, starting at the requested start block,
+// but the response may end early if the server does not have the full range.
+func fetchPayloadsByRange(str network.Stream, start uint64, count uint64) ([]*eth.ExecutionPayload, error) {
+	// set write timeout (if available)
+	_ = str.SetWriteDeadline(time.Now().Add(clientWriteRequestTimeout))
+	var req [16]byte
+	binary.LittleEndian.PutUint64(req[0:8], start)
+	binary.LittleEndian.PutUint64(req[8:16], count)
+	if _, err := str.Write(req[:]); err != nil {
+		return nil, fmt.Errorf("failed to write request (%d, %d): %w", start, count, err)
+	}
+	if err := str.CloseWrite(); err != nil {
+		return nil, fmt.Errorf("failed to close writer side while making request: %w", err)
+	}
+
+	// Limit the total input. Each chunk is limited individually as well.
+	r := io.LimitReader(str, maxPayloadsByRangeResponseSize)
+	payloads := make([]*eth.ExecutionPayload, 0, count)
+	for i := uint64(0); i < count; i++ {
+		// set read timeout (if available), per response chunk
+		_ = str.SetReadDeadline(time.Now().Add(clientReadResponsetimeout))
+
+		var result [1]byte
+		if _, err := io.ReadFull(r, result[:]); err != nil {
+			// The server may end the response early, e.g. when it hits the response size limit.
+			if errors.Is(err, io.EOF) && i > 0 {
+				break
+			}
+			return nil, fmt.Errorf("failed to read result part of response chunk %d: %w", i, err)
+		}
+		if res := result[0]; res != 0 {
+			// The server may not have all blocks of the range, but we can still use what it did send.
+			if i > 0 {
+				break
+			}
+			return nil, requestResultErr(res)
+		}
+		payload, err := readPayloadChunk(r)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response chunk %d: %w", i, err)
+		}
+		if err := verifyBlock(payload, start+i); err != nil {
+			return nil, fmt.Errorf("%w: %w", errInvalidPayload, err)
+		}
+		if i > 0 && payloads[i-1].BlockHash != payload.ParentHash {
+			return nil, fmt.Errorf("%w: payload %s does not build on previous payload %s of the range", errInvalidPayload, payload.ID(), payloads[i-1].ID())
+		}
+		payloads = append(payloads, payload)
+	}
+	if err := str.CloseRead(); err != nil {
+		return nil, fmt.Errorf("failed to close reading side")
+	}
+	return payloads, nil
+}
+
+// readPayloadChunk reads a single <version><length><payload> chunk of a payloads_by_range response.
+func readPayloadChunk(r io.Reader) (*eth.ExecutionPayload, error) {
+	var header [8]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, fmt.Errorf("failed to read chunk header: %w", err)
+	}
+	version := binary.LittleEndian.Uint32(header[0:4])
+	if version != 0 {
+		return nil, fmt.Errorf("unrecognized ExecutionPayload version: %d", version)
+	}
+	length := binary.LittleEndian.Uint32(header[4:8])
+	if length > maxGossipSize {
+		return nil, fmt.Errorf("chunk length %d exceeds max payload size", length)
+	}
+	data := make([]byte, length)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, fmt.Errorf("failed to read chunk data: %w", err)
+	}
+	// payload is SSZ encoded with Snappy framed compression, limit the decompressed output (zip-bomb)
+	data, err := io.ReadAll(io.LimitReader(snappy.NewReader(bytes.NewReader(data)), maxGossipSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress chunk data: %w", err)
+	}
+	var res eth.ExecutionPayload
+	if err := res.UnmarshalSSZ(uint32(len(data)), bytes.NewReader(data)); err != nil {
+		return nil, fmt.Errorf("failed to decode payload: %w", err)
+	}
+	return &res, nil
+}
+
 func verifyBlock(payload *eth.ExecutionPayload, expectedNum uint64) error {
 	// verify L2 block
 	if expectedNum != uint64(payload.BlockNumber) {
@@ -658,10 +1070,13 @@ type peerStat struct {
 
 type L2Chain interface {
 	PayloadByNumber(ctx context.Context, number uint64) (*eth.ExecutionPayload, error)
+	PayloadByHash(ctx context.Context, hash common.Hash) (*eth.ExecutionPayload, error)
 }
 
 type ReqRespServerMetrics interface {
 	ServerPayloadByNumberEvent(num uint64, resultCode byte, duration time.Duration)
+	ServerPayloadsByRangeEvent(start uint64, count uint64, resultCode byte, duration time.Duration)
+	ServerPayloadByHashEvent(resultCode byte, duration time.Duration)
 }
 
 type ReqRespServer struct {
@@ -671,13 +1086,15 @@ type ReqRespServer struct {
 
 	metrics ReqRespServerMetrics
 
+	peerScorer SyncServerPeerScorer
+
 	peerRateLimits *simplelru.LRU[peer.ID, *peerStat]
 	peerStatsLock  sync.Mutex
 
 	globalRequestsRL *rate.Limiter
 }
 
-func NewReqRespServer(cfg *rollup.Config, l2 L2Chain, metrics ReqRespServerMetrics) *ReqRespServer {
+func NewReqRespServer(cfg *rollup.Config, l2 L2Chain, metrics ReqRespServerMetrics, peerScorer SyncServerPeerScorer) *ReqRespServer {
 	// We should never allow over 1000 different peers to churn through quickly,
 	// so it's fine to prune rate-limit details past this.
 
@@ -688,6 +1105,7 @@ func NewReqRespServer(cfg *rollup.Config, l2 L2Chain, metrics ReqRespServerMetri
 		cfg:              cfg,
 		l2:               l2,
 		metrics:          metrics,
+		peerScorer:       peerScorer,
 		peerRateLimits:   peerRateLimits,
 		globalRequestsRL: globalRequestsRL,
 	}
@@ -719,6 +1137,7 @@ func (srv *ReqRespServer) HandleSyncRequest(ctx context.Context, log log.Logger,
 		} else {
 			resultCode = 3
 		}
+		srv.onRequestError(stream.Conn().RemotePeer(), err)
 		// try to write error code, so the other peer can understand the reason for failure.
 		_, _ = stream.Write([]byte{resultCode})
 	} else {
@@ -729,35 +1148,16 @@ func (srv *ReqRespServer) HandleSyncRequest(ctx context.Context, log log.Logger,
 
 var invalidRequestErr = errors.New("invalid request")
 
-func (srv *ReqRespServer) handleSyncRequest(ctx context.Context, stream network.Stream) (uint64, error) {
-	peerId := stream.Conn().RemotePeer()
+// peerRateLimitedErr is returned when the peer exceeds its request rate-limit by too much to wait for.
+var peerRateLimitedErr = errors.New("peer rate-limited")
 
-	// take a token from the global rate-limiter,
-	// to make sure there's not too much concurrent server work between different peers.
-	if err := srv.globalRequestsRL.Wait(ctx); err != nil {
-		return 0, fmt.Errorf("timed out waiting for global sync rate limit: %w", err)
-	}
+// errInvalidPayload is returned when the peer served a payload that fails verification.
+var errInvalidPayload = errors.New("received execution payload is invalid")
 
-	// find rate limiting data of peer, or add otherwise
-	srv.peerStatsLock.Lock()
-	ps, _ := srv.peerRateLimits.Get(peerId)
-	if ps == nil {
-		ps = &peerStat{
-			Requests: rate.NewLimiter(peerServerBlocksRateLimit, peerServerBlocksBurst),
-		}
-		srv.peerRateLimits.Add(peerId, ps)
-		ps.Requests.Reserve() // count the hit, but make it delay the next request rather than immediately waiting
-	} else {
-		// Only wait if it's an existing peer, otherwise the instant rate-limit Wait call always errors.
-
-		// If the requester thinks we're taking too long, then it's their problem and they can disconnect.
-		// We'll disconnect ourselves only when failing to read/write,
-		// if the work is invalid (range validation), or when individual sub tasks timeout.
-		if err := ps.Requests.Wait(ctx); err != nil {
-			return 0, fmt.Errorf("timed out waiting for global sync rate limit: %w", err)
-		}
+func (srv *ReqRespServer) handleSyncRequest(ctx context.Context, stream network.Stream) (uint64, error) {
+	if err := srv.rateLimit(ctx, stream.Conn().RemotePeer(), 1); err != nil {
+		return 0, err
 	}
-	srv.peerStatsLock.Unlock()
 
 	// Set read deadline, if available
 	_ = stream.SetReadDeadline(time.Now().Add(serverReadRequestTimeout))
@@ -772,25 +1172,101 @@ func (srv *ReqRespServer) handleSyncRequest(ctx context.Context, stream network.
 	}
 
 	// Check the request is within the expected range of blocks
-	if req < srv.cfg.Genesis.L2.Number {
-		return req, fmt.Errorf("cannot serve request for L2 block %d before genesis %d: %w", req, srv.cfg.Genesis.L2.Number, invalidRequestErr)
+	if err := srv.checkRequestRange(req, req); err != nil {
+		return req, err
 	}
-	max, err := srv.cfg.TargetBlockNumber(uint64(time.Now().Unix()))
+
+	payload, err := srv.l2.PayloadByNumber(ctx, req)
 	if err != nil {
-		return req, fmt.Errorf("cannot determine max target block number to verify request: %w", invalidRequestErr)
+		if errors.Is(err, ethereum.NotFound) {
+			return req, fmt.Errorf("peer requested unknown block by number: %w", err)
+		} else {
+			return req, fmt.Errorf("failed to retrieve payload to serve to peer: %w", err)
+		}
 	}
-	if req > max {
-		return req, fmt.Errorf("cannot serve request for L2 block %d after max expected block (%v): %w", req, max, invalidRequestErr)
+
+	// We set write deadline, if available, to safely write without blocking on a throttling peer connection
+	_ = stream.SetWriteDeadline(time.Now().Add(serverWriteChunkTimeout))
+
+	// 0 - resultCode: success = 0
+	// 1:5 - version: 0
+	var tmp [5]byte
+	if _, err := stream.Write(tmp[:]); err != nil {
+		return req, fmt.Errorf("failed to write response header data: %w", err)
 	}
+	w := snappy.NewBufferedWriter(stream)
+	if _, err := payload.MarshalSSZ(w); err != nil {
+		return req, fmt.Errorf("failed to write payload to sync response: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return req, fmt.Errorf("failed to finishing writing payload to sync response: %w", err)
+	}
+	return req, nil
+}
 
-	payload, err := srv.l2.PayloadByNumber(ctx, req)
+// HandleHashSyncRequest is a stream handler function to register the L2 unsafe payload_by_hash alt-sync protocol.
+// See MakeStreamHandler to transform this into a LibP2P handler function.
+//
+// Note that the same peer may open parallel streams.
+//
+// The caller must Close the stream.
+func (srv *ReqRespServer) HandleHashSyncRequest(ctx context.Context, log log.Logger, stream network.Stream) {
+	start := time.Now()
+
+	// We wait as long as necessary; we throttle the peer instead of disconnecting,
+	// unless the delay reaches a threshold that is unreasonable to wait for.
+	ctx, cancel := context.WithTimeout(ctx, maxThrottleDelay)
+	req, err := srv.handleHashSyncRequest(ctx, stream)
+	cancel()
+
+	resultCode := byte(0)
 	if err != nil {
+		log.Warn("failed to serve p2p hash sync request", "req", req, "err", err)
 		if errors.Is(err, ethereum.NotFound) {
-			return req, fmt.Errorf("peer requested unknown block by number: %w", err)
+			resultCode = 1
+		} else if errors.Is(err, invalidRequestErr) {
+			resultCode = 2
+		} else {
+			resultCode = 3
+		}
+		srv.onRequestError(stream.Conn().RemotePeer(), err)
+		// try to write error code, so the other peer can understand the reason for failure.
+		_, _ = stream.Write([]byte{resultCode})
+	} else {
+		log.Debug("successfully served hash sync response", "req", req)
+	}
+	srv.metrics.ServerPayloadByHashEvent(resultCode, time.Since(start))
+}
+
+func (srv *ReqRespServer) handleHashSyncRequest(ctx context.Context, stream network.Stream) (common.Hash, error) {
+	if err := srv.rateLimit(ctx, stream.Conn().RemotePeer(), 1); err != nil {
+		return common.Hash{}, err
+	}
+
+	// Set read deadline, if available
+	_ = stream.SetReadDeadline(time.Now().Add(serverReadRequestTimeout))
+
+	// Read the request
+	var req common.Hash
+	if _, err := io.ReadFull(stream, req[:]); err != nil {
+		return req, fmt.Errorf("failed to read requested block hash: %w", err)
+	}
+	if err := stream.CloseRead(); err != nil {
+		return req, fmt.Errorf("failed to close reading-side of a P2P hash sync request call: %w", err)
+	}
+
+	payload, err := srv.l2.PayloadByHash(ctx, req)
+	if err != nil {
+		if errors.Is(err, ethereum.NotFound) {
+			return req, fmt.Errorf("peer requested unknown block by hash: %w", err)
 		} else {
 			return req, fmt.Errorf("failed to retrieve payload to serve to peer: %w", err)
 		}
 	}
+	// Only serve blocks the other peer could also request by number.
+	if err := srv.checkRequestRange(uint64(payload.BlockNumber), uint64(payload.BlockNumber)); err != nil {
+		return req, err
+	}
 
 	// We set write deadline, if available, to safely write without blocking on a throttling peer connection
 	_ = stream.SetWriteDeadline(time.Now().Add(serverWriteChunkTimeout))
@@ -810,3 +1286,176 @@ func (srv *ReqRespServer) handleSyncRequest(ctx context.Context, stream network.
 	}
 	return req, nil
 }
+
+type rangeSyncRequest struct {
+	start uint64
+	count uint64
+}
+
+// HandleRangeSyncRequest is a stream handler function to register the L2 unsafe payloads_by_range alt-sync protocol.
+// See MakeStreamHandler to transform this into a LibP2P handler function.
+//
+// Note that the same peer may open parallel streams.
+//
+// The caller must Close the stream.
+func (srv *ReqRespServer) HandleRangeSyncRequest(ctx context.Context, log log.Logger, stream network.Stream) {
+	// may stay 0 if we fail to decode the request
+	start := time.Now()
+
+	// We wait as long as necessary; we throttle the peer instead of disconnecting,
+	// unless the delay reaches a threshold that is unreasonable to wait for.
+	ctx, cancel := context.WithTimeout(ctx, maxThrottleDelay)
+	req, err := srv.handleRangeSyncRequest(ctx, stream)
+	cancel()
+
+	resultCode := byte(0)
+	if err != nil {
+		log.Warn("failed to serve p2p range sync request", "start", req.start, "count", req.count, "err", err)
+		if errors.Is(err, ethereum.NotFound) {
+			resultCode = 1
+		} else if errors.Is(err, invalidRequestErr) {
+			resultCode = 2
+		} else {
+			resultCode = 3
+		}
+		srv.onRequestError(stream.Conn().RemotePeer(), err)
+		// try to write error code, so the other peer can understand the reason for failure,
+		// or why the response ended before the end of the requested range.
+		_, _ = stream.Write([]byte{resultCode})
+	} else {
+		log.Debug("successfully served range sync response", "start", req.start, "count", req.count)
+	}
+	srv.metrics.ServerPayloadsByRangeEvent(req.start, req.count, resultCode, time.Since(start))
+}
+
+func (srv *ReqRespServer) handleRangeSyncRequest(ctx context.Context, stream network.Stream) (rangeSyncRequest, error) {
+	// Set read deadline, if available
+	_ = stream.SetReadDeadline(time.Now().Add(serverReadRequestTimeout))
+
+	// Read the request. We need to know the size of the range before we can apply rate-limits.
+	var data [16]byte
+	if _, err := io.ReadFull(stream, data[:]); err != nil {
+		return rangeSyncRequest{}, fmt.Errorf("failed to read requested block range: %w", err)
+	}
+	req := rangeSyncRequest{
+		start: binary.LittleEndian.Uint64(data[0:8]),
+		count: binary.LittleEndian.Uint64(data[8:16]),
+	}
+	if err := stream.CloseRead(); err != nil {
+		return req, fmt.Errorf("failed to close reading-side of a P2P range sync request call: %w", err)
+	}
+
+	if req.count == 0 || req.count > maxPayloadsByRangeCount {
+		return req, fmt.Errorf("cannot serve request for %d blocks, expected 1 to %d: %w", req.count, maxPayloadsByRangeCount, invalidRequestErr)
+	}
+	end := req.start + req.count - 1
+	if end < req.start {
+		return req, fmt.Errorf("requested block range overflows: %w", invalidRequestErr)
+	}
+	// Check the request is within the expected range of blocks
+	if err := srv.checkRequestRange(req.start, end); err != nil {
+		return req, err
+	}
+
+	// Every block in the range costs a rate-limit token
+	if err := srv.rateLimit(ctx, stream.Conn().RemotePeer(), int(req.count)); err != nil {
+		return req, err
+	}
+
+	written := 0
+	for num := req.start; num <= end; num++ {
+		payload, err := srv.l2.PayloadByNumber(ctx, num)
+		if err != nil {
+			if errors.Is(err, ethereum.NotFound) {
+				return req, fmt.Errorf("peer requested unknown block %d by range: %w", num, err)
+			} else {
+				return req, fmt.Errorf("failed to retrieve payload %d to serve to peer: %w", num, err)
+			}
+		}
+		var buf bytes.Buffer
+		w := snappy.NewBufferedWriter(&buf)
+		if _, err := payload.MarshalSSZ(w); err != nil {
+			return req, fmt.Errorf("failed to encode payload %d for range sync response: %w", num, err)
+		}
+		if err := w.Close(); err != nil {
+			return req, fmt.Errorf("failed to finish encoding payload %d for range sync response: %w", num, err)
+		}
+		// 0 - resultCode: success = 0
+		// 1:5 - version: 0
+		// 5:9 - length of the compressed payload
+		var header [9]byte
+		binary.LittleEndian.PutUint32(header[5:9], uint32(buf.Len()))
+		// End the response early if the client would not read the full chunk: it can request the remainder later.
+		written += len(header) + buf.Len()
+		if written > maxPayloadsByRangeResponseSize || buf.Len() > maxGossipSize {
+			return req, nil
+		}
+
+		// We set write deadline, if available, to safely write without blocking on a throttling peer connection.
+		// The deadline is reset for every chunk.
+		_ = stream.SetWriteDeadline(time.Now().Add(serverWriteChunkTimeout))
+		if _, err := stream.Write(header[:]); err != nil {
+			return req, fmt.Errorf("failed to write response chunk header data: %w", err)
+		}
+		if _, err := stream.Write(buf.Bytes()); err != nil {
+			return req, fmt.Errorf("failed to write payload %d to range sync response: %w", num, err)
+		}
+	}
+	return req, nil
+}
+
+// checkRequestRange checks if the requested blocks may exist, between genesis and the current target block.
+func (srv *ReqRespServer) checkRequestRange(start, end uint64) error {
+	if start < srv.cfg.Genesis.L2.Number {
+		return fmt.Errorf("cannot serve request for L2 block %d before genesis %d: %w", start, srv.cfg.Genesis.L2.Number, invalidRequestErr)
+	}
+	max, err := srv.cfg.TargetBlockNumber(uint64(time.Now().Unix()))
+	if err != nil {
+		return fmt.Errorf("cannot determine max target block number to verify request: %w", invalidRequestErr)
+	}
+	if end > max {
+		return fmt.Errorf("cannot serve request for L2 block %d after max expected block (%v): %w", end, max, invalidRequestErr)
+	}
+	return nil
+}
+
+// onRequestError reports request errors that are the fault of the requesting peer to the peer scorer.
+func (srv *ReqRespServer) onRequestError(id peer.ID, err error) {
+	if errors.Is(err, invalidRequestErr) {
+		srv.peerScorer.onInvalidRequest(id)
+	} else if errors.Is(err, peerRateLimitedErr) {
+		srv.peerScorer.onRateLimited(id)
+	}
+}
+
+// rateLimit takes n tokens from the global and the per-peer rate-limiters, and waits if necessary.
+func (srv *ReqRespServer) rateLimit(ctx context.Context, peerId peer.ID, n int) error {
+	// take tokens from the global rate-limiter,
+	// to make sure there's not too much concurrent server work between different peers.
+	if err := srv.globalRequestsRL.WaitN(ctx, n); err != nil {
+		return fmt.Errorf("timed out waiting for global sync rate limit: %w", err)
+	}
+
+	// find rate limiting data of peer, or add otherwise
+	srv.peerStatsLock.Lock()
+	ps, _ := srv.peerRateLimits.Get(peerId)
+	if ps == nil {
+		ps = &peerStat{
+			Requests: rate.NewLimiter(peerServerBlocksRateLimit, peerServerBlocksBurst),
+		}
+		srv.peerRateLimits.Add(peerId, ps)
+		ps.Requests.ReserveN(time.Now(), n) // count the hit, but make it delay the next request rather than immediately waiting
+	} else {
+		// Only wait if it's an existing peer, otherwise the instant rate-limit Wait call always errors.
+
+		// If the requester thinks we're taking too long, then it's their problem and they can disconnect.
+		// We'll disconnect ourselves only when failing to read/write,
+		// if the work is invalid (range validation), or when individual sub tasks timeout.
+		if err := ps.Requests.WaitN(ctx, n); err != nil {
+			srv.peerStatsLock.Unlock()
+			return fmt.Errorf("timed out waiting for peer sync rate limit: %w: %w", peerRateLimitedErr, err)
+		}
+	}
+	srv.peerStatsLock.Unlock()
+	return nil
+}
@@ -4,12 +4,14 @@ import (
 	"context"
 	"math/big"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
 	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
 	"github.com/stretchr/testify/require"
 
@@ -29,6 +31,10 @@ func (fn mockPayloadFn) PayloadByNumber(_ context.Context, number uint64) (*eth.
 	return fn(number)
 }
 
+func (fn mockPayloadFn) PayloadByHash(_ context.Context, hash common.Hash) (*eth.ExecutionPayload, error) {
+	return nil, ethereum.NotFound
+}
+
 var _ L2Chain = mockPayloadFn(nil)
 
 type syncTestData struct {
@@ -55,6 +61,27 @@ func (s *syncTestData) addPayload(payload *eth.ExecutionPayload) {
 	s.payloads[uint64(payload.BlockNumber)] = payload
 }
 
+func (s *syncTestData) PayloadByNumber(_ context.Context, number uint64) (*eth.ExecutionPayload, error) {
+	p, ok := s.getPayload(number)
+	if !ok {
+		return nil, ethereum.NotFound
+	}
+	return p, nil
+}
+
+func (s *syncTestData) PayloadByHash(_ context.Context, hash common.Hash) (*eth.ExecutionPayload, error) {
+	s.RLock()
+	defer s.RUnlock()
+	for _, p := range s.payloads {
+		if p.BlockHash == hash {
+			return p, nil
+		}
+	}
+	return nil, ethereum.NotFound
+}
+
+var _ L2Chain = (*syncTestData)(nil)
+
 func (s *syncTestData) getBlockRef(i uint64) eth.L2BlockRef {
 	s.RLock()
 	defer s.RUnlock()
@@ -132,7 +159,7 @@ func TestSinglePeerSync(t *testing.T) {
 	defer cancel()
 
 	// Setup host A as the server
-	srv := NewReqRespServer(cfg, servePayload, metrics.NoopMetrics)
+	srv := NewReqRespServer(cfg, servePayload, metrics.NoopMetrics, &NoopApplicationScorer{})
 	payloadByNumber := MakeStreamHandler(ctx, log.New("role", "server"), srv.HandleSyncRequest)
 	hostA.SetStreamHandler(PayloadByNumberProtocolID(cfg.L2ChainID), payloadByNumber)
 
@@ -186,7 +213,7 @@ func TestMultiPeerSync(t *testing.T) {
 		})
 
 		// Setup as server
-		srv := NewReqRespServer(cfg, servePayload, metrics.NoopMetrics)
+		srv := NewReqRespServer(cfg, servePayload, metrics.NoopMetrics, &NoopApplicationScorer{})
 		payloadByNumber := MakeStreamHandler(ctx, log.New("serve", "payloads_by_number"), srv.HandleSyncRequest)
 		h.SetStreamHandler(PayloadByNumberProtocolID(cfg.L2ChainID), payloadByNumber)
 
@@ -288,3 +315,401 @@ func TestMultiPeerSync(t *testing.T) {
 		require.Equal(t, exp.BlockHash, p.BlockHash, "expecting the correct payload")
 	}
 }
+
+func TestSinglePeerRangeSync(t *testing.T) {
+	t.Parallel() // Takes a while, but can run in parallel
+
+	log := testlog.Logger(t, log.LvlError)
+
+	cfg, payloads := setupSyncTestData(50)
+
+	// Serving payloads: just load them from the map, if they exist
+	servePayload := mockPayloadFn(func(n uint64) (*eth.ExecutionPayload, error) {
+		p, ok := payloads.getPayload(n)
+		if !ok {
+			return nil, ethereum.NotFound
+		}
+		return p, nil
+	})
+
+	// collect received payloads in a buffered channel, so we can verify we get everything
+	received := make(chan *eth.ExecutionPayload, 100)
+	receivePayload := receivePayloadFn(func(ctx context.Context, from peer.ID, payload *eth.ExecutionPayload) error {
+		received <- payload
+		return nil
+	})
+
+	// Setup 2 minimal test hosts to attach the sync protocol to
+	mnet, err := mocknet.FullMeshConnected(2)
+	require.NoError(t, err, "failed to setup mocknet")
+	defer mnet.Close()
+	hosts := mnet.Hosts()
+	hostA, hostB := hosts[0], hosts[1]
+	require.Equal(t, hostA.Network().Connectedness(hostB.ID()), network.Connected)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Setup host A as the server, with only the range protocol, so the client cannot fall back to payload_by_number.
+	srv := NewReqRespServer(cfg, servePayload, metrics.NoopMetrics, &NoopApplicationScorer{})
+	payloadsByRange := MakeStreamHandler(ctx, log.New("role", "server"), srv.HandleRangeSyncRequest)
+	hostA.SetStreamHandler(PayloadsByRangeProtocolID(cfg.L2ChainID), payloadsByRange)
+
+	// Setup host B as the client
+	cl := NewSyncClient(log.New("role", "client"), cfg, hostB.NewStream, receivePayload, metrics.NoopMetrics, &NoopApplicationScorer{})
+
+	// Setup host B (client) to sync from its peer Host A (server)
+	cl.AddPeer(hostA.ID())
+	cl.Start()
+	defer cl.Close()
+
+	// request to start syncing between 10 and 40, this spans multiple range requests
+	require.NoError(t, cl.RequestL2Range(ctx, payloads.getBlockRef(10), payloads.getBlockRef(40)))
+
+	// and wait for the sync results to come in (in reverse order)
+	for i := uint64(39); i > 10; i-- {
+		p := <-received
+		require.Equal(t, uint64(p.BlockNumber), i, "expecting payloads in order")
+		exp, ok := payloads.getPayload(uint64(p.BlockNumber))
+		require.True(t, ok, "expecting known payload")
+		require.Equal(t, exp.BlockHash, p.BlockHash, "expecting the correct payload")
+	}
+}
+
+func TestRangeSyncPartialResponse(t *testing.T) {
+	log := testlog.Logger(t, log.LvlError)
+
+	cfg, payloads := setupSyncTestData(20)
+	// remove a block, so the server can only serve part of the range
+	payloads.deletePayload(12)
+
+	servePayload := mockPayloadFn(func(n uint64) (*eth.ExecutionPayload, error) {
+		p, ok := payloads.getPayload(n)
+		if !ok {
+			return nil, ethereum.NotFound
+		}
+		return p, nil
+	})
+
+	mnet, err := mocknet.FullMeshConnected(2)
+	require.NoError(t, err, "failed to setup mocknet")
+	defer mnet.Close()
+	hosts := mnet.Hosts()
+	hostA, hostB := hosts[0], hosts[1]
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	srv := NewReqRespServer(cfg, servePayload, metrics.NoopMetrics, &NoopApplicationScorer{})
+	hostA.SetStreamHandler(PayloadsByRangeProtocolID(cfg.L2ChainID),
+		MakeStreamHandler(ctx, log.New("role", "server"), srv.HandleRangeSyncRequest))
+
+	// The client is not started, we only test a single request, and read the results directly
+	cl := NewSyncClient(log.New("role", "client"), cfg, hostB.NewStream, nil, metrics.NoopMetrics, &NoopApplicationScorer{})
+
+	legacy, err := cl.doRangeRequest(ctx, hostA.ID(), peerRequest{start: 8, count: 6})
+	require.NoError(t, err)
+	require.False(t, legacy, "peer supports the range protocol")
+	// blocks 8 to 11 are served, received from high to low
+	for i := uint64(11); i >= 8; i-- {
+		res := <-cl.results
+		require.Equal(t, i, uint64(res.payload.BlockNumber))
+		require.Equal(t, hostA.ID(), res.peer)
+	}
+	require.Zero(t, len(cl.results), "the missing block and any blocks after it are not served")
+
+	// a request starting at the missing block fails with the not-found result code
+	_, err = cl.doRangeRequest(ctx, hostA.ID(), peerRequest{start: 12, count: 2})
+	require.ErrorIs(t, err, requestResultErr(1))
+
+	// ranges larger than the cap are rejected as invalid request
+	_, err = cl.doRangeRequest(ctx, hostA.ID(), peerRequest{start: 1, count: maxPayloadsByRangeCount + 1})
+	require.ErrorIs(t, err, requestResultErr(2))
+}
+
+func TestSyncClientPayloadByHash(t *testing.T) {
+	log := testlog.Logger(t, log.LvlError)
+
+	cfg, payloads := setupSyncTestData(20)
+
+	mnet, err := mocknet.FullMeshConnected(2)
+	require.NoError(t, err, "failed to setup mocknet")
+	defer mnet.Close()
+	hosts := mnet.Hosts()
+	hostA, hostB := hosts[0], hosts[1]
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	srv := NewReqRespServer(cfg, payloads, metrics.NoopMetrics, &NoopApplicationScorer{})
+	hostA.SetStreamHandler(PayloadByHashProtocolID(cfg.L2ChainID),
+		MakeStreamHandler(ctx, log.New("role", "server"), srv.HandleHashSyncRequest))
+
+	// The client is not started, the synchronous requests do not need the main loop
+	cl := NewSyncClient(log.New("role", "client"), cfg, hostB.NewStream, nil, metrics.NoopMetrics, &NoopApplicationScorer{})
+	defer cl.Close()
+
+	// only peers registered for sync duties can be requested from
+	_, err = cl.PayloadByHash(ctx, hostA.ID(), common.Hash{0x42})
+	require.ErrorContains(t, err, "not registered")
+	cl.AddPeer(hostA.ID())
+
+	exp, _ := payloads.getPayload(12)
+	p, err := cl.PayloadByHash(ctx, hostA.ID(), exp.BlockHash)
+	require.NoError(t, err)
+	require.Equal(t, exp.BlockHash, p.BlockHash)
+	require.Equal(t, exp.BlockNumber, p.BlockNumber)
+
+	_, err = cl.PayloadByHash(ctx, hostA.ID(), common.Hash{0x42})
+	require.ErrorIs(t, err, ethereum.NotFound)
+}
+
+func TestSyncClientPayloadsByRange(t *testing.T) {
+	log := testlog.Logger(t, log.LvlError)
+
+	cfg, payloads := setupSyncTestData(20)
+	payloads.deletePayload(15)
+
+	mnet, err := mocknet.FullMeshConnected(3)
+	require.NoError(t, err, "failed to setup mocknet")
+	defer mnet.Close()
+	hosts := mnet.Hosts()
+	hostA, hostB, hostC := hosts[0], hosts[1], hosts[2]
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// host A serves the range protocol, host B only the legacy payload_by_number protocol
+	srv := NewReqRespServer(cfg, payloads, metrics.NoopMetrics, &NoopApplicationScorer{})
+	hostA.SetStreamHandler(PayloadsByRangeProtocolID(cfg.L2ChainID),
+		MakeStreamHandler(ctx, log.New("role", "server"), srv.HandleRangeSyncRequest))
+	hostB.SetStreamHandler(PayloadByNumberProtocolID(cfg.L2ChainID),
+		MakeStreamHandler(ctx, log.New("role", "server"), srv.HandleSyncRequest))
+
+	cl := NewSyncClient(log.New("role", "client"), cfg, hostC.NewStream, nil, metrics.NoopMetrics, &NoopApplicationScorer{})
+	defer cl.Close()
+	cl.AddPeer(hostA.ID())
+	cl.AddPeer(hostB.ID())
+
+	for _, id := range []peer.ID{hostA.ID(), hostB.ID()} {
+		res, err := cl.PayloadsByRange(ctx, id, 10, 8)
+		require.NoError(t, err)
+		// blocks 10 to 14 are served in order, the missing block ends the response
+		require.Len(t, res, 5)
+		for i, p := range res {
+			require.Equal(t, uint64(10+i), uint64(p.BlockNumber))
+			exp, _ := payloads.getPayload(uint64(p.BlockNumber))
+			require.Equal(t, exp.BlockHash, p.BlockHash)
+		}
+
+		_, err = cl.PayloadsByRange(ctx, id, 15, 2)
+		require.ErrorIs(t, err, ethereum.NotFound)
+	}
+}
+
+type recordingAppScorer struct {
+	NoopApplicationScorer
+	sync.Mutex
+	valid, errors, rejected int
+}
+
+func (s *recordingAppScorer) onValidResponse(_ peer.ID) {
+	s.Lock()
+	defer s.Unlock()
+	s.valid++
+}
+
+func (s *recordingAppScorer) onResponseError(_ peer.ID) {
+	s.Lock()
+	defer s.Unlock()
+	s.errors++
+}
+
+func (s *recordingAppScorer) onRejectedPayload(_ peer.ID) {
+	s.Lock()
+	defer s.Unlock()
+	s.rejected++
+}
+
+func (s *recordingAppScorer) counts() (valid, errors, rejected int) {
+	s.Lock()
+	defer s.Unlock()
+	return s.valid, s.errors, s.rejected
+}
+
+type rangeEventMetrics struct {
+	SyncClientMetrics
+	events chan byte
+}
+
+func (m *rangeEventMetrics) ClientPayloadsByRangeEvent(_ uint64, _ uint64, resultCode byte, _ time.Duration) {
+	m.events <- resultCode
+}
+
+func TestSyncClientPayloadsByRangeInvalidFallback(t *testing.T) {
+	log := testlog.Logger(t, log.LvlError)
+
+	cfg, payloads := setupSyncTestData(20)
+
+	mnet, err := mocknet.FullMeshConnected(2)
+	require.NoError(t, err, "failed to setup mocknet")
+	defer mnet.Close()
+	hosts := mnet.Hosts()
+	hostA, hostB := hosts[0], hosts[1]
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// host A only serves the legacy payload_by_number protocol, and serves the wrong payload for block 12
+	srv := NewReqRespServer(cfg, mockPayloadFn(func(n uint64) (*eth.ExecutionPayload, error) {
+		if n == 12 {
+			n = 13
+		}
+		return payloads.PayloadByNumber(ctx, n)
+	}), metrics.NoopMetrics, &NoopApplicationScorer{})
+	hostA.SetStreamHandler(PayloadByNumberProtocolID(cfg.L2ChainID),
+		MakeStreamHandler(ctx, log.New("role", "server"), srv.HandleSyncRequest))
+
+	scorer := &recordingAppScorer{}
+	cl := NewSyncClient(log.New("role", "client"), cfg, hostB.NewStream, nil, metrics.NoopMetrics, scorer)
+	defer cl.Close()
+	cl.AddPeer(hostA.ID())
+
+	// the invalid payload is not hidden by the partial result of the blocks before it
+	_, err = cl.PayloadsByRange(ctx, hostA.ID(), 10, 4)
+	require.ErrorIs(t, err, errInvalidPayload)
+	valid, errs, rejected := scorer.counts()
+	require.Equal(t, 1, rejected)
+	require.Zero(t, valid)
+	require.Zero(t, errs)
+
+	res, err := cl.PayloadsByRange(ctx, hostA.ID(), 8, 4)
+	require.NoError(t, err)
+	require.Len(t, res, 4)
+	valid, _, _ = scorer.counts()
+	require.Equal(t, 1, valid)
+}
+
+func TestSyncClientPeerLoopScoring(t *testing.T) {
+	log := testlog.Logger(t, log.LvlError)
+
+	cfg, payloads := setupSyncTestData(20)
+	payloads.deletePayload(12)
+
+	mnet, err := mocknet.FullMeshConnected(2)
+	require.NoError(t, err, "failed to setup mocknet")
+	defer mnet.Close()
+	hosts := mnet.Hosts()
+	hostA, hostB := hosts[0], hosts[1]
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// host A does not have block 12, and serves the wrong payload for block 15
+	srv := NewReqRespServer(cfg, mockPayloadFn(func(n uint64) (*eth.ExecutionPayload, error) {
+		if n == 15 {
+			n = 16
+		}
+		return payloads.PayloadByNumber(ctx, n)
+	}), metrics.NoopMetrics, &NoopApplicationScorer{})
+	hostA.SetStreamHandler(PayloadsByRangeProtocolID(cfg.L2ChainID),
+		MakeStreamHandler(ctx, log.New("role", "server"), srv.HandleRangeSyncRequest))
+
+	// The client is not started, the peer loop serves the requests we schedule directly
+	scorer := &recordingAppScorer{}
+	m := &rangeEventMetrics{SyncClientMetrics: metrics.NoopMetrics, events: make(chan byte, 1)}
+	cl := NewSyncClient(log.New("role", "client"), cfg, hostB.NewStream, nil, m, scorer)
+	defer cl.Close()
+	cl.AddPeer(hostA.ID())
+
+	request := func(start, count uint64) {
+		cl.peerRequests <- peerRequest{start: start, count: count, complete: new(atomic.Bool)}
+	}
+
+	// a peer that does not have the requested blocks is not penalized
+	request(12, 1)
+	require.Equal(t, byte(1), <-m.events)
+	valid, errs, rejected := scorer.counts()
+	require.Zero(t, valid)
+	require.Zero(t, errs)
+	require.Zero(t, rejected)
+
+	// an invalid payload is scored as rejected payload, not as error response
+	request(14, 2)
+	require.Eventually(t, func() bool {
+		_, _, rejected := scorer.counts()
+		return rejected == 1
+	}, 10*time.Second, 10*time.Millisecond)
+	valid, errs, _ = scorer.counts()
+	require.Zero(t, valid)
+	require.Zero(t, errs)
+}
+
+func TestRangeSyncFallback(t *testing.T) {
+	log := testlog.Logger(t, log.LvlError)
+
+	cfg, payloads := setupSyncTestData(20)
+
+	mnet, err := mocknet.FullMeshConnected(2)
+	require.NoError(t, err, "failed to setup mocknet")
+	defer mnet.Close()
+	hosts := mnet.Hosts()
+	hostA, hostB := hosts[0], hosts[1]
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// host A only serves the legacy payload_by_number protocol
+	srv := NewReqRespServer(cfg, payloads, metrics.NoopMetrics, &NoopApplicationScorer{})
+	hostA.SetStreamHandler(PayloadByNumberProtocolID(cfg.L2ChainID),
+		MakeStreamHandler(ctx, log.New("role", "server"), srv.HandleSyncRequest))
+
+	// record the protocol every opened stream negotiated
+	var negotiated []protocol.ID
+	newStream := func(ctx context.Context, id peer.ID, protocols ...protocol.ID) (network.Stream, error) {
+		str, err := hostB.NewStream(ctx, id, protocols...)
+		if err == nil {
+			negotiated = append(negotiated, str.Protocol())
+		}
+		return str, err
+	}
+
+	// The client is not started, we only test a single request, and read the results directly
+	cl := NewSyncClient(log.New("role", "client"), cfg, newStream, nil, metrics.NoopMetrics, &NoopApplicationScorer{})
+
+	legacy, err := cl.doRangeRequest(ctx, hostA.ID(), peerRequest{start: 8, count: 4})
+	require.NoError(t, err)
+	require.True(t, legacy, "peer does not support the range protocol")
+	// blocks 8 to 11 are served one by one, from high to low
+	for i := uint64(11); i >= 8; i-- {
+		res := <-cl.results
+		require.Equal(t, i, uint64(res.payload.BlockNumber))
+		require.Equal(t, hostA.ID(), res.peer)
+	}
+	require.Zero(t, len(cl.results))
+
+	// the negotiated stream is reused for the first block, every other block opens its own stream
+	require.Len(t, negotiated, 4)
+	for _, p := range negotiated {
+		require.Equal(t, PayloadByNumberProtocolID(cfg.L2ChainID), p)
+	}
+}
+
+func TestSyncClientPeerRateLimit(t *testing.T) {
+	cfg, _ := setupSyncTestData(20)
+	cl := NewSyncClient(testlog.Logger(t, log.LvlError), cfg, nil, nil, metrics.NoopMetrics, &NoopApplicationScorer{})
+	defer cl.Close()
+	id := peer.ID("test-peer")
+	cl.AddPeer(id)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	// the per-peer burst is shared with the synchronous requests, which cannot exceed it without waiting
+	require.NoError(t, cl.waitRateLimit(ctx, id, peerServerBlocksBurst))
+	require.Error(t, cl.waitRateLimit(ctx, id, maxPayloadsByRangeCount), "peer rate limit must be exhausted")
+
+	// other peers have their own limiter
+	other := peer.ID("other-peer")
+	cl.AddPeer(other)
+	require.NoError(t, cl.waitRateLimit(ctx, other, maxPayloadsByRangeCount))
+}
@@ -0,0 +1,400 @@
+package derive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// PipelineCheckpointVersion is the version of the checkpoint encoding.
+// Checkpoints of a different version are ignored.
+const PipelineCheckpointVersion = 1
+
+// ErrInvalidCheckpoint is returned when a checkpoint is not consistent with the current L1 or L2 chain.
+var ErrInvalidCheckpoint = errors.New("invalid pipeline checkpoint")
+
+// PipelineCheckpoint is a snapshot of the buffered state of the derivation pipeline,
+// taken right after the pipeline advanced to a new L1 block, when all stages are drained of the previous L1 block.
+// Restoring a checkpoint avoids re-reading a full channel-timeout worth of L1 data after a restart.
+type PipelineCheckpoint struct {
+	Version uint64 `json:"version"`
+
+	L1Traversal L1TraversalCheckpoint `json:"l1Traversal"`
+	ChannelBank ChannelBankCheckpoint `json:"channelBank"`
+	BatchQueue  BatchQueueCheckpoint  `json:"batchQueue"`
+	EngineQueue EngineQueueCheckpoint `json:"engineQueue"`
+}
+
+type L1TraversalCheckpoint struct {
+	// Origin is the next L1 block to read data from.
+	Origin       eth.L1BlockRef   `json:"origin"`
+	SystemConfig eth.SystemConfig `json:"systemConfig"`
+}
+
+type ChannelCheckpoint struct {
+	ID                      ChannelID      `json:"id"`
+	OpenBlock               eth.L1BlockRef `json:"openBlock"`
+	Size                    uint64         `json:"size"`
+	Closed                  bool           `json:"closed"`
+	HighestFrameNumber      uint16         `json:"highestFrameNumber"`
+	EndFrameNumber          uint16         `json:"endFrameNumber"`
+	HighestL1InclusionBlock eth.L1BlockRef `json:"highestL1InclusionBlock"`
+	// Frames are ordered by frame number.
+	Frames []Frame `json:"frames"`
+}
+
+type ChannelBankCheckpoint struct {
+	// Channels in FIFO order.
+	Channels []ChannelCheckpoint `json:"channels"`
+}
+
+type BatchCheckpoint struct {
+	L1InclusionBlock eth.L1BlockRef `json:"l1InclusionBlock"`
+	// Batch is the typed binary encoding of the batch.
+	Batch hexutil.Bytes `json:"batch"`
+}
+
+type BatchQueueCheckpoint struct {
+	Origin   eth.L1BlockRef    `json:"origin"`
+	L1Blocks []eth.L1BlockRef  `json:"l1Blocks"`
+	Batches  []BatchCheckpoint `json:"batches"`
+}
+
+type EngineQueueCheckpoint struct {
+	Origin       eth.L1BlockRef `json:"origin"`
+	SafeHead     eth.L2BlockRef `json:"safeHead"`
+	FinalityData []FinalityData `json:"finalityData"`
+}
+
+// Checkpointer persists pipeline checkpoints.
+type Checkpointer interface {
+	// LoadCheckpoint returns the last saved checkpoint, or nil if there is none.
+	LoadCheckpoint() (*PipelineCheckpoint, error)
+	SaveCheckpoint(cp *PipelineCheckpoint) error
+}
+
+// checkpointStage is a pipeline stage that can write its state into a checkpoint, and restore it from one.
+type checkpointStage interface {
+	checkpoint(cp *PipelineCheckpoint) error
+	// restoreCheckpoint replaces the stage state with the checkpoint state.
+	// Only the engine queue may fail, before any stage is modified.
+	restoreCheckpoint(ctx context.Context, cp *PipelineCheckpoint) error
+}
+
+// EnableCheckpoints makes the pipeline save a checkpoint every interval L1 blocks,
+// and restore from the last saved checkpoint on the first reset, instead of finding the sync-start from scratch.
+// If the checkpoint is not consistent with the L1 and L2 chains, the regular reset is used instead.
+func (dp *DerivationPipeline) EnableCheckpoints(checkpointer Checkpointer, interval uint64) {
+	if interval == 0 {
+		interval = 1
+	}
+	dp.checkpointer = checkpointer
+	dp.checkpointInterval = interval
+	dp.restorePending = true
+}
+
+// Checkpoint returns a checkpoint of the current pipeline state.
+// Only checkpoints taken right after the L1 traversal advanced can be restored: the pipeline calls this itself.
+func (dp *DerivationPipeline) Checkpoint() (*PipelineCheckpoint, error) {
+	cp := &PipelineCheckpoint{Version: PipelineCheckpointVersion}
+	for i, stage := range dp.stages {
+		if s, ok := stage.(checkpointStage); ok {
+			if err := s.checkpoint(cp); err != nil {
+				return nil, fmt.Errorf("failed to checkpoint stage %d: %w", i, err)
+			}
+		}
+	}
+	return cp, nil
+}
+
+// maybeSaveCheckpoint saves a checkpoint if checkpoints are enabled and the interval has passed.
+// Failing to save a checkpoint is not critical to the derivation process.
+func (dp *DerivationPipeline) maybeSaveCheckpoint() {
+	if dp.checkpointer == nil {
+		return
+	}
+	dp.checkpointCounter += 1
+	if dp.checkpointCounter < dp.checkpointInterval {
+		return
+	}
+	dp.checkpointCounter = 0
+	cp, err := dp.Checkpoint()
+	if err != nil {
+		dp.log.Warn("Failed to create pipeline checkpoint", "err", err)
+		return
+	}
+	if err := dp.checkpointer.SaveCheckpoint(cp); err != nil {
+		dp.log.Warn("Failed to save pipeline checkpoint", "err", err)
+		return
+	}
+	dp.log.Debug("Saved pipeline checkpoint", "origin", cp.L1Traversal.Origin, "safe_head", cp.EngineQueue.SafeHead,
+		"channels", len(cp.ChannelBank.Channels), "batches", len(cp.BatchQueue.Batches))
+}
+
+// tryRestoreCheckpoint attempts to restore the last saved checkpoint.
+// It returns true if the pipeline was restored, and false if the regular reset should be used instead.
+// A temporary error is returned if the checkpoint could not be validated yet.
+func (dp *DerivationPipeline) tryRestoreCheckpoint(ctx context.Context) (bool, error) {
+	cp, err := dp.checkpointer.LoadCheckpoint()
+	if err != nil {
+		dp.log.Warn("Failed to load pipeline checkpoint, resetting pipeline instead", "err", err)
+		return false, nil
+	}
+	if cp == nil {
+		return false, nil
+	}
+	if cp.Version != PipelineCheckpointVersion {
+		dp.log.Warn("Ignoring pipeline checkpoint of unknown version", "version", cp.Version)
+		return false, nil
+	}
+	for i, stage := range dp.stages {
+		s, ok := stage.(checkpointStage)
+		if !ok {
+			return false, NewCriticalError(fmt.Errorf("stage %d does not support checkpoints", i))
+		}
+		if err := s.restoreCheckpoint(ctx, cp); errors.Is(err, ErrInvalidCheckpoint) {
+			dp.log.Warn("Pipeline checkpoint is not consistent with the chain, resetting pipeline instead", "err", err)
+			return false, nil
+		} else if err != nil {
+			return false, err
+		}
+	}
+	dp.log.Info("Restored pipeline from checkpoint", "origin", cp.L1Traversal.Origin, "safe_head", cp.EngineQueue.SafeHead,
+		"channels", len(cp.ChannelBank.Channels), "batches", len(cp.BatchQueue.Batches))
+	return true, nil
+}
+
+func (l1t *L1Traversal) checkpoint(cp *PipelineCheckpoint) error {
+	cp.L1Traversal = L1TraversalCheckpoint{
+		Origin:       l1t.block,
+		SystemConfig: l1t.sysCfg,
+	}
+	return nil
+}
+
+func (l1t *L1Traversal) restoreCheckpoint(_ context.Context, cp *PipelineCheckpoint) error {
+	l1t.block = cp.L1Traversal.Origin
+	l1t.done = false
+	l1t.sysCfg = cp.L1Traversal.SystemConfig
+	return nil
+}
+
+func (l1r *L1Retrieval) checkpoint(_ *PipelineCheckpoint) error {
+	return nil
+}
+
+func (l1r *L1Retrieval) restoreCheckpoint(_ context.Context, _ *PipelineCheckpoint) error {
+	// Unlike a reset, the data of the L1 traversal origin has not been read yet, and is opened on the next read.
+	l1r.datas = nil
+	return nil
+}
+
+func (fq *FrameQueue) checkpoint(_ *PipelineCheckpoint) error {
+	return nil
+}
+
+func (fq *FrameQueue) restoreCheckpoint(_ context.Context, _ *PipelineCheckpoint) error {
+	fq.frames = fq.frames[:0]
+	return nil
+}
+
+func (cb *ChannelBank) checkpoint(cp *PipelineCheckpoint) error {
+	channels := make([]ChannelCheckpoint, 0, len(cb.channelQueue))
+	for _, id := range cb.channelQueue {
+		ch, ok := cb.channels[id]
+		if !ok {
+			return fmt.Errorf("channel %s is queued but not buffered", id)
+		}
+		frames := make([]Frame, 0, len(ch.inputs))
+		for _, f := range ch.inputs {
+			frames = append(frames, f)
+		}
+		slices.SortFunc(frames, func(a, b Frame) int {
+			return int(a.FrameNumber) - int(b.FrameNumber)
+		})
+		channels = append(channels, ChannelCheckpoint{
+			ID:                      ch.id,
+			OpenBlock:               ch.openBlock,
+			Size:                    ch.size,
+			Closed:                  ch.closed,
+			HighestFrameNumber:      ch.highestFrameNumber,
+			EndFrameNumber:          ch.endFrameNumber,
+			HighestL1InclusionBlock: ch.highestL1InclusionBlock,
+			Frames:                  frames,
+		})
+	}
+	cp.ChannelBank = ChannelBankCheckpoint{Channels: channels}
+	return nil
+}
+
+func (cb *ChannelBank) restoreCheckpoint(_ context.Context, cp *PipelineCheckpoint) error {
+	cb.channels = make(map[ChannelID]*Channel)
+	cb.channelQueue = make([]ChannelID, 0, len(cp.ChannelBank.Channels))
+	for _, c := range cp.ChannelBank.Channels {
+		ch := NewChannel(c.ID, c.OpenBlock)
+		ch.size = c.Size
+		ch.closed = c.Closed
+		ch.highestFrameNumber = c.HighestFrameNumber
+		ch.endFrameNumber = c.EndFrameNumber
+		ch.highestL1InclusionBlock = c.HighestL1InclusionBlock
+		for _, f := range c.Frames {
+			ch.inputs[uint64(f.FrameNumber)] = f
+		}
+		cb.channels[c.ID] = ch
+		cb.channelQueue = append(cb.channelQueue, c.ID)
+	}
+	return nil
+}
+
+func (cr *ChannelInReader) checkpoint(_ *PipelineCheckpoint) error {
+	return nil
+}
+
+func (cr *ChannelInReader) restoreCheckpoint(_ context.Context, _ *PipelineCheckpoint) error {
+	cr.nextBatchFn = nil
+	return nil
+}
+
+func (bq *BatchQueue) checkpoint(cp *PipelineCheckpoint) error {
+	out := BatchQueueCheckpoint{
+		Origin:   bq.origin,
+		L1Blocks: append(make([]eth.L1BlockRef, 0, len(bq.l1Blocks)), bq.l1Blocks...),
+	}
+	timestamps := make([]uint64, 0, len(bq.batches))
+	for t := range bq.batches {
+		timestamps = append(timestamps, t)
+	}
+	slices.Sort(timestamps)
+	for _, t := range timestamps {
+		for _, b := range bq.batches[t] {
+			data, err := b.Batch.MarshalBinary()
+			if err != nil {
+				return fmt.Errorf("failed to encode batch with timestamp %d: %w", t, err)
+			}
+			out.Batches = append(out.Batches, BatchCheckpoint{
+				L1InclusionBlock: b.L1InclusionBlock,
+				Batch:            data,
+			})
+		}
+	}
+	cp.BatchQueue = out
+	return nil
+}
+
+func (bq *BatchQueue) restoreCheckpoint(_ context.Context, cp *PipelineCheckpoint) error {
+	// Batches are decoded before modifying the stage, but the engine queue already validated the checkpoint.
+	// Batches that cannot be decoded indicate a corrupted checkpoint, which is critical at this point.
+	batches := make(map[uint64][]*BatchWithL1InclusionBlock)
+	for i, b := range cp.BatchQueue.Batches {
+		var batch BatchData
+		if err := batch.UnmarshalBinary(b.Batch); err != nil {
+			return NewCriticalError(fmt.Errorf("failed to decode checkpointed batch %d: %w", i, err))
+		}
+		batches[batch.Timestamp] = append(batches[batch.Timestamp], &BatchWithL1InclusionBlock{
+			L1InclusionBlock: b.L1InclusionBlock,
+			Batch:            &batch,
+		})
+	}
+	bq.origin = cp.BatchQueue.Origin
+	bq.l1Blocks = append(bq.l1Blocks[:0], cp.BatchQueue.L1Blocks...)
+	bq.batches = batches
+	return nil
+}
+
+func (aq *AttributesQueue) checkpoint(_ *PipelineCheckpoint) error {
+	return nil
+}
+
+func (aq *AttributesQueue) restoreCheckpoint(_ context.Context, _ *PipelineCheckpoint) error {
+	aq.batch = nil
+	return nil
+}
+
+func (eq *EngineQueue) checkpoint(cp *PipelineCheckpoint) error {
+	cp.EngineQueue = EngineQueueCheckpoint{
+		Origin:       eq.origin,
+		SafeHead:     eq.safeHead,
+		FinalityData: append(make([]FinalityData, 0, len(eq.finalityData)), eq.finalityData...),
+	}
+	return nil
+}
+
+// restoreCheckpoint validates the checkpoint against the L1 and L2 chain, and then restores the engine queue state.
+// The checkpointed safe head must still be canonical in the engine, but the engine may have progressed further:
+// the blocks after the checkpointed safe head are then consolidated again.
+func (eq *EngineQueue) restoreCheckpoint(ctx context.Context, cp *PipelineCheckpoint) error {
+	heads, err := sync.CurrentHeads(ctx, eq.cfg, eq.engine)
+	if err != nil {
+		return NewTemporaryError(fmt.Errorf("failed to fetch current L2 heads: %w", err))
+	}
+	safe := cp.EngineQueue.SafeHead
+	if safe.Number > heads.Unsafe.Number {
+		return fmt.Errorf("%w: safe head %s is ahead of engine unsafe head %s", ErrInvalidCheckpoint, safe, heads.Unsafe)
+	}
+	if safe.Number < heads.Finalized.Number {
+		return fmt.Errorf("%w: safe head %s is behind engine finalized head %s", ErrInvalidCheckpoint, safe, heads.Finalized)
+	}
+	payload, err := eq.engine.PayloadByNumber(ctx, safe.Number)
+	if err != nil {
+		return NewTemporaryError(fmt.Errorf("failed to fetch L2 block %d: %w", safe.Number, err))
+	}
+	if payload.BlockHash != safe.Hash {
+		return fmt.Errorf("%w: safe head %s is not canonical, engine has %s", ErrInvalidCheckpoint, safe, payload.ID())
+	}
+
+	// The traversal origin is the most recent L1 block in the checkpoint, all other L1 blocks are its ancestors.
+	if err := eq.checkCanonicalL1(ctx, cp.L1Traversal.Origin.ID(), false); err != nil {
+		return err
+	}
+	// The unsafe head may be ahead of the visible L1 chain, but may not be on a different L1 chain.
+	if err := eq.checkCanonicalL1(ctx, heads.Unsafe.L1Origin, true); err != nil {
+		return err
+	}
+	if cp.L1Traversal.Origin.ParentHash != cp.EngineQueue.Origin.Hash {
+		return fmt.Errorf("%w: traversal origin %s does not build on engine queue origin %s", ErrInvalidCheckpoint,
+			cp.L1Traversal.Origin, cp.EngineQueue.Origin)
+	}
+
+	eq.log.Debug("Restore engine queue", "safeHead", safe, "unsafe", heads.Unsafe, "finalized", heads.Finalized, "l1Origin", cp.EngineQueue.Origin)
+	eq.unsafeHead = heads.Unsafe
+	eq.engineSyncTarget = heads.Unsafe
+	eq.safeHead = safe
+	eq.safeAttributes = nil
+	eq.finalized = heads.Finalized
+	eq.resetBuildingState()
+	eq.needForkchoiceUpdate = true
+	eq.finalityData = append(eq.finalityData[:0], cp.EngineQueue.FinalityData...)
+	eq.origin = cp.EngineQueue.Origin
+	eq.sysCfg = cp.L1Traversal.SystemConfig
+	eq.metrics.RecordL2Ref("l2_finalized", heads.Finalized)
+	eq.metrics.RecordL2Ref("l2_safe", safe)
+	eq.metrics.RecordL2Ref("l2_unsafe", heads.Unsafe)
+	eq.metrics.RecordL2Ref("l2_engineSyncTarget", heads.Unsafe)
+	eq.logSyncProgress("restored derivation work from checkpoint")
+	return nil
+}
+
+// checkCanonicalL1 checks that the given L1 block is canonical.
+// If allowAhead is true, L1 blocks that are not visible yet are accepted.
+func (eq *EngineQueue) checkCanonicalL1(ctx context.Context, id eth.BlockID, allowAhead bool) error {
+	ref, err := eq.l1Fetcher.L1BlockRefByNumber(ctx, id.Number)
+	if errors.Is(err, ethereum.NotFound) {
+		if allowAhead {
+			return nil
+		}
+		return fmt.Errorf("%w: L1 block %s is not available", ErrInvalidCheckpoint, id)
+	} else if err != nil {
+		return NewTemporaryError(fmt.Errorf("failed to fetch L1 block %d: %w", id.Number, err))
+	}
+	if ref.Hash != id.Hash {
+		return fmt.Errorf("%w: L1 block %s is not canonical, L1 has %s", ErrInvalidCheckpoint, id, ref)
+	}
+	return nil
+}
@@ -0,0 +1,199 @@
+package derive
+
+import (
+	"context"
+	"encoding/json"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+type memCheckpointer struct {
+	cp *PipelineCheckpoint
+}
+
+func (m *memCheckpointer) LoadCheckpoint() (*PipelineCheckpoint, error) {
+	if m.cp == nil {
+		return nil, nil
+	}
+	// round-trip through JSON, like a checkpoint that is persisted to disk
+	data, err := json.Marshal(m.cp)
+	if err != nil {
+		return nil, err
+	}
+	var out PipelineCheckpoint
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (m *memCheckpointer) SaveCheckpoint(cp *PipelineCheckpoint) error {
+	m.cp = cp
+	return nil
+}
+
+func TestPipelineCheckpoint(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlDebug)
+	rng := rand.New(rand.NewSource(1234))
+
+	refA := testutils.RandomBlockRef(rng)
+	refB := testutils.NextRandomRef(rng, refA)
+	refC := testutils.NextRandomRef(rng, refB)
+	cfg := &rollup.Config{
+		Genesis: rollup.Genesis{
+			L1: refA.ID(),
+		},
+		BlockTime:      2,
+		SeqWindowSize:  4,
+		ChannelTimeout: 10,
+	}
+	genesis := eth.L2BlockRef{Hash: testutils.RandomHash(rng), Number: 0, Time: refA.Time, L1Origin: refA.ID()}
+	cfg.Genesis.L2 = genesis.ID()
+	safeHead := testutils.NextRandomL2Ref(rng, cfg.BlockTime, genesis, refA.ID())
+	safeHead.L1Origin = refA.ID()
+	unsafeHead := testutils.NextRandomL2Ref(rng, cfg.BlockTime, safeHead, refB.ID())
+	unsafeHead.L1Origin = refB.ID()
+
+	// populate the pipeline with state, as it would be after traversing to refC
+	setupPipeline := func(eng *testutils.MockEngine, l1F *testutils.MockL1Source) *DerivationPipeline {
+		dp := NewDerivationPipeline(logger, cfg, l1F, eng, &testutils.TestDerivationMetrics{}, &sync.Config{})
+		dp.traversal.block = refC
+		dp.traversal.sysCfg = eth.SystemConfig{BatcherAddr: common.Address{0x42}, GasLimit: 1234}
+
+		ch := NewChannel(ChannelID{0xaa}, refA)
+		require.NoError(t, ch.AddFrame(Frame{ID: ChannelID{0xaa}, FrameNumber: 1, Data: []byte("world"), IsLast: true}, refB))
+		require.NoError(t, ch.AddFrame(Frame{ID: ChannelID{0xaa}, FrameNumber: 0, Data: []byte("hello")}, refA))
+		dp.channelBank.channels[ch.id] = ch
+		dp.channelBank.channelQueue = append(dp.channelBank.channelQueue, ch.id)
+
+		dp.batchQueue.origin = refB
+		dp.batchQueue.l1Blocks = []eth.L1BlockRef{refA, refB}
+		dp.batchQueue.batches = map[uint64][]*BatchWithL1InclusionBlock{
+			safeHead.Time + cfg.BlockTime: {{
+				L1InclusionBlock: refB,
+				Batch: NewSingularBatchData(SingularBatch{
+					ParentHash: safeHead.Hash,
+					EpochNum:   rollup.Epoch(refA.Number),
+					EpochHash:  refA.Hash,
+					Timestamp:  safeHead.Time + cfg.BlockTime,
+				}),
+			}},
+		}
+
+		eq := dp.eng.(*EngineQueue)
+		eq.origin = refB
+		eq.safeHead = safeHead
+		eq.unsafeHead = unsafeHead
+		eq.finalityData = []FinalityData{{L2Block: safeHead, L1Block: refB.ID()}}
+		dp.resetting = len(dp.stages)
+		return dp
+	}
+
+	expectHeads := func(eng *testutils.MockEngine) {
+		eng.ExpectL2BlockRefByLabel(eth.Finalized, genesis, nil)
+		eng.ExpectL2BlockRefByLabel(eth.Safe, safeHead, nil)
+		eng.ExpectL2BlockRefByLabel(eth.Unsafe, unsafeHead, nil)
+	}
+
+	t.Run("restore", func(t *testing.T) {
+		eng := &testutils.MockEngine{}
+		l1F := &testutils.MockL1Source{}
+		src := setupPipeline(eng, l1F)
+		checkpoints := &memCheckpointer{}
+		src.EnableCheckpoints(checkpoints, 1)
+		src.maybeSaveCheckpoint()
+		require.NotNil(t, checkpoints.cp)
+
+		restoredEng := &testutils.MockEngine{}
+		restoredL1 := &testutils.MockL1Source{}
+		expectHeads(restoredEng)
+		restoredEng.ExpectPayloadByNumber(safeHead.Number, &eth.ExecutionPayload{BlockHash: safeHead.Hash}, nil)
+		restoredL1.ExpectL1BlockRefByNumber(refC.Number, refC, nil)
+		restoredL1.ExpectL1BlockRefByNumber(refB.Number, refB, nil)
+
+		dp := NewDerivationPipeline(logger, cfg, restoredL1, restoredEng, &testutils.TestDerivationMetrics{}, &sync.Config{})
+		dp.EnableCheckpoints(checkpoints, 1)
+		require.NoError(t, dp.Step(context.Background()))
+		require.Equal(t, len(dp.stages), dp.resetting, "pipeline should be restored without reset")
+
+		expected := src.State()
+		actual := dp.State()
+		require.Equal(t, expected.L1Traversal, actual.L1Traversal)
+		require.Equal(t, expected.ChannelBank.Channels, actual.ChannelBank.Channels)
+		require.Equal(t, expected.BatchQueue, actual.BatchQueue)
+		require.Equal(t, safeHead, actual.EngineQueue.SafeHead)
+		require.Equal(t, unsafeHead, actual.EngineQueue.UnsafeHead)
+		require.Equal(t, expected.EngineQueue.FinalityData, actual.EngineQueue.FinalityData)
+		require.True(t, actual.EngineQueue.NeedForkchoiceUpdate)
+		require.Equal(t, src.traversal.SystemConfig(), dp.traversal.SystemConfig())
+		restoredEng.AssertExpectations(t)
+		restoredL1.AssertExpectations(t)
+	})
+
+	t.Run("non-canonical safe head", func(t *testing.T) {
+		eng := &testutils.MockEngine{}
+		l1F := &testutils.MockL1Source{}
+		src := setupPipeline(eng, l1F)
+		checkpoints := &memCheckpointer{}
+		src.EnableCheckpoints(checkpoints, 1)
+		src.maybeSaveCheckpoint()
+
+		restoredEng := &testutils.MockEngine{}
+		expectHeads(restoredEng)
+		restoredEng.ExpectPayloadByNumber(safeHead.Number, &eth.ExecutionPayload{BlockHash: common.Hash{0xff}}, nil)
+
+		dp := NewDerivationPipeline(logger, cfg, &testutils.MockL1Source{}, restoredEng, &testutils.TestDerivationMetrics{}, &sync.Config{})
+		dp.EnableCheckpoints(checkpoints, 1)
+		restored, err := dp.tryRestoreCheckpoint(context.Background())
+		require.NoError(t, err)
+		require.False(t, restored, "checkpoint must be rejected")
+		require.Empty(t, dp.channelBank.channels, "pipeline state must not be modified")
+		require.Equal(t, eth.L1BlockRef{}, dp.traversal.Origin())
+	})
+
+	t.Run("reorged L1", func(t *testing.T) {
+		eng := &testutils.MockEngine{}
+		l1F := &testutils.MockL1Source{}
+		src := setupPipeline(eng, l1F)
+		checkpoints := &memCheckpointer{}
+		src.EnableCheckpoints(checkpoints, 1)
+		src.maybeSaveCheckpoint()
+
+		restoredEng := &testutils.MockEngine{}
+		restoredL1 := &testutils.MockL1Source{}
+		expectHeads(restoredEng)
+		restoredEng.ExpectPayloadByNumber(safeHead.Number, &eth.ExecutionPayload{BlockHash: safeHead.Hash}, nil)
+		restoredL1.ExpectL1BlockRefByNumber(refC.Number, testutils.NextRandomRef(rng, refB), nil)
+
+		dp := NewDerivationPipeline(logger, cfg, restoredL1, restoredEng, &testutils.TestDerivationMetrics{}, &sync.Config{})
+		dp.EnableCheckpoints(checkpoints, 1)
+		restored, err := dp.tryRestoreCheckpoint(context.Background())
+		require.NoError(t, err)
+		require.False(t, restored, "checkpoint must be rejected")
+		require.Empty(t, dp.channelBank.channels, "pipeline state must not be modified")
+	})
+
+	t.Run("interval", func(t *testing.T) {
+		eng := &testutils.MockEngine{}
+		l1F := &testutils.MockL1Source{}
+		src := setupPipeline(eng, l1F)
+		checkpoints := &memCheckpointer{}
+		src.EnableCheckpoints(checkpoints, 3)
+		src.maybeSaveCheckpoint()
+		src.maybeSaveCheckpoint()
+		require.Nil(t, checkpoints.cp)
+		src.maybeSaveCheckpoint()
+		require.NotNil(t, checkpoints.cp)
+	})
+}
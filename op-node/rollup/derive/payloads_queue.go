@@ -1,9 +1,11 @@
 package derive
 
 import (
+	"cmp"
 	"container/heap"
 	"errors"
 	"fmt"
+	"slices"
 
 	"github.com/ethereum/go-ethereum/common"
 
@@ -131,6 +133,18 @@ func (upq *PayloadsQueue) Push(p *eth.ExecutionPayload) error {
 	return nil
 }
 
+// Payloads returns all queued payloads, ordered by ascending block number, without modifying the queue.
+func (upq *PayloadsQueue) Payloads() []*eth.ExecutionPayload {
+	out := make([]*eth.ExecutionPayload, 0, len(upq.pq))
+	for _, ps := range upq.pq {
+		out = append(out, ps.payload)
+	}
+	slices.SortStableFunc(out, func(a, b *eth.ExecutionPayload) int {
+		return cmp.Compare(a.BlockNumber, b.BlockNumber)
+	})
+	return out
+}
+
 // Peek retrieves the payload with the lowest block number from the queue in O(1), or nil if the queue is empty.
 func (upq *PayloadsQueue) Peek() *eth.ExecutionPayload {
 	if len(upq.pq) == 0 {
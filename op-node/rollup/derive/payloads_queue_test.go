@@ -101,6 +101,7 @@ func TestPayloadsQueue(t *testing.T) {
 	require.Equal(t, pq.Len(), 3)
 	require.Equal(t, pq.MemSize(), 3*payloadMemFixedCost)
 	require.Equal(t, pq.Peek(), a, "expecting a to be new lowest number")
+	require.Equal(t, []*eth.ExecutionPayload{a, b, c}, pq.Payloads(), "expecting payloads ordered by number")
 
 	require.Equal(t, pq.Pop(), a)
 	require.Equal(t, pq.Len(), 2, "expecting to pop the lowest")
@@ -60,6 +60,7 @@ type EngineQueueStage interface {
 	AddUnsafePayload(payload *eth.ExecutionPayload)
 	UnsafeL2SyncTarget() eth.L2BlockRef
 	Step(context.Context) error
+	State() EngineQueueState
 }
 
 // DerivationPipeline is updated with new L1 data, and the Step() function can be iterated on to keep the L2 Engine in sync.
@@ -74,8 +75,19 @@ type DerivationPipeline struct {
 	stages    []ResettableStage
 
 	// Special stages to keep track of
-	traversal *L1Traversal
-	eng       EngineQueueStage
+	traversal   *L1Traversal
+	frameQueue  *FrameQueue
+	channelBank *ChannelBank
+	batchQueue  *BatchQueue
+	eng         EngineQueueStage
+
+	// Optional checkpointing of the pipeline state, nil if disabled.
+	checkpointer       Checkpointer
+	checkpointInterval uint64
+	// L1 blocks traversed since the last checkpoint
+	checkpointCounter uint64
+	// restorePending is true until the first reset, which tries to restore the last checkpoint.
+	restorePending bool
 
 	metrics Metrics
 }
@@ -103,14 +115,17 @@ func NewDerivationPipeline(log log.Logger, cfg *rollup.Config, l1Fetcher L1Fetch
 	stages := []ResettableStage{eng, l1Traversal, l1Src, frameQueue, bank, chInReader, batchQueue, attributesQueue}
 
 	return &DerivationPipeline{
-		log:       log,
-		cfg:       cfg,
-		l1Fetcher: l1Fetcher,
-		resetting: 0,
-		stages:    stages,
-		eng:       eng,
-		metrics:   metrics,
-		traversal: l1Traversal,
+		log:         log,
+		cfg:         cfg,
+		l1Fetcher:   l1Fetcher,
+		resetting:   0,
+		stages:      stages,
+		eng:         eng,
+		metrics:     metrics,
+		traversal:   l1Traversal,
+		frameQueue:  frameQueue,
+		channelBank: bank,
+		batchQueue:  batchQueue,
 	}
 }
 
@@ -193,6 +208,17 @@ func (dp *DerivationPipeline) Step(ctx context.Context) error {
 	defer dp.metrics.RecordL1Ref("l1_derived", dp.Origin())
 
 	// if any stages need to be reset, do that first.
+	if dp.resetting == 0 && dp.restorePending {
+		restored, err := dp.tryRestoreCheckpoint(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to restore pipeline checkpoint: %w", err)
+		}
+		dp.restorePending = false
+		if restored {
+			dp.resetting = len(dp.stages)
+			return nil
+		}
+	}
 	if dp.resetting < len(dp.stages) {
 		if err := dp.stages[dp.resetting].Reset(ctx, dp.eng.Origin(), dp.eng.SystemConfig()); err == io.EOF {
 			dp.log.Debug("reset of stage completed", "stage", dp.resetting, "origin", dp.eng.Origin())
@@ -208,7 +234,12 @@ func (dp *DerivationPipeline) Step(ctx context.Context) error {
 	// Now step the engine queue. It will pull earlier data as needed.
 	if err := dp.eng.Step(ctx); err == io.EOF {
 		// If every stage has returned io.EOF, try to advance the L1 Origin
-		return dp.traversal.AdvanceL1Block(ctx)
+		if err := dp.traversal.AdvanceL1Block(ctx); err != nil {
+			return err
+		}
+		// All stages are drained of the previous L1 block: this is a consistent point to checkpoint.
+		dp.maybeSaveCheckpoint()
+		return nil
 	} else if errors.Is(err, EngineP2PSyncing) {
 		return err
 	} else if err != nil {
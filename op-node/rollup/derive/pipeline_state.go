@@ -0,0 +1,256 @@
+package derive
+
+import (
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// PipelineState is a snapshot of the internal state of the stages of the derivation pipeline.
+// It is intended for inspection and debugging of the derivation process, and is not part of any consensus logic.
+type PipelineState struct {
+	// Resetting is the index of the stage that is currently being reset,
+	// or the number of stages if the pipeline is not resetting.
+	Resetting int `json:"resetting"`
+	// StagesCount is the total number of resettable stages.
+	StagesCount int `json:"stagesCount"`
+
+	L1Traversal L1TraversalState `json:"l1Traversal"`
+	FrameQueue  FrameQueueState  `json:"frameQueue"`
+	ChannelBank ChannelBankState `json:"channelBank"`
+	BatchQueue  BatchQueueState  `json:"batchQueue"`
+	EngineQueue EngineQueueState `json:"engineQueue"`
+}
+
+type L1TraversalState struct {
+	Origin eth.L1BlockRef `json:"origin"`
+	// Done is true if the data of the origin has been consumed, and the traversal is waiting to advance.
+	Done bool `json:"done"`
+}
+
+type FrameState struct {
+	ID          ChannelID `json:"id"`
+	FrameNumber uint16    `json:"frameNumber"`
+	DataLen     int       `json:"dataLen"`
+	IsLast      bool      `json:"isLast"`
+}
+
+type FrameQueueState struct {
+	Frames []FrameState `json:"frames"`
+}
+
+type ChannelState struct {
+	ID        ChannelID      `json:"id"`
+	OpenBlock eth.L1BlockRef `json:"openBlock"`
+	// TimeoutBlock is the L1 block number after which the channel times out.
+	TimeoutBlock            uint64         `json:"timeoutBlock"`
+	Size                    uint64         `json:"size"`
+	Closed                  bool           `json:"closed"`
+	Ready                   bool           `json:"ready"`
+	FramesCount             int            `json:"framesCount"`
+	HighestFrameNumber      uint16         `json:"highestFrameNumber"`
+	EndFrameNumber          uint16         `json:"endFrameNumber"`
+	HighestL1InclusionBlock eth.L1BlockRef `json:"highestL1InclusionBlock"`
+}
+
+type ChannelBankState struct {
+	Origin eth.L1BlockRef `json:"origin"`
+	// Channels in FIFO order, the first channel is the one that is read from first.
+	Channels []ChannelState `json:"channels"`
+	// TotalSize is the estimated memory size of all buffered channels.
+	TotalSize uint64 `json:"totalSize"`
+}
+
+type BatchState struct {
+	BatchType        int            `json:"batchType"`
+	Timestamp        uint64         `json:"timestamp"`
+	L1InclusionBlock eth.L1BlockRef `json:"l1InclusionBlock"`
+	// The below fields are only set for singular batches.
+	ParentHash common.Hash  `json:"parentHash,omitempty"`
+	EpochNum   rollup.Epoch `json:"epochNum,omitempty"`
+	EpochHash  common.Hash  `json:"epochHash,omitempty"`
+	TxCount    int          `json:"txCount,omitempty"`
+}
+
+type BatchQueueState struct {
+	Origin eth.L1BlockRef `json:"origin"`
+	// L1Blocks is the window of L1 blocks that batches are buffered for.
+	L1Blocks []eth.L1BlockRef `json:"l1Blocks"`
+	// Batches are the buffered batches, ordered by L2 timestamp and by the order they were first seen.
+	Batches []BatchState `json:"batches"`
+}
+
+type AttributesState struct {
+	Parent       eth.L2BlockRef `json:"parent"`
+	Timestamp    uint64         `json:"timestamp"`
+	NoTxPool     bool           `json:"noTxPool"`
+	TxCount      int            `json:"txCount"`
+	GasLimit     uint64         `json:"gasLimit"`
+	FeeRecipient common.Address `json:"feeRecipient"`
+}
+
+type PayloadState struct {
+	Hash       common.Hash `json:"hash"`
+	Number     uint64      `json:"number"`
+	ParentHash common.Hash `json:"parentHash"`
+	Timestamp  uint64      `json:"timestamp"`
+	TxCount    int         `json:"txCount"`
+}
+
+type EngineQueueState struct {
+	Origin           eth.L1BlockRef `json:"origin"`
+	FinalizedL1      eth.L1BlockRef `json:"finalizedL1"`
+	Finalized        eth.L2BlockRef `json:"finalized"`
+	SafeHead         eth.L2BlockRef `json:"safeHead"`
+	UnsafeHead       eth.L2BlockRef `json:"unsafeHead"`
+	EngineSyncTarget eth.L2BlockRef `json:"engineSyncTarget"`
+
+	BuildingOnto eth.L2BlockRef `json:"buildingOnto"`
+	BuildingSafe bool           `json:"buildingSafe"`
+
+	NeedForkchoiceUpdate bool `json:"needForkchoiceUpdate"`
+
+	// SafeAttributes are the pending attributes to process as safe block, nil if none are pending.
+	SafeAttributes *AttributesState `json:"safeAttributes"`
+	// UnsafePayloads are the queued unsafe payloads, ordered by ascending block number.
+	UnsafePayloads        []PayloadState `json:"unsafePayloads"`
+	UnsafePayloadsMemSize uint64         `json:"unsafePayloadsMemSize"`
+
+	FinalityData []FinalityData `json:"finalityData"`
+}
+
+// State returns a snapshot of the internal state of the pipeline stages.
+// The pipeline is not safe for concurrent use: the caller must synchronize with the caller of Step.
+func (dp *DerivationPipeline) State() PipelineState {
+	return PipelineState{
+		Resetting:   dp.resetting,
+		StagesCount: len(dp.stages),
+		L1Traversal: dp.traversal.State(),
+		FrameQueue:  dp.frameQueue.State(),
+		ChannelBank: dp.channelBank.State(),
+		BatchQueue:  dp.batchQueue.State(),
+		EngineQueue: dp.eng.State(),
+	}
+}
+
+func (l1t *L1Traversal) State() L1TraversalState {
+	return L1TraversalState{
+		Origin: l1t.block,
+		Done:   l1t.done,
+	}
+}
+
+func (fq *FrameQueue) State() FrameQueueState {
+	frames := make([]FrameState, 0, len(fq.frames))
+	for _, f := range fq.frames {
+		frames = append(frames, FrameState{
+			ID:          f.ID,
+			FrameNumber: f.FrameNumber,
+			DataLen:     len(f.Data),
+			IsLast:      f.IsLast,
+		})
+	}
+	return FrameQueueState{Frames: frames}
+}
+
+func (cb *ChannelBank) State() ChannelBankState {
+	out := ChannelBankState{
+		Origin:   cb.Origin(),
+		Channels: make([]ChannelState, 0, len(cb.channelQueue)),
+	}
+	for _, id := range cb.channelQueue {
+		ch, ok := cb.channels[id]
+		if !ok {
+			continue
+		}
+		out.Channels = append(out.Channels, ch.State(cb.cfg))
+		out.TotalSize += ch.Size()
+	}
+	return out
+}
+
+func (ch *Channel) State(cfg *rollup.Config) ChannelState {
+	return ChannelState{
+		ID:                      ch.id,
+		OpenBlock:               ch.openBlock,
+		TimeoutBlock:            ch.OpenBlockNumber() + cfg.ChannelTimeout,
+		Size:                    ch.size,
+		Closed:                  ch.closed,
+		Ready:                   ch.IsReady(),
+		FramesCount:             len(ch.inputs),
+		HighestFrameNumber:      ch.highestFrameNumber,
+		EndFrameNumber:          ch.endFrameNumber,
+		HighestL1InclusionBlock: ch.highestL1InclusionBlock,
+	}
+}
+
+func (bq *BatchQueue) State() BatchQueueState {
+	out := BatchQueueState{
+		Origin:   bq.origin,
+		L1Blocks: append(make([]eth.L1BlockRef, 0, len(bq.l1Blocks)), bq.l1Blocks...),
+	}
+	timestamps := make([]uint64, 0, len(bq.batches))
+	for t := range bq.batches {
+		timestamps = append(timestamps, t)
+	}
+	slices.Sort(timestamps)
+	for _, t := range timestamps {
+		for _, b := range bq.batches[t] {
+			s := BatchState{
+				BatchType:        b.Batch.BatchType,
+				Timestamp:        t,
+				L1InclusionBlock: b.L1InclusionBlock,
+			}
+			if b.Batch.BatchType == SingularBatchType {
+				s.ParentHash = b.Batch.SingularBatch.ParentHash
+				s.EpochNum = b.Batch.SingularBatch.EpochNum
+				s.EpochHash = b.Batch.SingularBatch.EpochHash
+				s.TxCount = len(b.Batch.SingularBatch.Transactions)
+			}
+			out.Batches = append(out.Batches, s)
+		}
+	}
+	return out
+}
+
+func (eq *EngineQueue) State() EngineQueueState {
+	out := EngineQueueState{
+		Origin:                eq.origin,
+		FinalizedL1:           eq.finalizedL1,
+		Finalized:             eq.finalized,
+		SafeHead:              eq.safeHead,
+		UnsafeHead:            eq.unsafeHead,
+		EngineSyncTarget:      eq.engineSyncTarget,
+		BuildingOnto:          eq.buildingOnto,
+		BuildingSafe:          eq.buildingSafe,
+		NeedForkchoiceUpdate:  eq.needForkchoiceUpdate,
+		UnsafePayloadsMemSize: eq.unsafePayloads.MemSize(),
+		FinalityData:          append(make([]FinalityData, 0, len(eq.finalityData)), eq.finalityData...),
+	}
+	if eq.safeAttributes != nil {
+		attrs := eq.safeAttributes.attributes
+		out.SafeAttributes = &AttributesState{
+			Parent:       eq.safeAttributes.parent,
+			Timestamp:    uint64(attrs.Timestamp),
+			NoTxPool:     attrs.NoTxPool,
+			TxCount:      len(attrs.Transactions),
+			FeeRecipient: attrs.SuggestedFeeRecipient,
+		}
+		if attrs.GasLimit != nil {
+			out.SafeAttributes.GasLimit = uint64(*attrs.GasLimit)
+		}
+	}
+	for _, p := range eq.unsafePayloads.Payloads() {
+		out.UnsafePayloads = append(out.UnsafePayloads, PayloadState{
+			Hash:       p.BlockHash,
+			Number:     uint64(p.BlockNumber),
+			ParentHash: p.ParentHash,
+			Timestamp:  uint64(p.Timestamp),
+			TxCount:    len(p.Transactions),
+		})
+	}
+	return out
+}
@@ -0,0 +1,258 @@
+package derive
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+func TestL1TraversalState(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	origin := testutils.RandomBlockRef(rng)
+	l1t := &L1Traversal{block: origin, done: true}
+
+	require.Equal(t, L1TraversalState{Origin: origin, Done: true}, l1t.State())
+}
+
+func TestFrameQueueState(t *testing.T) {
+	fq := &FrameQueue{}
+	require.Empty(t, fq.State().Frames)
+
+	a, b := testFrame("a:0:first").ToFrame(), testFrame("b:3:last!").ToFrame()
+	fq.frames = []Frame{a, b}
+	require.Equal(t, FrameQueueState{Frames: []FrameState{
+		{ID: a.ID, FrameNumber: 0, DataLen: len(a.Data), IsLast: false},
+		{ID: b.ID, FrameNumber: 3, DataLen: len(b.Data), IsLast: true},
+	}}, fq.State())
+}
+
+func TestChannelBankState(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	origin := testutils.RandomBlockRef(rng)
+	input := &fakeChannelBankInput{origin: origin}
+	cfg := &rollup.Config{ChannelTimeout: 10}
+	cb := NewChannelBank(testlog.Logger(t, log.LvlCrit), cfg, input, nil, metrics.NoopMetrics)
+
+	ready := []Frame{testFrame("a:0:first").ToFrame(), testFrame("a:1:second!").ToFrame()}
+	open := []Frame{testFrame("b:2:third").ToFrame()}
+	for _, f := range append(ready, open...) {
+		cb.IngestFrame(f)
+	}
+
+	state := cb.State()
+	require.Equal(t, origin, state.Origin)
+	require.Equal(t, []ChannelState{
+		{
+			ID:                      ready[0].ID,
+			OpenBlock:               origin,
+			TimeoutBlock:            origin.Number + 10,
+			Size:                    frameSize(ready[0]) + frameSize(ready[1]),
+			Closed:                  true,
+			Ready:                   true,
+			FramesCount:             2,
+			HighestFrameNumber:      1,
+			EndFrameNumber:          1,
+			HighestL1InclusionBlock: origin,
+		},
+		{
+			ID:                      open[0].ID,
+			OpenBlock:               origin,
+			TimeoutBlock:            origin.Number + 10,
+			Size:                    frameSize(open[0]),
+			Closed:                  false,
+			Ready:                   false,
+			FramesCount:             1,
+			HighestFrameNumber:      2,
+			HighestL1InclusionBlock: origin,
+		},
+	}, state.Channels)
+	require.Equal(t, frameSize(ready[0])+frameSize(ready[1])+frameSize(open[0]), state.TotalSize)
+}
+
+func TestBatchQueueState(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	origin := testutils.RandomBlockRef(rng)
+	l1Blocks := []eth.L1BlockRef{testutils.RandomBlockRef(rng), origin}
+
+	singular := SingularBatch{
+		ParentHash:   testutils.RandomHash(rng),
+		EpochNum:     rollup.Epoch(l1Blocks[0].Number),
+		EpochHash:    l1Blocks[0].Hash,
+		Timestamp:    20,
+		Transactions: []hexutil.Bytes{{0x01}, {0x02}},
+	}
+	first := &BatchWithL1InclusionBlock{L1InclusionBlock: l1Blocks[0], Batch: NewSingularBatchData(singular)}
+	second := &BatchWithL1InclusionBlock{L1InclusionBlock: origin, Batch: NewSingularBatchData(SingularBatch{Timestamp: 20})}
+	span := &BatchWithL1InclusionBlock{L1InclusionBlock: origin, Batch: NewSpanBatchData(RawSpanBatch{})}
+	bq := &BatchQueue{
+		origin:   origin,
+		l1Blocks: l1Blocks,
+		batches: map[uint64][]*BatchWithL1InclusionBlock{
+			20: {first, second},
+			10: {span},
+		},
+	}
+
+	state := bq.State()
+	require.Equal(t, origin, state.Origin)
+	require.Equal(t, l1Blocks, state.L1Blocks)
+	require.Equal(t, []BatchState{
+		{BatchType: SpanBatchType, Timestamp: 10, L1InclusionBlock: origin},
+		{
+			BatchType:        SingularBatchType,
+			Timestamp:        20,
+			L1InclusionBlock: l1Blocks[0],
+			ParentHash:       singular.ParentHash,
+			EpochNum:         singular.EpochNum,
+			EpochHash:        singular.EpochHash,
+			TxCount:          2,
+		},
+		{BatchType: SingularBatchType, Timestamp: 20, L1InclusionBlock: origin},
+	}, state.Batches)
+
+	// the snapshot does not alias the buffered L1 blocks
+	state.L1Blocks[0] = eth.L1BlockRef{}
+	require.Equal(t, l1Blocks[0], bq.l1Blocks[0])
+}
+
+func TestEngineQueueState(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	origin := testutils.RandomBlockRef(rng)
+	safeHead := testutils.RandomL2BlockRef(rng)
+
+	unsafePayloads := NewPayloadsQueue(maxUnsafePayloadsMemory, payloadMemSize)
+	newPayload := func(number uint64, txs int) *eth.ExecutionPayload {
+		return &eth.ExecutionPayload{
+			BlockHash:    testutils.RandomHash(rng),
+			BlockNumber:  eth.Uint64Quantity(number),
+			ParentHash:   testutils.RandomHash(rng),
+			Timestamp:    eth.Uint64Quantity(number * 2),
+			Transactions: make([]eth.Data, txs),
+		}
+	}
+	high, low := newPayload(safeHead.Number+3, 1), newPayload(safeHead.Number+2, 0)
+	require.NoError(t, unsafePayloads.Push(high))
+	require.NoError(t, unsafePayloads.Push(low))
+
+	gasLimit := eth.Uint64Quantity(30_000_000)
+	feeRecipient := testutils.RandomAddress(rng)
+	finalityData := []FinalityData{{L2Block: safeHead, L1Block: origin.ID()}}
+	eq := &EngineQueue{
+		origin:               origin,
+		finalizedL1:          testutils.RandomBlockRef(rng),
+		finalized:            testutils.RandomL2BlockRef(rng),
+		safeHead:             safeHead,
+		unsafeHead:           testutils.RandomL2BlockRef(rng),
+		engineSyncTarget:     testutils.RandomL2BlockRef(rng),
+		buildingOnto:         safeHead,
+		buildingSafe:         true,
+		needForkchoiceUpdate: true,
+		safeAttributes: &attributesWithParent{
+			attributes: &eth.PayloadAttributes{
+				Timestamp:             eth.Uint64Quantity(safeHead.Time + 2),
+				SuggestedFeeRecipient: feeRecipient,
+				Transactions:          []eth.Data{{0x01}},
+				NoTxPool:              true,
+				GasLimit:              &gasLimit,
+			},
+			parent: safeHead,
+		},
+		unsafePayloads: unsafePayloads,
+		finalityData:   finalityData,
+	}
+
+	state := eq.State()
+	require.Equal(t, EngineQueueState{
+		Origin:               origin,
+		FinalizedL1:          eq.finalizedL1,
+		Finalized:            eq.finalized,
+		SafeHead:             safeHead,
+		UnsafeHead:           eq.unsafeHead,
+		EngineSyncTarget:     eq.engineSyncTarget,
+		BuildingOnto:         safeHead,
+		BuildingSafe:         true,
+		NeedForkchoiceUpdate: true,
+		SafeAttributes: &AttributesState{
+			Parent:       safeHead,
+			Timestamp:    safeHead.Time + 2,
+			NoTxPool:     true,
+			TxCount:      1,
+			GasLimit:     30_000_000,
+			FeeRecipient: feeRecipient,
+		},
+		UnsafePayloads: []PayloadState{
+			{Hash: low.BlockHash, Number: uint64(low.BlockNumber), ParentHash: low.ParentHash, Timestamp: uint64(low.Timestamp), TxCount: 0},
+			{Hash: high.BlockHash, Number: uint64(high.BlockNumber), ParentHash: high.ParentHash, Timestamp: uint64(high.Timestamp), TxCount: 1},
+		},
+		UnsafePayloadsMemSize: payloadMemSize(low) + payloadMemSize(high),
+		FinalityData:          finalityData,
+	}, state)
+
+	// the snapshot does not alias the finality data of the engine queue
+	state.FinalityData[0] = FinalityData{}
+	require.Equal(t, safeHead, eq.finalityData[0].L2Block)
+	// and does not consume the queued payloads
+	require.Equal(t, 2, eq.unsafePayloads.Len())
+}
+
+func TestEngineQueueStateNoSafeAttributes(t *testing.T) {
+	eq := &EngineQueue{
+		safeAttributes: &attributesWithParent{attributes: &eth.PayloadAttributes{}},
+		unsafePayloads: NewPayloadsQueue(maxUnsafePayloadsMemory, payloadMemSize),
+	}
+	require.Zero(t, eq.State().SafeAttributes.GasLimit, "gas limit is optional")
+
+	eq.safeAttributes = nil
+	state := eq.State()
+	require.Nil(t, state.SafeAttributes)
+	require.Empty(t, state.UnsafePayloads)
+	require.Zero(t, state.UnsafePayloadsMemSize)
+}
+
+func TestDerivationPipelineState(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	origin := testutils.RandomBlockRef(rng)
+	cfg := &rollup.Config{ChannelTimeout: 10}
+	logger := testlog.Logger(t, log.LvlCrit)
+
+	traversal := &L1Traversal{block: origin}
+	frameQueue := &FrameQueue{frames: []Frame{testFrame("a:0:data").ToFrame()}}
+	channelBank := NewChannelBank(logger, cfg, &fakeChannelBankInput{origin: origin}, nil, metrics.NoopMetrics)
+	batchQueue := &BatchQueue{origin: origin}
+	eng := &EngineQueue{
+		origin:         origin,
+		safeHead:       testutils.RandomL2BlockRef(rng),
+		unsafePayloads: NewPayloadsQueue(maxUnsafePayloadsMemory, payloadMemSize),
+	}
+	dp := &DerivationPipeline{
+		resetting:   1,
+		stages:      []ResettableStage{eng, traversal, frameQueue, channelBank, batchQueue},
+		traversal:   traversal,
+		frameQueue:  frameQueue,
+		channelBank: channelBank,
+		batchQueue:  batchQueue,
+		eng:         eng,
+	}
+
+	require.Equal(t, PipelineState{
+		Resetting:   1,
+		StagesCount: 5,
+		L1Traversal: traversal.State(),
+		FrameQueue:  frameQueue.State(),
+		ChannelBank: channelBank.State(),
+		BatchQueue:  batchQueue.State(),
+		EngineQueue: eng.State(),
+	}, dp.State())
+	require.Len(t, dp.State().FrameQueue.Frames, 1)
+	require.Equal(t, origin, dp.State().ChannelBank.Origin)
+	require.Equal(t, origin, dp.State().EngineQueue.Origin)
+}
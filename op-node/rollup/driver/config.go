@@ -20,4 +20,8 @@ type Config struct {
 	// SequencerMaxSafeLag is the maximum number of L2 blocks for restricting the distance between L2 safe and unsafe.
 	// Disabled if 0.
 	SequencerMaxSafeLag uint64 `json:"sequencer_max_safe_lag"`
+
+	// CheckpointInterval is the number of L1 blocks to traverse between derivation pipeline checkpoints.
+	// Only used if a pipeline checkpointer is configured.
+	CheckpointInterval uint64 `json:"checkpoint_interval"`
 }
@@ -516,6 +516,20 @@ func (s *Driver) BlockRefWithStatus(ctx context.Context, num uint64) (eth.L2Bloc
 	}
 }
 
+// PipelineState blocks the driver event loop and captures the internal state of the derivation pipeline stages.
+// If the event loop is too busy and the context expires, a context error is returned.
+func (s *Driver) PipelineState(ctx context.Context) (*derive.PipelineState, error) {
+	wait := make(chan struct{})
+	select {
+	case s.stateReq <- wait:
+		resp := s.derivation.State()
+		<-wait
+		return &resp, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
 // deferJSONString helps avoid a JSON-encoding performance hit if the snapshot logger does not run
 type deferJSONString struct {
 	x any
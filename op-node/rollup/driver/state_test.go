@@ -0,0 +1,55 @@
+package driver
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+type fakeStateDerivation struct {
+	DerivationPipeline
+	state derive.PipelineState
+	calls int
+}
+
+func (d *fakeStateDerivation) State() derive.PipelineState {
+	d.calls++
+	return d.state
+}
+
+func TestDriverPipelineState(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	derivation := &fakeStateDerivation{state: derive.PipelineState{
+		Resetting:   5,
+		StagesCount: 5,
+		L1Traversal: derive.L1TraversalState{Origin: testutils.RandomBlockRef(rng)},
+	}}
+	s := &Driver{derivation: derivation, stateReq: make(chan chan struct{})}
+
+	// serve a single state request, like the event loop does
+	served := make(chan struct{})
+	go func() {
+		defer close(served)
+		respCh := <-s.stateReq
+		respCh <- struct{}{}
+	}()
+
+	state, err := s.PipelineState(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, derivation.state, *state)
+	<-served
+	require.Equal(t, 1, derivation.calls)
+
+	// the pipeline is not inspected if the event loop does not pick up the request
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	state, err = s.PipelineState(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+	require.Nil(t, state)
+	require.Equal(t, 1, derivation.calls)
+}
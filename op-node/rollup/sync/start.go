@@ -59,10 +59,10 @@ type FindHeadsResult struct {
 	Finalized eth.L2BlockRef
 }
 
-// currentHeads returns the current finalized, safe and unsafe heads of the execution engine.
+// CurrentHeads returns the current finalized, safe and unsafe heads of the execution engine.
 // If nothing has been marked finalized yet, the finalized head defaults to the genesis block.
 // If nothing has been marked safe yet, the safe head defaults to the finalized block.
-func currentHeads(ctx context.Context, cfg *rollup.Config, l2 L2Chain) (*FindHeadsResult, error) {
+func CurrentHeads(ctx context.Context, cfg *rollup.Config, l2 L2Chain) (*FindHeadsResult, error) {
 	finalized, err := l2.L2BlockRefByLabel(ctx, eth.Finalized)
 	if errors.Is(err, ethereum.NotFound) {
 		// default to genesis if we have not finalized anything before.
@@ -105,7 +105,7 @@ func currentHeads(ctx context.Context, cfg *rollup.Config, l2 L2Chain) (*FindHea
 // and the same holds for all its ancestors.
 func FindL2Heads(ctx context.Context, cfg *rollup.Config, l1 L1Chain, l2 L2Chain, lgr log.Logger, syncCfg *Config) (result *FindHeadsResult, err error) {
 	// Fetch current L2 forkchoice state
-	result, err = currentHeads(ctx, cfg, l2)
+	result, err = CurrentHeads(ctx, cfg, l2)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch current L2 forkchoice state: %w", err)
 	}
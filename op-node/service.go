@@ -21,6 +21,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/node"
 	p2pcli "github.com/ethereum-optimism/optimism/op-node/p2p/cli"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/driver"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
 )
@@ -43,6 +44,8 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*node.Config, error) {
 
 	configPersistence := NewConfigPersistence(ctx)
 
+	pipelineCheckpointer := NewPipelineCheckpointer(ctx)
+
 	driverConfig := NewDriverConfig(ctx)
 
 	p2pSignerSetup, err := p2pcli.LoadSignerSetup(ctx)
@@ -64,6 +67,11 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*node.Config, error) {
 
 	l2SyncEndpoint := NewL2SyncEndpointConfig(ctx)
 
+	l2ShadowEndpoint, err := NewL2ShadowEndpointConfig(ctx, log)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load l2 shadow endpoint info: %w", err)
+	}
+
 	syncConfig := NewSyncConfig(ctx)
 
 	haltOption := ctx.String(flags.RollupHalt.Name)
@@ -102,10 +110,15 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*node.Config, error) {
 			URL:     ctx.String(flags.HeartbeatURLFlag.Name),
 		},
 		ConfigPersistence: configPersistence,
+		Checkpointer:      pipelineCheckpointer,
 		Sync:              *syncConfig,
 		RollupHalt:        haltOption,
 	}
 
+	if l2ShadowEndpoint != nil {
+		cfg.L2Shadow = l2ShadowEndpoint
+	}
+
 	if err := cfg.LoadPersisted(log); err != nil {
 		return nil, fmt.Errorf("failed to load driver config: %w", err)
 	}
@@ -129,40 +142,69 @@ func NewL1EndpointConfig(ctx *cli.Context) *node.L1EndpointConfig {
 
 func NewL2EndpointConfig(ctx *cli.Context, log log.Logger) (*node.L2EndpointConfig, error) {
 	l2Addr := ctx.String(flags.L2EngineAddr.Name)
-	fileName := ctx.String(flags.L2EngineJWTSecret.Name)
+	secret, err := loadJWTSecret(log, ctx.String(flags.L2EngineJWTSecret.Name))
+	if err != nil {
+		return nil, err
+	}
+
+	return &node.L2EndpointConfig{
+		L2EngineAddr:      l2Addr,
+		L2EngineJWTSecret: secret,
+	}, nil
+}
+
+// NewL2ShadowEndpointConfig returns a pointer to a L2EndpointConfig of the secondary engine if the
+// flag is set, otherwise nil.
+func NewL2ShadowEndpointConfig(ctx *cli.Context, log log.Logger) (*node.L2EndpointConfig, error) {
+	l2Addr := ctx.String(flags.L2ShadowEngineAddr.Name)
+	if l2Addr == "" {
+		return nil, nil
+	}
+	fileName := ctx.String(flags.L2ShadowEngineJWTSecret.Name)
+	if strings.TrimSpace(fileName) == "" {
+		fileName = ctx.String(flags.L2EngineJWTSecret.Name)
+	}
+	secret, err := loadJWTSecret(log, fileName)
+	if err != nil {
+		return nil, err
+	}
+	return &node.L2EndpointConfig{
+		L2EngineAddr:      l2Addr,
+		L2EngineJWTSecret: secret,
+	}, nil
+}
+
+// loadJWTSecret reads the JWT secret from the given file, or generates and writes a new secret if it cannot be read.
+func loadJWTSecret(log log.Logger, fileName string) ([32]byte, error) {
 	var secret [32]byte
 	fileName = strings.TrimSpace(fileName)
 	if fileName == "" {
-		return nil, fmt.Errorf("file-name of jwt secret is empty")
+		return secret, fmt.Errorf("file-name of jwt secret is empty")
 	}
 	if data, err := os.ReadFile(fileName); err == nil {
 		jwtSecret := common.FromHex(strings.TrimSpace(string(data)))
 		if len(jwtSecret) != 32 {
-			return nil, fmt.Errorf("invalid jwt secret in path %s, not 32 hex-formatted bytes", fileName)
+			return secret, fmt.Errorf("invalid jwt secret in path %s, not 32 hex-formatted bytes", fileName)
 		}
 		copy(secret[:], jwtSecret)
 	} else {
 		log.Warn("Failed to read JWT secret from file, generating a new one now. Configure L2 geth with --authrpc.jwt-secret=" + fmt.Sprintf("%q", fileName))
 		if _, err := io.ReadFull(rand.Reader, secret[:]); err != nil {
-			return nil, fmt.Errorf("failed to generate jwt secret: %w", err)
+			return secret, fmt.Errorf("failed to generate jwt secret: %w", err)
 		}
 		if err := os.WriteFile(fileName, []byte(hexutil.Encode(secret[:])), 0600); err != nil {
-			return nil, err
+			return secret, err
 		}
 	}
-
-	return &node.L2EndpointConfig{
-		L2EngineAddr:      l2Addr,
-		L2EngineJWTSecret: secret,
-	}, nil
+	return secret, nil
 }
 
-// NewL2SyncEndpointConfig returns a pointer to a L2SyncEndpointConfig if the
-// flag is set, otherwise nil.
+// NewL2SyncEndpointConfig returns a pointer to a L2SyncEndpointConfig,
+// with no endpoints if the flag is not set.
 func NewL2SyncEndpointConfig(ctx *cli.Context) *node.L2SyncEndpointConfig {
 	return &node.L2SyncEndpointConfig{
-		L2NodeAddr: ctx.String(flags.BackupL2UnsafeSyncRPC.Name),
-		TrustRPC:   ctx.Bool(flags.BackupL2UnsafeSyncRPCTrustRPC.Name),
+		L2NodeAddrs: ctx.StringSlice(flags.BackupL2UnsafeSyncRPC.Name),
+		TrustRPC:    ctx.Bool(flags.BackupL2UnsafeSyncRPCTrustRPC.Name),
 	}
 }
 
@@ -174,6 +216,14 @@ func NewConfigPersistence(ctx *cli.Context) node.ConfigPersistence {
 	return node.NewConfigPersistence(stateFile)
 }
 
+func NewPipelineCheckpointer(ctx *cli.Context) derive.Checkpointer {
+	checkpointFile := ctx.String(flags.PipelineCheckpointFile.Name)
+	if checkpointFile == "" {
+		return nil
+	}
+	return node.NewFileCheckpointer(checkpointFile)
+}
+
 func NewDriverConfig(ctx *cli.Context) *driver.Config {
 	return &driver.Config{
 		VerifierConfDepth:   ctx.Uint64(flags.VerifierL1Confs.Name),
@@ -181,6 +231,7 @@ func NewDriverConfig(ctx *cli.Context) *driver.Config {
 		SequencerEnabled:    ctx.Bool(flags.SequencerEnabledFlag.Name),
 		SequencerStopped:    ctx.Bool(flags.SequencerStoppedFlag.Name),
 		SequencerMaxSafeLag: ctx.Uint64(flags.SequencerMaxSafeLagFlag.Name),
+		CheckpointInterval:  ctx.Uint64(flags.PipelineCheckpointInterval.Name),
 	}
 }
 
@@ -0,0 +1,79 @@
+package eth
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// SequencerKey is a key that is authorised to sign unsafe blocks within a range of L2 block heights.
+type SequencerKey struct {
+	Address common.Address `json:"address"`
+	// Activation is the first L2 block number that the key may sign.
+	Activation uint64 `json:"activation"`
+	// Expiry is the first L2 block number that the key may no longer sign. Zero if the key does not expire.
+	Expiry uint64 `json:"expiry"`
+}
+
+func (k SequencerKey) String() string {
+	if k.Expiry == 0 {
+		return fmt.Sprintf("%s[%d:]", k.Address, k.Activation)
+	}
+	return fmt.Sprintf("%s[%d:%d]", k.Address, k.Activation, k.Expiry)
+}
+
+// ValidAt returns whether the key may sign the L2 block with the given number.
+func (k SequencerKey) ValidAt(num uint64) bool {
+	return num >= k.Activation && (k.Expiry == 0 || num < k.Expiry)
+}
+
+// DecodeSequencerKey decodes a sequencer key from a packed 32-byte storage value:
+// the expiry as uint48 in the first 6 bytes, the activation as uint48 in the next 6 bytes,
+// and the address in the last 20 bytes.
+func DecodeSequencerKey(v common.Hash) SequencerKey {
+	var expiry, activation [8]byte
+	copy(expiry[2:], v[0:6])
+	copy(activation[2:], v[6:12])
+	return SequencerKey{
+		Address:    common.BytesToAddress(v[12:]),
+		Activation: binary.BigEndian.Uint64(activation[:]),
+		Expiry:     binary.BigEndian.Uint64(expiry[:]),
+	}
+}
+
+// Encode packs the key into a 32-byte storage value, the inverse of DecodeSequencerKey.
+func (k SequencerKey) Encode() (out common.Hash) {
+	var expiry, activation [8]byte
+	binary.BigEndian.PutUint64(expiry[:], k.Expiry)
+	binary.BigEndian.PutUint64(activation[:], k.Activation)
+	copy(out[0:6], expiry[2:])
+	copy(out[6:12], activation[2:])
+	copy(out[12:], k.Address[:])
+	return out
+}
+
+// SequencerKeys is the set of keys that are authorised to sign unsafe blocks.
+// Keys may have overlapping validity ranges, so the sequencer can rotate keys without gossip downtime:
+// the new key is activated before the old key expires.
+type SequencerKeys []SequencerKey
+
+// Authorized returns whether the address may sign the L2 block with the given number.
+func (ks SequencerKeys) Authorized(addr common.Address, num uint64) bool {
+	for _, k := range ks {
+		if k.Address == addr && k.ValidAt(num) {
+			return true
+		}
+	}
+	return false
+}
+
+// Contains returns whether the address is part of the key set, regardless of its validity range.
+func (ks SequencerKeys) Contains(addr common.Address) bool {
+	for _, k := range ks {
+		if k.Address == addr {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,31 @@
+package eth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestSequencerKeyRoundTrip(t *testing.T) {
+	key := SequencerKey{
+		Address:    common.HexToAddress("0x715b7219D986641DF9eFd9C7Ef01218D528e19ec"),
+		Activation: 123456,
+		Expiry:     (1 << 48) - 1,
+	}
+	v := key.Encode()
+	require.Equal(t, common.HexToHash("0xffffffffffff00000001e240715b7219d986641df9efd9c7ef01218d528e19ec"), v)
+	require.Equal(t, key, DecodeSequencerKey(v))
+}
+
+func TestSequencerKeyValidAt(t *testing.T) {
+	key := SequencerKey{Activation: 10, Expiry: 20}
+	require.False(t, key.ValidAt(9))
+	require.True(t, key.ValidAt(10))
+	require.True(t, key.ValidAt(19))
+	require.False(t, key.ValidAt(20))
+
+	noExpiry := SequencerKey{Activation: 10}
+	require.True(t, noExpiry.ValidAt(1<<63))
+}
@@ -36,3 +36,9 @@ func (w *WrappedResponseWriter) WriteHeader(statusCode int) {
 	w.StatusCode = statusCode
 	w.w.WriteHeader(statusCode)
 }
+
+// Unwrap returns the underlying writer, allowing an http.ResponseController to access
+// optional capabilities such as flushing & write deadlines for streamed responses.
+func (w *WrappedResponseWriter) Unwrap() http.ResponseWriter {
+	return w.w
+}
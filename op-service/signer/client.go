@@ -17,6 +17,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
 )
 
 type SignerClient struct {
@@ -107,3 +108,12 @@ func (s *SignerClient) SignTransaction(ctx context.Context, chainId *big.Int, fr
 
 	return signed, nil
 }
+
+// SignTypedData signs EIP-712 typed data with the account of the signer, and returns the 65 byte signature.
+func (s *SignerClient) SignTypedData(ctx context.Context, from common.Address, data apitypes.TypedData) ([]byte, error) {
+	var result hexutil.Bytes
+	if err := s.client.CallContext(ctx, &result, "eth_signTypedData_v4", from, data); err != nil {
+		return nil, fmt.Errorf("eth_signTypedData_v4 failed: %w", err)
+	}
+	return result, nil
+}
@@ -1,11 +1,20 @@
 package testutils
 
-import "github.com/ethereum/go-ethereum/common"
+import (
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
 
 type MockRuntimeConfig struct {
 	P2PSeqAddress common.Address
+	P2PSeqKeys    eth.SequencerKeys
 }
 
 func (m *MockRuntimeConfig) P2PSequencerAddress() common.Address {
 	return m.P2PSeqAddress
 }
+
+func (m *MockRuntimeConfig) P2PSequencerKeys() eth.SequencerKeys {
+	return m.P2PSeqKeys
+}
@@ -65,7 +65,31 @@ type ProviderConfig struct {
 	ReceiptRetrievalInterval     TOMLDuration `toml:"receipt_retrieval_interval"`
 	ReceiptRetrievalTimeout      TOMLDuration `toml:"receipt_retrieval_timeout"`
 
+	// op-node RPC used to track safe and finalized inclusion of probe transactions, optional
+	RollupURL            string       `toml:"rollup_url"`
+	SyncStatusInterval   TOMLDuration `toml:"sync_status_interval"`
+	SafeInclusionTimeout TOMLDuration `toml:"safe_inclusion_timeout"`
+	FinalizationTimeout  TOMLDuration `toml:"finalization_timeout"`
+
 	Wallet string `toml:"wallet"`
+	// Wallets to send probe transactions from concurrently, in addition to Wallet
+	Wallets []string `toml:"wallets"`
+	// Number of concurrent probe streams per wallet, defaults to 1
+	ProbeStreams int `toml:"probe_streams"`
+}
+
+// WalletNames returns the names of all wallets the provider sends probe transactions from
+func (p *ProviderConfig) WalletNames() []string {
+	names := make([]string, 0, len(p.Wallets)+1)
+	if p.Wallet != "" {
+		names = append(names, p.Wallet)
+	}
+	for _, name := range p.Wallets {
+		if name != p.Wallet {
+			names = append(names, name)
+		}
+	}
+	return names
 }
 
 func New(file string) (*Config, error) {
@@ -155,11 +179,30 @@ func (c *Config) Validate() error {
 		if provider.ReceiptRetrievalTimeout == 0 {
 			return errors.Errorf("provider [%s] receipt_retrieval_timeout is missing", name)
 		}
-		if provider.Wallet == "" {
+		if len(provider.WalletNames()) == 0 {
 			return errors.Errorf("provider [%s] wallet is missing", name)
 		}
-		if _, ok := c.Wallets[provider.Wallet]; !ok {
-			return errors.Errorf("provider [%s] has an invalid wallet [%s]", name, provider.Wallet)
+		for _, wallet := range provider.WalletNames() {
+			if _, ok := c.Wallets[wallet]; !ok {
+				return errors.Errorf("provider [%s] has an invalid wallet [%s]", name, wallet)
+			}
+		}
+		if provider.ProbeStreams < 0 {
+			return errors.Errorf("provider [%s] probe_streams must not be negative", name)
+		}
+		if provider.ProbeStreams == 0 {
+			provider.ProbeStreams = 1
+		}
+		if provider.RollupURL != "" {
+			if provider.SyncStatusInterval == 0 {
+				return errors.Errorf("provider [%s] sync_status_interval is missing", name)
+			}
+			if provider.SafeInclusionTimeout == 0 {
+				return errors.Errorf("provider [%s] safe_inclusion_timeout is missing", name)
+			}
+			if provider.FinalizationTimeout == 0 {
+				return errors.Errorf("provider [%s] finalization_timeout is missing", name)
+			}
 		}
 	}
 
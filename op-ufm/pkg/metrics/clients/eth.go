@@ -113,6 +113,10 @@ func (i *InstrumentedEthClient) HeaderByNumber(ctx context.Context, number *big.
 	return header, err
 }
 
+func (i *InstrumentedEthClient) Close() {
+	i.c.Close()
+}
+
 func (i *InstrumentedEthClient) ignorableErrors(err error) bool {
 	msg := err.Error()
 	// we dont use errors.Is because eth client actually uses errors.New,
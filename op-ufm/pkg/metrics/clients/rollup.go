@@ -0,0 +1,55 @@
+package clients
+
+import (
+	"context"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-ufm/pkg/metrics"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// BlockRef is the subset of an op-node L2 block reference used to track transaction inclusion
+type BlockRef struct {
+	Hash   common.Hash `json:"hash"`
+	Number uint64      `json:"number"`
+}
+
+// SyncStatus is the subset of the op-node sync status used to track transaction inclusion
+type SyncStatus struct {
+	UnsafeL2    BlockRef `json:"unsafe_l2"`
+	SafeL2      BlockRef `json:"safe_l2"`
+	FinalizedL2 BlockRef `json:"finalized_l2"`
+}
+
+type InstrumentedRollupClient struct {
+	c            *rpc.Client
+	providerName string
+}
+
+func DialRollup(providerName string, url string) (*InstrumentedRollupClient, error) {
+	start := time.Now()
+	c, err := rpc.Dial(url)
+	if err != nil {
+		metrics.RecordErrorDetails(providerName, "rollupclient.Dial", err)
+		return nil, err
+	}
+	metrics.RecordRPCLatency(providerName, "rollupclient", "Dial", time.Since(start))
+	return &InstrumentedRollupClient{c: c, providerName: providerName}, nil
+}
+
+func (i *InstrumentedRollupClient) SyncStatus(ctx context.Context) (*SyncStatus, error) {
+	start := time.Now()
+	var status SyncStatus
+	err := i.c.CallContext(ctx, &status, "optimism_syncStatus")
+	if err != nil {
+		metrics.RecordErrorDetails(i.providerName, "rollupclient.SyncStatus", err)
+		return nil, err
+	}
+	metrics.RecordRPCLatency(i.providerName, "rollupclient", "SyncStatus", time.Since(start))
+	return &status, nil
+}
+
+func (i *InstrumentedRollupClient) Close() {
+	i.c.Close()
+}
@@ -15,6 +15,14 @@ const (
 	MetricsNamespace = "ufm"
 )
 
+// Stages of the probe transaction lifecycle
+const (
+	StageMempool   = "mempool"
+	StageUnsafe    = "unsafe"
+	StageSafe      = "safe"
+	StageFinalized = "finalized"
+)
+
 var (
 	Debug bool
 
@@ -78,6 +86,33 @@ var (
 	}, []string{
 		"network",
 	})
+
+	probeStageLatency = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: MetricsNamespace,
+		Name:      "probe_stage_latency_seconds",
+		Help:      "Latency from submission until a probe transaction reaches a lifecycle stage, per provider and stage",
+		Buckets:   []float64{.1, .25, .5, 1, 2, 4, 8, 15, 30, 60, 120, 300, 600, 900, 1200, 1800, 3600},
+	}, []string{
+		"provider",
+		"stage",
+	})
+
+	probeStageTimeouts = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: MetricsNamespace,
+		Name:      "probe_stage_timeouts_total",
+		Help:      "Count of probe transactions that did not reach a lifecycle stage in time, per provider and stage",
+	}, []string{
+		"provider",
+		"stage",
+	})
+
+	probesInFlight = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: MetricsNamespace,
+		Name:      "probes_inflight",
+		Help:      "Probe transactions of which the lifecycle is being tracked, per provider",
+	}, []string{
+		"provider",
+	})
 )
 
 var nonAlphanumericRegex = regexp.MustCompile(`[^a-zA-Z ]+`)
@@ -164,3 +199,34 @@ func RecordTransactionsInFlight(network string, count int) {
 	}
 	networkTransactionsInFlight.WithLabelValues(network).Set(float64(count))
 }
+
+func RecordProbeStageLatency(provider string, stage string, latency time.Duration) {
+	if Debug {
+		log.Debug("metric observe",
+			"m", "probe_stage_latency_seconds",
+			"provider", provider,
+			"stage", stage,
+			"latency", latency)
+	}
+	probeStageLatency.WithLabelValues(provider, stage).Observe(latency.Seconds())
+}
+
+func RecordProbeStageTimeout(provider string, stage string) {
+	if Debug {
+		log.Debug("metric inc",
+			"m", "probe_stage_timeouts_total",
+			"provider", provider,
+			"stage", stage)
+	}
+	probeStageTimeouts.WithLabelValues(provider, stage).Inc()
+}
+
+func RecordProbesInFlight(provider string, delta int) {
+	if Debug {
+		log.Debug("metric add",
+			"m", "probes_inflight",
+			"provider", provider,
+			"delta", delta)
+	}
+	probesInFlight.WithLabelValues(provider).Add(float64(delta))
+}
@@ -0,0 +1,49 @@
+package provider
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ethereum-optimism/optimism/op-ufm/pkg/config"
+	iclients "github.com/ethereum-optimism/optimism/op-ufm/pkg/metrics/clients"
+)
+
+// Wallet is used to send probe transactions.
+// It is shared by all providers and probe streams configured to use it.
+type Wallet struct {
+	Name   string
+	Config *config.WalletConfig
+	Nonces *NonceManager
+}
+
+// NonceManager hands out the nonces of a wallet to concurrent probe streams
+type NonceManager struct {
+	m      sync.Mutex
+	synced bool
+	next   uint64
+}
+
+// Next returns the next nonce to use, fetching the pending nonce of the wallet from the given client if not synced
+func (n *NonceManager) Next(ctx context.Context, client *iclients.InstrumentedEthClient, address string) (uint64, error) {
+	n.m.Lock()
+	defer n.m.Unlock()
+	if !n.synced {
+		nonce, err := client.PendingNonceAt(ctx, address)
+		if err != nil {
+			return 0, err
+		}
+		n.next = nonce
+		n.synced = true
+	}
+	nonce := n.next
+	n.next++
+	return nonce, nil
+}
+
+// Reset makes the next call to Next fetch the pending nonce again,
+// e.g. after a transaction failed to send and left a nonce gap, or the nonce turned out to be used already
+func (n *NonceManager) Reset() {
+	n.m.Lock()
+	defer n.m.Unlock()
+	n.synced = false
+}
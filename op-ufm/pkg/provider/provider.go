@@ -2,6 +2,7 @@ package provider
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"github.com/ethereum-optimism/optimism/op-ufm/pkg/config"
@@ -11,21 +12,23 @@ type Provider struct {
 	name         string
 	config       *config.ProviderConfig
 	signerConfig *config.SignerServiceConfig
-	walletConfig *config.WalletConfig
+	wallets      []*Wallet
 	txPool       *NetworkTransactionPool
 
 	cancelFunc context.CancelFunc
+	// probes tracks the goroutines that follow the lifecycle of sent probe transactions
+	probes sync.WaitGroup
 }
 
 func New(name string, cfg *config.ProviderConfig,
 	signerConfig *config.SignerServiceConfig,
-	walletConfig *config.WalletConfig,
+	wallets []*Wallet,
 	txPool *NetworkTransactionPool) *Provider {
 	p := &Provider{
 		name:         name,
 		config:       cfg,
 		signerConfig: signerConfig,
-		walletConfig: walletConfig,
+		wallets:      wallets,
 		txPool:       txPool,
 	}
 	return p
@@ -37,7 +40,15 @@ func (p *Provider) Start(ctx context.Context) {
 
 	schedule(providerCtx, time.Duration(p.config.ReadInterval), p.Heartbeat)
 	if !p.config.ReadOnly {
-		schedule(providerCtx, time.Duration(p.config.SendInterval), p.RoundTrip)
+		// run concurrent probe streams per wallet, sharing the nonce manager of the wallet
+		for _, wallet := range p.wallets {
+			w := wallet
+			for i := 0; i < p.config.ProbeStreams; i++ {
+				schedule(providerCtx, time.Duration(p.config.SendInterval), func(ctx context.Context) {
+					p.RoundTrip(ctx, w)
+				})
+			}
+		}
 	}
 }
 
@@ -45,6 +56,7 @@ func (p *Provider) Shutdown() {
 	if p.cancelFunc != nil {
 		p.cancelFunc()
 	}
+	p.probes.Wait()
 }
 
 func (p *Provider) Name() string {
@@ -20,10 +20,21 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 )
 
-// RoundTrip send a new transaction to measure round trip latency
-func (p *Provider) RoundTrip(ctx context.Context) {
+// probe is a transaction sent to measure its lifecycle latencies
+type probe struct {
+	hash  common.Hash
+	nonce uint64
+	// used for actual round trip time (disregard retry time)
+	startedAt time.Time
+}
+
+// RoundTrip send a new transaction from the given wallet to measure round trip latency.
+// The inclusion of the transaction is tracked asynchronously, until finalization if a rollup node is configured,
+// so that the probe stream can send the next transaction while earlier ones are still tracked.
+func (p *Provider) RoundTrip(ctx context.Context, wallet *Wallet) {
 	log.Debug("RoundTrip",
-		"provider", p.name)
+		"provider", p.name,
+		"wallet", wallet.Name)
 
 	client, err := iclients.Dial(p.name, p.config.URL)
 	if err != nil {
@@ -34,9 +45,114 @@ func (p *Provider) RoundTrip(ctx context.Context) {
 		return
 	}
 
-	p.txPool.ExclusiveSend.Lock()
-	defer p.txPool.ExclusiveSend.Unlock()
+	pr, ok := p.send(ctx, client, wallet)
+	if !ok {
+		client.Close()
+		return
+	}
+	metrics.RecordProbesInFlight(p.name, 1)
+	p.probes.Add(1)
+	go func() {
+		defer p.probes.Done()
+		defer metrics.RecordProbesInFlight(p.name, -1)
+		defer client.Close()
+		p.track(ctx, client, pr)
+	}()
+}
+
+// track waits for the receipt of the probe transaction,
+// and then for its block to become safe and finalized if a rollup node is configured
+func (p *Provider) track(ctx context.Context, client *iclients.InstrumentedEthClient, pr *probe) {
+	receipt, ok := p.awaitReceipt(ctx, client, pr)
+	if !ok {
+		return
+	}
+
+	roundTripLatency := time.Since(pr.startedAt)
+
+	metrics.RecordRoundTripLatency(p.name, roundTripLatency)
+	metrics.RecordProbeStageLatency(p.name, metrics.StageUnsafe, roundTripLatency)
+	metrics.RecordGasUsed(p.name, receipt.GasUsed)
+
+	log.Info("got transaction receipt",
+		"hash", pr.hash.Hex(),
+		"nonce", pr.nonce,
+		"roundTripLatency", roundTripLatency,
+		"provider", p.name,
+		"blockNumber", receipt.BlockNumber,
+		"blockHash", receipt.BlockHash,
+		"gasUsed", receipt.GasUsed)
+
+	if p.config.RollupURL == "" {
+		return
+	}
+	rollupClient, err := iclients.DialRollup(p.name, p.config.RollupURL)
+	if err != nil {
+		log.Error("cant dial to rollup node",
+			"provider", p.name,
+			"url", p.config.RollupURL,
+			"err", err)
+		return
+	}
+	defer rollupClient.Close()
+
+	receipt, ok = p.awaitStage(ctx, client, rollupClient, pr, receipt, metrics.StageSafe,
+		time.Duration(p.config.SafeInclusionTimeout),
+		func(status *iclients.SyncStatus) iclients.BlockRef { return status.SafeL2 })
+	if !ok {
+		return
+	}
+	p.awaitStage(ctx, client, rollupClient, pr, receipt, metrics.StageFinalized,
+		time.Duration(p.config.FinalizationTimeout),
+		func(status *iclients.SyncStatus) iclients.BlockRef { return status.FinalizedL2 })
+}
+
+// awaitReceipt polls for the receipt of the probe transaction, until the receipt retrieval timeout
+func (p *Provider) awaitReceipt(ctx context.Context, client *iclients.InstrumentedEthClient, pr *probe) (*types.Receipt, bool) {
+	attempt := 0
+	for {
+		if time.Since(pr.startedAt) >= time.Duration(p.config.ReceiptRetrievalTimeout) {
+			log.Error("receipt retrieval timed out",
+				"provider", p.name,
+				"hash", pr.hash,
+				"nonce", pr.nonce,
+				"elapsed", time.Since(pr.startedAt))
+			metrics.RecordError(p.name, "receipt.timeout")
+			metrics.RecordProbeStageTimeout(p.name, metrics.StageUnsafe)
+			return nil, false
+		}
+		select {
+		case <-time.After(time.Duration(p.config.ReceiptRetrievalInterval)):
+		case <-ctx.Done():
+			return nil, false
+		}
+		if attempt%10 == 0 {
+			log.Debug("checking for receipt...",
+				"provider", p.name,
+				"hash", pr.hash,
+				"nonce", pr.nonce,
+				"attempt", attempt,
+				"elapsed", time.Since(pr.startedAt))
+		}
+		receipt, err := client.TransactionReceipt(ctx, pr.hash)
+		if err == nil {
+			return receipt, true
+		}
+		if !errors.Is(err, ethereum.NotFound) {
+			log.Error("cant get receipt for transaction",
+				"provider", p.name,
+				"hash", pr.hash.Hex(),
+				"nonce", pr.nonce,
+				"err", err)
+			return nil, false
+		}
+		attempt++
+	}
+}
 
+// send creates, signs and sends a new transaction from the given wallet, retrying on nonce conflicts.
+// Only the nonce assignment is serialized per network, see nextNonce.
+func (p *Provider) send(ctx context.Context, client *iclients.InstrumentedEthClient, wallet *Wallet) (*probe, bool) {
 	txHash := common.Hash{}
 	attempt := 0
 	nonce := uint64(0)
@@ -46,40 +162,42 @@ func (p *Provider) RoundTrip(ctx context.Context) {
 	// used for actual round trip time (disregard retry time)
 	var roundTripStartedAt time.Time
 	for {
-
-		// sleep until we get a clear to send
-		for {
-			coolDown := time.Duration(p.config.SendTransactionCoolDown) - time.Since(p.txPool.LastSend)
-			if coolDown > 0 {
-				time.Sleep(coolDown)
-			} else {
-				break
-			}
+		var err error
+		nonce, err = p.nextNonce(ctx, client, wallet)
+		if err != nil {
+			log.Error("cant get nonce",
+				"provider", p.name,
+				"wallet", wallet.Name,
+				"err", err)
+			return nil, false
 		}
 
-		tx, err := p.createTx(ctx, client, nonce)
-		nonce = tx.Nonce()
+		tx, err := p.createTx(ctx, client, wallet, nonce)
 		if err != nil {
 			log.Error("cant create tx",
 				"provider", p.name,
 				"nonce", nonce,
 				"err", err)
-			return
+			wallet.Nonces.Reset()
+			return nil, false
 		}
 
-		signedTx, err := p.sign(ctx, tx)
+		signedTx, err := p.sign(ctx, wallet, tx)
 		if err != nil {
 			log.Error("cant sign tx",
 				"provider", p.name,
 				"tx", tx,
 				"err", err)
-			return
+			wallet.Nonces.Reset()
+			return nil, false
 		}
 		txHash = signedTx.Hash()
 
 		roundTripStartedAt = time.Now()
 		err = client.SendTransaction(ctx, signedTx)
 		if err != nil {
+			// the nonce is either used already or left unused, either way fetch the pending nonce again
+			wallet.Nonces.Reset()
 			if err.Error() == txpool.ErrAlreadyKnown.Error() ||
 				err.Error() == txpool.ErrReplaceUnderpriced.Error() ||
 				err.Error() == core.ErrNonceTooLow.Error() {
@@ -97,15 +215,15 @@ func (p *Provider) RoundTrip(ctx context.Context) {
 						"elapsed", time.Since(firstAttemptAt),
 						"attempt", attempt)
 					metrics.RecordErrorDetails(p.name, "send.timeout", err)
-					return
+					metrics.RecordProbeStageTimeout(p.name, metrics.StageMempool)
+					return nil, false
 				}
 
-				log.Warn("tx already known, incrementing nonce and trying again",
+				log.Warn("tx already known, refreshing nonce and trying again",
 					"provider", p.name,
 					"nonce", nonce)
 				time.Sleep(time.Duration(p.config.SendTransactionRetryInterval))
 
-				nonce++
 				attempt++
 				if attempt%10 == 0 {
 					log.Debug("retrying send transaction...",
@@ -120,15 +238,17 @@ func (p *Provider) RoundTrip(ctx context.Context) {
 					"nonce", nonce,
 					"err", err)
 				metrics.RecordErrorDetails(p.name, "ethclient.SendTransaction", err)
-				return
+				return nil, false
 			}
 		} else {
 			break
 		}
 	}
 
+	metrics.RecordProbeStageLatency(p.name, metrics.StageMempool, time.Since(roundTripStartedAt))
 	log.Info("transaction sent",
 		"provider", p.name,
+		"wallet", wallet.Name,
 		"hash", txHash.Hex(),
 		"nonce", nonce)
 
@@ -141,70 +261,111 @@ func (p *Provider) RoundTrip(ctx context.Context) {
 		SentAt:         sentAt,
 		SeenBy:         make(map[string]time.Time),
 	}
-	p.txPool.LastSend = sentAt
 	p.txPool.M.Unlock()
 
-	var receipt *types.Receipt
-	attempt = 0
-	for receipt == nil {
-		if time.Since(sentAt) >= time.Duration(p.config.ReceiptRetrievalTimeout) {
-			log.Error("receipt retrieval timed out",
-				"provider", p.name,
-				"hash", txHash,
-				"nonce", nonce,
-				"elapsed", time.Since(sentAt))
-			metrics.RecordErrorDetails(p.name, "receipt.timeout", err)
-			return
+	return &probe{hash: txHash, nonce: nonce, startedAt: roundTripStartedAt}, true
+}
+
+// nextNonce assigns the next nonce of the wallet, once the send cool down of the network has passed.
+// Nonce assignment is serialized per network, to space sends by the cool down,
+// but creating, signing and sending the transaction is not: a slow provider does not hold up other probe streams.
+func (p *Provider) nextNonce(ctx context.Context, client *iclients.InstrumentedEthClient, wallet *Wallet) (uint64, error) {
+	p.txPool.ExclusiveSend.Lock()
+	defer p.txPool.ExclusiveSend.Unlock()
+
+	// sleep until we get a clear to send
+	if coolDown := time.Duration(p.config.SendTransactionCoolDown) - time.Since(p.txPool.LastSend); coolDown > 0 {
+		select {
+		case <-time.After(coolDown):
+		case <-ctx.Done():
+			return 0, ctx.Err()
 		}
-		time.Sleep(time.Duration(p.config.ReceiptRetrievalInterval))
-		if attempt%10 == 0 {
-			log.Debug("checking for receipt...",
+	}
+
+	nonce, err := wallet.Nonces.Next(ctx, client, wallet.Config.Address)
+	if err != nil {
+		return 0, err
+	}
+	p.txPool.LastSend = time.Now()
+	return nonce, nil
+}
+
+// awaitStage polls the sync status of the rollup node until the given L2 head includes the block of the probe transaction.
+// The receipt is fetched again once the block is included, to detect the transaction being reorged into another block.
+func (p *Provider) awaitStage(ctx context.Context,
+	client *iclients.InstrumentedEthClient,
+	rollupClient *iclients.InstrumentedRollupClient,
+	pr *probe,
+	receipt *types.Receipt,
+	stage string,
+	timeout time.Duration,
+	head func(status *iclients.SyncStatus) iclients.BlockRef) (*types.Receipt, bool) {
+	for {
+		if time.Since(pr.startedAt) >= timeout {
+			log.Error("probe transaction stage timed out",
 				"provider", p.name,
-				"hash", txHash,
-				"nonce", nonce,
-				"attempt", attempt,
-				"elapsed", time.Since(sentAt))
+				"stage", stage,
+				"hash", pr.hash,
+				"blockNumber", receipt.BlockNumber,
+				"elapsed", time.Since(pr.startedAt))
+			metrics.RecordProbeStageTimeout(p.name, stage)
+			return nil, false
 		}
-		receipt, err = client.TransactionReceipt(ctx, txHash)
-		if err != nil && !errors.Is(err, ethereum.NotFound) {
-			log.Error("cant get receipt for transaction",
+		select {
+		case <-time.After(time.Duration(p.config.SyncStatusInterval)):
+		case <-ctx.Done():
+			return nil, false
+		}
+
+		status, err := rollupClient.SyncStatus(ctx)
+		if err != nil {
+			log.Error("cant get sync status",
 				"provider", p.name,
-				"hash", txHash.Hex(),
-				"nonce", nonce,
+				"url", p.config.RollupURL,
 				"err", err)
-			return
+			continue
+		}
+		if head(status).Number < receipt.BlockNumber.Uint64() {
+			continue
 		}
-		attempt++
-	}
-
-	roundTripLatency := time.Since(roundTripStartedAt)
-
-	metrics.RecordRoundTripLatency(p.name, roundTripLatency)
-	metrics.RecordGasUsed(p.name, receipt.GasUsed)
-
-	log.Info("got transaction receipt",
-		"hash", txHash.Hex(),
-		"nonce", nonce,
-		"roundTripLatency", roundTripLatency,
-		"provider", p.name,
-		"blockNumber", receipt.BlockNumber,
-		"blockHash", receipt.BlockHash,
-		"gasUsed", receipt.GasUsed)
-}
 
-func (p *Provider) createTx(ctx context.Context, client *iclients.InstrumentedEthClient, nonce uint64) (*types.Transaction, error) {
-	var err error
-	if nonce == 0 {
-		nonce, err = client.PendingNonceAt(ctx, p.walletConfig.Address)
+		current, err := client.TransactionReceipt(ctx, pr.hash)
 		if err != nil {
-			log.Error("cant get nounce",
+			// not found if the transaction is pending again after a reorg, wait for it to be included again
+			if !errors.Is(err, ethereum.NotFound) {
+				log.Error("cant get receipt for transaction",
+					"provider", p.name,
+					"hash", pr.hash.Hex(),
+					"err", err)
+			}
+			continue
+		}
+		if current.BlockHash != receipt.BlockHash {
+			log.Warn("probe transaction reorged",
 				"provider", p.name,
-				"nonce", nonce,
-				"err", err)
-			return nil, err
+				"stage", stage,
+				"hash", pr.hash,
+				"oldBlockHash", receipt.BlockHash,
+				"newBlockHash", current.BlockHash)
+			metrics.RecordError(p.name, "probe.reorged")
+			receipt = current
+			continue
 		}
+
+		latency := time.Since(pr.startedAt)
+		metrics.RecordProbeStageLatency(p.name, stage, latency)
+		log.Info("probe transaction reached stage",
+			"provider", p.name,
+			"stage", stage,
+			"hash", pr.hash.Hex(),
+			"nonce", pr.nonce,
+			"blockNumber", receipt.BlockNumber,
+			"latency", latency)
+		return receipt, true
 	}
+}
 
+func (p *Provider) createTx(ctx context.Context, client *iclients.InstrumentedEthClient, wallet *Wallet, nonce uint64) (*types.Transaction, error) {
 	gasTipCap, err := client.SuggestGasTipCap(ctx)
 	if err != nil {
 		log.Error("cant get gas tip cap",
@@ -228,15 +389,15 @@ func (p *Provider) createTx(ctx context.Context, client *iclients.InstrumentedEt
 		gasTipCap,
 		new(big.Int).Mul(baseFee, big.NewInt(2)))
 
-	addr := common.HexToAddress(p.walletConfig.Address)
+	addr := common.HexToAddress(wallet.Config.Address)
 	var data []byte
 	dynamicTx := &types.DynamicFeeTx{
-		ChainID:   &p.walletConfig.ChainID,
+		ChainID:   &wallet.Config.ChainID,
 		Nonce:     nonce,
 		GasFeeCap: gasFeeCap,
 		GasTipCap: gasTipCap,
 		To:        &addr,
-		Value:     &p.walletConfig.TxValue,
+		Value:     &wallet.Config.TxValue,
 		Data:      data,
 	}
 
@@ -271,15 +432,15 @@ func (p *Provider) createTx(ctx context.Context, client *iclients.InstrumentedEt
 	return tx, nil
 }
 
-func (p *Provider) sign(ctx context.Context, tx *types.Transaction) (*types.Transaction, error) {
-	if p.walletConfig.SignerMethod == "static" {
+func (p *Provider) sign(ctx context.Context, wallet *Wallet, tx *types.Transaction) (*types.Transaction, error) {
+	if wallet.Config.SignerMethod == "static" {
 		log.Debug("using static signer")
-		privateKey, err := crypto.HexToECDSA(p.walletConfig.PrivateKey)
+		privateKey, err := crypto.HexToECDSA(wallet.Config.PrivateKey)
 		if err != nil {
 			return nil, err
 		}
-		return types.SignTx(tx, types.LatestSignerForChainID(&p.walletConfig.ChainID), privateKey)
-	} else if p.walletConfig.SignerMethod == "signer" {
+		return types.SignTx(tx, types.LatestSignerForChainID(&wallet.Config.ChainID), privateKey)
+	} else if wallet.Config.SignerMethod == "signer" {
 		tlsConfig := tls.CLIConfig{
 			TLSCaCert: p.signerConfig.TLSCaCert,
 			TLSCert:   p.signerConfig.TLSCert,
@@ -297,7 +458,7 @@ func (p *Provider) sign(ctx context.Context, tx *types.Transaction) (*types.Tran
 			return nil, errors.New("could not initialize signer client")
 		}
 
-		signedTx, err := client.SignTransaction(ctx, &p.walletConfig.ChainID, tx)
+		signedTx, err := client.SignTransaction(ctx, &wallet.Config.ChainID, tx)
 		if err != nil {
 			return nil, err
 		}
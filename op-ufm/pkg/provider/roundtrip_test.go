@@ -0,0 +1,312 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/ethereum-optimism/optimism/op-ufm/pkg/config"
+	"github.com/ethereum-optimism/optimism/op-ufm/pkg/metrics"
+	iclients "github.com/ethereum-optimism/optimism/op-ufm/pkg/metrics/clients"
+)
+
+// testNode is a minimal execution engine and rollup node, that includes every sent transaction in a new block
+type testNode struct {
+	m         sync.Mutex
+	head      uint64
+	receipts  map[common.Hash]*types.Receipt
+	sent      []*types.Transaction
+	safe      uint64
+	finalized uint64
+
+	// sending blocks on sendGate if set, after reporting the transaction on sending
+	sending  chan *types.Transaction
+	sendGate chan struct{}
+}
+
+type testEthAPI struct {
+	n *testNode
+}
+
+func (api *testEthAPI) GetTransactionCount(addr common.Address, block string) hexutil.Uint64 {
+	api.n.m.Lock()
+	defer api.n.m.Unlock()
+	return hexutil.Uint64(len(api.n.sent))
+}
+
+func (api *testEthAPI) MaxPriorityFeePerGas() *hexutil.Big {
+	return (*hexutil.Big)(big.NewInt(1))
+}
+
+func (api *testEthAPI) GetBlockByNumber(number string, full bool) *types.Header {
+	api.n.m.Lock()
+	defer api.n.m.Unlock()
+	return &types.Header{Number: new(big.Int).SetUint64(api.n.head), Difficulty: new(big.Int), BaseFee: big.NewInt(7)}
+}
+
+func (api *testEthAPI) EstimateGas(args map[string]interface{}, block *string) hexutil.Uint64 {
+	return 21000
+}
+
+func (api *testEthAPI) SendRawTransaction(data hexutil.Bytes) (common.Hash, error) {
+	var tx types.Transaction
+	if err := tx.UnmarshalBinary(data); err != nil {
+		return common.Hash{}, err
+	}
+	if api.n.sending != nil {
+		api.n.sending <- &tx
+	}
+	if api.n.sendGate != nil {
+		<-api.n.sendGate
+	}
+	api.n.m.Lock()
+	defer api.n.m.Unlock()
+	api.n.head++
+	api.n.sent = append(api.n.sent, &tx)
+	api.n.receipts[tx.Hash()] = &types.Receipt{
+		Status:      types.ReceiptStatusSuccessful,
+		Logs:        []*types.Log{},
+		TxHash:      tx.Hash(),
+		GasUsed:     21000,
+		BlockHash:   common.BigToHash(new(big.Int).SetUint64(api.n.head)),
+		BlockNumber: new(big.Int).SetUint64(api.n.head),
+	}
+	return tx.Hash(), nil
+}
+
+func (api *testEthAPI) GetTransactionReceipt(hash common.Hash) *types.Receipt {
+	api.n.m.Lock()
+	defer api.n.m.Unlock()
+	return api.n.receipts[hash]
+}
+
+type testRollupAPI struct {
+	n *testNode
+}
+
+func (api *testRollupAPI) SyncStatus() *iclients.SyncStatus {
+	api.n.m.Lock()
+	defer api.n.m.Unlock()
+	return &iclients.SyncStatus{
+		UnsafeL2:    iclients.BlockRef{Number: api.n.head},
+		SafeL2:      iclients.BlockRef{Number: api.n.safe},
+		FinalizedL2: iclients.BlockRef{Number: api.n.finalized},
+	}
+}
+
+func (n *testNode) setSafe(safe uint64, finalized uint64) {
+	n.m.Lock()
+	defer n.m.Unlock()
+	n.safe = safe
+	n.finalized = finalized
+}
+
+func (n *testNode) sentNonces() []uint64 {
+	n.m.Lock()
+	defer n.m.Unlock()
+	var nonces []uint64
+	for _, tx := range n.sent {
+		nonces = append(nonces, tx.Nonce())
+	}
+	return nonces
+}
+
+// newTestProvider starts a test node, and returns a provider with short intervals that sends to it.
+// The provider name is unique, to not share metrics with earlier test runs.
+func newTestProvider(t *testing.T) (*Provider, *Wallet, *testNode) {
+	n := &testNode{receipts: make(map[common.Hash]*types.Receipt)}
+	srv := rpc.NewServer()
+	require.NoError(t, srv.RegisterName("eth", &testEthAPI{n: n}))
+	require.NoError(t, srv.RegisterName("optimism", &testRollupAPI{n: n}))
+	httpSrv := httptest.NewServer(srv)
+	t.Cleanup(func() {
+		httpSrv.Close()
+		srv.Stop()
+	})
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	wallet := &Wallet{
+		Name: "test",
+		Config: &config.WalletConfig{
+			ChainID:      *big.NewInt(901),
+			SignerMethod: "static",
+			Address:      crypto.PubkeyToAddress(key.PublicKey).Hex(),
+			PrivateKey:   common.Bytes2Hex(crypto.FromECDSA(key)),
+		},
+		Nonces: &NonceManager{},
+	}
+	cfg := &config.ProviderConfig{
+		Network:                      "test",
+		URL:                          httpSrv.URL,
+		SendTransactionRetryInterval: config.TOMLDuration(10 * time.Millisecond),
+		SendTransactionRetryTimeout:  config.TOMLDuration(time.Second),
+		ReceiptRetrievalInterval:     config.TOMLDuration(10 * time.Millisecond),
+		ReceiptRetrievalTimeout:      config.TOMLDuration(5 * time.Second),
+		RollupURL:                    httpSrv.URL,
+		SyncStatusInterval:           config.TOMLDuration(10 * time.Millisecond),
+		SafeInclusionTimeout:         config.TOMLDuration(5 * time.Second),
+		FinalizationTimeout:          config.TOMLDuration(5 * time.Second),
+	}
+	txPool := &NetworkTransactionPool{Transactions: make(map[string]*TransactionState), Expected: 1}
+	name := fmt.Sprintf("%s-%d", t.Name(), time.Now().UnixNano())
+	return New(name, cfg, nil, []*Wallet{wallet}, txPool), wallet, n
+}
+
+// gatherMetric returns the value of a gauge or counter, or the sample count of a histogram, with the given labels
+func gatherMetric(t *testing.T, name string, labels map[string]string) float64 {
+	families, err := prometheus.DefaultGatherer.Gather()
+	require.NoError(t, err)
+	for _, family := range families {
+		if family.GetName() != metrics.MetricsNamespace+"_"+name {
+			continue
+		}
+	metrics:
+		for _, m := range family.GetMetric() {
+			for _, l := range m.GetLabel() {
+				if v, ok := labels[l.GetName()]; ok && v != l.GetValue() {
+					continue metrics
+				}
+			}
+			switch {
+			case m.GetHistogram() != nil:
+				return float64(m.GetHistogram().GetSampleCount())
+			case m.GetCounter() != nil:
+				return m.GetCounter().GetValue()
+			default:
+				return m.GetGauge().GetValue()
+			}
+		}
+	}
+	return 0
+}
+
+func stageLatencyCount(t *testing.T, provider string, stage string) float64 {
+	return gatherMetric(t, "probe_stage_latency_seconds", map[string]string{"provider": provider, "stage": stage})
+}
+
+func TestRoundTripStages(t *testing.T) {
+	p, wallet, n := newTestProvider(t)
+
+	// the round trip only waits for the transaction to be sent, inclusion is tracked asynchronously
+	p.RoundTrip(context.Background(), wallet)
+	require.Equal(t, []uint64{0}, n.sentNonces())
+	require.Equal(t, 1.0, stageLatencyCount(t, p.name, metrics.StageMempool))
+	require.Equal(t, 1.0, gatherMetric(t, "probes_inflight", map[string]string{"provider": p.name}))
+
+	require.Eventually(t, func() bool {
+		return stageLatencyCount(t, p.name, metrics.StageUnsafe) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, 0.0, stageLatencyCount(t, p.name, metrics.StageSafe))
+
+	// a second probe is sent while the first one is still tracked
+	p.RoundTrip(context.Background(), wallet)
+	require.Equal(t, []uint64{0, 1}, n.sentNonces())
+	require.Equal(t, 2.0, stageLatencyCount(t, p.name, metrics.StageMempool))
+
+	n.setSafe(2, 1)
+	require.Eventually(t, func() bool {
+		return stageLatencyCount(t, p.name, metrics.StageSafe) == 2 &&
+			stageLatencyCount(t, p.name, metrics.StageFinalized) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	n.setSafe(2, 2)
+	p.probes.Wait()
+	require.Equal(t, 2.0, stageLatencyCount(t, p.name, metrics.StageUnsafe))
+	require.Equal(t, 2.0, stageLatencyCount(t, p.name, metrics.StageFinalized))
+	require.Equal(t, 0.0, gatherMetric(t, "probes_inflight", map[string]string{"provider": p.name}))
+}
+
+func TestRoundTripStageTimeout(t *testing.T) {
+	p, wallet, _ := newTestProvider(t)
+	p.config.SafeInclusionTimeout = config.TOMLDuration(100 * time.Millisecond)
+
+	p.RoundTrip(context.Background(), wallet)
+	p.probes.Wait()
+	require.Equal(t, 1.0, stageLatencyCount(t, p.name, metrics.StageUnsafe))
+	require.Equal(t, 0.0, stageLatencyCount(t, p.name, metrics.StageSafe))
+	require.Equal(t, 0.0, stageLatencyCount(t, p.name, metrics.StageFinalized))
+	require.Equal(t, 1.0, gatherMetric(t, "probe_stage_timeouts_total", map[string]string{"provider": p.name, "stage": metrics.StageSafe}))
+}
+
+func TestRoundTripShutdown(t *testing.T) {
+	p, wallet, _ := newTestProvider(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	p.cancelFunc = cancel
+
+	p.RoundTrip(ctx, wallet)
+	require.Eventually(t, func() bool {
+		return stageLatencyCount(t, p.name, metrics.StageUnsafe) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	// the safe head does not progress, shutting down stops tracking the probe
+	p.Shutdown()
+	require.Equal(t, 0.0, gatherMetric(t, "probes_inflight", map[string]string{"provider": p.name}))
+	require.Equal(t, 0.0, gatherMetric(t, "probe_stage_timeouts_total", map[string]string{"provider": p.name}))
+}
+
+func TestSendOnlySerializesNonces(t *testing.T) {
+	p, wallet, n := newTestProvider(t)
+	p.config.RollupURL = ""
+	n.sending = make(chan *types.Transaction)
+	n.sendGate = make(chan struct{})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p.RoundTrip(context.Background(), wallet)
+		}()
+	}
+	// both transactions are being sent at the same time: the send lock is not held while sending
+	nonces := map[uint64]bool{}
+	for i := 0; i < 2; i++ {
+		select {
+		case tx := <-n.sending:
+			nonces[tx.Nonce()] = true
+		case <-time.After(5 * time.Second):
+			t.Fatal("expected concurrent sends")
+		}
+	}
+	require.Equal(t, map[uint64]bool{0: true, 1: true}, nonces)
+	close(n.sendGate)
+	wg.Wait()
+	p.probes.Wait()
+	require.ElementsMatch(t, []uint64{0, 1}, n.sentNonces())
+	require.Equal(t, 2.0, stageLatencyCount(t, p.name, metrics.StageUnsafe))
+}
+
+func TestNextNonceCoolDown(t *testing.T) {
+	p, wallet, _ := newTestProvider(t)
+	p.config.SendTransactionCoolDown = config.TOMLDuration(100 * time.Millisecond)
+	client, err := iclients.Dial(p.name, p.config.URL)
+	require.NoError(t, err)
+	defer client.Close()
+
+	start := time.Now()
+	nonce, err := p.nextNonce(context.Background(), client, wallet)
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), nonce)
+	nonce, err = p.nextNonce(context.Background(), client, wallet)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), nonce)
+	require.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond, "second nonce waits for the cool down")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = p.nextNonce(ctx, client, wallet)
+	require.ErrorIs(t, err, context.Canceled)
+}
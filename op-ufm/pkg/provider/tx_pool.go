@@ -16,9 +16,9 @@ type NetworkTransactionPool struct {
 	Transactions map[string]*TransactionState
 	Expected     int
 
-	// Last time a transaction was sent
+	// Last time a nonce was assigned to send a transaction
 	LastSend time.Time
-	// Prevents concurrent transaction send
+	// Prevents concurrent nonce assignment, to space transaction sends by the send cool down
 	ExclusiveSend sync.Mutex
 }
 
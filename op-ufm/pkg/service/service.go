@@ -74,11 +74,25 @@ func (s *Service) Start(ctx context.Context) {
 		(*txpool)[name].Expected = len(providers) - 1
 	}
 
+	// wallets are shared between providers, so their nonces are managed across all probe streams
+	wallets := make(map[string]*provider.Wallet, len(s.Config.Wallets))
+	for name, walletConfig := range s.Config.Wallets {
+		wallets[name] = &provider.Wallet{
+			Name:   name,
+			Config: walletConfig,
+			Nonces: &provider.NonceManager{},
+		}
+	}
+
 	for name, providerConfig := range s.Config.Providers {
+		providerWallets := make([]*provider.Wallet, 0, len(providerConfig.WalletNames()))
+		for _, wallet := range providerConfig.WalletNames() {
+			providerWallets = append(providerWallets, wallets[wallet])
+		}
 		s.Providers[name] = provider.New(name,
 			providerConfig,
 			&s.Config.Signer,
-			s.Config.Wallets[providerConfig.Wallet],
+			providerWallets,
 			(*txpool)[providerConfig.Network])
 		s.Providers[name].Start(ctx)
 		log.Info("provider started",
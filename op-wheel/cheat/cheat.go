@@ -197,6 +197,14 @@ func StorageGet(address common.Address, key common.Hash, w io.Writer) HeadFn {
 // Combined with StoragePatch this allows for quick surgery of 1 account in one database,
 // to another account (maybe even in a different database!).
 func StorageReadAll(address common.Address, w io.Writer) HeadFn {
+	return storageReadAll(address, w, nil)
+}
+
+// annotateFn writes comments about the storage entry with the given hashed key of the account,
+// after the entry itself is written.
+type annotateFn func(w io.Writer, headState *state.StateDB, address common.Address, hashedKey []byte) error
+
+func storageReadAll(address common.Address, w io.Writer, annotate annotateFn) HeadFn {
 	return func(_ *types.Header, headState *state.StateDB) error {
 		storage, err := openStorageTrie(headState, address)
 		if err != nil {
@@ -214,6 +222,11 @@ func StorageReadAll(address common.Address, w io.Writer) HeadFn {
 			if _, err := fmt.Fprintf(w, "+ %x = %x\n", iter.Key, dbValueToHash(iter.Value)); err != nil {
 				return err
 			}
+			if annotate != nil {
+				if err := annotate(w, headState, address, iter.Key); err != nil {
+					return err
+				}
+			}
 		}
 		return nil
 	}
@@ -234,7 +247,20 @@ func dbValueToHash(enc []byte) common.Hash {
 // StorageDiff compares the storage of two different accounts, and writes a patch with differences.
 // Each difference is expressed with 1 character + or - to indicate the change from a to b, followed by key = value.
 func StorageDiff(out io.Writer, addressA, addressB common.Address) HeadFn {
+	return storageDiff(out, addressA, addressB, nil)
+}
+
+func storageDiff(out io.Writer, addressA, addressB common.Address, annotate annotateFn) HeadFn {
 	return func(_ *types.Header, headState *state.StateDB) error {
+		printEntry := func(prefix string, address common.Address, key, value []byte) error {
+			if _, err := fmt.Fprintf(out, "%s %x = %x\n", prefix, key, dbValueToHash(value)); err != nil {
+				return err
+			}
+			if annotate != nil {
+				return annotate(out, headState, address, key)
+			}
+			return nil
+		}
 		aStorage, err := openStorageTrie(headState, addressA)
 		if err != nil {
 			return fmt.Errorf("failed to open storage trie of addr A %s: %w", addressA, err)
@@ -267,23 +293,23 @@ func StorageDiff(out io.Writer, addressA, addressB common.Address) HeadFn {
 			}
 			if cmp := bytes.Compare(aIter.Key, bIter.Key); cmp < 0 {
 				// a is smaller, and thus missing in b. Print and move forward a
-				if _, err := fmt.Fprintf(out, "- %x = %x\n", aIter.Key, dbValueToHash(aIter.Value)); err != nil {
+				if err := printEntry("-", addressA, aIter.Key, aIter.Value); err != nil {
 					return err
 				}
 				hasA = aIter.Next()
 			} else if cmp > 0 {
 				// b is smaller, and thus missing in a. Print and move forward b
-				if _, err := fmt.Fprintf(out, "+ %x = %x\n", bIter.Key, dbValueToHash(bIter.Value)); err != nil {
+				if err := printEntry("+", addressB, bIter.Key, bIter.Value); err != nil {
 					return err
 				}
 				hasB = bIter.Next()
 			} else if cmp == 0 {
 				// same key, now check if the values differ
 				if !bytes.Equal(aIter.Value, bIter.Value) {
-					if _, err := fmt.Fprintf(out, "- %x = %x\n", aIter.Key, dbValueToHash(aIter.Value)); err != nil {
+					if err := printEntry("-", addressA, aIter.Key, aIter.Value); err != nil {
 						return err
 					}
-					if _, err := fmt.Fprintf(out, "+ %x = %x\n", bIter.Key, dbValueToHash(bIter.Value)); err != nil {
+					if err := printEntry("+", addressB, bIter.Key, bIter.Value); err != nil {
 						return err
 					}
 				}
@@ -0,0 +1,612 @@
+package cheat
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-bindings/solc"
+)
+
+// maxExpandedElements limits the number of elements of a static array that are labeled in
+// read-all and diff output, and that are printed when reading the array as a whole.
+const maxExpandedElements = 1024
+
+// maxBytesLength limits the length of a string or bytes value that is read from storage,
+// to not read a corrupted length.
+const maxBytesLength = 1 << 20
+
+var (
+	staticArrayTypeRe = regexp.MustCompile(`^t_array\(.+\)(\d+)_storage$`)
+	fixedBytesTypeRe  = regexp.MustCompile(`^t_bytes(\d+)$`)
+)
+
+// StorageVar is a storage variable, or a part of one, that is resolved from the storage layout
+// of a contract to its location in storage.
+type StorageVar struct {
+	// Path is the Solidity path of the variable, e.g. l2Outputs[5].outputRoot
+	Path string
+	// Slot is the first storage slot of the variable
+	Slot common.Hash
+	// Offset is the offset of the variable in its slot, in bytes from the right
+	Offset uint
+	// TypeID is the solc type identifier of the variable, e.g. t_uint256
+	TypeID string
+	// Type is the storage layout type of the variable
+	Type solc.StorageLayoutType
+
+	layout *solc.StorageLayout
+}
+
+// ResolveStorageVar resolves a variable path, such as L2OutputOracle.l2Outputs[5].outputRoot,
+// with the storage layout of the contract that the path starts with.
+func ResolveStorageVar(variable string) (*StorageVar, error) {
+	contract, path, ok := strings.Cut(variable, ".")
+	if !ok {
+		return nil, fmt.Errorf("expected Contract.variable, got %q", variable)
+	}
+	layout, err := bindings.GetStorageLayout(contract)
+	if err != nil {
+		return nil, err
+	}
+	return ResolveStorageVarInLayout(layout, path)
+}
+
+// ResolveStorageVarInLayout resolves a variable path, without the contract name, with the given storage layout.
+// Struct members are selected with .member, array elements with [index] and mapping values with [key].
+func ResolveStorageVarInLayout(layout *solc.StorageLayout, path string) (*StorageVar, error) {
+	name, rest := splitPathPart(path)
+	if name == "" {
+		return nil, fmt.Errorf("expected variable name in %q", path)
+	}
+	entry, err := layout.GetStorageLayoutEntry(name)
+	if err != nil {
+		return nil, err
+	}
+	v, err := newStorageVar(layout, name, common.BigToHash(new(big.Int).SetUint64(uint64(entry.Slot))), entry.Offset, entry.Type)
+	if err != nil {
+		return nil, err
+	}
+	for rest != "" {
+		switch rest[0] {
+		case '.':
+			name, rest = splitPathPart(rest[1:])
+			if v, err = v.member(name); err != nil {
+				return nil, err
+			}
+		case '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("missing ] in %q", rest)
+			}
+			key := rest[1:end]
+			rest = rest[end+1:]
+			if v, err = v.index(key); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("unexpected %q in path %q", rest, path)
+		}
+	}
+	return v, nil
+}
+
+// splitPathPart splits the identifier at the start of the path from the remaining path
+func splitPathPart(path string) (string, string) {
+	end := strings.IndexAny(path, ".[")
+	if end < 0 {
+		return path, ""
+	}
+	return path[:end], path[end:]
+}
+
+func newStorageVar(layout *solc.StorageLayout, path string, slot common.Hash, offset uint, typeID string) (*StorageVar, error) {
+	ty, err := layout.GetStorageLayoutType(typeID)
+	if err != nil {
+		return nil, fmt.Errorf("type of %s: %w", path, err)
+	}
+	return &StorageVar{Path: path, Slot: slot, Offset: offset, TypeID: typeID, Type: ty, layout: layout}, nil
+}
+
+// member returns the struct member with the given name
+func (v *StorageVar) member(name string) (*StorageVar, error) {
+	if !strings.HasPrefix(v.TypeID, "t_struct(") {
+		return nil, fmt.Errorf("%s is a %s, not a struct", v.Path, v.Type.Label)
+	}
+	if len(v.Type.Members) == 0 {
+		return nil, fmt.Errorf("storage layout has no members of %s, regenerate the bindings", v.Type.Label)
+	}
+	for _, m := range v.Type.Members {
+		if m.Label == name {
+			return newStorageVar(v.layout, v.Path+"."+name, addSlot(v.Slot, new(big.Int).SetUint64(uint64(m.Slot))), m.Offset, m.Type)
+		}
+	}
+	return nil, fmt.Errorf("%s has no member %s", v.Type.Label, name)
+}
+
+// index returns the array element or mapping value of the given key
+func (v *StorageVar) index(key string) (*StorageVar, error) {
+	path := v.Path + "[" + key + "]"
+	switch {
+	case v.Type.Encoding == "mapping":
+		keyType, err := v.layout.GetStorageLayoutType(v.Type.Key)
+		if err != nil {
+			return nil, fmt.Errorf("key type of %s: %w", v.Path, err)
+		}
+		encodedKey, err := encodeMappingKey(v.Type.Key, keyType, key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid key of %s: %w", v.Path, err)
+		}
+		slot := crypto.Keccak256Hash(encodedKey, v.Slot[:])
+		return newStorageVar(v.layout, path, slot, 0, v.Type.Value)
+	case v.Type.Encoding == "dynamic_array":
+		i, ok := new(big.Int).SetString(key, 0)
+		if !ok || i.Sign() < 0 {
+			return nil, fmt.Errorf("invalid index %q of %s", key, v.Path)
+		}
+		return v.element(path, crypto.Keccak256Hash(v.Slot[:]), i)
+	case staticArrayTypeRe.MatchString(v.TypeID):
+		i, ok := new(big.Int).SetString(key, 0)
+		if !ok || i.Sign() < 0 {
+			return nil, fmt.Errorf("invalid index %q of %s", key, v.Path)
+		}
+		if length := v.staticLength(); i.Cmp(new(big.Int).SetUint64(length)) >= 0 {
+			return nil, fmt.Errorf("index %s out of bounds of %s", i, v.Type.Label)
+		}
+		return v.element(path, v.Slot, i)
+	default:
+		return nil, fmt.Errorf("%s is a %s, and cannot be indexed", v.Path, v.Type.Label)
+	}
+}
+
+// element returns the element at index i of the array with elements starting at the given slot.
+// Elements of up to 16 bytes are packed into slots, larger elements start at a new slot.
+func (v *StorageVar) element(path string, start common.Hash, i *big.Int) (*StorageVar, error) {
+	base, err := v.layout.GetStorageLayoutType(v.Type.Base)
+	if err != nil {
+		return nil, fmt.Errorf("element type of %s: %w", v.Path, err)
+	}
+	size := uint64(base.NumberOfBytes)
+	// structs and arrays always start a new slot
+	if size <= 16 && !strings.HasPrefix(v.Type.Base, "t_struct(") && !strings.HasPrefix(v.Type.Base, "t_array(") {
+		perSlot := new(big.Int).SetUint64(32 / size)
+		slot, rem := new(big.Int).QuoRem(i, perSlot, new(big.Int))
+		return newStorageVar(v.layout, path, addSlot(start, slot), uint(rem.Uint64()*size), v.Type.Base)
+	}
+	slots := new(big.Int).SetUint64((size + 31) / 32)
+	return newStorageVar(v.layout, path, addSlot(start, new(big.Int).Mul(i, slots)), 0, v.Type.Base)
+}
+
+// staticLength returns the number of elements of a static array
+func (v *StorageVar) staticLength() uint64 {
+	m := staticArrayTypeRe.FindStringSubmatch(v.TypeID)
+	length, _ := strconv.ParseUint(m[1], 10, 64)
+	return length
+}
+
+// composite returns whether the variable is a struct or static array, that spans one or more slots.
+func (v *StorageVar) composite() bool {
+	return strings.HasPrefix(v.TypeID, "t_struct(") || staticArrayTypeRe.MatchString(v.TypeID)
+}
+
+// Fields returns the variable itself, or the members and elements of a struct or static array, recursively.
+// Members of structs are only known if the storage layout includes them.
+func (v *StorageVar) Fields() ([]*StorageVar, error) {
+	var children []*StorageVar
+	switch {
+	case strings.HasPrefix(v.TypeID, "t_struct("):
+		for _, m := range v.Type.Members {
+			child, err := v.member(m.Label)
+			if err != nil {
+				return nil, err
+			}
+			children = append(children, child)
+		}
+		if len(children) == 0 {
+			return []*StorageVar{v}, nil
+		}
+	case staticArrayTypeRe.MatchString(v.TypeID):
+		length := v.staticLength()
+		if length > maxExpandedElements {
+			length = maxExpandedElements
+		}
+		for i := uint64(0); i < length; i++ {
+			child, err := v.index(strconv.FormatUint(i, 10))
+			if err != nil {
+				return nil, err
+			}
+			children = append(children, child)
+		}
+	default:
+		return []*StorageVar{v}, nil
+	}
+	var out []*StorageVar
+	for _, child := range children {
+		fields, err := child.Fields()
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, fields...)
+	}
+	return out, nil
+}
+
+// Decode reads the value of the variable with the given storage reader, and formats it for its type.
+// Dynamic arrays are formatted as their length, and mappings have no value.
+func (v *StorageVar) Decode(read func(slot common.Hash) common.Hash) (string, error) {
+	word := read(v.Slot)
+	if strings.HasPrefix(v.TypeID, "t_struct(") && len(v.Type.Members) == 0 {
+		return word.Hex() + " (members of " + v.Type.Label + " are not in the storage layout)", nil
+	}
+	if v.composite() {
+		return "", fmt.Errorf("%s is a %s, decode its fields instead", v.Path, v.Type.Label)
+	}
+	switch v.Type.Encoding {
+	case "mapping":
+		return "(mapping)", nil
+	case "dynamic_array":
+		return "(length " + word.Big().String() + ")", nil
+	case "bytes":
+		data, err := readBytes(v.Slot, word, read)
+		if err != nil {
+			return "", fmt.Errorf("cannot read %s: %w", v.Path, err)
+		}
+		if v.TypeID == "t_string_storage" {
+			return strconv.Quote(string(data)), nil
+		}
+		return hexutil.Encode(data), nil
+	}
+
+	value := v.extract(word)
+	switch {
+	case v.TypeID == "t_bool":
+		return strconv.FormatBool(value[0] != 0), nil
+	case v.TypeID == "t_address" || v.TypeID == "t_address_payable" || strings.HasPrefix(v.TypeID, "t_contract("):
+		return common.BytesToAddress(value).String(), nil
+	case strings.HasPrefix(v.TypeID, "t_uint") || strings.HasPrefix(v.TypeID, "t_enum("):
+		return new(big.Int).SetBytes(value).String(), nil
+	case strings.HasPrefix(v.TypeID, "t_int"):
+		n := new(big.Int).SetBytes(value)
+		if len(value) > 0 && value[0]&0x80 != 0 {
+			n.Sub(n, new(big.Int).Lsh(big.NewInt(1), uint(len(value))*8))
+		}
+		return n.String(), nil
+	default:
+		// fixed-size bytes, and user defined value types
+		return hexutil.Encode(value), nil
+	}
+}
+
+// extract returns the bytes of the variable in the given slot
+func (v *StorageVar) extract(word common.Hash) []byte {
+	size := v.size()
+	return common.CopyBytes(word[32-v.Offset-size : 32-v.Offset])
+}
+
+// size returns the number of bytes of the variable in its slot
+func (v *StorageVar) size() uint {
+	if v.Type.NumberOfBytes > 32 {
+		return 32
+	}
+	return v.Type.NumberOfBytes
+}
+
+// Encode sets the value of the variable, parsed from the given string, in the given slot.
+// Strings and bytes may span multiple slots, and the returned map contains every slot to write.
+func (v *StorageVar) Encode(value string, read func(slot common.Hash) common.Hash) (map[common.Hash]common.Hash, error) {
+	if v.composite() {
+		return nil, fmt.Errorf("%s is a %s, set its fields instead", v.Path, v.Type.Label)
+	}
+	switch v.Type.Encoding {
+	case "mapping", "dynamic_array":
+		return nil, fmt.Errorf("%s is a %s, set its elements instead", v.Path, v.Type.Label)
+	case "bytes":
+		data := []byte(value)
+		if v.TypeID != "t_string_storage" {
+			var err error
+			if data, err = hexutil.Decode(value); err != nil {
+				return nil, fmt.Errorf("invalid bytes value: %w", err)
+			}
+		}
+		return encodeBytes(v.Slot, data, read), nil
+	}
+
+	encoded, err := encodeValue(v.TypeID, v.size(), value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value of %s: %w", v.Path, err)
+	}
+	word := read(v.Slot)
+	copy(word[32-v.Offset-v.size():32-v.Offset], encoded)
+	return map[common.Hash]common.Hash{v.Slot: word}, nil
+}
+
+// encodeValue encodes a value type into the given number of bytes
+func encodeValue(typeID string, size uint, value string) ([]byte, error) {
+	switch {
+	case typeID == "t_bool":
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, err
+		}
+		if b {
+			return []byte{1}, nil
+		}
+		return []byte{0}, nil
+	case typeID == "t_address" || typeID == "t_address_payable" || strings.HasPrefix(typeID, "t_contract("):
+		if !common.IsHexAddress(value) {
+			return nil, fmt.Errorf("invalid address %q", value)
+		}
+		return common.HexToAddress(value).Bytes(), nil
+	case strings.HasPrefix(typeID, "t_uint") || strings.HasPrefix(typeID, "t_int") || strings.HasPrefix(typeID, "t_enum("):
+		n, ok := new(big.Int).SetString(value, 0)
+		if !ok {
+			return nil, fmt.Errorf("invalid integer %q", value)
+		}
+		bits := size * 8
+		limit := new(big.Int).Lsh(big.NewInt(1), bits)
+		if strings.HasPrefix(typeID, "t_int") {
+			half := new(big.Int).Rsh(limit, 1)
+			if n.Cmp(half) >= 0 || n.Cmp(new(big.Int).Neg(half)) < 0 {
+				return nil, fmt.Errorf("%s overflows int%d", n, bits)
+			}
+			if n.Sign() < 0 {
+				n.Add(n, limit)
+			}
+		} else if n.Sign() < 0 || n.Cmp(limit) >= 0 {
+			return nil, fmt.Errorf("%s overflows uint%d", n, bits)
+		}
+		return n.FillBytes(make([]byte, size)), nil
+	case fixedBytesTypeRe.MatchString(typeID):
+		// fixed-size bytes are left-aligned
+		b, err := hexutil.Decode(value)
+		if err != nil {
+			return nil, err
+		}
+		if uint(len(b)) > size {
+			return nil, fmt.Errorf("%d bytes do not fit in bytes%d", len(b), size)
+		}
+		return common.RightPadBytes(b, int(size)), nil
+	default:
+		// user defined value types
+		b, err := hexutil.Decode(value)
+		if err != nil {
+			return nil, err
+		}
+		if uint(len(b)) > size {
+			return nil, fmt.Errorf("%d bytes do not fit in %d bytes", len(b), size)
+		}
+		return common.LeftPadBytes(b, int(size)), nil
+	}
+}
+
+// encodeMappingKey encodes a mapping key as it is hashed with the slot of the mapping.
+// Value types are padded to 32 bytes, strings and bytes are hashed as they are.
+func encodeMappingKey(typeID string, keyType solc.StorageLayoutType, key string) ([]byte, error) {
+	switch {
+	case typeID == "t_string_memory_ptr" || typeID == "t_string_storage":
+		return []byte(key), nil
+	case typeID == "t_bytes_memory_ptr" || typeID == "t_bytes_storage":
+		return hexutil.Decode(key)
+	}
+	b, err := encodeValue(typeID, keyType.NumberOfBytes, key)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case fixedBytesTypeRe.MatchString(typeID):
+		return common.RightPadBytes(b, 32), nil
+	case strings.HasPrefix(typeID, "t_int") && b[0]&0x80 != 0:
+		// negative integers are sign-extended
+		return append(bytes.Repeat([]byte{0xff}, 32-len(b)), b...), nil
+	default:
+		return common.LeftPadBytes(b, 32), nil
+	}
+}
+
+// readBytes reads a string or bytes value, that is stored in the slot with the given value.
+// Values shorter than 32 bytes are stored in the slot, with their length times 2 in the lowest byte.
+// Longer values store their length times 2 plus 1 in the slot, and the data starts at the hash of the slot.
+func readBytes(slot common.Hash, word common.Hash, read func(slot common.Hash) common.Hash) ([]byte, error) {
+	if word[31]&1 == 0 {
+		length := word[31] / 2
+		if length > 31 {
+			return nil, fmt.Errorf("invalid short length %d", length)
+		}
+		return common.CopyBytes(word[:length]), nil
+	}
+	length := new(big.Int).Rsh(word.Big(), 1)
+	if !length.IsUint64() || length.Uint64() > maxBytesLength {
+		return nil, fmt.Errorf("length %s exceeds %d bytes", length, maxBytesLength)
+	}
+	n := length.Uint64()
+	data := make([]byte, 0, n+31)
+	start := crypto.Keccak256Hash(slot[:])
+	for i := uint64(0); uint64(len(data)) < n; i++ {
+		chunk := read(addSlot(start, new(big.Int).SetUint64(i)))
+		data = append(data, chunk[:]...)
+	}
+	return data[:n], nil
+}
+
+// encodeBytes returns the slots to write to store a string or bytes value in the given slot.
+// The data slots of a previous long value are cleared.
+func encodeBytes(slot common.Hash, data []byte, read func(slot common.Hash) common.Hash) map[common.Hash]common.Hash {
+	out := make(map[common.Hash]common.Hash)
+	start := crypto.Keccak256Hash(slot[:])
+	if prev := read(slot); prev[31]&1 == 1 {
+		prevLength := new(big.Int).Rsh(prev.Big(), 1)
+		if prevLength.IsUint64() && prevLength.Uint64() <= maxBytesLength {
+			for i := uint64(0); i*32 < prevLength.Uint64(); i++ {
+				out[addSlot(start, new(big.Int).SetUint64(i))] = common.Hash{}
+			}
+		}
+	}
+	if len(data) < 32 {
+		var word common.Hash
+		copy(word[:], data)
+		word[31] = byte(len(data) * 2)
+		out[slot] = word
+		return out
+	}
+	out[slot] = common.BigToHash(new(big.Int).SetUint64(uint64(len(data))*2 + 1))
+	for i := 0; i*32 < len(data); i++ {
+		var chunk common.Hash
+		copy(chunk[:], data[i*32:])
+		out[addSlot(start, big.NewInt(int64(i)))] = chunk
+	}
+	return out
+}
+
+// addSlot adds n to the given slot, wrapping around at 2**256
+func addSlot(slot common.Hash, n *big.Int) common.Hash {
+	sum := new(big.Int).Add(slot.Big(), n)
+	return common.BigToHash(sum.And(sum, maxSlot))
+}
+
+var maxSlot = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+
+// StorageGetVar writes the decoded value of a storage variable, given as Contract.path, of the account.
+// Structs and static arrays are written as their fields, one per line.
+func StorageGetVar(address common.Address, variable string, w io.Writer) HeadFn {
+	return func(_ *types.Header, headState *state.StateDB) error {
+		v, err := ResolveStorageVar(variable)
+		if err != nil {
+			return err
+		}
+		fields, err := v.Fields()
+		if err != nil {
+			return err
+		}
+		read := func(slot common.Hash) common.Hash { return headState.GetState(address, slot) }
+		for _, field := range fields {
+			value, err := field.Decode(read)
+			if err != nil {
+				return err
+			}
+			if _, err := fmt.Fprintf(w, "%s = %s # slot %x offset %d\n", field.Path, value, field.Slot, field.Offset); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// StorageSetVar sets a storage variable, given as Contract.path, of the account to the value.
+// Packed variables are written without changing the other variables in their slot.
+func StorageSetVar(address common.Address, variable string, value string) HeadFn {
+	return func(_ *types.Header, headState *state.StateDB) error {
+		v, err := ResolveStorageVar(variable)
+		if err != nil {
+			return err
+		}
+		slots, err := v.Encode(value, func(slot common.Hash) common.Hash { return headState.GetState(address, slot) })
+		if err != nil {
+			return err
+		}
+		for slot, word := range slots {
+			headState.SetState(address, slot, word)
+		}
+		return nil
+	}
+}
+
+// StoragePatchVars applies a patch of storage variables to the account.
+// Each line sets a variable, given as Contract.path, to a value: Contract.path = value.
+// Comments (#) and empty lines are ignored.
+func StoragePatchVars(patch io.Reader, address common.Address) HeadFn {
+	return func(head *types.Header, headState *state.StateDB) error {
+		s := bufio.NewScanner(patch)
+		for s.Scan() {
+			line := strings.TrimSpace(s.Text())
+			if len(line) < 1 || line[0] == '#' { // skip empty lines and comments
+				continue
+			}
+			variable, value, ok := strings.Cut(line, "=")
+			if !ok {
+				return fmt.Errorf("expected Contract.path = value, got %q", line)
+			}
+			if err := StorageSetVar(address, strings.TrimSpace(variable), strings.TrimSpace(value))(head, headState); err != nil {
+				return err
+			}
+		}
+		return s.Err()
+	}
+}
+
+// storageLabels labels the slots of the statically-sized variables in a storage layout, by the hash of their slot,
+// as the storage trie is keyed by hashed slots.
+type storageLabels map[common.Hash][]*StorageVar
+
+func newStorageLabels(contract string) (storageLabels, error) {
+	layout, err := bindings.GetStorageLayout(contract)
+	if err != nil {
+		return nil, err
+	}
+	labels := make(storageLabels)
+	for _, entry := range layout.Storage {
+		v, err := ResolveStorageVarInLayout(layout, entry.Label)
+		if err != nil {
+			return nil, err
+		}
+		fields, err := v.Fields()
+		if err != nil {
+			return nil, err
+		}
+		for _, field := range fields {
+			// values of mappings are not stored at the slot of the mapping
+			if field.Type.Encoding == "mapping" {
+				continue
+			}
+			key := crypto.Keccak256Hash(field.Slot[:])
+			labels[key] = append(labels[key], field)
+		}
+	}
+	return labels, nil
+}
+
+// annotate writes the decoded variables of the storage entry with the given hashed key as comments.
+func (l storageLabels) annotate(w io.Writer, headState *state.StateDB, address common.Address, hashedKey []byte) error {
+	read := func(slot common.Hash) common.Hash { return headState.GetState(address, slot) }
+	for _, field := range l[common.BytesToHash(hashedKey)] {
+		value, err := field.Decode(read)
+		if err != nil {
+			value = fmt.Sprintf("(%v)", err)
+		}
+		if _, err := fmt.Fprintf(w, "#   %s = %s\n", field.Path, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// StorageReadAllLayout reads all storage of the account like StorageReadAll, and annotates the slots
+// of the variables in the storage layout of the contract with their decoded values.
+func StorageReadAllLayout(address common.Address, contract string, w io.Writer) HeadFn {
+	labels, err := newStorageLabels(contract)
+	if err != nil {
+		return func(*types.Header, *state.StateDB) error { return err }
+	}
+	return storageReadAll(address, w, labels.annotate)
+}
+
+// StorageDiffLayout compares the storage of two accounts like StorageDiff, and annotates the slots
+// of the variables in the storage layout of the contract with their decoded values in A (-) and B (+).
+func StorageDiffLayout(out io.Writer, addressA, addressB common.Address, contract string) HeadFn {
+	labels, err := newStorageLabels(contract)
+	if err != nil {
+		return func(*types.Header, *state.StateDB) error { return err }
+	}
+	return storageDiff(out, addressA, addressB, labels.annotate)
+}
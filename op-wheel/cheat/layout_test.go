@@ -0,0 +1,280 @@
+package cheat
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/solc"
+)
+
+// testLayout is the storage layout of:
+//
+//	contract Test {
+//	    mapping(address => uint256) balances;
+//	    mapping(string => mapping(int16 => bool)) flags;
+//	    uint64[] times;
+//	    uint8 small;
+//	    address owner;
+//	    bytes32[3] roots;
+//	    string name;
+//	}
+const testLayout = `{
+	"storage": [
+		{"label": "balances", "slot": "0", "offset": 0, "type": "t_mapping(t_address,t_uint256)"},
+		{"label": "flags", "slot": "1", "offset": 0, "type": "t_mapping(t_string_memory_ptr,t_mapping(t_int16,t_bool))"},
+		{"label": "times", "slot": "2", "offset": 0, "type": "t_array(t_uint64)dyn_storage"},
+		{"label": "small", "slot": "3", "offset": 0, "type": "t_uint8"},
+		{"label": "owner", "slot": "3", "offset": 1, "type": "t_address"},
+		{"label": "roots", "slot": "4", "offset": 0, "type": "t_array(t_bytes32)3_storage"},
+		{"label": "name", "slot": "7", "offset": 0, "type": "t_string_storage"}
+	],
+	"types": {
+		"t_address": {"encoding": "inplace", "label": "address", "numberOfBytes": "20"},
+		"t_array(t_bytes32)3_storage": {"encoding": "inplace", "label": "bytes32[3]", "numberOfBytes": "96", "base": "t_bytes32"},
+		"t_array(t_uint64)dyn_storage": {"encoding": "dynamic_array", "label": "uint64[]", "numberOfBytes": "32", "base": "t_uint64"},
+		"t_bool": {"encoding": "inplace", "label": "bool", "numberOfBytes": "1"},
+		"t_bytes32": {"encoding": "inplace", "label": "bytes32", "numberOfBytes": "32"},
+		"t_int16": {"encoding": "inplace", "label": "int16", "numberOfBytes": "2"},
+		"t_mapping(t_address,t_uint256)": {"encoding": "mapping", "label": "mapping(address => uint256)", "numberOfBytes": "32", "key": "t_address", "value": "t_uint256"},
+		"t_mapping(t_int16,t_bool)": {"encoding": "mapping", "label": "mapping(int16 => bool)", "numberOfBytes": "32", "key": "t_int16", "value": "t_bool"},
+		"t_mapping(t_string_memory_ptr,t_mapping(t_int16,t_bool))": {"encoding": "mapping", "label": "mapping(string => mapping(int16 => bool))", "numberOfBytes": "32", "key": "t_string_memory_ptr", "value": "t_mapping(t_int16,t_bool)"},
+		"t_string_memory_ptr": {"encoding": "bytes", "label": "string", "numberOfBytes": "32"},
+		"t_string_storage": {"encoding": "bytes", "label": "string", "numberOfBytes": "32"},
+		"t_uint256": {"encoding": "inplace", "label": "uint256", "numberOfBytes": "32"},
+		"t_uint64": {"encoding": "inplace", "label": "uint64", "numberOfBytes": "8"},
+		"t_uint8": {"encoding": "inplace", "label": "uint8", "numberOfBytes": "1"}
+	}
+}`
+
+func newTestLayout(t *testing.T) *solc.StorageLayout {
+	var layout solc.StorageLayout
+	require.NoError(t, json.Unmarshal([]byte(testLayout), &layout))
+	return &layout
+}
+
+// testStorage is an in-memory account storage
+type testStorage map[common.Hash]common.Hash
+
+func (s testStorage) read(slot common.Hash) common.Hash {
+	return s[slot]
+}
+
+func (s testStorage) set(t *testing.T, v *StorageVar, value string) {
+	slots, err := v.Encode(value, s.read)
+	require.NoError(t, err)
+	for slot, word := range slots {
+		s[slot] = word
+	}
+}
+
+func (s testStorage) get(t *testing.T, v *StorageVar) string {
+	value, err := v.Decode(s.read)
+	require.NoError(t, err)
+	return value
+}
+
+func slotAt(base common.Hash, n int64) common.Hash {
+	return addSlot(base, big.NewInt(n))
+}
+
+func TestResolveStructMembers(t *testing.T) {
+	// the example of the cheat commands: a member of a struct in a dynamic array
+	v, err := ResolveStorageVar("L2OutputOracle.l2Outputs[5].outputRoot")
+	require.NoError(t, err)
+	// each OutputProposal takes two slots, starting at the hash of the slot of the array
+	elements := crypto.Keccak256Hash(common.BigToHash(big.NewInt(3)).Bytes())
+	require.Equal(t, "l2Outputs[5].outputRoot", v.Path)
+	require.Equal(t, slotAt(elements, 10), v.Slot)
+	require.Equal(t, uint(0), v.Offset)
+	require.Equal(t, "t_bytes32", v.TypeID)
+
+	v, err = ResolveStorageVar("L2OutputOracle.l2Outputs[5].l2BlockNumber")
+	require.NoError(t, err)
+	require.Equal(t, slotAt(elements, 11), v.Slot)
+	require.Equal(t, uint(16), v.Offset)
+
+	v, err = ResolveStorageVar("L2OutputOracle.l2Outputs[5]")
+	require.NoError(t, err)
+	fields, err := v.Fields()
+	require.NoError(t, err)
+	var paths []string
+	for _, f := range fields {
+		paths = append(paths, f.Path)
+	}
+	require.Equal(t, []string{"l2Outputs[5].outputRoot", "l2Outputs[5].timestamp", "l2Outputs[5].l2BlockNumber"}, paths)
+
+	// a struct in a mapping
+	key := common.HexToHash("0x1234")
+	v, err = ResolveStorageVar("OptimismPortal.provenWithdrawals[" + key.Hex() + "].l2OutputIndex")
+	require.NoError(t, err)
+	require.Equal(t, slotAt(crypto.Keccak256Hash(key[:], common.BigToHash(big.NewInt(52)).Bytes()), 1), v.Slot)
+	require.Equal(t, uint(16), v.Offset)
+
+	_, err = ResolveStorageVar("L2OutputOracle.l2Outputs[5].stateRoot")
+	require.ErrorContains(t, err, "has no member stateRoot")
+	_, err = ResolveStorageVar("L2OutputOracle.proposer.outputRoot")
+	require.ErrorContains(t, err, "not a struct")
+	_, err = ResolveStorageVar("L2OutputOracle.proposer[0]")
+	require.ErrorContains(t, err, "cannot be indexed")
+	_, err = ResolveStorageVar("l2Outputs")
+	require.ErrorContains(t, err, "expected Contract.variable")
+}
+
+func TestPackedStructMembers(t *testing.T) {
+	// ResourceConfig packs six members into one slot
+	storage := make(testStorage)
+	set := func(member string, value string) {
+		v, err := ResolveStorageVar("SystemConfig._resourceConfig." + member)
+		require.NoError(t, err)
+		require.Equal(t, common.BigToHash(big.NewInt(105)), v.Slot)
+		storage.set(t, v, value)
+	}
+	set("maxResourceLimit", "20000000")
+	set("elasticityMultiplier", "10")
+	set("baseFeeMaxChangeDenominator", "8")
+	set("minimumBaseFee", "1000000000")
+	set("systemTxMaxGas", "1000000")
+	set("maximumBaseFee", "340282366920938463463374607431768211455")
+	require.Len(t, storage, 1)
+
+	v, err := ResolveStorageVar("SystemConfig._resourceConfig")
+	require.NoError(t, err)
+	fields, err := v.Fields()
+	require.NoError(t, err)
+	values := make(map[string]string)
+	for _, f := range fields {
+		values[f.Path] = storage.get(t, f)
+	}
+	require.Equal(t, map[string]string{
+		"_resourceConfig.maxResourceLimit":            "20000000",
+		"_resourceConfig.elasticityMultiplier":        "10",
+		"_resourceConfig.baseFeeMaxChangeDenominator": "8",
+		"_resourceConfig.minimumBaseFee":              "1000000000",
+		"_resourceConfig.systemTxMaxGas":              "1000000",
+		"_resourceConfig.maximumBaseFee":              "340282366920938463463374607431768211455",
+	}, values)
+
+	// setting a member does not change the other members in its slot
+	set("elasticityMultiplier", "4")
+	v, err = ResolveStorageVar("SystemConfig._resourceConfig.baseFeeMaxChangeDenominator")
+	require.NoError(t, err)
+	require.Equal(t, "8", storage.get(t, v))
+	v, err = ResolveStorageVar("SystemConfig._resourceConfig.elasticityMultiplier")
+	require.NoError(t, err)
+	require.Equal(t, "4", storage.get(t, v))
+	_, err = v.Encode("256", storage.read)
+	require.ErrorContains(t, err, "overflows uint8")
+}
+
+func TestMappings(t *testing.T) {
+	layout := newTestLayout(t)
+	storage := make(testStorage)
+
+	owner := common.HexToAddress("0xabcd")
+	v, err := ResolveStorageVarInLayout(layout, "balances["+owner.Hex()+"]")
+	require.NoError(t, err)
+	require.Equal(t, crypto.Keccak256Hash(common.LeftPadBytes(owner[:], 32), common.Hash{}.Bytes()), v.Slot)
+	storage.set(t, v, "1000")
+	require.Equal(t, "1000", storage.get(t, v))
+
+	// string keys are hashed as they are, and negative integer keys are sign-extended
+	v, err = ResolveStorageVarInLayout(layout, "flags[foo][-1]")
+	require.NoError(t, err)
+	inner := crypto.Keccak256Hash([]byte("foo"), common.BigToHash(big.NewInt(1)).Bytes())
+	require.Equal(t, crypto.Keccak256Hash(bytes.Repeat([]byte{0xff}, 32), inner[:]), v.Slot)
+	storage.set(t, v, "true")
+	require.Equal(t, "true", storage.get(t, v))
+
+	mapping, err := ResolveStorageVarInLayout(layout, "balances")
+	require.NoError(t, err)
+	require.Equal(t, "(mapping)", storage.get(t, mapping))
+	_, err = mapping.Encode("1", storage.read)
+	require.ErrorContains(t, err, "set its elements instead")
+
+	_, err = ResolveStorageVarInLayout(layout, "balances[0x1234]")
+	require.ErrorContains(t, err, "invalid key")
+	_, err = ResolveStorageVarInLayout(layout, "flags[foo][32768]")
+	require.ErrorContains(t, err, "overflows int16")
+}
+
+func TestArrays(t *testing.T) {
+	layout := newTestLayout(t)
+	storage := make(testStorage)
+
+	// four uint64 elements of a dynamic array are packed into each slot
+	elements := crypto.Keccak256Hash(common.BigToHash(big.NewInt(2)).Bytes())
+	v, err := ResolveStorageVarInLayout(layout, "times[5]")
+	require.NoError(t, err)
+	require.Equal(t, slotAt(elements, 1), v.Slot)
+	require.Equal(t, uint(8), v.Offset)
+	storage.set(t, v, "12345")
+	other, err := ResolveStorageVarInLayout(layout, "times[4]")
+	require.NoError(t, err)
+	storage.set(t, other, "6789")
+	require.Equal(t, "12345", storage.get(t, v))
+	require.Equal(t, "6789", storage.get(t, other))
+
+	length, err := ResolveStorageVarInLayout(layout, "times")
+	require.NoError(t, err)
+	storage[length.Slot] = common.BigToHash(big.NewInt(6))
+	require.Equal(t, "(length 6)", storage.get(t, length))
+
+	// static arrays are stored in place, and are bounds-checked
+	v, err = ResolveStorageVarInLayout(layout, "roots[2]")
+	require.NoError(t, err)
+	require.Equal(t, common.BigToHash(big.NewInt(6)), v.Slot)
+	_, err = ResolveStorageVarInLayout(layout, "roots[3]")
+	require.ErrorContains(t, err, "out of bounds")
+	roots, err := ResolveStorageVarInLayout(layout, "roots")
+	require.NoError(t, err)
+	fields, err := roots.Fields()
+	require.NoError(t, err)
+	require.Len(t, fields, 3)
+	_, err = roots.Decode(storage.read)
+	require.ErrorContains(t, err, "decode its fields instead")
+}
+
+func TestPackedValues(t *testing.T) {
+	layout := newTestLayout(t)
+	storage := make(testStorage)
+
+	small, err := ResolveStorageVarInLayout(layout, "small")
+	require.NoError(t, err)
+	owner, err := ResolveStorageVarInLayout(layout, "owner")
+	require.NoError(t, err)
+	storage.set(t, small, "7")
+	storage.set(t, owner, "0x00000000000000000000000000000000DeaDBeef")
+	require.Equal(t, common.HexToHash("0xdeadbeef07"), storage[small.Slot])
+	require.Equal(t, "7", storage.get(t, small))
+	require.Equal(t, "0x00000000000000000000000000000000DeaDBeef", storage.get(t, owner))
+}
+
+func TestStrings(t *testing.T) {
+	layout := newTestLayout(t)
+	storage := make(testStorage)
+	v, err := ResolveStorageVarInLayout(layout, "name")
+	require.NoError(t, err)
+
+	long := "a string that is longer than thirty-one bytes, and spans multiple slots"
+	storage.set(t, v, long)
+	require.Equal(t, `"`+long+`"`, storage.get(t, v))
+	require.Len(t, storage, 4)
+
+	// a short string is stored in its slot, and clears the slots of the previous long string
+	slots, err := v.Encode("short", storage.read)
+	require.NoError(t, err)
+	require.Len(t, slots, 4)
+	for slot, word := range slots {
+		storage[slot] = word
+	}
+	require.Equal(t, `"short"`, storage.get(t, v))
+	data := crypto.Keccak256Hash(v.Slot[:])
+	require.Equal(t, common.Hash{}, storage[data])
+}
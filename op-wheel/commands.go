@@ -16,6 +16,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/rpc"
@@ -90,16 +91,71 @@ var (
 		Usage:   "allow gaps in block building, like missed slots on the beacon chain.",
 		EnvVars: prefixEnvVars("ALLOW_GAPS"),
 	}
+	TransactionsFlag = &cli.PathFlag{
+		Name:      "transactions",
+		Usage:     "Path to a file of transactions to force into the block, or - for STDIN. Hex-encoded signed transactions, or JSON transaction and deposit transaction objects. Rollup engines only.",
+		TakesFile: true,
+		EnvVars:   prefixEnvVars("TRANSACTIONS"),
+	}
+	NoTxPoolFlag = &cli.BoolFlag{
+		Name:    "no-tx-pool",
+		Usage:   "build the block with only the forced transactions, and none of the tx pool. Rollup engines only.",
+		EnvVars: prefixEnvVars("NO_TX_POOL"),
+	}
+	GasLimitFlag = &cli.Uint64Flag{
+		Name:    "gas-limit",
+		Usage:   "exact gas limit of the block, required by rollup engines.",
+		EnvVars: prefixEnvVars("GAS_LIMIT"),
+	}
+	VarFlag = &cli.StringFlag{
+		Name:     "var",
+		Usage:    "Storage variable as Contract.path, e.g. L2OutputOracle.l2Outputs[5].outputRoot, resolved with the storage layout of the contract",
+		Required: true,
+		EnvVars:  prefixEnvVars("VAR"),
+	}
+	ContractFlag = &cli.StringFlag{
+		Name:    "contract",
+		Usage:   "Name of the contract to decode the storage variables of with its storage layout",
+		EnvVars: prefixEnvVars("CONTRACT"),
+	}
 )
 
 func ParseBuildingArgs(ctx *cli.Context) *engine.BlockBuildingSettings {
-	return &engine.BlockBuildingSettings{
+	settings := &engine.BlockBuildingSettings{
 		BlockTime:    ctx.Uint64(BlockTimeFlag.Name),
 		AllowGaps:    ctx.Bool(AllowGaps.Name),
 		Random:       hashFlagValue(RandaoFlag.Name, ctx),
 		FeeRecipient: addrFlagValue(FeeRecipientFlag.Name, ctx),
 		BuildTime:    ctx.Duration(BuildingTime.Name),
+		NoTxPool:     ctx.Bool(NoTxPoolFlag.Name),
+	}
+	if ctx.IsSet(GasLimitFlag.Name) {
+		gasLimit := ctx.Uint64(GasLimitFlag.Name)
+		settings.GasLimit = &gasLimit
 	}
+	return settings
+}
+
+// ParseTransactionsArg reads the transactions of the transactions flag, from a file or STDIN.
+func ParseTransactionsArg(ctx *cli.Context) ([]*types.Transaction, error) {
+	path := ctx.Path(TransactionsFlag.Name)
+	if path == "" {
+		return nil, nil
+	}
+	r := ctx.App.Reader
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open transactions file: %w", err)
+		}
+		defer f.Close()
+		r = f
+	}
+	txs, err := engine.ReadTransactions(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read transactions: %w", err)
+	}
+	return txs, nil
 }
 
 func CheatAction(readOnly bool, fn func(ctx *cli.Context, ch *cheat.Cheater) error) cli.ActionFunc {
@@ -248,20 +304,58 @@ var (
 			return ch.RunAndClose(cheat.StorageSet(addrFlagValue("address", ctx), hashFlagValue("key", ctx), hashFlagValue("value", ctx)))
 		}),
 	}
+	CheatStorageGetVarCmd = &cli.Command{
+		Name:    "get-var",
+		Aliases: []string{"read-var"},
+		Usage:   "Read and decode a storage variable, resolved with the storage layout of the contract",
+		Flags: []cli.Flag{
+			DataDirFlag,
+			addrFlag("address", "Address to read storage of"),
+			VarFlag,
+		},
+		Action: CheatAction(true, func(ctx *cli.Context, ch *cheat.Cheater) error {
+			return ch.RunAndClose(cheat.StorageGetVar(addrFlagValue("address", ctx), ctx.String(VarFlag.Name), ctx.App.Writer))
+		}),
+	}
+	CheatStorageSetVarCmd = &cli.Command{
+		Name:    "set-var",
+		Aliases: []string{"write-var"},
+		Usage:   "Write a storage variable, resolved with the storage layout of the contract. Packed variables keep the rest of their slot",
+		Flags: []cli.Flag{
+			DataDirFlag,
+			addrFlag("address", "Address to write storage of"),
+			VarFlag,
+			&cli.StringFlag{
+				Name:     "value",
+				Usage:    "the value to write, formatted for the type of the variable: decimal or hex integers, addresses, true/false, hex bytes, or a string",
+				Required: true,
+				EnvVars:  prefixEnvVars("VALUE"),
+			},
+		},
+		Action: CheatAction(false, func(ctx *cli.Context, ch *cheat.Cheater) error {
+			return ch.RunAndClose(cheat.StorageSetVar(addrFlagValue("address", ctx), ctx.String(VarFlag.Name), ctx.String("value")))
+		}),
+	}
 	CheatStorageReadAll = &cli.Command{
 		Name:    "read-all",
 		Aliases: []string{"get-all"},
 		Usage:   "Read all storage of the given account",
-		Flags:   []cli.Flag{DataDirFlag, addrFlag("address", "Address to read all storage of")},
+		Flags:   []cli.Flag{DataDirFlag, addrFlag("address", "Address to read all storage of"), ContractFlag},
 		Action: CheatAction(true, func(ctx *cli.Context, ch *cheat.Cheater) error {
+			if contract := ctx.String(ContractFlag.Name); contract != "" {
+				return ch.RunAndClose(cheat.StorageReadAllLayout(addrFlagValue("address", ctx), contract, ctx.App.Writer))
+			}
 			return ch.RunAndClose(cheat.StorageReadAll(addrFlagValue("address", ctx), ctx.App.Writer))
 		}),
 	}
 	CheatStorageDiffCmd = &cli.Command{
 		Name:  "diff",
 		Usage: "Diff the storage of accounts A and B",
-		Flags: []cli.Flag{DataDirFlag, hashFlag("a", "address of account A"), hashFlag("b", "address of account B")},
+		Flags: []cli.Flag{DataDirFlag, hashFlag("a", "address of account A"), hashFlag("b", "address of account B"), ContractFlag},
 		Action: CheatAction(true, func(ctx *cli.Context, ch *cheat.Cheater) error {
+			if contract := ctx.String(ContractFlag.Name); contract != "" {
+				return ch.RunAndClose(cheat.StorageDiffLayout(ctx.App.Writer, addrFlagValue("a", ctx), addrFlagValue("b", ctx), contract))
+			}
 			return ch.RunAndClose(cheat.StorageDiff(ctx.App.Writer, addrFlagValue("a", ctx), addrFlagValue("b", ctx)))
 		}),
 	}
@@ -273,14 +367,25 @@ var (
 			return ch.RunAndClose(cheat.StoragePatch(os.Stdin, addrFlagValue("address", ctx)))
 		}),
 	}
+	CheatStoragePatchVarsCmd = &cli.Command{
+		Name:  "patch-vars",
+		Usage: "Apply a patch of storage variables from STDIN to the given account address, one Contract.path = value per line",
+		Flags: []cli.Flag{DataDirFlag, addrFlag("address", "Address to patch storage of")},
+		Action: CheatAction(false, func(ctx *cli.Context, ch *cheat.Cheater) error {
+			return ch.RunAndClose(cheat.StoragePatchVars(os.Stdin, addrFlagValue("address", ctx)))
+		}),
+	}
 	CheatStorageCmd = &cli.Command{
 		Name: "storage",
 		Subcommands: []*cli.Command{
 			CheatStorageGetCmd,
 			CheatStorageSetCmd,
+			CheatStorageGetVarCmd,
+			CheatStorageSetVarCmd,
 			CheatStorageReadAll,
 			CheatStorageDiffCmd,
 			CheatStoragePatchCmd,
+			CheatStoragePatchVarsCmd,
 		},
 	}
 	CheatSetBalanceCmd = &cli.Command{
@@ -377,13 +482,17 @@ var (
 		Flags: []cli.Flag{
 			EngineEndpoint, EngineJWTPath,
 			FeeRecipientFlag, RandaoFlag, BlockTimeFlag, BuildingTime, AllowGaps,
+			TransactionsFlag, NoTxPoolFlag, GasLimitFlag,
 		},
-		// TODO: maybe support transaction and tx pool engine flags, since we use op-geth?
-		// TODO: reorg flag
 		// TODO: finalize/safe flag
 
 		Action: EngineAction(func(ctx *cli.Context, client client.RPC) error {
 			settings := ParseBuildingArgs(ctx)
+			txs, err := ParseTransactionsArg(ctx)
+			if err != nil {
+				return err
+			}
+			settings.Transactions = txs
 			status, err := engine.Status(context.Background(), client)
 			if err != nil {
 				return err
@@ -496,6 +605,53 @@ var (
 		}),
 	}
 
+	EngineReorgCmd = &cli.Command{
+		Name:        "reorg",
+		Usage:       "Set the head to an ancestor of the latest block, to build on it next",
+		Description: "The engine must allow reorgs of its own chain, like rollup engines. The new head cannot be older than the safe block.",
+		Flags: []cli.Flag{
+			EngineEndpoint, EngineJWTPath,
+			&cli.Uint64Flag{
+				Name:     "to",
+				Usage:    "Block number of the new head block",
+				Required: true,
+				EnvVars:  prefixEnvVars("REORG_TO"),
+			},
+		},
+		Action: EngineAction(func(ctx *cli.Context, client client.RPC) error {
+			return engine.Reorg(ctx.Context, client, ctx.Uint64("to"))
+		}),
+	}
+
+	EngineScenarioCmd = &cli.Command{
+		Name:  "scenario",
+		Usage: "Run a scenario of block building, reorg, forkchoice and gap steps",
+		Description: "The scenario is a JSON file with steps, e.g. {\"steps\": [{\"build\": {\"count\": 2}}, {\"reorg\": {\"depth\": 1}}, {\"gap\": {\"slots\": 3}}, " +
+			"{\"build\": {\"noTxPool\": true, \"transactions\": [\"0x...\"]}}, {\"forkchoice\": {\"unsafe\": 3, \"safe\": 2, \"finalized\": 1}}]}. " +
+			"The block building flags are the defaults of the build steps. The hash of every built block is written to the output.",
+		Flags: append([]cli.Flag{
+			EngineEndpoint, EngineJWTPath,
+			FeeRecipientFlag, RandaoFlag, BlockTimeFlag, BuildingTime, GasLimitFlag,
+			&cli.PathFlag{
+				Name:      "scenario",
+				Usage:     "Path to the JSON scenario file",
+				Required:  true,
+				TakesFile: true,
+				EnvVars:   prefixEnvVars("SCENARIO"),
+			},
+		}, oplog.CLIFlags(envVarPrefix)...),
+		Action: EngineAction(func(ctx *cli.Context, client client.RPC) error {
+			logCfg := oplog.ReadCLIConfig(ctx)
+			l := oplog.NewLogger(oplog.AppOut(ctx), logCfg)
+
+			scenario, err := engine.ReadScenario(ctx.Path("scenario"))
+			if err != nil {
+				return err
+			}
+			return engine.RunScenario(ctx.Context, client, l, scenario, ParseBuildingArgs(ctx), ctx.App.Writer)
+		}),
+	}
+
 	EngineJSONCmd = &cli.Command{
 		Name:        "json",
 		Description: "read json values from remaining args, or STDIN, and use them as RPC params to call the engine RPC method (first arg)",
@@ -551,6 +707,8 @@ var EngineCmd = &cli.Command{
 		EngineStatusCmd,
 		EngineCopyCmd,
 		EngineSetForkchoiceCmd,
+		EngineReorgCmd,
+		EngineScenarioCmd,
 		EngineJSONCmd,
 	},
 }
@@ -27,6 +27,12 @@ type PayloadAttributesV2 struct {
 	Random                common.Hash         `json:"prevRandao"`
 	SuggestedFeeRecipient common.Address      `json:"suggestedFeeRecipient"`
 	Withdrawals           []*types.Withdrawal `json:"withdrawals"`
+	// Transactions are forced into the block, before any transactions of the tx pool. Rollup engines only.
+	Transactions []hexutil.Bytes `json:"transactions,omitempty"`
+	// NoTxPool builds the block with only the forced transactions. Rollup engines only.
+	NoTxPool bool `json:"noTxPool,omitempty"`
+	// GasLimit is the exact gas limit of the block. Rollup engines only.
+	GasLimit *uint64 `json:"gasLimit,omitempty"`
 }
 
 func (p PayloadAttributesV2) MarshalJSON() ([]byte, error) {
@@ -35,12 +41,18 @@ func (p PayloadAttributesV2) MarshalJSON() ([]byte, error) {
 		Random                common.Hash         `json:"prevRandao"            gencodec:"required"`
 		SuggestedFeeRecipient common.Address      `json:"suggestedFeeRecipient" gencodec:"required"`
 		Withdrawals           []*types.Withdrawal `json:"withdrawals"`
+		Transactions          []hexutil.Bytes     `json:"transactions,omitempty"`
+		NoTxPool              bool                `json:"noTxPool,omitempty"`
+		GasLimit              *hexutil.Uint64     `json:"gasLimit,omitempty"`
 	}
 	var enc PayloadAttributes
 	enc.Timestamp = hexutil.Uint64(p.Timestamp)
 	enc.Random = p.Random
 	enc.SuggestedFeeRecipient = p.SuggestedFeeRecipient
 	enc.Withdrawals = make([]*types.Withdrawal, 0)
+	enc.Transactions = p.Transactions
+	enc.NoTxPool = p.NoTxPool
+	enc.GasLimit = (*hexutil.Uint64)(p.GasLimit)
 	return json.Marshal(&enc)
 }
 
@@ -127,16 +139,28 @@ type BlockBuildingSettings struct {
 	Random       common.Hash
 	FeeRecipient common.Address
 	BuildTime    time.Duration
+	// skip this number of slots before the block, to build a block with a gap in timestamps.
+	SkipSlots uint64
+	// Transactions to force into the block, like deposits. Only supported by rollup engines, such as op-geth.
+	Transactions []*types.Transaction
+	// build the block with the forced Transactions only, and none of the tx pool.
+	NoTxPool bool
+	// exact gas limit of the block, required by rollup engines if set.
+	GasLimit *uint64
 }
 
 func BuildBlock(ctx context.Context, client client.RPC, status *StatusData, settings *BlockBuildingSettings) (*engine.ExecutableData, error) {
-	timestamp := status.Head.Time + settings.BlockTime
+	timestamp := status.Head.Time + settings.BlockTime*(1+settings.SkipSlots)
 	if settings.AllowGaps {
 		now := uint64(time.Now().Unix())
 		if now > timestamp {
 			timestamp = now - ((now - timestamp) % settings.BlockTime)
 		}
 	}
+	txs, err := encodeTransactions(settings.Transactions)
+	if err != nil {
+		return nil, err
+	}
 	var pre engine.ForkChoiceResponse
 	if err := client.CallContext(ctx, &pre, "engine_forkchoiceUpdatedV2",
 		engine.ForkchoiceStateV1{
@@ -147,12 +171,18 @@ func BuildBlock(ctx context.Context, client client.RPC, status *StatusData, sett
 			Timestamp:             timestamp,
 			Random:                settings.Random,
 			SuggestedFeeRecipient: settings.FeeRecipient,
+			Transactions:          txs,
+			NoTxPool:              settings.NoTxPool,
+			GasLimit:              settings.GasLimit,
 		}); err != nil {
 		return nil, fmt.Errorf("failed to set forkchoice when building new block: %w", err)
 	}
 	if pre.PayloadStatus.Status != string(eth.ExecutionValid) {
 		return nil, fmt.Errorf("pre-block forkchoice update was not valid: %v", pre.PayloadStatus.ValidationError)
 	}
+	if pre.PayloadID == nil {
+		return nil, fmt.Errorf("engine did not start building a block on %s", status.Head.Hash)
+	}
 
 	// wait some time for the block to get built
 	select {
@@ -166,6 +196,10 @@ func BuildBlock(ctx context.Context, client client.RPC, status *StatusData, sett
 		return nil, fmt.Errorf("failed to get payload %v, %d time after instructing engine to build it: %w", pre.PayloadID, settings.BuildTime, err)
 	}
 
+	if err := checkForcedTransactions(payload.ExecutionPayload.Transactions, settings.Transactions); err != nil {
+		return nil, err
+	}
+
 	if err := insertBlock(ctx, client, payload.ExecutionPayload); err != nil {
 		return nil, err
 	}
@@ -338,6 +372,37 @@ func SetForkchoice(ctx context.Context, client client.RPC, finalizedNum, safeNum
 	return nil
 }
 
+// Reorg sets the head of the chain to the canonical block with the given number, which must not be older than the safe block.
+// Later blocks are no longer canonical, and new blocks are built on top of the new head.
+// Only rollup engines reorg to an ancestor of their head, other engines ignore the forkchoice update.
+func Reorg(ctx context.Context, client client.RPC, number uint64) error {
+	status, err := Status(ctx, client)
+	if err != nil {
+		return err
+	}
+	if number > status.Head.Number {
+		return fmt.Errorf("cannot reorg to %d > latest (%d)", number, status.Head.Number)
+	}
+	if number < status.Safe.Number {
+		return fmt.Errorf("cannot reorg to %d < safe (%d)", number, status.Safe.Number)
+	}
+	head, err := getHeader(ctx, client, "eth_getBlockByNumber", hexutil.Uint64(number).String())
+	if err != nil {
+		return fmt.Errorf("failed to get block %d to reorg to: %w", number, err)
+	}
+	if err := updateForkchoice(ctx, client, head.Hash(), status.Safe.Hash, status.Finalized.Hash); err != nil {
+		return fmt.Errorf("failed to reorg: %w", err)
+	}
+	latest, err := getHeader(ctx, client, "eth_getBlockByNumber", "latest")
+	if err != nil {
+		return fmt.Errorf("failed to get latest block after reorg: %w", err)
+	}
+	if latest.Hash() != head.Hash() {
+		return fmt.Errorf("engine ignored reorg to %d, latest block is %d", number, latest.Number)
+	}
+	return nil
+}
+
 func RawJSONInteraction(ctx context.Context, client client.RPC, method string, args []string, input io.Reader, output io.Writer) error {
 	var params []any
 	if input != nil {
@@ -0,0 +1,379 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/beacon/engine"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/trie"
+
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// testEngine is an in-memory rollup engine, that builds blocks with the forced transactions
+// followed by the transactions of its tx pool.
+type testEngine struct {
+	mu sync.Mutex
+
+	blocks    map[common.Hash]*types.Block
+	head      common.Hash
+	safe      common.Hash
+	finalized common.Hash
+	payloads  map[engine.PayloadID]*engine.ExecutableData
+	nextID    uint64
+
+	// pool is included after the forced transactions, unless noTxPool is set
+	pool []*types.Transaction
+	// reverseForced makes the engine include the forced transactions in reverse order
+	reverseForced bool
+	// dropForced makes the engine drop the last forced transaction
+	dropForced bool
+	// ignoreReorg makes the engine ignore forkchoice updates to an ancestor of its head, like L1 engines
+	ignoreReorg bool
+}
+
+type testAttributes struct {
+	Timestamp             hexutil.Uint64  `json:"timestamp"`
+	Random                common.Hash     `json:"prevRandao"`
+	SuggestedFeeRecipient common.Address  `json:"suggestedFeeRecipient"`
+	Transactions          []hexutil.Bytes `json:"transactions"`
+	NoTxPool              bool            `json:"noTxPool"`
+	GasLimit              *hexutil.Uint64 `json:"gasLimit"`
+}
+
+func newTestEngine(t *testing.T) (*testEngine, client.RPC) {
+	genesis := types.NewBlockWithHeader(&types.Header{
+		Number:     new(big.Int),
+		Time:       1000,
+		GasLimit:   30_000_000,
+		BaseFee:    big.NewInt(1),
+		Difficulty: new(big.Int),
+	})
+	e := &testEngine{
+		blocks:    map[common.Hash]*types.Block{genesis.Hash(): genesis},
+		head:      genesis.Hash(),
+		safe:      genesis.Hash(),
+		finalized: genesis.Hash(),
+		payloads:  make(map[engine.PayloadID]*engine.ExecutableData),
+	}
+	srv := rpc.NewServer()
+	require.NoError(t, srv.RegisterName("engine", &testEngineAPI{e}))
+	require.NoError(t, srv.RegisterName("eth", &testEthAPI{e}))
+	t.Cleanup(srv.Stop)
+	return e, client.NewBaseRPCClient(rpc.DialInProc(srv))
+}
+
+// canonical returns the canonical block with the given number
+func (e *testEngine) canonical(number uint64) *types.Block {
+	b := e.blocks[e.head]
+	for b != nil && b.NumberU64() > number {
+		b = e.blocks[b.ParentHash()]
+	}
+	if b == nil || b.NumberU64() != number {
+		return nil
+	}
+	return b
+}
+
+type testEngineAPI struct {
+	e *testEngine
+}
+
+func (api *testEngineAPI) ForkchoiceUpdatedV2(state engine.ForkchoiceStateV1, attrs *testAttributes) (engine.ForkChoiceResponse, error) {
+	e := api.e
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	head, ok := e.blocks[state.HeadBlockHash]
+	if !ok {
+		return engine.ForkChoiceResponse{PayloadStatus: engine.PayloadStatusV1{Status: string(eth.ExecutionSyncing)}}, nil
+	}
+	if !(e.ignoreReorg && head.NumberU64() < e.blocks[e.head].NumberU64()) {
+		e.head = state.HeadBlockHash
+	}
+	e.safe, e.finalized = state.SafeBlockHash, state.FinalizedBlockHash
+	res := engine.ForkChoiceResponse{PayloadStatus: engine.PayloadStatusV1{Status: string(eth.ExecutionValid)}}
+	if attrs == nil {
+		return res, nil
+	}
+
+	var txs []*types.Transaction
+	for _, data := range attrs.Transactions {
+		var tx types.Transaction
+		if err := tx.UnmarshalBinary(data); err != nil {
+			return engine.ForkChoiceResponse{}, err
+		}
+		txs = append(txs, &tx)
+	}
+	if e.dropForced && len(txs) > 0 {
+		txs = txs[:len(txs)-1]
+	}
+	if e.reverseForced {
+		for i, j := 0, len(txs)-1; i < j; i, j = i+1, j-1 {
+			txs[i], txs[j] = txs[j], txs[i]
+		}
+	}
+	if !attrs.NoTxPool {
+		txs = append(txs, e.pool...)
+	}
+	gasLimit := head.GasLimit()
+	if attrs.GasLimit != nil {
+		gasLimit = uint64(*attrs.GasLimit)
+	}
+	block := types.NewBlock(&types.Header{
+		ParentHash: head.Hash(),
+		Number:     new(big.Int).Add(head.Number(), big.NewInt(1)),
+		Time:       uint64(attrs.Timestamp),
+		MixDigest:  attrs.Random,
+		Coinbase:   attrs.SuggestedFeeRecipient,
+		GasLimit:   gasLimit,
+		BaseFee:    big.NewInt(1),
+		Difficulty: new(big.Int),
+	}, txs, nil, nil, trie.NewStackTrie(nil))
+
+	e.nextID++
+	var id engine.PayloadID
+	id[7] = byte(e.nextID)
+	e.payloads[id] = engine.BlockToExecutableData(block, nil, nil).ExecutionPayload
+	res.PayloadID = &id
+	return res, nil
+}
+
+func (api *testEngineAPI) GetPayloadV2(id engine.PayloadID) (*engine.ExecutionPayloadEnvelope, error) {
+	e := api.e
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	payload, ok := e.payloads[id]
+	if !ok {
+		return nil, errors.New("unknown payload")
+	}
+	return &engine.ExecutionPayloadEnvelope{ExecutionPayload: payload, BlockValue: new(big.Int)}, nil
+}
+
+func (api *testEngineAPI) NewPayloadV2(payload engine.ExecutableData) (engine.PayloadStatusV1, error) {
+	e := api.e
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	block, err := engine.ExecutableDataToBlock(payload, nil, nil)
+	if err != nil {
+		msg := err.Error()
+		return engine.PayloadStatusV1{Status: string(eth.ExecutionInvalid), ValidationError: &msg}, nil
+	}
+	if _, ok := e.blocks[block.ParentHash()]; !ok {
+		return engine.PayloadStatusV1{Status: string(eth.ExecutionSyncing)}, nil
+	}
+	e.blocks[block.Hash()] = block
+	return engine.PayloadStatusV1{Status: string(eth.ExecutionValid)}, nil
+}
+
+type testEthAPI struct {
+	e *testEngine
+}
+
+func (api *testEthAPI) GetBlockByNumber(tag string, full bool) (*types.Header, error) {
+	e := api.e
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	var block *types.Block
+	switch tag {
+	case "latest":
+		block = e.blocks[e.head]
+	case "safe":
+		block = e.blocks[e.safe]
+	case "finalized":
+		block = e.blocks[e.finalized]
+	default:
+		number, err := hexutil.DecodeUint64(tag)
+		if err != nil {
+			return nil, err
+		}
+		block = e.canonical(number)
+	}
+	if block == nil {
+		return nil, fmt.Errorf("block %s not found", tag)
+	}
+	return block.Header(), nil
+}
+
+func testDeposit(i byte) *types.Transaction {
+	to := common.Address{0x42}
+	return types.NewTx(&types.DepositTx{
+		SourceHash: common.Hash{i},
+		From:       common.Address{i},
+		To:         &to,
+		Value:      big.NewInt(int64(i)),
+		Gas:        100_000,
+	})
+}
+
+func testSettings() *BlockBuildingSettings {
+	return &BlockBuildingSettings{BlockTime: 2, Random: common.Hash{0xaa}, FeeRecipient: common.Address{0xbb}}
+}
+
+func buildBlock(t *testing.T, cl client.RPC, settings *BlockBuildingSettings) *engine.ExecutableData {
+	status, err := Status(context.Background(), cl)
+	require.NoError(t, err)
+	payload, err := BuildBlock(context.Background(), cl, status, settings)
+	require.NoError(t, err)
+	return payload
+}
+
+func txHashes(t *testing.T, included [][]byte) []common.Hash {
+	var hashes []common.Hash
+	for _, data := range included {
+		var tx types.Transaction
+		require.NoError(t, tx.UnmarshalBinary(data))
+		hashes = append(hashes, tx.Hash())
+	}
+	return hashes
+}
+
+func TestBuildBlockForcedTransactions(t *testing.T) {
+	ctx := context.Background()
+	forced := []*types.Transaction{testDeposit(1), testDeposit(2)}
+	poolTx := testDeposit(3)
+
+	t.Run("Included", func(t *testing.T) {
+		e, cl := newTestEngine(t)
+		e.pool = []*types.Transaction{poolTx}
+		settings := testSettings()
+		settings.Transactions = forced
+		payload := buildBlock(t, cl, settings)
+		require.Equal(t, []common.Hash{forced[0].Hash(), forced[1].Hash(), poolTx.Hash()}, txHashes(t, payload.Transactions))
+		require.Equal(t, payload.BlockHash, e.head)
+	})
+
+	t.Run("NoTxPool", func(t *testing.T) {
+		e, cl := newTestEngine(t)
+		e.pool = []*types.Transaction{poolTx}
+		gasLimit := uint64(10_000_000)
+		settings := testSettings()
+		settings.Transactions = forced
+		settings.NoTxPool = true
+		settings.GasLimit = &gasLimit
+		payload := buildBlock(t, cl, settings)
+		require.Equal(t, []common.Hash{forced[0].Hash(), forced[1].Hash()}, txHashes(t, payload.Transactions))
+		require.Equal(t, gasLimit, payload.GasLimit)
+	})
+
+	t.Run("Reordered", func(t *testing.T) {
+		// the engine includes as many transactions as were forced, but not in the forced order
+		e, cl := newTestEngine(t)
+		e.reverseForced = true
+		settings := testSettings()
+		settings.Transactions = forced
+		status, err := Status(ctx, cl)
+		require.NoError(t, err)
+		_, err = BuildBlock(ctx, cl, status, settings)
+		require.ErrorContains(t, err, fmt.Sprintf("at index 0, but %s was forced", forced[0].Hash()))
+		require.Equal(t, status.Head.Hash, e.head, "block must not be inserted")
+	})
+
+	t.Run("Replaced", func(t *testing.T) {
+		// a pool transaction takes the place of a dropped forced transaction
+		e, cl := newTestEngine(t)
+		e.dropForced = true
+		e.pool = []*types.Transaction{poolTx}
+		settings := testSettings()
+		settings.Transactions = forced
+		status, err := Status(ctx, cl)
+		require.NoError(t, err)
+		_, err = BuildBlock(ctx, cl, status, settings)
+		require.ErrorContains(t, err, fmt.Sprintf("engine included transaction %s at index 1", poolTx.Hash()))
+	})
+
+	t.Run("Dropped", func(t *testing.T) {
+		e, cl := newTestEngine(t)
+		e.dropForced = true
+		settings := testSettings()
+		settings.Transactions = forced
+		status, err := Status(ctx, cl)
+		require.NoError(t, err)
+		_, err = BuildBlock(ctx, cl, status, settings)
+		require.ErrorContains(t, err, "engine included 1 transactions, but 2 were forced")
+	})
+}
+
+func TestBuildBlockSkipSlots(t *testing.T) {
+	_, cl := newTestEngine(t)
+	settings := testSettings()
+	payload := buildBlock(t, cl, settings)
+	require.Equal(t, uint64(1002), payload.Timestamp)
+
+	settings.SkipSlots = 3
+	payload = buildBlock(t, cl, settings)
+	require.Equal(t, uint64(1002+2*4), payload.Timestamp)
+	require.Equal(t, uint64(2), payload.Number)
+}
+
+func TestReorg(t *testing.T) {
+	ctx := context.Background()
+	e, cl := newTestEngine(t)
+	var payloads []*engine.ExecutableData
+	for i := 0; i < 4; i++ {
+		payloads = append(payloads, buildBlock(t, cl, testSettings()))
+	}
+	require.NoError(t, SetForkchoice(ctx, cl, 0, 1, 4))
+
+	require.ErrorContains(t, Reorg(ctx, cl, 5), "cannot reorg to 5 > latest (4)")
+	require.ErrorContains(t, Reorg(ctx, cl, 0), "cannot reorg to 0 < safe (1)")
+
+	require.NoError(t, Reorg(ctx, cl, 2))
+	status, err := Status(ctx, cl)
+	require.NoError(t, err)
+	require.Equal(t, payloads[1].BlockHash, status.Head.Hash)
+	require.Equal(t, payloads[0].BlockHash, status.Safe.Hash)
+
+	// a new block on the reorged chain replaces the old block at the same height
+	settings := testSettings()
+	settings.Random = common.Hash{0xcc}
+	payload := buildBlock(t, cl, settings)
+	require.Equal(t, uint64(3), payload.Number)
+	require.Equal(t, payloads[2].Timestamp, payload.Timestamp)
+	require.NotEqual(t, payloads[2].BlockHash, payload.BlockHash)
+
+	e.ignoreReorg = true
+	require.ErrorContains(t, Reorg(ctx, cl, 2), "engine ignored reorg to 2, latest block is 3")
+}
+
+func TestCheckForcedTransactions(t *testing.T) {
+	a, b, c := testDeposit(1), testDeposit(2), testDeposit(3)
+	encode := func(txs ...*types.Transaction) [][]byte {
+		encoded, err := encodeTransactions(txs)
+		require.NoError(t, err)
+		out := make([][]byte, len(encoded))
+		for i := range encoded {
+			out[i] = encoded[i]
+		}
+		return out
+	}
+	require.NoError(t, checkForcedTransactions(encode(a, b, c), []*types.Transaction{a, b}))
+	require.NoError(t, checkForcedTransactions(encode(a), nil))
+	require.ErrorContains(t, checkForcedTransactions(encode(b, a), []*types.Transaction{a, b}), "at index 0")
+	require.ErrorContains(t, checkForcedTransactions(encode(a, c, b), []*types.Transaction{a, b}), "at index 1")
+	require.ErrorContains(t, checkForcedTransactions(encode(a), []*types.Transaction{a, b}), "but 2 were forced")
+	require.ErrorContains(t, checkForcedTransactions([][]byte{{0x7e}}, []*types.Transaction{a}), "failed to decode")
+}
+
+func TestBuildBlockTimeout(t *testing.T) {
+	_, cl := newTestEngine(t)
+	status, err := Status(context.Background(), cl)
+	require.NoError(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	settings := testSettings()
+	settings.BuildTime = time.Minute
+	_, err = BuildBlock(ctx, cl, status, settings)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -0,0 +1,189 @@
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/client"
+)
+
+// Scenario is a sequence of steps that are applied to an engine, to reproduce its behavior deterministically.
+// Timestamps of blocks only depend on the head block and the block time, and not on the time the scenario runs.
+type Scenario struct {
+	Steps []ScenarioStep `json:"steps"`
+}
+
+// ScenarioStep is a single step of a scenario. Exactly one of the steps must be set.
+type ScenarioStep struct {
+	Build      *BuildStep      `json:"build,omitempty"`
+	Reorg      *ReorgStep      `json:"reorg,omitempty"`
+	Forkchoice *ForkchoiceStep `json:"forkchoice,omitempty"`
+	Gap        *GapStep        `json:"gap,omitempty"`
+}
+
+// BuildStep builds blocks on top of the head block.
+type BuildStep struct {
+	// Count is the number of blocks to build, 1 if not set.
+	Count uint64 `json:"count,omitempty"`
+	// Transactions are forced into the first block, as hex strings, transaction objects or deposit transaction objects.
+	Transactions []json.RawMessage `json:"transactions,omitempty"`
+	// NoTxPool builds blocks with only the forced transactions.
+	NoTxPool bool `json:"noTxPool,omitempty"`
+	// GasLimit is the exact gas limit of the blocks.
+	GasLimit *uint64 `json:"gasLimit,omitempty"`
+	// Random overrides the randao value of the blocks, e.g. to build a different block on a reorged chain.
+	Random *common.Hash `json:"random,omitempty"`
+	// FeeRecipient overrides the fee recipient of the blocks.
+	FeeRecipient *common.Address `json:"feeRecipient,omitempty"`
+}
+
+// ReorgStep sets the head to an ancestor of the head block.
+type ReorgStep struct {
+	// Depth is the number of blocks to go back from the head block.
+	Depth uint64 `json:"depth"`
+}
+
+// ForkchoiceStep sets the unsafe, safe and finalized blocks by number.
+type ForkchoiceStep struct {
+	Unsafe    uint64 `json:"unsafe"`
+	Safe      uint64 `json:"safe"`
+	Finalized uint64 `json:"finalized"`
+}
+
+// GapStep skips slots before the next block that is built, like missed slots on the beacon chain.
+type GapStep struct {
+	Slots uint64 `json:"slots"`
+}
+
+// ReadScenario reads a JSON scenario from the file at the given path.
+func ReadScenario(path string) (*Scenario, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read scenario: %w", err)
+	}
+	var scenario Scenario
+	if err := json.Unmarshal(data, &scenario); err != nil {
+		return nil, fmt.Errorf("failed to decode scenario: %w", err)
+	}
+	for i, step := range scenario.Steps {
+		if err := step.check(); err != nil {
+			return nil, fmt.Errorf("invalid step %d: %w", i, err)
+		}
+	}
+	return &scenario, nil
+}
+
+func (s *ScenarioStep) check() error {
+	n := 0
+	if s.Build != nil {
+		n++
+	}
+	if s.Reorg != nil {
+		n++
+	}
+	if s.Forkchoice != nil {
+		n++
+	}
+	if s.Gap != nil {
+		n++
+	}
+	if n != 1 {
+		return errors.New("expected exactly one of build, reorg, forkchoice or gap")
+	}
+	return nil
+}
+
+// RunScenario applies the steps of the scenario to the engine, and writes the hash of every block that it builds.
+// The settings are the defaults of every build step. Gaps are only made by the gap steps of the scenario.
+func RunScenario(ctx context.Context, client client.RPC, log log.Logger, scenario *Scenario, settings *BlockBuildingSettings, w io.Writer) error {
+	skipSlots := uint64(0)
+	for i, step := range scenario.Steps {
+		if err := step.check(); err != nil {
+			return fmt.Errorf("invalid step %d: %w", i, err)
+		}
+		switch {
+		case step.Build != nil:
+			stepSettings, err := step.Build.settings(settings)
+			if err != nil {
+				return fmt.Errorf("invalid build step %d: %w", i, err)
+			}
+			count := step.Build.Count
+			if count == 0 {
+				count = 1
+			}
+			for j := uint64(0); j < count; j++ {
+				status, err := Status(ctx, client)
+				if err != nil {
+					return fmt.Errorf("step %d: %w", i, err)
+				}
+				stepSettings.SkipSlots = skipSlots
+				payload, err := BuildBlock(ctx, client, status, stepSettings)
+				if err != nil {
+					return fmt.Errorf("step %d: failed to build block %d: %w", i, j, err)
+				}
+				skipSlots = 0
+				stepSettings.Transactions = nil
+				log.Info("built block", "step", i, "hash", payload.BlockHash, "number", payload.Number,
+					"timestamp", payload.Timestamp, "txs", len(payload.Transactions), "gas", payload.GasUsed)
+				if _, err := fmt.Fprintln(w, payload.BlockHash); err != nil {
+					return err
+				}
+			}
+		case step.Reorg != nil:
+			status, err := Status(ctx, client)
+			if err != nil {
+				return fmt.Errorf("step %d: %w", i, err)
+			}
+			if step.Reorg.Depth > status.Head.Number {
+				return fmt.Errorf("step %d: cannot reorg %d blocks deep at block %d", i, step.Reorg.Depth, status.Head.Number)
+			}
+			number := status.Head.Number - step.Reorg.Depth
+			if err := Reorg(ctx, client, number); err != nil {
+				return fmt.Errorf("step %d: %w", i, err)
+			}
+			log.Info("reorged", "step", i, "from", status.Head, "to", number)
+		case step.Forkchoice != nil:
+			fc := step.Forkchoice
+			if err := SetForkchoice(ctx, client, fc.Finalized, fc.Safe, fc.Unsafe); err != nil {
+				return fmt.Errorf("step %d: %w", i, err)
+			}
+			log.Info("set forkchoice", "step", i, "unsafe", fc.Unsafe, "safe", fc.Safe, "finalized", fc.Finalized)
+		case step.Gap != nil:
+			skipSlots += step.Gap.Slots
+			log.Info("skipping slots", "step", i, "slots", step.Gap.Slots)
+		}
+	}
+	return nil
+}
+
+// settings returns the block building settings of the step, with the given settings as defaults.
+func (b *BuildStep) settings(defaults *BlockBuildingSettings) (*BlockBuildingSettings, error) {
+	settings := *defaults
+	settings.AllowGaps = false
+	settings.Transactions = nil
+	for _, value := range b.Transactions {
+		txs, err := DecodeTransactions(value)
+		if err != nil {
+			return nil, err
+		}
+		settings.Transactions = append(settings.Transactions, txs...)
+	}
+	settings.NoTxPool = b.NoTxPool
+	if b.GasLimit != nil {
+		settings.GasLimit = b.GasLimit
+	}
+	if b.Random != nil {
+		settings.Random = *b.Random
+	}
+	if b.FeeRecipient != nil {
+		settings.FeeRecipient = *b.FeeRecipient
+	}
+	return &settings, nil
+}
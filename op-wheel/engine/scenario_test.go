@@ -0,0 +1,99 @@
+package engine
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func writeScenario(t *testing.T, scenario string) string {
+	path := filepath.Join(t.TempDir(), "scenario.json")
+	require.NoError(t, os.WriteFile(path, []byte(scenario), 0o600))
+	return path
+}
+
+func TestReadScenario(t *testing.T) {
+	_, err := ReadScenario(writeScenario(t, `{"steps": [{"build": {}}, {"reorg": {"depth": 1}, "gap": {"slots": 1}}]}`))
+	require.ErrorContains(t, err, "invalid step 1: expected exactly one of build, reorg, forkchoice or gap")
+	_, err = ReadScenario(writeScenario(t, `{"steps": [{}]}`))
+	require.ErrorContains(t, err, "invalid step 0")
+	_, err = ReadScenario(writeScenario(t, `{"steps": [`))
+	require.ErrorContains(t, err, "failed to decode scenario")
+}
+
+func TestRunScenario(t *testing.T) {
+	e, cl := newTestEngine(t)
+	e.pool = []*types.Transaction{testDeposit(9)}
+	deposit, err := json.Marshal(testDeposit(1))
+	require.NoError(t, err)
+	raw, err := testDeposit(2).MarshalBinary()
+	require.NoError(t, err)
+
+	scenario, err := ReadScenario(writeScenario(t, `{"steps": [
+		{"build": {"count": 3}},
+		{"forkchoice": {"unsafe": 3, "safe": 2, "finalized": 1}},
+		{"reorg": {"depth": 1}},
+		{"gap": {"slots": 2}},
+		{"build": {"count": 2, "noTxPool": true, "random": "0x00000000000000000000000000000000000000000000000000000000000000cc",
+			"transactions": [`+string(deposit)+`, ["`+hexutil.Encode(raw)+`"]]}}
+	]}`))
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	require.NoError(t, RunScenario(context.Background(), cl, testlog.Logger(t, log.LvlInfo), scenario, testSettings(), &out))
+
+	lines := strings.Fields(out.String())
+	require.Len(t, lines, 5)
+	var hashes []common.Hash
+	for _, line := range lines {
+		hashes = append(hashes, common.HexToHash(line))
+	}
+	// the blocks after the reorg replace block 3 and extend the chain, after a gap of 2 slots
+	first := e.blocks[hashes[3]]
+	require.Equal(t, uint64(3), first.NumberU64())
+	require.Equal(t, hashes[1], first.ParentHash())
+	require.Equal(t, e.blocks[hashes[1]].Time()+2*3, first.Time())
+	require.Equal(t, common.HexToHash("0xcc"), first.MixDigest())
+	// the forced transactions are only included in the first block of the step, in order
+	require.Len(t, first.Transactions(), 2)
+	require.Equal(t, testDeposit(1).Hash(), first.Transactions()[0].Hash())
+	require.Equal(t, testDeposit(2).Hash(), first.Transactions()[1].Hash())
+	second := e.blocks[hashes[4]]
+	require.Equal(t, first.Hash(), second.ParentHash())
+	require.Equal(t, first.Time()+2, second.Time())
+	require.Empty(t, second.Transactions())
+
+	// the blocks before the reorg include the tx pool
+	require.Len(t, e.blocks[hashes[0]].Transactions(), 1)
+	require.Equal(t, hashes[4], e.head)
+	require.Equal(t, hashes[1], e.safe)
+	require.Equal(t, hashes[0], e.finalized)
+}
+
+func TestRunScenarioErrors(t *testing.T) {
+	ctx := context.Background()
+	logger := testlog.Logger(t, log.LvlInfo)
+	_, cl := newTestEngine(t)
+
+	scenario := &Scenario{Steps: []ScenarioStep{{Reorg: &ReorgStep{Depth: 1}}}}
+	require.ErrorContains(t, RunScenario(ctx, cl, logger, scenario, testSettings(), new(bytes.Buffer)), "step 0: cannot reorg 1 blocks deep at block 0")
+
+	scenario = &Scenario{Steps: []ScenarioStep{{Build: &BuildStep{Transactions: []json.RawMessage{json.RawMessage(`"0x1234"`)}}}}}
+	require.ErrorContains(t, RunScenario(ctx, cl, logger, scenario, testSettings(), new(bytes.Buffer)), "invalid build step 0")
+
+	scenario = &Scenario{Steps: []ScenarioStep{{Build: &BuildStep{}, Gap: &GapStep{Slots: 1}}}}
+	require.ErrorContains(t, RunScenario(ctx, cl, logger, scenario, testSettings(), new(bytes.Buffer)), "invalid step 0")
+}
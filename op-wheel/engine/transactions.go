@@ -0,0 +1,158 @@
+package engine
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// ReadTransactions reads transactions to include in a block. The input is either whitespace separated
+// hex-encoded transactions, as returned by eth_getRawTransactionByHash, or a sequence of JSON values.
+// JSON values may be hex strings, transaction objects with signature, deposit transaction objects,
+// or arrays of these.
+func ReadTransactions(r io.Reader) ([]*types.Transaction, error) {
+	br := bufio.NewReader(r)
+	first, err := peekNonSpace(br)
+	if errors.Is(err, io.EOF) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var txs []*types.Transaction
+	if first != '[' && first != '{' && first != '"' {
+		data, err := io.ReadAll(br)
+		if err != nil {
+			return nil, err
+		}
+		for i, field := range strings.Fields(string(data)) {
+			tx, err := decodeRawTransaction(field)
+			if err != nil {
+				return nil, fmt.Errorf("invalid transaction %d: %w", i, err)
+			}
+			txs = append(txs, tx)
+		}
+		return txs, nil
+	}
+
+	dec := json.NewDecoder(br)
+	for {
+		var value json.RawMessage
+		if err := dec.Decode(&value); errors.Is(err, io.EOF) {
+			return txs, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("invalid JSON after %d transactions: %w", len(txs), err)
+		}
+		decoded, err := DecodeTransactions(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid transaction after %d transactions: %w", len(txs), err)
+		}
+		txs = append(txs, decoded...)
+	}
+}
+
+// DecodeTransactions decodes a JSON hex string, transaction object, or array of these.
+func DecodeTransactions(value json.RawMessage) ([]*types.Transaction, error) {
+	value = bytes.TrimSpace(value)
+	if len(value) == 0 {
+		return nil, errors.New("empty transaction")
+	}
+	switch value[0] {
+	case '[':
+		var values []json.RawMessage
+		if err := json.Unmarshal(value, &values); err != nil {
+			return nil, err
+		}
+		var txs []*types.Transaction
+		for _, v := range values {
+			decoded, err := DecodeTransactions(v)
+			if err != nil {
+				return nil, err
+			}
+			txs = append(txs, decoded...)
+		}
+		return txs, nil
+	case '"':
+		var raw string
+		if err := json.Unmarshal(value, &raw); err != nil {
+			return nil, err
+		}
+		tx, err := decodeRawTransaction(raw)
+		if err != nil {
+			return nil, err
+		}
+		return []*types.Transaction{tx}, nil
+	default:
+		var tx types.Transaction
+		if err := json.Unmarshal(value, &tx); err != nil {
+			return nil, err
+		}
+		return []*types.Transaction{&tx}, nil
+	}
+}
+
+func decodeRawTransaction(raw string) (*types.Transaction, error) {
+	data, err := hexutil.Decode(raw)
+	if err != nil {
+		return nil, err
+	}
+	var tx types.Transaction
+	if err := tx.UnmarshalBinary(data); err != nil {
+		return nil, err
+	}
+	return &tx, nil
+}
+
+func encodeTransactions(txs []*types.Transaction) ([]hexutil.Bytes, error) {
+	out := make([]hexutil.Bytes, 0, len(txs))
+	for i, tx := range txs {
+		data, err := tx.MarshalBinary()
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode transaction %d: %w", i, err)
+		}
+		out = append(out, data)
+	}
+	return out, nil
+}
+
+// checkForcedTransactions checks that the block starts with the forced transactions, in the order they were forced.
+func checkForcedTransactions(included [][]byte, forced []*types.Transaction) error {
+	if len(included) < len(forced) {
+		return fmt.Errorf("engine included %d transactions, but %d were forced", len(included), len(forced))
+	}
+	for i, tx := range forced {
+		var got types.Transaction
+		if err := got.UnmarshalBinary(included[i]); err != nil {
+			return fmt.Errorf("failed to decode included transaction %d: %w", i, err)
+		}
+		if got.Hash() != tx.Hash() {
+			return fmt.Errorf("engine included transaction %s at index %d, but %s was forced", got.Hash(), i, tx.Hash())
+		}
+	}
+	return nil
+}
+
+// peekNonSpace skips leading whitespace, and returns the next byte without consuming it.
+func peekNonSpace(r *bufio.Reader) (byte, error) {
+	for {
+		b, err := r.Peek(1)
+		if err != nil {
+			return 0, err
+		}
+		switch b[0] {
+		case ' ', '\t', '\r', '\n':
+			if _, err := r.ReadByte(); err != nil {
+				return 0, err
+			}
+		default:
+			return b[0], nil
+		}
+	}
+}
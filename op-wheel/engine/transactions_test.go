@@ -0,0 +1,59 @@
+package engine
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestReadTransactions(t *testing.T) {
+	a, b, c := testDeposit(1), testDeposit(2), testDeposit(3)
+	rawA, err := a.MarshalBinary()
+	require.NoError(t, err)
+	rawB, err := b.MarshalBinary()
+	require.NoError(t, err)
+	objC, err := json.Marshal(c)
+	require.NoError(t, err)
+
+	hashes := func(txs []*types.Transaction) []common.Hash {
+		var out []common.Hash
+		for _, tx := range txs {
+			out = append(out, tx.Hash())
+		}
+		return out
+	}
+
+	t.Run("Raw", func(t *testing.T) {
+		txs, err := ReadTransactions(strings.NewReader("\n  " + hexutil.Encode(rawA) + "\n\t" + hexutil.Encode(rawB) + " \n"))
+		require.NoError(t, err)
+		require.Equal(t, []common.Hash{a.Hash(), b.Hash()}, hashes(txs))
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		input := `"` + hexutil.Encode(rawA) + `" ` + string(objC) + ` ["` + hexutil.Encode(rawB) + `", ` + string(objC) + `]`
+		txs, err := ReadTransactions(strings.NewReader(input))
+		require.NoError(t, err)
+		require.Equal(t, []common.Hash{a.Hash(), c.Hash(), b.Hash(), c.Hash()}, hashes(txs))
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		txs, err := ReadTransactions(strings.NewReader(" \n"))
+		require.NoError(t, err)
+		require.Empty(t, txs)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := ReadTransactions(strings.NewReader(hexutil.Encode(rawA) + " 0x1234"))
+		require.ErrorContains(t, err, "invalid transaction 1")
+		_, err = ReadTransactions(strings.NewReader(`"` + hexutil.Encode(rawA) + `" {"type": `))
+		require.ErrorContains(t, err, "invalid JSON after 1 transactions")
+		_, err = ReadTransactions(strings.NewReader(`[{}]`))
+		require.ErrorContains(t, err, "invalid transaction after 0 transactions")
+	})
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	monitor "github.com/ethereum-optimism/optimism/op-withdrawal-monitor"
+	"github.com/ethereum-optimism/optimism/op-withdrawal-monitor/flags"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	Version   = ""
+	GitCommit = ""
+	GitDate   = ""
+)
+
+func main() {
+	oplog.SetupDefaults()
+
+	app := cli.NewApp()
+	app.Flags = flags.Flags
+	app.Version = fmt.Sprintf("%s-%s-%s", Version, GitCommit, GitDate)
+	app.Name = "op-withdrawal-monitor"
+	app.Usage = "Withdrawal lifecycle monitor"
+	app.Description = "Service that periodically withdraws from L2 to L1, and records the latency of each stage of the withdrawal"
+	app.Action = monitor.Main(app.Version)
+	err := app.Run(os.Args)
+	if err != nil {
+		log.Crit("Application failed", "message", err)
+	}
+}
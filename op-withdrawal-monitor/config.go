@@ -0,0 +1,99 @@
+package op_withdrawal_monitor
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum-optimism/optimism/op-withdrawal-monitor/flags"
+)
+
+type Config struct {
+	// L2EthRpc is the L2 execution engine RPC, used to initiate withdrawals and to generate withdrawal proofs
+	L2EthRpc string
+
+	L2OOAddress           common.Address
+	OptimismPortalAddress common.Address
+
+	WithdrawalInterval time.Duration
+	WithdrawalValue    *big.Int
+	WithdrawalGasLimit uint64
+
+	PollInterval    time.Duration
+	ProposalTimeout time.Duration
+
+	// TxMgrConfig is used to send the L1 prove and finalize transactions.
+	// The same account initiates the withdrawals on L2.
+	TxMgrConfig txmgr.CLIConfig
+
+	Log oplog.CLIConfig
+
+	Metrics opmetrics.CLIConfig
+}
+
+func (c Config) Check() error {
+	if c.L2EthRpc == "" {
+		return errors.New("must specify a L2 RPC url")
+	}
+	if c.L2OOAddress == (common.Address{}) {
+		return errors.New("must specify the L2OutputOracle address")
+	}
+	if c.OptimismPortalAddress == (common.Address{}) {
+		return errors.New("must specify the OptimismPortal address")
+	}
+	if c.WithdrawalInterval <= 0 {
+		return errors.New("withdrawal interval must be positive")
+	}
+	if c.PollInterval <= 0 {
+		return errors.New("poll interval must be positive")
+	}
+	if c.WithdrawalGasLimit == 0 {
+		return errors.New("withdrawal gas limit must be positive")
+	}
+	if err := c.TxMgrConfig.Check(); err != nil {
+		return fmt.Errorf("invalid txmgr config: %w", err)
+	}
+	if err := c.Metrics.Check(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func NewConfig(ctx *cli.Context) (Config, error) {
+	l2OOAddress, err := parseAddress(ctx.String(flags.L2OOAddressFlag.Name))
+	if err != nil {
+		return Config{}, fmt.Errorf("invalid %s: %w", flags.L2OOAddressFlag.Name, err)
+	}
+	portalAddress, err := parseAddress(ctx.String(flags.OptimismPortalAddressFlag.Name))
+	if err != nil {
+		return Config{}, fmt.Errorf("invalid %s: %w", flags.OptimismPortalAddressFlag.Name, err)
+	}
+	return Config{
+		L2EthRpc:              ctx.String(flags.L2EthRpcFlag.Name),
+		L2OOAddress:           l2OOAddress,
+		OptimismPortalAddress: portalAddress,
+		WithdrawalInterval:    ctx.Duration(flags.WithdrawalIntervalFlag.Name),
+		WithdrawalValue:       new(big.Int).SetUint64(ctx.Uint64(flags.WithdrawalValueFlag.Name)),
+		WithdrawalGasLimit:    ctx.Uint64(flags.WithdrawalGasLimitFlag.Name),
+		PollInterval:          ctx.Duration(flags.PollIntervalFlag.Name),
+		ProposalTimeout:       ctx.Duration(flags.ProposalTimeoutFlag.Name),
+		TxMgrConfig:           txmgr.ReadCLIConfig(ctx),
+		Log:                   oplog.ReadCLIConfig(ctx),
+		Metrics:               opmetrics.ReadCLIConfig(ctx),
+	}, nil
+}
+
+func parseAddress(address string) (common.Address, error) {
+	if !common.IsHexAddress(address) {
+		return common.Address{}, fmt.Errorf("not a valid address: %q", address)
+	}
+	return common.HexToAddress(address), nil
+}
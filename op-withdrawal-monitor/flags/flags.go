@@ -0,0 +1,94 @@
+package flags
+
+import (
+	"time"
+
+	"github.com/urfave/cli/v2"
+
+	opservice "github.com/ethereum-optimism/optimism/op-service"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+)
+
+const envPrefix = "OP_WITHDRAWAL_MONITOR"
+
+func prefixEnvVars(name string) []string {
+	return opservice.PrefixEnvVar(envPrefix, name)
+}
+
+var (
+	L2EthRpcFlag = &cli.StringFlag{
+		Name:     "l2-eth-rpc",
+		Usage:    "HTTP provider URL for L2. Must support eth_getProof",
+		Required: true,
+		EnvVars:  prefixEnvVars("L2_ETH_RPC"),
+	}
+	L2OOAddressFlag = &cli.StringFlag{
+		Name:     "l2oo-address",
+		Usage:    "Address of the L2OutputOracle contract",
+		Required: true,
+		EnvVars:  prefixEnvVars("L2OO_ADDRESS"),
+	}
+	OptimismPortalAddressFlag = &cli.StringFlag{
+		Name:     "portal-address",
+		Usage:    "Address of the OptimismPortal contract",
+		Required: true,
+		EnvVars:  prefixEnvVars("PORTAL_ADDRESS"),
+	}
+	WithdrawalIntervalFlag = &cli.DurationFlag{
+		Name:    "withdrawal-interval",
+		Usage:   "Interval between the start of new withdrawals",
+		Value:   time.Hour,
+		EnvVars: prefixEnvVars("WITHDRAWAL_INTERVAL"),
+	}
+	WithdrawalValueFlag = &cli.Uint64Flag{
+		Name:    "withdrawal-value",
+		Usage:   "Value in wei of each withdrawal",
+		Value:   1,
+		EnvVars: prefixEnvVars("WITHDRAWAL_VALUE"),
+	}
+	WithdrawalGasLimitFlag = &cli.Uint64Flag{
+		Name:    "withdrawal-gas-limit",
+		Usage:   "Gas limit of the withdrawal transaction when finalized on L1",
+		Value:   21_000,
+		EnvVars: prefixEnvVars("WITHDRAWAL_GAS_LIMIT"),
+	}
+	PollIntervalFlag = &cli.DurationFlag{
+		Name:    "poll-interval",
+		Usage:   "Interval between checks of the stages of the pending withdrawals",
+		Value:   time.Minute,
+		EnvVars: prefixEnvVars("POLL_INTERVAL"),
+	}
+	ProposalTimeoutFlag = &cli.DurationFlag{
+		Name:    "proposal-timeout",
+		Usage:   "Time after which a withdrawal that is not covered by an output proposal yet is reported as timed out",
+		Value:   2 * time.Hour,
+		EnvVars: prefixEnvVars("PROPOSAL_TIMEOUT"),
+	}
+)
+
+var requiredFlags = []cli.Flag{
+	L2EthRpcFlag,
+	L2OOAddressFlag,
+	OptimismPortalAddressFlag,
+}
+
+var optionalFlags = []cli.Flag{
+	WithdrawalIntervalFlag,
+	WithdrawalValueFlag,
+	WithdrawalGasLimitFlag,
+	PollIntervalFlag,
+	ProposalTimeoutFlag,
+}
+
+// Flags contains the list of configuration options available to the binary.
+var Flags []cli.Flag
+
+func init() {
+	optionalFlags = append(optionalFlags, oplog.CLIFlags(envPrefix)...)
+	optionalFlags = append(optionalFlags, opmetrics.CLIFlags(envPrefix)...)
+	optionalFlags = append(optionalFlags, txmgr.CLIFlags(envPrefix)...)
+
+	Flags = append(requiredFlags, optionalFlags...)
+}
@@ -0,0 +1,122 @@
+package op_withdrawal_monitor
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const MetricsNamespace = "op_withdrawal_monitor"
+
+// Stages of the withdrawal lifecycle
+const (
+	StageInitiated = "initiated"
+	StageProposed  = "proposed"
+	StageProven    = "proven"
+	StageFinalized = "finalized"
+)
+
+var stages = []string{StageInitiated, StageProposed, StageProven, StageFinalized}
+
+type Metrics interface {
+	// RecordStage records the time from the start of a withdrawal until it reached the given stage
+	RecordStage(stage string, latency time.Duration)
+	RecordStageFailure(stage string)
+	RecordStageTimeout(stage string)
+	// RecordPending records the number of withdrawals waiting to reach each stage
+	RecordPending(pending map[string]int)
+	RecordLatestOutput(l2BlockNumber uint64, age time.Duration)
+	RecordVersion(version string)
+}
+
+type metrics struct {
+	stageLatency  *prometheus.HistogramVec
+	stageFailures *prometheus.CounterVec
+	stageTimeouts *prometheus.CounterVec
+	pending       *prometheus.GaugeVec
+	latestOutput  prometheus.Gauge
+	outputAge     prometheus.Gauge
+	version       *prometheus.GaugeVec
+}
+
+func NewMetrics(r *prometheus.Registry) Metrics {
+	return &metrics{
+		stageLatency: promauto.With(r).NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: MetricsNamespace,
+			Name:      "stage_latency_seconds",
+			Help:      "Time from the start of a withdrawal until it reached a stage, by stage",
+			Buckets: []float64{
+				10, 30, 60, 300, 600, 1800, 3600, 2 * 3600, 4 * 3600, 12 * 3600,
+				24 * 3600, 3 * 24 * 3600, 7 * 24 * 3600, 8 * 24 * 3600, 10 * 24 * 3600,
+			},
+		}, []string{
+			"stage",
+		}),
+		stageFailures: promauto.With(r).NewCounterVec(prometheus.CounterOpts{
+			Namespace: MetricsNamespace,
+			Name:      "stage_failures_total",
+			Help:      "Count of failed attempts to advance a withdrawal to a stage, by stage",
+		}, []string{
+			"stage",
+		}),
+		stageTimeouts: promauto.With(r).NewCounterVec(prometheus.CounterOpts{
+			Namespace: MetricsNamespace,
+			Name:      "stage_timeouts_total",
+			Help:      "Count of withdrawals that did not reach a stage in time, by stage",
+		}, []string{
+			"stage",
+		}),
+		pending: promauto.With(r).NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: MetricsNamespace,
+			Name:      "pending_withdrawals",
+			Help:      "Number of withdrawals waiting to reach a stage, by stage",
+		}, []string{
+			"stage",
+		}),
+		latestOutput: promauto.With(r).NewGauge(prometheus.GaugeOpts{
+			Namespace: MetricsNamespace,
+			Name:      "latest_output_l2_block",
+			Help:      "L2 block number of the latest output proposal",
+		}),
+		outputAge: promauto.With(r).NewGauge(prometheus.GaugeOpts{
+			Namespace: MetricsNamespace,
+			Name:      "latest_output_age_seconds",
+			Help:      "Time since the latest output proposal was submitted to L1",
+		}),
+		version: promauto.With(r).NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: MetricsNamespace,
+			Name:      "version",
+			Help:      "version pseudo-metrics",
+		}, []string{
+			"version",
+		}),
+	}
+}
+
+func (m *metrics) RecordStage(stage string, latency time.Duration) {
+	m.stageLatency.WithLabelValues(stage).Observe(latency.Seconds())
+}
+
+func (m *metrics) RecordStageFailure(stage string) {
+	m.stageFailures.WithLabelValues(stage).Inc()
+}
+
+func (m *metrics) RecordStageTimeout(stage string) {
+	m.stageTimeouts.WithLabelValues(stage).Inc()
+}
+
+func (m *metrics) RecordPending(pending map[string]int) {
+	for _, stage := range stages {
+		m.pending.WithLabelValues(stage).Set(float64(pending[stage]))
+	}
+}
+
+func (m *metrics) RecordLatestOutput(l2BlockNumber uint64, age time.Duration) {
+	m.latestOutput.Set(float64(l2BlockNumber))
+	m.outputAge.Set(age.Seconds())
+}
+
+func (m *metrics) RecordVersion(version string) {
+	m.version.WithLabelValues(version).Set(1)
+}
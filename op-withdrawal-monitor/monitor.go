@@ -0,0 +1,501 @@
+package op_withdrawal_monitor
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/ethclient/gethclient"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-bindings/predeploys"
+	"github.com/ethereum-optimism/optimism/op-chain-ops/crossdomain"
+	"github.com/ethereum-optimism/optimism/op-node/withdrawals"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+)
+
+// OutputOracle is the subset of the L2OutputOracle bindings used by the monitor.
+type OutputOracle interface {
+	LatestBlockNumber(opts *bind.CallOpts) (*big.Int, error)
+	LatestOutputIndex(opts *bind.CallOpts) (*big.Int, error)
+	GetL2Output(opts *bind.CallOpts, l2OutputIndex *big.Int) (bindings.TypesOutputProposal, error)
+	GetL2OutputIndexAfter(opts *bind.CallOpts, l2BlockNumber *big.Int) (*big.Int, error)
+}
+
+// Portal is the subset of the OptimismPortal bindings used by the monitor.
+type Portal interface {
+	ProvenWithdrawals(opts *bind.CallOpts, withdrawalHash [32]byte) (struct {
+		OutputRoot    [32]byte
+		Timestamp     *big.Int
+		L2OutputIndex *big.Int
+	}, error)
+	FinalizedWithdrawals(opts *bind.CallOpts, withdrawalHash [32]byte) (bool, error)
+}
+
+type HeaderClient interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+// L2Client is the subset of the L2 client used by the monitor.
+type L2Client interface {
+	HeaderClient
+	FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error)
+}
+
+// recoverRangeSize is the number of L2 blocks that are searched for withdrawals with a single logs query.
+const recoverRangeSize = 10_000
+
+// ProofFn generates the parameters to prove the withdrawal initiated by the given L2 tx,
+// against the output proposal of the given L2 block header.
+type ProofFn func(ctx context.Context, txHash common.Hash, header *types.Header) (withdrawals.ProvenWithdrawalParameters, error)
+
+// pendingWithdrawal is a withdrawal started by the monitor, that has not been finalized yet.
+type pendingWithdrawal struct {
+	txHash  common.Hash
+	l2Block *big.Int
+	hash    common.Hash
+	tx      bindings.TypesWithdrawalTransaction
+
+	startedAt time.Time
+	// next stage to reach
+	stage string
+	// whether the proposal timeout was reported already
+	proposalTimedOut bool
+	// L1 timestamp at which the withdrawal was proven, from which the finalization period starts
+	provenTimestamp uint64
+}
+
+// Monitor periodically starts small withdrawals, and tracks them through the output proposal,
+// prove and finalize stages of the withdrawal lifecycle.
+// Pending withdrawals are kept in memory, and recovered from L2 on startup.
+type Monitor struct {
+	log     log.Logger
+	cfg     Config
+	metrics Metrics
+
+	l1          HeaderClient
+	l2          L2Client
+	proofParams ProofFn
+
+	oracle OutputOracle
+	portal Portal
+
+	portalABI *abi.ABI
+	passerABI *abi.ABI
+
+	l1Tx txmgr.TxManager
+	l2Tx txmgr.TxManager
+
+	finalizationPeriod uint64
+
+	pending []*pendingWithdrawal
+}
+
+func NewMonitor(ctx context.Context, l log.Logger, cfg Config, m Metrics, l1 *ethclient.Client, l2 *ethclient.Client, proofCl *gethclient.Client, l1Tx txmgr.TxManager, l2Tx txmgr.TxManager) (*Monitor, error) {
+	oracle, err := bindings.NewL2OutputOracleCaller(cfg.L2OOAddress, l1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to bind L2OutputOracle: %w", err)
+	}
+	portal, err := bindings.NewOptimismPortalCaller(cfg.OptimismPortalAddress, l1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to bind OptimismPortal: %w", err)
+	}
+	period, err := oracle.FINALIZATIONPERIODSECONDS(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch finalization period: %w", err)
+	}
+	proofParams := func(ctx context.Context, txHash common.Hash, header *types.Header) (withdrawals.ProvenWithdrawalParameters, error) {
+		return withdrawals.ProveWithdrawalParameters(ctx, proofCl, l2, txHash, header, oracle)
+	}
+	return newMonitor(l, cfg, m, l1, l2, proofParams, oracle, portal, l1Tx, l2Tx, period.Uint64())
+}
+
+func newMonitor(l log.Logger, cfg Config, m Metrics, l1 HeaderClient, l2 L2Client, proofParams ProofFn, oracle OutputOracle, portal Portal, l1Tx txmgr.TxManager, l2Tx txmgr.TxManager, finalizationPeriod uint64) (*Monitor, error) {
+	portalABI, err := bindings.OptimismPortalMetaData.GetAbi()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load OptimismPortal ABI: %w", err)
+	}
+	passerABI, err := bindings.L2ToL1MessagePasserMetaData.GetAbi()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load L2ToL1MessagePasser ABI: %w", err)
+	}
+	return &Monitor{
+		log:                l,
+		cfg:                cfg,
+		metrics:            m,
+		l1:                 l1,
+		l2:                 l2,
+		proofParams:        proofParams,
+		oracle:             oracle,
+		portal:             portal,
+		portalABI:          portalABI,
+		passerABI:          passerABI,
+		l1Tx:               l1Tx,
+		l2Tx:               l2Tx,
+		finalizationPeriod: finalizationPeriod,
+	}, nil
+}
+
+// Run recovers the pending withdrawals of a previous run, then starts a withdrawal every withdrawal interval,
+// and advances the pending withdrawals every poll interval, until the context is canceled.
+func (m *Monitor) Run(ctx context.Context) {
+	withdrawalTicker := time.NewTicker(m.cfg.WithdrawalInterval)
+	defer withdrawalTicker.Stop()
+	pollTicker := time.NewTicker(m.cfg.PollInterval)
+	defer pollTicker.Stop()
+
+	if err := m.recover(ctx); err != nil {
+		m.log.Error("failed to recover pending withdrawals", "err", err)
+	}
+	m.initiate(ctx)
+	for {
+		select {
+		case <-withdrawalTicker.C:
+			m.initiate(ctx)
+		case <-pollTicker.C:
+			m.poll(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// initiate starts a new withdrawal on L2, to the same account on L1.
+func (m *Monitor) initiate(ctx context.Context) {
+	startedAt := time.Now()
+	w, err := m.sendWithdrawal(ctx)
+	if err != nil {
+		m.log.Error("failed to initiate withdrawal", "err", err)
+		m.metrics.RecordStageFailure(StageInitiated)
+		return
+	}
+	w.startedAt = startedAt
+	w.stage = StageProposed
+	m.pending = append(m.pending, w)
+	m.metrics.RecordStage(StageInitiated, time.Since(startedAt))
+	m.log.Info("initiated withdrawal", "tx", w.txHash, "l2_block", w.l2Block, "withdrawal_hash", w.hash)
+	m.recordPending()
+}
+
+func (m *Monitor) sendWithdrawal(ctx context.Context) (*pendingWithdrawal, error) {
+	data, err := m.passerABI.Pack("initiateWithdrawal", m.l2Tx.From(), new(big.Int).SetUint64(m.cfg.WithdrawalGasLimit), []byte{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack initiateWithdrawal: %w", err)
+	}
+	receipt, err := m.l2Tx.Send(ctx, txmgr.TxCandidate{
+		TxData: data,
+		To:     &predeploys.L2ToL1MessagePasserAddr,
+		Value:  m.cfg.WithdrawalValue,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to send initiateWithdrawal tx: %w", err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return nil, fmt.Errorf("initiateWithdrawal tx %s failed", receipt.TxHash)
+	}
+	wd, hash, err := parseWithdrawal(receipt)
+	if err != nil {
+		return nil, err
+	}
+	return &pendingWithdrawal{
+		txHash:  receipt.TxHash,
+		l2Block: receipt.BlockNumber,
+		hash:    hash,
+		tx:      wd.WithdrawalTransaction(),
+	}, nil
+}
+
+// recover restores the withdrawals that were initiated by the monitor account before a restart,
+// and that are not finalized yet, from the MessagePassed events of the account on L2.
+// Only the L2 blocks of the last finalization period and proposal timeout are searched:
+// older withdrawals are expected to be finalized already.
+func (m *Monitor) recover(ctx context.Context) error {
+	head, err := m.l2.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to fetch L2 head: %w", err)
+	}
+	if head.Number.Sign() == 0 {
+		return nil
+	}
+	genesis, err := m.l2.HeaderByNumber(ctx, common.Big0)
+	if err != nil {
+		return fmt.Errorf("failed to fetch L2 genesis: %w", err)
+	}
+	// L2 blocks are produced at a fixed interval, so the window start can be derived from the block time
+	blockTime := max((head.Time-genesis.Time)/head.Number.Uint64(), 1)
+	window := (m.finalizationPeriod + uint64(m.cfg.ProposalTimeout/time.Second)) / blockTime
+	end := head.Number.Uint64()
+	var start uint64
+	if window < end {
+		start = end - window
+	}
+
+	sender := common.BytesToHash(m.l2Tx.From().Bytes())
+	for from := start; from <= end; from += recoverRangeSize {
+		to := min(from+recoverRangeSize-1, end)
+		logs, err := m.l2.FilterLogs(ctx, ethereum.FilterQuery{
+			FromBlock: new(big.Int).SetUint64(from),
+			ToBlock:   new(big.Int).SetUint64(to),
+			Addresses: []common.Address{predeploys.L2ToL1MessagePasserAddr},
+			Topics:    [][]common.Hash{{withdrawals.MessagePassedTopic}, nil, {sender}},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to fetch MessagePassed events of blocks %d to %d: %w", from, to, err)
+		}
+		for _, l := range logs {
+			if err := m.recoverWithdrawal(ctx, l); err != nil {
+				return err
+			}
+		}
+	}
+	m.log.Info("recovered pending withdrawals", "count", len(m.pending), "from_l2_block", start, "to_l2_block", end)
+	m.recordPending()
+	return nil
+}
+
+// recoverWithdrawal adds the withdrawal of the MessagePassed event as pending withdrawal, at the stage it has to reach next.
+func (m *Monitor) recoverWithdrawal(ctx context.Context, l types.Log) error {
+	wd, hash, err := parseWithdrawal(&types.Receipt{Logs: []*types.Log{&l}})
+	if err != nil {
+		return fmt.Errorf("failed to recover withdrawal of tx %s: %w", l.TxHash, err)
+	}
+	opts := &bind.CallOpts{Context: ctx}
+	finalized, err := m.portal.FinalizedWithdrawals(opts, hash)
+	if err != nil {
+		return fmt.Errorf("failed to fetch finalized withdrawal: %w", err)
+	}
+	if finalized {
+		return nil
+	}
+	header, err := m.l2.HeaderByNumber(ctx, new(big.Int).SetUint64(l.BlockNumber))
+	if err != nil {
+		return fmt.Errorf("failed to fetch L2 header %d: %w", l.BlockNumber, err)
+	}
+	w := &pendingWithdrawal{
+		txHash:    l.TxHash,
+		l2Block:   header.Number,
+		hash:      hash,
+		tx:        wd.WithdrawalTransaction(),
+		startedAt: time.Unix(int64(header.Time), 0),
+		stage:     StageProposed,
+	}
+	proven, err := m.portal.ProvenWithdrawals(opts, hash)
+	if err != nil {
+		return fmt.Errorf("failed to fetch proven withdrawal: %w", err)
+	}
+	if proven.Timestamp.Sign() != 0 {
+		w.stage = StageFinalized
+		w.provenTimestamp = proven.Timestamp.Uint64()
+	} else {
+		latest, err := m.oracle.LatestBlockNumber(opts)
+		if err != nil {
+			return fmt.Errorf("failed to fetch latest output block: %w", err)
+		}
+		if latest.Cmp(w.l2Block) >= 0 {
+			w.stage = StageProven
+		}
+	}
+	m.pending = append(m.pending, w)
+	m.log.Info("recovered withdrawal", "tx", w.txHash, "l2_block", w.l2Block, "withdrawal_hash", w.hash, "stage", w.stage)
+	return nil
+}
+
+// parseWithdrawal parses the withdrawal from the MessagePassed event in the receipt,
+// and checks that its hash matches the withdrawal hash of the event.
+func parseWithdrawal(receipt *types.Receipt) (*crossdomain.Withdrawal, common.Hash, error) {
+	ev, err := withdrawals.ParseMessagePassed(receipt)
+	if err != nil {
+		return nil, common.Hash{}, fmt.Errorf("failed to parse MessagePassed event: %w", err)
+	}
+	wd := crossdomain.NewWithdrawal(ev.Nonce, &ev.Sender, &ev.Target, ev.Value, ev.GasLimit, ev.Data)
+	hash, err := wd.Hash()
+	if err != nil {
+		return nil, common.Hash{}, fmt.Errorf("failed to hash withdrawal: %w", err)
+	}
+	if hash != ev.WithdrawalHash {
+		return nil, common.Hash{}, fmt.Errorf("computed withdrawal hash %s does not match event withdrawal hash %s", hash, common.Hash(ev.WithdrawalHash))
+	}
+	return wd, hash, nil
+}
+
+// poll advances all pending withdrawals as far as possible, and drops the finalized ones.
+func (m *Monitor) poll(ctx context.Context) {
+	m.recordLatestOutput(ctx)
+
+	remaining := m.pending[:0]
+	for _, w := range m.pending {
+		for w.stage != "" {
+			reached, err := m.advance(ctx, w)
+			if err != nil {
+				m.log.Error("failed to advance withdrawal", "stage", w.stage, "withdrawal_hash", w.hash, "err", err)
+				m.metrics.RecordStageFailure(w.stage)
+				break
+			}
+			if !reached {
+				break
+			}
+			latency := time.Since(w.startedAt)
+			m.metrics.RecordStage(w.stage, latency)
+			m.log.Info("withdrawal reached stage", "stage", w.stage, "withdrawal_hash", w.hash, "latency", latency)
+			w.stage = nextStage(w.stage)
+		}
+		if w.stage != "" {
+			remaining = append(remaining, w)
+		}
+	}
+	m.pending = remaining
+	m.recordPending()
+}
+
+func nextStage(stage string) string {
+	switch stage {
+	case StageProposed:
+		return StageProven
+	case StageProven:
+		return StageFinalized
+	default:
+		return ""
+	}
+}
+
+// advance tries to bring the withdrawal to its next stage, and returns whether it reached it.
+func (m *Monitor) advance(ctx context.Context, w *pendingWithdrawal) (bool, error) {
+	opts := &bind.CallOpts{Context: ctx}
+	switch w.stage {
+	case StageProposed:
+		latest, err := m.oracle.LatestBlockNumber(opts)
+		if err != nil {
+			return false, fmt.Errorf("failed to fetch latest output block: %w", err)
+		}
+		if latest.Cmp(w.l2Block) >= 0 {
+			return true, nil
+		}
+		if !w.proposalTimedOut && time.Since(w.startedAt) > m.cfg.ProposalTimeout {
+			m.log.Warn("withdrawal is not covered by an output proposal in time", "withdrawal_hash", w.hash, "l2_block", w.l2Block, "latest_output_block", latest)
+			m.metrics.RecordStageTimeout(StageProposed)
+			w.proposalTimedOut = true
+		}
+		return false, nil
+	case StageProven:
+		// the withdrawal may be proven already, if a prove tx was included after it timed out
+		proven, err := m.portal.ProvenWithdrawals(opts, w.hash)
+		if err != nil {
+			return false, fmt.Errorf("failed to fetch proven withdrawal: %w", err)
+		}
+		if proven.Timestamp.Sign() == 0 {
+			if err := m.prove(ctx, w); err != nil {
+				return false, err
+			}
+			if proven, err = m.portal.ProvenWithdrawals(opts, w.hash); err != nil {
+				return false, fmt.Errorf("failed to fetch proven withdrawal: %w", err)
+			}
+		}
+		w.provenTimestamp = proven.Timestamp.Uint64()
+		return w.provenTimestamp != 0, nil
+	case StageFinalized:
+		finalized, err := m.portal.FinalizedWithdrawals(opts, w.hash)
+		if err != nil {
+			return false, fmt.Errorf("failed to fetch finalized withdrawal: %w", err)
+		}
+		if finalized {
+			return true, nil
+		}
+		head, err := m.l1.HeaderByNumber(ctx, nil)
+		if err != nil {
+			return false, fmt.Errorf("failed to fetch L1 head: %w", err)
+		}
+		if head.Time <= w.provenTimestamp+m.finalizationPeriod {
+			return false, nil
+		}
+		if err := m.finalize(ctx, w); err != nil {
+			return false, err
+		}
+		return true, nil
+	default:
+		return false, fmt.Errorf("unknown stage %q", w.stage)
+	}
+}
+
+// prove proves the withdrawal against the first output proposal that covers it.
+func (m *Monitor) prove(ctx context.Context, w *pendingWithdrawal) error {
+	opts := &bind.CallOpts{Context: ctx}
+	index, err := m.oracle.GetL2OutputIndexAfter(opts, w.l2Block)
+	if err != nil {
+		return fmt.Errorf("failed to fetch output index: %w", err)
+	}
+	output, err := m.oracle.GetL2Output(opts, index)
+	if err != nil {
+		return fmt.Errorf("failed to fetch output %d: %w", index, err)
+	}
+	header, err := m.l2.HeaderByNumber(ctx, output.L2BlockNumber)
+	if err != nil {
+		return fmt.Errorf("failed to fetch L2 header %d: %w", output.L2BlockNumber, err)
+	}
+	params, err := m.proofParams(ctx, w.txHash, header)
+	if err != nil {
+		return fmt.Errorf("failed to generate withdrawal proof: %w", err)
+	}
+	data, err := m.portalABI.Pack("proveWithdrawalTransaction", w.tx, params.L2OutputIndex, params.OutputRootProof, params.WithdrawalProof)
+	if err != nil {
+		return fmt.Errorf("failed to pack proveWithdrawalTransaction: %w", err)
+	}
+	return m.sendL1(ctx, "proveWithdrawalTransaction", data)
+}
+
+func (m *Monitor) finalize(ctx context.Context, w *pendingWithdrawal) error {
+	data, err := m.portalABI.Pack("finalizeWithdrawalTransaction", w.tx)
+	if err != nil {
+		return fmt.Errorf("failed to pack finalizeWithdrawalTransaction: %w", err)
+	}
+	return m.sendL1(ctx, "finalizeWithdrawalTransaction", data)
+}
+
+func (m *Monitor) sendL1(ctx context.Context, method string, data []byte) error {
+	receipt, err := m.l1Tx.Send(ctx, txmgr.TxCandidate{
+		TxData: data,
+		To:     &m.cfg.OptimismPortalAddress,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to send %s tx: %w", method, err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return fmt.Errorf("%s tx %s failed", method, receipt.TxHash)
+	}
+	m.log.Info("sent L1 tx", "method", method, "tx", receipt.TxHash, "l1_block", receipt.BlockNumber)
+	return nil
+}
+
+// recordLatestOutput records the latest output proposal and its age, to detect delayed proposals
+// independently of the pending withdrawals.
+func (m *Monitor) recordLatestOutput(ctx context.Context) {
+	opts := &bind.CallOpts{Context: ctx}
+	index, err := m.oracle.LatestOutputIndex(opts)
+	if err != nil {
+		m.log.Warn("failed to fetch latest output index", "err", err)
+		return
+	}
+	output, err := m.oracle.GetL2Output(opts, index)
+	if err != nil {
+		m.log.Warn("failed to fetch latest output", "index", index, "err", err)
+		return
+	}
+	// the output timestamp is the L1 time at which the output was proposed
+	age := time.Since(time.Unix(int64(output.Timestamp.Uint64()), 0))
+	m.metrics.RecordLatestOutput(output.L2BlockNumber.Uint64(), age)
+}
+
+func (m *Monitor) recordPending() {
+	pending := make(map[string]int)
+	for _, w := range m.pending {
+		pending[w.stage]++
+	}
+	m.metrics.RecordPending(pending)
+}
@@ -0,0 +1,543 @@
+package op_withdrawal_monitor
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-bindings/predeploys"
+	"github.com/ethereum-optimism/optimism/op-chain-ops/crossdomain"
+	"github.com/ethereum-optimism/optimism/op-node/withdrawals"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr/mocks"
+)
+
+func messagePassedLog(t *testing.T, wd *crossdomain.Withdrawal, hash common.Hash) *types.Log {
+	passerABI, err := bindings.L2ToL1MessagePasserMetaData.GetAbi()
+	require.NoError(t, err)
+	data, err := passerABI.Events["MessagePassed"].Inputs.NonIndexed().Pack(wd.Value, wd.GasLimit, []byte(wd.Data), hash)
+	require.NoError(t, err)
+	return &types.Log{
+		Address: predeploys.L2ToL1MessagePasserAddr,
+		Topics: []common.Hash{
+			withdrawals.MessagePassedTopic,
+			common.BigToHash(wd.Nonce),
+			common.BytesToHash(wd.Sender.Bytes()),
+			common.BytesToHash(wd.Target.Bytes()),
+		},
+		Data: data,
+	}
+}
+
+func TestParseWithdrawal(t *testing.T) {
+	sender := common.Address{0xaa}
+	wd := crossdomain.NewWithdrawal(big.NewInt(42), &sender, &sender, big.NewInt(1), big.NewInt(21_000), []byte{})
+	hash, err := wd.Hash()
+	require.NoError(t, err)
+
+	t.Run("valid", func(t *testing.T) {
+		receipt := &types.Receipt{Logs: []*types.Log{messagePassedLog(t, wd, hash)}}
+		parsed, parsedHash, err := parseWithdrawal(receipt)
+		require.NoError(t, err)
+		require.Equal(t, hash, parsedHash)
+		require.Equal(t, wd.WithdrawalTransaction(), parsed.WithdrawalTransaction())
+	})
+	t.Run("hash mismatch", func(t *testing.T) {
+		receipt := &types.Receipt{Logs: []*types.Log{messagePassedLog(t, wd, common.Hash{0x01})}}
+		_, _, err := parseWithdrawal(receipt)
+		require.ErrorContains(t, err, "does not match")
+	})
+	t.Run("no event", func(t *testing.T) {
+		_, _, err := parseWithdrawal(&types.Receipt{})
+		require.ErrorContains(t, err, "failed to parse MessagePassed event")
+	})
+}
+
+func TestNextStage(t *testing.T) {
+	stage := StageProposed
+	var seen []string
+	for stage != "" {
+		seen = append(seen, stage)
+		stage = nextStage(stage)
+	}
+	require.Equal(t, []string{StageProposed, StageProven, StageFinalized}, seen)
+}
+
+// testChain fakes the L1 contracts and the L1 and L2 chains that the monitor interacts with.
+type testChain struct {
+	t  *testing.T
+	mu sync.Mutex
+
+	l1Time    uint64
+	l2Head    uint64
+	l2Logs    []types.Log
+	nonce     int64
+	outputs   []bindings.TypesOutputProposal
+	proven    map[common.Hash]uint64
+	finalized map[common.Hash]bool
+	l1Calls   []string
+	sendErr   error
+}
+
+func newTestChain(t *testing.T) *testChain {
+	return &testChain{t: t, l1Time: 1000, proven: make(map[common.Hash]uint64), finalized: make(map[common.Hash]bool)}
+}
+
+func (c *testChain) LatestBlockNumber(opts *bind.CallOpts) (*big.Int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.outputs) == 0 {
+		return new(big.Int), nil
+	}
+	return c.outputs[len(c.outputs)-1].L2BlockNumber, nil
+}
+
+func (c *testChain) LatestOutputIndex(opts *bind.CallOpts) (*big.Int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.outputs) == 0 {
+		return nil, errors.New("no outputs")
+	}
+	return big.NewInt(int64(len(c.outputs) - 1)), nil
+}
+
+func (c *testChain) GetL2Output(opts *bind.CallOpts, l2OutputIndex *big.Int) (bindings.TypesOutputProposal, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.outputs[l2OutputIndex.Uint64()], nil
+}
+
+func (c *testChain) GetL2OutputIndexAfter(opts *bind.CallOpts, l2BlockNumber *big.Int) (*big.Int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, output := range c.outputs {
+		if output.L2BlockNumber.Cmp(l2BlockNumber) >= 0 {
+			return big.NewInt(int64(i)), nil
+		}
+	}
+	return nil, errors.New("no output covers the block")
+}
+
+func (c *testChain) ProvenWithdrawals(opts *bind.CallOpts, withdrawalHash [32]byte) (struct {
+	OutputRoot    [32]byte
+	Timestamp     *big.Int
+	L2OutputIndex *big.Int
+}, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var proven struct {
+		OutputRoot    [32]byte
+		Timestamp     *big.Int
+		L2OutputIndex *big.Int
+	}
+	proven.Timestamp = new(big.Int).SetUint64(c.proven[withdrawalHash])
+	proven.L2OutputIndex = new(big.Int)
+	return proven, nil
+}
+
+func (c *testChain) FinalizedWithdrawals(opts *bind.CallOpts, withdrawalHash [32]byte) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.finalized[withdrawalHash], nil
+}
+
+type testHeaders struct {
+	c  *testChain
+	l1 bool
+}
+
+func (h testHeaders) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	h.c.mu.Lock()
+	defer h.c.mu.Unlock()
+	if h.l1 {
+		return &types.Header{Number: big.NewInt(1), Time: h.c.l1Time}, nil
+	}
+	if number == nil {
+		number = new(big.Int).SetUint64(h.c.l2Head)
+	}
+	// L2 blocks are produced every 2 seconds
+	return &types.Header{Number: number, Time: number.Uint64() * 2}, nil
+}
+
+// FilterLogs returns the MessagePassed events of the L2 chain, filtered by block range and sender
+func (h testHeaders) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	h.c.mu.Lock()
+	defer h.c.mu.Unlock()
+	require.Equal(h.c.t, []common.Address{predeploys.L2ToL1MessagePasserAddr}, q.Addresses)
+	require.Equal(h.c.t, [][]common.Hash{{withdrawals.MessagePassedTopic}}, q.Topics[:1])
+	var out []types.Log
+	for _, l := range h.c.l2Logs {
+		if l.BlockNumber < q.FromBlock.Uint64() || l.BlockNumber > q.ToBlock.Uint64() || !slices.Contains(q.Topics[2], l.Topics[2]) {
+			continue
+		}
+		out = append(out, l)
+	}
+	return out, nil
+}
+
+func (c *testChain) proofParams(ctx context.Context, txHash common.Hash, header *types.Header) (withdrawals.ProvenWithdrawalParameters, error) {
+	return withdrawals.ProvenWithdrawalParameters{L2OutputIndex: big.NewInt(0), WithdrawalProof: [][]byte{}}, nil
+}
+
+// propose adds an output proposal for the given L2 block
+func (c *testChain) propose(l2Block uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.outputs = append(c.outputs, bindings.TypesOutputProposal{
+		L2BlockNumber: new(big.Int).SetUint64(l2Block),
+		Timestamp:     new(big.Int).SetUint64(c.l1Time),
+	})
+}
+
+func (c *testChain) advanceL1Time(seconds uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.l1Time += seconds
+}
+
+func (c *testChain) calls() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]string(nil), c.l1Calls...)
+}
+
+// sendL2 mocks the L2 tx manager: every initiateWithdrawal tx is included in a new L2 block
+func (c *testChain) sendL2(from common.Address) func(ctx context.Context, candidate txmgr.TxCandidate) (*types.Receipt, error) {
+	return func(ctx context.Context, candidate txmgr.TxCandidate) (*types.Receipt, error) {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if c.sendErr != nil {
+			return nil, c.sendErr
+		}
+		receipt := c.withdraw(from, candidate.Value)
+		return receipt, nil
+	}
+}
+
+// withdraw includes a withdrawal of the given account in a new L2 block. The caller must hold the lock.
+func (c *testChain) withdraw(from common.Address, value *big.Int) *types.Receipt {
+	c.l2Head++
+	c.nonce++
+	wd := crossdomain.NewWithdrawal(big.NewInt(c.nonce), &from, &from, value, big.NewInt(21_000), []byte{})
+	hash, err := wd.Hash()
+	require.NoError(c.t, err)
+	l := messagePassedLog(c.t, wd, hash)
+	l.TxHash = common.BigToHash(big.NewInt(c.nonce))
+	l.BlockNumber = c.l2Head
+	c.l2Logs = append(c.l2Logs, *l)
+	return &types.Receipt{
+		Status:      types.ReceiptStatusSuccessful,
+		TxHash:      l.TxHash,
+		BlockNumber: new(big.Int).SetUint64(c.l2Head),
+		Logs:        []*types.Log{l},
+	}
+}
+
+// sendL1 mocks the L1 tx manager: prove and finalize txs update the portal state
+func (c *testChain) sendL1(portalABI *abi.ABI) func(ctx context.Context, candidate txmgr.TxCandidate) (*types.Receipt, error) {
+	return func(ctx context.Context, candidate txmgr.TxCandidate) (*types.Receipt, error) {
+		method, err := portalABI.MethodById(candidate.TxData[:4])
+		require.NoError(c.t, err)
+		args, err := method.Inputs.Unpack(candidate.TxData[4:])
+		require.NoError(c.t, err)
+		tx := *abi.ConvertType(args[0], new(bindings.TypesWithdrawalTransaction)).(*bindings.TypesWithdrawalTransaction)
+		hash, err := crossdomain.NewWithdrawal(tx.Nonce, &tx.Sender, &tx.Target, tx.Value, tx.GasLimit, tx.Data).Hash()
+		require.NoError(c.t, err)
+
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if c.sendErr != nil {
+			return nil, c.sendErr
+		}
+		c.l1Calls = append(c.l1Calls, method.Name)
+		switch method.Name {
+		case "proveWithdrawalTransaction":
+			c.proven[hash] = c.l1Time
+		case "finalizeWithdrawalTransaction":
+			c.finalized[hash] = true
+		}
+		return &types.Receipt{Status: types.ReceiptStatusSuccessful, BlockNumber: big.NewInt(1)}, nil
+	}
+}
+
+func (c *testChain) setSendErr(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sendErr = err
+}
+
+type testMetrics struct {
+	mu       sync.Mutex
+	stages   []string
+	failures []string
+	timeouts []string
+	pending  map[string]int
+}
+
+func (m *testMetrics) RecordStage(stage string, latency time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.stages = append(m.stages, stage)
+}
+
+func (m *testMetrics) RecordStageFailure(stage string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.failures = append(m.failures, stage)
+}
+
+func (m *testMetrics) RecordStageTimeout(stage string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.timeouts = append(m.timeouts, stage)
+}
+
+func (m *testMetrics) RecordPending(pending map[string]int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.pending = pending
+}
+
+func (m *testMetrics) RecordLatestOutput(l2BlockNumber uint64, age time.Duration) {}
+
+func (m *testMetrics) RecordVersion(version string) {}
+
+func (m *testMetrics) recordedStages() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]string(nil), m.stages...)
+}
+
+const testFinalizationPeriod = 12
+
+func newTestMonitor(t *testing.T, cfg Config) (*Monitor, *testChain, *testMetrics) {
+	chain := newTestChain(t)
+	monitor, m := newTestChainMonitor(t, cfg, chain)
+	return monitor, chain, m
+}
+
+// newTestChainMonitor creates a monitor on an existing test chain, like a monitor that is restarted.
+func newTestChainMonitor(t *testing.T, cfg Config, chain *testChain) (*Monitor, *testMetrics) {
+	m := &testMetrics{}
+	from := common.Address{0xaa}
+	portalABI, err := bindings.OptimismPortalMetaData.GetAbi()
+	require.NoError(t, err)
+
+	l1Tx := &mocks.TxManager{}
+	l1Tx.On("Send", mock.Anything, mock.Anything).Return(chain.sendL1(portalABI))
+	l2Tx := &mocks.TxManager{}
+	l2Tx.On("From").Return(from)
+	l2Tx.On("Send", mock.Anything, mock.Anything).Return(chain.sendL2(from))
+
+	cfg.WithdrawalValue = big.NewInt(1)
+	cfg.WithdrawalGasLimit = 21_000
+	monitor, err := newMonitor(testlog.Logger(t, log.LvlInfo), cfg, m, testHeaders{c: chain, l1: true}, testHeaders{c: chain},
+		chain.proofParams, chain, chain, l1Tx, l2Tx, testFinalizationPeriod)
+	require.NoError(t, err)
+	return monitor, m
+}
+
+func TestMonitorRun(t *testing.T) {
+	monitor, chain, m := newTestMonitor(t, Config{
+		WithdrawalInterval: time.Hour,
+		PollInterval:       10 * time.Millisecond,
+		ProposalTimeout:    time.Hour,
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		monitor.Run(ctx)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	// the first withdrawal is initiated right away, and waits for an output proposal
+	require.Eventually(t, func() bool {
+		return slices.Equal([]string{StageInitiated}, m.recordedStages())
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// the withdrawal is proven once an output covers it, and waits for the finalization period
+	chain.propose(1)
+	require.Eventually(t, func() bool {
+		return slices.Equal([]string{StageInitiated, StageProposed, StageProven}, m.recordedStages())
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, []string{"proveWithdrawalTransaction"}, chain.calls())
+
+	chain.advanceL1Time(testFinalizationPeriod + 1)
+	require.Eventually(t, func() bool {
+		return slices.Equal([]string{StageInitiated, StageProposed, StageProven, StageFinalized}, m.recordedStages())
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, []string{"proveWithdrawalTransaction", "finalizeWithdrawalTransaction"}, chain.calls())
+
+	cancel()
+	<-done
+	require.Empty(t, monitor.pending)
+	require.Empty(t, m.failures)
+	require.Empty(t, m.timeouts)
+}
+
+func TestMonitorTransitions(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("initiate failure", func(t *testing.T) {
+		monitor, chain, m := newTestMonitor(t, Config{ProposalTimeout: time.Hour})
+		chain.setSendErr(errors.New("boom"))
+		monitor.initiate(ctx)
+		require.Empty(t, monitor.pending)
+		require.Equal(t, []string{StageInitiated}, m.failures)
+	})
+
+	t.Run("proposal timeout", func(t *testing.T) {
+		monitor, chain, m := newTestMonitor(t, Config{ProposalTimeout: 0})
+		monitor.initiate(ctx)
+		monitor.poll(ctx)
+		monitor.poll(ctx)
+		// the timeout is reported once, and the withdrawal keeps waiting for the proposal
+		require.Equal(t, []string{StageProposed}, m.timeouts)
+		require.Equal(t, map[string]int{StageProposed: 1}, m.pending)
+
+		chain.propose(1)
+		monitor.poll(ctx)
+		require.Equal(t, []string{StageInitiated, StageProposed, StageProven}, m.recordedStages())
+		require.Equal(t, map[string]int{StageFinalized: 1}, m.pending)
+	})
+
+	t.Run("proposal covers later withdrawals only", func(t *testing.T) {
+		monitor, chain, m := newTestMonitor(t, Config{ProposalTimeout: time.Hour})
+		monitor.initiate(ctx)
+		monitor.initiate(ctx)
+		chain.propose(1)
+		monitor.poll(ctx)
+		require.Equal(t, map[string]int{StageProposed: 1, StageFinalized: 1}, m.pending)
+		require.Equal(t, []string{"proveWithdrawalTransaction"}, chain.calls())
+	})
+
+	t.Run("prove failure is retried", func(t *testing.T) {
+		monitor, chain, m := newTestMonitor(t, Config{ProposalTimeout: time.Hour})
+		monitor.initiate(ctx)
+		chain.propose(1)
+		chain.setSendErr(errors.New("boom"))
+		monitor.poll(ctx)
+		require.Equal(t, []string{StageProven}, m.failures)
+		require.Equal(t, map[string]int{StageProven: 1}, m.pending)
+
+		chain.setSendErr(nil)
+		monitor.poll(ctx)
+		require.Equal(t, []string{StageInitiated, StageProposed, StageProven}, m.recordedStages())
+		require.Equal(t, map[string]int{StageFinalized: 1}, m.pending)
+	})
+
+	t.Run("already proven", func(t *testing.T) {
+		monitor, chain, m := newTestMonitor(t, Config{ProposalTimeout: time.Hour})
+		monitor.initiate(ctx)
+		chain.propose(1)
+		// a prove tx of an earlier attempt was included after all
+		chain.mu.Lock()
+		chain.proven[monitor.pending[0].hash] = chain.l1Time
+		chain.mu.Unlock()
+		monitor.poll(ctx)
+		require.Equal(t, []string{StageInitiated, StageProposed, StageProven}, m.recordedStages())
+		require.Empty(t, chain.calls())
+	})
+
+	t.Run("finalization period", func(t *testing.T) {
+		monitor, chain, m := newTestMonitor(t, Config{ProposalTimeout: time.Hour})
+		monitor.initiate(ctx)
+		chain.propose(1)
+		monitor.poll(ctx)
+		chain.advanceL1Time(testFinalizationPeriod)
+		monitor.poll(ctx)
+		require.Equal(t, map[string]int{StageFinalized: 1}, m.pending, "the finalization period has not passed yet")
+
+		chain.advanceL1Time(1)
+		monitor.poll(ctx)
+		require.Equal(t, []string{StageInitiated, StageProposed, StageProven, StageFinalized}, m.recordedStages())
+		require.Empty(t, m.pending)
+		require.Empty(t, monitor.pending)
+		require.Equal(t, []string{"proveWithdrawalTransaction", "finalizeWithdrawalTransaction"}, chain.calls())
+	})
+}
+
+func TestMonitorRecover(t *testing.T) {
+	ctx := context.Background()
+	// the recovery window is the finalization period of 12 seconds, or 6 L2 blocks
+	cfg := Config{ProposalTimeout: 0}
+	monitor, chain, _ := newTestMonitor(t, cfg)
+
+	// a withdrawal before the recovery window is not recovered
+	chain.mu.Lock()
+	chain.withdraw(common.Address{0xaa}, big.NewInt(1))
+	chain.l2Head += 10
+	chain.mu.Unlock()
+
+	// a finalized withdrawal
+	monitor.initiate(ctx)
+	chain.propose(chain.l2Head)
+	monitor.poll(ctx)
+	chain.advanceL1Time(testFinalizationPeriod + 1)
+	monitor.poll(ctx)
+	require.Empty(t, monitor.pending)
+	// a proven withdrawal, waiting for the finalization period
+	monitor.initiate(ctx)
+	chain.propose(chain.l2Head)
+	monitor.poll(ctx)
+	proven := monitor.pending[0]
+	require.Equal(t, StageFinalized, proven.stage)
+	// a withdrawal that is covered by an output proposal, but not proven yet
+	chain.mu.Lock()
+	covered := chain.withdraw(common.Address{0xaa}, big.NewInt(1))
+	chain.mu.Unlock()
+	chain.propose(chain.l2Head)
+	// a withdrawal that is not covered by an output proposal yet
+	monitor.initiate(ctx)
+	initiated := monitor.pending[1]
+	// a withdrawal of another account is ignored
+	chain.mu.Lock()
+	chain.withdraw(common.Address{0xbb}, big.NewInt(1))
+	chain.mu.Unlock()
+
+	restarted, m := newTestChainMonitor(t, cfg, chain)
+	require.NoError(t, restarted.recover(ctx))
+	require.Len(t, restarted.pending, 3)
+	require.Equal(t, map[string]int{StageProposed: 1, StageProven: 1, StageFinalized: 1}, m.pending)
+
+	require.Equal(t, proven.hash, restarted.pending[0].hash)
+	require.Equal(t, proven.tx, restarted.pending[0].tx)
+	require.Equal(t, proven.txHash, restarted.pending[0].txHash)
+	require.Equal(t, proven.l2Block, restarted.pending[0].l2Block)
+	require.Equal(t, StageFinalized, restarted.pending[0].stage)
+	require.Equal(t, proven.provenTimestamp, restarted.pending[0].provenTimestamp)
+
+	require.Equal(t, covered.TxHash, restarted.pending[1].txHash)
+	require.Equal(t, StageProven, restarted.pending[1].stage)
+
+	require.Equal(t, initiated.hash, restarted.pending[2].hash)
+	require.Equal(t, StageProposed, restarted.pending[2].stage)
+
+	// the recovered withdrawals are finalized by the restarted monitor
+	chain.propose(chain.l2Head)
+	restarted.poll(ctx)
+	chain.advanceL1Time(testFinalizationPeriod + 1)
+	restarted.poll(ctx)
+	require.Empty(t, restarted.pending)
+	require.Equal(t, []string{StageProven, StageProposed, StageProven, StageFinalized, StageFinalized, StageFinalized}, m.recordedStages())
+}
@@ -0,0 +1,139 @@
+package op_withdrawal_monitor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/ethclient/gethclient"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/ethereum-optimism/optimism/op-service/httputil"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	txmetrics "github.com/ethereum-optimism/optimism/op-service/txmgr/metrics"
+)
+
+func Main(version string) func(ctx *cli.Context) error {
+	return func(cliCtx *cli.Context) error {
+		cfg, err := NewConfig(cliCtx)
+		if err != nil {
+			return err
+		}
+		if err := cfg.Check(); err != nil {
+			return fmt.Errorf("invalid CLI flags: %w", err)
+		}
+
+		l := oplog.NewLogger(oplog.AppOut(cliCtx), cfg.Log)
+		oplog.SetGlobalLogHandler(l.GetHandler())
+		l.Info("starting withdrawal monitor", "version", version)
+
+		srv, err := Start(cliCtx.Context, l, cfg, version)
+		if err != nil {
+			return fmt.Errorf("error starting application: %w", err)
+		}
+
+		doneCh := make(chan os.Signal, 1)
+		signal.Notify(doneCh, []os.Signal{
+			os.Interrupt,
+			os.Kill,
+			syscall.SIGTERM,
+			syscall.SIGQUIT,
+		}...)
+		<-doneCh
+		return srv.Stop(context.Background())
+	}
+}
+
+type WithdrawalMonitorService struct {
+	metrics *httputil.HTTPServer
+	l1, l2  *ethclient.Client
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func (s *WithdrawalMonitorService) Stop(ctx context.Context) error {
+	var result error
+	if s.cancel != nil {
+		s.cancel()
+		<-s.done
+	}
+	if s.metrics != nil {
+		result = errors.Join(result, s.metrics.Stop(ctx))
+	}
+	if s.l1 != nil {
+		s.l1.Close()
+	}
+	if s.l2 != nil {
+		s.l2.Close()
+	}
+	return result
+}
+
+func Start(ctx context.Context, l log.Logger, cfg Config, version string) (*WithdrawalMonitorService, error) {
+	s := &WithdrawalMonitorService{}
+
+	registry := opmetrics.NewRegistry()
+	metricsCfg := cfg.Metrics
+	if metricsCfg.Enabled {
+		l.Debug("starting metrics server", "addr", metricsCfg.ListenAddr, "port", metricsCfg.ListenPort)
+		metricsSrv, err := opmetrics.StartServer(registry, metricsCfg.ListenAddr, metricsCfg.ListenPort)
+		if err != nil {
+			return nil, errors.Join(fmt.Errorf("failed to start metrics server: %w", err), s.Stop(ctx))
+		}
+		s.metrics = metricsSrv
+		l.Info("started metrics server", "addr", metricsSrv.Addr())
+	}
+	metrics := NewMetrics(registry)
+	metrics.RecordVersion(version)
+	factory := opmetrics.With(registry)
+
+	l1TxMetrics := txmetrics.MakeTxMetrics(MetricsNamespace+"_l1", factory)
+	l1Tx, err := txmgr.NewSimpleTxManager("l1", l, &l1TxMetrics, cfg.TxMgrConfig)
+	if err != nil {
+		return nil, errors.Join(fmt.Errorf("failed to create L1 tx manager: %w", err), s.Stop(ctx))
+	}
+	// the withdrawals are initiated by the same account on L2
+	l2TxCfg := cfg.TxMgrConfig
+	l2TxCfg.L1RPCURL = cfg.L2EthRpc
+	l2TxCfg.NumConfirmations = 1
+	l2TxMetrics := txmetrics.MakeTxMetrics(MetricsNamespace+"_l2", factory)
+	l2Tx, err := txmgr.NewSimpleTxManager("l2", l, &l2TxMetrics, l2TxCfg)
+	if err != nil {
+		return nil, errors.Join(fmt.Errorf("failed to create L2 tx manager: %w", err), s.Stop(ctx))
+	}
+
+	s.l1, err = ethclient.DialContext(ctx, cfg.TxMgrConfig.L1RPCURL)
+	if err != nil {
+		return nil, errors.Join(fmt.Errorf("failed to dial L1: %w", err), s.Stop(ctx))
+	}
+	l2Rpc, err := rpc.DialContext(ctx, cfg.L2EthRpc)
+	if err != nil {
+		return nil, errors.Join(fmt.Errorf("failed to dial L2: %w", err), s.Stop(ctx))
+	}
+	s.l2 = ethclient.NewClient(l2Rpc)
+
+	monitor, err := NewMonitor(ctx, l, cfg, metrics, s.l1, s.l2, gethclient.New(l2Rpc), l1Tx, l2Tx)
+	if err != nil {
+		return nil, errors.Join(fmt.Errorf("failed to create monitor: %w", err), s.Stop(ctx))
+	}
+
+	runCtx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	s.done = make(chan struct{})
+	go func() {
+		defer close(s.done)
+		monitor.Run(runCtx)
+	}()
+
+	return s, nil
+}